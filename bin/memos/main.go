@@ -44,6 +44,12 @@ var (
 				DSN:         viper.GetString("dsn"),
 				InstanceURL: viper.GetString("instance-url"),
 				Version:     version.GetCurrentVersion(viper.GetString("mode")),
+				DBPool: profile.DBPool{
+					MaxOpenConns:    viper.GetInt("db-max-open-conns"),
+					MaxIdleConns:    viper.GetInt("db-max-idle-conns"),
+					ConnMaxLifetime: viper.GetDuration("db-conn-max-lifetime"),
+					ConnMaxIdleTime: viper.GetDuration("db-conn-max-idle-time"),
+				},
 			}
 			if err := instanceProfile.Validate(); err != nil {
 				panic(err)
@@ -110,6 +116,10 @@ func init() {
 	rootCmd.PersistentFlags().String("driver", "sqlite", "database driver")
 	rootCmd.PersistentFlags().String("dsn", "", "database source name(aka. DSN)")
 	rootCmd.PersistentFlags().String("instance-url", "", "the url of your memos instance")
+	rootCmd.PersistentFlags().Int("db-max-open-conns", 0, "max number of open database connections, unlimited if 0")
+	rootCmd.PersistentFlags().Int("db-max-idle-conns", 0, "max number of idle database connections, 2 if 0 and none if negative")
+	rootCmd.PersistentFlags().Duration("db-conn-max-lifetime", 0, "max time a database connection may be reused, e.g. 1h, forever if 0")
+	rootCmd.PersistentFlags().Duration("db-conn-max-idle-time", 0, "max time a database connection may be idle, e.g. 5m, forever if 0")
 
 	if err := viper.BindPFlag("mode", rootCmd.PersistentFlags().Lookup("mode")); err != nil {
 		panic(err)
@@ -132,12 +142,36 @@ func init() {
 	if err := viper.BindPFlag("instance-url", rootCmd.PersistentFlags().Lookup("instance-url")); err != nil {
 		panic(err)
 	}
+	if err := viper.BindPFlag("db-max-open-conns", rootCmd.PersistentFlags().Lookup("db-max-open-conns")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("db-max-idle-conns", rootCmd.PersistentFlags().Lookup("db-max-idle-conns")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("db-conn-max-lifetime", rootCmd.PersistentFlags().Lookup("db-conn-max-lifetime")); err != nil {
+		panic(err)
+	}
+	if err := viper.BindPFlag("db-conn-max-idle-time", rootCmd.PersistentFlags().Lookup("db-conn-max-idle-time")); err != nil {
+		panic(err)
+	}
 
 	viper.SetEnvPrefix("memos")
 	viper.AutomaticEnv()
 	if err := viper.BindEnv("instance-url", "MEMOS_INSTANCE_URL"); err != nil {
 		panic(err)
 	}
+	if err := viper.BindEnv("db-max-open-conns", "MEMOS_DB_MAX_OPEN_CONNS"); err != nil {
+		panic(err)
+	}
+	if err := viper.BindEnv("db-max-idle-conns", "MEMOS_DB_MAX_IDLE_CONNS"); err != nil {
+		panic(err)
+	}
+	if err := viper.BindEnv("db-conn-max-lifetime", "MEMOS_DB_CONN_MAX_LIFETIME"); err != nil {
+		panic(err)
+	}
+	if err := viper.BindEnv("db-conn-max-idle-time", "MEMOS_DB_CONN_MAX_IDLE_TIME"); err != nil {
+		panic(err)
+	}
 }
 
 func printGreetings(profile *profile.Profile) {
@@ -10,6 +10,11 @@ type ConvertContext struct {
 	// The offset of the next argument in the condition string.
 	// Mainly using for PostgreSQL.
 	ArgsOffset int
+	// CompressedContentContains and CompressedContentEquals map the strings that content is matched
+	// against to the ids of the memos with compressed content that contain or equal them, which
+	// cannot be matched in SQL. They are nil when there are no such memos to match.
+	CompressedContentContains map[string][]int32
+	CompressedContentEquals   map[string][]int32
 }
 
 func NewConvertContext() *ConvertContext {
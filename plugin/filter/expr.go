@@ -37,3 +37,37 @@ func GetIdentExprName(expr *exprv1.Expr) (string, error) {
 	}
 	return expr.GetIdentExpr().GetName(), nil
 }
+
+// GetContentConditions returns the strings that content is matched against in the expression,
+// those of content.contains calls and those content is compared with by == and !=.
+func GetContentConditions(expr *exprv1.Expr) (contains []string, equals []string) {
+	v, ok := expr.ExprKind.(*exprv1.Expr_CallExpr)
+	if !ok {
+		return nil, nil
+	}
+	switch v.CallExpr.Function {
+	case "contains":
+		if identifier, err := GetIdentExprName(v.CallExpr.Target); err == nil && identifier == "content" && len(v.CallExpr.Args) == 1 {
+			if value, err := GetConstValue(v.CallExpr.Args[0]); err == nil {
+				if s, ok := value.(string); ok {
+					contains = append(contains, s)
+				}
+			}
+		}
+	case "_==_", "_!=_":
+		if len(v.CallExpr.Args) == 2 {
+			if identifier, err := GetIdentExprName(v.CallExpr.Args[0]); err == nil && identifier == "content" {
+				if value, err := GetConstValue(v.CallExpr.Args[1]); err == nil {
+					if s, ok := value.(string); ok {
+						equals = append(equals, s)
+					}
+				}
+			}
+		}
+	}
+	for _, arg := range v.CallExpr.Args {
+		argContains, argEquals := GetContentConditions(arg)
+		contains, equals = append(contains, argContains...), append(equals, argEquals...)
+	}
+	return contains, equals
+}
@@ -0,0 +1,407 @@
+package httpgetter
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"net/url"
+	"regexp"
+	"strings"
+	"unicode"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+const (
+	// minArticleTextLength is the min length in runes of the text of the main content of a
+	// page to be an article. Shorter content is rather navigation or a teaser.
+	minArticleTextLength = 250
+	// minParagraphTextLength is the min length in runes of a paragraph to count for its ancestors.
+	minParagraphTextLength = 25
+	// wordsPerMinute and cjkCharactersPerMinute are the reading speeds of the reading time.
+	wordsPerMinute         = 200
+	cjkCharactersPerMinute = 400
+)
+
+var (
+	// unlikelyArticleRegexp matches the classes and ids of elements that are rarely the article, e.g. "sidebar".
+	unlikelyArticleRegexp = regexp.MustCompile(`(?i)banner|breadcrumb|comment|cookie|footer|header|menu|modal|nav|popup|promo|related|share|sidebar|social|sponsor|subscribe|\bads?\b|advert`)
+	// likelyArticleRegexp matches the classes and ids of elements that are likely the article, e.g. "post-content".
+	likelyArticleRegexp = regexp.MustCompile(`(?i)article|body|content|entry|main|post|story|text`)
+	// markdownTextEscaper escapes the characters of text that markdown would take for syntax.
+	markdownTextEscaper = strings.NewReplacer(`\`, `\\`, "*", `\*`, "_", `\_`, "`", "\\`", "[", `\[`, "]", `\]`)
+)
+
+// Article is the main content of a HTML page, e.g. of a blog post without the navigation,
+// sidebars and comments around it.
+type Article struct {
+	// Markdown keeps the headings, paragraphs, lists, quotes, code blocks, links and emphasis of the article.
+	Markdown string `json:"markdown"`
+	// Text is the plain text of the article, with the blocks separated by blank lines.
+	Text string `json:"text"`
+	// WordCount counts each CJK character as a word.
+	WordCount int `json:"wordCount"`
+	// ReadingMinutes is the estimated reading time, rounded up.
+	ReadingMinutes int `json:"readingMinutes"`
+}
+
+// extractArticle extracts the main content of the HTML page, readability-style: paragraphs
+// score their parent and grandparent by their length, the classes and ids of elements add to
+// or take from their score, and the element with the best score, lowered by the share of its
+// text in links, is the article. Links are resolved against baseURL. It returns nil when no
+// element holds enough text.
+func extractArticle(r io.Reader, baseURL *url.URL) *Article {
+	document, err := html.Parse(r)
+	if err != nil {
+		return nil
+	}
+	removeUnlikelyArticleNodes(document)
+
+	// candidates are the scored elements in the order they were first scored, so that ties go
+	// to the earlier one.
+	candidates := []*html.Node{}
+	scores := map[*html.Node]float64{}
+	addScore := func(node *html.Node, score float64) {
+		if node == nil || node.Type != html.ElementNode || node.DataAtom == atom.Body || node.DataAtom == atom.Html {
+			return
+		}
+		if _, ok := scores[node]; !ok {
+			candidates = append(candidates, node)
+			scores[node] = getArticleNodeWeight(node)
+		}
+		scores[node] += score
+	}
+	var walk func(node *html.Node)
+	walk = func(node *html.Node) {
+		if node.Type == html.ElementNode && (node.DataAtom == atom.P || node.DataAtom == atom.Pre || node.DataAtom == atom.Td) {
+			text := getNodeText(node)
+			if length := len([]rune(text)); length >= minParagraphTextLength {
+				score := 1 + float64(strings.Count(text, ",")+strings.Count(text, "，")) + math.Min(float64(length)/100, 3)
+				addScore(node.Parent, score)
+				if node.Parent != nil {
+					addScore(node.Parent.Parent, score/2)
+				}
+			}
+		}
+		for child := node.FirstChild; child != nil; child = child.NextSibling {
+			walk(child)
+		}
+	}
+	walk(document)
+
+	var top *html.Node
+	topScore := 0.0
+	for _, node := range candidates {
+		score := scores[node] * (1 - getLinkDensity(node))
+		if top == nil || score > topScore {
+			top, topScore = node, score
+		}
+	}
+	if top == nil || len([]rune(getNodeText(top))) < minArticleTextLength {
+		return nil
+	}
+
+	renderer := &articleRenderer{baseURL: baseURL}
+	renderer.renderBlocks(top)
+	markdown := strings.Join(renderer.blocks, "\n\n")
+	text := strings.Join(renderer.texts, "\n\n")
+	wordCount, readingMinutes := getReadingTime(text)
+	return &Article{
+		Markdown:       markdown,
+		Text:           text,
+		WordCount:      wordCount,
+		ReadingMinutes: readingMinutes,
+	}
+}
+
+// removeUnlikelyArticleNodes removes the elements that are never part of an article, e.g.
+// scripts and navigation, and those whose classes and ids tell they are rather not.
+func removeUnlikelyArticleNodes(node *html.Node) {
+	for child := node.FirstChild; child != nil; {
+		next := child.NextSibling
+		if child.Type == html.CommentNode || (child.Type == html.ElementNode && isUnlikelyArticleNode(child)) {
+			node.RemoveChild(child)
+		} else {
+			removeUnlikelyArticleNodes(child)
+		}
+		child = next
+	}
+}
+
+func isUnlikelyArticleNode(node *html.Node) bool {
+	switch node.DataAtom {
+	case atom.Script, atom.Style, atom.Noscript, atom.Template, atom.Nav, atom.Header, atom.Footer, atom.Aside,
+		atom.Form, atom.Button, atom.Iframe, atom.Svg, atom.Canvas, atom.Object, atom.Embed, atom.Select:
+		return true
+	case atom.Html, atom.Body, atom.Article, atom.Main:
+		return false
+	}
+	names := getAttribute(node, "class") + " " + getAttribute(node, "id")
+	return unlikelyArticleRegexp.MatchString(names) && !likelyArticleRegexp.MatchString(names)
+}
+
+// getArticleNodeWeight returns the score an element starts with by its tag, class and id.
+func getArticleNodeWeight(node *html.Node) float64 {
+	weight := 0.0
+	switch node.DataAtom {
+	case atom.Article, atom.Main:
+		weight += 10
+	case atom.Div:
+		weight += 5
+	case atom.Pre, atom.Td, atom.Blockquote:
+		weight += 3
+	case atom.Ol, atom.Ul, atom.Dl, atom.Dd, atom.Dt, atom.Li:
+		weight -= 3
+	case atom.H1, atom.H2, atom.H3, atom.H4, atom.H5, atom.H6, atom.Th:
+		weight -= 5
+	}
+	names := getAttribute(node, "class") + " " + getAttribute(node, "id")
+	if likelyArticleRegexp.MatchString(names) {
+		weight += 25
+	}
+	if unlikelyArticleRegexp.MatchString(names) {
+		weight -= 25
+	}
+	return weight
+}
+
+// getLinkDensity returns the share of the text of the element that is in links.
+func getLinkDensity(node *html.Node) float64 {
+	length := len([]rune(getNodeText(node)))
+	if length == 0 {
+		return 0
+	}
+	linkLength := 0
+	var walk func(node *html.Node)
+	walk = func(node *html.Node) {
+		if node.Type == html.ElementNode && node.DataAtom == atom.A {
+			linkLength += len([]rune(getNodeText(node)))
+			return
+		}
+		for child := node.FirstChild; child != nil; child = child.NextSibling {
+			walk(child)
+		}
+	}
+	walk(node)
+	return float64(linkLength) / float64(length)
+}
+
+// getNodeText returns the text of the node with whitespace collapsed.
+func getNodeText(node *html.Node) string {
+	var builder strings.Builder
+	var walk func(node *html.Node)
+	walk = func(node *html.Node) {
+		if node.Type == html.TextNode {
+			builder.WriteString(node.Data)
+			builder.WriteByte(' ')
+		}
+		for child := node.FirstChild; child != nil; child = child.NextSibling {
+			walk(child)
+		}
+	}
+	walk(node)
+	return strings.Join(strings.Fields(builder.String()), " ")
+}
+
+func getAttribute(node *html.Node, key string) string {
+	for _, attr := range node.Attr {
+		if attr.Key == key {
+			return attr.Val
+		}
+	}
+	return ""
+}
+
+// articleRenderer renders the blocks of an article to markdown and plain text alike.
+type articleRenderer struct {
+	baseURL *url.URL
+	blocks  []string
+	texts   []string
+}
+
+// renderBlocks renders the block elements in the node, and the inline content between them
+// as paragraphs.
+func (r *articleRenderer) renderBlocks(node *html.Node) {
+	inline := []*html.Node{}
+	flush := func() {
+		r.addBlock("", inline)
+		inline = []*html.Node{}
+	}
+	for child := node.FirstChild; child != nil; child = child.NextSibling {
+		if child.Type != html.ElementNode || !isArticleBlock(child) {
+			inline = append(inline, child)
+			continue
+		}
+		flush()
+		switch child.DataAtom {
+		case atom.H1, atom.H2, atom.H3, atom.H4, atom.H5, atom.H6:
+			level := int(child.Data[1] - '0')
+			r.addBlock(strings.Repeat("#", level)+" ", childNodes(child))
+		case atom.P:
+			r.addBlock("", childNodes(child))
+		case atom.Pre:
+			code := strings.Trim(getRawText(child), "\n")
+			if strings.TrimSpace(code) != "" {
+				r.blocks = append(r.blocks, "```\n"+code+"\n```")
+				r.texts = append(r.texts, code)
+			}
+		case atom.Ul, atom.Ol:
+			index := 1
+			for item := child.FirstChild; item != nil; item = item.NextSibling {
+				if item.Type != html.ElementNode || item.DataAtom != atom.Li {
+					continue
+				}
+				marker := "- "
+				if child.DataAtom == atom.Ol {
+					marker = fmt.Sprintf("%d. ", index)
+				}
+				index++
+				r.addBlock(marker, childNodes(item))
+			}
+		case atom.Blockquote:
+			quote := &articleRenderer{baseURL: r.baseURL}
+			quote.renderBlocks(child)
+			if len(quote.blocks) > 0 {
+				r.blocks = append(r.blocks, "> "+strings.ReplaceAll(strings.Join(quote.blocks, "\n\n"), "\n", "\n> "))
+				r.texts = append(r.texts, quote.texts...)
+			}
+		case atom.Hr:
+		default:
+			r.renderBlocks(child)
+		}
+	}
+	flush()
+}
+
+// addBlock adds a block of the given inline nodes with the given markdown prefix, unless it has no text.
+func (r *articleRenderer) addBlock(prefix string, nodes []*html.Node) {
+	var markdown, text strings.Builder
+	for _, node := range nodes {
+		r.renderInline(node, &markdown, &text)
+	}
+	plainText := strings.Join(strings.Fields(text.String()), " ")
+	if plainText == "" {
+		return
+	}
+	r.blocks = append(r.blocks, prefix+strings.Join(strings.Fields(markdown.String()), " "))
+	r.texts = append(r.texts, plainText)
+}
+
+func (r *articleRenderer) renderInline(node *html.Node, markdown, text *strings.Builder) {
+	switch node.Type {
+	case html.TextNode:
+		markdown.WriteString(markdownTextEscaper.Replace(node.Data))
+		text.WriteString(node.Data)
+		return
+	case html.ElementNode:
+	default:
+		return
+	}
+	wrap := func(delimiter string) {
+		var content strings.Builder
+		for child := node.FirstChild; child != nil; child = child.NextSibling {
+			r.renderInline(child, &content, text)
+		}
+		if trimmed := strings.TrimSpace(content.String()); trimmed != "" {
+			markdown.WriteString(delimiter + trimmed + delimiter)
+		}
+	}
+	switch node.DataAtom {
+	case atom.Br:
+		markdown.WriteString(" ")
+		text.WriteString(" ")
+	case atom.Img:
+	case atom.Strong, atom.B:
+		wrap("**")
+	case atom.Em, atom.I:
+		wrap("*")
+	case atom.Code:
+		code := getRawText(node)
+		markdown.WriteString("`" + code + "`")
+		text.WriteString(code)
+	case atom.A:
+		var label strings.Builder
+		for child := node.FirstChild; child != nil; child = child.NextSibling {
+			r.renderInline(child, &label, text)
+		}
+		href := r.resolveLink(getAttribute(node, "href"))
+		if href == "" || strings.TrimSpace(label.String()) == "" {
+			markdown.WriteString(label.String())
+			return
+		}
+		markdown.WriteString("[" + strings.TrimSpace(label.String()) + "](" + href + ")")
+	default:
+		for child := node.FirstChild; child != nil; child = child.NextSibling {
+			r.renderInline(child, markdown, text)
+		}
+	}
+}
+
+// resolveLink returns the absolute http or https URL of the link, or "" for other links, e.g. "javascript:" ones.
+func (r *articleRenderer) resolveLink(href string) string {
+	href = strings.TrimSpace(href)
+	if href == "" || strings.HasPrefix(href, "#") {
+		return ""
+	}
+	u, err := r.baseURL.Parse(href)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") {
+		return ""
+	}
+	return strings.NewReplacer("(", "%28", ")", "%29", " ", "%20").Replace(u.String())
+}
+
+func isArticleBlock(node *html.Node) bool {
+	switch node.DataAtom {
+	case atom.Address, atom.Article, atom.Blockquote, atom.Dd, atom.Div, atom.Dl, atom.Dt, atom.Figcaption, atom.Figure,
+		atom.H1, atom.H2, atom.H3, atom.H4, atom.H5, atom.H6, atom.Hr, atom.Li, atom.Main, atom.Ol, atom.P, atom.Pre,
+		atom.Section, atom.Table, atom.Tbody, atom.Td, atom.Th, atom.Thead, atom.Tr, atom.Ul:
+		return true
+	}
+	return false
+}
+
+func childNodes(node *html.Node) []*html.Node {
+	nodes := []*html.Node{}
+	for child := node.FirstChild; child != nil; child = child.NextSibling {
+		nodes = append(nodes, child)
+	}
+	return nodes
+}
+
+// getRawText returns the text of the node as is, e.g. of code.
+func getRawText(node *html.Node) string {
+	var builder strings.Builder
+	var walk func(node *html.Node)
+	walk = func(node *html.Node) {
+		if node.Type == html.TextNode {
+			builder.WriteString(node.Data)
+		}
+		for child := node.FirstChild; child != nil; child = child.NextSibling {
+			walk(child)
+		}
+	}
+	walk(node)
+	return builder.String()
+}
+
+// getReadingTime returns the number of words of the text, counting each CJK character as a
+// word, and the minutes it takes to read them, at least one.
+func getReadingTime(text string) (int, int) {
+	words, cjkCharacters := 0, 0
+	for _, field := range strings.Fields(text) {
+		hasWord := false
+		for _, r := range field {
+			if unicode.In(r, unicode.Han, unicode.Hiragana, unicode.Katakana, unicode.Hangul) {
+				cjkCharacters++
+			} else if unicode.IsLetter(r) || unicode.IsDigit(r) {
+				hasWord = true
+			}
+		}
+		if hasWord {
+			words++
+		}
+	}
+	minutes := float64(words)/wordsPerMinute + float64(cjkCharacters)/cjkCharactersPerMinute
+	return words + cjkCharacters, max(1, int(math.Ceil(minutes)))
+}
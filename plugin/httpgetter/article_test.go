@@ -0,0 +1,104 @@
+package httpgetter
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestExtractArticle(t *testing.T) {
+	fixture, err := os.ReadFile("testdata/article.html")
+	require.NoError(t, err)
+	baseURL, err := url.Parse("https://blog.example.com/posts/plain-text")
+	require.NoError(t, err)
+
+	article := extractArticle(strings.NewReader(string(fixture)), baseURL)
+	require.NotNil(t, article)
+	require.Equal(t, strings.Join([]string{
+		"# Why I keep my notes in plain text",
+		"For years I tried every note-taking app that came along, and every time I moved on, my notes stayed behind in a format nothing else could read.",
+		"Plain text is **boring**, and that is the point: it opens in any editor, diffs well in `git`, and will still open in twenty years, whatever happens to the [tools](https://blog.example.com/tools) I use today.",
+		"## What I use",
+		"- A folder of markdown files, one per topic.",
+		"- A *daily* file for everything that does not fit anywhere yet.",
+		"> Your notes should outlive your apps.",
+		"```\nnotes/\n  daily/2024-01-01.md\n```",
+		"If you want to try it, start small: write today's notes in a text file, and see whether you miss anything at the end of the week.",
+	}, "\n\n"), article.Markdown)
+	// The navigation, sidebar, comments and scripts around the article are left out.
+	for _, excluded := range []string{"Archive", "Popular posts", "Great post", "analytics", "©"} {
+		require.NotContains(t, article.Text, excluded)
+	}
+	require.True(t, strings.HasPrefix(article.Text, "Why I keep my notes in plain text\n\nFor years"))
+	require.Equal(t, 125, article.WordCount)
+	require.Equal(t, 1, article.ReadingMinutes)
+
+	// Pages with little text have no article.
+	sparse := `<html><head><meta property="og:title" content="Sign in"></head><body><nav><a href="/">Home</a></nav><div class="content"><p>Please sign in to continue.</p><a href="/login">Sign in</a></div></body></html>`
+	require.Nil(t, extractArticle(strings.NewReader(sparse), baseURL))
+}
+
+func TestGetReadingTime(t *testing.T) {
+	tests := []struct {
+		text           string
+		wordCount      int
+		readingMinutes int
+	}{
+		{text: "", wordCount: 0, readingMinutes: 1},
+		{text: "Hello, world - again!", wordCount: 3, readingMinutes: 1},
+		{text: strings.Repeat("word ", 401), wordCount: 401, readingMinutes: 3},
+		{text: "你好世界 hello", wordCount: 5, readingMinutes: 1},
+		{text: strings.Repeat("字", 800), wordCount: 800, readingMinutes: 2},
+	}
+	for _, test := range tests {
+		wordCount, readingMinutes := getReadingTime(test.text)
+		require.Equal(t, test.wordCount, wordCount, test.text)
+		require.Equal(t, test.readingMinutes, readingMinutes, test.text)
+	}
+}
+
+func TestFetchHTMLMetaArticle(t *testing.T) {
+	fixture, err := os.ReadFile("testdata/article.html")
+	require.NoError(t, err)
+	mux := http.NewServeMux()
+	mux.HandleFunc("/article", func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		_, _ = w.Write(fixture)
+	})
+	mux.HandleFunc("/sparse", func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		_, _ = w.Write([]byte(`<html><head><meta property="og:title" content="Gallery"><meta property="og:image" content="https://example.com/cover.jpg"></head><body><img src="/a.jpg"><img src="/b.jpg"></body></html>`))
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	options := HTMLMetaOptions{Timeout: time.Second, MaxBodySize: 1 << 20, Article: true}
+	htmlMeta, err := fetchHTMLMeta(internalHTTPClient, server.URL+"/article", options)
+	require.NoError(t, err)
+	require.Equal(t, "Why I keep my notes in plain text", htmlMeta.Title)
+	require.NotNil(t, htmlMeta.Article)
+	require.Contains(t, htmlMeta.Article.Markdown, "[tools]("+server.URL+"/tools)")
+	require.False(t, htmlMeta.ArticleExtractionFailed)
+
+	// Pages without main content keep their OpenGraph fields.
+	htmlMeta, err = fetchHTMLMeta(internalHTTPClient, server.URL+"/sparse", options)
+	require.NoError(t, err)
+	require.Equal(t, "Gallery", htmlMeta.Title)
+	require.Equal(t, "https://example.com/cover.jpg", htmlMeta.Image)
+	require.Nil(t, htmlMeta.Article)
+	require.True(t, htmlMeta.ArticleExtractionFailed)
+
+	// The article is not extracted unless requested, and the page is still limited in size.
+	htmlMeta, err = fetchHTMLMeta(internalHTTPClient, server.URL+"/article", HTMLMetaOptions{Timeout: time.Second, MaxBodySize: 1 << 20})
+	require.NoError(t, err)
+	require.Nil(t, htmlMeta.Article)
+	require.False(t, htmlMeta.ArticleExtractionFailed)
+	_, err = fetchHTMLMeta(internalHTTPClient, server.URL+"/article", HTMLMetaOptions{Timeout: time.Second, MaxBodySize: 1024, Article: true})
+	require.ErrorIs(t, err, ErrBodyTooLarge)
+}
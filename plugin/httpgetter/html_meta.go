@@ -1,63 +1,263 @@
 package httpgetter
 
 import (
+	"bytes"
+	"context"
 	"fmt"
 	"io"
 	"net"
 	"net/http"
 	"net/url"
+	"slices"
+	"strings"
+	"time"
 
 	"github.com/pkg/errors"
 	"golang.org/x/net/html"
 	"golang.org/x/net/html/atom"
 )
 
-var ErrInternalIP = errors.New("internal IP addresses are not allowed")
+var (
+	ErrInvalidURL       = errors.New("invalid URL")
+	ErrInternalIP       = errors.New("internal IP addresses are not allowed")
+	ErrTimeout          = errors.New("request timed out")
+	ErrBodyTooLarge     = errors.New("response body too large")
+	ErrTooManyRedirects = errors.New("too many redirects")
+	// ErrNotModified is returned by conditional fetches when the link is unchanged.
+	ErrNotModified = errors.New("not modified")
+)
 
-var httpClient = &http.Client{
-	CheckRedirect: func(req *http.Request, via []*http.Request) error {
-		if err := validateURL(req.URL.String()); err != nil {
-			return errors.Wrap(err, "redirect to internal IP")
-		}
-		if len(via) >= 10 {
-			return errors.New("too many redirects")
-		}
-		return nil
-	},
+const (
+	// DefaultHTMLMetaTimeout is the default timeout of fetching a HTML page.
+	DefaultHTMLMetaTimeout = 5 * time.Second
+	// DefaultHTMLMetaMaxBodySize is the default max size of a HTML page in bytes. 2MB.
+	DefaultHTMLMetaMaxBodySize = 2 << 20
+	// maxRedirects is the max number of redirects to follow.
+	maxRedirects = 5
+)
+
+var (
+	// httpClient refuses to connect to internal IPs.
+	httpClient = newHTTPClient(net.DefaultResolver, false)
+	// internalHTTPClient may connect to internal IPs, e.g. when the server is behind a proxy.
+	internalHTTPClient = newHTTPClient(net.DefaultResolver, true)
+)
+
+// NewHTTPClient returns a client with the same guard against internal IPs as the link
+// metadata fetches, e.g. for requests to user provided URLs. Requests should be sent with
+// a timeout.
+func NewHTTPClient(allowInternalIPs bool) *http.Client {
+	return newHTTPClient(net.DefaultResolver, allowInternalIPs)
+}
+
+// ValidateURL returns ErrInvalidURL unless the URL is an absolute http or https URL.
+func ValidateURL(urlStr string) error {
+	return validateURL(urlStr)
+}
+
+func newHTTPClient(r resolver, allowInternalIPs bool) *http.Client {
+	dialer := &net.Dialer{Timeout: DefaultHTMLMetaTimeout}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.DialContext = dialer.DialContext
+	if !allowInternalIPs {
+		transport.DialContext = newGuardedDialContext(r, dialer)
+		// A proxy would make the guard check the proxy's address instead of the target's.
+		transport.Proxy = nil
+	}
+	return &http.Client{
+		Transport: transport,
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			if err := validateURL(req.URL.String()); err != nil {
+				return errors.Wrap(err, "invalid redirect")
+			}
+			if len(via) >= maxRedirects {
+				return ErrTooManyRedirects
+			}
+			return nil
+		},
+	}
 }
 
+// HTMLMeta is the metadata of a link, which is a HTML page, an image or a PDF document.
 type HTMLMeta struct {
+	// MediaType is the media type of the link from its Content-Type, e.g. "text/html" or "application/pdf".
+	MediaType   string `json:"mediaType"`
 	Title       string `json:"title"`
 	Description string `json:"description"`
 	Image       string `json:"image"`
+	// FaviconURL is absolute. It falls back to /favicon.ico at the host of the page.
+	FaviconURL string `json:"faviconUrl"`
+	// CanonicalURL is absolute, or empty if the page has no canonical link.
+	CanonicalURL string `json:"canonicalUrl"`
+	// OEmbedURL is the absolute URL of the oEmbed endpoint the page links, if any.
+	OEmbedURL string `json:"oembedUrl"`
+	// OEmbed is only set when requested with HTMLMetaOptions.OEmbed and the discovery succeeds.
+	OEmbed *OEmbed `json:"oembed"`
+	// ImageWidth and ImageHeight are the dimensions of image links, if their format is known.
+	ImageWidth  int `json:"imageWidth"`
+	ImageHeight int `json:"imageHeight"`
+	// Article is only set when requested with HTMLMetaOptions.Article and the page has a main content.
+	Article *Article `json:"article"`
+	// ArticleExtractionFailed tells that an article was requested but none was extracted, e.g. from
+	// pages with little text, images and PDF documents.
+	ArticleExtractionFailed bool `json:"articleExtractionFailed"`
+	// ETag and LastModified are the validators the origin sent with the link, if any, which are
+	// sent back with HTMLMetaOptions.IfNoneMatch and IfModifiedSince to refetch it conditionally.
+	ETag         string `json:"etag"`
+	LastModified string `json:"lastModified"`
+}
+
+// HTMLMetaOptions limits the fetching of a HTML page. Zero values fall back to the defaults.
+type HTMLMetaOptions struct {
+	Timeout     time.Duration
+	MaxBodySize int64
+	// AllowInternalIPs disables the guard against loopback, link-local and private addresses.
+	AllowInternalIPs bool
+	// OEmbed fetches the oEmbed endpoint linked by the page, with the same limits as the page.
+	OEmbed bool
+	// Article extracts the main content of HTML pages into HTMLMeta.Article, see extractArticle.
+	Article bool
+	// UserAgent is the User-Agent header of the requests. Go's default is sent when empty.
+	UserAgent string
+	// AcceptLanguage is the Accept-Language header of the requests, which is not sent when empty.
+	AcceptLanguage string
+	// RespectRobotsTxt fetches the robots.txt of the host first, and fails with ErrDisallowedByRobots
+	// when it disallows the page for UserAgent. The robots.txt of each host is cached for an hour.
+	RespectRobotsTxt bool
+	// IfNoneMatch and IfModifiedSince make the request of the link conditional, which then fails
+	// with ErrNotModified when the origin responds 304 Not Modified. The requests for the robots.txt
+	// and the oEmbed data are not conditional.
+	IfNoneMatch     string
+	IfModifiedSince string
 }
 
 func GetHTMLMeta(urlStr string) (*HTMLMeta, error) {
+	return GetHTMLMetaWithOptions(urlStr, HTMLMetaOptions{})
+}
+
+func GetHTMLMetaWithOptions(urlStr string, options HTMLMetaOptions) (*HTMLMeta, error) {
+	if options.Timeout <= 0 {
+		options.Timeout = DefaultHTMLMetaTimeout
+	}
+	if options.MaxBodySize <= 0 {
+		options.MaxBodySize = DefaultHTMLMetaMaxBodySize
+	}
 	if err := validateURL(urlStr); err != nil {
 		return nil, err
 	}
+	client := httpClient
+	if options.AllowInternalIPs {
+		client = internalHTTPClient
+	}
+	return fetchHTMLMeta(client, urlStr, options)
+}
 
-	response, err := httpClient.Get(urlStr)
+func fetchHTMLMeta(client *http.Client, urlStr string, options HTMLMetaOptions) (*HTMLMeta, error) {
+	if options.RespectRobotsTxt {
+		if err := defaultRobotsTxtCache.checkRobotsTxt(client, urlStr, options); err != nil {
+			return nil, err
+		}
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), options.Timeout)
+	defer cancel()
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, urlStr, nil)
 	if err != nil {
 		return nil, err
 	}
+	setRequestHeaders(request, options)
+	if options.IfNoneMatch != "" {
+		request.Header.Set("If-None-Match", options.IfNoneMatch)
+	}
+	if options.IfModifiedSince != "" {
+		request.Header.Set("If-Modified-Since", options.IfModifiedSince)
+	}
+	response, err := client.Do(request)
+	if err != nil {
+		return nil, convertRequestError(err)
+	}
 	defer response.Body.Close()
+	if response.StatusCode == http.StatusNotModified {
+		return nil, ErrNotModified
+	}
 
 	mediatype, err := getMediatype(response)
 	if err != nil {
 		return nil, err
 	}
-	if mediatype != "text/html" {
-		return nil, errors.New("not a HTML page")
+	switch {
+	case mediatype == "text/html":
+	case strings.HasPrefix(mediatype, "image/"):
+		htmlMeta := extractImageMeta(urlStr, mediatype, response, options)
+		htmlMeta.ArticleExtractionFailed = options.Article
+		setValidators(htmlMeta, response)
+		return htmlMeta, nil
+	case mediatype == pdfMediatype:
+		htmlMeta, err := extractPDFMeta(urlStr, response, options)
+		if err != nil {
+			return nil, err
+		}
+		htmlMeta.ArticleExtractionFailed = options.Article
+		setValidators(htmlMeta, response)
+		return htmlMeta, nil
+	default:
+		return nil, errors.Errorf("unsupported media type %s", mediatype)
 	}
 
-	// TODO: limit the size of the response body
+	// Read one more byte than allowed to tell whether the body exceeds the limit.
+	body, err := io.ReadAll(io.LimitReader(response.Body, options.MaxBodySize+1))
+	if err != nil {
+		return nil, convertRequestError(err)
+	}
+	if int64(len(body)) > options.MaxBodySize {
+		return nil, errors.Wrapf(ErrBodyTooLarge, "limit is %d bytes", options.MaxBodySize)
+	}
 
-	htmlMeta := extractHTMLMeta(response.Body)
+	htmlMeta := extractHTMLMeta(bytes.NewReader(body))
+	htmlMeta.MediaType = mediatype
+	// Relative links are resolved against the URL after redirects.
+	resolveHTMLMetaURLs(response.Request.URL, htmlMeta)
 	enrichSiteMeta(response.Request.URL, htmlMeta)
+	if options.Article {
+		htmlMeta.Article = extractArticle(bytes.NewReader(body), response.Request.URL)
+		htmlMeta.ArticleExtractionFailed = htmlMeta.Article == nil
+	}
+	if options.OEmbed && htmlMeta.OEmbedURL != "" {
+		// The page is still useful without its oEmbed data, so failures are dropped.
+		if oEmbed, err := fetchOEmbed(client, htmlMeta.OEmbedURL, options); err == nil {
+			htmlMeta.OEmbed = oEmbed
+		}
+	}
+	setValidators(htmlMeta, response)
 	return htmlMeta, nil
 }
 
+// setValidators sets the validators of the response, with which the link can be refetched
+// conditionally.
+func setValidators(htmlMeta *HTMLMeta, response *http.Response) {
+	htmlMeta.ETag = response.Header.Get("ETag")
+	htmlMeta.LastModified = response.Header.Get("Last-Modified")
+}
+
+// setRequestHeaders sets the headers configured by the options, which apply to the page
+// and to the requests it leads to alike.
+func setRequestHeaders(request *http.Request, options HTMLMetaOptions) {
+	if options.UserAgent != "" {
+		request.Header.Set("User-Agent", options.UserAgent)
+	}
+	if options.AcceptLanguage != "" {
+		request.Header.Set("Accept-Language", options.AcceptLanguage)
+	}
+}
+
+// convertRequestError wraps timeouts into ErrTimeout.
+func convertRequestError(err error) error {
+	var netErr net.Error
+	if errors.Is(err, context.DeadlineExceeded) || (errors.As(err, &netErr) && netErr.Timeout()) {
+		return errors.Wrap(ErrTimeout, err.Error())
+	}
+	return err
+}
+
 func extractHTMLMeta(resp io.Reader) *HTMLMeta {
 	tokenizer := html.NewTokenizer(resp)
 	htmlMeta := new(HTMLMeta)
@@ -96,6 +296,21 @@ func extractHTMLMeta(resp io.Reader) *HTMLMeta {
 				if ok {
 					htmlMeta.Image = ogImage
 				}
+			} else if token.DataAtom == atom.Link {
+				rel, href := extractLinkRelAndHref(token)
+				if href == "" {
+					continue
+				}
+				// Prefer the first icon, which is usually the generic one.
+				if slices.Contains(rel, "icon") && htmlMeta.FaviconURL == "" {
+					htmlMeta.FaviconURL = href
+				}
+				if slices.Contains(rel, "canonical") {
+					htmlMeta.CanonicalURL = href
+				}
+				if slices.Contains(rel, "alternate") && extractAttribute(token, "type") == oEmbedMediatype && htmlMeta.OEmbedURL == "" {
+					htmlMeta.OEmbedURL = href
+				}
 			}
 		}
 	}
@@ -116,39 +331,66 @@ func extractMetaProperty(token html.Token, prop string) (content string, ok bool
 	return content, ok
 }
 
-func validateURL(urlStr string) error {
-	u, err := url.Parse(urlStr)
-	if err != nil {
-		return errors.New("invalid URL format")
-	}
-
-	if u.Scheme != "http" && u.Scheme != "https" {
-		return errors.New("only http/https protocols are allowed")
+// extractLinkRelAndHref returns the lowercased link types and the href of a link token.
+func extractLinkRelAndHref(token html.Token) (rel []string, href string) {
+	for _, attr := range token.Attr {
+		if attr.Key == "rel" {
+			rel = strings.Fields(strings.ToLower(attr.Val))
+		}
+		if attr.Key == "href" {
+			href = strings.TrimSpace(attr.Val)
+		}
 	}
+	return rel, href
+}
 
-	host := u.Hostname()
-	if host == "" {
-		return errors.New("empty hostname")
+// extractAttribute returns the lowercased value of the given attribute of a token.
+func extractAttribute(token html.Token, key string) string {
+	for _, attr := range token.Attr {
+		if attr.Key == key {
+			return strings.ToLower(strings.TrimSpace(attr.Val))
+		}
 	}
+	return ""
+}
 
-	// check if the hostname is an IP
-	if ip := net.ParseIP(host); ip != nil {
-		if ip.IsLoopback() || ip.IsPrivate() || ip.IsLinkLocalUnicast() {
-			return errors.Wrap(ErrInternalIP, ip.String())
+// resolveHTMLMetaURLs makes the favicon, canonical and oEmbed URLs absolute, and falls back to
+// /favicon.ico when the page links no favicon. Unparsable links are dropped.
+func resolveHTMLMetaURLs(baseURL *url.URL, htmlMeta *HTMLMeta) {
+	resolve := func(ref string) string {
+		u, err := baseURL.Parse(ref)
+		if err != nil {
+			return ""
 		}
-		return nil
+		return u.String()
+	}
+	if htmlMeta.FaviconURL != "" {
+		htmlMeta.FaviconURL = resolve(htmlMeta.FaviconURL)
+	}
+	if htmlMeta.FaviconURL == "" {
+		htmlMeta.FaviconURL = resolve("/favicon.ico")
+	}
+	if htmlMeta.CanonicalURL != "" {
+		htmlMeta.CanonicalURL = resolve(htmlMeta.CanonicalURL)
+	}
+	if htmlMeta.OEmbedURL != "" {
+		htmlMeta.OEmbedURL = resolve(htmlMeta.OEmbedURL)
 	}
+}
 
-	// check if it's a hostname, resolve it and check all returned IPs
-	ips, err := net.LookupIP(host)
+func validateURL(urlStr string) error {
+	u, err := url.Parse(urlStr)
 	if err != nil {
-		return errors.Errorf("failed to resolve hostname: %v", err)
+		return errors.Wrap(ErrInvalidURL, "invalid URL format")
 	}
 
-	for _, ip := range ips {
-		if ip.IsLoopback() || ip.IsPrivate() || ip.IsLinkLocalUnicast() {
-			return errors.Wrapf(ErrInternalIP, "host=%s, ip=%s", host, ip.String())
-		}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return errors.Wrap(ErrInvalidURL, "only http/https protocols are allowed")
+	}
+
+	host := u.Hostname()
+	if host == "" {
+		return errors.Wrap(ErrInvalidURL, "empty hostname")
 	}
 
 	return nil
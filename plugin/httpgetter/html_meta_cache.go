@@ -0,0 +1,211 @@
+package httpgetter
+
+import (
+	"container/list"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"golang.org/x/sync/singleflight"
+)
+
+const (
+	// DefaultHTMLMetaCacheTTL is the default duration that fetched HTML meta is cached.
+	DefaultHTMLMetaCacheTTL = time.Hour
+	// DefaultHTMLMetaCacheMaxEntries is the default max number of cached entries.
+	DefaultHTMLMetaCacheMaxEntries = 1024
+	// htmlMetaNegativeCacheTTL is the max duration that a failed fetch is cached.
+	htmlMetaNegativeCacheTTL = 5 * time.Minute
+)
+
+// HTMLMetaCache is an in-process LRU cache of HTML meta keyed by normalized URL.
+// Failed fetches are cached as well, for a shorter duration, and concurrent
+// fetches of the same URL are coalesced into a single request. Expired HTML meta
+// with validators is refetched conditionally, and kept for another ttl when the
+// origin responds that the link is unchanged.
+type HTMLMetaCache struct {
+	maxEntries int
+	fetch      func(string, HTMLMetaOptions) (*HTMLMeta, error)
+	now        func() time.Time
+
+	mu      sync.Mutex
+	lru     *list.List
+	entries map[string]*list.Element
+	group   singleflight.Group
+}
+
+type htmlMetaCacheEntry struct {
+	key       string
+	htmlMeta  *HTMLMeta
+	err       error
+	expiresAt time.Time
+}
+
+func NewHTMLMetaCache(maxEntries int) *HTMLMetaCache {
+	if maxEntries <= 0 {
+		maxEntries = DefaultHTMLMetaCacheMaxEntries
+	}
+	return &HTMLMetaCache{
+		maxEntries: maxEntries,
+		fetch:      GetHTMLMetaWithOptions,
+		now:        time.Now,
+		lru:        list.New(),
+		entries:    map[string]*list.Element{},
+	}
+}
+
+// Get returns the HTML meta of the given URL, fetching it with the given options
+// when it is not cached or has expired. Successful results are cached for ttl.
+func (c *HTMLMetaCache) Get(urlStr string, ttl time.Duration, options HTMLMetaOptions) (*HTMLMeta, error) {
+	if ttl <= 0 {
+		ttl = DefaultHTMLMetaCacheTTL
+	}
+	key := normalizeURL(urlStr)
+	if options.OEmbed {
+		// Results with and without oEmbed data are cached apart.
+		key = "oembed:" + key
+	}
+	if options.Article {
+		// Results with and without the article are cached apart.
+		key = "article:" + key
+	}
+	if options.RespectRobotsTxt {
+		// Results with and without the robots.txt check are cached apart.
+		key = "robots:" + key
+	}
+	if options.AcceptLanguage != "" {
+		// Sites may localize the page by language.
+		key = "lang:" + options.AcceptLanguage + ":" + key
+	}
+	if options.UserAgent != "" {
+		// Sites may serve different pages to different user agents.
+		key = "ua:" + options.UserAgent + ":" + key
+	}
+	if options.AllowInternalIPs {
+		// Results fetched from internal hosts must not outlive the setting.
+		key = "internal:" + key
+	}
+	if entry, ok := c.load(key); ok {
+		return copyHTMLMeta(entry.htmlMeta), entry.err
+	}
+
+	value, err, _ := c.group.Do(key, func() (any, error) {
+		// Another caller may have stored the result while we were waiting.
+		if entry, ok := c.load(key); ok {
+			return entry.htmlMeta, entry.err
+		}
+		expired := c.loadExpired(key)
+		if expired != nil && expired.htmlMeta != nil {
+			options.IfNoneMatch = expired.htmlMeta.ETag
+			options.IfModifiedSince = expired.htmlMeta.LastModified
+		}
+		htmlMeta, err := c.fetch(urlStr, options)
+		if errors.Is(err, ErrNotModified) && expired != nil && expired.htmlMeta != nil {
+			htmlMeta, err = expired.htmlMeta, nil
+		}
+		entryTTL := ttl
+		if err != nil {
+			htmlMeta = nil
+			entryTTL = min(ttl, htmlMetaNegativeCacheTTL)
+		}
+		c.store(&htmlMetaCacheEntry{
+			key:       key,
+			htmlMeta:  htmlMeta,
+			err:       err,
+			expiresAt: c.now().Add(entryTTL),
+		})
+		return htmlMeta, err
+	})
+	htmlMeta, _ := value.(*HTMLMeta)
+	return copyHTMLMeta(htmlMeta), err
+}
+
+func (c *HTMLMetaCache) load(key string) (*htmlMetaCacheEntry, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	element, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+	entry, _ := element.Value.(*htmlMetaCacheEntry)
+	if !c.now().Before(entry.expiresAt) {
+		// Expired entries are kept until they are replaced or evicted, see loadExpired.
+		return nil, false
+	}
+	c.lru.MoveToFront(element)
+	return entry, true
+}
+
+// loadExpired returns the expired entry of the key, if any, whose validators make its refetch
+// conditional.
+func (c *HTMLMetaCache) loadExpired(key string) *htmlMetaCacheEntry {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	element, ok := c.entries[key]
+	if !ok {
+		return nil
+	}
+	entry, _ := element.Value.(*htmlMetaCacheEntry)
+	if c.now().Before(entry.expiresAt) {
+		return nil
+	}
+	return entry
+}
+
+func (c *HTMLMetaCache) store(entry *htmlMetaCacheEntry) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if element, ok := c.entries[entry.key]; ok {
+		element.Value = entry
+		c.lru.MoveToFront(element)
+		return
+	}
+	c.entries[entry.key] = c.lru.PushFront(entry)
+	for c.lru.Len() > c.maxEntries {
+		oldest := c.lru.Back()
+		c.lru.Remove(oldest)
+		delete(c.entries, oldest.Value.(*htmlMetaCacheEntry).key)
+	}
+}
+
+// normalizeURL returns the cache key of the given URL. The scheme and host are
+// lowercased, default ports and fragments are dropped and an empty path becomes "/".
+func normalizeURL(urlStr string) string {
+	u, err := url.Parse(strings.TrimSpace(urlStr))
+	if err != nil {
+		return urlStr
+	}
+	u.Scheme = strings.ToLower(u.Scheme)
+	u.Host = strings.ToLower(u.Host)
+	if (u.Scheme == "http" && u.Port() == "80") || (u.Scheme == "https" && u.Port() == "443") {
+		u.Host = u.Hostname()
+	}
+	if u.Path == "" {
+		u.Path = "/"
+	}
+	u.Fragment = ""
+	u.RawFragment = ""
+	return u.String()
+}
+
+// copyHTMLMeta returns a copy so that callers cannot modify the cached value.
+func copyHTMLMeta(htmlMeta *HTMLMeta) *HTMLMeta {
+	if htmlMeta == nil {
+		return nil
+	}
+	htmlMetaCopy := *htmlMeta
+	if htmlMeta.OEmbed != nil {
+		oEmbedCopy := *htmlMeta.OEmbed
+		htmlMetaCopy.OEmbed = &oEmbedCopy
+	}
+	if htmlMeta.Article != nil {
+		articleCopy := *htmlMeta.Article
+		htmlMetaCopy.Article = &articleCopy
+	}
+	return &htmlMetaCopy
+}
@@ -0,0 +1,213 @@
+package httpgetter
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func newTestHTMLMetaCache(maxEntries int, fetch func(string, HTMLMetaOptions) (*HTMLMeta, error)) (*HTMLMetaCache, *time.Time) {
+	now := time.Unix(0, 0)
+	cache := NewHTMLMetaCache(maxEntries)
+	cache.fetch = fetch
+	cache.now = func() time.Time { return now }
+	return cache, &now
+}
+
+func TestHTMLMetaCacheTTL(t *testing.T) {
+	fetches := 0
+	cache, now := newTestHTMLMetaCache(10, func(urlStr string, _ HTMLMetaOptions) (*HTMLMeta, error) {
+		fetches++
+		return &HTMLMeta{Title: urlStr}, nil
+	})
+
+	for _, urlStr := range []string{"https://example.com", "HTTPS://Example.com:443/", "https://example.com/#top"} {
+		htmlMeta, err := cache.Get(urlStr, time.Hour, HTMLMetaOptions{})
+		require.NoError(t, err)
+		require.Equal(t, "https://example.com", htmlMeta.Title)
+	}
+	require.Equal(t, 1, fetches)
+
+	*now = now.Add(time.Hour)
+	_, err := cache.Get("https://example.com", time.Hour, HTMLMetaOptions{})
+	require.NoError(t, err)
+	require.Equal(t, 2, fetches)
+}
+
+func TestHTMLMetaCacheNegativeTTL(t *testing.T) {
+	fetches := 0
+	cache, now := newTestHTMLMetaCache(10, func(string, HTMLMetaOptions) (*HTMLMeta, error) {
+		fetches++
+		return nil, errors.New("not found")
+	})
+
+	_, err := cache.Get("https://example.com/dead", time.Hour, HTMLMetaOptions{})
+	require.Error(t, err)
+	_, err = cache.Get("https://example.com/dead", time.Hour, HTMLMetaOptions{})
+	require.Error(t, err)
+	require.Equal(t, 1, fetches)
+
+	*now = now.Add(htmlMetaNegativeCacheTTL)
+	_, err = cache.Get("https://example.com/dead", time.Hour, HTMLMetaOptions{})
+	require.Error(t, err)
+	require.Equal(t, 2, fetches)
+}
+
+func TestHTMLMetaCacheEviction(t *testing.T) {
+	fetches := map[string]int{}
+	cache, _ := newTestHTMLMetaCache(2, func(urlStr string, _ HTMLMetaOptions) (*HTMLMeta, error) {
+		fetches[urlStr]++
+		return &HTMLMeta{}, nil
+	})
+
+	for _, urlStr := range []string{"https://a.com/", "https://b.com/", "https://a.com/", "https://c.com/", "https://a.com/", "https://b.com/"} {
+		_, err := cache.Get(urlStr, time.Hour, HTMLMetaOptions{})
+		require.NoError(t, err)
+	}
+	// b.com is the least recently used entry when c.com is added.
+	require.Equal(t, map[string]int{"https://a.com/": 1, "https://b.com/": 2, "https://c.com/": 1}, fetches)
+}
+
+func TestHTMLMetaCacheCoalesce(t *testing.T) {
+	var fetches atomic.Int32
+	release := make(chan struct{})
+	cache, _ := newTestHTMLMetaCache(10, func(string, HTMLMetaOptions) (*HTMLMeta, error) {
+		fetches.Add(1)
+		<-release
+		return &HTMLMeta{Title: "example"}, nil
+	})
+
+	var wg sync.WaitGroup
+	// Results are asserted on the test goroutine, as require must not be called on others.
+	titles, errs := make(chan string, 10), make(chan error, 10)
+	for range 10 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			htmlMeta, err := cache.Get("https://example.com", time.Hour, HTMLMetaOptions{})
+			if err != nil {
+				errs <- err
+				return
+			}
+			titles <- htmlMeta.Title
+		}()
+	}
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+	wg.Wait()
+	close(titles)
+	close(errs)
+	for err := range errs {
+		require.NoError(t, err)
+	}
+	for title := range titles {
+		require.Equal(t, "example", title)
+	}
+	require.Equal(t, int32(1), fetches.Load())
+}
+
+func TestHTMLMetaCacheConditionalRefetch(t *testing.T) {
+	tests := []struct {
+		name string
+		// setValidator sets the validator of the page on the response.
+		setValidator func(http.Header)
+		// matches reports whether the request holds the validator of the page.
+		matches func(*http.Request) bool
+	}{
+		{
+			name:         "etag",
+			setValidator: func(header http.Header) { header.Set("ETag", `"v1"`) },
+			matches:      func(r *http.Request) bool { return r.Header.Get("If-None-Match") == `"v1"` },
+		},
+		{
+			name:         "last modified",
+			setValidator: func(header http.Header) { header.Set("Last-Modified", "Mon, 02 Jan 2006 15:04:05 GMT") },
+			matches: func(r *http.Request) bool {
+				return r.Header.Get("If-Modified-Since") == "Mon, 02 Jan 2006 15:04:05 GMT"
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var pages, notModified atomic.Int32
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				test.setValidator(w.Header())
+				if test.matches(r) {
+					notModified.Add(1)
+					w.WriteHeader(http.StatusNotModified)
+					return
+				}
+				pages.Add(1)
+				w.Header().Set("Content-Type", "text/html")
+				_, _ = w.Write([]byte("<html><head><title>Cached</title></head></html>"))
+			}))
+			defer server.Close()
+			cache, now := newTestHTMLMetaCache(10, GetHTMLMetaWithOptions)
+			options := HTMLMetaOptions{AllowInternalIPs: true}
+
+			htmlMeta, err := cache.Get(server.URL, time.Hour, options)
+			require.NoError(t, err)
+			require.Equal(t, "Cached", htmlMeta.Title)
+
+			// The expired metadata is reused when the page is unchanged, and kept for another hour.
+			*now = now.Add(time.Hour)
+			htmlMeta, err = cache.Get(server.URL, time.Hour, options)
+			require.NoError(t, err)
+			require.Equal(t, "Cached", htmlMeta.Title)
+			*now = now.Add(time.Hour - time.Second)
+			_, err = cache.Get(server.URL, time.Hour, options)
+			require.NoError(t, err)
+			require.Equal(t, int32(1), pages.Load())
+			require.Equal(t, int32(1), notModified.Load())
+		})
+	}
+
+	// Pages without validators are refetched in full.
+	var pages atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Empty(t, r.Header.Get("If-None-Match"))
+		require.Empty(t, r.Header.Get("If-Modified-Since"))
+		pages.Add(1)
+		w.Header().Set("Content-Type", "text/html")
+		_, _ = w.Write([]byte("<html><head><title>Fresh</title></head></html>"))
+	}))
+	defer server.Close()
+	cache, now := newTestHTMLMetaCache(10, GetHTMLMetaWithOptions)
+	for range 2 {
+		htmlMeta, err := cache.Get(server.URL, time.Hour, HTMLMetaOptions{AllowInternalIPs: true})
+		require.NoError(t, err)
+		require.Equal(t, "Fresh", htmlMeta.Title)
+		*now = now.Add(time.Hour)
+	}
+	require.Equal(t, int32(2), pages.Load())
+}
+
+func TestHTMLMetaCacheKeysFetchOptions(t *testing.T) {
+	fetches := 0
+	cache, _ := newTestHTMLMetaCache(10, func(_ string, options HTMLMetaOptions) (*HTMLMeta, error) {
+		fetches++
+		if !options.AllowInternalIPs {
+			return nil, errors.New("internal IP")
+		}
+		return &HTMLMeta{Title: "intranet"}, nil
+	})
+
+	htmlMeta, err := cache.Get("https://intranet.example.com", time.Hour, HTMLMetaOptions{AllowInternalIPs: true})
+	require.NoError(t, err)
+	require.Equal(t, "intranet", htmlMeta.Title)
+
+	// Turning the guard back on must not serve the result fetched without it.
+	_, err = cache.Get("https://intranet.example.com", time.Hour, HTMLMetaOptions{})
+	require.Error(t, err)
+	require.Equal(t, 2, fetches)
+
+	_, err = cache.Get("https://intranet.example.com", time.Hour, HTMLMetaOptions{AllowInternalIPs: true, UserAgent: "bot"})
+	require.NoError(t, err)
+	require.Equal(t, 3, fetches)
+}
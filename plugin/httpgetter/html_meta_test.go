@@ -2,7 +2,12 @@ package httpgetter
 
 import (
 	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 )
@@ -30,3 +35,169 @@ func TestGetHTMLMetaForInternal(t *testing.T) {
 		t.Errorf("Expected error for resolved internal IP, got %v", err)
 	}
 }
+
+func TestGetHTMLMetaForInvalidScheme(t *testing.T) {
+	for _, urlStr := range []string{"ftp://example.com", "file:///etc/passwd", "javascript:alert(1)"} {
+		if _, err := GetHTMLMeta(urlStr); !errors.Is(err, ErrInvalidURL) {
+			t.Errorf("Expected invalid URL error for %s, got %v", urlStr, err)
+		}
+	}
+}
+
+func TestFetchHTMLMetaLimits(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		switch r.URL.Path {
+		case "/slow":
+			time.Sleep(200 * time.Millisecond)
+		case "/large":
+			_, _ = w.Write([]byte(strings.Repeat("a", 2048)))
+		}
+		_, _ = w.Write([]byte("<html><head><title>memos</title></head></html>"))
+	}))
+	defer server.Close()
+
+	options := HTMLMetaOptions{Timeout: 100 * time.Millisecond, MaxBodySize: 1024}
+	htmlMeta, err := fetchHTMLMeta(internalHTTPClient, server.URL, options)
+	require.NoError(t, err)
+	require.Equal(t, "memos", htmlMeta.Title)
+
+	_, err = fetchHTMLMeta(internalHTTPClient, server.URL+"/slow", options)
+	require.ErrorIs(t, err, ErrTimeout)
+
+	_, err = fetchHTMLMeta(internalHTTPClient, server.URL+"/large", options)
+	require.ErrorIs(t, err, ErrBodyTooLarge)
+}
+
+func TestExtractHTMLMetaLinks(t *testing.T) {
+	tests := []struct {
+		html         string
+		faviconURL   string
+		canonicalURL string
+	}{
+		{
+			html:         `<html><head><link rel="icon" href="/static/icon.png"><link rel="canonical" href="https://example.com/post"></head></html>`,
+			faviconURL:   "https://www.example.com/static/icon.png",
+			canonicalURL: "https://example.com/post",
+		},
+		{
+			html:         `<html><head><link rel="Shortcut Icon" href="img/fav.ico"><link rel="icon" href="/other.png"><link rel="canonical" href="../post?id=1"></head></html>`,
+			faviconURL:   "https://www.example.com/blog/2024/img/fav.ico",
+			canonicalURL: "https://www.example.com/blog/post?id=1",
+		},
+		{
+			html:       `<html><head><title>no links</title><link rel="stylesheet" href="/style.css"></head><body><link rel="icon" href="/body.png"></body></html>`,
+			faviconURL: "https://www.example.com/favicon.ico",
+		},
+	}
+	baseURL, err := url.Parse("https://www.example.com/blog/2024/")
+	require.NoError(t, err)
+	for _, test := range tests {
+		htmlMeta := extractHTMLMeta(strings.NewReader(test.html))
+		resolveHTMLMetaURLs(baseURL, htmlMeta)
+		require.Equal(t, test.faviconURL, htmlMeta.FaviconURL, test.html)
+		require.Equal(t, test.canonicalURL, htmlMeta.CanonicalURL, test.html)
+	}
+}
+
+func TestFetchHTMLMetaResolvesAgainstRedirect(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.Handle("/old", http.RedirectHandler("/new/page", http.StatusFound))
+	mux.HandleFunc("/new/page", func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		_, _ = w.Write([]byte(`<html><head><link rel="icon" href="icon.png"></head></html>`))
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	htmlMeta, err := fetchHTMLMeta(internalHTTPClient, server.URL+"/old", HTMLMetaOptions{Timeout: time.Second, MaxBodySize: 1024})
+	require.NoError(t, err)
+	require.Equal(t, server.URL+"/new/icon.png", htmlMeta.FaviconURL)
+	require.Equal(t, "", htmlMeta.CanonicalURL)
+}
+
+func TestFetchHTMLMetaOEmbed(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/video", func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		_, _ = w.Write([]byte(`<html><head><title>video</title><link rel="alternate" type="application/json+oembed" href="/oembed?url=video"></head></html>`))
+	})
+	mux.HandleFunc("/oembed", func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"version":"1.0","type":"video","html":"<iframe src=\"/embed\"></iframe>","width":640,"height":"360","thumbnail_url":"https://example.com/thumb.jpg","thumbnail_width":480,"thumbnail_height":null,"provider_name":"Example","provider_url":"https://example.com/"}`))
+	})
+	mux.HandleFunc("/article", func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		_, _ = w.Write([]byte(`<html><head><title>article</title><link rel="alternate" type="application/rss+xml" href="/feed"></head></html>`))
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	options := HTMLMetaOptions{Timeout: time.Second, MaxBodySize: 1024, OEmbed: true}
+	htmlMeta, err := fetchHTMLMeta(internalHTTPClient, server.URL+"/video", options)
+	require.NoError(t, err)
+	require.Equal(t, server.URL+"/oembed?url=video", htmlMeta.OEmbedURL)
+	require.Equal(t, &OEmbed{
+		Type:           "video",
+		HTML:           `<iframe src="/embed"></iframe>`,
+		Width:          640,
+		Height:         360,
+		ThumbnailURL:   "https://example.com/thumb.jpg",
+		ThumbnailWidth: 480,
+		ProviderName:   "Example",
+		ProviderURL:    "https://example.com/",
+	}, htmlMeta.OEmbed)
+
+	htmlMeta, err = fetchHTMLMeta(internalHTTPClient, server.URL+"/article", options)
+	require.NoError(t, err)
+	require.Equal(t, "article", htmlMeta.Title)
+	require.Nil(t, htmlMeta.OEmbed)
+
+	// The oEmbed endpoint is not fetched unless requested.
+	options.OEmbed = false
+	htmlMeta, err = fetchHTMLMeta(internalHTTPClient, server.URL+"/video", options)
+	require.NoError(t, err)
+	require.Nil(t, htmlMeta.OEmbed)
+}
+
+func TestFetchOEmbedLimits(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"type":"rich","html":"` + strings.Repeat("a", 2048) + `"}`))
+	}))
+	defer server.Close()
+
+	_, err := fetchOEmbed(internalHTTPClient, server.URL, HTMLMetaOptions{Timeout: time.Second, MaxBodySize: 1024})
+	require.ErrorIs(t, err, ErrBodyTooLarge)
+	_, err = fetchOEmbed(httpClient, server.URL, HTMLMetaOptions{Timeout: time.Second, MaxBodySize: 1024})
+	require.ErrorIs(t, err, ErrInternalIP)
+}
+
+func TestFetchHTMLMetaHeaders(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/page", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		_, _ = w.Write([]byte(`<html><head><title>` + r.UserAgent() + `</title><meta property="description" content="` + r.Header.Get("Accept-Language") + `"><link rel="alternate" type="application/json+oembed" href="/oembed"></head></html>`))
+	})
+	mux.HandleFunc("/oembed", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"type":"rich","title":"` + r.UserAgent() + `","author_name":"` + r.Header.Get("Accept-Language") + `"}`))
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	options := HTMLMetaOptions{Timeout: time.Second, MaxBodySize: 1024, OEmbed: true, UserAgent: "memos/1.0 (+https://www.usememos.com)", AcceptLanguage: "de-DE,de;q=0.9"}
+	htmlMeta, err := fetchHTMLMeta(internalHTTPClient, server.URL+"/page", options)
+	require.NoError(t, err)
+	require.Equal(t, options.UserAgent, htmlMeta.Title)
+	require.Equal(t, options.AcceptLanguage, htmlMeta.Description)
+	// The oEmbed request is sent with the same headers.
+	require.Equal(t, options.UserAgent, htmlMeta.OEmbed.Title)
+	require.Equal(t, options.AcceptLanguage, htmlMeta.OEmbed.AuthorName)
+
+	// Without the options, Go's User-Agent is sent and no Accept-Language.
+	htmlMeta, err = fetchHTMLMeta(internalHTTPClient, server.URL+"/page", HTMLMetaOptions{Timeout: time.Second, MaxBodySize: 1024})
+	require.NoError(t, err)
+	require.True(t, strings.HasPrefix(htmlMeta.Title, "Go-http-client/"))
+	require.Equal(t, "", htmlMeta.Description)
+}
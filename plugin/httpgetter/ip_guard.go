@@ -0,0 +1,46 @@
+package httpgetter
+
+import (
+	"context"
+	"net"
+
+	"github.com/pkg/errors"
+)
+
+// resolver resolves a hostname to its IP addresses.
+type resolver interface {
+	LookupIPAddr(ctx context.Context, host string) ([]net.IPAddr, error)
+}
+
+// isInternalIP reports whether the IP is a loopback, link-local, private (RFC 1918),
+// unique local (RFC 4193) or unspecified address.
+func isInternalIP(ip net.IP) bool {
+	return ip.IsLoopback() || ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() || ip.IsPrivate() || ip.IsUnspecified()
+}
+
+// newGuardedDialContext returns a dial function that resolves the host itself and
+// refuses to connect when any of its IPs is internal. As the check runs for every
+// connection, it applies to each redirect hop and cannot be bypassed by a DNS
+// record that changes between validation and connection.
+func newGuardedDialContext(r resolver, dialer *net.Dialer) func(ctx context.Context, network, address string) (net.Conn, error) {
+	return func(ctx context.Context, network, address string) (net.Conn, error) {
+		host, port, err := net.SplitHostPort(address)
+		if err != nil {
+			return nil, err
+		}
+		ipAddrs, err := r.LookupIPAddr(ctx, host)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to resolve hostname %s", host)
+		}
+		if len(ipAddrs) == 0 {
+			return nil, errors.Errorf("no IP addresses found for hostname %s", host)
+		}
+		for _, ipAddr := range ipAddrs {
+			if isInternalIP(ipAddr.IP) {
+				return nil, errors.Wrapf(ErrInternalIP, "host=%s, ip=%s", host, ipAddr.IP.String())
+			}
+		}
+		// Dial the checked IP rather than the hostname so it is not resolved again.
+		return dialer.DialContext(ctx, network, net.JoinHostPort(ipAddrs[0].IP.String(), port))
+	}
+}
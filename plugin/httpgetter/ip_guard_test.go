@@ -0,0 +1,74 @@
+package httpgetter
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeResolver map[string][]string
+
+func (r fakeResolver) LookupIPAddr(_ context.Context, host string) ([]net.IPAddr, error) {
+	ips, ok := r[host]
+	if !ok {
+		return nil, errors.Errorf("unknown host %s", host)
+	}
+	ipAddrs := []net.IPAddr{}
+	for _, ip := range ips {
+		ipAddrs = append(ipAddrs, net.IPAddr{IP: net.ParseIP(ip)})
+	}
+	return ipAddrs, nil
+}
+
+func TestGuardedDialContext(t *testing.T) {
+	r := fakeResolver{
+		"localhost":         {"127.0.0.1"},
+		"metadata.internal": {"169.254.169.254"},
+		"rfc1918.example":   {"10.0.0.1"},
+		"ula.example":       {"fd00::1"},
+		"unspecified":       {"0.0.0.0"},
+		"mixed.example":     {"93.184.216.34", "192.168.1.1"},
+	}
+	client := newHTTPClient(r, false)
+	for host := range r {
+		_, err := fetchHTMLMeta(client, "http://"+host+":5432/", HTMLMetaOptions{Timeout: DefaultHTMLMetaTimeout, MaxBodySize: DefaultHTMLMetaMaxBodySize})
+		require.ErrorIs(t, err, ErrInternalIP, host)
+	}
+}
+
+func TestIsInternalIP(t *testing.T) {
+	tests := []struct {
+		ip       string
+		internal bool
+	}{
+		{"127.0.0.1", true},
+		{"::1", true},
+		{"169.254.169.254", true},
+		{"fe80::1", true},
+		{"10.1.2.3", true},
+		{"172.16.0.1", true},
+		{"192.168.0.1", true},
+		{"fc00::1", true},
+		{"0.0.0.0", true},
+		{"93.184.216.34", false},
+		{"2606:2800:220:1:248:1893:25c8:1946", false},
+	}
+	for _, test := range tests {
+		require.Equal(t, test.internal, isInternalIP(net.ParseIP(test.ip)), test.ip)
+	}
+}
+
+func TestGuardedClientSkipsProxy(t *testing.T) {
+	// Through a proxy the guard would only check the proxy's address.
+	transport, ok := newHTTPClient(fakeResolver{}, false).Transport.(*http.Transport)
+	require.True(t, ok)
+	require.Nil(t, transport.Proxy)
+
+	transport, ok = newHTTPClient(fakeResolver{}, true).Transport.(*http.Transport)
+	require.True(t, ok)
+	require.NotNil(t, transport.Proxy)
+}
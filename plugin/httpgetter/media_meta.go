@@ -0,0 +1,213 @@
+package httpgetter
+
+import (
+	"bytes"
+	"encoding/binary"
+	"html"
+	"image"
+	// Register the image formats whose dimensions are read.
+	_ "image/gif"
+	_ "image/jpeg"
+	_ "image/png"
+	"io"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode/utf16"
+
+	_ "golang.org/x/image/webp"
+)
+
+// pdfMediatype is the media type of PDF documents.
+const pdfMediatype = "application/pdf"
+
+var (
+	// pdfInfoRefRegexp matches the reference to the document information dictionary in the
+	// trailer or the cross-reference stream, e.g. "/Info 12 0 R".
+	pdfInfoRefRegexp = regexp.MustCompile(`/Info\s+(\d+)\s+(\d+)\s+R`)
+	// xmpTitleRegexp matches the first title in the XMP metadata of a document.
+	xmpTitleRegexp = regexp.MustCompile(`(?s)<dc:title>.*?<rdf:li[^>]*>(.*?)</rdf:li>`)
+)
+
+// extractImageMeta returns the metadata of an image, whose title is its URL. Only the header
+// of the image is read for its dimensions, which are left unset for unknown formats.
+func extractImageMeta(urlStr, mediatype string, response *http.Response, options HTMLMetaOptions) *HTMLMeta {
+	htmlMeta := &HTMLMeta{
+		MediaType: mediatype,
+		Title:     urlStr,
+		Image:     urlStr,
+	}
+	if config, _, err := image.DecodeConfig(io.LimitReader(response.Body, options.MaxBodySize)); err == nil {
+		htmlMeta.ImageWidth = config.Width
+		htmlMeta.ImageHeight = config.Height
+	}
+	resolveHTMLMetaURLs(response.Request.URL, htmlMeta)
+	return htmlMeta
+}
+
+// extractPDFMeta returns the metadata of a PDF document, whose title is taken from its
+// document information dictionary or XMP metadata, and falls back to its URL. Only the first
+// MaxBodySize bytes are read, so the metadata of larger documents may not be found.
+func extractPDFMeta(urlStr string, response *http.Response, options HTMLMetaOptions) (*HTMLMeta, error) {
+	body, err := io.ReadAll(io.LimitReader(response.Body, options.MaxBodySize))
+	if err != nil {
+		return nil, convertRequestError(err)
+	}
+	htmlMeta := &HTMLMeta{
+		MediaType: pdfMediatype,
+		Title:     extractPDFTitle(body),
+	}
+	if htmlMeta.Title == "" {
+		htmlMeta.Title = urlStr
+	}
+	resolveHTMLMetaURLs(response.Request.URL, htmlMeta)
+	return htmlMeta, nil
+}
+
+// extractPDFTitle returns the title of the document information dictionary, or of the XMP
+// metadata if the former has none. Dictionaries in compressed object streams are not read.
+func extractPDFTitle(body []byte) string {
+	for _, matches := range pdfInfoRefRegexp.FindAllSubmatch(body, -1) {
+		objectRegexp := regexp.MustCompile(`(?:^|[^0-9])` + string(matches[1]) + `\s+` + string(matches[2]) + `\s+obj\b`)
+		loc := objectRegexp.FindIndex(body)
+		if loc == nil {
+			continue
+		}
+		object := body[loc[1]:]
+		if objectEnd := bytes.Index(object, []byte("endobj")); objectEnd >= 0 {
+			object = object[:objectEnd]
+		}
+		titleStart := bytes.Index(object, []byte("/Title"))
+		if titleStart < 0 {
+			continue
+		}
+		if title := strings.TrimSpace(parsePDFString(object[titleStart+len("/Title"):])); title != "" {
+			return title
+		}
+	}
+	if matches := xmpTitleRegexp.FindSubmatch(body); matches != nil {
+		return strings.TrimSpace(html.UnescapeString(string(matches[1])))
+	}
+	return ""
+}
+
+// parsePDFString parses the literal, e.g. "(memos)", or hexadecimal, e.g. "<6D656D6F73>",
+// string at the start of the data, skipping leading whitespace.
+func parsePDFString(data []byte) string {
+	data = bytes.TrimLeft(data, " \t\r\n\f\x00")
+	if len(data) == 0 {
+		return ""
+	}
+	var raw []byte
+	switch data[0] {
+	case '(':
+		raw = parsePDFLiteralString(data[1:])
+	case '<':
+		end := bytes.IndexByte(data, '>')
+		if end < 0 {
+			return ""
+		}
+		raw = parsePDFHexString(data[1:end])
+	default:
+		return ""
+	}
+	return decodePDFText(raw)
+}
+
+// parsePDFLiteralString parses the content of a literal string after its opening
+// parenthesis, which ends at the matching closing parenthesis.
+func parsePDFLiteralString(data []byte) []byte {
+	result := []byte{}
+	depth := 0
+	for i := 0; i < len(data); i++ {
+		c := data[i]
+		switch c {
+		case '(':
+			depth++
+		case ')':
+			if depth == 0 {
+				return result
+			}
+			depth--
+		case '\\':
+			i++
+			if i >= len(data) {
+				return result
+			}
+			switch e := data[i]; e {
+			case 'n':
+				c = '\n'
+			case 'r':
+				c = '\r'
+			case 't':
+				c = '\t'
+			case 'b':
+				c = '\b'
+			case 'f':
+				c = '\f'
+			case '\r', '\n':
+				// A line continuation.
+				if e == '\r' && i+1 < len(data) && data[i+1] == '\n' {
+					i++
+				}
+				continue
+			default:
+				if e < '0' || e > '7' {
+					c = e
+					break
+				}
+				// An octal character code of up to three digits.
+				code := 0
+				for j := 0; j < 3 && i < len(data) && data[i] >= '0' && data[i] <= '7'; j++ {
+					code = code*8 + int(data[i]-'0')
+					i++
+				}
+				i--
+				c = byte(code)
+			}
+		}
+		result = append(result, c)
+	}
+	return result
+}
+
+func parsePDFHexString(data []byte) []byte {
+	digits := []byte{}
+	for _, c := range data {
+		if (c >= '0' && c <= '9') || (c >= 'a' && c <= 'f') || (c >= 'A' && c <= 'F') {
+			digits = append(digits, c)
+		}
+	}
+	if len(digits)%2 == 1 {
+		digits = append(digits, '0')
+	}
+	result := make([]byte, 0, len(digits)/2)
+	for i := 0; i < len(digits); i += 2 {
+		b, _ := strconv.ParseUint(string(digits[i:i+2]), 16, 8)
+		result = append(result, byte(b))
+	}
+	return result
+}
+
+// decodePDFText decodes a text string, which is UTF-16BE or UTF-8 with a byte order mark,
+// or else PDFDocEncoding, which is read as Latin-1.
+func decodePDFText(raw []byte) string {
+	switch {
+	case bytes.HasPrefix(raw, []byte{0xfe, 0xff}):
+		raw = raw[2:]
+		units := make([]uint16, 0, len(raw)/2)
+		for i := 0; i+1 < len(raw); i += 2 {
+			units = append(units, binary.BigEndian.Uint16(raw[i:]))
+		}
+		return string(utf16.Decode(units))
+	case bytes.HasPrefix(raw, []byte{0xef, 0xbb, 0xbf}):
+		return string(raw[3:])
+	default:
+		runes := make([]rune, 0, len(raw))
+		for _, b := range raw {
+			runes = append(runes, rune(b))
+		}
+		return string(runes)
+	}
+}
@@ -0,0 +1,115 @@
+package httpgetter
+
+import (
+	"bytes"
+	"image"
+	"image/png"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFetchHTMLMetaImage(t *testing.T) {
+	var pngImage bytes.Buffer
+	require.NoError(t, png.Encode(&pngImage, image.NewRGBA(image.Rect(0, 0, 640, 480))))
+	mux := http.NewServeMux()
+	mux.HandleFunc("/photo.png", func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "image/png")
+		_, _ = w.Write(pngImage.Bytes())
+		// The image data after the header is not read, so the size cap is not hit.
+		_, _ = w.Write(make([]byte, 4096))
+	})
+	mux.HandleFunc("/logo.svg", func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "image/svg+xml")
+		_, _ = w.Write([]byte(`<svg xmlns="http://www.w3.org/2000/svg" width="16" height="16"></svg>`))
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	options := HTMLMetaOptions{Timeout: time.Second, MaxBodySize: 1024}
+	htmlMeta, err := fetchHTMLMeta(internalHTTPClient, server.URL+"/photo.png", options)
+	require.NoError(t, err)
+	require.Equal(t, &HTMLMeta{
+		MediaType:   "image/png",
+		Title:       server.URL + "/photo.png",
+		Image:       server.URL + "/photo.png",
+		FaviconURL:  server.URL + "/favicon.ico",
+		ImageWidth:  640,
+		ImageHeight: 480,
+	}, htmlMeta)
+
+	// The dimensions of unknown formats are left unset.
+	htmlMeta, err = fetchHTMLMeta(internalHTTPClient, server.URL+"/logo.svg", options)
+	require.NoError(t, err)
+	require.Equal(t, "image/svg+xml", htmlMeta.MediaType)
+	require.Equal(t, server.URL+"/logo.svg", htmlMeta.Title)
+	require.Zero(t, htmlMeta.ImageWidth)
+}
+
+func TestFetchHTMLMetaPDF(t *testing.T) {
+	titledPDF, err := os.ReadFile("testdata/titled.pdf")
+	require.NoError(t, err)
+	documents := map[string][]byte{
+		"/titled.pdf": titledPDF,
+		// A UTF-16BE title in a hexadecimal string.
+		"/hex.pdf": []byte("%PDF-1.7\n12 0 obj\n<< /Title <FEFF 004D 0065 006D 006F 0073 0020 65E5 8BB0> >>\nendobj\ntrailer\n<< /Info 12 0 R >>\n%%EOF\n"),
+		"/xmp.pdf": []byte("%PDF-1.7\n<x:xmpmeta><rdf:RDF><rdf:Description><dc:title><rdf:Alt><rdf:li xml:lang=\"x-default\">Memos &amp; Notes</rdf:li></rdf:Alt></dc:title></rdf:Description></rdf:RDF></x:xmpmeta>\n%%EOF\n"),
+		// The title is beyond the size cap, which does not fail the fetch.
+		"/large.pdf": []byte("%PDF-1.7\n" + strings.Repeat("%", 2048) + "\n1 0 obj\n<< /Title (Large) >>\nendobj\ntrailer\n<< /Info 1 0 R >>\n%%EOF\n"),
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/pdf")
+		_, _ = w.Write(documents[r.URL.Path])
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		path  string
+		title string
+	}{
+		{path: "/titled.pdf", title: "Memos (Release Notes) © 2025"},
+		{path: "/hex.pdf", title: "Memos 日记"},
+		{path: "/xmp.pdf", title: "Memos & Notes"},
+		{path: "/large.pdf", title: server.URL + "/large.pdf"},
+	}
+	options := HTMLMetaOptions{Timeout: time.Second, MaxBodySize: 1024}
+	for _, test := range tests {
+		htmlMeta, err := fetchHTMLMeta(internalHTTPClient, server.URL+test.path, options)
+		require.NoError(t, err)
+		require.Equal(t, "application/pdf", htmlMeta.MediaType)
+		require.Equal(t, test.title, htmlMeta.Title, test.path)
+	}
+}
+
+func TestFetchHTMLMetaUnsupportedMediaType(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/zip")
+	}))
+	defer server.Close()
+
+	_, err := fetchHTMLMeta(internalHTTPClient, server.URL, HTMLMetaOptions{Timeout: time.Second, MaxBodySize: 1024})
+	require.ErrorContains(t, err, "unsupported media type application/zip")
+}
+
+func TestParsePDFString(t *testing.T) {
+	tests := []struct {
+		data string
+		want string
+	}{
+		{data: " (memos)", want: "memos"},
+		{data: "(a (nested) string) /Author (x)", want: "a (nested) string"},
+		{data: `(line\nbreak \) \\ \101\102C)`, want: "line\nbreak ) \\ ABC"},
+		{data: "(con\\\ntinued)", want: "continued"},
+		{data: "<6D656D6F73>", want: "memos"},
+		{data: "<6D656D6F7>", want: "memop"},
+		{data: "/Name", want: ""},
+	}
+	for _, test := range tests {
+		require.Equal(t, test.want, parsePDFString([]byte(test.data)), test.data)
+	}
+}
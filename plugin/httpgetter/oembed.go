@@ -0,0 +1,110 @@
+package httpgetter
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// oEmbedMediatype is the type of the link through which a page exposes its oEmbed endpoint.
+const oEmbedMediatype = "application/json+oembed"
+
+// OEmbed is the embeddable representation of a page as described by https://oembed.com.
+type OEmbed struct {
+	Type            string `json:"type"`
+	Title           string `json:"title"`
+	HTML            string `json:"html"`
+	Width           int    `json:"width"`
+	Height          int    `json:"height"`
+	ThumbnailURL    string `json:"thumbnailUrl"`
+	ThumbnailWidth  int    `json:"thumbnailWidth"`
+	ThumbnailHeight int    `json:"thumbnailHeight"`
+	ProviderName    string `json:"providerName"`
+	ProviderURL     string `json:"providerUrl"`
+	AuthorName      string `json:"authorName"`
+}
+
+// oEmbedResponse is the JSON response of an oEmbed endpoint.
+type oEmbedResponse struct {
+	Type            string          `json:"type"`
+	Title           string          `json:"title"`
+	HTML            string          `json:"html"`
+	Width           oEmbedDimension `json:"width"`
+	Height          oEmbedDimension `json:"height"`
+	ThumbnailURL    string          `json:"thumbnail_url"`
+	ThumbnailWidth  oEmbedDimension `json:"thumbnail_width"`
+	ThumbnailHeight oEmbedDimension `json:"thumbnail_height"`
+	ProviderName    string          `json:"provider_name"`
+	ProviderURL     string          `json:"provider_url"`
+	AuthorName      string          `json:"author_name"`
+}
+
+// oEmbedDimension is a size in pixels. Some providers encode sizes as strings, and
+// rich embeds may leave them null, so anything that is not a number becomes 0.
+type oEmbedDimension int
+
+func (d *oEmbedDimension) UnmarshalJSON(data []byte) error {
+	value, err := strconv.ParseFloat(strings.Trim(string(data), `"`), 64)
+	if err != nil {
+		*d = 0
+		return nil
+	}
+	*d = oEmbedDimension(value)
+	return nil
+}
+
+// fetchOEmbed fetches the oEmbed endpoint with the same client and limits as the page.
+func fetchOEmbed(client *http.Client, urlStr string, options HTMLMetaOptions) (*OEmbed, error) {
+	if err := validateURL(urlStr); err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), options.Timeout)
+	defer cancel()
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, urlStr, nil)
+	if err != nil {
+		return nil, err
+	}
+	setRequestHeaders(request, options)
+	response, err := client.Do(request)
+	if err != nil {
+		return nil, convertRequestError(err)
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		return nil, errors.Errorf("unexpected status %d", response.StatusCode)
+	}
+	body, err := io.ReadAll(io.LimitReader(response.Body, options.MaxBodySize+1))
+	if err != nil {
+		return nil, convertRequestError(err)
+	}
+	if int64(len(body)) > options.MaxBodySize {
+		return nil, errors.Wrapf(ErrBodyTooLarge, "limit is %d bytes", options.MaxBodySize)
+	}
+
+	oEmbed := &oEmbedResponse{}
+	if err := json.Unmarshal(body, oEmbed); err != nil {
+		return nil, errors.Wrap(err, "invalid oEmbed response")
+	}
+	if oEmbed.Type == "" {
+		return nil, errors.New("oEmbed response has no type")
+	}
+	return &OEmbed{
+		Type:            oEmbed.Type,
+		Title:           oEmbed.Title,
+		HTML:            oEmbed.HTML,
+		Width:           int(oEmbed.Width),
+		Height:          int(oEmbed.Height),
+		ThumbnailURL:    oEmbed.ThumbnailURL,
+		ThumbnailWidth:  int(oEmbed.ThumbnailWidth),
+		ThumbnailHeight: int(oEmbed.ThumbnailHeight),
+		ProviderName:    oEmbed.ProviderName,
+		ProviderURL:     oEmbed.ProviderURL,
+		AuthorName:      oEmbed.AuthorName,
+	}, nil
+}
@@ -0,0 +1,252 @@
+package httpgetter
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// ErrDisallowedByRobots is returned when the robots.txt of the host disallows fetching the page.
+var ErrDisallowedByRobots = errors.New("disallowed by robots.txt")
+
+const (
+	// robotsTxtCacheTTL is how long the robots.txt of a host is cached.
+	robotsTxtCacheTTL = time.Hour
+	// robotsTxtCacheMaxEntries is the max number of hosts whose robots.txt is cached.
+	robotsTxtCacheMaxEntries = 1024
+	// robotsTxtMaxBodySize is the max size of a robots.txt that is parsed, the rest is ignored. 500KB.
+	robotsTxtMaxBodySize = 500 << 10
+)
+
+// robotsTxtRule allows or disallows the paths matching its pattern.
+type robotsTxtRule struct {
+	allow bool
+	// length is the length of the pattern, which decides between matching rules.
+	length int
+	regexp *regexp.Regexp
+}
+
+// robotsTxtGroup is the rules for the user agents of a group.
+type robotsTxtGroup struct {
+	userAgents []string
+	rules      []robotsTxtRule
+}
+
+// robotsTxt is a parsed robots.txt as specified by RFC 9309.
+type robotsTxt struct {
+	groups []*robotsTxtGroup
+	// disallowAll is set when the robots.txt is unreachable due to a server error.
+	disallowAll bool
+}
+
+// parseRobotsTxt parses the groups of a robots.txt. Lines other than user-agent, allow and
+// disallow, e.g. sitemap or crawl-delay, are ignored.
+func parseRobotsTxt(r io.Reader) *robotsTxt {
+	robots := &robotsTxt{}
+	var group *robotsTxtGroup
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line, _, _ := strings.Cut(scanner.Text(), "#")
+		key, value, ok := strings.Cut(line, ":")
+		if !ok {
+			continue
+		}
+		key, value = strings.ToLower(strings.TrimSpace(key)), strings.TrimSpace(value)
+		switch key {
+		case "user-agent":
+			// Consecutive user-agent lines share a group.
+			if group == nil || len(group.rules) > 0 {
+				group = &robotsTxtGroup{}
+				robots.groups = append(robots.groups, group)
+			}
+			group.userAgents = append(group.userAgents, strings.ToLower(value))
+		case "allow", "disallow":
+			if group == nil {
+				continue
+			}
+			// An empty rule matches nothing, e.g. "Disallow:" allows everything.
+			if value == "" {
+				continue
+			}
+			group.rules = append(group.rules, robotsTxtRule{allow: key == "allow", length: len(value), regexp: compileRobotsTxtPattern(value)})
+		}
+	}
+	return robots
+}
+
+// isAllowed reports whether the user agent may fetch the path, including the query. The rules
+// of the group naming the product token of the user agent apply, or else those of "*". The
+// longest matching rule wins, and allow wins a tie.
+func (r *robotsTxt) isAllowed(userAgent, path string) bool {
+	if r.disallowAll {
+		return false
+	}
+	if path == "/robots.txt" {
+		return true
+	}
+	rules := r.getRules(getProductToken(userAgent))
+	allowed, matchedLength := true, -1
+	for _, rule := range rules {
+		if !rule.regexp.MatchString(path) {
+			continue
+		}
+		if rule.length > matchedLength || (rule.length == matchedLength && rule.allow) {
+			allowed, matchedLength = rule.allow, rule.length
+		}
+	}
+	return allowed
+}
+
+func (r *robotsTxt) getRules(productToken string) []robotsTxtRule {
+	rules, wildcardRules := []robotsTxtRule{}, []robotsTxtRule{}
+	matched := false
+	for _, group := range r.groups {
+		for _, userAgent := range group.userAgents {
+			if userAgent == "*" {
+				wildcardRules = append(wildcardRules, group.rules...)
+			} else if productToken != "" && userAgent == productToken {
+				rules = append(rules, group.rules...)
+				matched = true
+			}
+		}
+	}
+	if matched {
+		return rules
+	}
+	return wildcardRules
+}
+
+// getProductToken returns the lowercased name of the user agent, e.g. "memos" of
+// "memos/0.24.3 (+https://www.usememos.com)".
+func getProductToken(userAgent string) string {
+	token, _, _ := strings.Cut(strings.TrimSpace(userAgent), "/")
+	token, _, _ = strings.Cut(token, " ")
+	return strings.ToLower(token)
+}
+
+// compileRobotsTxtPattern compiles the pattern of a rule, which matches the paths starting
+// with it. A "*" matches any characters and a trailing "$" matches the end of the path.
+func compileRobotsTxtPattern(pattern string) *regexp.Regexp {
+	anchored := strings.HasSuffix(pattern, "$")
+	parts := strings.Split(strings.TrimSuffix(pattern, "$"), "*")
+	for i, part := range parts {
+		parts[i] = regexp.QuoteMeta(part)
+	}
+	expr := "^" + strings.Join(parts, ".*")
+	if anchored {
+		expr += "$"
+	}
+	return regexp.MustCompile(expr)
+}
+
+type robotsTxtCacheEntry struct {
+	robots    *robotsTxt
+	expiresAt time.Time
+}
+
+// robotsTxtCache caches the robots.txt of hosts, keyed by scheme and host.
+type robotsTxtCache struct {
+	now func() time.Time
+
+	mu      sync.Mutex
+	entries map[string]*robotsTxtCacheEntry
+}
+
+func newRobotsTxtCache() *robotsTxtCache {
+	return &robotsTxtCache{
+		now:     time.Now,
+		entries: map[string]*robotsTxtCacheEntry{},
+	}
+}
+
+// defaultRobotsTxtCache is shared by the link metadata fetches.
+var defaultRobotsTxtCache = newRobotsTxtCache()
+
+// checkRobotsTxt returns ErrDisallowedByRobots if the robots.txt of the host of the URL
+// disallows fetching it for the user agent of the options.
+func (c *robotsTxtCache) checkRobotsTxt(client *http.Client, urlStr string, options HTMLMetaOptions) error {
+	u, err := url.Parse(urlStr)
+	if err != nil {
+		return errors.Wrap(ErrInvalidURL, "invalid URL format")
+	}
+	robots, err := c.get(client, u, options)
+	if err != nil {
+		return err
+	}
+	userAgent := options.UserAgent
+	if userAgent == "" {
+		userAgent = "Go-http-client"
+	}
+	if !robots.isAllowed(userAgent, u.RequestURI()) {
+		return errors.Wrapf(ErrDisallowedByRobots, "%s", urlStr)
+	}
+	return nil
+}
+
+func (c *robotsTxtCache) get(client *http.Client, u *url.URL, options HTMLMetaOptions) (*robotsTxt, error) {
+	key := strings.ToLower(u.Scheme + "://" + u.Host)
+	c.mu.Lock()
+	entry, ok := c.entries[key]
+	c.mu.Unlock()
+	if ok && c.now().Before(entry.expiresAt) {
+		return entry.robots, nil
+	}
+
+	robots, err := fetchRobotsTxt(client, key+"/robots.txt", options)
+	if err != nil {
+		return nil, err
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if len(c.entries) >= robotsTxtCacheMaxEntries {
+		for key, entry := range c.entries {
+			if !c.now().Before(entry.expiresAt) {
+				delete(c.entries, key)
+			}
+		}
+		// Still full of live entries, so start over rather than track their use.
+		if len(c.entries) >= robotsTxtCacheMaxEntries {
+			c.entries = map[string]*robotsTxtCacheEntry{}
+		}
+	}
+	c.entries[key] = &robotsTxtCacheEntry{robots: robots, expiresAt: c.now().Add(robotsTxtCacheTTL)}
+	return robots, nil
+}
+
+// fetchRobotsTxt fetches and parses the robots.txt. As RFC 9309 asks, a missing robots.txt
+// allows everything and one that fails with a server error disallows everything.
+func fetchRobotsTxt(client *http.Client, robotsURL string, options HTMLMetaOptions) (*robotsTxt, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), options.Timeout)
+	defer cancel()
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, robotsURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	setRequestHeaders(request, options)
+	response, err := client.Do(request)
+	if err != nil {
+		return nil, convertRequestError(err)
+	}
+	defer response.Body.Close()
+
+	switch {
+	case response.StatusCode >= 500:
+		return &robotsTxt{disallowAll: true}, nil
+	case response.StatusCode >= 400:
+		return &robotsTxt{}, nil
+	}
+	body, err := io.ReadAll(io.LimitReader(response.Body, robotsTxtMaxBodySize))
+	if err != nil {
+		return nil, convertRequestError(err)
+	}
+	return parseRobotsTxt(bytes.NewReader(body)), nil
+}
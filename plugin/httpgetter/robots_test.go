@@ -0,0 +1,106 @@
+package httpgetter
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRobotsTxtIsAllowed(t *testing.T) {
+	robots := parseRobotsTxt(strings.NewReader(`
+# Comments and unknown lines are ignored.
+Sitemap: https://example.com/sitemap.xml
+
+User-agent: *
+Disallow: /private
+Allow: /private/public
+Disallow: /*.pdf$
+
+User-agent: memos
+User-agent: other
+Disallow: /
+Allow: /notes/
+Disallow: /notes/draft
+`))
+	tests := []struct {
+		userAgent string
+		path      string
+		allowed   bool
+	}{
+		{userAgent: "Go-http-client/1.1", path: "/", allowed: true},
+		{userAgent: "Go-http-client/1.1", path: "/private/a", allowed: false},
+		{userAgent: "Go-http-client/1.1", path: "/private/public/a", allowed: true},
+		{userAgent: "Go-http-client/1.1", path: "/docs/a.pdf", allowed: false},
+		{userAgent: "Go-http-client/1.1", path: "/docs/a.pdf?download=1", allowed: true},
+		// The group naming the product token replaces the "*" group.
+		{userAgent: "memos/0.24.3 (+https://www.usememos.com)", path: "/", allowed: false},
+		{userAgent: "Memos/0.24.3", path: "/notes/1", allowed: true},
+		{userAgent: "memos/0.24.3", path: "/notes/draft/1", allowed: false},
+		{userAgent: "memos/0.24.3", path: "/robots.txt", allowed: true},
+	}
+	for _, test := range tests {
+		require.Equal(t, test.allowed, robots.isAllowed(test.userAgent, test.path), "%s %s", test.userAgent, test.path)
+	}
+
+	// Allow wins a tie, and an empty disallow allows everything.
+	robots = parseRobotsTxt(strings.NewReader("User-agent: *\nDisallow: /page\nAllow: /page\n\nUser-agent: memos\nDisallow:\n"))
+	require.True(t, robots.isAllowed("Go-http-client/1.1", "/page"))
+	require.True(t, robots.isAllowed("memos/0.24.3", "/page"))
+}
+
+func TestFetchHTMLMetaRespectRobotsTxt(t *testing.T) {
+	var robotsRequests atomic.Int32
+	mux := http.NewServeMux()
+	mux.HandleFunc("/robots.txt", func(w http.ResponseWriter, _ *http.Request) {
+		robotsRequests.Add(1)
+		_, _ = w.Write([]byte("User-agent: memos\nDisallow: /private\n"))
+	})
+	mux.HandleFunc("/", func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		_, _ = w.Write([]byte("<html><head><title>memos</title></head></html>"))
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+	defaultRobotsTxtCache = newRobotsTxtCache()
+
+	options := HTMLMetaOptions{Timeout: time.Second, MaxBodySize: 1024, UserAgent: "memos/0.24.3", RespectRobotsTxt: true}
+	htmlMeta, err := fetchHTMLMeta(internalHTTPClient, server.URL+"/public", options)
+	require.NoError(t, err)
+	require.Equal(t, "memos", htmlMeta.Title)
+	_, err = fetchHTMLMeta(internalHTTPClient, server.URL+"/private/page", options)
+	require.ErrorIs(t, err, ErrDisallowedByRobots)
+	// The robots.txt is cached per host.
+	require.Equal(t, int32(1), robotsRequests.Load())
+
+	// Other user agents and fetches without the option are not disallowed.
+	options.UserAgent = "other/1.0"
+	_, err = fetchHTMLMeta(internalHTTPClient, server.URL+"/private/page", options)
+	require.NoError(t, err)
+	options.UserAgent, options.RespectRobotsTxt = "memos/0.24.3", false
+	_, err = fetchHTMLMeta(internalHTTPClient, server.URL+"/private/page", options)
+	require.NoError(t, err)
+}
+
+func TestFetchRobotsTxtStatus(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/missing/robots.txt", http.NotFound)
+	mux.HandleFunc("/unavailable/robots.txt", func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	options := HTMLMetaOptions{Timeout: time.Second}
+	// A missing robots.txt allows everything, an unavailable one disallows everything.
+	robots, err := fetchRobotsTxt(internalHTTPClient, server.URL+"/missing/robots.txt", options)
+	require.NoError(t, err)
+	require.True(t, robots.isAllowed("memos", "/page"))
+	robots, err = fetchRobotsTxt(internalHTTPClient, server.URL+"/unavailable/robots.txt", options)
+	require.NoError(t, err)
+	require.False(t, robots.isAllowed("memos", "/page"))
+}
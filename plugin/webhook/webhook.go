@@ -2,6 +2,10 @@ package webhook
 
 import (
 	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"io"
 	"net/http"
@@ -10,43 +14,95 @@ import (
 	"github.com/pkg/errors"
 	"google.golang.org/protobuf/encoding/protojson"
 
+	"github.com/usememos/memos/plugin/httpgetter"
 	v1pb "github.com/usememos/memos/proto/gen/api/v1"
 )
 
+// SignatureHeader is the header of the HMAC-SHA256 signature of the request body, which
+// receivers can verify with the secret of the webhook. Format: sha256={hex digest}.
+const SignatureHeader = "X-Memos-Signature-256"
+
 var (
 	// timeout is the timeout for webhook request. Default to 30 seconds.
 	timeout = 30 * time.Second
+	// maxRetries is the max number of times a failed webhook request is retried.
+	maxRetries = 3
+	// initialBackoff is the wait before the first retry, which doubles with every retry.
+	initialBackoff = time.Second
+
+	// httpClient refuses to connect to internal IPs.
+	httpClient = httpgetter.NewHTTPClient(false)
+	// internalHTTPClient may connect to internal IPs, e.g. to automation services in the same network.
+	internalHTTPClient = httpgetter.NewHTTPClient(true)
 )
 
-// Post posts the message to webhook endpoint.
-func Post(requestPayload *v1pb.WebhookRequestPayload) error {
+// Options configures how a webhook request is sent.
+type Options struct {
+	// Secret signs the request body into SignatureHeader. Requests are not signed when it is empty.
+	Secret string
+	// AllowInternalIPs disables the guard against loopback, link-local and private addresses.
+	AllowInternalIPs bool
+}
+
+// Post posts the message to webhook endpoint. Requests that fail to be sent or get a non-2xx
+// response are retried with exponential backoff, up to maxRetries times.
+func Post(requestPayload *v1pb.WebhookRequestPayload, options Options) error {
+	if err := httpgetter.ValidateURL(requestPayload.Url); err != nil {
+		return errors.Wrapf(err, "invalid webhook url %s", requestPayload.Url)
+	}
 	body, err := protojson.Marshal(requestPayload)
 	if err != nil {
 		return errors.Wrapf(err, "failed to marshal webhook request to %s", requestPayload.Url)
 	}
 
-	req, err := http.NewRequest("POST", requestPayload.Url, bytes.NewBuffer(body))
+	client := httpClient
+	if options.AllowInternalIPs {
+		client = internalHTTPClient
+	}
+	backoff := initialBackoff
+	for retries := 0; ; retries++ {
+		retryable, err := post(client, requestPayload.Url, body, options)
+		if err == nil || !retryable || retries >= maxRetries {
+			return err
+		}
+		time.Sleep(backoff)
+		backoff *= 2
+	}
+}
+
+// Sign returns the signature of the body with the secret as sent in SignatureHeader.
+func Sign(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// post sends the webhook request once and reports whether it is worth retrying on failure.
+func post(client *http.Client, url string, body []byte, options Options) (bool, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewBuffer(body))
 	if err != nil {
-		return errors.Wrapf(err, "failed to construct webhook request to %s", requestPayload.Url)
+		return false, errors.Wrapf(err, "failed to construct webhook request to %s", url)
 	}
 
 	req.Header.Set("Content-Type", "application/json")
-	client := &http.Client{
-		Timeout: timeout,
+	if options.Secret != "" {
+		req.Header.Set(SignatureHeader, Sign(options.Secret, body))
 	}
 	resp, err := client.Do(req)
 	if err != nil {
-		return errors.Wrapf(err, "failed to post webhook to %s", requestPayload.Url)
+		return !errors.Is(err, httpgetter.ErrInternalIP), errors.Wrapf(err, "failed to post webhook to %s", url)
 	}
+	defer resp.Body.Close()
 
 	b, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return errors.Wrapf(err, "failed to read webhook response from %s", requestPayload.Url)
+		return true, errors.Wrapf(err, "failed to read webhook response from %s", url)
 	}
-	defer resp.Body.Close()
 
 	if resp.StatusCode < 200 || resp.StatusCode > 299 {
-		return errors.Errorf("failed to post webhook %s, status code: %d, response body: %s", requestPayload.Url, resp.StatusCode, b)
+		return true, errors.Errorf("failed to post webhook %s, status code: %d, response body: %s", url, resp.StatusCode, b)
 	}
 
 	response := &struct {
@@ -54,12 +110,12 @@ func Post(requestPayload *v1pb.WebhookRequestPayload) error {
 		Message string `json:"message"`
 	}{}
 	if err := json.Unmarshal(b, response); err != nil {
-		return errors.Wrapf(err, "failed to unmarshal webhook response from %s", requestPayload.Url)
+		return false, errors.Wrapf(err, "failed to unmarshal webhook response from %s", url)
 	}
 
 	if response.Code != 0 {
-		return errors.Errorf("receive error code sent by webhook server, code %d, msg: %s", response.Code, response.Message)
+		return false, errors.Errorf("receive error code sent by webhook server, code %d, msg: %s", response.Code, response.Message)
 	}
 
-	return nil
+	return false, nil
 }
@@ -1 +1,91 @@
 package webhook
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/usememos/memos/plugin/httpgetter"
+	v1pb "github.com/usememos/memos/proto/gen/api/v1"
+)
+
+func TestSign(t *testing.T) {
+	// The digest of HMAC-SHA256 with key "key" of "The quick brown fox jumps over the lazy dog".
+	require.Equal(t, "sha256=f7bc83f430538424b13298e6aa6fb143ef4d59a14946175997479dbc2d1a3cd8", Sign("key", []byte("The quick brown fox jumps over the lazy dog")))
+}
+
+func TestPostSignature(t *testing.T) {
+	var signature, body string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, _ := io.ReadAll(r.Body)
+		signature, body = r.Header.Get(SignatureHeader), string(b)
+		_, _ = w.Write([]byte(`{"code":0}`))
+	}))
+	defer server.Close()
+
+	payload := &v1pb.WebhookRequestPayload{Url: server.URL, ActivityType: "memos.memo.created", Actor: "users/1"}
+	require.NoError(t, Post(payload, Options{Secret: "secret", AllowInternalIPs: true}))
+	require.Contains(t, body, `"actor":"users/1"`)
+	require.Equal(t, Sign("secret", []byte(body)), signature)
+
+	// Requests are not signed without a secret.
+	require.NoError(t, Post(payload, Options{AllowInternalIPs: true}))
+	require.Empty(t, signature)
+}
+
+func TestPostRetry(t *testing.T) {
+	initialBackoff = time.Millisecond
+	defer func() { initialBackoff = time.Second }()
+
+	tests := []struct {
+		failures int32
+		wantErr  bool
+		// The number of requests the server gets.
+		requests int32
+	}{
+		{failures: 0, requests: 1},
+		{failures: 2, requests: 3},
+		{failures: int32(maxRetries), requests: int32(maxRetries) + 1},
+		{failures: 100, wantErr: true, requests: int32(maxRetries) + 1},
+	}
+	for _, test := range tests {
+		var requests atomic.Int32
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			if requests.Add(1) <= test.failures {
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+			_, _ = w.Write([]byte(`{"code":0}`))
+		}))
+		err := Post(&v1pb.WebhookRequestPayload{Url: server.URL}, Options{AllowInternalIPs: true})
+		server.Close()
+		if test.wantErr {
+			require.Error(t, err)
+		} else {
+			require.NoError(t, err)
+		}
+		require.Equal(t, test.requests, requests.Load())
+	}
+}
+
+func TestPostInternalIP(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		requests.Add(1)
+		_, _ = w.Write([]byte(`{"code":0}`))
+	}))
+	defer server.Close()
+
+	// The test server listens on a loopback address, which is refused without retries.
+	err := Post(&v1pb.WebhookRequestPayload{Url: server.URL}, Options{})
+	require.ErrorIs(t, err, httpgetter.ErrInternalIP)
+	require.Equal(t, int32(0), requests.Load())
+
+	err = Post(&v1pb.WebhookRequestPayload{Url: "ftp://example.com"}, Options{})
+	require.ErrorIs(t, err, httpgetter.ErrInvalidURL)
+}
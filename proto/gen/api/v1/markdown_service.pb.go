@@ -40,6 +40,8 @@ const (
 	NodeType_MATH_BLOCK          NodeType = 11
 	NodeType_TABLE               NodeType = 12
 	NodeType_EMBEDDED_CONTENT    NodeType = 13
+	NodeType_FRONTMATTER         NodeType = 14
+	NodeType_FOOTNOTE_DEF        NodeType = 15
 	// Inline nodes.
 	NodeType_TEXT               NodeType = 51
 	NodeType_BOLD               NodeType = 52
@@ -59,6 +61,10 @@ const (
 	NodeType_REFERENCED_CONTENT NodeType = 66
 	NodeType_SPOILER            NodeType = 67
 	NodeType_HTML_ELEMENT       NodeType = 68
+	NodeType_EMOJI              NodeType = 69
+	// A node of an extension registered with the server, see CustomNode.
+	NodeType_CUSTOM       NodeType = 70
+	NodeType_FOOTNOTE_REF NodeType = 71
 )
 
 // Enum value maps for NodeType.
@@ -78,6 +84,8 @@ var (
 		11: "MATH_BLOCK",
 		12: "TABLE",
 		13: "EMBEDDED_CONTENT",
+		14: "FRONTMATTER",
+		15: "FOOTNOTE_DEF",
 		51: "TEXT",
 		52: "BOLD",
 		53: "ITALIC",
@@ -96,6 +104,9 @@ var (
 		66: "REFERENCED_CONTENT",
 		67: "SPOILER",
 		68: "HTML_ELEMENT",
+		69: "EMOJI",
+		70: "CUSTOM",
+		71: "FOOTNOTE_REF",
 	}
 	NodeType_value = map[string]int32{
 		"NODE_UNSPECIFIED":    0,
@@ -112,6 +123,8 @@ var (
 		"MATH_BLOCK":          11,
 		"TABLE":               12,
 		"EMBEDDED_CONTENT":    13,
+		"FRONTMATTER":         14,
+		"FOOTNOTE_DEF":        15,
 		"TEXT":                51,
 		"BOLD":                52,
 		"ITALIC":              53,
@@ -130,6 +143,9 @@ var (
 		"REFERENCED_CONTENT":  66,
 		"SPOILER":             67,
 		"HTML_ELEMENT":        68,
+		"EMOJI":               69,
+		"CUSTOM":              70,
+		"FOOTNOTE_REF":        71,
 	}
 )
 
@@ -160,6 +176,342 @@ func (NodeType) EnumDescriptor() ([]byte, []int) {
 	return file_api_v1_markdown_service_proto_rawDescGZIP(), []int{0}
 }
 
+type ParseMarkdownRequest_RawHTMLMode int32
+
+const (
+	// Same as ESCAPE, so that clients are safe by default.
+	ParseMarkdownRequest_RAW_HTML_MODE_UNSPECIFIED ParseMarkdownRequest_RawHTMLMode = 0
+	// Raw HTML is kept as parsed, e.g. "<br />" as an HTML_ELEMENT node. Clients must sanitize it before rendering.
+	ParseMarkdownRequest_ALLOW ParseMarkdownRequest_RawHTMLMode = 1
+	// Raw HTML is kept as TEXT nodes of its markup. Tags in angle brackets are text rather than AUTO_LINK nodes,
+	// e.g. "<img src=x onerror=alert(1)>", while auto links with a scheme like "<https://usememos.com>" are kept.
+	ParseMarkdownRequest_ESCAPE ParseMarkdownRequest_RawHTMLMode = 2
+	// Raw HTML is dropped, including tags in text, e.g. "a <b>b</b>" becomes "a b".
+	ParseMarkdownRequest_STRIP ParseMarkdownRequest_RawHTMLMode = 3
+)
+
+// Enum value maps for ParseMarkdownRequest_RawHTMLMode.
+var (
+	ParseMarkdownRequest_RawHTMLMode_name = map[int32]string{
+		0: "RAW_HTML_MODE_UNSPECIFIED",
+		1: "ALLOW",
+		2: "ESCAPE",
+		3: "STRIP",
+	}
+	ParseMarkdownRequest_RawHTMLMode_value = map[string]int32{
+		"RAW_HTML_MODE_UNSPECIFIED": 0,
+		"ALLOW":                     1,
+		"ESCAPE":                    2,
+		"STRIP":                     3,
+	}
+)
+
+func (x ParseMarkdownRequest_RawHTMLMode) Enum() *ParseMarkdownRequest_RawHTMLMode {
+	p := new(ParseMarkdownRequest_RawHTMLMode)
+	*p = x
+	return p
+}
+
+func (x ParseMarkdownRequest_RawHTMLMode) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ParseMarkdownRequest_RawHTMLMode) Descriptor() protoreflect.EnumDescriptor {
+	return file_api_v1_markdown_service_proto_enumTypes[1].Descriptor()
+}
+
+func (ParseMarkdownRequest_RawHTMLMode) Type() protoreflect.EnumType {
+	return &file_api_v1_markdown_service_proto_enumTypes[1]
+}
+
+func (x ParseMarkdownRequest_RawHTMLMode) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ParseMarkdownRequest_RawHTMLMode.Descriptor instead.
+func (ParseMarkdownRequest_RawHTMLMode) EnumDescriptor() ([]byte, []int) {
+	return file_api_v1_markdown_service_proto_rawDescGZIP(), []int{0, 0}
+}
+
+type StringifyMarkdownNodesRequest_Mode int32
+
+const (
+	// The raw string of the nodes, e.g. links become their URL.
+	StringifyMarkdownNodesRequest_MODE_UNSPECIFIED StringifyMarkdownNodesRequest_Mode = 0
+	// The text of the nodes without markdown syntax, e.g. links become their label and images their alt text.
+	// Consecutive block nodes are separated by a single newline.
+	StringifyMarkdownNodesRequest_PLAIN_TEXT StringifyMarkdownNodesRequest_Mode = 1
+	// GitHub Flavored Markdown, e.g. task list items as "- [x]", pipe tables and "~~text~~".
+	StringifyMarkdownNodesRequest_GFM StringifyMarkdownNodesRequest_Mode = 2
+	// Strict CommonMark. Task list items, tables and strikethrough fall back to inline HTML.
+	StringifyMarkdownNodesRequest_COMMONMARK StringifyMarkdownNodesRequest_Mode = 3
+	// The table of contents of the top-level headings as a nested markdown list of links to their anchors,
+	// e.g. "- [Title](#title)" with subheadings indented below. The response also holds it as a structure.
+	StringifyMarkdownNodesRequest_TABLE_OF_CONTENTS StringifyMarkdownNodesRequest_Mode = 4
+)
+
+// Enum value maps for StringifyMarkdownNodesRequest_Mode.
+var (
+	StringifyMarkdownNodesRequest_Mode_name = map[int32]string{
+		0: "MODE_UNSPECIFIED",
+		1: "PLAIN_TEXT",
+		2: "GFM",
+		3: "COMMONMARK",
+		4: "TABLE_OF_CONTENTS",
+	}
+	StringifyMarkdownNodesRequest_Mode_value = map[string]int32{
+		"MODE_UNSPECIFIED":  0,
+		"PLAIN_TEXT":        1,
+		"GFM":               2,
+		"COMMONMARK":        3,
+		"TABLE_OF_CONTENTS": 4,
+	}
+)
+
+func (x StringifyMarkdownNodesRequest_Mode) Enum() *StringifyMarkdownNodesRequest_Mode {
+	p := new(StringifyMarkdownNodesRequest_Mode)
+	*p = x
+	return p
+}
+
+func (x StringifyMarkdownNodesRequest_Mode) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (StringifyMarkdownNodesRequest_Mode) Descriptor() protoreflect.EnumDescriptor {
+	return file_api_v1_markdown_service_proto_enumTypes[2].Descriptor()
+}
+
+func (StringifyMarkdownNodesRequest_Mode) Type() protoreflect.EnumType {
+	return &file_api_v1_markdown_service_proto_enumTypes[2]
+}
+
+func (x StringifyMarkdownNodesRequest_Mode) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use StringifyMarkdownNodesRequest_Mode.Descriptor instead.
+func (StringifyMarkdownNodesRequest_Mode) EnumDescriptor() ([]byte, []int) {
+	return file_api_v1_markdown_service_proto_rawDescGZIP(), []int{7, 0}
+}
+
+type StringifyMarkdownNodesRequest_LinkMode int32
+
+const (
+	// The URLs of links and images are left as is.
+	StringifyMarkdownNodesRequest_LINK_MODE_UNSPECIFIED StringifyMarkdownNodesRequest_LinkMode = 0
+	// Relative URLs are resolved against base_url, e.g. "img/a.png" becomes "https://example.com/img/a.png".
+	// Protocol-relative URLs take the scheme of base_url.
+	StringifyMarkdownNodesRequest_ABSOLUTIZE StringifyMarkdownNodesRequest_LinkMode = 1
+	// URLs on the same host as base_url are made relative to it, e.g. "https://example.com/img/a.png"
+	// becomes "img/a.png" with base_url "https://example.com/". URLs on other hosts are left as is.
+	StringifyMarkdownNodesRequest_RELATIVIZE StringifyMarkdownNodesRequest_LinkMode = 2
+)
+
+// Enum value maps for StringifyMarkdownNodesRequest_LinkMode.
+var (
+	StringifyMarkdownNodesRequest_LinkMode_name = map[int32]string{
+		0: "LINK_MODE_UNSPECIFIED",
+		1: "ABSOLUTIZE",
+		2: "RELATIVIZE",
+	}
+	StringifyMarkdownNodesRequest_LinkMode_value = map[string]int32{
+		"LINK_MODE_UNSPECIFIED": 0,
+		"ABSOLUTIZE":            1,
+		"RELATIVIZE":            2,
+	}
+)
+
+func (x StringifyMarkdownNodesRequest_LinkMode) Enum() *StringifyMarkdownNodesRequest_LinkMode {
+	p := new(StringifyMarkdownNodesRequest_LinkMode)
+	*p = x
+	return p
+}
+
+func (x StringifyMarkdownNodesRequest_LinkMode) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (StringifyMarkdownNodesRequest_LinkMode) Descriptor() protoreflect.EnumDescriptor {
+	return file_api_v1_markdown_service_proto_enumTypes[3].Descriptor()
+}
+
+func (StringifyMarkdownNodesRequest_LinkMode) Type() protoreflect.EnumType {
+	return &file_api_v1_markdown_service_proto_enumTypes[3]
+}
+
+func (x StringifyMarkdownNodesRequest_LinkMode) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use StringifyMarkdownNodesRequest_LinkMode.Descriptor instead.
+func (StringifyMarkdownNodesRequest_LinkMode) EnumDescriptor() ([]byte, []int) {
+	return file_api_v1_markdown_service_proto_rawDescGZIP(), []int{7, 1}
+}
+
+type MarkdownNodeDiff_Type int32
+
+const (
+	MarkdownNodeDiff_TYPE_UNSPECIFIED MarkdownNodeDiff_Type = 0
+	// The node is only in the new version.
+	MarkdownNodeDiff_ADDED MarkdownNodeDiff_Type = 1
+	// The node is only in the old version.
+	MarkdownNodeDiff_REMOVED MarkdownNodeDiff_Type = 2
+	// The node of the old version was edited into the node of the new version, e.g. the text of a paragraph.
+	MarkdownNodeDiff_CHANGED MarkdownNodeDiff_Type = 3
+)
+
+// Enum value maps for MarkdownNodeDiff_Type.
+var (
+	MarkdownNodeDiff_Type_name = map[int32]string{
+		0: "TYPE_UNSPECIFIED",
+		1: "ADDED",
+		2: "REMOVED",
+		3: "CHANGED",
+	}
+	MarkdownNodeDiff_Type_value = map[string]int32{
+		"TYPE_UNSPECIFIED": 0,
+		"ADDED":            1,
+		"REMOVED":          2,
+		"CHANGED":          3,
+	}
+)
+
+func (x MarkdownNodeDiff_Type) Enum() *MarkdownNodeDiff_Type {
+	p := new(MarkdownNodeDiff_Type)
+	*p = x
+	return p
+}
+
+func (x MarkdownNodeDiff_Type) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (MarkdownNodeDiff_Type) Descriptor() protoreflect.EnumDescriptor {
+	return file_api_v1_markdown_service_proto_enumTypes[4].Descriptor()
+}
+
+func (MarkdownNodeDiff_Type) Type() protoreflect.EnumType {
+	return &file_api_v1_markdown_service_proto_enumTypes[4]
+}
+
+func (x MarkdownNodeDiff_Type) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use MarkdownNodeDiff_Type.Descriptor instead.
+func (MarkdownNodeDiff_Type) EnumDescriptor() ([]byte, []int) {
+	return file_api_v1_markdown_service_proto_rawDescGZIP(), []int{12, 0}
+}
+
+type MarkdownDiagnostic_Severity int32
+
+const (
+	MarkdownDiagnostic_SEVERITY_UNSPECIFIED MarkdownDiagnostic_Severity = 0
+	// The markdown does not render as intended, e.g. a code fence that is not closed, whose code
+	// is rendered as paragraphs.
+	MarkdownDiagnostic_ERROR MarkdownDiagnostic_Severity = 1
+	// The markdown is likely not what was meant, e.g. link syntax that is rendered as text.
+	MarkdownDiagnostic_WARNING MarkdownDiagnostic_Severity = 2
+)
+
+// Enum value maps for MarkdownDiagnostic_Severity.
+var (
+	MarkdownDiagnostic_Severity_name = map[int32]string{
+		0: "SEVERITY_UNSPECIFIED",
+		1: "ERROR",
+		2: "WARNING",
+	}
+	MarkdownDiagnostic_Severity_value = map[string]int32{
+		"SEVERITY_UNSPECIFIED": 0,
+		"ERROR":                1,
+		"WARNING":              2,
+	}
+)
+
+func (x MarkdownDiagnostic_Severity) Enum() *MarkdownDiagnostic_Severity {
+	p := new(MarkdownDiagnostic_Severity)
+	*p = x
+	return p
+}
+
+func (x MarkdownDiagnostic_Severity) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (MarkdownDiagnostic_Severity) Descriptor() protoreflect.EnumDescriptor {
+	return file_api_v1_markdown_service_proto_enumTypes[5].Descriptor()
+}
+
+func (MarkdownDiagnostic_Severity) Type() protoreflect.EnumType {
+	return &file_api_v1_markdown_service_proto_enumTypes[5]
+}
+
+func (x MarkdownDiagnostic_Severity) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use MarkdownDiagnostic_Severity.Descriptor instead.
+func (MarkdownDiagnostic_Severity) EnumDescriptor() ([]byte, []int) {
+	return file_api_v1_markdown_service_proto_rawDescGZIP(), []int{19, 0}
+}
+
+type NormalizeMarkdownRequest_BulletStyle int32
+
+const (
+	// The bullets of unordered and task list items are left as is.
+	NormalizeMarkdownRequest_BULLET_STYLE_UNSPECIFIED NormalizeMarkdownRequest_BulletStyle = 0
+	// "- item"
+	NormalizeMarkdownRequest_DASH NormalizeMarkdownRequest_BulletStyle = 1
+	// "* item"
+	NormalizeMarkdownRequest_ASTERISK NormalizeMarkdownRequest_BulletStyle = 2
+	// "+ item"
+	NormalizeMarkdownRequest_PLUS NormalizeMarkdownRequest_BulletStyle = 3
+)
+
+// Enum value maps for NormalizeMarkdownRequest_BulletStyle.
+var (
+	NormalizeMarkdownRequest_BulletStyle_name = map[int32]string{
+		0: "BULLET_STYLE_UNSPECIFIED",
+		1: "DASH",
+		2: "ASTERISK",
+		3: "PLUS",
+	}
+	NormalizeMarkdownRequest_BulletStyle_value = map[string]int32{
+		"BULLET_STYLE_UNSPECIFIED": 0,
+		"DASH":                     1,
+		"ASTERISK":                 2,
+		"PLUS":                     3,
+	}
+)
+
+func (x NormalizeMarkdownRequest_BulletStyle) Enum() *NormalizeMarkdownRequest_BulletStyle {
+	p := new(NormalizeMarkdownRequest_BulletStyle)
+	*p = x
+	return p
+}
+
+func (x NormalizeMarkdownRequest_BulletStyle) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (NormalizeMarkdownRequest_BulletStyle) Descriptor() protoreflect.EnumDescriptor {
+	return file_api_v1_markdown_service_proto_enumTypes[6].Descriptor()
+}
+
+func (NormalizeMarkdownRequest_BulletStyle) Type() protoreflect.EnumType {
+	return &file_api_v1_markdown_service_proto_enumTypes[6]
+}
+
+func (x NormalizeMarkdownRequest_BulletStyle) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use NormalizeMarkdownRequest_BulletStyle.Descriptor instead.
+func (NormalizeMarkdownRequest_BulletStyle) EnumDescriptor() ([]byte, []int) {
+	return file_api_v1_markdown_service_proto_rawDescGZIP(), []int{20, 0}
+}
+
 type ListNode_Kind int32
 
 const (
@@ -167,6 +519,7 @@ const (
 	ListNode_ORDERED          ListNode_Kind = 1
 	ListNode_UNORDERED        ListNode_Kind = 2
 	ListNode_DESCRIPTION      ListNode_Kind = 3
+	ListNode_TASK             ListNode_Kind = 4
 )
 
 // Enum value maps for ListNode_Kind.
@@ -176,12 +529,14 @@ var (
 		1: "ORDERED",
 		2: "UNORDERED",
 		3: "DESCRIPTION",
+		4: "TASK",
 	}
 	ListNode_Kind_value = map[string]int32{
 		"KIND_UNSPECIFIED": 0,
 		"ORDERED":          1,
 		"UNORDERED":        2,
 		"DESCRIPTION":      3,
+		"TASK":             4,
 	}
 )
 
@@ -196,11 +551,11 @@ func (x ListNode_Kind) String() string {
 }
 
 func (ListNode_Kind) Descriptor() protoreflect.EnumDescriptor {
-	return file_api_v1_markdown_service_proto_enumTypes[1].Descriptor()
+	return file_api_v1_markdown_service_proto_enumTypes[7].Descriptor()
 }
 
 func (ListNode_Kind) Type() protoreflect.EnumType {
-	return &file_api_v1_markdown_service_proto_enumTypes[1]
+	return &file_api_v1_markdown_service_proto_enumTypes[7]
 }
 
 func (x ListNode_Kind) Number() protoreflect.EnumNumber {
@@ -209,12 +564,60 @@ func (x ListNode_Kind) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use ListNode_Kind.Descriptor instead.
 func (ListNode_Kind) EnumDescriptor() ([]byte, []int) {
-	return file_api_v1_markdown_service_proto_rawDescGZIP(), []int{15, 0}
+	return file_api_v1_markdown_service_proto_rawDescGZIP(), []int{32, 0}
 }
 
 type ParseMarkdownRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Markdown      string                 `protobuf:"bytes,1,opt,name=markdown,proto3" json:"markdown,omitempty"`
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Markdown string                 `protobuf:"bytes,1,opt,name=markdown,proto3" json:"markdown,omitempty"`
+	// auto_link_www detects bare www.-prefixed hosts, e.g. www.usememos.com, as auto links.
+	// Bare http and https URLs are always detected.
+	AutoLinkWww bool `protobuf:"varint,2,opt,name=auto_link_www,json=autoLinkWww,proto3" json:"auto_link_www,omitempty"`
+	// include_positions sets the position of each node in the markdown.
+	IncludePositions bool `protobuf:"varint,3,opt,name=include_positions,json=includePositions,proto3" json:"include_positions,omitempty"`
+	// frontmatter parses a leading YAML block between "---" lines into a FRONTMATTER node
+	// instead of a horizontal rule and paragraphs, e.g. for notes imported from Obsidian.
+	Frontmatter bool `protobuf:"varint,4,opt,name=frontmatter,proto3" json:"frontmatter,omitempty"`
+	// math parses inline "$...$" and "$$" blocks into MATH and MATH_BLOCK nodes, keeping the raw TeX.
+	// Inline math only starts at a "$" followed by a non-space and ends at a "$" after a non-space
+	// that is not followed by a digit, so "$5 and $10" stays text. Without it, both are text.
+	Math bool `protobuf:"varint,5,opt,name=math,proto3" json:"math,omitempty"`
+	// max_nesting_depth limits how deeply blockquotes and lists nest, 32 if not set.
+	// Deeper blockquotes are kept as text in the innermost allowed one, and deeper list
+	// items are moved up into the innermost allowed list.
+	MaxNestingDepth int32 `protobuf:"varint,6,opt,name=max_nesting_depth,json=maxNestingDepth,proto3" json:"max_nesting_depth,omitempty"`
+	// emoji parses known shortcodes like ":smile:" into EMOJI nodes. Shortcodes next to a letter,
+	// digit or underscore, e.g. in ":foo:bar:" or "10:30:00", and unknown shortcodes stay text.
+	Emoji bool `protobuf:"varint,7,opt,name=emoji,proto3" json:"emoji,omitempty"`
+	// include_images returns the images of the markdown in images, e.g. for a gallery or to
+	// detect broken images.
+	IncludeImages bool `protobuf:"varint,8,opt,name=include_images,json=includeImages,proto3" json:"include_images,omitempty"`
+	// raw_html_mode controls how raw HTML in the markdown is returned, ESCAPE if not set.
+	RawHtmlMode ParseMarkdownRequest_RawHTMLMode `protobuf:"varint,9,opt,name=raw_html_mode,json=rawHtmlMode,proto3,enum=memos.api.v1.ParseMarkdownRequest_RawHTMLMode" json:"raw_html_mode,omitempty"`
+	// footnotes parses footnote definitions like "[^1]: A note." on a line of their own into FOOTNOTE_DEF
+	// nodes, and references like "text[^1]" to defined labels into FOOTNOTE_REF nodes. References to
+	// undefined labels stay text.
+	Footnotes bool `protobuf:"varint,10,opt,name=footnotes,proto3" json:"footnotes,omitempty"`
+	// code_languages normalizes the info strings of code blocks, which may then hold metadata after the
+	// language, e.g. "Go {hl=1}". The language of a CODE_BLOCK node becomes the lowercased first word of
+	// its info string, mapped through the aliases, e.g. "golang" to "go", and the rest becomes its metadata.
+	// The raw info string is kept as its info.
+	CodeLanguages bool `protobuf:"varint,11,opt,name=code_languages,json=codeLanguages,proto3" json:"code_languages,omitempty"`
+	// code_language_aliases maps lowercased languages to the languages they are aliases of, in addition
+	// to and overriding the built-in aliases like "js" for "javascript" and "py" for "python". Only used
+	// with code_languages.
+	CodeLanguageAliases map[string]string `protobuf:"bytes,12,rep,name=code_language_aliases,json=codeLanguageAliases,proto3" json:"code_language_aliases,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// scripts parses "~sub~" and "^sup^" into SUBSCRIPT and SUPERSCRIPT nodes only where the content has
+	// no whitespace, as in Pandoc, e.g. "H~2~O" and "x^2^", so that bare "~" and "^" like in "a^b c^d"
+	// stay text. "~~strike~~" is still STRIKETHROUGH. Without it, any text up to the next "~" or "^"
+	// on the line is taken.
+	Scripts bool `protobuf:"varint,13,opt,name=scripts,proto3" json:"scripts,omitempty"`
+	// code_fences parses fenced code blocks as CommonMark does, so that they round-trip exactly through
+	// StringifyMarkdownNodes. The fence may be three or more backticks or tildes, and the closing fence is
+	// made of the same character and at least as long. The content of a CODE_BLOCK node then holds the
+	// exact lines between the fences, each ending with a newline, e.g. "code\n\n" for a block whose last
+	// line is blank, and the fences are kept as its fence and closing_fence.
+	CodeFences    bool `protobuf:"varint,14,opt,name=code_fences,json=codeFences,proto3" json:"code_fences,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -256,9 +659,108 @@ func (x *ParseMarkdownRequest) GetMarkdown() string {
 	return ""
 }
 
+func (x *ParseMarkdownRequest) GetAutoLinkWww() bool {
+	if x != nil {
+		return x.AutoLinkWww
+	}
+	return false
+}
+
+func (x *ParseMarkdownRequest) GetIncludePositions() bool {
+	if x != nil {
+		return x.IncludePositions
+	}
+	return false
+}
+
+func (x *ParseMarkdownRequest) GetFrontmatter() bool {
+	if x != nil {
+		return x.Frontmatter
+	}
+	return false
+}
+
+func (x *ParseMarkdownRequest) GetMath() bool {
+	if x != nil {
+		return x.Math
+	}
+	return false
+}
+
+func (x *ParseMarkdownRequest) GetMaxNestingDepth() int32 {
+	if x != nil {
+		return x.MaxNestingDepth
+	}
+	return 0
+}
+
+func (x *ParseMarkdownRequest) GetEmoji() bool {
+	if x != nil {
+		return x.Emoji
+	}
+	return false
+}
+
+func (x *ParseMarkdownRequest) GetIncludeImages() bool {
+	if x != nil {
+		return x.IncludeImages
+	}
+	return false
+}
+
+func (x *ParseMarkdownRequest) GetRawHtmlMode() ParseMarkdownRequest_RawHTMLMode {
+	if x != nil {
+		return x.RawHtmlMode
+	}
+	return ParseMarkdownRequest_RAW_HTML_MODE_UNSPECIFIED
+}
+
+func (x *ParseMarkdownRequest) GetFootnotes() bool {
+	if x != nil {
+		return x.Footnotes
+	}
+	return false
+}
+
+func (x *ParseMarkdownRequest) GetCodeLanguages() bool {
+	if x != nil {
+		return x.CodeLanguages
+	}
+	return false
+}
+
+func (x *ParseMarkdownRequest) GetCodeLanguageAliases() map[string]string {
+	if x != nil {
+		return x.CodeLanguageAliases
+	}
+	return nil
+}
+
+func (x *ParseMarkdownRequest) GetScripts() bool {
+	if x != nil {
+		return x.Scripts
+	}
+	return false
+}
+
+func (x *ParseMarkdownRequest) GetCodeFences() bool {
+	if x != nil {
+		return x.CodeFences
+	}
+	return false
+}
+
 type ParseMarkdownResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Nodes         []*Node                `protobuf:"bytes,1,rep,name=nodes,proto3" json:"nodes,omitempty"`
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Nodes []*Node                `protobuf:"bytes,1,rep,name=nodes,proto3" json:"nodes,omitempty"`
+	// The distinct tags of the markdown in order of first appearance, e.g. "foo" and "nested/bar".
+	// They are formatted as memos store them, see WorkspaceMemoRelatedSetting.tag_lowercase.
+	Tags []string `protobuf:"bytes,2,rep,name=tags,proto3" json:"tags,omitempty"`
+	// Whether blockquotes or lists were nested deeper than max_nesting_depth.
+	Truncated bool `protobuf:"varint,3,opt,name=truncated,proto3" json:"truncated,omitempty"`
+	// The images of the markdown in document order, including those in links and table cells,
+	// if include_images is set. Image syntax in code spans and code blocks is not an image.
+	Images        []*ImageReference `protobuf:"bytes,4,rep,name=images,proto3" json:"images,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -300,27 +802,52 @@ func (x *ParseMarkdownResponse) GetNodes() []*Node {
 	return nil
 }
 
-type RestoreMarkdownNodesRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Nodes         []*Node                `protobuf:"bytes,1,rep,name=nodes,proto3" json:"nodes,omitempty"`
+func (x *ParseMarkdownResponse) GetTags() []string {
+	if x != nil {
+		return x.Tags
+	}
+	return nil
+}
+
+func (x *ParseMarkdownResponse) GetTruncated() bool {
+	if x != nil {
+		return x.Truncated
+	}
+	return false
+}
+
+func (x *ParseMarkdownResponse) GetImages() []*ImageReference {
+	if x != nil {
+		return x.Images
+	}
+	return nil
+}
+
+type ImageReference struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Url     string                 `protobuf:"bytes,1,opt,name=url,proto3" json:"url,omitempty"`
+	AltText string                 `protobuf:"bytes,2,opt,name=alt_text,json=altText,proto3" json:"alt_text,omitempty"`
+	// Whether the URL has neither a scheme nor a host, e.g. "assets/a.png" or "/a.png".
+	// Protocol-relative URLs like "//example.com/a.png" are not relative.
+	IsRelative    bool `protobuf:"varint,3,opt,name=is_relative,json=isRelative,proto3" json:"is_relative,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *RestoreMarkdownNodesRequest) Reset() {
-	*x = RestoreMarkdownNodesRequest{}
+func (x *ImageReference) Reset() {
+	*x = ImageReference{}
 	mi := &file_api_v1_markdown_service_proto_msgTypes[2]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *RestoreMarkdownNodesRequest) String() string {
+func (x *ImageReference) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*RestoreMarkdownNodesRequest) ProtoMessage() {}
+func (*ImageReference) ProtoMessage() {}
 
-func (x *RestoreMarkdownNodesRequest) ProtoReflect() protoreflect.Message {
+func (x *ImageReference) ProtoReflect() protoreflect.Message {
 	mi := &file_api_v1_markdown_service_proto_msgTypes[2]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -332,39 +859,59 @@ func (x *RestoreMarkdownNodesRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use RestoreMarkdownNodesRequest.ProtoReflect.Descriptor instead.
-func (*RestoreMarkdownNodesRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use ImageReference.ProtoReflect.Descriptor instead.
+func (*ImageReference) Descriptor() ([]byte, []int) {
 	return file_api_v1_markdown_service_proto_rawDescGZIP(), []int{2}
 }
 
-func (x *RestoreMarkdownNodesRequest) GetNodes() []*Node {
+func (x *ImageReference) GetUrl() string {
 	if x != nil {
-		return x.Nodes
+		return x.Url
 	}
-	return nil
-}
-
-type RestoreMarkdownNodesResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Markdown      string                 `protobuf:"bytes,1,opt,name=markdown,proto3" json:"markdown,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	return ""
 }
 
-func (x *RestoreMarkdownNodesResponse) Reset() {
-	*x = RestoreMarkdownNodesResponse{}
+func (x *ImageReference) GetAltText() string {
+	if x != nil {
+		return x.AltText
+	}
+	return ""
+}
+
+func (x *ImageReference) GetIsRelative() bool {
+	if x != nil {
+		return x.IsRelative
+	}
+	return false
+}
+
+type BatchParseMarkdownRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The markdown contents to parse. At most 200 contents are allowed.
+	// An empty list returns an empty list of results.
+	Markdowns []string `protobuf:"bytes,1,rep,name=markdowns,proto3" json:"markdowns,omitempty"`
+	// auto_link_www detects bare www.-prefixed hosts as auto links, see ParseMarkdownRequest.
+	AutoLinkWww bool `protobuf:"varint,2,opt,name=auto_link_www,json=autoLinkWww,proto3" json:"auto_link_www,omitempty"`
+	// math parses math, see ParseMarkdownRequest.
+	Math          bool `protobuf:"varint,3,opt,name=math,proto3" json:"math,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BatchParseMarkdownRequest) Reset() {
+	*x = BatchParseMarkdownRequest{}
 	mi := &file_api_v1_markdown_service_proto_msgTypes[3]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *RestoreMarkdownNodesResponse) String() string {
+func (x *BatchParseMarkdownRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*RestoreMarkdownNodesResponse) ProtoMessage() {}
+func (*BatchParseMarkdownRequest) ProtoMessage() {}
 
-func (x *RestoreMarkdownNodesResponse) ProtoReflect() protoreflect.Message {
+func (x *BatchParseMarkdownRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_api_v1_markdown_service_proto_msgTypes[3]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -376,39 +923,54 @@ func (x *RestoreMarkdownNodesResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use RestoreMarkdownNodesResponse.ProtoReflect.Descriptor instead.
-func (*RestoreMarkdownNodesResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use BatchParseMarkdownRequest.ProtoReflect.Descriptor instead.
+func (*BatchParseMarkdownRequest) Descriptor() ([]byte, []int) {
 	return file_api_v1_markdown_service_proto_rawDescGZIP(), []int{3}
 }
 
-func (x *RestoreMarkdownNodesResponse) GetMarkdown() string {
+func (x *BatchParseMarkdownRequest) GetMarkdowns() []string {
 	if x != nil {
-		return x.Markdown
+		return x.Markdowns
 	}
-	return ""
+	return nil
 }
 
-type StringifyMarkdownNodesRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Nodes         []*Node                `protobuf:"bytes,1,rep,name=nodes,proto3" json:"nodes,omitempty"`
+func (x *BatchParseMarkdownRequest) GetAutoLinkWww() bool {
+	if x != nil {
+		return x.AutoLinkWww
+	}
+	return false
+}
+
+func (x *BatchParseMarkdownRequest) GetMath() bool {
+	if x != nil {
+		return x.Math
+	}
+	return false
+}
+
+type BatchParseMarkdownResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The results in the same order as the requested markdown contents.
+	Results       []*BatchParseMarkdownResponse_Result `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *StringifyMarkdownNodesRequest) Reset() {
-	*x = StringifyMarkdownNodesRequest{}
+func (x *BatchParseMarkdownResponse) Reset() {
+	*x = BatchParseMarkdownResponse{}
 	mi := &file_api_v1_markdown_service_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *StringifyMarkdownNodesRequest) String() string {
+func (x *BatchParseMarkdownResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*StringifyMarkdownNodesRequest) ProtoMessage() {}
+func (*BatchParseMarkdownResponse) ProtoMessage() {}
 
-func (x *StringifyMarkdownNodesRequest) ProtoReflect() protoreflect.Message {
+func (x *BatchParseMarkdownResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_api_v1_markdown_service_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -420,39 +982,42 @@ func (x *StringifyMarkdownNodesRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use StringifyMarkdownNodesRequest.ProtoReflect.Descriptor instead.
-func (*StringifyMarkdownNodesRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use BatchParseMarkdownResponse.ProtoReflect.Descriptor instead.
+func (*BatchParseMarkdownResponse) Descriptor() ([]byte, []int) {
 	return file_api_v1_markdown_service_proto_rawDescGZIP(), []int{4}
 }
 
-func (x *StringifyMarkdownNodesRequest) GetNodes() []*Node {
+func (x *BatchParseMarkdownResponse) GetResults() []*BatchParseMarkdownResponse_Result {
 	if x != nil {
-		return x.Nodes
+		return x.Results
 	}
 	return nil
 }
 
-type StringifyMarkdownNodesResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	PlainText     string                 `protobuf:"bytes,1,opt,name=plain_text,json=plainText,proto3" json:"plain_text,omitempty"`
+type RestoreMarkdownNodesRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Nodes []*Node                `protobuf:"bytes,1,rep,name=nodes,proto3" json:"nodes,omitempty"`
+	// Whether to reject malformed nodes, e.g. table rows whose cell count differs from the header
+	// or links without URL, instead of restoring them as is.
+	Strict        bool `protobuf:"varint,2,opt,name=strict,proto3" json:"strict,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *StringifyMarkdownNodesResponse) Reset() {
-	*x = StringifyMarkdownNodesResponse{}
+func (x *RestoreMarkdownNodesRequest) Reset() {
+	*x = RestoreMarkdownNodesRequest{}
 	mi := &file_api_v1_markdown_service_proto_msgTypes[5]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *StringifyMarkdownNodesResponse) String() string {
+func (x *RestoreMarkdownNodesRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*StringifyMarkdownNodesResponse) ProtoMessage() {}
+func (*RestoreMarkdownNodesRequest) ProtoMessage() {}
 
-func (x *StringifyMarkdownNodesResponse) ProtoReflect() protoreflect.Message {
+func (x *RestoreMarkdownNodesRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_api_v1_markdown_service_proto_msgTypes[5]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -464,39 +1029,46 @@ func (x *StringifyMarkdownNodesResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use StringifyMarkdownNodesResponse.ProtoReflect.Descriptor instead.
-func (*StringifyMarkdownNodesResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use RestoreMarkdownNodesRequest.ProtoReflect.Descriptor instead.
+func (*RestoreMarkdownNodesRequest) Descriptor() ([]byte, []int) {
 	return file_api_v1_markdown_service_proto_rawDescGZIP(), []int{5}
 }
 
-func (x *StringifyMarkdownNodesResponse) GetPlainText() string {
+func (x *RestoreMarkdownNodesRequest) GetNodes() []*Node {
 	if x != nil {
-		return x.PlainText
+		return x.Nodes
 	}
-	return ""
+	return nil
 }
 
-type GetLinkMetadataRequest struct {
+func (x *RestoreMarkdownNodesRequest) GetStrict() bool {
+	if x != nil {
+		return x.Strict
+	}
+	return false
+}
+
+type RestoreMarkdownNodesResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Link          string                 `protobuf:"bytes,1,opt,name=link,proto3" json:"link,omitempty"`
+	Markdown      string                 `protobuf:"bytes,1,opt,name=markdown,proto3" json:"markdown,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetLinkMetadataRequest) Reset() {
-	*x = GetLinkMetadataRequest{}
+func (x *RestoreMarkdownNodesResponse) Reset() {
+	*x = RestoreMarkdownNodesResponse{}
 	mi := &file_api_v1_markdown_service_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetLinkMetadataRequest) String() string {
+func (x *RestoreMarkdownNodesResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetLinkMetadataRequest) ProtoMessage() {}
+func (*RestoreMarkdownNodesResponse) ProtoMessage() {}
 
-func (x *GetLinkMetadataRequest) ProtoReflect() protoreflect.Message {
+func (x *RestoreMarkdownNodesResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_api_v1_markdown_service_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -508,41 +1080,51 @@ func (x *GetLinkMetadataRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetLinkMetadataRequest.ProtoReflect.Descriptor instead.
-func (*GetLinkMetadataRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use RestoreMarkdownNodesResponse.ProtoReflect.Descriptor instead.
+func (*RestoreMarkdownNodesResponse) Descriptor() ([]byte, []int) {
 	return file_api_v1_markdown_service_proto_rawDescGZIP(), []int{6}
 }
 
-func (x *GetLinkMetadataRequest) GetLink() string {
+func (x *RestoreMarkdownNodesResponse) GetMarkdown() string {
 	if x != nil {
-		return x.Link
+		return x.Markdown
 	}
 	return ""
 }
 
-type LinkMetadata struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Title         string                 `protobuf:"bytes,1,opt,name=title,proto3" json:"title,omitempty"`
-	Description   string                 `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
-	Image         string                 `protobuf:"bytes,3,opt,name=image,proto3" json:"image,omitempty"`
+type StringifyMarkdownNodesRequest struct {
+	state protoimpl.MessageState             `protogen:"open.v1"`
+	Nodes []*Node                            `protobuf:"bytes,1,rep,name=nodes,proto3" json:"nodes,omitempty"`
+	Mode  StringifyMarkdownNodesRequest_Mode `protobuf:"varint,2,opt,name=mode,proto3,enum=memos.api.v1.StringifyMarkdownNodesRequest_Mode" json:"mode,omitempty"`
+	// wrap_width hard-wraps the text of paragraphs at the given column, e.g. for plain-text emails.
+	// Words are kept whole and links, images and other inline nodes are never broken, while code
+	// blocks and tables are left as is. CJK characters count as two columns. 0 means no wrapping.
+	WrapWidth int32 `protobuf:"varint,3,opt,name=wrap_width,json=wrapWidth,proto3" json:"wrap_width,omitempty"`
+	// link_mode rewrites the URLs of links and images against base_url. Fragment-only URLs like
+	// "#section" point into the document itself and are never rewritten, and neither are auto links.
+	LinkMode StringifyMarkdownNodesRequest_LinkMode `protobuf:"varint,4,opt,name=link_mode,json=linkMode,proto3,enum=memos.api.v1.StringifyMarkdownNodesRequest_LinkMode" json:"link_mode,omitempty"`
+	// base_url is the absolute URL the links are resolved against, required unless link_mode is unspecified.
+	BaseUrl string `protobuf:"bytes,5,opt,name=base_url,json=baseUrl,proto3" json:"base_url,omitempty"`
+	// emoji_unicode writes EMOJI nodes as their unicode, e.g. "😄", instead of their shortcode.
+	EmojiUnicode  bool `protobuf:"varint,6,opt,name=emoji_unicode,json=emojiUnicode,proto3" json:"emoji_unicode,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *LinkMetadata) Reset() {
-	*x = LinkMetadata{}
+func (x *StringifyMarkdownNodesRequest) Reset() {
+	*x = StringifyMarkdownNodesRequest{}
 	mi := &file_api_v1_markdown_service_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *LinkMetadata) String() string {
+func (x *StringifyMarkdownNodesRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*LinkMetadata) ProtoMessage() {}
+func (*StringifyMarkdownNodesRequest) ProtoMessage() {}
 
-func (x *LinkMetadata) ProtoReflect() protoreflect.Message {
+func (x *StringifyMarkdownNodesRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_api_v1_markdown_service_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -554,87 +1136,76 @@ func (x *LinkMetadata) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use LinkMetadata.ProtoReflect.Descriptor instead.
-func (*LinkMetadata) Descriptor() ([]byte, []int) {
+// Deprecated: Use StringifyMarkdownNodesRequest.ProtoReflect.Descriptor instead.
+func (*StringifyMarkdownNodesRequest) Descriptor() ([]byte, []int) {
 	return file_api_v1_markdown_service_proto_rawDescGZIP(), []int{7}
 }
 
-func (x *LinkMetadata) GetTitle() string {
+func (x *StringifyMarkdownNodesRequest) GetNodes() []*Node {
 	if x != nil {
-		return x.Title
+		return x.Nodes
 	}
-	return ""
+	return nil
 }
 
-func (x *LinkMetadata) GetDescription() string {
+func (x *StringifyMarkdownNodesRequest) GetMode() StringifyMarkdownNodesRequest_Mode {
 	if x != nil {
-		return x.Description
+		return x.Mode
 	}
-	return ""
+	return StringifyMarkdownNodesRequest_MODE_UNSPECIFIED
 }
 
-func (x *LinkMetadata) GetImage() string {
+func (x *StringifyMarkdownNodesRequest) GetWrapWidth() int32 {
 	if x != nil {
-		return x.Image
+		return x.WrapWidth
+	}
+	return 0
+}
+
+func (x *StringifyMarkdownNodesRequest) GetLinkMode() StringifyMarkdownNodesRequest_LinkMode {
+	if x != nil {
+		return x.LinkMode
+	}
+	return StringifyMarkdownNodesRequest_LINK_MODE_UNSPECIFIED
+}
+
+func (x *StringifyMarkdownNodesRequest) GetBaseUrl() string {
+	if x != nil {
+		return x.BaseUrl
 	}
 	return ""
 }
 
-type Node struct {
-	state protoimpl.MessageState `protogen:"open.v1"`
-	Type  NodeType               `protobuf:"varint,1,opt,name=type,proto3,enum=memos.api.v1.NodeType" json:"type,omitempty"`
-	// Types that are valid to be assigned to Node:
-	//
-	//	*Node_LineBreakNode
-	//	*Node_ParagraphNode
-	//	*Node_CodeBlockNode
-	//	*Node_HeadingNode
-	//	*Node_HorizontalRuleNode
-	//	*Node_BlockquoteNode
-	//	*Node_ListNode
-	//	*Node_OrderedListItemNode
-	//	*Node_UnorderedListItemNode
-	//	*Node_TaskListItemNode
-	//	*Node_MathBlockNode
-	//	*Node_TableNode
-	//	*Node_EmbeddedContentNode
-	//	*Node_TextNode
-	//	*Node_BoldNode
-	//	*Node_ItalicNode
-	//	*Node_BoldItalicNode
-	//	*Node_CodeNode
-	//	*Node_ImageNode
-	//	*Node_LinkNode
-	//	*Node_AutoLinkNode
-	//	*Node_TagNode
-	//	*Node_StrikethroughNode
-	//	*Node_EscapingCharacterNode
-	//	*Node_MathNode
-	//	*Node_HighlightNode
-	//	*Node_SubscriptNode
-	//	*Node_SuperscriptNode
-	//	*Node_ReferencedContentNode
-	//	*Node_SpoilerNode
-	//	*Node_HtmlElementNode
-	Node          isNode_Node `protobuf_oneof:"node"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+func (x *StringifyMarkdownNodesRequest) GetEmojiUnicode() bool {
+	if x != nil {
+		return x.EmojiUnicode
+	}
+	return false
 }
 
-func (x *Node) Reset() {
-	*x = Node{}
+type StringifyMarkdownNodesResponse struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	PlainText string                 `protobuf:"bytes,1,opt,name=plain_text,json=plainText,proto3" json:"plain_text,omitempty"`
+	// The table of contents of the top-level headings, only set in the TABLE_OF_CONTENTS mode.
+	TableOfContents []*TableOfContentsEntry `protobuf:"bytes,2,rep,name=table_of_contents,json=tableOfContents,proto3" json:"table_of_contents,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *StringifyMarkdownNodesResponse) Reset() {
+	*x = StringifyMarkdownNodesResponse{}
 	mi := &file_api_v1_markdown_service_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *Node) String() string {
+func (x *StringifyMarkdownNodesResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Node) ProtoMessage() {}
+func (*StringifyMarkdownNodesResponse) ProtoMessage() {}
 
-func (x *Node) ProtoReflect() protoreflect.Message {
+func (x *StringifyMarkdownNodesResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_api_v1_markdown_service_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -646,517 +1217,737 @@ func (x *Node) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Node.ProtoReflect.Descriptor instead.
-func (*Node) Descriptor() ([]byte, []int) {
+// Deprecated: Use StringifyMarkdownNodesResponse.ProtoReflect.Descriptor instead.
+func (*StringifyMarkdownNodesResponse) Descriptor() ([]byte, []int) {
 	return file_api_v1_markdown_service_proto_rawDescGZIP(), []int{8}
 }
 
-func (x *Node) GetType() NodeType {
+func (x *StringifyMarkdownNodesResponse) GetPlainText() string {
 	if x != nil {
-		return x.Type
+		return x.PlainText
 	}
-	return NodeType_NODE_UNSPECIFIED
+	return ""
 }
 
-func (x *Node) GetNode() isNode_Node {
+func (x *StringifyMarkdownNodesResponse) GetTableOfContents() []*TableOfContentsEntry {
 	if x != nil {
-		return x.Node
+		return x.TableOfContents
 	}
 	return nil
 }
 
-func (x *Node) GetLineBreakNode() *LineBreakNode {
+type TableOfContentsEntry struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The plain text of the heading.
+	Title string `protobuf:"bytes,1,opt,name=title,proto3" json:"title,omitempty"`
+	Level int32  `protobuf:"varint,2,opt,name=level,proto3" json:"level,omitempty"`
+	// The anchor of the heading, which is also its id in the HTML of RenderMarkdownToHTML. Anchors are the
+	// lowercased text with spaces turned into hyphens and punctuation dropped, e.g. "hello-world" for
+	// "Hello, World!". Repeated anchors get numeric suffixes, e.g. "hello-world-1" and "hello-world-2".
+	Anchor string `protobuf:"bytes,3,opt,name=anchor,proto3" json:"anchor,omitempty"`
+	// The entries of the subheadings, i.e. the following headings of a higher level up to the next
+	// heading of the same or a lower level.
+	Children      []*TableOfContentsEntry `protobuf:"bytes,4,rep,name=children,proto3" json:"children,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TableOfContentsEntry) Reset() {
+	*x = TableOfContentsEntry{}
+	mi := &file_api_v1_markdown_service_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TableOfContentsEntry) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TableOfContentsEntry) ProtoMessage() {}
+
+func (x *TableOfContentsEntry) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_markdown_service_proto_msgTypes[9]
 	if x != nil {
-		if x, ok := x.Node.(*Node_LineBreakNode); ok {
-			return x.LineBreakNode
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
 		}
+		return ms
 	}
-	return nil
+	return mi.MessageOf(x)
 }
 
-func (x *Node) GetParagraphNode() *ParagraphNode {
+// Deprecated: Use TableOfContentsEntry.ProtoReflect.Descriptor instead.
+func (*TableOfContentsEntry) Descriptor() ([]byte, []int) {
+	return file_api_v1_markdown_service_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *TableOfContentsEntry) GetTitle() string {
 	if x != nil {
-		if x, ok := x.Node.(*Node_ParagraphNode); ok {
-			return x.ParagraphNode
-		}
+		return x.Title
 	}
-	return nil
+	return ""
 }
 
-func (x *Node) GetCodeBlockNode() *CodeBlockNode {
+func (x *TableOfContentsEntry) GetLevel() int32 {
 	if x != nil {
-		if x, ok := x.Node.(*Node_CodeBlockNode); ok {
-			return x.CodeBlockNode
-		}
+		return x.Level
 	}
-	return nil
+	return 0
 }
 
-func (x *Node) GetHeadingNode() *HeadingNode {
+func (x *TableOfContentsEntry) GetAnchor() string {
 	if x != nil {
-		if x, ok := x.Node.(*Node_HeadingNode); ok {
-			return x.HeadingNode
-		}
+		return x.Anchor
 	}
-	return nil
+	return ""
 }
 
-func (x *Node) GetHorizontalRuleNode() *HorizontalRuleNode {
+func (x *TableOfContentsEntry) GetChildren() []*TableOfContentsEntry {
 	if x != nil {
-		if x, ok := x.Node.(*Node_HorizontalRuleNode); ok {
-			return x.HorizontalRuleNode
-		}
+		return x.Children
 	}
 	return nil
 }
 
-func (x *Node) GetBlockquoteNode() *BlockquoteNode {
-	if x != nil {
-		if x, ok := x.Node.(*Node_BlockquoteNode); ok {
-			return x.BlockquoteNode
-		}
-	}
-	return nil
+type DiffMarkdownNodesRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The nodes of the old version.
+	OldNodes []*Node `protobuf:"bytes,1,rep,name=old_nodes,json=oldNodes,proto3" json:"old_nodes,omitempty"`
+	// The nodes of the new version.
+	NewNodes []*Node `protobuf:"bytes,2,rep,name=new_nodes,json=newNodes,proto3" json:"new_nodes,omitempty"`
+	// The markdown of the old version, which is parsed when old_nodes is empty.
+	OldMarkdown string `protobuf:"bytes,3,opt,name=old_markdown,json=oldMarkdown,proto3" json:"old_markdown,omitempty"`
+	// The markdown of the new version, which is parsed when new_nodes is empty.
+	NewMarkdown   string `protobuf:"bytes,4,opt,name=new_markdown,json=newMarkdown,proto3" json:"new_markdown,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *Node) GetListNode() *ListNode {
-	if x != nil {
-		if x, ok := x.Node.(*Node_ListNode); ok {
-			return x.ListNode
-		}
-	}
-	return nil
+func (x *DiffMarkdownNodesRequest) Reset() {
+	*x = DiffMarkdownNodesRequest{}
+	mi := &file_api_v1_markdown_service_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
-func (x *Node) GetOrderedListItemNode() *OrderedListItemNode {
-	if x != nil {
-		if x, ok := x.Node.(*Node_OrderedListItemNode); ok {
-			return x.OrderedListItemNode
-		}
-	}
-	return nil
+func (x *DiffMarkdownNodesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
 
-func (x *Node) GetUnorderedListItemNode() *UnorderedListItemNode {
+func (*DiffMarkdownNodesRequest) ProtoMessage() {}
+
+func (x *DiffMarkdownNodesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_markdown_service_proto_msgTypes[10]
 	if x != nil {
-		if x, ok := x.Node.(*Node_UnorderedListItemNode); ok {
-			return x.UnorderedListItemNode
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
 		}
+		return ms
 	}
-	return nil
+	return mi.MessageOf(x)
 }
 
-func (x *Node) GetTaskListItemNode() *TaskListItemNode {
-	if x != nil {
-		if x, ok := x.Node.(*Node_TaskListItemNode); ok {
-			return x.TaskListItemNode
-		}
-	}
-	return nil
+// Deprecated: Use DiffMarkdownNodesRequest.ProtoReflect.Descriptor instead.
+func (*DiffMarkdownNodesRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_markdown_service_proto_rawDescGZIP(), []int{10}
 }
 
-func (x *Node) GetMathBlockNode() *MathBlockNode {
+func (x *DiffMarkdownNodesRequest) GetOldNodes() []*Node {
 	if x != nil {
-		if x, ok := x.Node.(*Node_MathBlockNode); ok {
-			return x.MathBlockNode
-		}
+		return x.OldNodes
 	}
 	return nil
 }
 
-func (x *Node) GetTableNode() *TableNode {
+func (x *DiffMarkdownNodesRequest) GetNewNodes() []*Node {
 	if x != nil {
-		if x, ok := x.Node.(*Node_TableNode); ok {
-			return x.TableNode
-		}
+		return x.NewNodes
 	}
 	return nil
 }
 
-func (x *Node) GetEmbeddedContentNode() *EmbeddedContentNode {
+func (x *DiffMarkdownNodesRequest) GetOldMarkdown() string {
 	if x != nil {
-		if x, ok := x.Node.(*Node_EmbeddedContentNode); ok {
-			return x.EmbeddedContentNode
-		}
+		return x.OldMarkdown
 	}
-	return nil
+	return ""
 }
 
-func (x *Node) GetTextNode() *TextNode {
+func (x *DiffMarkdownNodesRequest) GetNewMarkdown() string {
 	if x != nil {
-		if x, ok := x.Node.(*Node_TextNode); ok {
-			return x.TextNode
-		}
+		return x.NewMarkdown
 	}
-	return nil
+	return ""
 }
 
-func (x *Node) GetBoldNode() *BoldNode {
-	if x != nil {
-		if x, ok := x.Node.(*Node_BoldNode); ok {
-			return x.BoldNode
-		}
-	}
-	return nil
+type DiffMarkdownNodesResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The differences in document order. Line breaks between blocks are not compared.
+	Diffs         []*MarkdownNodeDiff `protobuf:"bytes,1,rep,name=diffs,proto3" json:"diffs,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *Node) GetItalicNode() *ItalicNode {
-	if x != nil {
-		if x, ok := x.Node.(*Node_ItalicNode); ok {
-			return x.ItalicNode
-		}
-	}
-	return nil
+func (x *DiffMarkdownNodesResponse) Reset() {
+	*x = DiffMarkdownNodesResponse{}
+	mi := &file_api_v1_markdown_service_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
-func (x *Node) GetBoldItalicNode() *BoldItalicNode {
-	if x != nil {
-		if x, ok := x.Node.(*Node_BoldItalicNode); ok {
-			return x.BoldItalicNode
-		}
-	}
-	return nil
+func (x *DiffMarkdownNodesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
 
-func (x *Node) GetCodeNode() *CodeNode {
+func (*DiffMarkdownNodesResponse) ProtoMessage() {}
+
+func (x *DiffMarkdownNodesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_markdown_service_proto_msgTypes[11]
 	if x != nil {
-		if x, ok := x.Node.(*Node_CodeNode); ok {
-			return x.CodeNode
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
 		}
+		return ms
 	}
-	return nil
+	return mi.MessageOf(x)
 }
 
-func (x *Node) GetImageNode() *ImageNode {
-	if x != nil {
-		if x, ok := x.Node.(*Node_ImageNode); ok {
-			return x.ImageNode
-		}
-	}
-	return nil
+// Deprecated: Use DiffMarkdownNodesResponse.ProtoReflect.Descriptor instead.
+func (*DiffMarkdownNodesResponse) Descriptor() ([]byte, []int) {
+	return file_api_v1_markdown_service_proto_rawDescGZIP(), []int{11}
 }
 
-func (x *Node) GetLinkNode() *LinkNode {
+func (x *DiffMarkdownNodesResponse) GetDiffs() []*MarkdownNodeDiff {
 	if x != nil {
-		if x, ok := x.Node.(*Node_LinkNode); ok {
-			return x.LinkNode
-		}
+		return x.Diffs
 	}
 	return nil
 }
 
-func (x *Node) GetAutoLinkNode() *AutoLinkNode {
-	if x != nil {
-		if x, ok := x.Node.(*Node_AutoLinkNode); ok {
-			return x.AutoLinkNode
-		}
-	}
-	return nil
+type MarkdownNodeDiff struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Type  MarkdownNodeDiff_Type  `protobuf:"varint,1,opt,name=type,proto3,enum=memos.api.v1.MarkdownNodeDiff_Type" json:"type,omitempty"`
+	// The indexes of the node in the old version from the top-level nodes down, e.g. [2, 1] for the
+	// second item of the list at index 2. Empty for added nodes.
+	OldPath []int32 `protobuf:"varint,2,rep,packed,name=old_path,json=oldPath,proto3" json:"old_path,omitempty"`
+	// The indexes of the node in the new version, see old_path. Empty for removed nodes.
+	NewPath       []int32 `protobuf:"varint,3,rep,packed,name=new_path,json=newPath,proto3" json:"new_path,omitempty"`
+	OldNode       *Node   `protobuf:"bytes,4,opt,name=old_node,json=oldNode,proto3" json:"old_node,omitempty"`
+	NewNode       *Node   `protobuf:"bytes,5,opt,name=new_node,json=newNode,proto3" json:"new_node,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *Node) GetTagNode() *TagNode {
-	if x != nil {
-		if x, ok := x.Node.(*Node_TagNode); ok {
-			return x.TagNode
-		}
-	}
-	return nil
+func (x *MarkdownNodeDiff) Reset() {
+	*x = MarkdownNodeDiff{}
+	mi := &file_api_v1_markdown_service_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
-func (x *Node) GetStrikethroughNode() *StrikethroughNode {
+func (x *MarkdownNodeDiff) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MarkdownNodeDiff) ProtoMessage() {}
+
+func (x *MarkdownNodeDiff) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_markdown_service_proto_msgTypes[12]
 	if x != nil {
-		if x, ok := x.Node.(*Node_StrikethroughNode); ok {
-			return x.StrikethroughNode
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
 		}
+		return ms
 	}
-	return nil
+	return mi.MessageOf(x)
 }
 
-func (x *Node) GetEscapingCharacterNode() *EscapingCharacterNode {
+// Deprecated: Use MarkdownNodeDiff.ProtoReflect.Descriptor instead.
+func (*MarkdownNodeDiff) Descriptor() ([]byte, []int) {
+	return file_api_v1_markdown_service_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *MarkdownNodeDiff) GetType() MarkdownNodeDiff_Type {
 	if x != nil {
-		if x, ok := x.Node.(*Node_EscapingCharacterNode); ok {
-			return x.EscapingCharacterNode
-		}
+		return x.Type
 	}
-	return nil
+	return MarkdownNodeDiff_TYPE_UNSPECIFIED
 }
 
-func (x *Node) GetMathNode() *MathNode {
+func (x *MarkdownNodeDiff) GetOldPath() []int32 {
 	if x != nil {
-		if x, ok := x.Node.(*Node_MathNode); ok {
-			return x.MathNode
-		}
+		return x.OldPath
 	}
 	return nil
 }
 
-func (x *Node) GetHighlightNode() *HighlightNode {
+func (x *MarkdownNodeDiff) GetNewPath() []int32 {
 	if x != nil {
-		if x, ok := x.Node.(*Node_HighlightNode); ok {
-			return x.HighlightNode
-		}
+		return x.NewPath
 	}
 	return nil
 }
 
-func (x *Node) GetSubscriptNode() *SubscriptNode {
+func (x *MarkdownNodeDiff) GetOldNode() *Node {
 	if x != nil {
-		if x, ok := x.Node.(*Node_SubscriptNode); ok {
-			return x.SubscriptNode
-		}
+		return x.OldNode
 	}
 	return nil
 }
 
-func (x *Node) GetSuperscriptNode() *SuperscriptNode {
+func (x *MarkdownNodeDiff) GetNewNode() *Node {
 	if x != nil {
-		if x, ok := x.Node.(*Node_SuperscriptNode); ok {
-			return x.SuperscriptNode
-		}
+		return x.NewNode
 	}
 	return nil
 }
 
-func (x *Node) GetReferencedContentNode() *ReferencedContentNode {
+type RenderMarkdownToHTMLRequest struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Markdown string                 `protobuf:"bytes,1,opt,name=markdown,proto3" json:"markdown,omitempty"`
+	// auto_link_www detects bare www.-prefixed hosts as auto links, see ParseMarkdownRequest.
+	AutoLinkWww   bool `protobuf:"varint,2,opt,name=auto_link_www,json=autoLinkWww,proto3" json:"auto_link_www,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RenderMarkdownToHTMLRequest) Reset() {
+	*x = RenderMarkdownToHTMLRequest{}
+	mi := &file_api_v1_markdown_service_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RenderMarkdownToHTMLRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RenderMarkdownToHTMLRequest) ProtoMessage() {}
+
+func (x *RenderMarkdownToHTMLRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_markdown_service_proto_msgTypes[13]
 	if x != nil {
-		if x, ok := x.Node.(*Node_ReferencedContentNode); ok {
-			return x.ReferencedContentNode
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
 		}
+		return ms
 	}
-	return nil
+	return mi.MessageOf(x)
 }
 
-func (x *Node) GetSpoilerNode() *SpoilerNode {
+// Deprecated: Use RenderMarkdownToHTMLRequest.ProtoReflect.Descriptor instead.
+func (*RenderMarkdownToHTMLRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_markdown_service_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *RenderMarkdownToHTMLRequest) GetMarkdown() string {
 	if x != nil {
-		if x, ok := x.Node.(*Node_SpoilerNode); ok {
-			return x.SpoilerNode
-		}
+		return x.Markdown
 	}
-	return nil
+	return ""
 }
 
-func (x *Node) GetHtmlElementNode() *HTMLElementNode {
+func (x *RenderMarkdownToHTMLRequest) GetAutoLinkWww() bool {
 	if x != nil {
-		if x, ok := x.Node.(*Node_HtmlElementNode); ok {
-			return x.HtmlElementNode
-		}
+		return x.AutoLinkWww
 	}
-	return nil
+	return false
 }
 
-type isNode_Node interface {
-	isNode_Node()
+type RenderMarkdownToHTMLResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The HTML escapes all content of the markdown, including inline HTML, and only holds
+	// links and images with http, https, mailto or relative URLs.
+	Html          string `protobuf:"bytes,1,opt,name=html,proto3" json:"html,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-type Node_LineBreakNode struct {
-	// Block nodes.
-	LineBreakNode *LineBreakNode `protobuf:"bytes,11,opt,name=line_break_node,json=lineBreakNode,proto3,oneof"`
+func (x *RenderMarkdownToHTMLResponse) Reset() {
+	*x = RenderMarkdownToHTMLResponse{}
+	mi := &file_api_v1_markdown_service_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
-type Node_ParagraphNode struct {
-	ParagraphNode *ParagraphNode `protobuf:"bytes,12,opt,name=paragraph_node,json=paragraphNode,proto3,oneof"`
+func (x *RenderMarkdownToHTMLResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
 
-type Node_CodeBlockNode struct {
-	CodeBlockNode *CodeBlockNode `protobuf:"bytes,13,opt,name=code_block_node,json=codeBlockNode,proto3,oneof"`
+func (*RenderMarkdownToHTMLResponse) ProtoMessage() {}
+
+func (x *RenderMarkdownToHTMLResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_markdown_service_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
 }
 
-type Node_HeadingNode struct {
-	HeadingNode *HeadingNode `protobuf:"bytes,14,opt,name=heading_node,json=headingNode,proto3,oneof"`
+// Deprecated: Use RenderMarkdownToHTMLResponse.ProtoReflect.Descriptor instead.
+func (*RenderMarkdownToHTMLResponse) Descriptor() ([]byte, []int) {
+	return file_api_v1_markdown_service_proto_rawDescGZIP(), []int{14}
 }
 
-type Node_HorizontalRuleNode struct {
-	HorizontalRuleNode *HorizontalRuleNode `protobuf:"bytes,15,opt,name=horizontal_rule_node,json=horizontalRuleNode,proto3,oneof"`
+func (x *RenderMarkdownToHTMLResponse) GetHtml() string {
+	if x != nil {
+		return x.Html
+	}
+	return ""
 }
 
-type Node_BlockquoteNode struct {
-	BlockquoteNode *BlockquoteNode `protobuf:"bytes,16,opt,name=blockquote_node,json=blockquoteNode,proto3,oneof"`
+type GetMarkdownStatsRequest struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Markdown string                 `protobuf:"bytes,1,opt,name=markdown,proto3" json:"markdown,omitempty"`
+	// Whether to count the content of code blocks, which is left out by default.
+	IncludeCodeBlocks bool `protobuf:"varint,2,opt,name=include_code_blocks,json=includeCodeBlocks,proto3" json:"include_code_blocks,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
 }
 
-type Node_ListNode struct {
-	ListNode *ListNode `protobuf:"bytes,17,opt,name=list_node,json=listNode,proto3,oneof"`
+func (x *GetMarkdownStatsRequest) Reset() {
+	*x = GetMarkdownStatsRequest{}
+	mi := &file_api_v1_markdown_service_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
-type Node_OrderedListItemNode struct {
-	OrderedListItemNode *OrderedListItemNode `protobuf:"bytes,18,opt,name=ordered_list_item_node,json=orderedListItemNode,proto3,oneof"`
+func (x *GetMarkdownStatsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
 
-type Node_UnorderedListItemNode struct {
-	UnorderedListItemNode *UnorderedListItemNode `protobuf:"bytes,19,opt,name=unordered_list_item_node,json=unorderedListItemNode,proto3,oneof"`
-}
+func (*GetMarkdownStatsRequest) ProtoMessage() {}
 
-type Node_TaskListItemNode struct {
-	TaskListItemNode *TaskListItemNode `protobuf:"bytes,20,opt,name=task_list_item_node,json=taskListItemNode,proto3,oneof"`
+func (x *GetMarkdownStatsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_markdown_service_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
 }
 
-type Node_MathBlockNode struct {
-	MathBlockNode *MathBlockNode `protobuf:"bytes,21,opt,name=math_block_node,json=mathBlockNode,proto3,oneof"`
+// Deprecated: Use GetMarkdownStatsRequest.ProtoReflect.Descriptor instead.
+func (*GetMarkdownStatsRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_markdown_service_proto_rawDescGZIP(), []int{15}
 }
 
-type Node_TableNode struct {
-	TableNode *TableNode `protobuf:"bytes,22,opt,name=table_node,json=tableNode,proto3,oneof"`
+func (x *GetMarkdownStatsRequest) GetMarkdown() string {
+	if x != nil {
+		return x.Markdown
+	}
+	return ""
 }
 
-type Node_EmbeddedContentNode struct {
-	EmbeddedContentNode *EmbeddedContentNode `protobuf:"bytes,23,opt,name=embedded_content_node,json=embeddedContentNode,proto3,oneof"`
+func (x *GetMarkdownStatsRequest) GetIncludeCodeBlocks() bool {
+	if x != nil {
+		return x.IncludeCodeBlocks
+	}
+	return false
 }
 
-type Node_TextNode struct {
-	// Inline nodes.
-	TextNode *TextNode `protobuf:"bytes,51,opt,name=text_node,json=textNode,proto3,oneof"`
+type MarkdownStats struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The number of words of the text. Each CJK character counts as a word on its own.
+	WordCount int32 `protobuf:"varint,1,opt,name=word_count,json=wordCount,proto3" json:"word_count,omitempty"`
+	// The number of characters of the text, excluding markdown syntax and whitespace.
+	CharacterCount int32 `protobuf:"varint,2,opt,name=character_count,json=characterCount,proto3" json:"character_count,omitempty"`
+	// The estimated reading time in minutes, rounded up. Empty text takes 0 minutes.
+	ReadingTimeMinutes int32 `protobuf:"varint,3,opt,name=reading_time_minutes,json=readingTimeMinutes,proto3" json:"reading_time_minutes,omitempty"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
+}
+
+func (x *MarkdownStats) Reset() {
+	*x = MarkdownStats{}
+	mi := &file_api_v1_markdown_service_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
-type Node_BoldNode struct {
-	BoldNode *BoldNode `protobuf:"bytes,52,opt,name=bold_node,json=boldNode,proto3,oneof"`
+func (x *MarkdownStats) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
 
-type Node_ItalicNode struct {
-	ItalicNode *ItalicNode `protobuf:"bytes,53,opt,name=italic_node,json=italicNode,proto3,oneof"`
-}
+func (*MarkdownStats) ProtoMessage() {}
 
-type Node_BoldItalicNode struct {
-	BoldItalicNode *BoldItalicNode `protobuf:"bytes,54,opt,name=bold_italic_node,json=boldItalicNode,proto3,oneof"`
+func (x *MarkdownStats) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_markdown_service_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
 }
 
-type Node_CodeNode struct {
-	CodeNode *CodeNode `protobuf:"bytes,55,opt,name=code_node,json=codeNode,proto3,oneof"`
+// Deprecated: Use MarkdownStats.ProtoReflect.Descriptor instead.
+func (*MarkdownStats) Descriptor() ([]byte, []int) {
+	return file_api_v1_markdown_service_proto_rawDescGZIP(), []int{16}
 }
 
-type Node_ImageNode struct {
-	ImageNode *ImageNode `protobuf:"bytes,56,opt,name=image_node,json=imageNode,proto3,oneof"`
+func (x *MarkdownStats) GetWordCount() int32 {
+	if x != nil {
+		return x.WordCount
+	}
+	return 0
 }
 
-type Node_LinkNode struct {
-	LinkNode *LinkNode `protobuf:"bytes,57,opt,name=link_node,json=linkNode,proto3,oneof"`
+func (x *MarkdownStats) GetCharacterCount() int32 {
+	if x != nil {
+		return x.CharacterCount
+	}
+	return 0
 }
 
-type Node_AutoLinkNode struct {
-	AutoLinkNode *AutoLinkNode `protobuf:"bytes,58,opt,name=auto_link_node,json=autoLinkNode,proto3,oneof"`
+func (x *MarkdownStats) GetReadingTimeMinutes() int32 {
+	if x != nil {
+		return x.ReadingTimeMinutes
+	}
+	return 0
 }
 
-type Node_TagNode struct {
-	TagNode *TagNode `protobuf:"bytes,59,opt,name=tag_node,json=tagNode,proto3,oneof"`
+type LintMarkdownRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Markdown      string                 `protobuf:"bytes,1,opt,name=markdown,proto3" json:"markdown,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-type Node_StrikethroughNode struct {
-	StrikethroughNode *StrikethroughNode `protobuf:"bytes,60,opt,name=strikethrough_node,json=strikethroughNode,proto3,oneof"`
+func (x *LintMarkdownRequest) Reset() {
+	*x = LintMarkdownRequest{}
+	mi := &file_api_v1_markdown_service_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
-type Node_EscapingCharacterNode struct {
-	EscapingCharacterNode *EscapingCharacterNode `protobuf:"bytes,61,opt,name=escaping_character_node,json=escapingCharacterNode,proto3,oneof"`
+func (x *LintMarkdownRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
 
-type Node_MathNode struct {
-	MathNode *MathNode `protobuf:"bytes,62,opt,name=math_node,json=mathNode,proto3,oneof"`
-}
+func (*LintMarkdownRequest) ProtoMessage() {}
 
-type Node_HighlightNode struct {
-	HighlightNode *HighlightNode `protobuf:"bytes,63,opt,name=highlight_node,json=highlightNode,proto3,oneof"`
+func (x *LintMarkdownRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_markdown_service_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
 }
 
-type Node_SubscriptNode struct {
-	SubscriptNode *SubscriptNode `protobuf:"bytes,64,opt,name=subscript_node,json=subscriptNode,proto3,oneof"`
+// Deprecated: Use LintMarkdownRequest.ProtoReflect.Descriptor instead.
+func (*LintMarkdownRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_markdown_service_proto_rawDescGZIP(), []int{17}
 }
 
-type Node_SuperscriptNode struct {
-	SuperscriptNode *SuperscriptNode `protobuf:"bytes,65,opt,name=superscript_node,json=superscriptNode,proto3,oneof"`
+func (x *LintMarkdownRequest) GetMarkdown() string {
+	if x != nil {
+		return x.Markdown
+	}
+	return ""
 }
 
-type Node_ReferencedContentNode struct {
-	ReferencedContentNode *ReferencedContentNode `protobuf:"bytes,66,opt,name=referenced_content_node,json=referencedContentNode,proto3,oneof"`
+type LintMarkdownResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The diagnostics in the order of their positions.
+	Diagnostics   []*MarkdownDiagnostic `protobuf:"bytes,1,rep,name=diagnostics,proto3" json:"diagnostics,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-type Node_SpoilerNode struct {
-	SpoilerNode *SpoilerNode `protobuf:"bytes,67,opt,name=spoiler_node,json=spoilerNode,proto3,oneof"`
+func (x *LintMarkdownResponse) Reset() {
+	*x = LintMarkdownResponse{}
+	mi := &file_api_v1_markdown_service_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
-type Node_HtmlElementNode struct {
-	HtmlElementNode *HTMLElementNode `protobuf:"bytes,68,opt,name=html_element_node,json=htmlElementNode,proto3,oneof"`
+func (x *LintMarkdownResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Node_LineBreakNode) isNode_Node() {}
-
-func (*Node_ParagraphNode) isNode_Node() {}
-
-func (*Node_CodeBlockNode) isNode_Node() {}
-
-func (*Node_HeadingNode) isNode_Node() {}
-
-func (*Node_HorizontalRuleNode) isNode_Node() {}
-
-func (*Node_BlockquoteNode) isNode_Node() {}
-
-func (*Node_ListNode) isNode_Node() {}
-
-func (*Node_OrderedListItemNode) isNode_Node() {}
-
-func (*Node_UnorderedListItemNode) isNode_Node() {}
-
-func (*Node_TaskListItemNode) isNode_Node() {}
-
-func (*Node_MathBlockNode) isNode_Node() {}
+func (*LintMarkdownResponse) ProtoMessage() {}
 
-func (*Node_TableNode) isNode_Node() {}
+func (x *LintMarkdownResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_markdown_service_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
 
-func (*Node_EmbeddedContentNode) isNode_Node() {}
+// Deprecated: Use LintMarkdownResponse.ProtoReflect.Descriptor instead.
+func (*LintMarkdownResponse) Descriptor() ([]byte, []int) {
+	return file_api_v1_markdown_service_proto_rawDescGZIP(), []int{18}
+}
 
-func (*Node_TextNode) isNode_Node() {}
+func (x *LintMarkdownResponse) GetDiagnostics() []*MarkdownDiagnostic {
+	if x != nil {
+		return x.Diagnostics
+	}
+	return nil
+}
 
-func (*Node_BoldNode) isNode_Node() {}
+type MarkdownDiagnostic struct {
+	state    protoimpl.MessageState      `protogen:"open.v1"`
+	Severity MarkdownDiagnostic_Severity `protobuf:"varint,1,opt,name=severity,proto3,enum=memos.api.v1.MarkdownDiagnostic_Severity" json:"severity,omitempty"`
+	Message  string                      `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	// The position of the problem in the markdown.
+	Position      *Position `protobuf:"bytes,3,opt,name=position,proto3" json:"position,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
 
-func (*Node_ItalicNode) isNode_Node() {}
+func (x *MarkdownDiagnostic) Reset() {
+	*x = MarkdownDiagnostic{}
+	mi := &file_api_v1_markdown_service_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
 
-func (*Node_BoldItalicNode) isNode_Node() {}
+func (x *MarkdownDiagnostic) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
 
-func (*Node_CodeNode) isNode_Node() {}
+func (*MarkdownDiagnostic) ProtoMessage() {}
 
-func (*Node_ImageNode) isNode_Node() {}
+func (x *MarkdownDiagnostic) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_markdown_service_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
 
-func (*Node_LinkNode) isNode_Node() {}
+// Deprecated: Use MarkdownDiagnostic.ProtoReflect.Descriptor instead.
+func (*MarkdownDiagnostic) Descriptor() ([]byte, []int) {
+	return file_api_v1_markdown_service_proto_rawDescGZIP(), []int{19}
+}
 
-func (*Node_AutoLinkNode) isNode_Node() {}
+func (x *MarkdownDiagnostic) GetSeverity() MarkdownDiagnostic_Severity {
+	if x != nil {
+		return x.Severity
+	}
+	return MarkdownDiagnostic_SEVERITY_UNSPECIFIED
+}
 
-func (*Node_TagNode) isNode_Node() {}
+func (x *MarkdownDiagnostic) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
 
-func (*Node_StrikethroughNode) isNode_Node() {}
+func (x *MarkdownDiagnostic) GetPosition() *Position {
+	if x != nil {
+		return x.Position
+	}
+	return nil
+}
 
-func (*Node_EscapingCharacterNode) isNode_Node() {}
+type NormalizeMarkdownRequest struct {
+	state         protoimpl.MessageState               `protogen:"open.v1"`
+	Markdown      string                               `protobuf:"bytes,1,opt,name=markdown,proto3" json:"markdown,omitempty"`
+	BulletStyle   NormalizeMarkdownRequest_BulletStyle `protobuf:"varint,2,opt,name=bullet_style,json=bulletStyle,proto3,enum=memos.api.v1.NormalizeMarkdownRequest_BulletStyle" json:"bullet_style,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
 
-func (*Node_MathNode) isNode_Node() {}
+func (x *NormalizeMarkdownRequest) Reset() {
+	*x = NormalizeMarkdownRequest{}
+	mi := &file_api_v1_markdown_service_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
 
-func (*Node_HighlightNode) isNode_Node() {}
+func (x *NormalizeMarkdownRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
 
-func (*Node_SubscriptNode) isNode_Node() {}
+func (*NormalizeMarkdownRequest) ProtoMessage() {}
 
-func (*Node_SuperscriptNode) isNode_Node() {}
+func (x *NormalizeMarkdownRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_markdown_service_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
 
-func (*Node_ReferencedContentNode) isNode_Node() {}
+// Deprecated: Use NormalizeMarkdownRequest.ProtoReflect.Descriptor instead.
+func (*NormalizeMarkdownRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_markdown_service_proto_rawDescGZIP(), []int{20}
+}
 
-func (*Node_SpoilerNode) isNode_Node() {}
+func (x *NormalizeMarkdownRequest) GetMarkdown() string {
+	if x != nil {
+		return x.Markdown
+	}
+	return ""
+}
 
-func (*Node_HtmlElementNode) isNode_Node() {}
+func (x *NormalizeMarkdownRequest) GetBulletStyle() NormalizeMarkdownRequest_BulletStyle {
+	if x != nil {
+		return x.BulletStyle
+	}
+	return NormalizeMarkdownRequest_BULLET_STYLE_UNSPECIFIED
+}
 
-type LineBreakNode struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
+type NormalizeMarkdownResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The markdown is parsed and restored, then the trailing whitespace of each line is trimmed and
+	// three or more blank lines in a row are collapsed to one. Non-empty markdown ends with a single
+	// newline. Code blocks are left as is.
+	Markdown      string `protobuf:"bytes,1,opt,name=markdown,proto3" json:"markdown,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *LineBreakNode) Reset() {
-	*x = LineBreakNode{}
-	mi := &file_api_v1_markdown_service_proto_msgTypes[9]
+func (x *NormalizeMarkdownResponse) Reset() {
+	*x = NormalizeMarkdownResponse{}
+	mi := &file_api_v1_markdown_service_proto_msgTypes[21]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *LineBreakNode) String() string {
+func (x *NormalizeMarkdownResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*LineBreakNode) ProtoMessage() {}
+func (*NormalizeMarkdownResponse) ProtoMessage() {}
 
-func (x *LineBreakNode) ProtoReflect() protoreflect.Message {
-	mi := &file_api_v1_markdown_service_proto_msgTypes[9]
+func (x *NormalizeMarkdownResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_markdown_service_proto_msgTypes[21]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1167,9 +1958,990 @@ func (x *LineBreakNode) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use LineBreakNode.ProtoReflect.Descriptor instead.
-func (*LineBreakNode) Descriptor() ([]byte, []int) {
-	return file_api_v1_markdown_service_proto_rawDescGZIP(), []int{9}
+// Deprecated: Use NormalizeMarkdownResponse.ProtoReflect.Descriptor instead.
+func (*NormalizeMarkdownResponse) Descriptor() ([]byte, []int) {
+	return file_api_v1_markdown_service_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *NormalizeMarkdownResponse) GetMarkdown() string {
+	if x != nil {
+		return x.Markdown
+	}
+	return ""
+}
+
+type GetLinkMetadataRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Link  string                 `protobuf:"bytes,1,opt,name=link,proto3" json:"link,omitempty"`
+	// Whether to discover and fetch the oEmbed data of the link, which costs another request.
+	Oembed bool `protobuf:"varint,2,opt,name=oembed,proto3" json:"oembed,omitempty"`
+	// The language to fetch the metadata in as a BCP 47 tag, e.g. "de-DE", which is sent as the Accept-Language
+	// header of the fetches. Defaults to the locale of the current user, then to the workspace setting.
+	// Invalid tags are ignored.
+	Language string `protobuf:"bytes,3,opt,name=language,proto3" json:"language,omitempty"`
+	// Whether to extract the main content of HTML pages as an article, e.g. for reading later. The fetch
+	// is subject to the same limits. Pages without main content have article_extraction_failed set instead.
+	Article       bool `protobuf:"varint,4,opt,name=article,proto3" json:"article,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetLinkMetadataRequest) Reset() {
+	*x = GetLinkMetadataRequest{}
+	mi := &file_api_v1_markdown_service_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetLinkMetadataRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetLinkMetadataRequest) ProtoMessage() {}
+
+func (x *GetLinkMetadataRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_markdown_service_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetLinkMetadataRequest.ProtoReflect.Descriptor instead.
+func (*GetLinkMetadataRequest) Descriptor() ([]byte, []int) {
+	return file_api_v1_markdown_service_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *GetLinkMetadataRequest) GetLink() string {
+	if x != nil {
+		return x.Link
+	}
+	return ""
+}
+
+func (x *GetLinkMetadataRequest) GetOembed() bool {
+	if x != nil {
+		return x.Oembed
+	}
+	return false
+}
+
+func (x *GetLinkMetadataRequest) GetLanguage() string {
+	if x != nil {
+		return x.Language
+	}
+	return ""
+}
+
+func (x *GetLinkMetadataRequest) GetArticle() bool {
+	if x != nil {
+		return x.Article
+	}
+	return false
+}
+
+type LinkMetadata struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Title       string                 `protobuf:"bytes,1,opt,name=title,proto3" json:"title,omitempty"`
+	Description string                 `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
+	Image       string                 `protobuf:"bytes,3,opt,name=image,proto3" json:"image,omitempty"`
+	// The absolute URL of the favicon, which falls back to /favicon.ico at the host of the page.
+	FaviconUrl string `protobuf:"bytes,4,opt,name=favicon_url,json=faviconUrl,proto3" json:"favicon_url,omitempty"`
+	// The absolute URL from the canonical link of the page, if any.
+	CanonicalUrl string `protobuf:"bytes,5,opt,name=canonical_url,json=canonicalUrl,proto3" json:"canonical_url,omitempty"`
+	// The oEmbed data of the page, only set when requested and discovered.
+	Oembed *LinkMetadata_OEmbed `protobuf:"bytes,6,opt,name=oembed,proto3" json:"oembed,omitempty"`
+	// The media type of the link, e.g. "text/html", "image/png" or "application/pdf".
+	// Images and PDF documents are titled by their URL unless a PDF has a title in its metadata.
+	MediaType string `protobuf:"bytes,7,opt,name=media_type,json=mediaType,proto3" json:"media_type,omitempty"`
+	// The dimensions of image links, if their format is known.
+	ImageWidth  int32 `protobuf:"varint,8,opt,name=image_width,json=imageWidth,proto3" json:"image_width,omitempty"`
+	ImageHeight int32 `protobuf:"varint,9,opt,name=image_height,json=imageHeight,proto3" json:"image_height,omitempty"`
+	// The main content of the page, only set when requested and extracted.
+	Article *LinkMetadata_Article `protobuf:"bytes,10,opt,name=article,proto3" json:"article,omitempty"`
+	// Whether an article was requested but could not be extracted, e.g. from pages with little text,
+	// images and PDF documents. The other fields are set as without the article.
+	ArticleExtractionFailed bool `protobuf:"varint,11,opt,name=article_extraction_failed,json=articleExtractionFailed,proto3" json:"article_extraction_failed,omitempty"`
+	unknownFields           protoimpl.UnknownFields
+	sizeCache               protoimpl.SizeCache
+}
+
+func (x *LinkMetadata) Reset() {
+	*x = LinkMetadata{}
+	mi := &file_api_v1_markdown_service_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LinkMetadata) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LinkMetadata) ProtoMessage() {}
+
+func (x *LinkMetadata) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_markdown_service_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LinkMetadata.ProtoReflect.Descriptor instead.
+func (*LinkMetadata) Descriptor() ([]byte, []int) {
+	return file_api_v1_markdown_service_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *LinkMetadata) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *LinkMetadata) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *LinkMetadata) GetImage() string {
+	if x != nil {
+		return x.Image
+	}
+	return ""
+}
+
+func (x *LinkMetadata) GetFaviconUrl() string {
+	if x != nil {
+		return x.FaviconUrl
+	}
+	return ""
+}
+
+func (x *LinkMetadata) GetCanonicalUrl() string {
+	if x != nil {
+		return x.CanonicalUrl
+	}
+	return ""
+}
+
+func (x *LinkMetadata) GetOembed() *LinkMetadata_OEmbed {
+	if x != nil {
+		return x.Oembed
+	}
+	return nil
+}
+
+func (x *LinkMetadata) GetMediaType() string {
+	if x != nil {
+		return x.MediaType
+	}
+	return ""
+}
+
+func (x *LinkMetadata) GetImageWidth() int32 {
+	if x != nil {
+		return x.ImageWidth
+	}
+	return 0
+}
+
+func (x *LinkMetadata) GetImageHeight() int32 {
+	if x != nil {
+		return x.ImageHeight
+	}
+	return 0
+}
+
+func (x *LinkMetadata) GetArticle() *LinkMetadata_Article {
+	if x != nil {
+		return x.Article
+	}
+	return nil
+}
+
+func (x *LinkMetadata) GetArticleExtractionFailed() bool {
+	if x != nil {
+		return x.ArticleExtractionFailed
+	}
+	return false
+}
+
+type Node struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Type  NodeType               `protobuf:"varint,1,opt,name=type,proto3,enum=memos.api.v1.NodeType" json:"type,omitempty"`
+	// The position of the node in the parsed markdown, only set when requested.
+	Position *Position `protobuf:"bytes,2,opt,name=position,proto3" json:"position,omitempty"`
+	// Types that are valid to be assigned to Node:
+	//
+	//	*Node_LineBreakNode
+	//	*Node_ParagraphNode
+	//	*Node_CodeBlockNode
+	//	*Node_HeadingNode
+	//	*Node_HorizontalRuleNode
+	//	*Node_BlockquoteNode
+	//	*Node_ListNode
+	//	*Node_OrderedListItemNode
+	//	*Node_UnorderedListItemNode
+	//	*Node_TaskListItemNode
+	//	*Node_MathBlockNode
+	//	*Node_TableNode
+	//	*Node_EmbeddedContentNode
+	//	*Node_FrontmatterNode
+	//	*Node_FootnoteDefNode
+	//	*Node_TextNode
+	//	*Node_BoldNode
+	//	*Node_ItalicNode
+	//	*Node_BoldItalicNode
+	//	*Node_CodeNode
+	//	*Node_ImageNode
+	//	*Node_LinkNode
+	//	*Node_AutoLinkNode
+	//	*Node_TagNode
+	//	*Node_StrikethroughNode
+	//	*Node_EscapingCharacterNode
+	//	*Node_MathNode
+	//	*Node_HighlightNode
+	//	*Node_SubscriptNode
+	//	*Node_SuperscriptNode
+	//	*Node_ReferencedContentNode
+	//	*Node_SpoilerNode
+	//	*Node_HtmlElementNode
+	//	*Node_EmojiNode
+	//	*Node_CustomNode
+	//	*Node_FootnoteRefNode
+	Node          isNode_Node `protobuf_oneof:"node"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Node) Reset() {
+	*x = Node{}
+	mi := &file_api_v1_markdown_service_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Node) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Node) ProtoMessage() {}
+
+func (x *Node) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_markdown_service_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Node.ProtoReflect.Descriptor instead.
+func (*Node) Descriptor() ([]byte, []int) {
+	return file_api_v1_markdown_service_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *Node) GetType() NodeType {
+	if x != nil {
+		return x.Type
+	}
+	return NodeType_NODE_UNSPECIFIED
+}
+
+func (x *Node) GetPosition() *Position {
+	if x != nil {
+		return x.Position
+	}
+	return nil
+}
+
+func (x *Node) GetNode() isNode_Node {
+	if x != nil {
+		return x.Node
+	}
+	return nil
+}
+
+func (x *Node) GetLineBreakNode() *LineBreakNode {
+	if x != nil {
+		if x, ok := x.Node.(*Node_LineBreakNode); ok {
+			return x.LineBreakNode
+		}
+	}
+	return nil
+}
+
+func (x *Node) GetParagraphNode() *ParagraphNode {
+	if x != nil {
+		if x, ok := x.Node.(*Node_ParagraphNode); ok {
+			return x.ParagraphNode
+		}
+	}
+	return nil
+}
+
+func (x *Node) GetCodeBlockNode() *CodeBlockNode {
+	if x != nil {
+		if x, ok := x.Node.(*Node_CodeBlockNode); ok {
+			return x.CodeBlockNode
+		}
+	}
+	return nil
+}
+
+func (x *Node) GetHeadingNode() *HeadingNode {
+	if x != nil {
+		if x, ok := x.Node.(*Node_HeadingNode); ok {
+			return x.HeadingNode
+		}
+	}
+	return nil
+}
+
+func (x *Node) GetHorizontalRuleNode() *HorizontalRuleNode {
+	if x != nil {
+		if x, ok := x.Node.(*Node_HorizontalRuleNode); ok {
+			return x.HorizontalRuleNode
+		}
+	}
+	return nil
+}
+
+func (x *Node) GetBlockquoteNode() *BlockquoteNode {
+	if x != nil {
+		if x, ok := x.Node.(*Node_BlockquoteNode); ok {
+			return x.BlockquoteNode
+		}
+	}
+	return nil
+}
+
+func (x *Node) GetListNode() *ListNode {
+	if x != nil {
+		if x, ok := x.Node.(*Node_ListNode); ok {
+			return x.ListNode
+		}
+	}
+	return nil
+}
+
+func (x *Node) GetOrderedListItemNode() *OrderedListItemNode {
+	if x != nil {
+		if x, ok := x.Node.(*Node_OrderedListItemNode); ok {
+			return x.OrderedListItemNode
+		}
+	}
+	return nil
+}
+
+func (x *Node) GetUnorderedListItemNode() *UnorderedListItemNode {
+	if x != nil {
+		if x, ok := x.Node.(*Node_UnorderedListItemNode); ok {
+			return x.UnorderedListItemNode
+		}
+	}
+	return nil
+}
+
+func (x *Node) GetTaskListItemNode() *TaskListItemNode {
+	if x != nil {
+		if x, ok := x.Node.(*Node_TaskListItemNode); ok {
+			return x.TaskListItemNode
+		}
+	}
+	return nil
+}
+
+func (x *Node) GetMathBlockNode() *MathBlockNode {
+	if x != nil {
+		if x, ok := x.Node.(*Node_MathBlockNode); ok {
+			return x.MathBlockNode
+		}
+	}
+	return nil
+}
+
+func (x *Node) GetTableNode() *TableNode {
+	if x != nil {
+		if x, ok := x.Node.(*Node_TableNode); ok {
+			return x.TableNode
+		}
+	}
+	return nil
+}
+
+func (x *Node) GetEmbeddedContentNode() *EmbeddedContentNode {
+	if x != nil {
+		if x, ok := x.Node.(*Node_EmbeddedContentNode); ok {
+			return x.EmbeddedContentNode
+		}
+	}
+	return nil
+}
+
+func (x *Node) GetFrontmatterNode() *FrontmatterNode {
+	if x != nil {
+		if x, ok := x.Node.(*Node_FrontmatterNode); ok {
+			return x.FrontmatterNode
+		}
+	}
+	return nil
+}
+
+func (x *Node) GetFootnoteDefNode() *FootnoteDefNode {
+	if x != nil {
+		if x, ok := x.Node.(*Node_FootnoteDefNode); ok {
+			return x.FootnoteDefNode
+		}
+	}
+	return nil
+}
+
+func (x *Node) GetTextNode() *TextNode {
+	if x != nil {
+		if x, ok := x.Node.(*Node_TextNode); ok {
+			return x.TextNode
+		}
+	}
+	return nil
+}
+
+func (x *Node) GetBoldNode() *BoldNode {
+	if x != nil {
+		if x, ok := x.Node.(*Node_BoldNode); ok {
+			return x.BoldNode
+		}
+	}
+	return nil
+}
+
+func (x *Node) GetItalicNode() *ItalicNode {
+	if x != nil {
+		if x, ok := x.Node.(*Node_ItalicNode); ok {
+			return x.ItalicNode
+		}
+	}
+	return nil
+}
+
+func (x *Node) GetBoldItalicNode() *BoldItalicNode {
+	if x != nil {
+		if x, ok := x.Node.(*Node_BoldItalicNode); ok {
+			return x.BoldItalicNode
+		}
+	}
+	return nil
+}
+
+func (x *Node) GetCodeNode() *CodeNode {
+	if x != nil {
+		if x, ok := x.Node.(*Node_CodeNode); ok {
+			return x.CodeNode
+		}
+	}
+	return nil
+}
+
+func (x *Node) GetImageNode() *ImageNode {
+	if x != nil {
+		if x, ok := x.Node.(*Node_ImageNode); ok {
+			return x.ImageNode
+		}
+	}
+	return nil
+}
+
+func (x *Node) GetLinkNode() *LinkNode {
+	if x != nil {
+		if x, ok := x.Node.(*Node_LinkNode); ok {
+			return x.LinkNode
+		}
+	}
+	return nil
+}
+
+func (x *Node) GetAutoLinkNode() *AutoLinkNode {
+	if x != nil {
+		if x, ok := x.Node.(*Node_AutoLinkNode); ok {
+			return x.AutoLinkNode
+		}
+	}
+	return nil
+}
+
+func (x *Node) GetTagNode() *TagNode {
+	if x != nil {
+		if x, ok := x.Node.(*Node_TagNode); ok {
+			return x.TagNode
+		}
+	}
+	return nil
+}
+
+func (x *Node) GetStrikethroughNode() *StrikethroughNode {
+	if x != nil {
+		if x, ok := x.Node.(*Node_StrikethroughNode); ok {
+			return x.StrikethroughNode
+		}
+	}
+	return nil
+}
+
+func (x *Node) GetEscapingCharacterNode() *EscapingCharacterNode {
+	if x != nil {
+		if x, ok := x.Node.(*Node_EscapingCharacterNode); ok {
+			return x.EscapingCharacterNode
+		}
+	}
+	return nil
+}
+
+func (x *Node) GetMathNode() *MathNode {
+	if x != nil {
+		if x, ok := x.Node.(*Node_MathNode); ok {
+			return x.MathNode
+		}
+	}
+	return nil
+}
+
+func (x *Node) GetHighlightNode() *HighlightNode {
+	if x != nil {
+		if x, ok := x.Node.(*Node_HighlightNode); ok {
+			return x.HighlightNode
+		}
+	}
+	return nil
+}
+
+func (x *Node) GetSubscriptNode() *SubscriptNode {
+	if x != nil {
+		if x, ok := x.Node.(*Node_SubscriptNode); ok {
+			return x.SubscriptNode
+		}
+	}
+	return nil
+}
+
+func (x *Node) GetSuperscriptNode() *SuperscriptNode {
+	if x != nil {
+		if x, ok := x.Node.(*Node_SuperscriptNode); ok {
+			return x.SuperscriptNode
+		}
+	}
+	return nil
+}
+
+func (x *Node) GetReferencedContentNode() *ReferencedContentNode {
+	if x != nil {
+		if x, ok := x.Node.(*Node_ReferencedContentNode); ok {
+			return x.ReferencedContentNode
+		}
+	}
+	return nil
+}
+
+func (x *Node) GetSpoilerNode() *SpoilerNode {
+	if x != nil {
+		if x, ok := x.Node.(*Node_SpoilerNode); ok {
+			return x.SpoilerNode
+		}
+	}
+	return nil
+}
+
+func (x *Node) GetHtmlElementNode() *HTMLElementNode {
+	if x != nil {
+		if x, ok := x.Node.(*Node_HtmlElementNode); ok {
+			return x.HtmlElementNode
+		}
+	}
+	return nil
+}
+
+func (x *Node) GetEmojiNode() *EmojiNode {
+	if x != nil {
+		if x, ok := x.Node.(*Node_EmojiNode); ok {
+			return x.EmojiNode
+		}
+	}
+	return nil
+}
+
+func (x *Node) GetCustomNode() *CustomNode {
+	if x != nil {
+		if x, ok := x.Node.(*Node_CustomNode); ok {
+			return x.CustomNode
+		}
+	}
+	return nil
+}
+
+func (x *Node) GetFootnoteRefNode() *FootnoteRefNode {
+	if x != nil {
+		if x, ok := x.Node.(*Node_FootnoteRefNode); ok {
+			return x.FootnoteRefNode
+		}
+	}
+	return nil
+}
+
+type isNode_Node interface {
+	isNode_Node()
+}
+
+type Node_LineBreakNode struct {
+	// Block nodes.
+	LineBreakNode *LineBreakNode `protobuf:"bytes,11,opt,name=line_break_node,json=lineBreakNode,proto3,oneof"`
+}
+
+type Node_ParagraphNode struct {
+	ParagraphNode *ParagraphNode `protobuf:"bytes,12,opt,name=paragraph_node,json=paragraphNode,proto3,oneof"`
+}
+
+type Node_CodeBlockNode struct {
+	CodeBlockNode *CodeBlockNode `protobuf:"bytes,13,opt,name=code_block_node,json=codeBlockNode,proto3,oneof"`
+}
+
+type Node_HeadingNode struct {
+	HeadingNode *HeadingNode `protobuf:"bytes,14,opt,name=heading_node,json=headingNode,proto3,oneof"`
+}
+
+type Node_HorizontalRuleNode struct {
+	HorizontalRuleNode *HorizontalRuleNode `protobuf:"bytes,15,opt,name=horizontal_rule_node,json=horizontalRuleNode,proto3,oneof"`
+}
+
+type Node_BlockquoteNode struct {
+	BlockquoteNode *BlockquoteNode `protobuf:"bytes,16,opt,name=blockquote_node,json=blockquoteNode,proto3,oneof"`
+}
+
+type Node_ListNode struct {
+	ListNode *ListNode `protobuf:"bytes,17,opt,name=list_node,json=listNode,proto3,oneof"`
+}
+
+type Node_OrderedListItemNode struct {
+	OrderedListItemNode *OrderedListItemNode `protobuf:"bytes,18,opt,name=ordered_list_item_node,json=orderedListItemNode,proto3,oneof"`
+}
+
+type Node_UnorderedListItemNode struct {
+	UnorderedListItemNode *UnorderedListItemNode `protobuf:"bytes,19,opt,name=unordered_list_item_node,json=unorderedListItemNode,proto3,oneof"`
+}
+
+type Node_TaskListItemNode struct {
+	TaskListItemNode *TaskListItemNode `protobuf:"bytes,20,opt,name=task_list_item_node,json=taskListItemNode,proto3,oneof"`
+}
+
+type Node_MathBlockNode struct {
+	MathBlockNode *MathBlockNode `protobuf:"bytes,21,opt,name=math_block_node,json=mathBlockNode,proto3,oneof"`
+}
+
+type Node_TableNode struct {
+	TableNode *TableNode `protobuf:"bytes,22,opt,name=table_node,json=tableNode,proto3,oneof"`
+}
+
+type Node_EmbeddedContentNode struct {
+	EmbeddedContentNode *EmbeddedContentNode `protobuf:"bytes,23,opt,name=embedded_content_node,json=embeddedContentNode,proto3,oneof"`
+}
+
+type Node_FrontmatterNode struct {
+	FrontmatterNode *FrontmatterNode `protobuf:"bytes,24,opt,name=frontmatter_node,json=frontmatterNode,proto3,oneof"`
+}
+
+type Node_FootnoteDefNode struct {
+	FootnoteDefNode *FootnoteDefNode `protobuf:"bytes,25,opt,name=footnote_def_node,json=footnoteDefNode,proto3,oneof"`
+}
+
+type Node_TextNode struct {
+	// Inline nodes.
+	TextNode *TextNode `protobuf:"bytes,51,opt,name=text_node,json=textNode,proto3,oneof"`
+}
+
+type Node_BoldNode struct {
+	BoldNode *BoldNode `protobuf:"bytes,52,opt,name=bold_node,json=boldNode,proto3,oneof"`
+}
+
+type Node_ItalicNode struct {
+	ItalicNode *ItalicNode `protobuf:"bytes,53,opt,name=italic_node,json=italicNode,proto3,oneof"`
+}
+
+type Node_BoldItalicNode struct {
+	BoldItalicNode *BoldItalicNode `protobuf:"bytes,54,opt,name=bold_italic_node,json=boldItalicNode,proto3,oneof"`
+}
+
+type Node_CodeNode struct {
+	CodeNode *CodeNode `protobuf:"bytes,55,opt,name=code_node,json=codeNode,proto3,oneof"`
+}
+
+type Node_ImageNode struct {
+	ImageNode *ImageNode `protobuf:"bytes,56,opt,name=image_node,json=imageNode,proto3,oneof"`
+}
+
+type Node_LinkNode struct {
+	LinkNode *LinkNode `protobuf:"bytes,57,opt,name=link_node,json=linkNode,proto3,oneof"`
+}
+
+type Node_AutoLinkNode struct {
+	AutoLinkNode *AutoLinkNode `protobuf:"bytes,58,opt,name=auto_link_node,json=autoLinkNode,proto3,oneof"`
+}
+
+type Node_TagNode struct {
+	TagNode *TagNode `protobuf:"bytes,59,opt,name=tag_node,json=tagNode,proto3,oneof"`
+}
+
+type Node_StrikethroughNode struct {
+	StrikethroughNode *StrikethroughNode `protobuf:"bytes,60,opt,name=strikethrough_node,json=strikethroughNode,proto3,oneof"`
+}
+
+type Node_EscapingCharacterNode struct {
+	EscapingCharacterNode *EscapingCharacterNode `protobuf:"bytes,61,opt,name=escaping_character_node,json=escapingCharacterNode,proto3,oneof"`
+}
+
+type Node_MathNode struct {
+	MathNode *MathNode `protobuf:"bytes,62,opt,name=math_node,json=mathNode,proto3,oneof"`
+}
+
+type Node_HighlightNode struct {
+	HighlightNode *HighlightNode `protobuf:"bytes,63,opt,name=highlight_node,json=highlightNode,proto3,oneof"`
+}
+
+type Node_SubscriptNode struct {
+	SubscriptNode *SubscriptNode `protobuf:"bytes,64,opt,name=subscript_node,json=subscriptNode,proto3,oneof"`
+}
+
+type Node_SuperscriptNode struct {
+	SuperscriptNode *SuperscriptNode `protobuf:"bytes,65,opt,name=superscript_node,json=superscriptNode,proto3,oneof"`
+}
+
+type Node_ReferencedContentNode struct {
+	ReferencedContentNode *ReferencedContentNode `protobuf:"bytes,66,opt,name=referenced_content_node,json=referencedContentNode,proto3,oneof"`
+}
+
+type Node_SpoilerNode struct {
+	SpoilerNode *SpoilerNode `protobuf:"bytes,67,opt,name=spoiler_node,json=spoilerNode,proto3,oneof"`
+}
+
+type Node_HtmlElementNode struct {
+	HtmlElementNode *HTMLElementNode `protobuf:"bytes,68,opt,name=html_element_node,json=htmlElementNode,proto3,oneof"`
+}
+
+type Node_EmojiNode struct {
+	EmojiNode *EmojiNode `protobuf:"bytes,69,opt,name=emoji_node,json=emojiNode,proto3,oneof"`
+}
+
+type Node_CustomNode struct {
+	CustomNode *CustomNode `protobuf:"bytes,70,opt,name=custom_node,json=customNode,proto3,oneof"`
+}
+
+type Node_FootnoteRefNode struct {
+	FootnoteRefNode *FootnoteRefNode `protobuf:"bytes,71,opt,name=footnote_ref_node,json=footnoteRefNode,proto3,oneof"`
+}
+
+func (*Node_LineBreakNode) isNode_Node() {}
+
+func (*Node_ParagraphNode) isNode_Node() {}
+
+func (*Node_CodeBlockNode) isNode_Node() {}
+
+func (*Node_HeadingNode) isNode_Node() {}
+
+func (*Node_HorizontalRuleNode) isNode_Node() {}
+
+func (*Node_BlockquoteNode) isNode_Node() {}
+
+func (*Node_ListNode) isNode_Node() {}
+
+func (*Node_OrderedListItemNode) isNode_Node() {}
+
+func (*Node_UnorderedListItemNode) isNode_Node() {}
+
+func (*Node_TaskListItemNode) isNode_Node() {}
+
+func (*Node_MathBlockNode) isNode_Node() {}
+
+func (*Node_TableNode) isNode_Node() {}
+
+func (*Node_EmbeddedContentNode) isNode_Node() {}
+
+func (*Node_FrontmatterNode) isNode_Node() {}
+
+func (*Node_FootnoteDefNode) isNode_Node() {}
+
+func (*Node_TextNode) isNode_Node() {}
+
+func (*Node_BoldNode) isNode_Node() {}
+
+func (*Node_ItalicNode) isNode_Node() {}
+
+func (*Node_BoldItalicNode) isNode_Node() {}
+
+func (*Node_CodeNode) isNode_Node() {}
+
+func (*Node_ImageNode) isNode_Node() {}
+
+func (*Node_LinkNode) isNode_Node() {}
+
+func (*Node_AutoLinkNode) isNode_Node() {}
+
+func (*Node_TagNode) isNode_Node() {}
+
+func (*Node_StrikethroughNode) isNode_Node() {}
+
+func (*Node_EscapingCharacterNode) isNode_Node() {}
+
+func (*Node_MathNode) isNode_Node() {}
+
+func (*Node_HighlightNode) isNode_Node() {}
+
+func (*Node_SubscriptNode) isNode_Node() {}
+
+func (*Node_SuperscriptNode) isNode_Node() {}
+
+func (*Node_ReferencedContentNode) isNode_Node() {}
+
+func (*Node_SpoilerNode) isNode_Node() {}
+
+func (*Node_HtmlElementNode) isNode_Node() {}
+
+func (*Node_EmojiNode) isNode_Node() {}
+
+func (*Node_CustomNode) isNode_Node() {}
+
+func (*Node_FootnoteRefNode) isNode_Node() {}
+
+// Position is a range in the markdown. The offsets are in bytes of the UTF-8 content and
+// the end is exclusive. Lines and columns start at 1 and columns count bytes as well.
+type Position struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Start         int32                  `protobuf:"varint,1,opt,name=start,proto3" json:"start,omitempty"`
+	End           int32                  `protobuf:"varint,2,opt,name=end,proto3" json:"end,omitempty"`
+	StartLine     int32                  `protobuf:"varint,3,opt,name=start_line,json=startLine,proto3" json:"start_line,omitempty"`
+	StartColumn   int32                  `protobuf:"varint,4,opt,name=start_column,json=startColumn,proto3" json:"start_column,omitempty"`
+	EndLine       int32                  `protobuf:"varint,5,opt,name=end_line,json=endLine,proto3" json:"end_line,omitempty"`
+	EndColumn     int32                  `protobuf:"varint,6,opt,name=end_column,json=endColumn,proto3" json:"end_column,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Position) Reset() {
+	*x = Position{}
+	mi := &file_api_v1_markdown_service_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Position) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Position) ProtoMessage() {}
+
+func (x *Position) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_markdown_service_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Position.ProtoReflect.Descriptor instead.
+func (*Position) Descriptor() ([]byte, []int) {
+	return file_api_v1_markdown_service_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *Position) GetStart() int32 {
+	if x != nil {
+		return x.Start
+	}
+	return 0
+}
+
+func (x *Position) GetEnd() int32 {
+	if x != nil {
+		return x.End
+	}
+	return 0
+}
+
+func (x *Position) GetStartLine() int32 {
+	if x != nil {
+		return x.StartLine
+	}
+	return 0
+}
+
+func (x *Position) GetStartColumn() int32 {
+	if x != nil {
+		return x.StartColumn
+	}
+	return 0
+}
+
+func (x *Position) GetEndLine() int32 {
+	if x != nil {
+		return x.EndLine
+	}
+	return 0
+}
+
+func (x *Position) GetEndColumn() int32 {
+	if x != nil {
+		return x.EndColumn
+	}
+	return 0
+}
+
+type LineBreakNode struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LineBreakNode) Reset() {
+	*x = LineBreakNode{}
+	mi := &file_api_v1_markdown_service_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LineBreakNode) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LineBreakNode) ProtoMessage() {}
+
+func (x *LineBreakNode) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_markdown_service_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LineBreakNode.ProtoReflect.Descriptor instead.
+func (*LineBreakNode) Descriptor() ([]byte, []int) {
+	return file_api_v1_markdown_service_proto_rawDescGZIP(), []int{26}
 }
 
 type ParagraphNode struct {
@@ -1179,21 +2951,498 @@ type ParagraphNode struct {
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ParagraphNode) Reset() {
-	*x = ParagraphNode{}
-	mi := &file_api_v1_markdown_service_proto_msgTypes[10]
+func (x *ParagraphNode) Reset() {
+	*x = ParagraphNode{}
+	mi := &file_api_v1_markdown_service_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ParagraphNode) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ParagraphNode) ProtoMessage() {}
+
+func (x *ParagraphNode) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_markdown_service_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ParagraphNode.ProtoReflect.Descriptor instead.
+func (*ParagraphNode) Descriptor() ([]byte, []int) {
+	return file_api_v1_markdown_service_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *ParagraphNode) GetChildren() []*Node {
+	if x != nil {
+		return x.Children
+	}
+	return nil
+}
+
+type CodeBlockNode struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Language string                 `protobuf:"bytes,1,opt,name=language,proto3" json:"language,omitempty"`
+	Content  string                 `protobuf:"bytes,2,opt,name=content,proto3" json:"content,omitempty"`
+	// The raw info string after the opening fence, only set when parsed with code_languages. It is
+	// restored instead of the language and metadata when set.
+	Info string `protobuf:"bytes,3,opt,name=info,proto3" json:"info,omitempty"`
+	// The info string after the language, e.g. "{hl=1}" for "go {hl=1}", only set when parsed with code_languages.
+	Metadata string `protobuf:"bytes,4,opt,name=metadata,proto3" json:"metadata,omitempty"`
+	// The opening fence, e.g. "~~~" or "````", only set when parsed with code_fences. When set, the block
+	// is restored with its fences and its content verbatim.
+	Fence string `protobuf:"bytes,5,opt,name=fence,proto3" json:"fence,omitempty"`
+	// The closing fence, which may be longer than the opening one, only set when parsed with code_fences.
+	ClosingFence  string `protobuf:"bytes,6,opt,name=closing_fence,json=closingFence,proto3" json:"closing_fence,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CodeBlockNode) Reset() {
+	*x = CodeBlockNode{}
+	mi := &file_api_v1_markdown_service_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CodeBlockNode) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CodeBlockNode) ProtoMessage() {}
+
+func (x *CodeBlockNode) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_markdown_service_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CodeBlockNode.ProtoReflect.Descriptor instead.
+func (*CodeBlockNode) Descriptor() ([]byte, []int) {
+	return file_api_v1_markdown_service_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *CodeBlockNode) GetLanguage() string {
+	if x != nil {
+		return x.Language
+	}
+	return ""
+}
+
+func (x *CodeBlockNode) GetContent() string {
+	if x != nil {
+		return x.Content
+	}
+	return ""
+}
+
+func (x *CodeBlockNode) GetInfo() string {
+	if x != nil {
+		return x.Info
+	}
+	return ""
+}
+
+func (x *CodeBlockNode) GetMetadata() string {
+	if x != nil {
+		return x.Metadata
+	}
+	return ""
+}
+
+func (x *CodeBlockNode) GetFence() string {
+	if x != nil {
+		return x.Fence
+	}
+	return ""
+}
+
+func (x *CodeBlockNode) GetClosingFence() string {
+	if x != nil {
+		return x.ClosingFence
+	}
+	return ""
+}
+
+type HeadingNode struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Level         int32                  `protobuf:"varint,1,opt,name=level,proto3" json:"level,omitempty"`
+	Children      []*Node                `protobuf:"bytes,2,rep,name=children,proto3" json:"children,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *HeadingNode) Reset() {
+	*x = HeadingNode{}
+	mi := &file_api_v1_markdown_service_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HeadingNode) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HeadingNode) ProtoMessage() {}
+
+func (x *HeadingNode) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_markdown_service_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HeadingNode.ProtoReflect.Descriptor instead.
+func (*HeadingNode) Descriptor() ([]byte, []int) {
+	return file_api_v1_markdown_service_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *HeadingNode) GetLevel() int32 {
+	if x != nil {
+		return x.Level
+	}
+	return 0
+}
+
+func (x *HeadingNode) GetChildren() []*Node {
+	if x != nil {
+		return x.Children
+	}
+	return nil
+}
+
+type HorizontalRuleNode struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Symbol        string                 `protobuf:"bytes,1,opt,name=symbol,proto3" json:"symbol,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *HorizontalRuleNode) Reset() {
+	*x = HorizontalRuleNode{}
+	mi := &file_api_v1_markdown_service_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *HorizontalRuleNode) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*HorizontalRuleNode) ProtoMessage() {}
+
+func (x *HorizontalRuleNode) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_markdown_service_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use HorizontalRuleNode.ProtoReflect.Descriptor instead.
+func (*HorizontalRuleNode) Descriptor() ([]byte, []int) {
+	return file_api_v1_markdown_service_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *HorizontalRuleNode) GetSymbol() string {
+	if x != nil {
+		return x.Symbol
+	}
+	return ""
+}
+
+type BlockquoteNode struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Children      []*Node                `protobuf:"bytes,1,rep,name=children,proto3" json:"children,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BlockquoteNode) Reset() {
+	*x = BlockquoteNode{}
+	mi := &file_api_v1_markdown_service_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BlockquoteNode) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BlockquoteNode) ProtoMessage() {}
+
+func (x *BlockquoteNode) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_markdown_service_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BlockquoteNode.ProtoReflect.Descriptor instead.
+func (*BlockquoteNode) Descriptor() ([]byte, []int) {
+	return file_api_v1_markdown_service_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *BlockquoteNode) GetChildren() []*Node {
+	if x != nil {
+		return x.Children
+	}
+	return nil
+}
+
+type ListNode struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Kind          ListNode_Kind          `protobuf:"varint,1,opt,name=kind,proto3,enum=memos.api.v1.ListNode_Kind" json:"kind,omitempty"`
+	Indent        int32                  `protobuf:"varint,2,opt,name=indent,proto3" json:"indent,omitempty"`
+	Children      []*Node                `protobuf:"bytes,3,rep,name=children,proto3" json:"children,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListNode) Reset() {
+	*x = ListNode{}
+	mi := &file_api_v1_markdown_service_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListNode) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListNode) ProtoMessage() {}
+
+func (x *ListNode) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_markdown_service_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListNode.ProtoReflect.Descriptor instead.
+func (*ListNode) Descriptor() ([]byte, []int) {
+	return file_api_v1_markdown_service_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *ListNode) GetKind() ListNode_Kind {
+	if x != nil {
+		return x.Kind
+	}
+	return ListNode_KIND_UNSPECIFIED
+}
+
+func (x *ListNode) GetIndent() int32 {
+	if x != nil {
+		return x.Indent
+	}
+	return 0
+}
+
+func (x *ListNode) GetChildren() []*Node {
+	if x != nil {
+		return x.Children
+	}
+	return nil
+}
+
+type OrderedListItemNode struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Number   string                 `protobuf:"bytes,1,opt,name=number,proto3" json:"number,omitempty"`
+	Indent   int32                  `protobuf:"varint,2,opt,name=indent,proto3" json:"indent,omitempty"`
+	Children []*Node                `protobuf:"bytes,3,rep,name=children,proto3" json:"children,omitempty"`
+	// The raw leading whitespace of the item, only set when it contains tabs.
+	IndentPrefix  string `protobuf:"bytes,4,opt,name=indent_prefix,json=indentPrefix,proto3" json:"indent_prefix,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OrderedListItemNode) Reset() {
+	*x = OrderedListItemNode{}
+	mi := &file_api_v1_markdown_service_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OrderedListItemNode) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OrderedListItemNode) ProtoMessage() {}
+
+func (x *OrderedListItemNode) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_markdown_service_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OrderedListItemNode.ProtoReflect.Descriptor instead.
+func (*OrderedListItemNode) Descriptor() ([]byte, []int) {
+	return file_api_v1_markdown_service_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *OrderedListItemNode) GetNumber() string {
+	if x != nil {
+		return x.Number
+	}
+	return ""
+}
+
+func (x *OrderedListItemNode) GetIndent() int32 {
+	if x != nil {
+		return x.Indent
+	}
+	return 0
+}
+
+func (x *OrderedListItemNode) GetChildren() []*Node {
+	if x != nil {
+		return x.Children
+	}
+	return nil
+}
+
+func (x *OrderedListItemNode) GetIndentPrefix() string {
+	if x != nil {
+		return x.IndentPrefix
+	}
+	return ""
+}
+
+type UnorderedListItemNode struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Symbol   string                 `protobuf:"bytes,1,opt,name=symbol,proto3" json:"symbol,omitempty"`
+	Indent   int32                  `protobuf:"varint,2,opt,name=indent,proto3" json:"indent,omitempty"`
+	Children []*Node                `protobuf:"bytes,3,rep,name=children,proto3" json:"children,omitempty"`
+	// The raw leading whitespace of the item, only set when it contains tabs.
+	IndentPrefix  string `protobuf:"bytes,4,opt,name=indent_prefix,json=indentPrefix,proto3" json:"indent_prefix,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UnorderedListItemNode) Reset() {
+	*x = UnorderedListItemNode{}
+	mi := &file_api_v1_markdown_service_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UnorderedListItemNode) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UnorderedListItemNode) ProtoMessage() {}
+
+func (x *UnorderedListItemNode) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_markdown_service_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UnorderedListItemNode.ProtoReflect.Descriptor instead.
+func (*UnorderedListItemNode) Descriptor() ([]byte, []int) {
+	return file_api_v1_markdown_service_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *UnorderedListItemNode) GetSymbol() string {
+	if x != nil {
+		return x.Symbol
+	}
+	return ""
+}
+
+func (x *UnorderedListItemNode) GetIndent() int32 {
+	if x != nil {
+		return x.Indent
+	}
+	return 0
+}
+
+func (x *UnorderedListItemNode) GetChildren() []*Node {
+	if x != nil {
+		return x.Children
+	}
+	return nil
+}
+
+func (x *UnorderedListItemNode) GetIndentPrefix() string {
+	if x != nil {
+		return x.IndentPrefix
+	}
+	return ""
+}
+
+type TaskListItemNode struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Symbol   string                 `protobuf:"bytes,1,opt,name=symbol,proto3" json:"symbol,omitempty"`
+	Indent   int32                  `protobuf:"varint,2,opt,name=indent,proto3" json:"indent,omitempty"`
+	Complete bool                   `protobuf:"varint,3,opt,name=complete,proto3" json:"complete,omitempty"`
+	Children []*Node                `protobuf:"bytes,4,rep,name=children,proto3" json:"children,omitempty"`
+	// The raw leading whitespace of the item, only set when it contains tabs.
+	IndentPrefix  string `protobuf:"bytes,5,opt,name=indent_prefix,json=indentPrefix,proto3" json:"indent_prefix,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TaskListItemNode) Reset() {
+	*x = TaskListItemNode{}
+	mi := &file_api_v1_markdown_service_proto_msgTypes[35]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ParagraphNode) String() string {
+func (x *TaskListItemNode) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ParagraphNode) ProtoMessage() {}
+func (*TaskListItemNode) ProtoMessage() {}
 
-func (x *ParagraphNode) ProtoReflect() protoreflect.Message {
-	mi := &file_api_v1_markdown_service_proto_msgTypes[10]
+func (x *TaskListItemNode) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_markdown_service_proto_msgTypes[35]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1204,41 +3453,68 @@ func (x *ParagraphNode) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ParagraphNode.ProtoReflect.Descriptor instead.
-func (*ParagraphNode) Descriptor() ([]byte, []int) {
-	return file_api_v1_markdown_service_proto_rawDescGZIP(), []int{10}
+// Deprecated: Use TaskListItemNode.ProtoReflect.Descriptor instead.
+func (*TaskListItemNode) Descriptor() ([]byte, []int) {
+	return file_api_v1_markdown_service_proto_rawDescGZIP(), []int{35}
 }
 
-func (x *ParagraphNode) GetChildren() []*Node {
+func (x *TaskListItemNode) GetSymbol() string {
+	if x != nil {
+		return x.Symbol
+	}
+	return ""
+}
+
+func (x *TaskListItemNode) GetIndent() int32 {
+	if x != nil {
+		return x.Indent
+	}
+	return 0
+}
+
+func (x *TaskListItemNode) GetComplete() bool {
+	if x != nil {
+		return x.Complete
+	}
+	return false
+}
+
+func (x *TaskListItemNode) GetChildren() []*Node {
 	if x != nil {
 		return x.Children
 	}
 	return nil
 }
 
-type CodeBlockNode struct {
+func (x *TaskListItemNode) GetIndentPrefix() string {
+	if x != nil {
+		return x.IndentPrefix
+	}
+	return ""
+}
+
+type MathBlockNode struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Language      string                 `protobuf:"bytes,1,opt,name=language,proto3" json:"language,omitempty"`
-	Content       string                 `protobuf:"bytes,2,opt,name=content,proto3" json:"content,omitempty"`
+	Content       string                 `protobuf:"bytes,1,opt,name=content,proto3" json:"content,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *CodeBlockNode) Reset() {
-	*x = CodeBlockNode{}
-	mi := &file_api_v1_markdown_service_proto_msgTypes[11]
+func (x *MathBlockNode) Reset() {
+	*x = MathBlockNode{}
+	mi := &file_api_v1_markdown_service_proto_msgTypes[36]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *CodeBlockNode) String() string {
+func (x *MathBlockNode) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CodeBlockNode) ProtoMessage() {}
+func (*MathBlockNode) ProtoMessage() {}
 
-func (x *CodeBlockNode) ProtoReflect() protoreflect.Message {
-	mi := &file_api_v1_markdown_service_proto_msgTypes[11]
+func (x *MathBlockNode) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_markdown_service_proto_msgTypes[36]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1249,48 +3525,42 @@ func (x *CodeBlockNode) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CodeBlockNode.ProtoReflect.Descriptor instead.
-func (*CodeBlockNode) Descriptor() ([]byte, []int) {
-	return file_api_v1_markdown_service_proto_rawDescGZIP(), []int{11}
-}
-
-func (x *CodeBlockNode) GetLanguage() string {
-	if x != nil {
-		return x.Language
-	}
-	return ""
+// Deprecated: Use MathBlockNode.ProtoReflect.Descriptor instead.
+func (*MathBlockNode) Descriptor() ([]byte, []int) {
+	return file_api_v1_markdown_service_proto_rawDescGZIP(), []int{36}
 }
 
-func (x *CodeBlockNode) GetContent() string {
+func (x *MathBlockNode) GetContent() string {
 	if x != nil {
 		return x.Content
 	}
 	return ""
 }
 
-type HeadingNode struct {
+type TableNode struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Level         int32                  `protobuf:"varint,1,opt,name=level,proto3" json:"level,omitempty"`
-	Children      []*Node                `protobuf:"bytes,2,rep,name=children,proto3" json:"children,omitempty"`
+	Header        []*Node                `protobuf:"bytes,1,rep,name=header,proto3" json:"header,omitempty"`
+	Delimiter     []string               `protobuf:"bytes,2,rep,name=delimiter,proto3" json:"delimiter,omitempty"`
+	Rows          []*TableNode_Row       `protobuf:"bytes,3,rep,name=rows,proto3" json:"rows,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *HeadingNode) Reset() {
-	*x = HeadingNode{}
-	mi := &file_api_v1_markdown_service_proto_msgTypes[12]
+func (x *TableNode) Reset() {
+	*x = TableNode{}
+	mi := &file_api_v1_markdown_service_proto_msgTypes[37]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *HeadingNode) String() string {
+func (x *TableNode) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*HeadingNode) ProtoMessage() {}
+func (*TableNode) ProtoMessage() {}
 
-func (x *HeadingNode) ProtoReflect() protoreflect.Message {
-	mi := &file_api_v1_markdown_service_proto_msgTypes[12]
+func (x *TableNode) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_markdown_service_proto_msgTypes[37]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1301,47 +3571,55 @@ func (x *HeadingNode) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use HeadingNode.ProtoReflect.Descriptor instead.
-func (*HeadingNode) Descriptor() ([]byte, []int) {
-	return file_api_v1_markdown_service_proto_rawDescGZIP(), []int{12}
+// Deprecated: Use TableNode.ProtoReflect.Descriptor instead.
+func (*TableNode) Descriptor() ([]byte, []int) {
+	return file_api_v1_markdown_service_proto_rawDescGZIP(), []int{37}
 }
 
-func (x *HeadingNode) GetLevel() int32 {
+func (x *TableNode) GetHeader() []*Node {
 	if x != nil {
-		return x.Level
+		return x.Header
 	}
-	return 0
+	return nil
 }
 
-func (x *HeadingNode) GetChildren() []*Node {
+func (x *TableNode) GetDelimiter() []string {
 	if x != nil {
-		return x.Children
+		return x.Delimiter
 	}
 	return nil
 }
 
-type HorizontalRuleNode struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Symbol        string                 `protobuf:"bytes,1,opt,name=symbol,proto3" json:"symbol,omitempty"`
+func (x *TableNode) GetRows() []*TableNode_Row {
+	if x != nil {
+		return x.Rows
+	}
+	return nil
+}
+
+type FrontmatterNode struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The raw YAML between the "---" lines, including its trailing newline.
+	Content       string `protobuf:"bytes,1,opt,name=content,proto3" json:"content,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *HorizontalRuleNode) Reset() {
-	*x = HorizontalRuleNode{}
-	mi := &file_api_v1_markdown_service_proto_msgTypes[13]
+func (x *FrontmatterNode) Reset() {
+	*x = FrontmatterNode{}
+	mi := &file_api_v1_markdown_service_proto_msgTypes[38]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *HorizontalRuleNode) String() string {
+func (x *FrontmatterNode) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*HorizontalRuleNode) ProtoMessage() {}
+func (*FrontmatterNode) ProtoMessage() {}
 
-func (x *HorizontalRuleNode) ProtoReflect() protoreflect.Message {
-	mi := &file_api_v1_markdown_service_proto_msgTypes[13]
+func (x *FrontmatterNode) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_markdown_service_proto_msgTypes[38]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1352,40 +3630,48 @@ func (x *HorizontalRuleNode) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use HorizontalRuleNode.ProtoReflect.Descriptor instead.
-func (*HorizontalRuleNode) Descriptor() ([]byte, []int) {
-	return file_api_v1_markdown_service_proto_rawDescGZIP(), []int{13}
+// Deprecated: Use FrontmatterNode.ProtoReflect.Descriptor instead.
+func (*FrontmatterNode) Descriptor() ([]byte, []int) {
+	return file_api_v1_markdown_service_proto_rawDescGZIP(), []int{38}
 }
 
-func (x *HorizontalRuleNode) GetSymbol() string {
+func (x *FrontmatterNode) GetContent() string {
 	if x != nil {
-		return x.Symbol
+		return x.Content
 	}
 	return ""
 }
 
-type BlockquoteNode struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Children      []*Node                `protobuf:"bytes,1,rep,name=children,proto3" json:"children,omitempty"`
+// FootnoteDefNode is the definition of a footnote, e.g. "[^1]: A note.".
+type FootnoteDefNode struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The label of the footnote, e.g. "1" or "note".
+	Label    string  `protobuf:"bytes,1,opt,name=label,proto3" json:"label,omitempty"`
+	Children []*Node `protobuf:"bytes,2,rep,name=children,proto3" json:"children,omitempty"`
+	// The number of the footnote in order of first reference, starting at 1.
+	// 0 if the footnote is never referenced or is a duplicate.
+	Number int32 `protobuf:"varint,3,opt,name=number,proto3" json:"number,omitempty"`
+	// Whether an earlier definition has the same label. References link to the first definition.
+	Duplicate     bool `protobuf:"varint,4,opt,name=duplicate,proto3" json:"duplicate,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *BlockquoteNode) Reset() {
-	*x = BlockquoteNode{}
-	mi := &file_api_v1_markdown_service_proto_msgTypes[14]
+func (x *FootnoteDefNode) Reset() {
+	*x = FootnoteDefNode{}
+	mi := &file_api_v1_markdown_service_proto_msgTypes[39]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *BlockquoteNode) String() string {
+func (x *FootnoteDefNode) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*BlockquoteNode) ProtoMessage() {}
+func (*FootnoteDefNode) ProtoMessage() {}
 
-func (x *BlockquoteNode) ProtoReflect() protoreflect.Message {
-	mi := &file_api_v1_markdown_service_proto_msgTypes[14]
+func (x *FootnoteDefNode) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_markdown_service_proto_msgTypes[39]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1396,42 +3682,62 @@ func (x *BlockquoteNode) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use BlockquoteNode.ProtoReflect.Descriptor instead.
-func (*BlockquoteNode) Descriptor() ([]byte, []int) {
-	return file_api_v1_markdown_service_proto_rawDescGZIP(), []int{14}
+// Deprecated: Use FootnoteDefNode.ProtoReflect.Descriptor instead.
+func (*FootnoteDefNode) Descriptor() ([]byte, []int) {
+	return file_api_v1_markdown_service_proto_rawDescGZIP(), []int{39}
 }
 
-func (x *BlockquoteNode) GetChildren() []*Node {
+func (x *FootnoteDefNode) GetLabel() string {
+	if x != nil {
+		return x.Label
+	}
+	return ""
+}
+
+func (x *FootnoteDefNode) GetChildren() []*Node {
 	if x != nil {
 		return x.Children
 	}
 	return nil
 }
 
-type ListNode struct {
+func (x *FootnoteDefNode) GetNumber() int32 {
+	if x != nil {
+		return x.Number
+	}
+	return 0
+}
+
+func (x *FootnoteDefNode) GetDuplicate() bool {
+	if x != nil {
+		return x.Duplicate
+	}
+	return false
+}
+
+type EmbeddedContentNode struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Kind          ListNode_Kind          `protobuf:"varint,1,opt,name=kind,proto3,enum=memos.api.v1.ListNode_Kind" json:"kind,omitempty"`
-	Indent        int32                  `protobuf:"varint,2,opt,name=indent,proto3" json:"indent,omitempty"`
-	Children      []*Node                `protobuf:"bytes,3,rep,name=children,proto3" json:"children,omitempty"`
+	ResourceName  string                 `protobuf:"bytes,1,opt,name=resource_name,json=resourceName,proto3" json:"resource_name,omitempty"`
+	Params        string                 `protobuf:"bytes,2,opt,name=params,proto3" json:"params,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ListNode) Reset() {
-	*x = ListNode{}
-	mi := &file_api_v1_markdown_service_proto_msgTypes[15]
+func (x *EmbeddedContentNode) Reset() {
+	*x = EmbeddedContentNode{}
+	mi := &file_api_v1_markdown_service_proto_msgTypes[40]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListNode) String() string {
+func (x *EmbeddedContentNode) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListNode) ProtoMessage() {}
+func (*EmbeddedContentNode) ProtoMessage() {}
 
-func (x *ListNode) ProtoReflect() protoreflect.Message {
-	mi := &file_api_v1_markdown_service_proto_msgTypes[15]
+func (x *EmbeddedContentNode) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_markdown_service_proto_msgTypes[40]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1442,56 +3748,92 @@ func (x *ListNode) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListNode.ProtoReflect.Descriptor instead.
-func (*ListNode) Descriptor() ([]byte, []int) {
-	return file_api_v1_markdown_service_proto_rawDescGZIP(), []int{15}
+// Deprecated: Use EmbeddedContentNode.ProtoReflect.Descriptor instead.
+func (*EmbeddedContentNode) Descriptor() ([]byte, []int) {
+	return file_api_v1_markdown_service_proto_rawDescGZIP(), []int{40}
 }
 
-func (x *ListNode) GetKind() ListNode_Kind {
+func (x *EmbeddedContentNode) GetResourceName() string {
 	if x != nil {
-		return x.Kind
+		return x.ResourceName
 	}
-	return ListNode_KIND_UNSPECIFIED
+	return ""
 }
 
-func (x *ListNode) GetIndent() int32 {
+func (x *EmbeddedContentNode) GetParams() string {
 	if x != nil {
-		return x.Indent
+		return x.Params
 	}
-	return 0
+	return ""
 }
 
-func (x *ListNode) GetChildren() []*Node {
+type TextNode struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Content       string                 `protobuf:"bytes,1,opt,name=content,proto3" json:"content,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TextNode) Reset() {
+	*x = TextNode{}
+	mi := &file_api_v1_markdown_service_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TextNode) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TextNode) ProtoMessage() {}
+
+func (x *TextNode) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_markdown_service_proto_msgTypes[41]
 	if x != nil {
-		return x.Children
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TextNode.ProtoReflect.Descriptor instead.
+func (*TextNode) Descriptor() ([]byte, []int) {
+	return file_api_v1_markdown_service_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *TextNode) GetContent() string {
+	if x != nil {
+		return x.Content
 	}
-	return nil
+	return ""
 }
 
-type OrderedListItemNode struct {
+type BoldNode struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Number        string                 `protobuf:"bytes,1,opt,name=number,proto3" json:"number,omitempty"`
-	Indent        int32                  `protobuf:"varint,2,opt,name=indent,proto3" json:"indent,omitempty"`
-	Children      []*Node                `protobuf:"bytes,3,rep,name=children,proto3" json:"children,omitempty"`
+	Symbol        string                 `protobuf:"bytes,1,opt,name=symbol,proto3" json:"symbol,omitempty"`
+	Children      []*Node                `protobuf:"bytes,2,rep,name=children,proto3" json:"children,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *OrderedListItemNode) Reset() {
-	*x = OrderedListItemNode{}
-	mi := &file_api_v1_markdown_service_proto_msgTypes[16]
+func (x *BoldNode) Reset() {
+	*x = BoldNode{}
+	mi := &file_api_v1_markdown_service_proto_msgTypes[42]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *OrderedListItemNode) String() string {
+func (x *BoldNode) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*OrderedListItemNode) ProtoMessage() {}
+func (*BoldNode) ProtoMessage() {}
 
-func (x *OrderedListItemNode) ProtoReflect() protoreflect.Message {
-	mi := &file_api_v1_markdown_service_proto_msgTypes[16]
+func (x *BoldNode) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_markdown_service_proto_msgTypes[42]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1502,56 +3844,48 @@ func (x *OrderedListItemNode) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use OrderedListItemNode.ProtoReflect.Descriptor instead.
-func (*OrderedListItemNode) Descriptor() ([]byte, []int) {
-	return file_api_v1_markdown_service_proto_rawDescGZIP(), []int{16}
+// Deprecated: Use BoldNode.ProtoReflect.Descriptor instead.
+func (*BoldNode) Descriptor() ([]byte, []int) {
+	return file_api_v1_markdown_service_proto_rawDescGZIP(), []int{42}
 }
 
-func (x *OrderedListItemNode) GetNumber() string {
+func (x *BoldNode) GetSymbol() string {
 	if x != nil {
-		return x.Number
+		return x.Symbol
 	}
 	return ""
 }
 
-func (x *OrderedListItemNode) GetIndent() int32 {
-	if x != nil {
-		return x.Indent
-	}
-	return 0
-}
-
-func (x *OrderedListItemNode) GetChildren() []*Node {
+func (x *BoldNode) GetChildren() []*Node {
 	if x != nil {
 		return x.Children
 	}
 	return nil
 }
 
-type UnorderedListItemNode struct {
+type ItalicNode struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Symbol        string                 `protobuf:"bytes,1,opt,name=symbol,proto3" json:"symbol,omitempty"`
-	Indent        int32                  `protobuf:"varint,2,opt,name=indent,proto3" json:"indent,omitempty"`
-	Children      []*Node                `protobuf:"bytes,3,rep,name=children,proto3" json:"children,omitempty"`
+	Children      []*Node                `protobuf:"bytes,2,rep,name=children,proto3" json:"children,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *UnorderedListItemNode) Reset() {
-	*x = UnorderedListItemNode{}
-	mi := &file_api_v1_markdown_service_proto_msgTypes[17]
+func (x *ItalicNode) Reset() {
+	*x = ItalicNode{}
+	mi := &file_api_v1_markdown_service_proto_msgTypes[43]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *UnorderedListItemNode) String() string {
+func (x *ItalicNode) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UnorderedListItemNode) ProtoMessage() {}
+func (*ItalicNode) ProtoMessage() {}
 
-func (x *UnorderedListItemNode) ProtoReflect() protoreflect.Message {
-	mi := &file_api_v1_markdown_service_proto_msgTypes[17]
+func (x *ItalicNode) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_markdown_service_proto_msgTypes[43]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1562,57 +3896,48 @@ func (x *UnorderedListItemNode) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UnorderedListItemNode.ProtoReflect.Descriptor instead.
-func (*UnorderedListItemNode) Descriptor() ([]byte, []int) {
-	return file_api_v1_markdown_service_proto_rawDescGZIP(), []int{17}
+// Deprecated: Use ItalicNode.ProtoReflect.Descriptor instead.
+func (*ItalicNode) Descriptor() ([]byte, []int) {
+	return file_api_v1_markdown_service_proto_rawDescGZIP(), []int{43}
 }
 
-func (x *UnorderedListItemNode) GetSymbol() string {
+func (x *ItalicNode) GetSymbol() string {
 	if x != nil {
 		return x.Symbol
 	}
 	return ""
 }
 
-func (x *UnorderedListItemNode) GetIndent() int32 {
-	if x != nil {
-		return x.Indent
-	}
-	return 0
-}
-
-func (x *UnorderedListItemNode) GetChildren() []*Node {
+func (x *ItalicNode) GetChildren() []*Node {
 	if x != nil {
 		return x.Children
 	}
 	return nil
 }
 
-type TaskListItemNode struct {
+type BoldItalicNode struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Symbol        string                 `protobuf:"bytes,1,opt,name=symbol,proto3" json:"symbol,omitempty"`
-	Indent        int32                  `protobuf:"varint,2,opt,name=indent,proto3" json:"indent,omitempty"`
-	Complete      bool                   `protobuf:"varint,3,opt,name=complete,proto3" json:"complete,omitempty"`
-	Children      []*Node                `protobuf:"bytes,4,rep,name=children,proto3" json:"children,omitempty"`
+	Content       string                 `protobuf:"bytes,2,opt,name=content,proto3" json:"content,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *TaskListItemNode) Reset() {
-	*x = TaskListItemNode{}
-	mi := &file_api_v1_markdown_service_proto_msgTypes[18]
+func (x *BoldItalicNode) Reset() {
+	*x = BoldItalicNode{}
+	mi := &file_api_v1_markdown_service_proto_msgTypes[44]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *TaskListItemNode) String() string {
+func (x *BoldItalicNode) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*TaskListItemNode) ProtoMessage() {}
+func (*BoldItalicNode) ProtoMessage() {}
 
-func (x *TaskListItemNode) ProtoReflect() protoreflect.Message {
-	mi := &file_api_v1_markdown_service_proto_msgTypes[18]
+func (x *BoldItalicNode) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_markdown_service_proto_msgTypes[44]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1623,61 +3948,47 @@ func (x *TaskListItemNode) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use TaskListItemNode.ProtoReflect.Descriptor instead.
-func (*TaskListItemNode) Descriptor() ([]byte, []int) {
-	return file_api_v1_markdown_service_proto_rawDescGZIP(), []int{18}
+// Deprecated: Use BoldItalicNode.ProtoReflect.Descriptor instead.
+func (*BoldItalicNode) Descriptor() ([]byte, []int) {
+	return file_api_v1_markdown_service_proto_rawDescGZIP(), []int{44}
 }
 
-func (x *TaskListItemNode) GetSymbol() string {
+func (x *BoldItalicNode) GetSymbol() string {
 	if x != nil {
 		return x.Symbol
 	}
 	return ""
 }
 
-func (x *TaskListItemNode) GetIndent() int32 {
-	if x != nil {
-		return x.Indent
-	}
-	return 0
-}
-
-func (x *TaskListItemNode) GetComplete() bool {
-	if x != nil {
-		return x.Complete
-	}
-	return false
-}
-
-func (x *TaskListItemNode) GetChildren() []*Node {
+func (x *BoldItalicNode) GetContent() string {
 	if x != nil {
-		return x.Children
+		return x.Content
 	}
-	return nil
+	return ""
 }
 
-type MathBlockNode struct {
+type CodeNode struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Content       string                 `protobuf:"bytes,1,opt,name=content,proto3" json:"content,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *MathBlockNode) Reset() {
-	*x = MathBlockNode{}
-	mi := &file_api_v1_markdown_service_proto_msgTypes[19]
+func (x *CodeNode) Reset() {
+	*x = CodeNode{}
+	mi := &file_api_v1_markdown_service_proto_msgTypes[45]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *MathBlockNode) String() string {
+func (x *CodeNode) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*MathBlockNode) ProtoMessage() {}
+func (*CodeNode) ProtoMessage() {}
 
-func (x *MathBlockNode) ProtoReflect() protoreflect.Message {
-	mi := &file_api_v1_markdown_service_proto_msgTypes[19]
+func (x *CodeNode) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_markdown_service_proto_msgTypes[45]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1688,42 +3999,41 @@ func (x *MathBlockNode) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use MathBlockNode.ProtoReflect.Descriptor instead.
-func (*MathBlockNode) Descriptor() ([]byte, []int) {
-	return file_api_v1_markdown_service_proto_rawDescGZIP(), []int{19}
+// Deprecated: Use CodeNode.ProtoReflect.Descriptor instead.
+func (*CodeNode) Descriptor() ([]byte, []int) {
+	return file_api_v1_markdown_service_proto_rawDescGZIP(), []int{45}
 }
 
-func (x *MathBlockNode) GetContent() string {
+func (x *CodeNode) GetContent() string {
 	if x != nil {
 		return x.Content
 	}
 	return ""
 }
 
-type TableNode struct {
+type ImageNode struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Header        []*Node                `protobuf:"bytes,1,rep,name=header,proto3" json:"header,omitempty"`
-	Delimiter     []string               `protobuf:"bytes,2,rep,name=delimiter,proto3" json:"delimiter,omitempty"`
-	Rows          []*TableNode_Row       `protobuf:"bytes,3,rep,name=rows,proto3" json:"rows,omitempty"`
+	AltText       string                 `protobuf:"bytes,1,opt,name=alt_text,json=altText,proto3" json:"alt_text,omitempty"`
+	Url           string                 `protobuf:"bytes,2,opt,name=url,proto3" json:"url,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *TableNode) Reset() {
-	*x = TableNode{}
-	mi := &file_api_v1_markdown_service_proto_msgTypes[20]
+func (x *ImageNode) Reset() {
+	*x = ImageNode{}
+	mi := &file_api_v1_markdown_service_proto_msgTypes[46]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *TableNode) String() string {
+func (x *ImageNode) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*TableNode) ProtoMessage() {}
+func (*ImageNode) ProtoMessage() {}
 
-func (x *TableNode) ProtoReflect() protoreflect.Message {
-	mi := &file_api_v1_markdown_service_proto_msgTypes[20]
+func (x *ImageNode) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_markdown_service_proto_msgTypes[46]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1734,55 +4044,48 @@ func (x *TableNode) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use TableNode.ProtoReflect.Descriptor instead.
-func (*TableNode) Descriptor() ([]byte, []int) {
-	return file_api_v1_markdown_service_proto_rawDescGZIP(), []int{20}
-}
-
-func (x *TableNode) GetHeader() []*Node {
-	if x != nil {
-		return x.Header
-	}
-	return nil
+// Deprecated: Use ImageNode.ProtoReflect.Descriptor instead.
+func (*ImageNode) Descriptor() ([]byte, []int) {
+	return file_api_v1_markdown_service_proto_rawDescGZIP(), []int{46}
 }
 
-func (x *TableNode) GetDelimiter() []string {
+func (x *ImageNode) GetAltText() string {
 	if x != nil {
-		return x.Delimiter
+		return x.AltText
 	}
-	return nil
+	return ""
 }
 
-func (x *TableNode) GetRows() []*TableNode_Row {
+func (x *ImageNode) GetUrl() string {
 	if x != nil {
-		return x.Rows
+		return x.Url
 	}
-	return nil
+	return ""
 }
 
-type EmbeddedContentNode struct {
+type LinkNode struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	ResourceName  string                 `protobuf:"bytes,1,opt,name=resource_name,json=resourceName,proto3" json:"resource_name,omitempty"`
-	Params        string                 `protobuf:"bytes,2,opt,name=params,proto3" json:"params,omitempty"`
+	Content       []*Node                `protobuf:"bytes,1,rep,name=content,proto3" json:"content,omitempty"`
+	Url           string                 `protobuf:"bytes,2,opt,name=url,proto3" json:"url,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *EmbeddedContentNode) Reset() {
-	*x = EmbeddedContentNode{}
-	mi := &file_api_v1_markdown_service_proto_msgTypes[21]
+func (x *LinkNode) Reset() {
+	*x = LinkNode{}
+	mi := &file_api_v1_markdown_service_proto_msgTypes[47]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *EmbeddedContentNode) String() string {
+func (x *LinkNode) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*EmbeddedContentNode) ProtoMessage() {}
+func (*LinkNode) ProtoMessage() {}
 
-func (x *EmbeddedContentNode) ProtoReflect() protoreflect.Message {
-	mi := &file_api_v1_markdown_service_proto_msgTypes[21]
+func (x *LinkNode) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_markdown_service_proto_msgTypes[47]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1793,47 +4096,48 @@ func (x *EmbeddedContentNode) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use EmbeddedContentNode.ProtoReflect.Descriptor instead.
-func (*EmbeddedContentNode) Descriptor() ([]byte, []int) {
-	return file_api_v1_markdown_service_proto_rawDescGZIP(), []int{21}
+// Deprecated: Use LinkNode.ProtoReflect.Descriptor instead.
+func (*LinkNode) Descriptor() ([]byte, []int) {
+	return file_api_v1_markdown_service_proto_rawDescGZIP(), []int{47}
 }
 
-func (x *EmbeddedContentNode) GetResourceName() string {
+func (x *LinkNode) GetContent() []*Node {
 	if x != nil {
-		return x.ResourceName
+		return x.Content
 	}
-	return ""
+	return nil
 }
 
-func (x *EmbeddedContentNode) GetParams() string {
+func (x *LinkNode) GetUrl() string {
 	if x != nil {
-		return x.Params
+		return x.Url
 	}
 	return ""
 }
 
-type TextNode struct {
+type AutoLinkNode struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Content       string                 `protobuf:"bytes,1,opt,name=content,proto3" json:"content,omitempty"`
+	Url           string                 `protobuf:"bytes,1,opt,name=url,proto3" json:"url,omitempty"`
+	IsRawText     bool                   `protobuf:"varint,2,opt,name=is_raw_text,json=isRawText,proto3" json:"is_raw_text,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *TextNode) Reset() {
-	*x = TextNode{}
-	mi := &file_api_v1_markdown_service_proto_msgTypes[22]
+func (x *AutoLinkNode) Reset() {
+	*x = AutoLinkNode{}
+	mi := &file_api_v1_markdown_service_proto_msgTypes[48]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *TextNode) String() string {
+func (x *AutoLinkNode) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*TextNode) ProtoMessage() {}
+func (*AutoLinkNode) ProtoMessage() {}
 
-func (x *TextNode) ProtoReflect() protoreflect.Message {
-	mi := &file_api_v1_markdown_service_proto_msgTypes[22]
+func (x *AutoLinkNode) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_markdown_service_proto_msgTypes[48]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1844,41 +4148,47 @@ func (x *TextNode) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use TextNode.ProtoReflect.Descriptor instead.
-func (*TextNode) Descriptor() ([]byte, []int) {
-	return file_api_v1_markdown_service_proto_rawDescGZIP(), []int{22}
+// Deprecated: Use AutoLinkNode.ProtoReflect.Descriptor instead.
+func (*AutoLinkNode) Descriptor() ([]byte, []int) {
+	return file_api_v1_markdown_service_proto_rawDescGZIP(), []int{48}
 }
 
-func (x *TextNode) GetContent() string {
+func (x *AutoLinkNode) GetUrl() string {
 	if x != nil {
-		return x.Content
+		return x.Url
 	}
 	return ""
 }
 
-type BoldNode struct {
+func (x *AutoLinkNode) GetIsRawText() bool {
+	if x != nil {
+		return x.IsRawText
+	}
+	return false
+}
+
+type TagNode struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Symbol        string                 `protobuf:"bytes,1,opt,name=symbol,proto3" json:"symbol,omitempty"`
-	Children      []*Node                `protobuf:"bytes,2,rep,name=children,proto3" json:"children,omitempty"`
+	Content       string                 `protobuf:"bytes,1,opt,name=content,proto3" json:"content,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *BoldNode) Reset() {
-	*x = BoldNode{}
-	mi := &file_api_v1_markdown_service_proto_msgTypes[23]
+func (x *TagNode) Reset() {
+	*x = TagNode{}
+	mi := &file_api_v1_markdown_service_proto_msgTypes[49]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *BoldNode) String() string {
+func (x *TagNode) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*BoldNode) ProtoMessage() {}
+func (*TagNode) ProtoMessage() {}
 
-func (x *BoldNode) ProtoReflect() protoreflect.Message {
-	mi := &file_api_v1_markdown_service_proto_msgTypes[23]
+func (x *TagNode) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_markdown_service_proto_msgTypes[49]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1889,48 +4199,40 @@ func (x *BoldNode) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use BoldNode.ProtoReflect.Descriptor instead.
-func (*BoldNode) Descriptor() ([]byte, []int) {
-	return file_api_v1_markdown_service_proto_rawDescGZIP(), []int{23}
+// Deprecated: Use TagNode.ProtoReflect.Descriptor instead.
+func (*TagNode) Descriptor() ([]byte, []int) {
+	return file_api_v1_markdown_service_proto_rawDescGZIP(), []int{49}
 }
 
-func (x *BoldNode) GetSymbol() string {
+func (x *TagNode) GetContent() string {
 	if x != nil {
-		return x.Symbol
+		return x.Content
 	}
 	return ""
 }
 
-func (x *BoldNode) GetChildren() []*Node {
-	if x != nil {
-		return x.Children
-	}
-	return nil
-}
-
-type ItalicNode struct {
+type StrikethroughNode struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Symbol        string                 `protobuf:"bytes,1,opt,name=symbol,proto3" json:"symbol,omitempty"`
-	Children      []*Node                `protobuf:"bytes,2,rep,name=children,proto3" json:"children,omitempty"`
+	Content       string                 `protobuf:"bytes,1,opt,name=content,proto3" json:"content,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ItalicNode) Reset() {
-	*x = ItalicNode{}
-	mi := &file_api_v1_markdown_service_proto_msgTypes[24]
+func (x *StrikethroughNode) Reset() {
+	*x = StrikethroughNode{}
+	mi := &file_api_v1_markdown_service_proto_msgTypes[50]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ItalicNode) String() string {
+func (x *StrikethroughNode) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ItalicNode) ProtoMessage() {}
+func (*StrikethroughNode) ProtoMessage() {}
 
-func (x *ItalicNode) ProtoReflect() protoreflect.Message {
-	mi := &file_api_v1_markdown_service_proto_msgTypes[24]
+func (x *StrikethroughNode) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_markdown_service_proto_msgTypes[50]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1941,48 +4243,40 @@ func (x *ItalicNode) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ItalicNode.ProtoReflect.Descriptor instead.
-func (*ItalicNode) Descriptor() ([]byte, []int) {
-	return file_api_v1_markdown_service_proto_rawDescGZIP(), []int{24}
+// Deprecated: Use StrikethroughNode.ProtoReflect.Descriptor instead.
+func (*StrikethroughNode) Descriptor() ([]byte, []int) {
+	return file_api_v1_markdown_service_proto_rawDescGZIP(), []int{50}
 }
 
-func (x *ItalicNode) GetSymbol() string {
+func (x *StrikethroughNode) GetContent() string {
 	if x != nil {
-		return x.Symbol
+		return x.Content
 	}
 	return ""
 }
 
-func (x *ItalicNode) GetChildren() []*Node {
-	if x != nil {
-		return x.Children
-	}
-	return nil
-}
-
-type BoldItalicNode struct {
+type EscapingCharacterNode struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Symbol        string                 `protobuf:"bytes,1,opt,name=symbol,proto3" json:"symbol,omitempty"`
-	Content       string                 `protobuf:"bytes,2,opt,name=content,proto3" json:"content,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *BoldItalicNode) Reset() {
-	*x = BoldItalicNode{}
-	mi := &file_api_v1_markdown_service_proto_msgTypes[25]
+func (x *EscapingCharacterNode) Reset() {
+	*x = EscapingCharacterNode{}
+	mi := &file_api_v1_markdown_service_proto_msgTypes[51]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *BoldItalicNode) String() string {
+func (x *EscapingCharacterNode) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*BoldItalicNode) ProtoMessage() {}
+func (*EscapingCharacterNode) ProtoMessage() {}
 
-func (x *BoldItalicNode) ProtoReflect() protoreflect.Message {
-	mi := &file_api_v1_markdown_service_proto_msgTypes[25]
+func (x *EscapingCharacterNode) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_markdown_service_proto_msgTypes[51]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1993,47 +4287,40 @@ func (x *BoldItalicNode) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use BoldItalicNode.ProtoReflect.Descriptor instead.
-func (*BoldItalicNode) Descriptor() ([]byte, []int) {
-	return file_api_v1_markdown_service_proto_rawDescGZIP(), []int{25}
+// Deprecated: Use EscapingCharacterNode.ProtoReflect.Descriptor instead.
+func (*EscapingCharacterNode) Descriptor() ([]byte, []int) {
+	return file_api_v1_markdown_service_proto_rawDescGZIP(), []int{51}
 }
 
-func (x *BoldItalicNode) GetSymbol() string {
+func (x *EscapingCharacterNode) GetSymbol() string {
 	if x != nil {
 		return x.Symbol
 	}
 	return ""
 }
 
-func (x *BoldItalicNode) GetContent() string {
-	if x != nil {
-		return x.Content
-	}
-	return ""
-}
-
-type CodeNode struct {
+type MathNode struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Content       string                 `protobuf:"bytes,1,opt,name=content,proto3" json:"content,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *CodeNode) Reset() {
-	*x = CodeNode{}
-	mi := &file_api_v1_markdown_service_proto_msgTypes[26]
+func (x *MathNode) Reset() {
+	*x = MathNode{}
+	mi := &file_api_v1_markdown_service_proto_msgTypes[52]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *CodeNode) String() string {
+func (x *MathNode) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CodeNode) ProtoMessage() {}
+func (*MathNode) ProtoMessage() {}
 
-func (x *CodeNode) ProtoReflect() protoreflect.Message {
-	mi := &file_api_v1_markdown_service_proto_msgTypes[26]
+func (x *MathNode) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_markdown_service_proto_msgTypes[52]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2044,41 +4331,40 @@ func (x *CodeNode) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CodeNode.ProtoReflect.Descriptor instead.
-func (*CodeNode) Descriptor() ([]byte, []int) {
-	return file_api_v1_markdown_service_proto_rawDescGZIP(), []int{26}
+// Deprecated: Use MathNode.ProtoReflect.Descriptor instead.
+func (*MathNode) Descriptor() ([]byte, []int) {
+	return file_api_v1_markdown_service_proto_rawDescGZIP(), []int{52}
 }
 
-func (x *CodeNode) GetContent() string {
+func (x *MathNode) GetContent() string {
 	if x != nil {
 		return x.Content
 	}
 	return ""
 }
 
-type ImageNode struct {
+type HighlightNode struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	AltText       string                 `protobuf:"bytes,1,opt,name=alt_text,json=altText,proto3" json:"alt_text,omitempty"`
-	Url           string                 `protobuf:"bytes,2,opt,name=url,proto3" json:"url,omitempty"`
+	Content       string                 `protobuf:"bytes,1,opt,name=content,proto3" json:"content,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ImageNode) Reset() {
-	*x = ImageNode{}
-	mi := &file_api_v1_markdown_service_proto_msgTypes[27]
+func (x *HighlightNode) Reset() {
+	*x = HighlightNode{}
+	mi := &file_api_v1_markdown_service_proto_msgTypes[53]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ImageNode) String() string {
+func (x *HighlightNode) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ImageNode) ProtoMessage() {}
+func (*HighlightNode) ProtoMessage() {}
 
-func (x *ImageNode) ProtoReflect() protoreflect.Message {
-	mi := &file_api_v1_markdown_service_proto_msgTypes[27]
+func (x *HighlightNode) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_markdown_service_proto_msgTypes[53]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2089,48 +4375,40 @@ func (x *ImageNode) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ImageNode.ProtoReflect.Descriptor instead.
-func (*ImageNode) Descriptor() ([]byte, []int) {
-	return file_api_v1_markdown_service_proto_rawDescGZIP(), []int{27}
-}
-
-func (x *ImageNode) GetAltText() string {
-	if x != nil {
-		return x.AltText
-	}
-	return ""
+// Deprecated: Use HighlightNode.ProtoReflect.Descriptor instead.
+func (*HighlightNode) Descriptor() ([]byte, []int) {
+	return file_api_v1_markdown_service_proto_rawDescGZIP(), []int{53}
 }
 
-func (x *ImageNode) GetUrl() string {
+func (x *HighlightNode) GetContent() string {
 	if x != nil {
-		return x.Url
+		return x.Content
 	}
 	return ""
 }
 
-type LinkNode struct {
+type SubscriptNode struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Content       []*Node                `protobuf:"bytes,1,rep,name=content,proto3" json:"content,omitempty"`
-	Url           string                 `protobuf:"bytes,2,opt,name=url,proto3" json:"url,omitempty"`
+	Content       string                 `protobuf:"bytes,1,opt,name=content,proto3" json:"content,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *LinkNode) Reset() {
-	*x = LinkNode{}
-	mi := &file_api_v1_markdown_service_proto_msgTypes[28]
+func (x *SubscriptNode) Reset() {
+	*x = SubscriptNode{}
+	mi := &file_api_v1_markdown_service_proto_msgTypes[54]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *LinkNode) String() string {
+func (x *SubscriptNode) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*LinkNode) ProtoMessage() {}
+func (*SubscriptNode) ProtoMessage() {}
 
-func (x *LinkNode) ProtoReflect() protoreflect.Message {
-	mi := &file_api_v1_markdown_service_proto_msgTypes[28]
+func (x *SubscriptNode) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_markdown_service_proto_msgTypes[54]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2141,48 +4419,40 @@ func (x *LinkNode) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use LinkNode.ProtoReflect.Descriptor instead.
-func (*LinkNode) Descriptor() ([]byte, []int) {
-	return file_api_v1_markdown_service_proto_rawDescGZIP(), []int{28}
+// Deprecated: Use SubscriptNode.ProtoReflect.Descriptor instead.
+func (*SubscriptNode) Descriptor() ([]byte, []int) {
+	return file_api_v1_markdown_service_proto_rawDescGZIP(), []int{54}
 }
 
-func (x *LinkNode) GetContent() []*Node {
+func (x *SubscriptNode) GetContent() string {
 	if x != nil {
 		return x.Content
 	}
-	return nil
-}
-
-func (x *LinkNode) GetUrl() string {
-	if x != nil {
-		return x.Url
-	}
 	return ""
 }
 
-type AutoLinkNode struct {
+type SuperscriptNode struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Url           string                 `protobuf:"bytes,1,opt,name=url,proto3" json:"url,omitempty"`
-	IsRawText     bool                   `protobuf:"varint,2,opt,name=is_raw_text,json=isRawText,proto3" json:"is_raw_text,omitempty"`
+	Content       string                 `protobuf:"bytes,1,opt,name=content,proto3" json:"content,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *AutoLinkNode) Reset() {
-	*x = AutoLinkNode{}
-	mi := &file_api_v1_markdown_service_proto_msgTypes[29]
+func (x *SuperscriptNode) Reset() {
+	*x = SuperscriptNode{}
+	mi := &file_api_v1_markdown_service_proto_msgTypes[55]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *AutoLinkNode) String() string {
+func (x *SuperscriptNode) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*AutoLinkNode) ProtoMessage() {}
+func (*SuperscriptNode) ProtoMessage() {}
 
-func (x *AutoLinkNode) ProtoReflect() protoreflect.Message {
-	mi := &file_api_v1_markdown_service_proto_msgTypes[29]
+func (x *SuperscriptNode) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_markdown_service_proto_msgTypes[55]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2193,47 +4463,41 @@ func (x *AutoLinkNode) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use AutoLinkNode.ProtoReflect.Descriptor instead.
-func (*AutoLinkNode) Descriptor() ([]byte, []int) {
-	return file_api_v1_markdown_service_proto_rawDescGZIP(), []int{29}
+// Deprecated: Use SuperscriptNode.ProtoReflect.Descriptor instead.
+func (*SuperscriptNode) Descriptor() ([]byte, []int) {
+	return file_api_v1_markdown_service_proto_rawDescGZIP(), []int{55}
 }
 
-func (x *AutoLinkNode) GetUrl() string {
+func (x *SuperscriptNode) GetContent() string {
 	if x != nil {
-		return x.Url
+		return x.Content
 	}
 	return ""
 }
 
-func (x *AutoLinkNode) GetIsRawText() bool {
-	if x != nil {
-		return x.IsRawText
-	}
-	return false
-}
-
-type TagNode struct {
+type ReferencedContentNode struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Content       string                 `protobuf:"bytes,1,opt,name=content,proto3" json:"content,omitempty"`
+	ResourceName  string                 `protobuf:"bytes,1,opt,name=resource_name,json=resourceName,proto3" json:"resource_name,omitempty"`
+	Params        string                 `protobuf:"bytes,2,opt,name=params,proto3" json:"params,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *TagNode) Reset() {
-	*x = TagNode{}
-	mi := &file_api_v1_markdown_service_proto_msgTypes[30]
+func (x *ReferencedContentNode) Reset() {
+	*x = ReferencedContentNode{}
+	mi := &file_api_v1_markdown_service_proto_msgTypes[56]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *TagNode) String() string {
+func (x *ReferencedContentNode) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*TagNode) ProtoMessage() {}
+func (*ReferencedContentNode) ProtoMessage() {}
 
-func (x *TagNode) ProtoReflect() protoreflect.Message {
-	mi := &file_api_v1_markdown_service_proto_msgTypes[30]
+func (x *ReferencedContentNode) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_markdown_service_proto_msgTypes[56]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2244,40 +4508,47 @@ func (x *TagNode) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use TagNode.ProtoReflect.Descriptor instead.
-func (*TagNode) Descriptor() ([]byte, []int) {
-	return file_api_v1_markdown_service_proto_rawDescGZIP(), []int{30}
+// Deprecated: Use ReferencedContentNode.ProtoReflect.Descriptor instead.
+func (*ReferencedContentNode) Descriptor() ([]byte, []int) {
+	return file_api_v1_markdown_service_proto_rawDescGZIP(), []int{56}
 }
 
-func (x *TagNode) GetContent() string {
+func (x *ReferencedContentNode) GetResourceName() string {
 	if x != nil {
-		return x.Content
+		return x.ResourceName
 	}
 	return ""
 }
 
-type StrikethroughNode struct {
+func (x *ReferencedContentNode) GetParams() string {
+	if x != nil {
+		return x.Params
+	}
+	return ""
+}
+
+type SpoilerNode struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Content       string                 `protobuf:"bytes,1,opt,name=content,proto3" json:"content,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *StrikethroughNode) Reset() {
-	*x = StrikethroughNode{}
-	mi := &file_api_v1_markdown_service_proto_msgTypes[31]
+func (x *SpoilerNode) Reset() {
+	*x = SpoilerNode{}
+	mi := &file_api_v1_markdown_service_proto_msgTypes[57]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *StrikethroughNode) String() string {
+func (x *SpoilerNode) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*StrikethroughNode) ProtoMessage() {}
+func (*SpoilerNode) ProtoMessage() {}
 
-func (x *StrikethroughNode) ProtoReflect() protoreflect.Message {
-	mi := &file_api_v1_markdown_service_proto_msgTypes[31]
+func (x *SpoilerNode) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_markdown_service_proto_msgTypes[57]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2288,40 +4559,41 @@ func (x *StrikethroughNode) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use StrikethroughNode.ProtoReflect.Descriptor instead.
-func (*StrikethroughNode) Descriptor() ([]byte, []int) {
-	return file_api_v1_markdown_service_proto_rawDescGZIP(), []int{31}
+// Deprecated: Use SpoilerNode.ProtoReflect.Descriptor instead.
+func (*SpoilerNode) Descriptor() ([]byte, []int) {
+	return file_api_v1_markdown_service_proto_rawDescGZIP(), []int{57}
 }
 
-func (x *StrikethroughNode) GetContent() string {
+func (x *SpoilerNode) GetContent() string {
 	if x != nil {
 		return x.Content
 	}
 	return ""
 }
 
-type EscapingCharacterNode struct {
+type HTMLElementNode struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Symbol        string                 `protobuf:"bytes,1,opt,name=symbol,proto3" json:"symbol,omitempty"`
+	TagName       string                 `protobuf:"bytes,1,opt,name=tag_name,json=tagName,proto3" json:"tag_name,omitempty"`
+	Attributes    map[string]string      `protobuf:"bytes,2,rep,name=attributes,proto3" json:"attributes,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *EscapingCharacterNode) Reset() {
-	*x = EscapingCharacterNode{}
-	mi := &file_api_v1_markdown_service_proto_msgTypes[32]
+func (x *HTMLElementNode) Reset() {
+	*x = HTMLElementNode{}
+	mi := &file_api_v1_markdown_service_proto_msgTypes[58]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *EscapingCharacterNode) String() string {
+func (x *HTMLElementNode) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*EscapingCharacterNode) ProtoMessage() {}
+func (*HTMLElementNode) ProtoMessage() {}
 
-func (x *EscapingCharacterNode) ProtoReflect() protoreflect.Message {
-	mi := &file_api_v1_markdown_service_proto_msgTypes[32]
+func (x *HTMLElementNode) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_markdown_service_proto_msgTypes[58]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2332,40 +4604,50 @@ func (x *EscapingCharacterNode) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use EscapingCharacterNode.ProtoReflect.Descriptor instead.
-func (*EscapingCharacterNode) Descriptor() ([]byte, []int) {
-	return file_api_v1_markdown_service_proto_rawDescGZIP(), []int{32}
+// Deprecated: Use HTMLElementNode.ProtoReflect.Descriptor instead.
+func (*HTMLElementNode) Descriptor() ([]byte, []int) {
+	return file_api_v1_markdown_service_proto_rawDescGZIP(), []int{58}
 }
 
-func (x *EscapingCharacterNode) GetSymbol() string {
+func (x *HTMLElementNode) GetTagName() string {
 	if x != nil {
-		return x.Symbol
+		return x.TagName
 	}
 	return ""
 }
 
-type MathNode struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Content       string                 `protobuf:"bytes,1,opt,name=content,proto3" json:"content,omitempty"`
+func (x *HTMLElementNode) GetAttributes() map[string]string {
+	if x != nil {
+		return x.Attributes
+	}
+	return nil
+}
+
+type EmojiNode struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The shortcode without colons, e.g. "smile".
+	Shortcode string `protobuf:"bytes,1,opt,name=shortcode,proto3" json:"shortcode,omitempty"`
+	// The unicode of the emoji, e.g. "😄".
+	Unicode       string `protobuf:"bytes,2,opt,name=unicode,proto3" json:"unicode,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *MathNode) Reset() {
-	*x = MathNode{}
-	mi := &file_api_v1_markdown_service_proto_msgTypes[33]
+func (x *EmojiNode) Reset() {
+	*x = EmojiNode{}
+	mi := &file_api_v1_markdown_service_proto_msgTypes[59]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *MathNode) String() string {
+func (x *EmojiNode) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*MathNode) ProtoMessage() {}
+func (*EmojiNode) ProtoMessage() {}
 
-func (x *MathNode) ProtoReflect() protoreflect.Message {
-	mi := &file_api_v1_markdown_service_proto_msgTypes[33]
+func (x *EmojiNode) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_markdown_service_proto_msgTypes[59]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2376,40 +4658,54 @@ func (x *MathNode) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use MathNode.ProtoReflect.Descriptor instead.
-func (*MathNode) Descriptor() ([]byte, []int) {
-	return file_api_v1_markdown_service_proto_rawDescGZIP(), []int{33}
+// Deprecated: Use EmojiNode.ProtoReflect.Descriptor instead.
+func (*EmojiNode) Descriptor() ([]byte, []int) {
+	return file_api_v1_markdown_service_proto_rawDescGZIP(), []int{59}
 }
 
-func (x *MathNode) GetContent() string {
+func (x *EmojiNode) GetShortcode() string {
 	if x != nil {
-		return x.Content
+		return x.Shortcode
 	}
 	return ""
 }
 
-type HighlightNode struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Content       string                 `protobuf:"bytes,1,opt,name=content,proto3" json:"content,omitempty"`
+func (x *EmojiNode) GetUnicode() string {
+	if x != nil {
+		return x.Unicode
+	}
+	return ""
+}
+
+// CustomNode is an inline node of a markdown extension registered with the server, e.g. for
+// "#ticket-123" linking to an issue tracker.
+type CustomNode struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The name of the extension that parsed the node.
+	Extension string `protobuf:"bytes,1,opt,name=extension,proto3" json:"extension,omitempty"`
+	// The markdown the node was parsed from, which it is restored to.
+	Raw string `protobuf:"bytes,2,opt,name=raw,proto3" json:"raw,omitempty"`
+	// The data the extension extracted from the markdown, e.g. the ticket number.
+	Attributes    map[string]string `protobuf:"bytes,3,rep,name=attributes,proto3" json:"attributes,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *HighlightNode) Reset() {
-	*x = HighlightNode{}
-	mi := &file_api_v1_markdown_service_proto_msgTypes[34]
+func (x *CustomNode) Reset() {
+	*x = CustomNode{}
+	mi := &file_api_v1_markdown_service_proto_msgTypes[60]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *HighlightNode) String() string {
+func (x *CustomNode) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*HighlightNode) ProtoMessage() {}
+func (*CustomNode) ProtoMessage() {}
 
-func (x *HighlightNode) ProtoReflect() protoreflect.Message {
-	mi := &file_api_v1_markdown_service_proto_msgTypes[34]
+func (x *CustomNode) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_markdown_service_proto_msgTypes[60]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2420,40 +4716,58 @@ func (x *HighlightNode) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use HighlightNode.ProtoReflect.Descriptor instead.
-func (*HighlightNode) Descriptor() ([]byte, []int) {
-	return file_api_v1_markdown_service_proto_rawDescGZIP(), []int{34}
+// Deprecated: Use CustomNode.ProtoReflect.Descriptor instead.
+func (*CustomNode) Descriptor() ([]byte, []int) {
+	return file_api_v1_markdown_service_proto_rawDescGZIP(), []int{60}
 }
 
-func (x *HighlightNode) GetContent() string {
+func (x *CustomNode) GetExtension() string {
 	if x != nil {
-		return x.Content
+		return x.Extension
 	}
 	return ""
 }
 
-type SubscriptNode struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Content       string                 `protobuf:"bytes,1,opt,name=content,proto3" json:"content,omitempty"`
+func (x *CustomNode) GetRaw() string {
+	if x != nil {
+		return x.Raw
+	}
+	return ""
+}
+
+func (x *CustomNode) GetAttributes() map[string]string {
+	if x != nil {
+		return x.Attributes
+	}
+	return nil
+}
+
+// FootnoteRefNode is a reference to a footnote, e.g. "[^1]".
+type FootnoteRefNode struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The label of the referenced footnote definition.
+	Label string `protobuf:"bytes,1,opt,name=label,proto3" json:"label,omitempty"`
+	// The number of the footnote, see FootnoteDefNode.
+	Number        int32 `protobuf:"varint,2,opt,name=number,proto3" json:"number,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *SubscriptNode) Reset() {
-	*x = SubscriptNode{}
-	mi := &file_api_v1_markdown_service_proto_msgTypes[35]
+func (x *FootnoteRefNode) Reset() {
+	*x = FootnoteRefNode{}
+	mi := &file_api_v1_markdown_service_proto_msgTypes[61]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *SubscriptNode) String() string {
+func (x *FootnoteRefNode) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SubscriptNode) ProtoMessage() {}
+func (*FootnoteRefNode) ProtoMessage() {}
 
-func (x *SubscriptNode) ProtoReflect() protoreflect.Message {
-	mi := &file_api_v1_markdown_service_proto_msgTypes[35]
+func (x *FootnoteRefNode) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_markdown_service_proto_msgTypes[61]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2464,40 +4778,50 @@ func (x *SubscriptNode) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SubscriptNode.ProtoReflect.Descriptor instead.
-func (*SubscriptNode) Descriptor() ([]byte, []int) {
-	return file_api_v1_markdown_service_proto_rawDescGZIP(), []int{35}
+// Deprecated: Use FootnoteRefNode.ProtoReflect.Descriptor instead.
+func (*FootnoteRefNode) Descriptor() ([]byte, []int) {
+	return file_api_v1_markdown_service_proto_rawDescGZIP(), []int{61}
 }
 
-func (x *SubscriptNode) GetContent() string {
+func (x *FootnoteRefNode) GetLabel() string {
 	if x != nil {
-		return x.Content
+		return x.Label
 	}
 	return ""
 }
 
-type SuperscriptNode struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Content       string                 `protobuf:"bytes,1,opt,name=content,proto3" json:"content,omitempty"`
+func (x *FootnoteRefNode) GetNumber() int32 {
+	if x != nil {
+		return x.Number
+	}
+	return 0
+}
+
+type BatchParseMarkdownResponse_Result struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The parsed nodes of the content.
+	Nodes []*Node `protobuf:"bytes,1,rep,name=nodes,proto3" json:"nodes,omitempty"`
+	// The error message when the content fails to parse.
+	Error         string `protobuf:"bytes,2,opt,name=error,proto3" json:"error,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *SuperscriptNode) Reset() {
-	*x = SuperscriptNode{}
-	mi := &file_api_v1_markdown_service_proto_msgTypes[36]
+func (x *BatchParseMarkdownResponse_Result) Reset() {
+	*x = BatchParseMarkdownResponse_Result{}
+	mi := &file_api_v1_markdown_service_proto_msgTypes[63]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *SuperscriptNode) String() string {
+func (x *BatchParseMarkdownResponse_Result) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*SuperscriptNode) ProtoMessage() {}
+func (*BatchParseMarkdownResponse_Result) ProtoMessage() {}
 
-func (x *SuperscriptNode) ProtoReflect() protoreflect.Message {
-	mi := &file_api_v1_markdown_service_proto_msgTypes[36]
+func (x *BatchParseMarkdownResponse_Result) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_markdown_service_proto_msgTypes[63]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2508,41 +4832,59 @@ func (x *SuperscriptNode) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use SuperscriptNode.ProtoReflect.Descriptor instead.
-func (*SuperscriptNode) Descriptor() ([]byte, []int) {
-	return file_api_v1_markdown_service_proto_rawDescGZIP(), []int{36}
+// Deprecated: Use BatchParseMarkdownResponse_Result.ProtoReflect.Descriptor instead.
+func (*BatchParseMarkdownResponse_Result) Descriptor() ([]byte, []int) {
+	return file_api_v1_markdown_service_proto_rawDescGZIP(), []int{4, 0}
 }
 
-func (x *SuperscriptNode) GetContent() string {
+func (x *BatchParseMarkdownResponse_Result) GetNodes() []*Node {
 	if x != nil {
-		return x.Content
+		return x.Nodes
 	}
-	return ""
+	return nil
 }
 
-type ReferencedContentNode struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	ResourceName  string                 `protobuf:"bytes,1,opt,name=resource_name,json=resourceName,proto3" json:"resource_name,omitempty"`
-	Params        string                 `protobuf:"bytes,2,opt,name=params,proto3" json:"params,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+func (x *BatchParseMarkdownResponse_Result) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
 }
 
-func (x *ReferencedContentNode) Reset() {
-	*x = ReferencedContentNode{}
-	mi := &file_api_v1_markdown_service_proto_msgTypes[37]
+type LinkMetadata_OEmbed struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The oEmbed type, e.g. "video" or "rich".
+	Type  string `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`
+	Title string `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
+	// The HTML to embed, as returned by the provider.
+	Html            string `protobuf:"bytes,3,opt,name=html,proto3" json:"html,omitempty"`
+	Width           int32  `protobuf:"varint,4,opt,name=width,proto3" json:"width,omitempty"`
+	Height          int32  `protobuf:"varint,5,opt,name=height,proto3" json:"height,omitempty"`
+	ThumbnailUrl    string `protobuf:"bytes,6,opt,name=thumbnail_url,json=thumbnailUrl,proto3" json:"thumbnail_url,omitempty"`
+	ThumbnailWidth  int32  `protobuf:"varint,7,opt,name=thumbnail_width,json=thumbnailWidth,proto3" json:"thumbnail_width,omitempty"`
+	ThumbnailHeight int32  `protobuf:"varint,8,opt,name=thumbnail_height,json=thumbnailHeight,proto3" json:"thumbnail_height,omitempty"`
+	ProviderName    string `protobuf:"bytes,9,opt,name=provider_name,json=providerName,proto3" json:"provider_name,omitempty"`
+	ProviderUrl     string `protobuf:"bytes,10,opt,name=provider_url,json=providerUrl,proto3" json:"provider_url,omitempty"`
+	AuthorName      string `protobuf:"bytes,11,opt,name=author_name,json=authorName,proto3" json:"author_name,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *LinkMetadata_OEmbed) Reset() {
+	*x = LinkMetadata_OEmbed{}
+	mi := &file_api_v1_markdown_service_proto_msgTypes[64]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ReferencedContentNode) String() string {
+func (x *LinkMetadata_OEmbed) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ReferencedContentNode) ProtoMessage() {}
+func (*LinkMetadata_OEmbed) ProtoMessage() {}
 
-func (x *ReferencedContentNode) ProtoReflect() protoreflect.Message {
-	mi := &file_api_v1_markdown_service_proto_msgTypes[37]
+func (x *LinkMetadata_OEmbed) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_markdown_service_proto_msgTypes[64]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2553,92 +4895,118 @@ func (x *ReferencedContentNode) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ReferencedContentNode.ProtoReflect.Descriptor instead.
-func (*ReferencedContentNode) Descriptor() ([]byte, []int) {
-	return file_api_v1_markdown_service_proto_rawDescGZIP(), []int{37}
+// Deprecated: Use LinkMetadata_OEmbed.ProtoReflect.Descriptor instead.
+func (*LinkMetadata_OEmbed) Descriptor() ([]byte, []int) {
+	return file_api_v1_markdown_service_proto_rawDescGZIP(), []int{23, 0}
 }
 
-func (x *ReferencedContentNode) GetResourceName() string {
+func (x *LinkMetadata_OEmbed) GetType() string {
 	if x != nil {
-		return x.ResourceName
+		return x.Type
 	}
 	return ""
 }
 
-func (x *ReferencedContentNode) GetParams() string {
+func (x *LinkMetadata_OEmbed) GetTitle() string {
 	if x != nil {
-		return x.Params
+		return x.Title
 	}
 	return ""
 }
 
-type SpoilerNode struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Content       string                 `protobuf:"bytes,1,opt,name=content,proto3" json:"content,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+func (x *LinkMetadata_OEmbed) GetHtml() string {
+	if x != nil {
+		return x.Html
+	}
+	return ""
 }
 
-func (x *SpoilerNode) Reset() {
-	*x = SpoilerNode{}
-	mi := &file_api_v1_markdown_service_proto_msgTypes[38]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
+func (x *LinkMetadata_OEmbed) GetWidth() int32 {
+	if x != nil {
+		return x.Width
+	}
+	return 0
 }
 
-func (x *SpoilerNode) String() string {
-	return protoimpl.X.MessageStringOf(x)
+func (x *LinkMetadata_OEmbed) GetHeight() int32 {
+	if x != nil {
+		return x.Height
+	}
+	return 0
 }
 
-func (*SpoilerNode) ProtoMessage() {}
+func (x *LinkMetadata_OEmbed) GetThumbnailUrl() string {
+	if x != nil {
+		return x.ThumbnailUrl
+	}
+	return ""
+}
 
-func (x *SpoilerNode) ProtoReflect() protoreflect.Message {
-	mi := &file_api_v1_markdown_service_proto_msgTypes[38]
+func (x *LinkMetadata_OEmbed) GetThumbnailWidth() int32 {
 	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+		return x.ThumbnailWidth
 	}
-	return mi.MessageOf(x)
+	return 0
 }
 
-// Deprecated: Use SpoilerNode.ProtoReflect.Descriptor instead.
-func (*SpoilerNode) Descriptor() ([]byte, []int) {
-	return file_api_v1_markdown_service_proto_rawDescGZIP(), []int{38}
+func (x *LinkMetadata_OEmbed) GetThumbnailHeight() int32 {
+	if x != nil {
+		return x.ThumbnailHeight
+	}
+	return 0
 }
 
-func (x *SpoilerNode) GetContent() string {
+func (x *LinkMetadata_OEmbed) GetProviderName() string {
 	if x != nil {
-		return x.Content
+		return x.ProviderName
 	}
 	return ""
 }
 
-type HTMLElementNode struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	TagName       string                 `protobuf:"bytes,1,opt,name=tag_name,json=tagName,proto3" json:"tag_name,omitempty"`
-	Attributes    map[string]string      `protobuf:"bytes,2,rep,name=attributes,proto3" json:"attributes,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+func (x *LinkMetadata_OEmbed) GetProviderUrl() string {
+	if x != nil {
+		return x.ProviderUrl
+	}
+	return ""
 }
 
-func (x *HTMLElementNode) Reset() {
-	*x = HTMLElementNode{}
-	mi := &file_api_v1_markdown_service_proto_msgTypes[39]
+func (x *LinkMetadata_OEmbed) GetAuthorName() string {
+	if x != nil {
+		return x.AuthorName
+	}
+	return ""
+}
+
+type LinkMetadata_Article struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// The article as markdown, keeping its headings, paragraphs, lists, quotes, code blocks, links
+	// and emphasis. Links are absolute.
+	Markdown string `protobuf:"bytes,1,opt,name=markdown,proto3" json:"markdown,omitempty"`
+	// The plain text of the article, with the blocks separated by blank lines.
+	Text string `protobuf:"bytes,2,opt,name=text,proto3" json:"text,omitempty"`
+	// The number of words of the article, counting each CJK character as a word.
+	WordCount int32 `protobuf:"varint,3,opt,name=word_count,json=wordCount,proto3" json:"word_count,omitempty"`
+	// The estimated reading time in minutes, rounded up.
+	ReadingMinutes int32 `protobuf:"varint,4,opt,name=reading_minutes,json=readingMinutes,proto3" json:"reading_minutes,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *LinkMetadata_Article) Reset() {
+	*x = LinkMetadata_Article{}
+	mi := &file_api_v1_markdown_service_proto_msgTypes[65]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *HTMLElementNode) String() string {
+func (x *LinkMetadata_Article) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*HTMLElementNode) ProtoMessage() {}
+func (*LinkMetadata_Article) ProtoMessage() {}
 
-func (x *HTMLElementNode) ProtoReflect() protoreflect.Message {
-	mi := &file_api_v1_markdown_service_proto_msgTypes[39]
+func (x *LinkMetadata_Article) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_markdown_service_proto_msgTypes[65]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2649,23 +5017,37 @@ func (x *HTMLElementNode) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use HTMLElementNode.ProtoReflect.Descriptor instead.
-func (*HTMLElementNode) Descriptor() ([]byte, []int) {
-	return file_api_v1_markdown_service_proto_rawDescGZIP(), []int{39}
+// Deprecated: Use LinkMetadata_Article.ProtoReflect.Descriptor instead.
+func (*LinkMetadata_Article) Descriptor() ([]byte, []int) {
+	return file_api_v1_markdown_service_proto_rawDescGZIP(), []int{23, 1}
 }
 
-func (x *HTMLElementNode) GetTagName() string {
+func (x *LinkMetadata_Article) GetMarkdown() string {
 	if x != nil {
-		return x.TagName
+		return x.Markdown
 	}
 	return ""
 }
 
-func (x *HTMLElementNode) GetAttributes() map[string]string {
+func (x *LinkMetadata_Article) GetText() string {
 	if x != nil {
-		return x.Attributes
+		return x.Text
 	}
-	return nil
+	return ""
+}
+
+func (x *LinkMetadata_Article) GetWordCount() int32 {
+	if x != nil {
+		return x.WordCount
+	}
+	return 0
+}
+
+func (x *LinkMetadata_Article) GetReadingMinutes() int32 {
+	if x != nil {
+		return x.ReadingMinutes
+	}
+	return 0
 }
 
 type TableNode_Row struct {
@@ -2677,7 +5059,7 @@ type TableNode_Row struct {
 
 func (x *TableNode_Row) Reset() {
 	*x = TableNode_Row{}
-	mi := &file_api_v1_markdown_service_proto_msgTypes[40]
+	mi := &file_api_v1_markdown_service_proto_msgTypes[66]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2689,7 +5071,7 @@ func (x *TableNode_Row) String() string {
 func (*TableNode_Row) ProtoMessage() {}
 
 func (x *TableNode_Row) ProtoReflect() protoreflect.Message {
-	mi := &file_api_v1_markdown_service_proto_msgTypes[40]
+	mi := &file_api_v1_markdown_service_proto_msgTypes[66]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2702,7 +5084,7 @@ func (x *TableNode_Row) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use TableNode_Row.ProtoReflect.Descriptor instead.
 func (*TableNode_Row) Descriptor() ([]byte, []int) {
-	return file_api_v1_markdown_service_proto_rawDescGZIP(), []int{20, 0}
+	return file_api_v1_markdown_service_proto_rawDescGZIP(), []int{37, 0}
 }
 
 func (x *TableNode_Row) GetCells() []*Node {
@@ -2716,28 +5098,185 @@ var File_api_v1_markdown_service_proto protoreflect.FileDescriptor
 
 const file_api_v1_markdown_service_proto_rawDesc = "" +
 	"\n" +
-	"\x1dapi/v1/markdown_service.proto\x12\fmemos.api.v1\x1a\x1cgoogle/api/annotations.proto\"2\n" +
+	"\x1dapi/v1/markdown_service.proto\x12\fmemos.api.v1\x1a\x1cgoogle/api/annotations.proto\"\xff\x05\n" +
 	"\x14ParseMarkdownRequest\x12\x1a\n" +
-	"\bmarkdown\x18\x01 \x01(\tR\bmarkdown\"A\n" +
+	"\bmarkdown\x18\x01 \x01(\tR\bmarkdown\x12\"\n" +
+	"\rauto_link_www\x18\x02 \x01(\bR\vautoLinkWww\x12+\n" +
+	"\x11include_positions\x18\x03 \x01(\bR\x10includePositions\x12 \n" +
+	"\vfrontmatter\x18\x04 \x01(\bR\vfrontmatter\x12\x12\n" +
+	"\x04math\x18\x05 \x01(\bR\x04math\x12*\n" +
+	"\x11max_nesting_depth\x18\x06 \x01(\x05R\x0fmaxNestingDepth\x12\x14\n" +
+	"\x05emoji\x18\a \x01(\bR\x05emoji\x12%\n" +
+	"\x0einclude_images\x18\b \x01(\bR\rincludeImages\x12R\n" +
+	"\rraw_html_mode\x18\t \x01(\x0e2..memos.api.v1.ParseMarkdownRequest.RawHTMLModeR\vrawHtmlMode\x12\x1c\n" +
+	"\tfootnotes\x18\n" +
+	" \x01(\bR\tfootnotes\x12%\n" +
+	"\x0ecode_languages\x18\v \x01(\bR\rcodeLanguages\x12o\n" +
+	"\x15code_language_aliases\x18\f \x03(\v2;.memos.api.v1.ParseMarkdownRequest.CodeLanguageAliasesEntryR\x13codeLanguageAliases\x12\x18\n" +
+	"\ascripts\x18\r \x01(\bR\ascripts\x12\x1f\n" +
+	"\vcode_fences\x18\x0e \x01(\bR\n" +
+	"codeFences\x1aF\n" +
+	"\x18CodeLanguageAliasesEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"N\n" +
+	"\vRawHTMLMode\x12\x1d\n" +
+	"\x19RAW_HTML_MODE_UNSPECIFIED\x10\x00\x12\t\n" +
+	"\x05ALLOW\x10\x01\x12\n" +
+	"\n" +
+	"\x06ESCAPE\x10\x02\x12\t\n" +
+	"\x05STRIP\x10\x03\"\xa9\x01\n" +
 	"\x15ParseMarkdownResponse\x12(\n" +
-	"\x05nodes\x18\x01 \x03(\v2\x12.memos.api.v1.NodeR\x05nodes\"G\n" +
+	"\x05nodes\x18\x01 \x03(\v2\x12.memos.api.v1.NodeR\x05nodes\x12\x12\n" +
+	"\x04tags\x18\x02 \x03(\tR\x04tags\x12\x1c\n" +
+	"\ttruncated\x18\x03 \x01(\bR\ttruncated\x124\n" +
+	"\x06images\x18\x04 \x03(\v2\x1c.memos.api.v1.ImageReferenceR\x06images\"^\n" +
+	"\x0eImageReference\x12\x10\n" +
+	"\x03url\x18\x01 \x01(\tR\x03url\x12\x19\n" +
+	"\balt_text\x18\x02 \x01(\tR\aaltText\x12\x1f\n" +
+	"\vis_relative\x18\x03 \x01(\bR\n" +
+	"isRelative\"q\n" +
+	"\x19BatchParseMarkdownRequest\x12\x1c\n" +
+	"\tmarkdowns\x18\x01 \x03(\tR\tmarkdowns\x12\"\n" +
+	"\rauto_link_www\x18\x02 \x01(\bR\vautoLinkWww\x12\x12\n" +
+	"\x04math\x18\x03 \x01(\bR\x04math\"\xb1\x01\n" +
+	"\x1aBatchParseMarkdownResponse\x12I\n" +
+	"\aresults\x18\x01 \x03(\v2/.memos.api.v1.BatchParseMarkdownResponse.ResultR\aresults\x1aH\n" +
+	"\x06Result\x12(\n" +
+	"\x05nodes\x18\x01 \x03(\v2\x12.memos.api.v1.NodeR\x05nodes\x12\x14\n" +
+	"\x05error\x18\x02 \x01(\tR\x05error\"_\n" +
 	"\x1bRestoreMarkdownNodesRequest\x12(\n" +
-	"\x05nodes\x18\x01 \x03(\v2\x12.memos.api.v1.NodeR\x05nodes\":\n" +
+	"\x05nodes\x18\x01 \x03(\v2\x12.memos.api.v1.NodeR\x05nodes\x12\x16\n" +
+	"\x06strict\x18\x02 \x01(\bR\x06strict\":\n" +
 	"\x1cRestoreMarkdownNodesResponse\x12\x1a\n" +
-	"\bmarkdown\x18\x01 \x01(\tR\bmarkdown\"I\n" +
+	"\bmarkdown\x18\x01 \x01(\tR\bmarkdown\"\xe6\x03\n" +
 	"\x1dStringifyMarkdownNodesRequest\x12(\n" +
-	"\x05nodes\x18\x01 \x03(\v2\x12.memos.api.v1.NodeR\x05nodes\"?\n" +
+	"\x05nodes\x18\x01 \x03(\v2\x12.memos.api.v1.NodeR\x05nodes\x12D\n" +
+	"\x04mode\x18\x02 \x01(\x0e20.memos.api.v1.StringifyMarkdownNodesRequest.ModeR\x04mode\x12\x1d\n" +
+	"\n" +
+	"wrap_width\x18\x03 \x01(\x05R\twrapWidth\x12Q\n" +
+	"\tlink_mode\x18\x04 \x01(\x0e24.memos.api.v1.StringifyMarkdownNodesRequest.LinkModeR\blinkMode\x12\x19\n" +
+	"\bbase_url\x18\x05 \x01(\tR\abaseUrl\x12#\n" +
+	"\remoji_unicode\x18\x06 \x01(\bR\femojiUnicode\"\\\n" +
+	"\x04Mode\x12\x14\n" +
+	"\x10MODE_UNSPECIFIED\x10\x00\x12\x0e\n" +
+	"\n" +
+	"PLAIN_TEXT\x10\x01\x12\a\n" +
+	"\x03GFM\x10\x02\x12\x0e\n" +
+	"\n" +
+	"COMMONMARK\x10\x03\x12\x15\n" +
+	"\x11TABLE_OF_CONTENTS\x10\x04\"E\n" +
+	"\bLinkMode\x12\x19\n" +
+	"\x15LINK_MODE_UNSPECIFIED\x10\x00\x12\x0e\n" +
+	"\n" +
+	"ABSOLUTIZE\x10\x01\x12\x0e\n" +
+	"\n" +
+	"RELATIVIZE\x10\x02\"\x8f\x01\n" +
 	"\x1eStringifyMarkdownNodesResponse\x12\x1d\n" +
 	"\n" +
-	"plain_text\x18\x01 \x01(\tR\tplainText\",\n" +
+	"plain_text\x18\x01 \x01(\tR\tplainText\x12N\n" +
+	"\x11table_of_contents\x18\x02 \x03(\v2\".memos.api.v1.TableOfContentsEntryR\x0ftableOfContents\"\x9a\x01\n" +
+	"\x14TableOfContentsEntry\x12\x14\n" +
+	"\x05title\x18\x01 \x01(\tR\x05title\x12\x14\n" +
+	"\x05level\x18\x02 \x01(\x05R\x05level\x12\x16\n" +
+	"\x06anchor\x18\x03 \x01(\tR\x06anchor\x12>\n" +
+	"\bchildren\x18\x04 \x03(\v2\".memos.api.v1.TableOfContentsEntryR\bchildren\"\xc2\x01\n" +
+	"\x18DiffMarkdownNodesRequest\x12/\n" +
+	"\told_nodes\x18\x01 \x03(\v2\x12.memos.api.v1.NodeR\boldNodes\x12/\n" +
+	"\tnew_nodes\x18\x02 \x03(\v2\x12.memos.api.v1.NodeR\bnewNodes\x12!\n" +
+	"\fold_markdown\x18\x03 \x01(\tR\voldMarkdown\x12!\n" +
+	"\fnew_markdown\x18\x04 \x01(\tR\vnewMarkdown\"Q\n" +
+	"\x19DiffMarkdownNodesResponse\x124\n" +
+	"\x05diffs\x18\x01 \x03(\v2\x1e.memos.api.v1.MarkdownNodeDiffR\x05diffs\"\xa2\x02\n" +
+	"\x10MarkdownNodeDiff\x127\n" +
+	"\x04type\x18\x01 \x01(\x0e2#.memos.api.v1.MarkdownNodeDiff.TypeR\x04type\x12\x19\n" +
+	"\bold_path\x18\x02 \x03(\x05R\aoldPath\x12\x19\n" +
+	"\bnew_path\x18\x03 \x03(\x05R\anewPath\x12-\n" +
+	"\bold_node\x18\x04 \x01(\v2\x12.memos.api.v1.NodeR\aoldNode\x12-\n" +
+	"\bnew_node\x18\x05 \x01(\v2\x12.memos.api.v1.NodeR\anewNode\"A\n" +
+	"\x04Type\x12\x14\n" +
+	"\x10TYPE_UNSPECIFIED\x10\x00\x12\t\n" +
+	"\x05ADDED\x10\x01\x12\v\n" +
+	"\aREMOVED\x10\x02\x12\v\n" +
+	"\aCHANGED\x10\x03\"]\n" +
+	"\x1bRenderMarkdownToHTMLRequest\x12\x1a\n" +
+	"\bmarkdown\x18\x01 \x01(\tR\bmarkdown\x12\"\n" +
+	"\rauto_link_www\x18\x02 \x01(\bR\vautoLinkWww\"2\n" +
+	"\x1cRenderMarkdownToHTMLResponse\x12\x12\n" +
+	"\x04html\x18\x01 \x01(\tR\x04html\"e\n" +
+	"\x17GetMarkdownStatsRequest\x12\x1a\n" +
+	"\bmarkdown\x18\x01 \x01(\tR\bmarkdown\x12.\n" +
+	"\x13include_code_blocks\x18\x02 \x01(\bR\x11includeCodeBlocks\"\x89\x01\n" +
+	"\rMarkdownStats\x12\x1d\n" +
+	"\n" +
+	"word_count\x18\x01 \x01(\x05R\twordCount\x12'\n" +
+	"\x0fcharacter_count\x18\x02 \x01(\x05R\x0echaracterCount\x120\n" +
+	"\x14reading_time_minutes\x18\x03 \x01(\x05R\x12readingTimeMinutes\"1\n" +
+	"\x13LintMarkdownRequest\x12\x1a\n" +
+	"\bmarkdown\x18\x01 \x01(\tR\bmarkdown\"Z\n" +
+	"\x14LintMarkdownResponse\x12B\n" +
+	"\vdiagnostics\x18\x01 \x03(\v2 .memos.api.v1.MarkdownDiagnosticR\vdiagnostics\"\xe7\x01\n" +
+	"\x12MarkdownDiagnostic\x12E\n" +
+	"\bseverity\x18\x01 \x01(\x0e2).memos.api.v1.MarkdownDiagnostic.SeverityR\bseverity\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\x122\n" +
+	"\bposition\x18\x03 \x01(\v2\x16.memos.api.v1.PositionR\bposition\"<\n" +
+	"\bSeverity\x12\x18\n" +
+	"\x14SEVERITY_UNSPECIFIED\x10\x00\x12\t\n" +
+	"\x05ERROR\x10\x01\x12\v\n" +
+	"\aWARNING\x10\x02\"\xdc\x01\n" +
+	"\x18NormalizeMarkdownRequest\x12\x1a\n" +
+	"\bmarkdown\x18\x01 \x01(\tR\bmarkdown\x12U\n" +
+	"\fbullet_style\x18\x02 \x01(\x0e22.memos.api.v1.NormalizeMarkdownRequest.BulletStyleR\vbulletStyle\"M\n" +
+	"\vBulletStyle\x12\x1c\n" +
+	"\x18BULLET_STYLE_UNSPECIFIED\x10\x00\x12\b\n" +
+	"\x04DASH\x10\x01\x12\f\n" +
+	"\bASTERISK\x10\x02\x12\b\n" +
+	"\x04PLUS\x10\x03\"7\n" +
+	"\x19NormalizeMarkdownResponse\x12\x1a\n" +
+	"\bmarkdown\x18\x01 \x01(\tR\bmarkdown\"z\n" +
 	"\x16GetLinkMetadataRequest\x12\x12\n" +
-	"\x04link\x18\x01 \x01(\tR\x04link\"\\\n" +
+	"\x04link\x18\x01 \x01(\tR\x04link\x12\x16\n" +
+	"\x06oembed\x18\x02 \x01(\bR\x06oembed\x12\x1a\n" +
+	"\blanguage\x18\x03 \x01(\tR\blanguage\x12\x18\n" +
+	"\aarticle\x18\x04 \x01(\bR\aarticle\"\x97\a\n" +
 	"\fLinkMetadata\x12\x14\n" +
 	"\x05title\x18\x01 \x01(\tR\x05title\x12 \n" +
 	"\vdescription\x18\x02 \x01(\tR\vdescription\x12\x14\n" +
-	"\x05image\x18\x03 \x01(\tR\x05image\"\xca\x11\n" +
+	"\x05image\x18\x03 \x01(\tR\x05image\x12\x1f\n" +
+	"\vfavicon_url\x18\x04 \x01(\tR\n" +
+	"faviconUrl\x12#\n" +
+	"\rcanonical_url\x18\x05 \x01(\tR\fcanonicalUrl\x129\n" +
+	"\x06oembed\x18\x06 \x01(\v2!.memos.api.v1.LinkMetadata.OEmbedR\x06oembed\x12\x1d\n" +
+	"\n" +
+	"media_type\x18\a \x01(\tR\tmediaType\x12\x1f\n" +
+	"\vimage_width\x18\b \x01(\x05R\n" +
+	"imageWidth\x12!\n" +
+	"\fimage_height\x18\t \x01(\x05R\vimageHeight\x12<\n" +
+	"\aarticle\x18\n" +
+	" \x01(\v2\".memos.api.v1.LinkMetadata.ArticleR\aarticle\x12:\n" +
+	"\x19article_extraction_failed\x18\v \x01(\bR\x17articleExtractionFailed\x1a\xd6\x02\n" +
+	"\x06OEmbed\x12\x12\n" +
+	"\x04type\x18\x01 \x01(\tR\x04type\x12\x14\n" +
+	"\x05title\x18\x02 \x01(\tR\x05title\x12\x12\n" +
+	"\x04html\x18\x03 \x01(\tR\x04html\x12\x14\n" +
+	"\x05width\x18\x04 \x01(\x05R\x05width\x12\x16\n" +
+	"\x06height\x18\x05 \x01(\x05R\x06height\x12#\n" +
+	"\rthumbnail_url\x18\x06 \x01(\tR\fthumbnailUrl\x12'\n" +
+	"\x0fthumbnail_width\x18\a \x01(\x05R\x0ethumbnailWidth\x12)\n" +
+	"\x10thumbnail_height\x18\b \x01(\x05R\x0fthumbnailHeight\x12#\n" +
+	"\rprovider_name\x18\t \x01(\tR\fproviderName\x12!\n" +
+	"\fprovider_url\x18\n" +
+	" \x01(\tR\vproviderUrl\x12\x1f\n" +
+	"\vauthor_name\x18\v \x01(\tR\n" +
+	"authorName\x1a\x81\x01\n" +
+	"\aArticle\x12\x1a\n" +
+	"\bmarkdown\x18\x01 \x01(\tR\bmarkdown\x12\x12\n" +
+	"\x04text\x18\x02 \x01(\tR\x04text\x12\x1d\n" +
+	"\n" +
+	"word_count\x18\x03 \x01(\x05R\twordCount\x12'\n" +
+	"\x0freading_minutes\x18\x04 \x01(\x05R\x0ereadingMinutes\"\xdb\x14\n" +
 	"\x04Node\x12*\n" +
-	"\x04type\x18\x01 \x01(\x0e2\x16.memos.api.v1.NodeTypeR\x04type\x12E\n" +
+	"\x04type\x18\x01 \x01(\x0e2\x16.memos.api.v1.NodeTypeR\x04type\x122\n" +
+	"\bposition\x18\x02 \x01(\v2\x16.memos.api.v1.PositionR\bposition\x12E\n" +
 	"\x0fline_break_node\x18\v \x01(\v2\x1b.memos.api.v1.LineBreakNodeH\x00R\rlineBreakNode\x12D\n" +
 	"\x0eparagraph_node\x18\f \x01(\v2\x1b.memos.api.v1.ParagraphNodeH\x00R\rparagraphNode\x12E\n" +
 	"\x0fcode_block_node\x18\r \x01(\v2\x1b.memos.api.v1.CodeBlockNodeH\x00R\rcodeBlockNode\x12>\n" +
@@ -2751,7 +5290,9 @@ const file_api_v1_markdown_service_proto_rawDesc = "" +
 	"\x0fmath_block_node\x18\x15 \x01(\v2\x1b.memos.api.v1.MathBlockNodeH\x00R\rmathBlockNode\x128\n" +
 	"\n" +
 	"table_node\x18\x16 \x01(\v2\x17.memos.api.v1.TableNodeH\x00R\ttableNode\x12W\n" +
-	"\x15embedded_content_node\x18\x17 \x01(\v2!.memos.api.v1.EmbeddedContentNodeH\x00R\x13embeddedContentNode\x125\n" +
+	"\x15embedded_content_node\x18\x17 \x01(\v2!.memos.api.v1.EmbeddedContentNodeH\x00R\x13embeddedContentNode\x12J\n" +
+	"\x10frontmatter_node\x18\x18 \x01(\v2\x1d.memos.api.v1.FrontmatterNodeH\x00R\x0ffrontmatterNode\x12K\n" +
+	"\x11footnote_def_node\x18\x19 \x01(\v2\x1d.memos.api.v1.FootnoteDefNodeH\x00R\x0ffootnoteDefNode\x125\n" +
 	"\ttext_node\x183 \x01(\v2\x16.memos.api.v1.TextNodeH\x00R\btextNode\x125\n" +
 	"\tbold_node\x184 \x01(\v2\x16.memos.api.v1.BoldNodeH\x00R\bboldNode\x12;\n" +
 	"\vitalic_node\x185 \x01(\v2\x18.memos.api.v1.ItalicNodeH\x00R\n" +
@@ -2771,43 +5312,65 @@ const file_api_v1_markdown_service_proto_rawDesc = "" +
 	"\x10superscript_node\x18A \x01(\v2\x1d.memos.api.v1.SuperscriptNodeH\x00R\x0fsuperscriptNode\x12]\n" +
 	"\x17referenced_content_node\x18B \x01(\v2#.memos.api.v1.ReferencedContentNodeH\x00R\x15referencedContentNode\x12>\n" +
 	"\fspoiler_node\x18C \x01(\v2\x19.memos.api.v1.SpoilerNodeH\x00R\vspoilerNode\x12K\n" +
-	"\x11html_element_node\x18D \x01(\v2\x1d.memos.api.v1.HTMLElementNodeH\x00R\x0fhtmlElementNodeB\x06\n" +
-	"\x04node\"\x0f\n" +
+	"\x11html_element_node\x18D \x01(\v2\x1d.memos.api.v1.HTMLElementNodeH\x00R\x0fhtmlElementNode\x128\n" +
+	"\n" +
+	"emoji_node\x18E \x01(\v2\x17.memos.api.v1.EmojiNodeH\x00R\temojiNode\x12;\n" +
+	"\vcustom_node\x18F \x01(\v2\x18.memos.api.v1.CustomNodeH\x00R\n" +
+	"customNode\x12K\n" +
+	"\x11footnote_ref_node\x18G \x01(\v2\x1d.memos.api.v1.FootnoteRefNodeH\x00R\x0ffootnoteRefNodeB\x06\n" +
+	"\x04node\"\xae\x01\n" +
+	"\bPosition\x12\x14\n" +
+	"\x05start\x18\x01 \x01(\x05R\x05start\x12\x10\n" +
+	"\x03end\x18\x02 \x01(\x05R\x03end\x12\x1d\n" +
+	"\n" +
+	"start_line\x18\x03 \x01(\x05R\tstartLine\x12!\n" +
+	"\fstart_column\x18\x04 \x01(\x05R\vstartColumn\x12\x19\n" +
+	"\bend_line\x18\x05 \x01(\x05R\aendLine\x12\x1d\n" +
+	"\n" +
+	"end_column\x18\x06 \x01(\x05R\tendColumn\"\x0f\n" +
 	"\rLineBreakNode\"?\n" +
 	"\rParagraphNode\x12.\n" +
-	"\bchildren\x18\x01 \x03(\v2\x12.memos.api.v1.NodeR\bchildren\"E\n" +
+	"\bchildren\x18\x01 \x03(\v2\x12.memos.api.v1.NodeR\bchildren\"\xb0\x01\n" +
 	"\rCodeBlockNode\x12\x1a\n" +
 	"\blanguage\x18\x01 \x01(\tR\blanguage\x12\x18\n" +
-	"\acontent\x18\x02 \x01(\tR\acontent\"S\n" +
+	"\acontent\x18\x02 \x01(\tR\acontent\x12\x12\n" +
+	"\x04info\x18\x03 \x01(\tR\x04info\x12\x1a\n" +
+	"\bmetadata\x18\x04 \x01(\tR\bmetadata\x12\x14\n" +
+	"\x05fence\x18\x05 \x01(\tR\x05fence\x12#\n" +
+	"\rclosing_fence\x18\x06 \x01(\tR\fclosingFence\"S\n" +
 	"\vHeadingNode\x12\x14\n" +
 	"\x05level\x18\x01 \x01(\x05R\x05level\x12.\n" +
 	"\bchildren\x18\x02 \x03(\v2\x12.memos.api.v1.NodeR\bchildren\",\n" +
 	"\x12HorizontalRuleNode\x12\x16\n" +
 	"\x06symbol\x18\x01 \x01(\tR\x06symbol\"@\n" +
 	"\x0eBlockquoteNode\x12.\n" +
-	"\bchildren\x18\x01 \x03(\v2\x12.memos.api.v1.NodeR\bchildren\"\xce\x01\n" +
+	"\bchildren\x18\x01 \x03(\v2\x12.memos.api.v1.NodeR\bchildren\"\xd8\x01\n" +
 	"\bListNode\x12/\n" +
 	"\x04kind\x18\x01 \x01(\x0e2\x1b.memos.api.v1.ListNode.KindR\x04kind\x12\x16\n" +
 	"\x06indent\x18\x02 \x01(\x05R\x06indent\x12.\n" +
-	"\bchildren\x18\x03 \x03(\v2\x12.memos.api.v1.NodeR\bchildren\"I\n" +
+	"\bchildren\x18\x03 \x03(\v2\x12.memos.api.v1.NodeR\bchildren\"S\n" +
 	"\x04Kind\x12\x14\n" +
 	"\x10KIND_UNSPECIFIED\x10\x00\x12\v\n" +
 	"\aORDERED\x10\x01\x12\r\n" +
 	"\tUNORDERED\x10\x02\x12\x0f\n" +
-	"\vDESCRIPTION\x10\x03\"u\n" +
+	"\vDESCRIPTION\x10\x03\x12\b\n" +
+	"\x04TASK\x10\x04\"\x9a\x01\n" +
 	"\x13OrderedListItemNode\x12\x16\n" +
 	"\x06number\x18\x01 \x01(\tR\x06number\x12\x16\n" +
 	"\x06indent\x18\x02 \x01(\x05R\x06indent\x12.\n" +
-	"\bchildren\x18\x03 \x03(\v2\x12.memos.api.v1.NodeR\bchildren\"w\n" +
+	"\bchildren\x18\x03 \x03(\v2\x12.memos.api.v1.NodeR\bchildren\x12#\n" +
+	"\rindent_prefix\x18\x04 \x01(\tR\findentPrefix\"\x9c\x01\n" +
 	"\x15UnorderedListItemNode\x12\x16\n" +
 	"\x06symbol\x18\x01 \x01(\tR\x06symbol\x12\x16\n" +
 	"\x06indent\x18\x02 \x01(\x05R\x06indent\x12.\n" +
-	"\bchildren\x18\x03 \x03(\v2\x12.memos.api.v1.NodeR\bchildren\"\x8e\x01\n" +
+	"\bchildren\x18\x03 \x03(\v2\x12.memos.api.v1.NodeR\bchildren\x12#\n" +
+	"\rindent_prefix\x18\x04 \x01(\tR\findentPrefix\"\xb3\x01\n" +
 	"\x10TaskListItemNode\x12\x16\n" +
 	"\x06symbol\x18\x01 \x01(\tR\x06symbol\x12\x16\n" +
 	"\x06indent\x18\x02 \x01(\x05R\x06indent\x12\x1a\n" +
 	"\bcomplete\x18\x03 \x01(\bR\bcomplete\x12.\n" +
-	"\bchildren\x18\x04 \x03(\v2\x12.memos.api.v1.NodeR\bchildren\")\n" +
+	"\bchildren\x18\x04 \x03(\v2\x12.memos.api.v1.NodeR\bchildren\x12#\n" +
+	"\rindent_prefix\x18\x05 \x01(\tR\findentPrefix\")\n" +
 	"\rMathBlockNode\x12\x18\n" +
 	"\acontent\x18\x01 \x01(\tR\acontent\"\xb7\x01\n" +
 	"\tTableNode\x12*\n" +
@@ -2815,7 +5378,14 @@ const file_api_v1_markdown_service_proto_rawDesc = "" +
 	"\tdelimiter\x18\x02 \x03(\tR\tdelimiter\x12/\n" +
 	"\x04rows\x18\x03 \x03(\v2\x1b.memos.api.v1.TableNode.RowR\x04rows\x1a/\n" +
 	"\x03Row\x12(\n" +
-	"\x05cells\x18\x01 \x03(\v2\x12.memos.api.v1.NodeR\x05cells\"R\n" +
+	"\x05cells\x18\x01 \x03(\v2\x12.memos.api.v1.NodeR\x05cells\"+\n" +
+	"\x0fFrontmatterNode\x12\x18\n" +
+	"\acontent\x18\x01 \x01(\tR\acontent\"\x8d\x01\n" +
+	"\x0fFootnoteDefNode\x12\x14\n" +
+	"\x05label\x18\x01 \x01(\tR\x05label\x12.\n" +
+	"\bchildren\x18\x02 \x03(\v2\x12.memos.api.v1.NodeR\bchildren\x12\x16\n" +
+	"\x06number\x18\x03 \x01(\x05R\x06number\x12\x1c\n" +
+	"\tduplicate\x18\x04 \x01(\bR\tduplicate\"R\n" +
 	"\x13EmbeddedContentNode\x12#\n" +
 	"\rresource_name\x18\x01 \x01(\tR\fresourceName\x12\x16\n" +
 	"\x06params\x18\x02 \x01(\tR\x06params\"$\n" +
@@ -2868,7 +5438,23 @@ const file_api_v1_markdown_service_proto_rawDesc = "" +
 	"attributes\x1a=\n" +
 	"\x0fAttributesEntry\x12\x10\n" +
 	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
-	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01*\x83\x04\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"C\n" +
+	"\tEmojiNode\x12\x1c\n" +
+	"\tshortcode\x18\x01 \x01(\tR\tshortcode\x12\x18\n" +
+	"\aunicode\x18\x02 \x01(\tR\aunicode\"\xc5\x01\n" +
+	"\n" +
+	"CustomNode\x12\x1c\n" +
+	"\textension\x18\x01 \x01(\tR\textension\x12\x10\n" +
+	"\x03raw\x18\x02 \x01(\tR\x03raw\x12H\n" +
+	"\n" +
+	"attributes\x18\x03 \x03(\v2(.memos.api.v1.CustomNode.AttributesEntryR\n" +
+	"attributes\x1a=\n" +
+	"\x0fAttributesEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"?\n" +
+	"\x0fFootnoteRefNode\x12\x14\n" +
+	"\x05label\x18\x01 \x01(\tR\x05label\x12\x16\n" +
+	"\x06number\x18\x02 \x01(\x05R\x06number*\xcf\x04\n" +
 	"\bNodeType\x12\x14\n" +
 	"\x10NODE_UNSPECIFIED\x10\x00\x12\x0e\n" +
 	"\n" +
@@ -2888,7 +5474,9 @@ const file_api_v1_markdown_service_proto_rawDesc = "" +
 	"\n" +
 	"MATH_BLOCK\x10\v\x12\t\n" +
 	"\x05TABLE\x10\f\x12\x14\n" +
-	"\x10EMBEDDED_CONTENT\x10\r\x12\b\n" +
+	"\x10EMBEDDED_CONTENT\x10\r\x12\x0f\n" +
+	"\vFRONTMATTER\x10\x0e\x12\x10\n" +
+	"\fFOOTNOTE_DEF\x10\x0f\x12\b\n" +
 	"\x04TEXT\x103\x12\b\n" +
 	"\x04BOLD\x104\x12\n" +
 	"\n" +
@@ -2907,11 +5495,22 @@ const file_api_v1_markdown_service_proto_rawDesc = "" +
 	"\vSUPERSCRIPT\x10A\x12\x16\n" +
 	"\x12REFERENCED_CONTENT\x10B\x12\v\n" +
 	"\aSPOILER\x10C\x12\x10\n" +
-	"\fHTML_ELEMENT\x10D2\xc7\x04\n" +
+	"\fHTML_ELEMENT\x10D\x12\t\n" +
+	"\x05EMOJI\x10E\x12\n" +
+	"\n" +
+	"\x06CUSTOM\x10F\x12\x10\n" +
+	"\fFOOTNOTE_REF\x10G2\xfd\n" +
+	"\n" +
 	"\x0fMarkdownService\x12{\n" +
-	"\rParseMarkdown\x12\".memos.api.v1.ParseMarkdownRequest\x1a#.memos.api.v1.ParseMarkdownResponse\"!\x82\xd3\xe4\x93\x02\x1b:\x01*\"\x16/api/v1/markdown:parse\x12\x97\x01\n" +
+	"\rParseMarkdown\x12\".memos.api.v1.ParseMarkdownRequest\x1a#.memos.api.v1.ParseMarkdownResponse\"!\x82\xd3\xe4\x93\x02\x1b:\x01*\"\x16/api/v1/markdown:parse\x12\x8f\x01\n" +
+	"\x12BatchParseMarkdown\x12'.memos.api.v1.BatchParseMarkdownRequest\x1a(.memos.api.v1.BatchParseMarkdownResponse\"&\x82\xd3\xe4\x93\x02 :\x01*\"\x1b/api/v1/markdown:batchParse\x12\x97\x01\n" +
 	"\x14RestoreMarkdownNodes\x12).memos.api.v1.RestoreMarkdownNodesRequest\x1a*.memos.api.v1.RestoreMarkdownNodesResponse\"(\x82\xd3\xe4\x93\x02\":\x01*\"\x1d/api/v1/markdown/node:restore\x12\x9f\x01\n" +
-	"\x16StringifyMarkdownNodes\x12+.memos.api.v1.StringifyMarkdownNodesRequest\x1a,.memos.api.v1.StringifyMarkdownNodesResponse\"*\x82\xd3\xe4\x93\x02$:\x01*\"\x1f/api/v1/markdown/node:stringify\x12{\n" +
+	"\x16StringifyMarkdownNodes\x12+.memos.api.v1.StringifyMarkdownNodesRequest\x1a,.memos.api.v1.StringifyMarkdownNodesResponse\"*\x82\xd3\xe4\x93\x02$:\x01*\"\x1f/api/v1/markdown/node:stringify\x12\x8b\x01\n" +
+	"\x11DiffMarkdownNodes\x12&.memos.api.v1.DiffMarkdownNodesRequest\x1a'.memos.api.v1.DiffMarkdownNodesResponse\"%\x82\xd3\xe4\x93\x02\x1f:\x01*\"\x1a/api/v1/markdown/node:diff\x12\x91\x01\n" +
+	"\x14RenderMarkdownToHTML\x12).memos.api.v1.RenderMarkdownToHTMLRequest\x1a*.memos.api.v1.RenderMarkdownToHTMLResponse\"\"\x82\xd3\xe4\x93\x02\x1c:\x01*\"\x17/api/v1/markdown:render\x12y\n" +
+	"\x10GetMarkdownStats\x12%.memos.api.v1.GetMarkdownStatsRequest\x1a\x1b.memos.api.v1.MarkdownStats\"!\x82\xd3\xe4\x93\x02\x1b:\x01*\"\x16/api/v1/markdown:stats\x12w\n" +
+	"\fLintMarkdown\x12!.memos.api.v1.LintMarkdownRequest\x1a\".memos.api.v1.LintMarkdownResponse\" \x82\xd3\xe4\x93\x02\x1a:\x01*\"\x15/api/v1/markdown:lint\x12\x8b\x01\n" +
+	"\x11NormalizeMarkdown\x12&.memos.api.v1.NormalizeMarkdownRequest\x1a'.memos.api.v1.NormalizeMarkdownResponse\"%\x82\xd3\xe4\x93\x02\x1f:\x01*\"\x1a/api/v1/markdown:normalize\x12{\n" +
 	"\x0fGetLinkMetadata\x12$.memos.api.v1.GetLinkMetadataRequest\x1a\x1a.memos.api.v1.LinkMetadata\"&\x82\xd3\xe4\x93\x02 \x12\x1e/api/v1/markdown/link:metadataB\xac\x01\n" +
 	"\x10com.memos.api.v1B\x14MarkdownServiceProtoP\x01Z0github.com/usememos/memos/proto/gen/api/v1;apiv1\xa2\x02\x03MAX\xaa\x02\fMemos.Api.V1\xca\x02\fMemos\\Api\\V1\xe2\x02\x18Memos\\Api\\V1\\GPBMetadata\xea\x02\x0eMemos::Api::V1b\x06proto3"
 
@@ -2927,118 +5526,192 @@ func file_api_v1_markdown_service_proto_rawDescGZIP() []byte {
 	return file_api_v1_markdown_service_proto_rawDescData
 }
 
-var file_api_v1_markdown_service_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
-var file_api_v1_markdown_service_proto_msgTypes = make([]protoimpl.MessageInfo, 42)
+var file_api_v1_markdown_service_proto_enumTypes = make([]protoimpl.EnumInfo, 8)
+var file_api_v1_markdown_service_proto_msgTypes = make([]protoimpl.MessageInfo, 69)
 var file_api_v1_markdown_service_proto_goTypes = []any{
-	(NodeType)(0),                          // 0: memos.api.v1.NodeType
-	(ListNode_Kind)(0),                     // 1: memos.api.v1.ListNode.Kind
-	(*ParseMarkdownRequest)(nil),           // 2: memos.api.v1.ParseMarkdownRequest
-	(*ParseMarkdownResponse)(nil),          // 3: memos.api.v1.ParseMarkdownResponse
-	(*RestoreMarkdownNodesRequest)(nil),    // 4: memos.api.v1.RestoreMarkdownNodesRequest
-	(*RestoreMarkdownNodesResponse)(nil),   // 5: memos.api.v1.RestoreMarkdownNodesResponse
-	(*StringifyMarkdownNodesRequest)(nil),  // 6: memos.api.v1.StringifyMarkdownNodesRequest
-	(*StringifyMarkdownNodesResponse)(nil), // 7: memos.api.v1.StringifyMarkdownNodesResponse
-	(*GetLinkMetadataRequest)(nil),         // 8: memos.api.v1.GetLinkMetadataRequest
-	(*LinkMetadata)(nil),                   // 9: memos.api.v1.LinkMetadata
-	(*Node)(nil),                           // 10: memos.api.v1.Node
-	(*LineBreakNode)(nil),                  // 11: memos.api.v1.LineBreakNode
-	(*ParagraphNode)(nil),                  // 12: memos.api.v1.ParagraphNode
-	(*CodeBlockNode)(nil),                  // 13: memos.api.v1.CodeBlockNode
-	(*HeadingNode)(nil),                    // 14: memos.api.v1.HeadingNode
-	(*HorizontalRuleNode)(nil),             // 15: memos.api.v1.HorizontalRuleNode
-	(*BlockquoteNode)(nil),                 // 16: memos.api.v1.BlockquoteNode
-	(*ListNode)(nil),                       // 17: memos.api.v1.ListNode
-	(*OrderedListItemNode)(nil),            // 18: memos.api.v1.OrderedListItemNode
-	(*UnorderedListItemNode)(nil),          // 19: memos.api.v1.UnorderedListItemNode
-	(*TaskListItemNode)(nil),               // 20: memos.api.v1.TaskListItemNode
-	(*MathBlockNode)(nil),                  // 21: memos.api.v1.MathBlockNode
-	(*TableNode)(nil),                      // 22: memos.api.v1.TableNode
-	(*EmbeddedContentNode)(nil),            // 23: memos.api.v1.EmbeddedContentNode
-	(*TextNode)(nil),                       // 24: memos.api.v1.TextNode
-	(*BoldNode)(nil),                       // 25: memos.api.v1.BoldNode
-	(*ItalicNode)(nil),                     // 26: memos.api.v1.ItalicNode
-	(*BoldItalicNode)(nil),                 // 27: memos.api.v1.BoldItalicNode
-	(*CodeNode)(nil),                       // 28: memos.api.v1.CodeNode
-	(*ImageNode)(nil),                      // 29: memos.api.v1.ImageNode
-	(*LinkNode)(nil),                       // 30: memos.api.v1.LinkNode
-	(*AutoLinkNode)(nil),                   // 31: memos.api.v1.AutoLinkNode
-	(*TagNode)(nil),                        // 32: memos.api.v1.TagNode
-	(*StrikethroughNode)(nil),              // 33: memos.api.v1.StrikethroughNode
-	(*EscapingCharacterNode)(nil),          // 34: memos.api.v1.EscapingCharacterNode
-	(*MathNode)(nil),                       // 35: memos.api.v1.MathNode
-	(*HighlightNode)(nil),                  // 36: memos.api.v1.HighlightNode
-	(*SubscriptNode)(nil),                  // 37: memos.api.v1.SubscriptNode
-	(*SuperscriptNode)(nil),                // 38: memos.api.v1.SuperscriptNode
-	(*ReferencedContentNode)(nil),          // 39: memos.api.v1.ReferencedContentNode
-	(*SpoilerNode)(nil),                    // 40: memos.api.v1.SpoilerNode
-	(*HTMLElementNode)(nil),                // 41: memos.api.v1.HTMLElementNode
-	(*TableNode_Row)(nil),                  // 42: memos.api.v1.TableNode.Row
-	nil,                                    // 43: memos.api.v1.HTMLElementNode.AttributesEntry
+	(NodeType)(0),                               // 0: memos.api.v1.NodeType
+	(ParseMarkdownRequest_RawHTMLMode)(0),       // 1: memos.api.v1.ParseMarkdownRequest.RawHTMLMode
+	(StringifyMarkdownNodesRequest_Mode)(0),     // 2: memos.api.v1.StringifyMarkdownNodesRequest.Mode
+	(StringifyMarkdownNodesRequest_LinkMode)(0), // 3: memos.api.v1.StringifyMarkdownNodesRequest.LinkMode
+	(MarkdownNodeDiff_Type)(0),                  // 4: memos.api.v1.MarkdownNodeDiff.Type
+	(MarkdownDiagnostic_Severity)(0),            // 5: memos.api.v1.MarkdownDiagnostic.Severity
+	(NormalizeMarkdownRequest_BulletStyle)(0),   // 6: memos.api.v1.NormalizeMarkdownRequest.BulletStyle
+	(ListNode_Kind)(0),                          // 7: memos.api.v1.ListNode.Kind
+	(*ParseMarkdownRequest)(nil),                // 8: memos.api.v1.ParseMarkdownRequest
+	(*ParseMarkdownResponse)(nil),               // 9: memos.api.v1.ParseMarkdownResponse
+	(*ImageReference)(nil),                      // 10: memos.api.v1.ImageReference
+	(*BatchParseMarkdownRequest)(nil),           // 11: memos.api.v1.BatchParseMarkdownRequest
+	(*BatchParseMarkdownResponse)(nil),          // 12: memos.api.v1.BatchParseMarkdownResponse
+	(*RestoreMarkdownNodesRequest)(nil),         // 13: memos.api.v1.RestoreMarkdownNodesRequest
+	(*RestoreMarkdownNodesResponse)(nil),        // 14: memos.api.v1.RestoreMarkdownNodesResponse
+	(*StringifyMarkdownNodesRequest)(nil),       // 15: memos.api.v1.StringifyMarkdownNodesRequest
+	(*StringifyMarkdownNodesResponse)(nil),      // 16: memos.api.v1.StringifyMarkdownNodesResponse
+	(*TableOfContentsEntry)(nil),                // 17: memos.api.v1.TableOfContentsEntry
+	(*DiffMarkdownNodesRequest)(nil),            // 18: memos.api.v1.DiffMarkdownNodesRequest
+	(*DiffMarkdownNodesResponse)(nil),           // 19: memos.api.v1.DiffMarkdownNodesResponse
+	(*MarkdownNodeDiff)(nil),                    // 20: memos.api.v1.MarkdownNodeDiff
+	(*RenderMarkdownToHTMLRequest)(nil),         // 21: memos.api.v1.RenderMarkdownToHTMLRequest
+	(*RenderMarkdownToHTMLResponse)(nil),        // 22: memos.api.v1.RenderMarkdownToHTMLResponse
+	(*GetMarkdownStatsRequest)(nil),             // 23: memos.api.v1.GetMarkdownStatsRequest
+	(*MarkdownStats)(nil),                       // 24: memos.api.v1.MarkdownStats
+	(*LintMarkdownRequest)(nil),                 // 25: memos.api.v1.LintMarkdownRequest
+	(*LintMarkdownResponse)(nil),                // 26: memos.api.v1.LintMarkdownResponse
+	(*MarkdownDiagnostic)(nil),                  // 27: memos.api.v1.MarkdownDiagnostic
+	(*NormalizeMarkdownRequest)(nil),            // 28: memos.api.v1.NormalizeMarkdownRequest
+	(*NormalizeMarkdownResponse)(nil),           // 29: memos.api.v1.NormalizeMarkdownResponse
+	(*GetLinkMetadataRequest)(nil),              // 30: memos.api.v1.GetLinkMetadataRequest
+	(*LinkMetadata)(nil),                        // 31: memos.api.v1.LinkMetadata
+	(*Node)(nil),                                // 32: memos.api.v1.Node
+	(*Position)(nil),                            // 33: memos.api.v1.Position
+	(*LineBreakNode)(nil),                       // 34: memos.api.v1.LineBreakNode
+	(*ParagraphNode)(nil),                       // 35: memos.api.v1.ParagraphNode
+	(*CodeBlockNode)(nil),                       // 36: memos.api.v1.CodeBlockNode
+	(*HeadingNode)(nil),                         // 37: memos.api.v1.HeadingNode
+	(*HorizontalRuleNode)(nil),                  // 38: memos.api.v1.HorizontalRuleNode
+	(*BlockquoteNode)(nil),                      // 39: memos.api.v1.BlockquoteNode
+	(*ListNode)(nil),                            // 40: memos.api.v1.ListNode
+	(*OrderedListItemNode)(nil),                 // 41: memos.api.v1.OrderedListItemNode
+	(*UnorderedListItemNode)(nil),               // 42: memos.api.v1.UnorderedListItemNode
+	(*TaskListItemNode)(nil),                    // 43: memos.api.v1.TaskListItemNode
+	(*MathBlockNode)(nil),                       // 44: memos.api.v1.MathBlockNode
+	(*TableNode)(nil),                           // 45: memos.api.v1.TableNode
+	(*FrontmatterNode)(nil),                     // 46: memos.api.v1.FrontmatterNode
+	(*FootnoteDefNode)(nil),                     // 47: memos.api.v1.FootnoteDefNode
+	(*EmbeddedContentNode)(nil),                 // 48: memos.api.v1.EmbeddedContentNode
+	(*TextNode)(nil),                            // 49: memos.api.v1.TextNode
+	(*BoldNode)(nil),                            // 50: memos.api.v1.BoldNode
+	(*ItalicNode)(nil),                          // 51: memos.api.v1.ItalicNode
+	(*BoldItalicNode)(nil),                      // 52: memos.api.v1.BoldItalicNode
+	(*CodeNode)(nil),                            // 53: memos.api.v1.CodeNode
+	(*ImageNode)(nil),                           // 54: memos.api.v1.ImageNode
+	(*LinkNode)(nil),                            // 55: memos.api.v1.LinkNode
+	(*AutoLinkNode)(nil),                        // 56: memos.api.v1.AutoLinkNode
+	(*TagNode)(nil),                             // 57: memos.api.v1.TagNode
+	(*StrikethroughNode)(nil),                   // 58: memos.api.v1.StrikethroughNode
+	(*EscapingCharacterNode)(nil),               // 59: memos.api.v1.EscapingCharacterNode
+	(*MathNode)(nil),                            // 60: memos.api.v1.MathNode
+	(*HighlightNode)(nil),                       // 61: memos.api.v1.HighlightNode
+	(*SubscriptNode)(nil),                       // 62: memos.api.v1.SubscriptNode
+	(*SuperscriptNode)(nil),                     // 63: memos.api.v1.SuperscriptNode
+	(*ReferencedContentNode)(nil),               // 64: memos.api.v1.ReferencedContentNode
+	(*SpoilerNode)(nil),                         // 65: memos.api.v1.SpoilerNode
+	(*HTMLElementNode)(nil),                     // 66: memos.api.v1.HTMLElementNode
+	(*EmojiNode)(nil),                           // 67: memos.api.v1.EmojiNode
+	(*CustomNode)(nil),                          // 68: memos.api.v1.CustomNode
+	(*FootnoteRefNode)(nil),                     // 69: memos.api.v1.FootnoteRefNode
+	nil,                                         // 70: memos.api.v1.ParseMarkdownRequest.CodeLanguageAliasesEntry
+	(*BatchParseMarkdownResponse_Result)(nil),   // 71: memos.api.v1.BatchParseMarkdownResponse.Result
+	(*LinkMetadata_OEmbed)(nil),                 // 72: memos.api.v1.LinkMetadata.OEmbed
+	(*LinkMetadata_Article)(nil),                // 73: memos.api.v1.LinkMetadata.Article
+	(*TableNode_Row)(nil),                       // 74: memos.api.v1.TableNode.Row
+	nil,                                         // 75: memos.api.v1.HTMLElementNode.AttributesEntry
+	nil,                                         // 76: memos.api.v1.CustomNode.AttributesEntry
 }
 var file_api_v1_markdown_service_proto_depIdxs = []int32{
-	10, // 0: memos.api.v1.ParseMarkdownResponse.nodes:type_name -> memos.api.v1.Node
-	10, // 1: memos.api.v1.RestoreMarkdownNodesRequest.nodes:type_name -> memos.api.v1.Node
-	10, // 2: memos.api.v1.StringifyMarkdownNodesRequest.nodes:type_name -> memos.api.v1.Node
-	0,  // 3: memos.api.v1.Node.type:type_name -> memos.api.v1.NodeType
-	11, // 4: memos.api.v1.Node.line_break_node:type_name -> memos.api.v1.LineBreakNode
-	12, // 5: memos.api.v1.Node.paragraph_node:type_name -> memos.api.v1.ParagraphNode
-	13, // 6: memos.api.v1.Node.code_block_node:type_name -> memos.api.v1.CodeBlockNode
-	14, // 7: memos.api.v1.Node.heading_node:type_name -> memos.api.v1.HeadingNode
-	15, // 8: memos.api.v1.Node.horizontal_rule_node:type_name -> memos.api.v1.HorizontalRuleNode
-	16, // 9: memos.api.v1.Node.blockquote_node:type_name -> memos.api.v1.BlockquoteNode
-	17, // 10: memos.api.v1.Node.list_node:type_name -> memos.api.v1.ListNode
-	18, // 11: memos.api.v1.Node.ordered_list_item_node:type_name -> memos.api.v1.OrderedListItemNode
-	19, // 12: memos.api.v1.Node.unordered_list_item_node:type_name -> memos.api.v1.UnorderedListItemNode
-	20, // 13: memos.api.v1.Node.task_list_item_node:type_name -> memos.api.v1.TaskListItemNode
-	21, // 14: memos.api.v1.Node.math_block_node:type_name -> memos.api.v1.MathBlockNode
-	22, // 15: memos.api.v1.Node.table_node:type_name -> memos.api.v1.TableNode
-	23, // 16: memos.api.v1.Node.embedded_content_node:type_name -> memos.api.v1.EmbeddedContentNode
-	24, // 17: memos.api.v1.Node.text_node:type_name -> memos.api.v1.TextNode
-	25, // 18: memos.api.v1.Node.bold_node:type_name -> memos.api.v1.BoldNode
-	26, // 19: memos.api.v1.Node.italic_node:type_name -> memos.api.v1.ItalicNode
-	27, // 20: memos.api.v1.Node.bold_italic_node:type_name -> memos.api.v1.BoldItalicNode
-	28, // 21: memos.api.v1.Node.code_node:type_name -> memos.api.v1.CodeNode
-	29, // 22: memos.api.v1.Node.image_node:type_name -> memos.api.v1.ImageNode
-	30, // 23: memos.api.v1.Node.link_node:type_name -> memos.api.v1.LinkNode
-	31, // 24: memos.api.v1.Node.auto_link_node:type_name -> memos.api.v1.AutoLinkNode
-	32, // 25: memos.api.v1.Node.tag_node:type_name -> memos.api.v1.TagNode
-	33, // 26: memos.api.v1.Node.strikethrough_node:type_name -> memos.api.v1.StrikethroughNode
-	34, // 27: memos.api.v1.Node.escaping_character_node:type_name -> memos.api.v1.EscapingCharacterNode
-	35, // 28: memos.api.v1.Node.math_node:type_name -> memos.api.v1.MathNode
-	36, // 29: memos.api.v1.Node.highlight_node:type_name -> memos.api.v1.HighlightNode
-	37, // 30: memos.api.v1.Node.subscript_node:type_name -> memos.api.v1.SubscriptNode
-	38, // 31: memos.api.v1.Node.superscript_node:type_name -> memos.api.v1.SuperscriptNode
-	39, // 32: memos.api.v1.Node.referenced_content_node:type_name -> memos.api.v1.ReferencedContentNode
-	40, // 33: memos.api.v1.Node.spoiler_node:type_name -> memos.api.v1.SpoilerNode
-	41, // 34: memos.api.v1.Node.html_element_node:type_name -> memos.api.v1.HTMLElementNode
-	10, // 35: memos.api.v1.ParagraphNode.children:type_name -> memos.api.v1.Node
-	10, // 36: memos.api.v1.HeadingNode.children:type_name -> memos.api.v1.Node
-	10, // 37: memos.api.v1.BlockquoteNode.children:type_name -> memos.api.v1.Node
-	1,  // 38: memos.api.v1.ListNode.kind:type_name -> memos.api.v1.ListNode.Kind
-	10, // 39: memos.api.v1.ListNode.children:type_name -> memos.api.v1.Node
-	10, // 40: memos.api.v1.OrderedListItemNode.children:type_name -> memos.api.v1.Node
-	10, // 41: memos.api.v1.UnorderedListItemNode.children:type_name -> memos.api.v1.Node
-	10, // 42: memos.api.v1.TaskListItemNode.children:type_name -> memos.api.v1.Node
-	10, // 43: memos.api.v1.TableNode.header:type_name -> memos.api.v1.Node
-	42, // 44: memos.api.v1.TableNode.rows:type_name -> memos.api.v1.TableNode.Row
-	10, // 45: memos.api.v1.BoldNode.children:type_name -> memos.api.v1.Node
-	10, // 46: memos.api.v1.ItalicNode.children:type_name -> memos.api.v1.Node
-	10, // 47: memos.api.v1.LinkNode.content:type_name -> memos.api.v1.Node
-	43, // 48: memos.api.v1.HTMLElementNode.attributes:type_name -> memos.api.v1.HTMLElementNode.AttributesEntry
-	10, // 49: memos.api.v1.TableNode.Row.cells:type_name -> memos.api.v1.Node
-	2,  // 50: memos.api.v1.MarkdownService.ParseMarkdown:input_type -> memos.api.v1.ParseMarkdownRequest
-	4,  // 51: memos.api.v1.MarkdownService.RestoreMarkdownNodes:input_type -> memos.api.v1.RestoreMarkdownNodesRequest
-	6,  // 52: memos.api.v1.MarkdownService.StringifyMarkdownNodes:input_type -> memos.api.v1.StringifyMarkdownNodesRequest
-	8,  // 53: memos.api.v1.MarkdownService.GetLinkMetadata:input_type -> memos.api.v1.GetLinkMetadataRequest
-	3,  // 54: memos.api.v1.MarkdownService.ParseMarkdown:output_type -> memos.api.v1.ParseMarkdownResponse
-	5,  // 55: memos.api.v1.MarkdownService.RestoreMarkdownNodes:output_type -> memos.api.v1.RestoreMarkdownNodesResponse
-	7,  // 56: memos.api.v1.MarkdownService.StringifyMarkdownNodes:output_type -> memos.api.v1.StringifyMarkdownNodesResponse
-	9,  // 57: memos.api.v1.MarkdownService.GetLinkMetadata:output_type -> memos.api.v1.LinkMetadata
-	54, // [54:58] is the sub-list for method output_type
-	50, // [50:54] is the sub-list for method input_type
-	50, // [50:50] is the sub-list for extension type_name
-	50, // [50:50] is the sub-list for extension extendee
-	0,  // [0:50] is the sub-list for field type_name
+	1,  // 0: memos.api.v1.ParseMarkdownRequest.raw_html_mode:type_name -> memos.api.v1.ParseMarkdownRequest.RawHTMLMode
+	70, // 1: memos.api.v1.ParseMarkdownRequest.code_language_aliases:type_name -> memos.api.v1.ParseMarkdownRequest.CodeLanguageAliasesEntry
+	32, // 2: memos.api.v1.ParseMarkdownResponse.nodes:type_name -> memos.api.v1.Node
+	10, // 3: memos.api.v1.ParseMarkdownResponse.images:type_name -> memos.api.v1.ImageReference
+	71, // 4: memos.api.v1.BatchParseMarkdownResponse.results:type_name -> memos.api.v1.BatchParseMarkdownResponse.Result
+	32, // 5: memos.api.v1.RestoreMarkdownNodesRequest.nodes:type_name -> memos.api.v1.Node
+	32, // 6: memos.api.v1.StringifyMarkdownNodesRequest.nodes:type_name -> memos.api.v1.Node
+	2,  // 7: memos.api.v1.StringifyMarkdownNodesRequest.mode:type_name -> memos.api.v1.StringifyMarkdownNodesRequest.Mode
+	3,  // 8: memos.api.v1.StringifyMarkdownNodesRequest.link_mode:type_name -> memos.api.v1.StringifyMarkdownNodesRequest.LinkMode
+	17, // 9: memos.api.v1.StringifyMarkdownNodesResponse.table_of_contents:type_name -> memos.api.v1.TableOfContentsEntry
+	17, // 10: memos.api.v1.TableOfContentsEntry.children:type_name -> memos.api.v1.TableOfContentsEntry
+	32, // 11: memos.api.v1.DiffMarkdownNodesRequest.old_nodes:type_name -> memos.api.v1.Node
+	32, // 12: memos.api.v1.DiffMarkdownNodesRequest.new_nodes:type_name -> memos.api.v1.Node
+	20, // 13: memos.api.v1.DiffMarkdownNodesResponse.diffs:type_name -> memos.api.v1.MarkdownNodeDiff
+	4,  // 14: memos.api.v1.MarkdownNodeDiff.type:type_name -> memos.api.v1.MarkdownNodeDiff.Type
+	32, // 15: memos.api.v1.MarkdownNodeDiff.old_node:type_name -> memos.api.v1.Node
+	32, // 16: memos.api.v1.MarkdownNodeDiff.new_node:type_name -> memos.api.v1.Node
+	27, // 17: memos.api.v1.LintMarkdownResponse.diagnostics:type_name -> memos.api.v1.MarkdownDiagnostic
+	5,  // 18: memos.api.v1.MarkdownDiagnostic.severity:type_name -> memos.api.v1.MarkdownDiagnostic.Severity
+	33, // 19: memos.api.v1.MarkdownDiagnostic.position:type_name -> memos.api.v1.Position
+	6,  // 20: memos.api.v1.NormalizeMarkdownRequest.bullet_style:type_name -> memos.api.v1.NormalizeMarkdownRequest.BulletStyle
+	72, // 21: memos.api.v1.LinkMetadata.oembed:type_name -> memos.api.v1.LinkMetadata.OEmbed
+	73, // 22: memos.api.v1.LinkMetadata.article:type_name -> memos.api.v1.LinkMetadata.Article
+	0,  // 23: memos.api.v1.Node.type:type_name -> memos.api.v1.NodeType
+	33, // 24: memos.api.v1.Node.position:type_name -> memos.api.v1.Position
+	34, // 25: memos.api.v1.Node.line_break_node:type_name -> memos.api.v1.LineBreakNode
+	35, // 26: memos.api.v1.Node.paragraph_node:type_name -> memos.api.v1.ParagraphNode
+	36, // 27: memos.api.v1.Node.code_block_node:type_name -> memos.api.v1.CodeBlockNode
+	37, // 28: memos.api.v1.Node.heading_node:type_name -> memos.api.v1.HeadingNode
+	38, // 29: memos.api.v1.Node.horizontal_rule_node:type_name -> memos.api.v1.HorizontalRuleNode
+	39, // 30: memos.api.v1.Node.blockquote_node:type_name -> memos.api.v1.BlockquoteNode
+	40, // 31: memos.api.v1.Node.list_node:type_name -> memos.api.v1.ListNode
+	41, // 32: memos.api.v1.Node.ordered_list_item_node:type_name -> memos.api.v1.OrderedListItemNode
+	42, // 33: memos.api.v1.Node.unordered_list_item_node:type_name -> memos.api.v1.UnorderedListItemNode
+	43, // 34: memos.api.v1.Node.task_list_item_node:type_name -> memos.api.v1.TaskListItemNode
+	44, // 35: memos.api.v1.Node.math_block_node:type_name -> memos.api.v1.MathBlockNode
+	45, // 36: memos.api.v1.Node.table_node:type_name -> memos.api.v1.TableNode
+	48, // 37: memos.api.v1.Node.embedded_content_node:type_name -> memos.api.v1.EmbeddedContentNode
+	46, // 38: memos.api.v1.Node.frontmatter_node:type_name -> memos.api.v1.FrontmatterNode
+	47, // 39: memos.api.v1.Node.footnote_def_node:type_name -> memos.api.v1.FootnoteDefNode
+	49, // 40: memos.api.v1.Node.text_node:type_name -> memos.api.v1.TextNode
+	50, // 41: memos.api.v1.Node.bold_node:type_name -> memos.api.v1.BoldNode
+	51, // 42: memos.api.v1.Node.italic_node:type_name -> memos.api.v1.ItalicNode
+	52, // 43: memos.api.v1.Node.bold_italic_node:type_name -> memos.api.v1.BoldItalicNode
+	53, // 44: memos.api.v1.Node.code_node:type_name -> memos.api.v1.CodeNode
+	54, // 45: memos.api.v1.Node.image_node:type_name -> memos.api.v1.ImageNode
+	55, // 46: memos.api.v1.Node.link_node:type_name -> memos.api.v1.LinkNode
+	56, // 47: memos.api.v1.Node.auto_link_node:type_name -> memos.api.v1.AutoLinkNode
+	57, // 48: memos.api.v1.Node.tag_node:type_name -> memos.api.v1.TagNode
+	58, // 49: memos.api.v1.Node.strikethrough_node:type_name -> memos.api.v1.StrikethroughNode
+	59, // 50: memos.api.v1.Node.escaping_character_node:type_name -> memos.api.v1.EscapingCharacterNode
+	60, // 51: memos.api.v1.Node.math_node:type_name -> memos.api.v1.MathNode
+	61, // 52: memos.api.v1.Node.highlight_node:type_name -> memos.api.v1.HighlightNode
+	62, // 53: memos.api.v1.Node.subscript_node:type_name -> memos.api.v1.SubscriptNode
+	63, // 54: memos.api.v1.Node.superscript_node:type_name -> memos.api.v1.SuperscriptNode
+	64, // 55: memos.api.v1.Node.referenced_content_node:type_name -> memos.api.v1.ReferencedContentNode
+	65, // 56: memos.api.v1.Node.spoiler_node:type_name -> memos.api.v1.SpoilerNode
+	66, // 57: memos.api.v1.Node.html_element_node:type_name -> memos.api.v1.HTMLElementNode
+	67, // 58: memos.api.v1.Node.emoji_node:type_name -> memos.api.v1.EmojiNode
+	68, // 59: memos.api.v1.Node.custom_node:type_name -> memos.api.v1.CustomNode
+	69, // 60: memos.api.v1.Node.footnote_ref_node:type_name -> memos.api.v1.FootnoteRefNode
+	32, // 61: memos.api.v1.ParagraphNode.children:type_name -> memos.api.v1.Node
+	32, // 62: memos.api.v1.HeadingNode.children:type_name -> memos.api.v1.Node
+	32, // 63: memos.api.v1.BlockquoteNode.children:type_name -> memos.api.v1.Node
+	7,  // 64: memos.api.v1.ListNode.kind:type_name -> memos.api.v1.ListNode.Kind
+	32, // 65: memos.api.v1.ListNode.children:type_name -> memos.api.v1.Node
+	32, // 66: memos.api.v1.OrderedListItemNode.children:type_name -> memos.api.v1.Node
+	32, // 67: memos.api.v1.UnorderedListItemNode.children:type_name -> memos.api.v1.Node
+	32, // 68: memos.api.v1.TaskListItemNode.children:type_name -> memos.api.v1.Node
+	32, // 69: memos.api.v1.TableNode.header:type_name -> memos.api.v1.Node
+	74, // 70: memos.api.v1.TableNode.rows:type_name -> memos.api.v1.TableNode.Row
+	32, // 71: memos.api.v1.FootnoteDefNode.children:type_name -> memos.api.v1.Node
+	32, // 72: memos.api.v1.BoldNode.children:type_name -> memos.api.v1.Node
+	32, // 73: memos.api.v1.ItalicNode.children:type_name -> memos.api.v1.Node
+	32, // 74: memos.api.v1.LinkNode.content:type_name -> memos.api.v1.Node
+	75, // 75: memos.api.v1.HTMLElementNode.attributes:type_name -> memos.api.v1.HTMLElementNode.AttributesEntry
+	76, // 76: memos.api.v1.CustomNode.attributes:type_name -> memos.api.v1.CustomNode.AttributesEntry
+	32, // 77: memos.api.v1.BatchParseMarkdownResponse.Result.nodes:type_name -> memos.api.v1.Node
+	32, // 78: memos.api.v1.TableNode.Row.cells:type_name -> memos.api.v1.Node
+	8,  // 79: memos.api.v1.MarkdownService.ParseMarkdown:input_type -> memos.api.v1.ParseMarkdownRequest
+	11, // 80: memos.api.v1.MarkdownService.BatchParseMarkdown:input_type -> memos.api.v1.BatchParseMarkdownRequest
+	13, // 81: memos.api.v1.MarkdownService.RestoreMarkdownNodes:input_type -> memos.api.v1.RestoreMarkdownNodesRequest
+	15, // 82: memos.api.v1.MarkdownService.StringifyMarkdownNodes:input_type -> memos.api.v1.StringifyMarkdownNodesRequest
+	18, // 83: memos.api.v1.MarkdownService.DiffMarkdownNodes:input_type -> memos.api.v1.DiffMarkdownNodesRequest
+	21, // 84: memos.api.v1.MarkdownService.RenderMarkdownToHTML:input_type -> memos.api.v1.RenderMarkdownToHTMLRequest
+	23, // 85: memos.api.v1.MarkdownService.GetMarkdownStats:input_type -> memos.api.v1.GetMarkdownStatsRequest
+	25, // 86: memos.api.v1.MarkdownService.LintMarkdown:input_type -> memos.api.v1.LintMarkdownRequest
+	28, // 87: memos.api.v1.MarkdownService.NormalizeMarkdown:input_type -> memos.api.v1.NormalizeMarkdownRequest
+	30, // 88: memos.api.v1.MarkdownService.GetLinkMetadata:input_type -> memos.api.v1.GetLinkMetadataRequest
+	9,  // 89: memos.api.v1.MarkdownService.ParseMarkdown:output_type -> memos.api.v1.ParseMarkdownResponse
+	12, // 90: memos.api.v1.MarkdownService.BatchParseMarkdown:output_type -> memos.api.v1.BatchParseMarkdownResponse
+	14, // 91: memos.api.v1.MarkdownService.RestoreMarkdownNodes:output_type -> memos.api.v1.RestoreMarkdownNodesResponse
+	16, // 92: memos.api.v1.MarkdownService.StringifyMarkdownNodes:output_type -> memos.api.v1.StringifyMarkdownNodesResponse
+	19, // 93: memos.api.v1.MarkdownService.DiffMarkdownNodes:output_type -> memos.api.v1.DiffMarkdownNodesResponse
+	22, // 94: memos.api.v1.MarkdownService.RenderMarkdownToHTML:output_type -> memos.api.v1.RenderMarkdownToHTMLResponse
+	24, // 95: memos.api.v1.MarkdownService.GetMarkdownStats:output_type -> memos.api.v1.MarkdownStats
+	26, // 96: memos.api.v1.MarkdownService.LintMarkdown:output_type -> memos.api.v1.LintMarkdownResponse
+	29, // 97: memos.api.v1.MarkdownService.NormalizeMarkdown:output_type -> memos.api.v1.NormalizeMarkdownResponse
+	31, // 98: memos.api.v1.MarkdownService.GetLinkMetadata:output_type -> memos.api.v1.LinkMetadata
+	89, // [89:99] is the sub-list for method output_type
+	79, // [79:89] is the sub-list for method input_type
+	79, // [79:79] is the sub-list for extension type_name
+	79, // [79:79] is the sub-list for extension extendee
+	0,  // [0:79] is the sub-list for field type_name
 }
 
 func init() { file_api_v1_markdown_service_proto_init() }
@@ -3046,7 +5719,7 @@ func file_api_v1_markdown_service_proto_init() {
 	if File_api_v1_markdown_service_proto != nil {
 		return
 	}
-	file_api_v1_markdown_service_proto_msgTypes[8].OneofWrappers = []any{
+	file_api_v1_markdown_service_proto_msgTypes[24].OneofWrappers = []any{
 		(*Node_LineBreakNode)(nil),
 		(*Node_ParagraphNode)(nil),
 		(*Node_CodeBlockNode)(nil),
@@ -3060,6 +5733,8 @@ func file_api_v1_markdown_service_proto_init() {
 		(*Node_MathBlockNode)(nil),
 		(*Node_TableNode)(nil),
 		(*Node_EmbeddedContentNode)(nil),
+		(*Node_FrontmatterNode)(nil),
+		(*Node_FootnoteDefNode)(nil),
 		(*Node_TextNode)(nil),
 		(*Node_BoldNode)(nil),
 		(*Node_ItalicNode)(nil),
@@ -3078,14 +5753,17 @@ func file_api_v1_markdown_service_proto_init() {
 		(*Node_ReferencedContentNode)(nil),
 		(*Node_SpoilerNode)(nil),
 		(*Node_HtmlElementNode)(nil),
+		(*Node_EmojiNode)(nil),
+		(*Node_CustomNode)(nil),
+		(*Node_FootnoteRefNode)(nil),
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_api_v1_markdown_service_proto_rawDesc), len(file_api_v1_markdown_service_proto_rawDesc)),
-			NumEnums:      2,
-			NumMessages:   42,
+			NumEnums:      8,
+			NumMessages:   69,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -20,8 +20,14 @@ const _ = grpc.SupportPackageIsVersion9
 
 const (
 	MarkdownService_ParseMarkdown_FullMethodName          = "/memos.api.v1.MarkdownService/ParseMarkdown"
+	MarkdownService_BatchParseMarkdown_FullMethodName     = "/memos.api.v1.MarkdownService/BatchParseMarkdown"
 	MarkdownService_RestoreMarkdownNodes_FullMethodName   = "/memos.api.v1.MarkdownService/RestoreMarkdownNodes"
 	MarkdownService_StringifyMarkdownNodes_FullMethodName = "/memos.api.v1.MarkdownService/StringifyMarkdownNodes"
+	MarkdownService_DiffMarkdownNodes_FullMethodName      = "/memos.api.v1.MarkdownService/DiffMarkdownNodes"
+	MarkdownService_RenderMarkdownToHTML_FullMethodName   = "/memos.api.v1.MarkdownService/RenderMarkdownToHTML"
+	MarkdownService_GetMarkdownStats_FullMethodName       = "/memos.api.v1.MarkdownService/GetMarkdownStats"
+	MarkdownService_LintMarkdown_FullMethodName           = "/memos.api.v1.MarkdownService/LintMarkdown"
+	MarkdownService_NormalizeMarkdown_FullMethodName      = "/memos.api.v1.MarkdownService/NormalizeMarkdown"
 	MarkdownService_GetLinkMetadata_FullMethodName        = "/memos.api.v1.MarkdownService/GetLinkMetadata"
 )
 
@@ -31,10 +37,28 @@ const (
 type MarkdownServiceClient interface {
 	// ParseMarkdown parses the given markdown content and returns a list of nodes.
 	ParseMarkdown(ctx context.Context, in *ParseMarkdownRequest, opts ...grpc.CallOption) (*ParseMarkdownResponse, error)
+	// BatchParseMarkdown parses a list of markdown contents and returns the nodes of each content in the same order.
+	// A content that fails to parse reports its error without failing the whole batch.
+	BatchParseMarkdown(ctx context.Context, in *BatchParseMarkdownRequest, opts ...grpc.CallOption) (*BatchParseMarkdownResponse, error)
 	// RestoreMarkdownNodes restores the given nodes to markdown content.
 	RestoreMarkdownNodes(ctx context.Context, in *RestoreMarkdownNodesRequest, opts ...grpc.CallOption) (*RestoreMarkdownNodesResponse, error)
 	// StringifyMarkdownNodes stringify the given nodes to plain text content.
+	// Use the PLAIN_TEXT mode to strip all markdown syntax, e.g. for search indexes and notifications.
 	StringifyMarkdownNodes(ctx context.Context, in *StringifyMarkdownNodesRequest, opts ...grpc.CallOption) (*StringifyMarkdownNodesResponse, error)
+	// DiffMarkdownNodes compares two versions of markdown block by block, e.g. to highlight the
+	// paragraphs that changed between two revisions of a memo.
+	DiffMarkdownNodes(ctx context.Context, in *DiffMarkdownNodesRequest, opts ...grpc.CallOption) (*DiffMarkdownNodesResponse, error)
+	// RenderMarkdownToHTML renders markdown to sanitized HTML, e.g. for feeds and emails.
+	RenderMarkdownToHTML(ctx context.Context, in *RenderMarkdownToHTMLRequest, opts ...grpc.CallOption) (*RenderMarkdownToHTMLResponse, error)
+	// GetMarkdownStats counts the words and characters of the text of the given markdown
+	// and estimates its reading time, e.g. for writing stats.
+	GetMarkdownStats(ctx context.Context, in *GetMarkdownStatsRequest, opts ...grpc.CallOption) (*MarkdownStats, error)
+	// LintMarkdown reports the problems of the given markdown, e.g. for checks in CI. Malformed
+	// syntax does not fail the request but is reported as diagnostics.
+	LintMarkdown(ctx context.Context, in *LintMarkdownRequest, opts ...grpc.CallOption) (*LintMarkdownResponse, error)
+	// NormalizeMarkdown canonicalizes the given markdown, e.g. to deduplicate and store memos consistently.
+	// Normalizing normalized markdown leaves it as is.
+	NormalizeMarkdown(ctx context.Context, in *NormalizeMarkdownRequest, opts ...grpc.CallOption) (*NormalizeMarkdownResponse, error)
 	// GetLinkMetadata returns metadata for a given link.
 	GetLinkMetadata(ctx context.Context, in *GetLinkMetadataRequest, opts ...grpc.CallOption) (*LinkMetadata, error)
 }
@@ -57,6 +81,16 @@ func (c *markdownServiceClient) ParseMarkdown(ctx context.Context, in *ParseMark
 	return out, nil
 }
 
+func (c *markdownServiceClient) BatchParseMarkdown(ctx context.Context, in *BatchParseMarkdownRequest, opts ...grpc.CallOption) (*BatchParseMarkdownResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BatchParseMarkdownResponse)
+	err := c.cc.Invoke(ctx, MarkdownService_BatchParseMarkdown_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *markdownServiceClient) RestoreMarkdownNodes(ctx context.Context, in *RestoreMarkdownNodesRequest, opts ...grpc.CallOption) (*RestoreMarkdownNodesResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(RestoreMarkdownNodesResponse)
@@ -77,6 +111,56 @@ func (c *markdownServiceClient) StringifyMarkdownNodes(ctx context.Context, in *
 	return out, nil
 }
 
+func (c *markdownServiceClient) DiffMarkdownNodes(ctx context.Context, in *DiffMarkdownNodesRequest, opts ...grpc.CallOption) (*DiffMarkdownNodesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DiffMarkdownNodesResponse)
+	err := c.cc.Invoke(ctx, MarkdownService_DiffMarkdownNodes_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *markdownServiceClient) RenderMarkdownToHTML(ctx context.Context, in *RenderMarkdownToHTMLRequest, opts ...grpc.CallOption) (*RenderMarkdownToHTMLResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RenderMarkdownToHTMLResponse)
+	err := c.cc.Invoke(ctx, MarkdownService_RenderMarkdownToHTML_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *markdownServiceClient) GetMarkdownStats(ctx context.Context, in *GetMarkdownStatsRequest, opts ...grpc.CallOption) (*MarkdownStats, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(MarkdownStats)
+	err := c.cc.Invoke(ctx, MarkdownService_GetMarkdownStats_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *markdownServiceClient) LintMarkdown(ctx context.Context, in *LintMarkdownRequest, opts ...grpc.CallOption) (*LintMarkdownResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(LintMarkdownResponse)
+	err := c.cc.Invoke(ctx, MarkdownService_LintMarkdown_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *markdownServiceClient) NormalizeMarkdown(ctx context.Context, in *NormalizeMarkdownRequest, opts ...grpc.CallOption) (*NormalizeMarkdownResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(NormalizeMarkdownResponse)
+	err := c.cc.Invoke(ctx, MarkdownService_NormalizeMarkdown_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *markdownServiceClient) GetLinkMetadata(ctx context.Context, in *GetLinkMetadataRequest, opts ...grpc.CallOption) (*LinkMetadata, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(LinkMetadata)
@@ -93,10 +177,28 @@ func (c *markdownServiceClient) GetLinkMetadata(ctx context.Context, in *GetLink
 type MarkdownServiceServer interface {
 	// ParseMarkdown parses the given markdown content and returns a list of nodes.
 	ParseMarkdown(context.Context, *ParseMarkdownRequest) (*ParseMarkdownResponse, error)
+	// BatchParseMarkdown parses a list of markdown contents and returns the nodes of each content in the same order.
+	// A content that fails to parse reports its error without failing the whole batch.
+	BatchParseMarkdown(context.Context, *BatchParseMarkdownRequest) (*BatchParseMarkdownResponse, error)
 	// RestoreMarkdownNodes restores the given nodes to markdown content.
 	RestoreMarkdownNodes(context.Context, *RestoreMarkdownNodesRequest) (*RestoreMarkdownNodesResponse, error)
 	// StringifyMarkdownNodes stringify the given nodes to plain text content.
+	// Use the PLAIN_TEXT mode to strip all markdown syntax, e.g. for search indexes and notifications.
 	StringifyMarkdownNodes(context.Context, *StringifyMarkdownNodesRequest) (*StringifyMarkdownNodesResponse, error)
+	// DiffMarkdownNodes compares two versions of markdown block by block, e.g. to highlight the
+	// paragraphs that changed between two revisions of a memo.
+	DiffMarkdownNodes(context.Context, *DiffMarkdownNodesRequest) (*DiffMarkdownNodesResponse, error)
+	// RenderMarkdownToHTML renders markdown to sanitized HTML, e.g. for feeds and emails.
+	RenderMarkdownToHTML(context.Context, *RenderMarkdownToHTMLRequest) (*RenderMarkdownToHTMLResponse, error)
+	// GetMarkdownStats counts the words and characters of the text of the given markdown
+	// and estimates its reading time, e.g. for writing stats.
+	GetMarkdownStats(context.Context, *GetMarkdownStatsRequest) (*MarkdownStats, error)
+	// LintMarkdown reports the problems of the given markdown, e.g. for checks in CI. Malformed
+	// syntax does not fail the request but is reported as diagnostics.
+	LintMarkdown(context.Context, *LintMarkdownRequest) (*LintMarkdownResponse, error)
+	// NormalizeMarkdown canonicalizes the given markdown, e.g. to deduplicate and store memos consistently.
+	// Normalizing normalized markdown leaves it as is.
+	NormalizeMarkdown(context.Context, *NormalizeMarkdownRequest) (*NormalizeMarkdownResponse, error)
 	// GetLinkMetadata returns metadata for a given link.
 	GetLinkMetadata(context.Context, *GetLinkMetadataRequest) (*LinkMetadata, error)
 	mustEmbedUnimplementedMarkdownServiceServer()
@@ -112,12 +214,30 @@ type UnimplementedMarkdownServiceServer struct{}
 func (UnimplementedMarkdownServiceServer) ParseMarkdown(context.Context, *ParseMarkdownRequest) (*ParseMarkdownResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ParseMarkdown not implemented")
 }
+func (UnimplementedMarkdownServiceServer) BatchParseMarkdown(context.Context, *BatchParseMarkdownRequest) (*BatchParseMarkdownResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BatchParseMarkdown not implemented")
+}
 func (UnimplementedMarkdownServiceServer) RestoreMarkdownNodes(context.Context, *RestoreMarkdownNodesRequest) (*RestoreMarkdownNodesResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method RestoreMarkdownNodes not implemented")
 }
 func (UnimplementedMarkdownServiceServer) StringifyMarkdownNodes(context.Context, *StringifyMarkdownNodesRequest) (*StringifyMarkdownNodesResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method StringifyMarkdownNodes not implemented")
 }
+func (UnimplementedMarkdownServiceServer) DiffMarkdownNodes(context.Context, *DiffMarkdownNodesRequest) (*DiffMarkdownNodesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DiffMarkdownNodes not implemented")
+}
+func (UnimplementedMarkdownServiceServer) RenderMarkdownToHTML(context.Context, *RenderMarkdownToHTMLRequest) (*RenderMarkdownToHTMLResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RenderMarkdownToHTML not implemented")
+}
+func (UnimplementedMarkdownServiceServer) GetMarkdownStats(context.Context, *GetMarkdownStatsRequest) (*MarkdownStats, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetMarkdownStats not implemented")
+}
+func (UnimplementedMarkdownServiceServer) LintMarkdown(context.Context, *LintMarkdownRequest) (*LintMarkdownResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method LintMarkdown not implemented")
+}
+func (UnimplementedMarkdownServiceServer) NormalizeMarkdown(context.Context, *NormalizeMarkdownRequest) (*NormalizeMarkdownResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method NormalizeMarkdown not implemented")
+}
 func (UnimplementedMarkdownServiceServer) GetLinkMetadata(context.Context, *GetLinkMetadataRequest) (*LinkMetadata, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetLinkMetadata not implemented")
 }
@@ -160,6 +280,24 @@ func _MarkdownService_ParseMarkdown_Handler(srv interface{}, ctx context.Context
 	return interceptor(ctx, in, info, handler)
 }
 
+func _MarkdownService_BatchParseMarkdown_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BatchParseMarkdownRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MarkdownServiceServer).BatchParseMarkdown(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MarkdownService_BatchParseMarkdown_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MarkdownServiceServer).BatchParseMarkdown(ctx, req.(*BatchParseMarkdownRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _MarkdownService_RestoreMarkdownNodes_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(RestoreMarkdownNodesRequest)
 	if err := dec(in); err != nil {
@@ -196,6 +334,96 @@ func _MarkdownService_StringifyMarkdownNodes_Handler(srv interface{}, ctx contex
 	return interceptor(ctx, in, info, handler)
 }
 
+func _MarkdownService_DiffMarkdownNodes_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DiffMarkdownNodesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MarkdownServiceServer).DiffMarkdownNodes(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MarkdownService_DiffMarkdownNodes_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MarkdownServiceServer).DiffMarkdownNodes(ctx, req.(*DiffMarkdownNodesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MarkdownService_RenderMarkdownToHTML_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RenderMarkdownToHTMLRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MarkdownServiceServer).RenderMarkdownToHTML(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MarkdownService_RenderMarkdownToHTML_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MarkdownServiceServer).RenderMarkdownToHTML(ctx, req.(*RenderMarkdownToHTMLRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MarkdownService_GetMarkdownStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetMarkdownStatsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MarkdownServiceServer).GetMarkdownStats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MarkdownService_GetMarkdownStats_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MarkdownServiceServer).GetMarkdownStats(ctx, req.(*GetMarkdownStatsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MarkdownService_LintMarkdown_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LintMarkdownRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MarkdownServiceServer).LintMarkdown(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MarkdownService_LintMarkdown_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MarkdownServiceServer).LintMarkdown(ctx, req.(*LintMarkdownRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MarkdownService_NormalizeMarkdown_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(NormalizeMarkdownRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MarkdownServiceServer).NormalizeMarkdown(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MarkdownService_NormalizeMarkdown_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MarkdownServiceServer).NormalizeMarkdown(ctx, req.(*NormalizeMarkdownRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _MarkdownService_GetLinkMetadata_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(GetLinkMetadataRequest)
 	if err := dec(in); err != nil {
@@ -225,6 +453,10 @@ var MarkdownService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "ParseMarkdown",
 			Handler:    _MarkdownService_ParseMarkdown_Handler,
 		},
+		{
+			MethodName: "BatchParseMarkdown",
+			Handler:    _MarkdownService_BatchParseMarkdown_Handler,
+		},
 		{
 			MethodName: "RestoreMarkdownNodes",
 			Handler:    _MarkdownService_RestoreMarkdownNodes_Handler,
@@ -233,6 +465,26 @@ var MarkdownService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "StringifyMarkdownNodes",
 			Handler:    _MarkdownService_StringifyMarkdownNodes_Handler,
 		},
+		{
+			MethodName: "DiffMarkdownNodes",
+			Handler:    _MarkdownService_DiffMarkdownNodes_Handler,
+		},
+		{
+			MethodName: "RenderMarkdownToHTML",
+			Handler:    _MarkdownService_RenderMarkdownToHTML_Handler,
+		},
+		{
+			MethodName: "GetMarkdownStats",
+			Handler:    _MarkdownService_GetMarkdownStats_Handler,
+		},
+		{
+			MethodName: "LintMarkdown",
+			Handler:    _MarkdownService_LintMarkdown_Handler,
+		},
+		{
+			MethodName: "NormalizeMarkdown",
+			Handler:    _MarkdownService_NormalizeMarkdown_Handler,
+		},
 		{
 			MethodName: "GetLinkMetadata",
 			Handler:    _MarkdownService_GetLinkMetadata_Handler,
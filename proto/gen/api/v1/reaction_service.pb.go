@@ -93,6 +93,68 @@ func (x *Reaction) GetReactionType() string {
 	return ""
 }
 
+// ReactionSummary is the number of reactions of one type to a content.
+type ReactionSummary struct {
+	state        protoimpl.MessageState `protogen:"open.v1"`
+	ReactionType string                 `protobuf:"bytes,1,opt,name=reaction_type,json=reactionType,proto3" json:"reaction_type,omitempty"`
+	Count        int32                  `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"`
+	// Whether the current user is one of the reactors.
+	ViewerReacted bool `protobuf:"varint,3,opt,name=viewer_reacted,json=viewerReacted,proto3" json:"viewer_reacted,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReactionSummary) Reset() {
+	*x = ReactionSummary{}
+	mi := &file_api_v1_reaction_service_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReactionSummary) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReactionSummary) ProtoMessage() {}
+
+func (x *ReactionSummary) ProtoReflect() protoreflect.Message {
+	mi := &file_api_v1_reaction_service_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReactionSummary.ProtoReflect.Descriptor instead.
+func (*ReactionSummary) Descriptor() ([]byte, []int) {
+	return file_api_v1_reaction_service_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *ReactionSummary) GetReactionType() string {
+	if x != nil {
+		return x.ReactionType
+	}
+	return ""
+}
+
+func (x *ReactionSummary) GetCount() int32 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+func (x *ReactionSummary) GetViewerReacted() bool {
+	if x != nil {
+		return x.ViewerReacted
+	}
+	return false
+}
+
 var File_api_v1_reaction_service_proto protoreflect.FileDescriptor
 
 const file_api_v1_reaction_service_proto_rawDesc = "" +
@@ -103,7 +165,11 @@ const file_api_v1_reaction_service_proto_rawDesc = "" +
 	"\acreator\x18\x02 \x01(\tR\acreator\x12\x1d\n" +
 	"\n" +
 	"content_id\x18\x03 \x01(\tR\tcontentId\x12#\n" +
-	"\rreaction_type\x18\x04 \x01(\tR\freactionTypeB\xac\x01\n" +
+	"\rreaction_type\x18\x04 \x01(\tR\freactionType\"s\n" +
+	"\x0fReactionSummary\x12#\n" +
+	"\rreaction_type\x18\x01 \x01(\tR\freactionType\x12\x14\n" +
+	"\x05count\x18\x02 \x01(\x05R\x05count\x12%\n" +
+	"\x0eviewer_reacted\x18\x03 \x01(\bR\rviewerReactedB\xac\x01\n" +
 	"\x10com.memos.api.v1B\x14ReactionServiceProtoP\x01Z0github.com/usememos/memos/proto/gen/api/v1;apiv1\xa2\x02\x03MAX\xaa\x02\fMemos.Api.V1\xca\x02\fMemos\\Api\\V1\xe2\x02\x18Memos\\Api\\V1\\GPBMetadata\xea\x02\x0eMemos::Api::V1b\x06proto3"
 
 var (
@@ -118,9 +184,10 @@ func file_api_v1_reaction_service_proto_rawDescGZIP() []byte {
 	return file_api_v1_reaction_service_proto_rawDescData
 }
 
-var file_api_v1_reaction_service_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
+var file_api_v1_reaction_service_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
 var file_api_v1_reaction_service_proto_goTypes = []any{
-	(*Reaction)(nil), // 0: memos.api.v1.Reaction
+	(*Reaction)(nil),        // 0: memos.api.v1.Reaction
+	(*ReactionSummary)(nil), // 1: memos.api.v1.ReactionSummary
 }
 var file_api_v1_reaction_service_proto_depIdxs = []int32{
 	0, // [0:0] is the sub-list for method output_type
@@ -141,7 +208,7 @@ func file_api_v1_reaction_service_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_api_v1_reaction_service_proto_rawDesc), len(file_api_v1_reaction_service_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   1,
+			NumMessages:   2,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
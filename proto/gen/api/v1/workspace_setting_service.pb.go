@@ -205,8 +205,11 @@ type WorkspaceGeneralSetting struct {
 	DisallowChangeUsername bool `protobuf:"varint,7,opt,name=disallow_change_username,json=disallowChangeUsername,proto3" json:"disallow_change_username,omitempty"`
 	// disallow_change_nickname disallows changing nickname.
 	DisallowChangeNickname bool `protobuf:"varint,8,opt,name=disallow_change_nickname,json=disallowChangeNickname,proto3" json:"disallow_change_nickname,omitempty"`
-	unknownFields          protoimpl.UnknownFields
-	sizeCache              protoimpl.SizeCache
+	// enable_query_explain allows admins to get the query plans of memo lists, e.g. to diagnose
+	// slow queries. The plans reveal the schema and indexes, so it is off by default.
+	EnableQueryExplain bool `protobuf:"varint,9,opt,name=enable_query_explain,json=enableQueryExplain,proto3" json:"enable_query_explain,omitempty"`
+	unknownFields      protoimpl.UnknownFields
+	sizeCache          protoimpl.SizeCache
 }
 
 func (x *WorkspaceGeneralSetting) Reset() {
@@ -295,6 +298,13 @@ func (x *WorkspaceGeneralSetting) GetDisallowChangeNickname() bool {
 	return false
 }
 
+func (x *WorkspaceGeneralSetting) GetEnableQueryExplain() bool {
+	if x != nil {
+		return x.EnableQueryExplain
+	}
+	return false
+}
+
 type WorkspaceCustomProfile struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Title         string                 `protobuf:"bytes,1,opt,name=title,proto3" json:"title,omitempty"`
@@ -467,7 +477,48 @@ type WorkspaceMemoRelatedSetting struct {
 	// enable_blur_nsfw_content enables blurring of content marked as not safe for work (NSFW).
 	EnableBlurNsfwContent bool `protobuf:"varint,12,opt,name=enable_blur_nsfw_content,json=enableBlurNsfwContent,proto3" json:"enable_blur_nsfw_content,omitempty"`
 	// nsfw_tags is the list of tags that mark content as NSFW for blurring.
-	NsfwTags      []string `protobuf:"bytes,13,rep,name=nsfw_tags,json=nsfwTags,proto3" json:"nsfw_tags,omitempty"`
+	NsfwTags []string `protobuf:"bytes,13,rep,name=nsfw_tags,json=nsfwTags,proto3" json:"nsfw_tags,omitempty"`
+	// link_metadata_cache_ttl is how long fetched link metadata is cached. Unit is second.
+	LinkMetadataCacheTtl int32 `protobuf:"varint,14,opt,name=link_metadata_cache_ttl,json=linkMetadataCacheTtl,proto3" json:"link_metadata_cache_ttl,omitempty"`
+	// link_metadata_fetch_timeout is the timeout of fetching link metadata. Unit is second.
+	LinkMetadataFetchTimeout int32 `protobuf:"varint,15,opt,name=link_metadata_fetch_timeout,json=linkMetadataFetchTimeout,proto3" json:"link_metadata_fetch_timeout,omitempty"`
+	// link_metadata_allow_internal_ips allows fetching link metadata from loopback, link-local and private addresses,
+	// e.g. when memos is behind a proxy.
+	LinkMetadataAllowInternalIps bool `protobuf:"varint,16,opt,name=link_metadata_allow_internal_ips,json=linkMetadataAllowInternalIps,proto3" json:"link_metadata_allow_internal_ips,omitempty"`
+	// link_metadata_user_agent is the User-Agent header of link metadata fetches.
+	// Defaults to one that identifies memos and its version.
+	LinkMetadataUserAgent string `protobuf:"bytes,17,opt,name=link_metadata_user_agent,json=linkMetadataUserAgent,proto3" json:"link_metadata_user_agent,omitempty"`
+	// link_metadata_accept_language is the Accept-Language header of link metadata fetches, e.g. "en-US,en;q=0.9".
+	// The header is not sent when empty.
+	LinkMetadataAcceptLanguage string `protobuf:"bytes,18,opt,name=link_metadata_accept_language,json=linkMetadataAcceptLanguage,proto3" json:"link_metadata_accept_language,omitempty"`
+	// link_metadata_rate_limit is how many link metadata requests a user, or an anonymous IP, may send per minute.
+	LinkMetadataRateLimit int32 `protobuf:"varint,19,opt,name=link_metadata_rate_limit,json=linkMetadataRateLimit,proto3" json:"link_metadata_rate_limit,omitempty"`
+	// link_metadata_rate_limit_burst is how many link metadata requests may be sent at once.
+	LinkMetadataRateLimitBurst int32 `protobuf:"varint,20,opt,name=link_metadata_rate_limit_burst,json=linkMetadataRateLimitBurst,proto3" json:"link_metadata_rate_limit_burst,omitempty"`
+	// webhook_allow_internal_ips allows webhooks to post to loopback, link-local and private addresses.
+	WebhookAllowInternalIps bool `protobuf:"varint,21,opt,name=webhook_allow_internal_ips,json=webhookAllowInternalIps,proto3" json:"webhook_allow_internal_ips,omitempty"`
+	// link_metadata_respect_robots_txt skips fetching link metadata from pages that the robots.txt of their host
+	// disallows for the link metadata user agent.
+	LinkMetadataRespectRobotsTxt bool `protobuf:"varint,22,opt,name=link_metadata_respect_robots_txt,json=linkMetadataRespectRobotsTxt,proto3" json:"link_metadata_respect_robots_txt,omitempty"`
+	// update_time_on_metadata_change also updates the update time of a memo when its visibility or pinned state
+	// changes. By default, only changes of its content do, unless the update time is given explicitly.
+	UpdateTimeOnMetadataChange bool `protobuf:"varint,23,opt,name=update_time_on_metadata_change,json=updateTimeOnMetadataChange,proto3" json:"update_time_on_metadata_change,omitempty"`
+	// content_compression_threshold compresses the content of memos longer than it at rest. Unit is byte.
+	// Compression is disabled when zero. Compressed content is not matched by content search and filters.
+	ContentCompressionThreshold int32 `protobuf:"varint,24,opt,name=content_compression_threshold,json=contentCompressionThreshold,proto3" json:"content_compression_threshold,omitempty"`
+	// memo_count_quota is the max number of memos of each user, comments included. No limit when zero.
+	MemoCountQuota int32 `protobuf:"varint,25,opt,name=memo_count_quota,json=memoCountQuota,proto3" json:"memo_count_quota,omitempty"`
+	// memo_size_quota is the max total size of the content of the memos of each user, as stored, i.e. compressed
+	// when it is. Unit is byte. No limit when zero.
+	MemoSizeQuota int64 `protobuf:"varint,26,opt,name=memo_size_quota,json=memoSizeQuota,proto3" json:"memo_size_quota,omitempty"`
+	// memo_quota_include_archived counts archived memos toward the quotas too.
+	MemoQuotaIncludeArchived bool `protobuf:"varint,27,opt,name=memo_quota_include_archived,json=memoQuotaIncludeArchived,proto3" json:"memo_quota_include_archived,omitempty"`
+	// memo_revision_limit is the max number of revisions of the content kept per memo, of which the oldest
+	// are pruned. Defaults to 50 when zero.
+	MemoRevisionLimit int32 `protobuf:"varint,28,opt,name=memo_revision_limit,json=memoRevisionLimit,proto3" json:"memo_revision_limit,omitempty"`
+	// tag_lowercase lowercases the tags of memos as they are stored, e.g. "#Work" is stored as "work".
+	// Tags are always trimmed and their runs of separators collapsed.
+	TagLowercase  bool `protobuf:"varint,29,opt,name=tag_lowercase,json=tagLowercase,proto3" json:"tag_lowercase,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -579,6 +630,118 @@ func (x *WorkspaceMemoRelatedSetting) GetNsfwTags() []string {
 	return nil
 }
 
+func (x *WorkspaceMemoRelatedSetting) GetLinkMetadataCacheTtl() int32 {
+	if x != nil {
+		return x.LinkMetadataCacheTtl
+	}
+	return 0
+}
+
+func (x *WorkspaceMemoRelatedSetting) GetLinkMetadataFetchTimeout() int32 {
+	if x != nil {
+		return x.LinkMetadataFetchTimeout
+	}
+	return 0
+}
+
+func (x *WorkspaceMemoRelatedSetting) GetLinkMetadataAllowInternalIps() bool {
+	if x != nil {
+		return x.LinkMetadataAllowInternalIps
+	}
+	return false
+}
+
+func (x *WorkspaceMemoRelatedSetting) GetLinkMetadataUserAgent() string {
+	if x != nil {
+		return x.LinkMetadataUserAgent
+	}
+	return ""
+}
+
+func (x *WorkspaceMemoRelatedSetting) GetLinkMetadataAcceptLanguage() string {
+	if x != nil {
+		return x.LinkMetadataAcceptLanguage
+	}
+	return ""
+}
+
+func (x *WorkspaceMemoRelatedSetting) GetLinkMetadataRateLimit() int32 {
+	if x != nil {
+		return x.LinkMetadataRateLimit
+	}
+	return 0
+}
+
+func (x *WorkspaceMemoRelatedSetting) GetLinkMetadataRateLimitBurst() int32 {
+	if x != nil {
+		return x.LinkMetadataRateLimitBurst
+	}
+	return 0
+}
+
+func (x *WorkspaceMemoRelatedSetting) GetWebhookAllowInternalIps() bool {
+	if x != nil {
+		return x.WebhookAllowInternalIps
+	}
+	return false
+}
+
+func (x *WorkspaceMemoRelatedSetting) GetLinkMetadataRespectRobotsTxt() bool {
+	if x != nil {
+		return x.LinkMetadataRespectRobotsTxt
+	}
+	return false
+}
+
+func (x *WorkspaceMemoRelatedSetting) GetUpdateTimeOnMetadataChange() bool {
+	if x != nil {
+		return x.UpdateTimeOnMetadataChange
+	}
+	return false
+}
+
+func (x *WorkspaceMemoRelatedSetting) GetContentCompressionThreshold() int32 {
+	if x != nil {
+		return x.ContentCompressionThreshold
+	}
+	return 0
+}
+
+func (x *WorkspaceMemoRelatedSetting) GetMemoCountQuota() int32 {
+	if x != nil {
+		return x.MemoCountQuota
+	}
+	return 0
+}
+
+func (x *WorkspaceMemoRelatedSetting) GetMemoSizeQuota() int64 {
+	if x != nil {
+		return x.MemoSizeQuota
+	}
+	return 0
+}
+
+func (x *WorkspaceMemoRelatedSetting) GetMemoQuotaIncludeArchived() bool {
+	if x != nil {
+		return x.MemoQuotaIncludeArchived
+	}
+	return false
+}
+
+func (x *WorkspaceMemoRelatedSetting) GetMemoRevisionLimit() int32 {
+	if x != nil {
+		return x.MemoRevisionLimit
+	}
+	return 0
+}
+
+func (x *WorkspaceMemoRelatedSetting) GetTagLowercase() bool {
+	if x != nil {
+		return x.TagLowercase
+	}
+	return false
+}
+
 type GetWorkspaceSettingRequest struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
 	// The resource name of the workspace setting.
@@ -765,7 +928,7 @@ const file_api_v1_workspace_setting_service_proto_rawDesc = "" +
 	"\x0fgeneral_setting\x18\x02 \x01(\v2%.memos.api.v1.WorkspaceGeneralSettingH\x00R\x0egeneralSetting\x12P\n" +
 	"\x0fstorage_setting\x18\x03 \x01(\v2%.memos.api.v1.WorkspaceStorageSettingH\x00R\x0estorageSetting\x12]\n" +
 	"\x14memo_related_setting\x18\x04 \x01(\v2).memos.api.v1.WorkspaceMemoRelatedSettingH\x00R\x12memoRelatedSettingB\a\n" +
-	"\x05value\"\xd9\x03\n" +
+	"\x05value\"\x8b\x04\n" +
 	"\x17WorkspaceGeneralSetting\x12<\n" +
 	"\x1adisallow_user_registration\x18\x01 \x01(\bR\x18disallowUserRegistration\x124\n" +
 	"\x16disallow_password_auth\x18\x02 \x01(\bR\x14disallowPasswordAuth\x12+\n" +
@@ -774,7 +937,8 @@ const file_api_v1_workspace_setting_service_proto_rawDesc = "" +
 	"\x0ecustom_profile\x18\x05 \x01(\v2$.memos.api.v1.WorkspaceCustomProfileR\rcustomProfile\x121\n" +
 	"\x15week_start_day_offset\x18\x06 \x01(\x05R\x12weekStartDayOffset\x128\n" +
 	"\x18disallow_change_username\x18\a \x01(\bR\x16disallowChangeUsername\x128\n" +
-	"\x18disallow_change_nickname\x18\b \x01(\bR\x16disallowChangeNickname\"\xa3\x01\n" +
+	"\x18disallow_change_nickname\x18\b \x01(\bR\x16disallowChangeNickname\x120\n" +
+	"\x14enable_query_explain\x18\t \x01(\bR\x12enableQueryExplain\"\xa3\x01\n" +
 	"\x16WorkspaceCustomProfile\x12\x14\n" +
 	"\x05title\x18\x01 \x01(\tR\x05title\x12 \n" +
 	"\vdescription\x18\x02 \x01(\tR\vdescription\x12\x19\n" +
@@ -799,7 +963,7 @@ const file_api_v1_workspace_setting_service_proto_rawDesc = "" +
 	"\x18STORAGE_TYPE_UNSPECIFIED\x10\x00\x12\f\n" +
 	"\bDATABASE\x10\x01\x12\t\n" +
 	"\x05LOCAL\x10\x02\x12\x06\n" +
-	"\x02S3\x10\x03\"\xb7\x04\n" +
+	"\x02S3\x10\x03\"\xe1\v\n" +
 	"\x1bWorkspaceMemoRelatedSetting\x12<\n" +
 	"\x1adisallow_public_visibility\x18\x01 \x01(\bR\x18disallowPublicVisibility\x127\n" +
 	"\x18display_with_update_time\x18\x02 \x01(\bR\x15displayWithUpdateTime\x120\n" +
@@ -812,7 +976,23 @@ const file_api_v1_workspace_setting_service_proto_rawDesc = "" +
 	" \x03(\tR\treactions\x12<\n" +
 	"\x1adisable_markdown_shortcuts\x18\v \x01(\bR\x18disableMarkdownShortcuts\x127\n" +
 	"\x18enable_blur_nsfw_content\x18\f \x01(\bR\x15enableBlurNsfwContent\x12\x1b\n" +
-	"\tnsfw_tags\x18\r \x03(\tR\bnsfwTagsJ\x04\b\x04\x10\x05\"6\n" +
+	"\tnsfw_tags\x18\r \x03(\tR\bnsfwTags\x125\n" +
+	"\x17link_metadata_cache_ttl\x18\x0e \x01(\x05R\x14linkMetadataCacheTtl\x12=\n" +
+	"\x1blink_metadata_fetch_timeout\x18\x0f \x01(\x05R\x18linkMetadataFetchTimeout\x12F\n" +
+	" link_metadata_allow_internal_ips\x18\x10 \x01(\bR\x1clinkMetadataAllowInternalIps\x127\n" +
+	"\x18link_metadata_user_agent\x18\x11 \x01(\tR\x15linkMetadataUserAgent\x12A\n" +
+	"\x1dlink_metadata_accept_language\x18\x12 \x01(\tR\x1alinkMetadataAcceptLanguage\x127\n" +
+	"\x18link_metadata_rate_limit\x18\x13 \x01(\x05R\x15linkMetadataRateLimit\x12B\n" +
+	"\x1elink_metadata_rate_limit_burst\x18\x14 \x01(\x05R\x1alinkMetadataRateLimitBurst\x12;\n" +
+	"\x1awebhook_allow_internal_ips\x18\x15 \x01(\bR\x17webhookAllowInternalIps\x12F\n" +
+	" link_metadata_respect_robots_txt\x18\x16 \x01(\bR\x1clinkMetadataRespectRobotsTxt\x12B\n" +
+	"\x1eupdate_time_on_metadata_change\x18\x17 \x01(\bR\x1aupdateTimeOnMetadataChange\x12B\n" +
+	"\x1dcontent_compression_threshold\x18\x18 \x01(\x05R\x1bcontentCompressionThreshold\x12(\n" +
+	"\x10memo_count_quota\x18\x19 \x01(\x05R\x0ememoCountQuota\x12&\n" +
+	"\x0fmemo_size_quota\x18\x1a \x01(\x03R\rmemoSizeQuota\x12=\n" +
+	"\x1bmemo_quota_include_archived\x18\x1b \x01(\bR\x18memoQuotaIncludeArchived\x12.\n" +
+	"\x13memo_revision_limit\x18\x1c \x01(\x05R\x11memoRevisionLimit\x12#\n" +
+	"\rtag_lowercase\x18\x1d \x01(\bR\ftagLowercaseJ\x04\b\x04\x10\x05\"6\n" +
 	"\x1aGetWorkspaceSettingRequest\x12\x18\n" +
 	"\x04name\x18\x01 \x01(\tB\x04\xe2A\x01\x02R\x04name\"V\n" +
 	"\x1aSetWorkspaceSettingRequest\x128\n" +
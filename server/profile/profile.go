@@ -7,6 +7,7 @@ import (
 	"path/filepath"
 	"runtime"
 	"strings"
+	"time"
 
 	"github.com/pkg/errors"
 )
@@ -30,6 +31,21 @@ type Profile struct {
 	Version string
 	// InstanceURL is the url of your memos instance.
 	InstanceURL string
+	// DBPool is the connection pool configuration of the database.
+	DBPool DBPool
+}
+
+// DBPool configures the connection pool of the database. Zero values keep the defaults of
+// database/sql, i.e. unlimited open connections, 2 idle connections and no time limits.
+type DBPool struct {
+	// MaxOpenConns is the max number of open connections.
+	MaxOpenConns int
+	// MaxIdleConns is the max number of idle connections. Negative keeps no idle connections.
+	MaxIdleConns int
+	// ConnMaxLifetime is the max time a connection may be reused.
+	ConnMaxLifetime time.Duration
+	// ConnMaxIdleTime is the max time a connection may be idle.
+	ConnMaxIdleTime time.Duration
 }
 
 func (p *Profile) IsDev() bool {
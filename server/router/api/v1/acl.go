@@ -2,8 +2,10 @@ package v1
 
 import (
 	"context"
+	"log/slog"
 	"net/http"
 	"strings"
+	"time"
 
 	"github.com/golang-jwt/jwt/v5"
 	"github.com/pkg/errors"
@@ -122,9 +124,19 @@ func (in *GRPCAuthInterceptor) authenticate(ctx context.Context, accessToken str
 	if err != nil {
 		return "", errors.Wrapf(err, "failed to get user access tokens")
 	}
-	if !validateAccessToken(accessToken, accessTokens) {
+	userAccessToken := findAccessToken(accessToken, accessTokens)
+	if userAccessToken == nil {
 		return "", status.Errorf(codes.Unauthenticated, "invalid access token")
 	}
+	if userAccessToken.Revoked {
+		return "", status.Errorf(codes.Unauthenticated, "access token is revoked")
+	}
+	if now := time.Now(); store.IsAccessTokenLastUsedStale(userAccessToken, now) {
+		// The last used time is informational, so failing to record it does not fail the request.
+		if err := in.Store.TouchUserAccessToken(ctx, user.ID, accessToken, now); err != nil {
+			slog.Warn("Failed to update access token last used time", slog.Int("user", int(user.ID)), slog.Any("err", err))
+		}
+	}
 
 	return user.Username, nil
 }
@@ -152,11 +164,11 @@ func getTokenFromMetadata(md metadata.MD) (string, error) {
 	return accessToken, nil
 }
 
-func validateAccessToken(accessTokenString string, userAccessTokens []*storepb.AccessTokensUserSetting_AccessToken) bool {
+func findAccessToken(accessTokenString string, userAccessTokens []*storepb.AccessTokensUserSetting_AccessToken) *storepb.AccessTokensUserSetting_AccessToken {
 	for _, userAccessToken := range userAccessTokens {
 		if accessTokenString == userAccessToken.AccessToken {
-			return true
+			return userAccessToken
 		}
 	}
-	return false
+	return nil
 }
@@ -0,0 +1,120 @@
+package v1
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"strings"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+
+	v1pb "github.com/usememos/memos/proto/gen/api/v1"
+)
+
+// markdownETagRequests are the requests of the gateway paths of the markdown endpoints whose
+// responses only depend on their request, so that they can be tagged with a hash of it.
+var markdownETagRequests = map[string]func() proto.Message{
+	"/api/v1/markdown:render": func() proto.Message { return &v1pb.RenderMarkdownToHTMLRequest{} },
+	"/api/v1/markdown:stats":  func() proto.Message { return &v1pb.GetMarkdownStatsRequest{} },
+}
+
+// newMarkdownETagHandler wraps the gateway handler so that the successful responses of the
+// markdown render endpoints carry a strong ETag, the hash of the request and the server version,
+// which renders differently across versions. A request whose If-None-Match holds the ETag is still
+// forwarded, so that it is authorized as usual, but gets 304 Not Modified without the body.
+func newMarkdownETagHandler(next http.Handler, version string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		newRequest, ok := markdownETagRequests[r.URL.Path]
+		if !ok || r.Method != http.MethodPost {
+			next.ServeHTTP(w, r)
+			return
+		}
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			http.Error(w, "failed to read request body", http.StatusBadRequest)
+			return
+		}
+		r.Body = io.NopCloser(bytes.NewReader(body))
+		// Malformed requests are left to the gateway to reject.
+		request := newRequest()
+		if err := (protojson.UnmarshalOptions{DiscardUnknown: true}).Unmarshal(body, request); err != nil {
+			next.ServeHTTP(w, r)
+			return
+		}
+		etag, err := getMarkdownETag(r.URL.Path, version, request)
+		if err != nil {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		response := &bufferedResponseWriter{header: http.Header{}}
+		next.ServeHTTP(response, r)
+		for key, values := range response.header {
+			w.Header()[key] = values
+		}
+		if response.status != http.StatusOK {
+			w.WriteHeader(response.status)
+			_, _ = w.Write(response.body.Bytes())
+			return
+		}
+		w.Header().Set("ETag", etag)
+		if matchesETag(r.Header.Get("If-None-Match"), etag) {
+			w.Header().Del("Content-Length")
+			w.Header().Del("Content-Type")
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write(response.body.Bytes())
+	})
+}
+
+// getMarkdownETag returns the strong ETag of the request to the given path, which is the same
+// for requests with the same fields however their JSON is written.
+func getMarkdownETag(path, version string, request proto.Message) (string, error) {
+	bytes, err := proto.MarshalOptions{Deterministic: true}.Marshal(request)
+	if err != nil {
+		return "", err
+	}
+	hash := sha256.New()
+	hash.Write([]byte(path + "\x00" + version + "\x00"))
+	hash.Write(bytes)
+	return `"` + hex.EncodeToString(hash.Sum(nil)) + `"`, nil
+}
+
+// matchesETag reports whether the If-None-Match header holds the ETag, which is compared weakly
+// as the header asks, or is "*".
+func matchesETag(ifNoneMatch, etag string) bool {
+	for _, candidate := range strings.Split(ifNoneMatch, ",") {
+		candidate = strings.TrimPrefix(strings.TrimSpace(candidate), "W/")
+		if candidate == "*" || candidate == etag {
+			return true
+		}
+	}
+	return false
+}
+
+// bufferedResponseWriter holds a response until it is known whether to send its body.
+type bufferedResponseWriter struct {
+	header http.Header
+	status int
+	body   bytes.Buffer
+}
+
+func (w *bufferedResponseWriter) Header() http.Header {
+	return w.header
+}
+
+func (w *bufferedResponseWriter) WriteHeader(status int) {
+	if w.status == 0 {
+		w.status = status
+	}
+}
+
+func (w *bufferedResponseWriter) Write(b []byte) (int, error) {
+	w.WriteHeader(http.StatusOK)
+	return w.body.Write(b)
+}
@@ -0,0 +1,59 @@
+package v1
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"github.com/stretchr/testify/require"
+
+	v1pb "github.com/usememos/memos/proto/gen/api/v1"
+)
+
+func TestMarkdownETagHandler(t *testing.T) {
+	gwMux := runtime.NewServeMux()
+	require.NoError(t, v1pb.RegisterMarkdownServiceHandlerServer(context.Background(), gwMux, &APIV1Service{}))
+	handler := newMarkdownETagHandler(gwMux, "0.24.0")
+	post := func(path, body, ifNoneMatch string) *httptest.ResponseRecorder {
+		request := httptest.NewRequest(http.MethodPost, path, strings.NewReader(body))
+		if ifNoneMatch != "" {
+			request.Header.Set("If-None-Match", ifNoneMatch)
+		}
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, request)
+		return recorder
+	}
+
+	response := post("/api/v1/markdown:render", `{"markdown": "**hello**"}`, "")
+	require.Equal(t, http.StatusOK, response.Code)
+	etag := response.Header().Get("ETag")
+	require.NotEmpty(t, etag)
+	require.Contains(t, response.Body.String(), "<strong>hello</strong>")
+
+	// The same request, however its JSON is written, matches the ETag.
+	response = post("/api/v1/markdown:render", `{"markdown":"**hello**","autoLinkWww":false}`, etag)
+	require.Equal(t, http.StatusNotModified, response.Code)
+	require.Equal(t, etag, response.Header().Get("ETag"))
+	require.Empty(t, response.Body.String())
+	response = post("/api/v1/markdown:render", `{"markdown": "**hello**"}`, `W/"other", `+etag)
+	require.Equal(t, http.StatusNotModified, response.Code)
+
+	// Other requests or ETags get the full body.
+	response = post("/api/v1/markdown:render", `{"markdown": "**hello**"}`, `"other"`)
+	require.Equal(t, http.StatusOK, response.Code)
+	require.Contains(t, response.Body.String(), "<strong>hello</strong>")
+	response = post("/api/v1/markdown:render", `{"markdown": "**hello**", "autoLinkWww": true}`, etag)
+	require.Equal(t, http.StatusOK, response.Code)
+	require.NotEqual(t, etag, response.Header().Get("ETag"))
+	response = post("/api/v1/markdown:stats", `{"markdown": "**hello**"}`, etag)
+	require.Equal(t, http.StatusOK, response.Code)
+	require.Contains(t, response.Body.String(), `"wordCount":1`)
+
+	// Errors are not tagged.
+	response = post("/api/v1/markdown:render", `{"markdown": 1}`, etag)
+	require.Equal(t, http.StatusBadRequest, response.Code)
+	require.Empty(t, response.Header().Get("ETag"))
+}
@@ -2,58 +2,364 @@ package v1
 
 import (
 	"context"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/pkg/errors"
 	"github.com/usememos/gomark/ast"
-	"github.com/usememos/gomark/parser"
-	"github.com/usememos/gomark/parser/tokenizer"
 	"github.com/usememos/gomark/renderer"
-	"github.com/usememos/gomark/restore"
+	"golang.org/x/text/language"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
 
 	"github.com/usememos/memos/plugin/httpgetter"
 	v1pb "github.com/usememos/memos/proto/gen/api/v1"
+	storepb "github.com/usememos/memos/proto/gen/store"
+	"github.com/usememos/memos/server/runner/memopayload"
+	"github.com/usememos/memos/store"
 )
 
-func (*APIV1Service) ParseMarkdown(_ context.Context, request *v1pb.ParseMarkdownRequest) (*v1pb.ParseMarkdownResponse, error) {
-	rawNodes, err := parser.Parse(tokenizer.Tokenize(request.Markdown))
+// maxBatchParseMarkdownSize is the max number of contents in a BatchParseMarkdown request.
+const maxBatchParseMarkdownSize = 200
+
+func (s *APIV1Service) ParseMarkdown(ctx context.Context, request *v1pb.ParseMarkdownRequest) (*v1pb.ParseMarkdownResponse, error) {
+	if request.MaxNestingDepth < 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "max nesting depth must not be negative")
+	}
+	nodes, truncated, err := parseMarkdownNodes(request.Markdown, parseMarkdownOptions{
+		autoLinkWWW:         request.AutoLinkWww,
+		withPositions:       request.IncludePositions,
+		withFrontmatter:     request.Frontmatter,
+		withMath:            request.Math,
+		withEmoji:           request.Emoji,
+		withFootnotes:       request.Footnotes,
+		withScripts:         request.Scripts,
+		withCodeLanguages:   request.CodeLanguages,
+		codeLanguageAliases: request.CodeLanguageAliases,
+		withCodeFences:      request.CodeFences,
+		extensions:          s.markdownExtensions,
+		maxNestingDepth:     int(request.MaxNestingDepth),
+		rawHTMLMode:         request.RawHtmlMode,
+	})
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to parse memo content")
 	}
+	workspaceMemoRelatedSetting, err := s.Store.GetWorkspaceMemoRelatedSetting(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get workspace memo related setting")
+	}
+	response := &v1pb.ParseMarkdownResponse{
+		Nodes:     nodes,
+		Tags:      store.FormatTags(memopayload.ExtractTags(convertToASTNodes(replaceFootnoteNodes(nodes))), workspaceMemoRelatedSetting.TagLowercase),
+		Truncated: truncated,
+	}
+	if request.IncludeImages {
+		response.Images = extractImageReferences(nodes)
+	}
+	return response, nil
+}
+
+func (s *APIV1Service) BatchParseMarkdown(_ context.Context, request *v1pb.BatchParseMarkdownRequest) (*v1pb.BatchParseMarkdownResponse, error) {
+	if len(request.Markdowns) > maxBatchParseMarkdownSize {
+		return nil, status.Errorf(codes.InvalidArgument, "at most %d markdown contents are allowed, got %d", maxBatchParseMarkdownSize, len(request.Markdowns))
+	}
 
-	nodes := convertFromASTNodes(rawNodes)
-	return &v1pb.ParseMarkdownResponse{
-		Nodes: nodes,
+	results := make([]*v1pb.BatchParseMarkdownResponse_Result, 0, len(request.Markdowns))
+	for _, markdown := range request.Markdowns {
+		result := &v1pb.BatchParseMarkdownResponse_Result{}
+		nodes, _, err := parseMarkdownNodes(markdown, parseMarkdownOptions{autoLinkWWW: request.AutoLinkWww, withMath: request.Math, extensions: s.markdownExtensions})
+		if err != nil {
+			result.Error = errors.Wrap(err, "failed to parse memo content").Error()
+		} else {
+			result.Nodes = nodes
+		}
+		results = append(results, result)
+	}
+	return &v1pb.BatchParseMarkdownResponse{
+		Results: results,
 	}, nil
 }
 
 func (*APIV1Service) RestoreMarkdownNodes(_ context.Context, request *v1pb.RestoreMarkdownNodesRequest) (*v1pb.RestoreMarkdownNodesResponse, error) {
-	markdown := restore.Restore(convertToASTNodes(request.Nodes))
+	if request.Strict {
+		if err := validateMarkdownNodes(request.Nodes, "nodes"); err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid nodes: %v", err)
+		}
+	}
+	markdown := restoreMarkdownNodes(request.Nodes, false)
 	return &v1pb.RestoreMarkdownNodesResponse{
 		Markdown: markdown,
 	}, nil
 }
 
 func (*APIV1Service) StringifyMarkdownNodes(_ context.Context, request *v1pb.StringifyMarkdownNodesRequest) (*v1pb.StringifyMarkdownNodesResponse, error) {
-	stringRenderer := renderer.NewStringRenderer()
-	plainText := stringRenderer.Render(convertToASTNodes(request.Nodes))
+	if request.WrapWidth < 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "wrap width must not be negative")
+	}
+	nodes, err := rewriteMarkdownLinks(request.Nodes, request.LinkMode, request.BaseUrl)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "%v", err)
+	}
+	nodes = replaceEmojiNodes(nodes, request.EmojiUnicode)
+	nodes = replaceCustomNodes(nodes)
+	nodes = replaceFootnoteNodes(nodes)
+	if request.WrapWidth > 0 {
+		nodes = wrapMarkdownNodes(nodes, int(request.WrapWidth), request.Mode)
+	}
+	var plainText string
+	var tableOfContents []*v1pb.TableOfContentsEntry
+	switch request.Mode {
+	case v1pb.StringifyMarkdownNodesRequest_PLAIN_TEXT:
+		plainText = renderPlainText(excludeFrontmatter(nodes))
+	case v1pb.StringifyMarkdownNodesRequest_GFM:
+		plainText = restoreMarkdownNodes(nodes, false)
+	case v1pb.StringifyMarkdownNodesRequest_COMMONMARK:
+		plainText = restoreMarkdownNodes(nodes, true)
+	case v1pb.StringifyMarkdownNodesRequest_TABLE_OF_CONTENTS:
+		tableOfContents = getTableOfContents(nodes)
+		plainText = renderTableOfContents(tableOfContents)
+	default:
+		stringRenderer := renderer.NewStringRenderer()
+		plainText = stringRenderer.Render(convertToASTNodes(excludeFrontmatter(nodes)))
+	}
 	return &v1pb.StringifyMarkdownNodesResponse{
-		PlainText: plainText,
+		PlainText:       plainText,
+		TableOfContents: tableOfContents,
+	}, nil
+}
+
+func (s *APIV1Service) DiffMarkdownNodes(_ context.Context, request *v1pb.DiffMarkdownNodesRequest) (*v1pb.DiffMarkdownNodesResponse, error) {
+	oldNodes, err := s.getDiffMarkdownNodes(request.OldNodes, request.OldMarkdown)
+	if err != nil {
+		return nil, err
+	}
+	newNodes, err := s.getDiffMarkdownNodes(request.NewNodes, request.NewMarkdown)
+	if err != nil {
+		return nil, err
+	}
+	diffs, ok := diffMarkdownNodes(oldNodes, newNodes, nil, nil)
+	if !ok {
+		return nil, status.Errorf(codes.InvalidArgument, "the versions differ in too many blocks to compare")
+	}
+	return &v1pb.DiffMarkdownNodesResponse{
+		Diffs: diffs,
+	}, nil
+}
+
+// getDiffMarkdownNodes returns the given nodes of a version to diff, or the nodes of its markdown
+// when no nodes are given.
+func (s *APIV1Service) getDiffMarkdownNodes(nodes []*v1pb.Node, markdown string) ([]*v1pb.Node, error) {
+	if len(nodes) > 0 {
+		return nodes, nil
+	}
+	nodes, _, err := parseMarkdownNodes(markdown, parseMarkdownOptions{extensions: s.markdownExtensions})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to parse memo content")
+	}
+	return nodes, nil
+}
+
+func (*APIV1Service) RenderMarkdownToHTML(_ context.Context, request *v1pb.RenderMarkdownToHTMLRequest) (*v1pb.RenderMarkdownToHTMLResponse, error) {
+	parsed, err := parseMarkdown(request.Markdown, parseMarkdownOptions{withMath: true})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to parse memo content")
+	}
+	rawNodes := adjustMath(parsed.nodes, true)
+	rawNodes = detectAutoLinks(rawNodes, request.AutoLinkWww)
+	rawNodes = splitTagPunctuation(rawNodes)
+	return &v1pb.RenderMarkdownToHTMLResponse{
+		Html: renderSafeHTML(rawNodes),
+	}, nil
+}
+
+func (*APIV1Service) GetMarkdownStats(_ context.Context, request *v1pb.GetMarkdownStatsRequest) (*v1pb.MarkdownStats, error) {
+	nodes, _, err := parseMarkdownNodes(request.Markdown, parseMarkdownOptions{})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to parse memo content")
+	}
+	text := strings.Join(renderPlainTextBlocks(nodes, request.IncludeCodeBlocks), "\n")
+	return getMarkdownStats(text), nil
+}
+
+func (*APIV1Service) LintMarkdown(_ context.Context, request *v1pb.LintMarkdownRequest) (*v1pb.LintMarkdownResponse, error) {
+	diagnostics, err := lintMarkdown(request.Markdown)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to parse memo content")
+	}
+	return &v1pb.LintMarkdownResponse{
+		Diagnostics: diagnostics,
+	}, nil
+}
+
+func (*APIV1Service) NormalizeMarkdown(_ context.Context, request *v1pb.NormalizeMarkdownRequest) (*v1pb.NormalizeMarkdownResponse, error) {
+	markdown, err := normalizeMarkdown(request.Markdown, request.BulletStyle)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to parse memo content")
+	}
+	return &v1pb.NormalizeMarkdownResponse{
+		Markdown: markdown,
 	}, nil
 }
 
-func (*APIV1Service) GetLinkMetadata(_ context.Context, request *v1pb.GetLinkMetadataRequest) (*v1pb.LinkMetadata, error) {
-	htmlMeta, err := httpgetter.GetHTMLMeta(request.Link)
+func (s *APIV1Service) GetLinkMetadata(ctx context.Context, request *v1pb.GetLinkMetadataRequest) (*v1pb.LinkMetadata, error) {
+	workspaceMemoRelatedSetting, err := s.Store.GetWorkspaceMemoRelatedSetting(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get workspace memo related setting")
+	}
+	if err := s.checkLinkMetadataRateLimit(ctx, workspaceMemoRelatedSetting); err != nil {
+		return nil, err
+	}
+	acceptLanguage, err := s.getLinkMetadataAcceptLanguage(ctx, request.Language, workspaceMemoRelatedSetting)
 	if err != nil {
 		return nil, err
 	}
+	ttl := time.Duration(workspaceMemoRelatedSetting.LinkMetadataCacheTtl) * time.Second
+	htmlMeta, err := s.linkMetadataCache.Get(request.Link, ttl, httpgetter.HTMLMetaOptions{
+		Timeout:          time.Duration(workspaceMemoRelatedSetting.LinkMetadataFetchTimeout) * time.Second,
+		AllowInternalIPs: workspaceMemoRelatedSetting.LinkMetadataAllowInternalIps,
+		OEmbed:           request.Oembed,
+		Article:          request.Article,
+		UserAgent:        workspaceMemoRelatedSetting.LinkMetadataUserAgent,
+		AcceptLanguage:   acceptLanguage,
+		RespectRobotsTxt: workspaceMemoRelatedSetting.LinkMetadataRespectRobotsTxt,
+	})
+	if err != nil {
+		return nil, convertLinkMetadataError(err)
+	}
 
 	return &v1pb.LinkMetadata{
-		Title:       htmlMeta.Title,
-		Description: htmlMeta.Description,
-		Image:       htmlMeta.Image,
+		Title:                   htmlMeta.Title,
+		Description:             htmlMeta.Description,
+		Image:                   htmlMeta.Image,
+		FaviconUrl:              htmlMeta.FaviconURL,
+		CanonicalUrl:            htmlMeta.CanonicalURL,
+		Oembed:                  convertOEmbedFromHTMLMeta(htmlMeta.OEmbed),
+		MediaType:               htmlMeta.MediaType,
+		ImageWidth:              int32(htmlMeta.ImageWidth),
+		ImageHeight:             int32(htmlMeta.ImageHeight),
+		Article:                 convertArticleFromHTMLMeta(htmlMeta.Article),
+		ArticleExtractionFailed: htmlMeta.ArticleExtractionFailed,
 	}, nil
 }
 
+// getLinkMetadataAcceptLanguage returns the Accept-Language header of link metadata fetches: the
+// requested language, else the locale of the current user, else the workspace setting. Invalid
+// language tags are skipped.
+func (s *APIV1Service) getLinkMetadataAcceptLanguage(ctx context.Context, requested string, setting *storepb.WorkspaceMemoRelatedSetting) (string, error) {
+	if tag, ok := parseLanguageTag(requested); ok {
+		return tag, nil
+	}
+	user, err := s.GetCurrentUser(ctx)
+	if err != nil {
+		return "", status.Errorf(codes.Internal, "failed to get current user: %v", err)
+	}
+	if user != nil {
+		userSetting, err := s.Store.GetUserSetting(ctx, &store.FindUserSetting{
+			UserID: &user.ID,
+			Key:    storepb.UserSettingKey_LOCALE,
+		})
+		if err != nil {
+			return "", status.Errorf(codes.Internal, "failed to get user setting: %v", err)
+		}
+		if tag, ok := parseLanguageTag(userSetting.GetLocale()); ok {
+			return tag, nil
+		}
+	}
+	return setting.LinkMetadataAcceptLanguage, nil
+}
+
+// parseLanguageTag returns the canonical form of a BCP 47 language tag, e.g. "de-DE".
+func parseLanguageTag(tag string) (string, bool) {
+	if tag == "" {
+		return "", false
+	}
+	parsed, err := language.Parse(tag)
+	if err != nil {
+		return "", false
+	}
+	return parsed.String(), true
+}
+
+// checkLinkMetadataRateLimit returns a ResourceExhausted error when the current user, or
+// the client IP for anonymous requests, has sent too many link metadata requests. The
+// time to wait is sent back in the retry-after header.
+func (s *APIV1Service) checkLinkMetadataRateLimit(ctx context.Context, setting *storepb.WorkspaceMemoRelatedSetting) error {
+	user, err := s.GetCurrentUser(ctx)
+	if err != nil {
+		return status.Errorf(codes.Internal, "failed to get current user: %v", err)
+	}
+	key := "ip:" + getClientIP(ctx)
+	if user != nil {
+		key = fmt.Sprintf("user:%d", user.ID)
+	}
+	allowed, retryAfter, err := s.LinkMetadataRateLimiter.Allow(ctx, key, RateLimit{
+		Rate:  float64(setting.LinkMetadataRateLimit) / 60,
+		Burst: int(setting.LinkMetadataRateLimitBurst),
+	})
+	if err != nil {
+		return status.Errorf(codes.Internal, "failed to check rate limit: %v", err)
+	}
+	if !allowed {
+		seconds := int(math.Ceil(retryAfter.Seconds()))
+		_ = grpc.SetHeader(ctx, metadata.Pairs("retry-after", strconv.Itoa(seconds)))
+		return status.Errorf(codes.ResourceExhausted, "too many link metadata requests, retry after %d seconds", seconds)
+	}
+	return nil
+}
+
+func convertOEmbedFromHTMLMeta(oEmbed *httpgetter.OEmbed) *v1pb.LinkMetadata_OEmbed {
+	if oEmbed == nil {
+		return nil
+	}
+	return &v1pb.LinkMetadata_OEmbed{
+		Type:            oEmbed.Type,
+		Title:           oEmbed.Title,
+		Html:            oEmbed.HTML,
+		Width:           int32(oEmbed.Width),
+		Height:          int32(oEmbed.Height),
+		ThumbnailUrl:    oEmbed.ThumbnailURL,
+		ThumbnailWidth:  int32(oEmbed.ThumbnailWidth),
+		ThumbnailHeight: int32(oEmbed.ThumbnailHeight),
+		ProviderName:    oEmbed.ProviderName,
+		ProviderUrl:     oEmbed.ProviderURL,
+		AuthorName:      oEmbed.AuthorName,
+	}
+}
+
+// convertArticleFromHTMLMeta converts the readable article extracted from a page, if any.
+func convertArticleFromHTMLMeta(article *httpgetter.Article) *v1pb.LinkMetadata_Article {
+	if article == nil {
+		return nil
+	}
+	return &v1pb.LinkMetadata_Article{
+		Markdown:       article.Markdown,
+		Text:           article.Text,
+		WordCount:      int32(article.WordCount),
+		ReadingMinutes: int32(article.ReadingMinutes),
+	}
+}
+
+// convertLinkMetadataError converts the error of fetching link metadata to a status error.
+func convertLinkMetadataError(err error) error {
+	switch {
+	case errors.Is(err, httpgetter.ErrTimeout):
+		return status.Errorf(codes.DeadlineExceeded, "failed to get link metadata: %v", err)
+	case errors.Is(err, httpgetter.ErrDisallowedByRobots):
+		return status.Errorf(codes.FailedPrecondition, "link target is disallowed by the robots.txt of its host: %v", err)
+	case errors.Is(err, httpgetter.ErrInternalIP):
+		return status.Errorf(codes.PermissionDenied, "link target resolves to a blocked internal address: %v", err)
+	case errors.Is(err, httpgetter.ErrInvalidURL), errors.Is(err, httpgetter.ErrBodyTooLarge), errors.Is(err, httpgetter.ErrTooManyRedirects):
+		return status.Errorf(codes.InvalidArgument, "failed to get link metadata: %v", err)
+	default:
+		return status.Errorf(codes.Internal, "failed to get link metadata: %v", err)
+	}
+}
+
 func convertFromASTNode(rawNode ast.Node) *v1pb.Node {
 	node := &v1pb.Node{
 		Type: v1pb.NodeType(v1pb.NodeType_value[string(rawNode.Type())]),
@@ -129,6 +435,16 @@ func convertFromASTNode(rawNode ast.Node) *v1pb.Node {
 		node.Node = &v1pb.Node_SpoilerNode{SpoilerNode: &v1pb.SpoilerNode{Content: n.Content}}
 	case *ast.HTMLElement:
 		node.Node = &v1pb.Node_HtmlElementNode{HtmlElementNode: &v1pb.HTMLElementNode{TagName: n.TagName, Attributes: n.Attributes}}
+	case *frontmatter:
+		node.Node = &v1pb.Node_FrontmatterNode{FrontmatterNode: &v1pb.FrontmatterNode{Content: n.Content}}
+	case *emoji:
+		node.Node = &v1pb.Node_EmojiNode{EmojiNode: &v1pb.EmojiNode{Shortcode: n.Shortcode, Unicode: n.Unicode}}
+	case *customNode:
+		node.Node = &v1pb.Node_CustomNode{CustomNode: &v1pb.CustomNode{Extension: n.Extension, Raw: n.Raw, Attributes: n.Attributes}}
+	case *footnoteDef:
+		node.Node = &v1pb.Node_FootnoteDefNode{FootnoteDefNode: &v1pb.FootnoteDefNode{Label: n.Label, Children: convertFromASTNodes(n.Children), Number: int32(n.Number), Duplicate: n.Duplicate}}
+	case *footnoteRef:
+		node.Node = &v1pb.Node_FootnoteRefNode{FootnoteRefNode: &v1pb.FootnoteRefNode{Label: n.Label, Number: int32(n.Number)}}
 	default:
 		node.Node = &v1pb.Node_TextNode{TextNode: &v1pb.TextNode{}}
 	}
@@ -162,7 +478,8 @@ func convertListKindFromASTNode(node ast.ListKind) v1pb.ListNode_Kind {
 	case ast.UnorderedList:
 		return v1pb.ListNode_UNORDERED
 	case ast.DescrpitionList:
-		return v1pb.ListNode_DESCRIPTION
+		// gomark only groups task list items into description lists.
+		return v1pb.ListNode_TASK
 	default:
 		return v1pb.ListNode_KIND_UNSPECIFIED
 	}
@@ -176,7 +493,11 @@ func convertToASTNode(node *v1pb.Node) ast.Node {
 		children := convertToASTNodes(n.ParagraphNode.Children)
 		return &ast.Paragraph{Children: children}
 	case *v1pb.Node_CodeBlockNode:
-		return &ast.CodeBlock{Language: n.CodeBlockNode.Language, Content: n.CodeBlockNode.Content}
+		language := n.CodeBlockNode.Language
+		if n.CodeBlockNode.Info != "" {
+			language = n.CodeBlockNode.Info
+		}
+		return &ast.CodeBlock{Language: language, Content: n.CodeBlockNode.Content}
 	case *v1pb.Node_HeadingNode:
 		children := convertToASTNodes(n.HeadingNode.Children)
 		return &ast.Heading{Level: int(n.HeadingNode.Level), Children: children}
@@ -239,6 +560,16 @@ func convertToASTNode(node *v1pb.Node) ast.Node {
 		return &ast.Spoiler{Content: n.SpoilerNode.Content}
 	case *v1pb.Node_HtmlElementNode:
 		return &ast.HTMLElement{TagName: n.HtmlElementNode.TagName, Attributes: n.HtmlElementNode.Attributes}
+	case *v1pb.Node_FrontmatterNode:
+		return &frontmatter{Content: n.FrontmatterNode.Content}
+	case *v1pb.Node_EmojiNode:
+		return &emoji{Shortcode: n.EmojiNode.Shortcode, Unicode: n.EmojiNode.Unicode}
+	case *v1pb.Node_CustomNode:
+		return &customNode{Extension: n.CustomNode.Extension, Raw: n.CustomNode.Raw, Attributes: n.CustomNode.Attributes}
+	case *v1pb.Node_FootnoteDefNode:
+		return &footnoteDef{Label: n.FootnoteDefNode.Label, Children: convertToASTNodes(n.FootnoteDefNode.Children), Number: int(n.FootnoteDefNode.Number), Duplicate: n.FootnoteDefNode.Duplicate}
+	case *v1pb.Node_FootnoteRefNode:
+		return &footnoteRef{Label: n.FootnoteRefNode.Label, Number: int(n.FootnoteRefNode.Number)}
 	default:
 		return &ast.Text{}
 	}
@@ -270,7 +601,7 @@ func convertListKindToASTNode(kind v1pb.ListNode_Kind) ast.ListKind {
 		return ast.OrderedList
 	case v1pb.ListNode_UNORDERED:
 		return ast.UnorderedList
-	case v1pb.ListNode_DESCRIPTION:
+	case v1pb.ListNode_DESCRIPTION, v1pb.ListNode_TASK:
 		return ast.DescrpitionList
 	default:
 		// Default to description list.
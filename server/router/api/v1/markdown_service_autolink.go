@@ -0,0 +1,121 @@
+package v1
+
+import (
+	"net/url"
+	"regexp"
+	"strings"
+
+	"github.com/usememos/gomark/ast"
+)
+
+var (
+	bareURLRegexp        = regexp.MustCompile(`(?i)\bhttps?://[^\s<>]+`)
+	bareURLOrWWWRegexp   = regexp.MustCompile(`(?i)\b(?:https?://|www\.)[^\s<>]+`)
+	trailingPunctuations = ".,:;!?'\""
+)
+
+// detectAutoLinks turns bare URLs in the text of the given nodes into auto links and
+// keeps trailing punctuation out of them. Hosts prefixed with www. are only detected
+// when autoLinkWWW is set. The nodes are modified in place.
+func detectAutoLinks(nodes []ast.Node, autoLinkWWW bool) []ast.Node {
+	result := make([]ast.Node, 0, len(nodes))
+	for _, node := range nodes {
+		switch n := node.(type) {
+		case *ast.Paragraph:
+			n.Children = detectAutoLinks(n.Children, autoLinkWWW)
+		case *ast.Heading:
+			n.Children = detectAutoLinks(n.Children, autoLinkWWW)
+		case *ast.Blockquote:
+			n.Children = detectAutoLinks(n.Children, autoLinkWWW)
+		case *ast.List:
+			n.Children = detectAutoLinks(n.Children, autoLinkWWW)
+		case *ast.OrderedListItem:
+			n.Children = detectAutoLinks(n.Children, autoLinkWWW)
+		case *ast.UnorderedListItem:
+			n.Children = detectAutoLinks(n.Children, autoLinkWWW)
+		case *ast.TaskListItem:
+			n.Children = detectAutoLinks(n.Children, autoLinkWWW)
+		case *ast.Bold:
+			n.Children = detectAutoLinks(n.Children, autoLinkWWW)
+		case *ast.Italic:
+			n.Children = detectAutoLinks(n.Children, autoLinkWWW)
+		case *footnoteDef:
+			n.Children = detectAutoLinks(n.Children, autoLinkWWW)
+		case *ast.AutoLink:
+			if n.IsRawText {
+				result = append(result, splitAutoLink(n.URL)...)
+				continue
+			}
+		case *ast.Text:
+			result = append(result, splitText(n.Content, autoLinkWWW)...)
+			continue
+		}
+		result = append(result, node)
+	}
+	return result
+}
+
+// splitText splits the given text into text and auto link nodes.
+func splitText(content string, autoLinkWWW bool) []ast.Node {
+	urlRegexp := bareURLRegexp
+	if autoLinkWWW {
+		urlRegexp = bareURLOrWWWRegexp
+	}
+	nodes := []ast.Node{}
+	start := 0
+	for _, loc := range urlRegexp.FindAllStringIndex(content, -1) {
+		urlStr := trimTrailingPunctuation(content[loc[0]:loc[1]])
+		if !isAutoLinkURL(urlStr) {
+			continue
+		}
+		if loc[0] > start {
+			nodes = append(nodes, &ast.Text{Content: content[start:loc[0]]})
+		}
+		nodes = append(nodes, &ast.AutoLink{URL: urlStr, IsRawText: true})
+		start = loc[0] + len(urlStr)
+	}
+	if start < len(content) {
+		nodes = append(nodes, &ast.Text{Content: content[start:]})
+	}
+	return nodes
+}
+
+// splitAutoLink moves the trailing punctuation of a bare auto link into a text node.
+func splitAutoLink(urlStr string) []ast.Node {
+	trimmed := trimTrailingPunctuation(urlStr)
+	if !isAutoLinkURL(trimmed) {
+		return []ast.Node{&ast.Text{Content: urlStr}}
+	}
+	nodes := []ast.Node{&ast.AutoLink{URL: trimmed, IsRawText: true}}
+	if len(trimmed) < len(urlStr) {
+		nodes = append(nodes, &ast.Text{Content: urlStr[len(trimmed):]})
+	}
+	return nodes
+}
+
+// trimTrailingPunctuation removes the punctuation that more likely ends the sentence
+// than the URL, e.g. a period or a closing parenthesis without an opening one.
+func trimTrailingPunctuation(urlStr string) string {
+	for len(urlStr) > 0 {
+		last := urlStr[len(urlStr)-1]
+		switch {
+		case strings.IndexByte(trailingPunctuations, last) >= 0:
+		case last == ')' && strings.Count(urlStr, "(") < strings.Count(urlStr, ")"):
+		case last == ']' && strings.Count(urlStr, "[") < strings.Count(urlStr, "]"):
+		default:
+			return urlStr
+		}
+		urlStr = urlStr[:len(urlStr)-1]
+	}
+	return urlStr
+}
+
+// isAutoLinkURL reports whether the given string is a URL with a host or a www.-prefixed host.
+func isAutoLinkURL(urlStr string) bool {
+	if strings.HasPrefix(strings.ToLower(urlStr), "www.") {
+		host, _, _ := strings.Cut(urlStr[len("www."):], "/")
+		return strings.Contains(strings.Trim(host, "."), ".")
+	}
+	u, err := url.Parse(urlStr)
+	return err == nil && u.Scheme != "" && u.Host != ""
+}
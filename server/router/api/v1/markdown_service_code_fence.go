@@ -0,0 +1,48 @@
+package v1
+
+import (
+	"strings"
+
+	"github.com/usememos/gomark/ast"
+
+	v1pb "github.com/usememos/memos/proto/gen/api/v1"
+)
+
+// codeFence holds the fences of a code block as written.
+type codeFence struct {
+	Fence        string
+	ClosingFence string
+}
+
+// setCodeFences sets the fences of the code blocks in the given nodes, which are converted from
+// the given raw nodes. Code blocks are only parsed at the top level, see blockquoteParser.
+func setCodeFences(rawNodes []ast.Node, nodes []*v1pb.Node, fences map[ast.Node]codeFence) {
+	for i, rawNode := range rawNodes {
+		fence, ok := fences[rawNode]
+		if !ok {
+			continue
+		}
+		if n, ok := nodes[i].Node.(*v1pb.Node_CodeBlockNode); ok {
+			n.CodeBlockNode.Fence = fence.Fence
+			n.CodeBlockNode.ClosingFence = fence.ClosingFence
+		}
+	}
+}
+
+// restoreFencedCodeBlock restores the given code block with its fences, keeping its content
+// verbatim. The content of nodes built by hand may lack the trailing newline, which is then added.
+func restoreFencedCodeBlock(codeBlock *v1pb.CodeBlockNode) string {
+	info := codeBlock.Language
+	if codeBlock.Info != "" {
+		info = codeBlock.Info
+	}
+	closingFence := codeBlock.ClosingFence
+	if closingFence == "" {
+		closingFence = codeBlock.Fence
+	}
+	content := codeBlock.Content
+	if content != "" && !strings.HasSuffix(content, "\n") {
+		content += "\n"
+	}
+	return codeBlock.Fence + info + "\n" + content + closingFence
+}
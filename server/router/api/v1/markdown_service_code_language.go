@@ -0,0 +1,168 @@
+package v1
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/usememos/gomark/ast"
+	"github.com/usememos/gomark/parser"
+	"github.com/usememos/gomark/parser/tokenizer"
+
+	v1pb "github.com/usememos/memos/proto/gen/api/v1"
+)
+
+// defaultCodeLanguageAliases maps common aliases of languages to the names highlighters know them by.
+var defaultCodeLanguageAliases = map[string]string{
+	"js":         "javascript",
+	"mjs":        "javascript",
+	"cjs":        "javascript",
+	"ts":         "typescript",
+	"py":         "python",
+	"py3":        "python",
+	"rb":         "ruby",
+	"golang":     "go",
+	"rs":         "rust",
+	"kt":         "kotlin",
+	"cs":         "csharp",
+	"c#":         "csharp",
+	"c++":        "cpp",
+	"sh":         "bash",
+	"shell":      "bash",
+	"zsh":        "bash",
+	"ps1":        "powershell",
+	"yml":        "yaml",
+	"md":         "markdown",
+	"htm":        "html",
+	"dockerfile": "docker",
+	"objc":       "objectivec",
+	"tex":        "latex",
+}
+
+// codeBlockParser matches a fenced code block like gomark, but takes any info string without
+// backticks after the opening fence, e.g. "go {hl=1}", while gomark only takes a single word.
+type codeBlockParser struct {
+	// fences holds the fences of the matched code blocks if not nil, in which case the code blocks
+	// are matched as in CommonMark, see parseMarkdownOptions.withCodeFences.
+	fences map[ast.Node]codeFence
+}
+
+var _ parser.BlockParser = (*codeBlockParser)(nil)
+
+func (p *codeBlockParser) Match(tokens []*tokenizer.Token) (ast.Node, int) {
+	rows := tokenizer.Split(tokens, tokenizer.NewLine)
+	// Without keeping the fences, an empty code block must be followed by another line, like in gomark.
+	if len(rows) < 2 || (p.fences == nil && len(rows) < 3) {
+		return nil, 0
+	}
+	firstRow := rows[0]
+	if len(firstRow) == 0 {
+		return nil, 0
+	}
+	fenceType := firstRow[0].Type
+	if fenceType != tokenizer.Backtick && (p.fences == nil || fenceType != tokenizer.Tilde) {
+		return nil, 0
+	}
+	fenceLength := 0
+	for fenceLength < len(firstRow) && firstRow[fenceLength].Type == fenceType {
+		fenceLength++
+	}
+	if fenceLength < 3 {
+		return nil, 0
+	}
+	if p.fences == nil {
+		// Longer fences are left with backticks in their info string, which is rejected below.
+		fenceLength = 3
+	}
+	infoTokens := firstRow[fenceLength:]
+	for _, token := range infoTokens {
+		if fenceType == tokenizer.Backtick && token.Type == tokenizer.Backtick {
+			return nil, 0
+		}
+	}
+
+	contentRows := [][]*tokenizer.Token{}
+	var closingRow []*tokenizer.Token
+	for _, row := range rows[1:] {
+		if p.isClosingFence(row, fenceType, fenceLength) {
+			closingRow = row
+			break
+		}
+		contentRows = append(contentRows, row)
+	}
+	if closingRow == nil {
+		return nil, 0
+	}
+	// The size is that of the opening fence line, the content and the closing fence with the newline before it.
+	size := len(firstRow) + 1 + len(closingRow)
+	contentTokens := []*tokenizer.Token{}
+	for i, row := range contentRows {
+		if i > 0 && p.fences == nil {
+			contentTokens = append(contentTokens, tokenizer.NewToken(tokenizer.NewLine, "\n"))
+		}
+		contentTokens = append(contentTokens, row...)
+		// As in CommonMark, every line of the content ends with a newline when keeping the fences.
+		if p.fences != nil {
+			contentTokens = append(contentTokens, tokenizer.NewToken(tokenizer.NewLine, "\n"))
+		}
+		size += len(row) + 1
+	}
+	node := &ast.CodeBlock{
+		Language: tokenizer.Stringify(infoTokens),
+		Content:  tokenizer.Stringify(contentTokens),
+	}
+	if p.fences != nil {
+		p.fences[node] = codeFence{
+			Fence:        tokenizer.Stringify(firstRow[:fenceLength]),
+			ClosingFence: tokenizer.Stringify(closingRow),
+		}
+	}
+	return node, size
+}
+
+// isClosingFence reports whether the given row closes a code block opened with a fence of the
+// given type and length. Without keeping the fences, it must be exactly three backticks.
+func (p *codeBlockParser) isClosingFence(row []*tokenizer.Token, fenceType tokenizer.TokenType, fenceLength int) bool {
+	if len(row) < fenceLength || (p.fences == nil && len(row) != fenceLength) {
+		return false
+	}
+	for _, token := range row {
+		if token.Type != fenceType {
+			return false
+		}
+	}
+	return true
+}
+
+// normalizeCodeLanguages splits the info strings of the code blocks in the given nodes, which are
+// parsed into their language, into the normalized language and metadata, see ParseMarkdownRequest.
+// The given aliases override the default ones. The nodes are modified in place.
+func normalizeCodeLanguages(nodes []*v1pb.Node, aliases map[string]string) {
+	for _, node := range nodes {
+		if n, ok := node.Node.(*v1pb.Node_CodeBlockNode); ok {
+			codeBlock := n.CodeBlockNode
+			codeBlock.Info = codeBlock.Language
+			codeBlock.Language, codeBlock.Metadata = splitCodeInfo(codeBlock.Info, aliases)
+			continue
+		}
+		normalizeCodeLanguages(getNodeChildren(node), aliases)
+	}
+}
+
+// splitCodeInfo returns the normalized language of the info string of a code block and the
+// metadata after it. The language ends at the first whitespace or brace, e.g. "go" in "go{hl=1}".
+func splitCodeInfo(info string, aliases map[string]string) (string, string) {
+	info = strings.TrimSpace(info)
+	end := strings.IndexFunc(info, func(r rune) bool {
+		return unicode.IsSpace(r) || r == '{'
+	})
+	if end < 0 {
+		end = len(info)
+	}
+	language, metadata := strings.ToLower(info[:end]), strings.TrimSpace(info[end:])
+	if alias, ok := aliases[language]; ok {
+		language = alias
+	} else if alias, ok := defaultCodeLanguageAliases[language]; ok {
+		language = alias
+	}
+	return language, metadata
+}
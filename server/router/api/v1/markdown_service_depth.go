@@ -0,0 +1,108 @@
+package v1
+
+import (
+	"github.com/usememos/gomark/ast"
+	"github.com/usememos/gomark/parser"
+	"github.com/usememos/gomark/parser/tokenizer"
+)
+
+// blockquoteParser parses blockquotes as the gomark blockquote parser does, which parses
+// nested blockquotes recursively, but stops descending at maxDepth. The rows of the innermost
+// blockquote are paragraphs, so that deeper quote markers are kept as text.
+type blockquoteParser struct {
+	// depth is the depth of the blockquotes the parser matches, 1 for top-level ones.
+	depth    int
+	maxDepth int
+	// truncated is set when a blockquote nested deeper than maxDepth is kept as text.
+	truncated *bool
+}
+
+func (p *blockquoteParser) Match(tokens []*tokenizer.Token) (ast.Node, int) {
+	rows := tokenizer.Split(tokens, tokenizer.NewLine)
+	contentRows := [][]*tokenizer.Token{}
+	for _, row := range rows {
+		if !isBlockquoteRow(row) {
+			break
+		}
+		contentRows = append(contentRows, row)
+	}
+	if len(contentRows) == 0 {
+		return nil, 0
+	}
+
+	children := []ast.Node{}
+	size := 0
+	for index, row := range contentRows {
+		contentTokens := row[2:]
+		var node ast.Node
+		if len(contentTokens) == 0 {
+			node = &ast.Paragraph{
+				Children: []ast.Node{&ast.Text{Content: " "}},
+			}
+		} else {
+			blockParsers := []parser.BlockParser{parser.NewParagraphParser()}
+			if p.depth < p.maxDepth {
+				nestedParser := &blockquoteParser{depth: p.depth + 1, maxDepth: p.maxDepth, truncated: p.truncated}
+				blockParsers = append([]parser.BlockParser{nestedParser}, blockParsers...)
+			} else if isBlockquoteRow(contentTokens) {
+				*p.truncated = true
+			}
+			nodes, err := parser.ParseBlockWithParsers(contentTokens, blockParsers)
+			if err != nil || len(nodes) != 1 {
+				return nil, 0
+			}
+			node = nodes[0]
+		}
+		children = append(children, node)
+		size += len(row)
+		if index != len(contentRows)-1 {
+			size++ // NewLine.
+		}
+	}
+	return &ast.Blockquote{
+		Children: children,
+	}, size
+}
+
+// isBlockquoteRow reports whether the row starts with a quote marker, i.e. ">" and a space.
+func isBlockquoteRow(row []*tokenizer.Token) bool {
+	return len(row) >= 2 && row[0].Type == tokenizer.GreaterThan && row[1].Type == tokenizer.Space
+}
+
+// flattenNestedLists moves the items of lists nested deeper than maxDepth up into the
+// innermost allowed list, and reports whether any list was flattened. The items keep their
+// indentation, so that they are restored as written.
+func flattenNestedLists(nodes []ast.Node, maxDepth int) bool {
+	flattened := false
+	for _, node := range nodes {
+		if list, ok := node.(*ast.List); ok && flattenNestedList(list, 1, maxDepth) {
+			flattened = true
+		}
+	}
+	return flattened
+}
+
+func flattenNestedList(list *ast.List, depth, maxDepth int) bool {
+	flattened := false
+	children := make([]ast.Node, 0, len(list.Children))
+	for _, child := range list.Children {
+		nestedList, ok := child.(*ast.List)
+		if !ok {
+			children = append(children, child)
+			continue
+		}
+		if depth < maxDepth {
+			if flattenNestedList(nestedList, depth+1, maxDepth) {
+				flattened = true
+			}
+			children = append(children, child)
+			continue
+		}
+		// The nested list is flattened at this depth, along with the lists nested in it.
+		flattenNestedList(nestedList, depth, maxDepth)
+		children = append(children, nestedList.Children...)
+		flattened = true
+	}
+	list.Children = children
+	return flattened
+}
@@ -0,0 +1,80 @@
+package v1
+
+import (
+	"fmt"
+	"html"
+	"strings"
+
+	"github.com/usememos/gomark/ast"
+)
+
+// convertToCommonMark replaces the GFM extensions in the given node with inline HTML that
+// strict CommonMark renderers understand: task list items become list items with a disabled
+// checkbox, tables become HTML tables and strikethrough becomes <del>. The node is modified in place.
+func convertToCommonMark(node ast.Node) ast.Node {
+	switch n := node.(type) {
+	case *ast.Paragraph:
+		n.Children = convertToCommonMarkNodes(n.Children)
+	case *ast.Heading:
+		n.Children = convertToCommonMarkNodes(n.Children)
+	case *ast.Blockquote:
+		n.Children = convertToCommonMarkNodes(n.Children)
+	case *ast.List:
+		n.Children = convertToCommonMarkNodes(n.Children)
+	case *ast.OrderedListItem:
+		n.Children = convertToCommonMarkNodes(n.Children)
+	case *ast.UnorderedListItem:
+		n.Children = convertToCommonMarkNodes(n.Children)
+	case *ast.Bold:
+		n.Children = convertToCommonMarkNodes(n.Children)
+	case *ast.Italic:
+		n.Children = convertToCommonMarkNodes(n.Children)
+	case *ast.Link:
+		n.Content = convertToCommonMarkNodes(n.Content)
+	case *ast.TaskListItem:
+		checkbox := `<input type="checkbox" disabled /> `
+		if n.Complete {
+			checkbox = `<input type="checkbox" checked disabled /> `
+		}
+		return &ast.UnorderedListItem{
+			Symbol:   n.Symbol,
+			Indent:   n.Indent,
+			Children: append([]ast.Node{&ast.Text{Content: checkbox}}, convertToCommonMarkNodes(n.Children)...),
+		}
+	case *ast.Table:
+		return &ast.Paragraph{
+			Children: []ast.Node{&ast.Text{Content: renderHTMLTable(n)}},
+		}
+	case *ast.Strikethrough:
+		return &ast.Text{Content: fmt.Sprintf("<del>%s</del>", n.Content)}
+	}
+	return node
+}
+
+func convertToCommonMarkNodes(nodes []ast.Node) []ast.Node {
+	result := make([]ast.Node, 0, len(nodes))
+	for _, node := range nodes {
+		result = append(result, convertToCommonMark(node))
+	}
+	return result
+}
+
+// renderHTMLTable renders the table as a HTML block. The cells hold their escaped markdown
+// as CommonMark does not render markdown inside HTML blocks.
+func renderHTMLTable(table *ast.Table) string {
+	var result strings.Builder
+	result.WriteString("<table>\n<thead>\n<tr>")
+	for _, cell := range table.Header {
+		result.WriteString("<th>" + html.EscapeString(cell.Restore()) + "</th>")
+	}
+	result.WriteString("</tr>\n</thead>\n<tbody>\n")
+	for _, row := range table.Rows {
+		result.WriteString("<tr>")
+		for _, cell := range row {
+			result.WriteString("<td>" + html.EscapeString(cell.Restore()) + "</td>")
+		}
+		result.WriteString("</tr>\n")
+	}
+	result.WriteString("</tbody>\n</table>")
+	return result.String()
+}
@@ -0,0 +1,168 @@
+package v1
+
+import (
+	v1pb "github.com/usememos/memos/proto/gen/api/v1"
+)
+
+// maxDiffMarkdownCells is the max size of the table of the longest common subsequence of the
+// blocks that differ, i.e. the product of their counts in the old and new version.
+const maxDiffMarkdownCells = 1 << 22
+
+// diffBlock is a block of a diffed node list with its index in the list and its markdown,
+// by which blocks are compared.
+type diffBlock struct {
+	node     *v1pb.Node
+	index    int32
+	markdown string
+}
+
+// newDiffBlocks returns the blocks of the given nodes, leaving out the line breaks between them.
+func newDiffBlocks(nodes []*v1pb.Node) []diffBlock {
+	blocks := make([]diffBlock, 0, len(nodes))
+	for i, node := range nodes {
+		if node.Type == v1pb.NodeType_LINE_BREAK {
+			continue
+		}
+		blocks = append(blocks, diffBlock{node: node, index: int32(i), markdown: restoreMarkdownNodes([]*v1pb.Node{node}, false)})
+	}
+	return blocks
+}
+
+// diffMarkdownNodes compares the old and new nodes block by block. The blocks are aligned by
+// the longest common subsequence of their markdown, and in each run of blocks between the
+// aligned ones, the removed blocks are paired in order with the added blocks of the same type
+// as changed. Changed lists and blockquotes are compared by their children. The bool reports
+// false when the nodes are too large to compare, see maxDiffMarkdownCells.
+func diffMarkdownNodes(oldNodes, newNodes []*v1pb.Node, oldPath, newPath []int32) ([]*v1pb.MarkdownNodeDiff, bool) {
+	oldBlocks, newBlocks := newDiffBlocks(oldNodes), newDiffBlocks(newNodes)
+	// The common prefix and suffix are trimmed first, as most edits touch a few blocks.
+	prefix := 0
+	for prefix < len(oldBlocks) && prefix < len(newBlocks) && oldBlocks[prefix].markdown == newBlocks[prefix].markdown {
+		prefix++
+	}
+	suffix := 0
+	for suffix < len(oldBlocks)-prefix && suffix < len(newBlocks)-prefix &&
+		oldBlocks[len(oldBlocks)-1-suffix].markdown == newBlocks[len(newBlocks)-1-suffix].markdown {
+		suffix++
+	}
+	oldBlocks = oldBlocks[prefix : len(oldBlocks)-suffix]
+	newBlocks = newBlocks[prefix : len(newBlocks)-suffix]
+	if (len(oldBlocks)+1)*(len(newBlocks)+1) > maxDiffMarkdownCells {
+		return nil, false
+	}
+
+	// lengths[i][j] is the length of the longest common subsequence of oldBlocks[i:] and newBlocks[j:].
+	lengths := make([][]int, len(oldBlocks)+1)
+	for i := range lengths {
+		lengths[i] = make([]int, len(newBlocks)+1)
+	}
+	for i := len(oldBlocks) - 1; i >= 0; i-- {
+		for j := len(newBlocks) - 1; j >= 0; j-- {
+			if oldBlocks[i].markdown == newBlocks[j].markdown {
+				lengths[i][j] = lengths[i+1][j+1] + 1
+			} else {
+				lengths[i][j] = max(lengths[i+1][j], lengths[i][j+1])
+			}
+		}
+	}
+
+	diffs := []*v1pb.MarkdownNodeDiff{}
+	removed, added := []diffBlock{}, []diffBlock{}
+	flush := func() bool {
+		gapDiffs, ok := diffMarkdownGap(removed, added, oldPath, newPath)
+		diffs = append(diffs, gapDiffs...)
+		removed, added = []diffBlock{}, []diffBlock{}
+		return ok
+	}
+	i, j := 0, 0
+	for i < len(oldBlocks) || j < len(newBlocks) {
+		switch {
+		case i < len(oldBlocks) && j < len(newBlocks) && oldBlocks[i].markdown == newBlocks[j].markdown:
+			if !flush() {
+				return nil, false
+			}
+			i++
+			j++
+		case j == len(newBlocks) || (i < len(oldBlocks) && lengths[i+1][j] >= lengths[i][j+1]):
+			removed = append(removed, oldBlocks[i])
+			i++
+		default:
+			added = append(added, newBlocks[j])
+			j++
+		}
+	}
+	if !flush() {
+		return nil, false
+	}
+	return diffs, true
+}
+
+// diffMarkdownGap returns the differences of a run of removed and added blocks between two
+// aligned blocks, pairing each removed block with the next added block of the same type.
+func diffMarkdownGap(removed, added []diffBlock, oldPath, newPath []int32) ([]*v1pb.MarkdownNodeDiff, bool) {
+	diffs := []*v1pb.MarkdownNodeDiff{}
+	next := 0
+	for _, oldBlock := range removed {
+		paired := false
+		for k := next; k < len(added); k++ {
+			if added[k].node.Type != oldBlock.node.Type {
+				continue
+			}
+			for _, newBlock := range added[next:k] {
+				diffs = append(diffs, newAddedNodeDiff(newBlock, newPath))
+			}
+			changedDiffs, ok := diffChangedMarkdownNode(oldBlock, added[k], oldPath, newPath)
+			if !ok {
+				return nil, false
+			}
+			diffs = append(diffs, changedDiffs...)
+			next, paired = k+1, true
+			break
+		}
+		if !paired {
+			diffs = append(diffs, &v1pb.MarkdownNodeDiff{
+				Type:    v1pb.MarkdownNodeDiff_REMOVED,
+				OldPath: appendDiffPath(oldPath, oldBlock.index),
+				OldNode: oldBlock.node,
+			})
+		}
+	}
+	for _, newBlock := range added[next:] {
+		diffs = append(diffs, newAddedNodeDiff(newBlock, newPath))
+	}
+	return diffs, true
+}
+
+// diffChangedMarkdownNode returns the differences of a block edited into another of the same
+// type. Lists and blockquotes are compared by their children, other blocks changed as a whole.
+func diffChangedMarkdownNode(oldBlock, newBlock diffBlock, oldPath, newPath []int32) ([]*v1pb.MarkdownNodeDiff, bool) {
+	oldPath, newPath = appendDiffPath(oldPath, oldBlock.index), appendDiffPath(newPath, newBlock.index)
+	switch oldBlock.node.Type {
+	case v1pb.NodeType_LIST, v1pb.NodeType_BLOCKQUOTE:
+		diffs, ok := diffMarkdownNodes(getNodeChildren(oldBlock.node), getNodeChildren(newBlock.node), oldPath, newPath)
+		if !ok || len(diffs) > 0 {
+			return diffs, ok
+		}
+	}
+	return []*v1pb.MarkdownNodeDiff{{
+		Type:    v1pb.MarkdownNodeDiff_CHANGED,
+		OldPath: oldPath,
+		NewPath: newPath,
+		OldNode: oldBlock.node,
+		NewNode: newBlock.node,
+	}}, true
+}
+
+func newAddedNodeDiff(block diffBlock, newPath []int32) *v1pb.MarkdownNodeDiff {
+	return &v1pb.MarkdownNodeDiff{
+		Type:    v1pb.MarkdownNodeDiff_ADDED,
+		NewPath: appendDiffPath(newPath, block.index),
+		NewNode: block.node,
+	}
+}
+
+// appendDiffPath returns a copy of the path with the index appended, so that sibling paths
+// never share their backing array.
+func appendDiffPath(path []int32, index int32) []int32 {
+	return append(append(make([]int32, 0, len(path)+1), path...), index)
+}
@@ -0,0 +1,128 @@
+package v1
+
+import (
+	"strings"
+
+	"github.com/usememos/gomark/ast"
+	"google.golang.org/protobuf/proto"
+
+	v1pb "github.com/usememos/memos/proto/gen/api/v1"
+)
+
+// emojiNodeType is the type of emoji nodes, which gomark does not know.
+const emojiNodeType ast.NodeType = "EMOJI"
+
+// emoji is an emoji written as its shortcode, e.g. ":smile:".
+type emoji struct {
+	// Shortcode is the name of the emoji without the colons.
+	Shortcode string
+	Unicode   string
+}
+
+func (*emoji) Type() ast.NodeType {
+	return emojiNodeType
+}
+
+func (n *emoji) Restore() string {
+	return ":" + n.Shortcode + ":"
+}
+
+// expandEmojiShortcodes turns the known emoji shortcodes in the text of the given nodes into
+// emoji nodes. Code spans and code blocks are not text nodes, so shortcodes in them are kept.
+func expandEmojiShortcodes(nodes []ast.Node) []ast.Node {
+	result := make([]ast.Node, 0, len(nodes))
+	for _, node := range nodes {
+		switch n := node.(type) {
+		case *ast.Paragraph:
+			n.Children = expandEmojiShortcodes(n.Children)
+		case *ast.Heading:
+			n.Children = expandEmojiShortcodes(n.Children)
+		case *ast.Blockquote:
+			n.Children = expandEmojiShortcodes(n.Children)
+		case *ast.List:
+			n.Children = expandEmojiShortcodes(n.Children)
+		case *ast.OrderedListItem:
+			n.Children = expandEmojiShortcodes(n.Children)
+		case *ast.UnorderedListItem:
+			n.Children = expandEmojiShortcodes(n.Children)
+		case *ast.TaskListItem:
+			n.Children = expandEmojiShortcodes(n.Children)
+		case *ast.Bold:
+			n.Children = expandEmojiShortcodes(n.Children)
+		case *ast.Italic:
+			n.Children = expandEmojiShortcodes(n.Children)
+		case *footnoteDef:
+			n.Children = expandEmojiShortcodes(n.Children)
+		case *ast.Text:
+			result = append(result, splitEmojiShortcodes(n.Content)...)
+			continue
+		}
+		result = append(result, node)
+	}
+	return result
+}
+
+// splitEmojiShortcodes splits the given text into text and emoji nodes. A shortcode must not
+// be next to a letter, digit or underscore, so that e.g. "10:30:00" and ":foo:bar:" stay text.
+func splitEmojiShortcodes(content string) []ast.Node {
+	nodes := []ast.Node{}
+	start := 0
+	for i := 0; i < len(content); i++ {
+		if content[i] != ':' || (i > 0 && isEmojiShortcodeBoundary(content[i-1])) {
+			continue
+		}
+		end := strings.IndexByte(content[i+1:], ':')
+		if end <= 0 {
+			continue
+		}
+		end += i + 1
+		shortcode := content[i+1 : end]
+		unicode, ok := emojiShortcodes[shortcode]
+		if !ok || (end+1 < len(content) && isEmojiShortcodeBoundary(content[end+1])) {
+			continue
+		}
+		if i > start {
+			nodes = append(nodes, &ast.Text{Content: content[start:i]})
+		}
+		nodes = append(nodes, &emoji{Shortcode: shortcode, Unicode: unicode})
+		start = end + 1
+		i = end
+	}
+	if start < len(content) {
+		nodes = append(nodes, &ast.Text{Content: content[start:]})
+	}
+	return nodes
+}
+
+// isEmojiShortcodeBoundary reports whether the byte may not be next to a shortcode.
+func isEmojiShortcodeBoundary(c byte) bool {
+	return c == '_' || ('0' <= c && c <= '9') || ('a' <= c && c <= 'z') || ('A' <= c && c <= 'Z')
+}
+
+// replaceEmojiNodes replaces the emoji nodes in the given nodes with text nodes of their
+// unicode, or of their shortcode when unicode is false. The given nodes are not modified,
+// the result is a copy.
+func replaceEmojiNodes(nodes []*v1pb.Node, unicode bool) []*v1pb.Node {
+	result := make([]*v1pb.Node, 0, len(nodes))
+	for _, node := range nodes {
+		node = proto.Clone(node).(*v1pb.Node)
+		replaceEmojiNode(node, unicode)
+		result = append(result, node)
+	}
+	return result
+}
+
+func replaceEmojiNode(node *v1pb.Node, unicode bool) {
+	if n, ok := node.Node.(*v1pb.Node_EmojiNode); ok {
+		content := ":" + n.EmojiNode.Shortcode + ":"
+		if unicode && n.EmojiNode.Unicode != "" {
+			content = n.EmojiNode.Unicode
+		}
+		node.Type = v1pb.NodeType_TEXT
+		node.Node = &v1pb.Node_TextNode{TextNode: &v1pb.TextNode{Content: content}}
+		return
+	}
+	for _, child := range getNodeChildren(node) {
+		replaceEmojiNode(child, unicode)
+	}
+}
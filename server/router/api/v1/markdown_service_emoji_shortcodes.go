@@ -0,0 +1,280 @@
+package v1
+
+// emojiShortcodes maps the bundled emoji shortcodes, as used by GitHub and Slack, to their
+// unicode. It holds the commonly used emojis rather than the whole emoji set.
+var emojiShortcodes = map[string]string{
+	"smile":                        "\U0001F604",       // 😄
+	"smiley":                       "\U0001F603",       // 😃
+	"grinning":                     "\U0001F600",       // 😀
+	"grin":                         "\U0001F601",       // 😁
+	"laughing":                     "\U0001F606",       // 😆
+	"satisfied":                    "\U0001F606",       // 😆
+	"sweat_smile":                  "\U0001F605",       // 😅
+	"joy":                          "\U0001F602",       // 😂
+	"rofl":                         "\U0001F923",       // 🤣
+	"slightly_smiling_face":        "\U0001F642",       // 🙂
+	"upside_down_face":             "\U0001F643",       // 🙃
+	"wink":                         "\U0001F609",       // 😉
+	"blush":                        "\U0001F60A",       // 😊
+	"innocent":                     "\U0001F607",       // 😇
+	"heart_eyes":                   "\U0001F60D",       // 😍
+	"star_struck":                  "\U0001F929",       // 🤩
+	"kissing_heart":                "\U0001F618",       // 😘
+	"yum":                          "\U0001F60B",       // 😋
+	"stuck_out_tongue":             "\U0001F61B",       // 😛
+	"stuck_out_tongue_winking_eye": "\U0001F61C",       // 😜
+	"hugs":                         "\U0001F917",       // 🤗
+	"thinking":                     "\U0001F914",       // 🤔
+	"neutral_face":                 "\U0001F610",       // 😐
+	"expressionless":               "\U0001F611",       // 😑
+	"no_mouth":                     "\U0001F636",       // 😶
+	"smirk":                        "\U0001F60F",       // 😏
+	"unamused":                     "\U0001F612",       // 😒
+	"roll_eyes":                    "\U0001F644",       // 🙄
+	"grimacing":                    "\U0001F62C",       // 😬
+	"relieved":                     "\U0001F60C",       // 😌
+	"pensive":                      "\U0001F614",       // 😔
+	"sleepy":                       "\U0001F62A",       // 😪
+	"sleeping":                     "\U0001F634",       // 😴
+	"mask":                         "\U0001F637",       // 😷
+	"nerd_face":                    "\U0001F913",       // 🤓
+	"sunglasses":                   "\U0001F60E",       // 😎
+	"confused":                     "\U0001F615",       // 😕
+	"worried":                      "\U0001F61F",       // 😟
+	"frowning_face":                "\u2639\uFE0F",     // ☹️
+	"open_mouth":                   "\U0001F62E",       // 😮
+	"astonished":                   "\U0001F632",       // 😲
+	"flushed":                      "\U0001F633",       // 😳
+	"pleading_face":                "\U0001F97A",       // 🥺
+	"fearful":                      "\U0001F628",       // 😨
+	"cold_sweat":                   "\U0001F630",       // 😰
+	"cry":                          "\U0001F622",       // 😢
+	"sob":                          "\U0001F62D",       // 😭
+	"scream":                       "\U0001F631",       // 😱
+	"tired_face":                   "\U0001F62B",       // 😫
+	"yawning_face":                 "\U0001F971",       // 🥱
+	"triumph":                      "\U0001F624",       // 😤
+	"rage":                         "\U0001F621",       // 😡
+	"angry":                        "\U0001F620",       // 😠
+	"skull":                        "\U0001F480",       // 💀
+	"poop":                         "\U0001F4A9",       // 💩
+	"clown_face":                   "\U0001F921",       // 🤡
+	"ghost":                        "\U0001F47B",       // 👻
+	"alien":                        "\U0001F47D",       // 👽
+	"robot":                        "\U0001F916",       // 🤖
+	"see_no_evil":                  "\U0001F648",       // 🙈
+	"hear_no_evil":                 "\U0001F649",       // 🙉
+	"speak_no_evil":                "\U0001F64A",       // 🙊
+	"heart":                        "\u2764\uFE0F",     // ❤️
+	"orange_heart":                 "\U0001F9E1",       // 🧡
+	"yellow_heart":                 "\U0001F49B",       // 💛
+	"green_heart":                  "\U0001F49A",       // 💚
+	"blue_heart":                   "\U0001F499",       // 💙
+	"purple_heart":                 "\U0001F49C",       // 💜
+	"black_heart":                  "\U0001F5A4",       // 🖤
+	"broken_heart":                 "\U0001F494",       // 💔
+	"sparkling_heart":              "\U0001F496",       // 💖
+	"100":                          "\U0001F4AF",       // 💯
+	"boom":                         "\U0001F4A5",       // 💥
+	"dizzy":                        "\U0001F4AB",       // 💫
+	"zzz":                          "\U0001F4A4",       // 💤
+	"wave":                         "\U0001F44B",       // 👋
+	"ok_hand":                      "\U0001F44C",       // 👌
+	"v":                            "\u270C\uFE0F",     // ✌️
+	"crossed_fingers":              "\U0001F91E",       // 🤞
+	"point_up":                     "\u261D\uFE0F",     // ☝️
+	"point_down":                   "\U0001F447",       // 👇
+	"point_left":                   "\U0001F448",       // 👈
+	"point_right":                  "\U0001F449",       // 👉
+	"+1":                           "\U0001F44D",       // 👍
+	"thumbsup":                     "\U0001F44D",       // 👍
+	"-1":                           "\U0001F44E",       // 👎
+	"thumbsdown":                   "\U0001F44E",       // 👎
+	"fist":                         "\u270A",           // ✊
+	"clap":                         "\U0001F44F",       // 👏
+	"raised_hands":                 "\U0001F64C",       // 🙌
+	"open_hands":                   "\U0001F450",       // 👐
+	"handshake":                    "\U0001F91D",       // 🤝
+	"pray":                         "\U0001F64F",       // 🙏
+	"muscle":                       "\U0001F4AA",       // 💪
+	"eyes":                         "\U0001F440",       // 👀
+	"brain":                        "\U0001F9E0",       // 🧠
+	"dog":                          "\U0001F436",       // 🐶
+	"cat":                          "\U0001F431",       // 🐱
+	"mouse":                        "\U0001F42D",       // 🐭
+	"rabbit":                       "\U0001F430",       // 🐰
+	"fox_face":                     "\U0001F98A",       // 🦊
+	"bear":                         "\U0001F43B",       // 🐻
+	"panda_face":                   "\U0001F43C",       // 🐼
+	"tiger":                        "\U0001F42F",       // 🐯
+	"cow":                          "\U0001F42E",       // 🐮
+	"pig":                          "\U0001F437",       // 🐷
+	"frog":                         "\U0001F438",       // 🐸
+	"monkey":                       "\U0001F412",       // 🐒
+	"chicken":                      "\U0001F414",       // 🐔
+	"penguin":                      "\U0001F427",       // 🐧
+	"bird":                         "\U0001F426",       // 🐦
+	"unicorn":                      "\U0001F984",       // 🦄
+	"bee":                          "\U0001F41D",       // 🐝
+	"bug":                          "\U0001F41B",       // 🐛
+	"butterfly":                    "\U0001F98B",       // 🦋
+	"snail":                        "\U0001F40C",       // 🐌
+	"turtle":                       "\U0001F422",       // 🐢
+	"snake":                        "\U0001F40D",       // 🐍
+	"octopus":                      "\U0001F419",       // 🐙
+	"whale":                        "\U0001F433",       // 🐳
+	"fish":                         "\U0001F41F",       // 🐟
+	"cactus":                       "\U0001F335",       // 🌵
+	"evergreen_tree":               "\U0001F332",       // 🌲
+	"deciduous_tree":               "\U0001F333",       // 🌳
+	"seedling":                     "\U0001F331",       // 🌱
+	"herb":                         "\U0001F33F",       // 🌿
+	"four_leaf_clover":             "\U0001F340",       // 🍀
+	"fallen_leaf":                  "\U0001F342",       // 🍂
+	"mushroom":                     "\U0001F344",       // 🍄
+	"rose":                         "\U0001F339",       // 🌹
+	"tulip":                        "\U0001F337",       // 🌷
+	"sunflower":                    "\U0001F33B",       // 🌻
+	"cherry_blossom":               "\U0001F338",       // 🌸
+	"sunny":                        "\u2600\uFE0F",     // ☀️
+	"cloud":                        "\u2601\uFE0F",     // ☁️
+	"umbrella":                     "\u2614",           // ☔
+	"snowflake":                    "\u2744\uFE0F",     // ❄️
+	"zap":                          "\u26A1",           // ⚡
+	"fire":                         "\U0001F525",       // 🔥
+	"droplet":                      "\U0001F4A7",       // 💧
+	"ocean":                        "\U0001F30A",       // 🌊
+	"rainbow":                      "\U0001F308",       // 🌈
+	"star":                         "\u2B50",           // ⭐
+	"star2":                        "\U0001F31F",       // 🌟
+	"sparkles":                     "\u2728",           // ✨
+	"crescent_moon":                "\U0001F319",       // 🌙
+	"earth_asia":                   "\U0001F30F",       // 🌏
+	"apple":                        "\U0001F34E",       // 🍎
+	"banana":                       "\U0001F34C",       // 🍌
+	"grapes":                       "\U0001F347",       // 🍇
+	"strawberry":                   "\U0001F353",       // 🍓
+	"watermelon":                   "\U0001F349",       // 🍉
+	"peach":                        "\U0001F351",       // 🍑
+	"lemon":                        "\U0001F34B",       // 🍋
+	"avocado":                      "\U0001F951",       // 🥑
+	"bread":                        "\U0001F35E",       // 🍞
+	"pizza":                        "\U0001F355",       // 🍕
+	"hamburger":                    "\U0001F354",       // 🍔
+	"fries":                        "\U0001F35F",       // 🍟
+	"ramen":                        "\U0001F35C",       // 🍜
+	"sushi":                        "\U0001F363",       // 🍣
+	"rice":                         "\U0001F35A",       // 🍚
+	"cake":                         "\U0001F370",       // 🍰
+	"birthday":                     "\U0001F382",       // 🎂
+	"cookie":                       "\U0001F36A",       // 🍪
+	"coffee":                       "\u2615",           // ☕
+	"tea":                          "\U0001F375",       // 🍵
+	"beer":                         "\U0001F37A",       // 🍺
+	"wine_glass":                   "\U0001F377",       // 🍷
+	"soccer":                       "\u26BD",           // ⚽
+	"basketball":                   "\U0001F3C0",       // 🏀
+	"trophy":                       "\U0001F3C6",       // 🏆
+	"medal_sports":                 "\U0001F3C5",       // 🏅
+	"dart":                         "\U0001F3AF",       // 🎯
+	"video_game":                   "\U0001F3AE",       // 🎮
+	"musical_note":                 "\U0001F3B5",       // 🎵
+	"headphones":                   "\U0001F3A7",       // 🎧
+	"art":                          "\U0001F3A8",       // 🎨
+	"tada":                         "\U0001F389",       // 🎉
+	"confetti_ball":                "\U0001F38A",       // 🎊
+	"gift":                         "\U0001F381",       // 🎁
+	"balloon":                      "\U0001F388",       // 🎈
+	"christmas_tree":               "\U0001F384",       // 🎄
+	"car":                          "\U0001F697",       // 🚗
+	"bus":                          "\U0001F68C",       // 🚌
+	"train":                        "\U0001F68B",       // 🚋
+	"airplane":                     "\u2708\uFE0F",     // ✈️
+	"rocket":                       "\U0001F680",       // 🚀
+	"bike":                         "\U0001F6B2",       // 🚲
+	"ship":                         "\U0001F6A2",       // 🚢
+	"house":                        "\U0001F3E0",       // 🏠
+	"office":                       "\U0001F3E2",       // 🏢
+	"hospital":                     "\U0001F3E5",       // 🏥
+	"school":                       "\U0001F3EB",       // 🏫
+	"watch":                        "\u231A",           // ⌚
+	"iphone":                       "\U0001F4F1",       // 📱
+	"computer":                     "\U0001F4BB",       // 💻
+	"keyboard":                     "\u2328\uFE0F",     // ⌨️
+	"camera":                       "\U0001F4F7",       // 📷
+	"tv":                           "\U0001F4FA",       // 📺
+	"bulb":                         "\U0001F4A1",       // 💡
+	"battery":                      "\U0001F50B",       // 🔋
+	"electric_plug":                "\U0001F50C",       // 🔌
+	"moneybag":                     "\U0001F4B0",       // 💰
+	"credit_card":                  "\U0001F4B3",       // 💳
+	"email":                        "\U0001F4E7",       // 📧
+	"envelope":                     "\u2709\uFE0F",     // ✉️
+	"package":                      "\U0001F4E6",       // 📦
+	"memo":                         "\U0001F4DD",       // 📝
+	"pencil2":                      "\u270F\uFE0F",     // ✏️
+	"pen":                          "\U0001F58A\uFE0F", // 🖊️
+	"book":                         "\U0001F4D6",       // 📖
+	"books":                        "\U0001F4DA",       // 📚
+	"bookmark":                     "\U0001F516",       // 🔖
+	"label":                        "\U0001F3F7\uFE0F", // 🏷️
+	"calendar":                     "\U0001F4C6",       // 📆
+	"date":                         "\U0001F4C5",       // 📅
+	"clipboard":                    "\U0001F4CB",       // 📋
+	"pushpin":                      "\U0001F4CC",       // 📌
+	"paperclip":                    "\U0001F4CE",       // 📎
+	"scissors":                     "\u2702\uFE0F",     // ✂️
+	"file_folder":                  "\U0001F4C1",       // 📁
+	"chart_with_upwards_trend":     "\U0001F4C8",       // 📈
+	"chart_with_downwards_trend":   "\U0001F4C9",       // 📉
+	"bar_chart":                    "\U0001F4CA",       // 📊
+	"lock":                         "\U0001F512",       // 🔒
+	"unlock":                       "\U0001F513",       // 🔓
+	"key":                          "\U0001F511",       // 🔑
+	"hammer":                       "\U0001F528",       // 🔨
+	"wrench":                       "\U0001F527",       // 🔧
+	"gear":                         "\u2699\uFE0F",     // ⚙️
+	"link":                         "\U0001F517",       // 🔗
+	"mag":                          "\U0001F50D",       // 🔍
+	"bell":                         "\U0001F514",       // 🔔
+	"hourglass":                    "\u231B",           // ⌛
+	"alarm_clock":                  "\u23F0",           // ⏰
+	"stopwatch":                    "\u23F1\uFE0F",     // ⏱️
+	"pill":                         "\U0001F48A",       // 💊
+	"white_check_mark":             "\u2705",           // ✅
+	"heavy_check_mark":             "\u2714\uFE0F",     // ✔️
+	"ballot_box_with_check":        "\u2611\uFE0F",     // ☑️
+	"x":                            "\u274C",           // ❌
+	"negative_squared_cross_mark":  "\u274E",           // ❎
+	"heavy_plus_sign":              "\u2795",           // ➕
+	"heavy_minus_sign":             "\u2796",           // ➖
+	"question":                     "\u2753",           // ❓
+	"grey_question":                "\u2754",           // ❔
+	"exclamation":                  "\u2757",           // ❗
+	"bangbang":                     "\u203C\uFE0F",     // ‼️
+	"warning":                      "\u26A0\uFE0F",     // ⚠️
+	"no_entry":                     "\u26D4",           // ⛔
+	"no_entry_sign":                "\U0001F6AB",       // 🚫
+	"construction":                 "\U0001F6A7",       // 🚧
+	"recycle":                      "\u267B\uFE0F",     // ♻️
+	"white_flag":                   "\U0001F3F3\uFE0F", // 🏳️
+	"checkered_flag":               "\U0001F3C1",       // 🏁
+	"triangular_flag_on_post":      "\U0001F6A9",       // 🚩
+	"red_circle":                   "\U0001F534",       // 🔴
+	"large_blue_circle":            "\U0001F535",       // 🔵
+	"green_circle":                 "\U0001F7E2",       // 🟢
+	"yellow_circle":                "\U0001F7E1",       // 🟡
+	"arrow_up":                     "\u2B06\uFE0F",     // ⬆️
+	"arrow_down":                   "\u2B07\uFE0F",     // ⬇️
+	"arrow_left":                   "\u2B05\uFE0F",     // ⬅️
+	"arrow_right":                  "\u27A1\uFE0F",     // ➡️
+	"arrows_counterclockwise":      "\U0001F504",       // 🔄
+	"new":                          "\U0001F195",       // 🆕
+	"free":                         "\U0001F193",       // 🆓
+	"sos":                          "\U0001F198",       // 🆘
+	"ok":                           "\U0001F197",       // 🆗
+	"cool":                         "\U0001F192",       // 🆒
+	"copyright":                    "\u00A9\uFE0F",     // ©️
+	"registered":                   "\u00AE\uFE0F",     // ®️
+	"tm":                           "\u2122\uFE0F",     // ™️
+}
@@ -0,0 +1,233 @@
+package v1
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/usememos/gomark/ast"
+	"google.golang.org/protobuf/proto"
+
+	v1pb "github.com/usememos/memos/proto/gen/api/v1"
+)
+
+// customNodeType is the type of the nodes of markdown extensions, which gomark does not know.
+const customNodeType ast.NodeType = "CUSTOM"
+
+// MarkdownExtension parses a custom inline syntax of markdown into custom nodes, e.g.
+// "#ticket-123" into nodes linking to an issue tracker.
+type MarkdownExtension struct {
+	// Name identifies the extension in the nodes it parses. It must be unique.
+	Name string
+	// Pattern matches the syntax. Empty matches are ignored.
+	Pattern *regexp.Regexp
+	// NewNode returns the attributes of the node of a match from its submatches, of which the
+	// first is the whole match, or false to leave the match as it is.
+	NewNode func(submatches []string) (map[string]string, bool)
+}
+
+// RegisterMarkdownExtension registers an extension of the markdown that ParseMarkdown and
+// BatchParseMarkdown parse. It must be called at startup, before the service serves requests.
+//
+// Extensions parse after the built-in syntax, within the runs of text and tags of paragraphs,
+// headings, list items and emphasis. So they take precedence over tags, e.g. for "#ticket-123",
+// but not over code, links or the other built-in syntax, and a match may not cut a tag in two.
+// When matches overlap, the leftmost wins, and of matches at the same position the one of the
+// extension registered first.
+func (s *APIV1Service) RegisterMarkdownExtension(extension *MarkdownExtension) error {
+	if extension.Name == "" {
+		return errors.New("extension name is required")
+	}
+	if extension.Pattern == nil || extension.NewNode == nil {
+		return errors.Errorf("extension %s has no pattern or node factory", extension.Name)
+	}
+	for _, registered := range s.markdownExtensions {
+		if registered.Name == extension.Name {
+			return errors.Errorf("extension %s is already registered", extension.Name)
+		}
+	}
+	s.markdownExtensions = append(s.markdownExtensions, extension)
+	return nil
+}
+
+// customNode is a node parsed by a markdown extension.
+type customNode struct {
+	Extension  string
+	Raw        string
+	Attributes map[string]string
+}
+
+func (*customNode) Type() ast.NodeType {
+	return customNodeType
+}
+
+func (n *customNode) Restore() string {
+	return n.Raw
+}
+
+// applyMarkdownExtensions parses the syntax of the extensions in the given nodes into custom
+// nodes, see RegisterMarkdownExtension.
+func applyMarkdownExtensions(nodes []ast.Node, extensions []*MarkdownExtension) []ast.Node {
+	result := make([]ast.Node, 0, len(nodes))
+	run := []ast.Node{}
+	for _, node := range nodes {
+		switch n := node.(type) {
+		case *ast.Text, *ast.Tag:
+			run = append(run, node)
+			continue
+		case *ast.Paragraph:
+			n.Children = applyMarkdownExtensions(n.Children, extensions)
+		case *ast.Heading:
+			n.Children = applyMarkdownExtensions(n.Children, extensions)
+		case *ast.Blockquote:
+			n.Children = applyMarkdownExtensions(n.Children, extensions)
+		case *ast.List:
+			n.Children = applyMarkdownExtensions(n.Children, extensions)
+		case *ast.OrderedListItem:
+			n.Children = applyMarkdownExtensions(n.Children, extensions)
+		case *ast.UnorderedListItem:
+			n.Children = applyMarkdownExtensions(n.Children, extensions)
+		case *ast.TaskListItem:
+			n.Children = applyMarkdownExtensions(n.Children, extensions)
+		case *ast.Bold:
+			n.Children = applyMarkdownExtensions(n.Children, extensions)
+		case *ast.Italic:
+			n.Children = applyMarkdownExtensions(n.Children, extensions)
+		case *footnoteDef:
+			n.Children = applyMarkdownExtensions(n.Children, extensions)
+		}
+		result = append(result, parseExtensionRun(run, extensions)...)
+		run = []ast.Node{}
+		result = append(result, node)
+	}
+	return append(result, parseExtensionRun(run, extensions)...)
+}
+
+// extensionSegment is the range of a node of a run in the markdown of the run.
+type extensionSegment struct {
+	node       ast.Node
+	start, end int
+}
+
+// extensionMatch is a match of an extension in the markdown of a run.
+type extensionMatch struct {
+	start, end int
+	node       *customNode
+}
+
+// parseExtensionRun parses the syntax of the extensions in the markdown of a run of text and
+// tag nodes. The parts of the run outside of the matches are kept as they are.
+func parseExtensionRun(run []ast.Node, extensions []*MarkdownExtension) []ast.Node {
+	if len(run) == 0 || len(extensions) == 0 {
+		return run
+	}
+	var markdown strings.Builder
+	segments := make([]extensionSegment, 0, len(run))
+	for _, node := range run {
+		start := markdown.Len()
+		markdown.WriteString(node.Restore())
+		segments = append(segments, extensionSegment{node: node, start: start, end: markdown.Len()})
+	}
+	content := markdown.String()
+
+	matches := []extensionMatch{}
+	for _, extension := range extensions {
+		for _, loc := range extension.Pattern.FindAllStringSubmatchIndex(content, -1) {
+			if loc[0] == loc[1] || cutsTagSegment(segments, loc[0], loc[1]) {
+				continue
+			}
+			submatches := make([]string, 0, len(loc)/2)
+			for i := 0; i < len(loc); i += 2 {
+				if loc[i] < 0 {
+					submatches = append(submatches, "")
+				} else {
+					submatches = append(submatches, content[loc[i]:loc[i+1]])
+				}
+			}
+			attributes, ok := extension.NewNode(submatches)
+			if !ok {
+				continue
+			}
+			matches = append(matches, extensionMatch{
+				start: loc[0],
+				end:   loc[1],
+				node:  &customNode{Extension: extension.Name, Raw: submatches[0], Attributes: attributes},
+			})
+		}
+	}
+	if len(matches) == 0 {
+		return run
+	}
+	// The matches of each extension are in the order of registration, which the stable sort keeps for ties.
+	sort.SliceStable(matches, func(i, j int) bool {
+		return matches[i].start < matches[j].start
+	})
+
+	result := []ast.Node{}
+	cursor := 0
+	for _, match := range matches {
+		if match.start < cursor {
+			continue
+		}
+		result = append(result, sliceExtensionSegments(segments, content, cursor, match.start)...)
+		result = append(result, match.node)
+		cursor = match.end
+	}
+	return append(result, sliceExtensionSegments(segments, content, cursor, len(content))...)
+}
+
+// cutsTagSegment reports whether the range cuts a tag of the segments in two.
+func cutsTagSegment(segments []extensionSegment, start, end int) bool {
+	for _, segment := range segments {
+		if _, ok := segment.node.(*ast.Tag); !ok {
+			continue
+		}
+		overlaps := start < segment.end && segment.start < end
+		covers := start <= segment.start && segment.end <= end
+		if overlaps && !covers {
+			return true
+		}
+	}
+	return false
+}
+
+// sliceExtensionSegments returns the nodes of the segments in the range, cutting the text
+// nodes at its bounds. Tags are never cut, see cutsTagSegment.
+func sliceExtensionSegments(segments []extensionSegment, content string, start, end int) []ast.Node {
+	nodes := []ast.Node{}
+	for _, segment := range segments {
+		if segment.end <= start || end <= segment.start {
+			continue
+		}
+		if _, ok := segment.node.(*ast.Text); !ok {
+			nodes = append(nodes, segment.node)
+			continue
+		}
+		nodes = append(nodes, &ast.Text{Content: content[max(start, segment.start):min(end, segment.end)]})
+	}
+	return nodes
+}
+
+// replaceCustomNodes replaces the custom nodes in the given nodes with text nodes of their raw
+// markdown. The given nodes are not modified, the result is a copy.
+func replaceCustomNodes(nodes []*v1pb.Node) []*v1pb.Node {
+	result := make([]*v1pb.Node, 0, len(nodes))
+	for _, node := range nodes {
+		node = proto.Clone(node).(*v1pb.Node)
+		replaceCustomNode(node)
+		result = append(result, node)
+	}
+	return result
+}
+
+func replaceCustomNode(node *v1pb.Node) {
+	if n, ok := node.Node.(*v1pb.Node_CustomNode); ok {
+		node.Type = v1pb.NodeType_TEXT
+		node.Node = &v1pb.Node_TextNode{TextNode: &v1pb.TextNode{Content: n.CustomNode.Raw}}
+		return
+	}
+	for _, child := range getNodeChildren(node) {
+		replaceCustomNode(child)
+	}
+}
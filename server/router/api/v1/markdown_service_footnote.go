@@ -0,0 +1,250 @@
+package v1
+
+import (
+	"strings"
+
+	"github.com/usememos/gomark/ast"
+	"github.com/usememos/gomark/parser"
+	"github.com/usememos/gomark/parser/tokenizer"
+	"google.golang.org/protobuf/proto"
+
+	v1pb "github.com/usememos/memos/proto/gen/api/v1"
+)
+
+const (
+	// footnoteDefNodeType and footnoteRefNodeType are the types of footnote nodes, which gomark does not know.
+	footnoteDefNodeType ast.NodeType = "FOOTNOTE_DEF"
+	footnoteRefNodeType ast.NodeType = "FOOTNOTE_REF"
+)
+
+// footnoteDef is the definition of a footnote on a line of its own, e.g. "[^1]: A note.".
+type footnoteDef struct {
+	Label    string
+	Children []ast.Node
+	// Number is the number of the footnote in order of first reference, 0 if it is never
+	// referenced or is a duplicate.
+	Number int
+	// Duplicate is whether an earlier definition has the same label.
+	Duplicate bool
+}
+
+func (*footnoteDef) Type() ast.NodeType {
+	return footnoteDefNodeType
+}
+
+func (n *footnoteDef) Restore() string {
+	var result strings.Builder
+	result.WriteString("[^" + n.Label + "]:")
+	if len(n.Children) > 0 {
+		result.WriteString(" ")
+	}
+	for _, child := range n.Children {
+		result.WriteString(child.Restore())
+	}
+	return result.String()
+}
+
+// footnoteRef is a reference to a defined footnote, e.g. "[^1]".
+type footnoteRef struct {
+	Label string
+	// Number is the number of the referenced footnote, see footnoteDef.
+	Number int
+}
+
+func (*footnoteRef) Type() ast.NodeType {
+	return footnoteRefNodeType
+}
+
+func (n *footnoteRef) Restore() string {
+	return "[^" + n.Label + "]"
+}
+
+// footnoteDefParser matches a footnote definition. The spaces between the colon and the
+// text of the footnote are dropped.
+type footnoteDefParser struct{}
+
+var _ parser.BlockParser = (*footnoteDefParser)(nil)
+
+func (*footnoteDefParser) Match(tokens []*tokenizer.Token) (ast.Node, int) {
+	line := tokenizer.GetFirstLine(tokens)
+	if len(line) < 5 || line[0].Type != tokenizer.LeftSquareBracket || line[1].Type != tokenizer.Caret {
+		return nil, 0
+	}
+	end := 2
+	for end < len(line) && line[end].Type != tokenizer.RightSquareBracket {
+		end++
+	}
+	if end+1 >= len(line) || line[end+1].Type != tokenizer.Colon {
+		return nil, 0
+	}
+	label := tokenizer.Stringify(line[2:end])
+	if !isFootnoteLabel(label) {
+		return nil, 0
+	}
+	start := end + 2
+	for start < len(line) && line[start].Type == tokenizer.Space {
+		start++
+	}
+	children, err := parser.ParseInline(line[start:])
+	if err != nil {
+		return nil, 0
+	}
+	return &footnoteDef{Label: label, Children: children}, len(line)
+}
+
+// isFootnoteLabel reports whether the label is valid, i.e. not empty and without whitespace or brackets.
+func isFootnoteLabel(label string) bool {
+	return label != "" && !strings.ContainsAny(label, " \t\n[]^")
+}
+
+// parseFootnotes turns the references to the defined footnotes in the text of the given nodes
+// into footnote reference nodes, and numbers the footnotes in order of their first reference.
+// References link to the first definition of a label, later ones are marked as duplicates.
+// The nodes are modified in place.
+func parseFootnotes(nodes []ast.Node) []ast.Node {
+	defs := map[string]*footnoteDef{}
+	for _, node := range nodes {
+		def, ok := node.(*footnoteDef)
+		if !ok {
+			continue
+		}
+		if _, ok := defs[def.Label]; ok {
+			def.Duplicate = true
+			continue
+		}
+		defs[def.Label] = def
+	}
+	if len(defs) == 0 {
+		return nodes
+	}
+	count := 0
+	return expandFootnoteRefs(nodes, defs, &count)
+}
+
+// expandFootnoteRefs splits the references to the given definitions out of the text of the nodes.
+// gomark takes the text between two carets for superscript, e.g. "^1] and [^" in "a[^1] and [^2]",
+// so superscript nodes are turned back into text first and parsed again once the references are
+// split out. count is the number of footnotes referenced so far.
+func expandFootnoteRefs(nodes []ast.Node, defs map[string]*footnoteDef, count *int) []ast.Node {
+	merged := make([]ast.Node, 0, len(nodes))
+	for _, node := range nodes {
+		switch n := node.(type) {
+		case *ast.Paragraph:
+			n.Children = expandFootnoteRefs(n.Children, defs, count)
+		case *ast.Heading:
+			n.Children = expandFootnoteRefs(n.Children, defs, count)
+		case *ast.Blockquote:
+			n.Children = expandFootnoteRefs(n.Children, defs, count)
+		case *ast.List:
+			n.Children = expandFootnoteRefs(n.Children, defs, count)
+		case *ast.OrderedListItem:
+			n.Children = expandFootnoteRefs(n.Children, defs, count)
+		case *ast.UnorderedListItem:
+			n.Children = expandFootnoteRefs(n.Children, defs, count)
+		case *ast.TaskListItem:
+			n.Children = expandFootnoteRefs(n.Children, defs, count)
+		case *ast.Bold:
+			n.Children = expandFootnoteRefs(n.Children, defs, count)
+		case *ast.Italic:
+			n.Children = expandFootnoteRefs(n.Children, defs, count)
+		case *footnoteDef:
+			n.Children = expandFootnoteRefs(n.Children, defs, count)
+		case *ast.Superscript:
+			node = &ast.Text{Content: n.Restore()}
+		}
+		if text, ok := node.(*ast.Text); ok && len(merged) > 0 {
+			if prevText, ok := merged[len(merged)-1].(*ast.Text); ok {
+				merged[len(merged)-1] = &ast.Text{Content: prevText.Content + text.Content}
+				continue
+			}
+		}
+		merged = append(merged, node)
+	}
+
+	result := make([]ast.Node, 0, len(merged))
+	for _, node := range merged {
+		if text, ok := node.(*ast.Text); ok {
+			result = append(result, splitFootnoteRefs(text.Content, defs, count)...)
+			continue
+		}
+		result = append(result, node)
+	}
+	return result
+}
+
+// splitFootnoteRefs splits the given text into footnote references to the given definitions and
+// the text around them, in which superscript is parsed as gomark does.
+func splitFootnoteRefs(content string, defs map[string]*footnoteDef, count *int) []ast.Node {
+	nodes := []ast.Node{}
+	appendText := func(text string) {
+		if text == "" {
+			return
+		}
+		if !strings.Contains(text, "^") {
+			nodes = append(nodes, &ast.Text{Content: text})
+			return
+		}
+		superscriptNodes, _ := parser.ParseInlineWithParsers(tokenizer.Tokenize(text), []parser.InlineParser{parser.NewSuperscriptParser(), parser.NewTextParser()})
+		nodes = append(nodes, superscriptNodes...)
+	}
+	start := 0
+	for i := 0; i+1 < len(content); i++ {
+		if content[i] != '[' || content[i+1] != '^' {
+			continue
+		}
+		end := strings.IndexByte(content[i+2:], ']')
+		if end < 0 {
+			break
+		}
+		end += i + 2
+		label := content[i+2 : end]
+		def, ok := defs[label]
+		if !ok || !isFootnoteLabel(label) {
+			continue
+		}
+		if def.Number == 0 {
+			*count++
+			def.Number = *count
+		}
+		appendText(content[start:i])
+		nodes = append(nodes, &footnoteRef{Label: label, Number: def.Number})
+		start = end + 1
+		i = end
+	}
+	appendText(content[start:])
+	return nodes
+}
+
+// replaceFootnoteNodes replaces the footnote nodes in the given nodes with their markdown, i.e.
+// references with text nodes and definitions with paragraphs starting with their label, so that
+// they are kept in place by renderers that do not know footnotes. The given nodes are not
+// modified, the result is a copy.
+func replaceFootnoteNodes(nodes []*v1pb.Node) []*v1pb.Node {
+	result := make([]*v1pb.Node, 0, len(nodes))
+	for _, node := range nodes {
+		node = proto.Clone(node).(*v1pb.Node)
+		replaceFootnoteNode(node)
+		result = append(result, node)
+	}
+	return result
+}
+
+func replaceFootnoteNode(node *v1pb.Node) {
+	switch n := node.Node.(type) {
+	case *v1pb.Node_FootnoteRefNode:
+		node.Type = v1pb.NodeType_TEXT
+		node.Node = &v1pb.Node_TextNode{TextNode: &v1pb.TextNode{Content: "[^" + n.FootnoteRefNode.Label + "]"}}
+		return
+	case *v1pb.Node_FootnoteDefNode:
+		prefix := "[^" + n.FootnoteDefNode.Label + "]:"
+		if len(n.FootnoteDefNode.Children) > 0 {
+			prefix += " "
+		}
+		children := append([]*v1pb.Node{{Type: v1pb.NodeType_TEXT, Node: &v1pb.Node_TextNode{TextNode: &v1pb.TextNode{Content: prefix}}}}, n.FootnoteDefNode.Children...)
+		node.Type = v1pb.NodeType_PARAGRAPH
+		node.Node = &v1pb.Node_ParagraphNode{ParagraphNode: &v1pb.ParagraphNode{Children: children}}
+	}
+	for _, child := range getNodeChildren(node) {
+		replaceFootnoteNode(child)
+	}
+}
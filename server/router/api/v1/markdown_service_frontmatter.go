@@ -0,0 +1,69 @@
+package v1
+
+import (
+	"regexp"
+
+	"github.com/usememos/gomark/ast"
+	"github.com/usememos/gomark/parser"
+	"github.com/usememos/gomark/parser/tokenizer"
+
+	v1pb "github.com/usememos/memos/proto/gen/api/v1"
+)
+
+// frontmatterNodeType is the type of frontmatter nodes, which gomark does not know.
+const frontmatterNodeType ast.NodeType = "FRONTMATTER"
+
+// frontmatterRegexp matches a YAML frontmatter block at the start of the content, i.e.
+// the lines between a leading "---" line and the next "---" line.
+var frontmatterRegexp = regexp.MustCompile(`\A---\n((?:.*\n)*?)---(?:\n|\z)`)
+
+// frontmatter is the YAML frontmatter block of imported notes, e.g. from Obsidian or Jekyll.
+type frontmatter struct {
+	// Content is the raw YAML including its trailing newline, if not empty.
+	Content string
+}
+
+func (*frontmatter) Type() ast.NodeType {
+	return frontmatterNodeType
+}
+
+func (n *frontmatter) Restore() string {
+	return "---\n" + n.Content + "---"
+}
+
+// frontmatterParser matches a frontmatter block, but only at the start of the content.
+type frontmatterParser struct {
+	tokenCount int
+}
+
+var _ parser.BlockParser = (*frontmatterParser)(nil)
+
+func (p *frontmatterParser) Match(tokens []*tokenizer.Token) (ast.Node, int) {
+	if len(tokens) != p.tokenCount {
+		return nil, 0
+	}
+	matches := frontmatterRegexp.FindStringSubmatch(tokenizer.Stringify(tokens))
+	if matches == nil {
+		return nil, 0
+	}
+	// The closing newline is left to the following line break, like for other blocks.
+	node := &frontmatter{Content: matches[1]}
+	length, size := len(node.Restore()), 0
+	for consumed := 0; consumed < length; size++ {
+		consumed += len(tokens[size].Value)
+	}
+	return node, size
+}
+
+// excludeFrontmatter returns the nodes without a leading frontmatter node and the line
+// break after it, e.g. for rendering the body of a note.
+func excludeFrontmatter(nodes []*v1pb.Node) []*v1pb.Node {
+	if len(nodes) == 0 || nodes[0].Type != v1pb.NodeType_FRONTMATTER {
+		return nodes
+	}
+	nodes = nodes[1:]
+	if len(nodes) > 0 && nodes[0].Type == v1pb.NodeType_LINE_BREAK {
+		nodes = nodes[1:]
+	}
+	return nodes
+}
@@ -0,0 +1,201 @@
+package v1
+
+import (
+	"fmt"
+	"html"
+	"net/url"
+	"strings"
+
+	"github.com/usememos/gomark/ast"
+)
+
+// safeURLSchemes are the URL schemes that may appear in rendered links and images.
+// URLs without a scheme are relative and allowed as well.
+var safeURLSchemes = []string{"http", "https", "mailto"}
+
+// htmlRenderer renders nodes to HTML that is safe to embed in other pages. Unlike the
+// gomark HTML renderer it escapes all user content and only emits a fixed set of tags
+// and attributes, so inline HTML in the markdown never reaches the output as markup.
+type htmlRenderer struct {
+	output strings.Builder
+	// headingAnchors are the ids of the top-level headings, see getHeadingAnchors.
+	headingAnchors map[*ast.Heading]string
+}
+
+// renderSafeHTML renders the given nodes to sanitized HTML. Top-level headings get the ids of
+// their anchors in the table of contents.
+func renderSafeHTML(nodes []ast.Node) string {
+	r := &htmlRenderer{headingAnchors: getHeadingAnchors(nodes)}
+	r.renderNodes(nodes)
+	return r.output.String()
+}
+
+func (r *htmlRenderer) renderNodes(nodes []ast.Node) {
+	var prevNode ast.Node
+	for _, node := range nodes {
+		// The line break that ends a block is implied by the block element.
+		if node.Type() == ast.LineBreakNode && prevNode != nil && ast.IsBlockNode(prevNode) && prevNode.Type() != ast.LineBreakNode {
+			prevNode = node
+			continue
+		}
+		r.renderNode(node)
+		prevNode = node
+	}
+}
+
+func (r *htmlRenderer) renderNode(node ast.Node) {
+	switch n := node.(type) {
+	case *ast.LineBreak, *ast.HTMLElement:
+		// <br /> is the only HTML element gomark parses.
+		r.output.WriteString("<br>")
+	case *ast.Paragraph:
+		r.renderElement("p", n.Children)
+	case *ast.CodeBlock:
+		r.output.WriteString("<pre><code")
+		if n.Language != "" {
+			r.output.WriteString(` class="language-` + html.EscapeString(n.Language) + `"`)
+		}
+		r.output.WriteString(">" + html.EscapeString(n.Content) + "</code></pre>")
+	case *ast.Heading:
+		tagName := fmt.Sprintf("h%d", min(max(n.Level, 1), 6))
+		anchor, ok := r.headingAnchors[n]
+		if !ok {
+			r.renderElement(tagName, n.Children)
+			break
+		}
+		r.output.WriteString("<" + tagName + ` id="` + html.EscapeString(anchor) + `">`)
+		r.renderNodes(n.Children)
+		r.output.WriteString("</" + tagName + ">")
+	case *ast.HorizontalRule:
+		r.output.WriteString("<hr>")
+	case *ast.Blockquote:
+		r.renderElement("blockquote", n.Children)
+	case *ast.List:
+		tagName := "ul"
+		if n.Kind == ast.OrderedList {
+			tagName = "ol"
+		}
+		r.renderElement(tagName, n.Children)
+	case *ast.OrderedListItem:
+		r.renderElement("li", n.Children)
+	case *ast.UnorderedListItem:
+		r.renderElement("li", n.Children)
+	case *ast.TaskListItem:
+		r.output.WriteString(`<li><input type="checkbox" disabled`)
+		if n.Complete {
+			r.output.WriteString(" checked")
+		}
+		r.output.WriteString(">")
+		r.renderNodes(n.Children)
+		r.output.WriteString("</li>")
+	case *ast.MathBlock:
+		r.output.WriteString("<pre><code>" + html.EscapeString(n.Content) + "</code></pre>")
+	case *ast.Table:
+		r.renderTable(n)
+	case *ast.EmbeddedContent:
+		r.output.WriteString("<div>" + html.EscapeString(n.Restore()) + "</div>")
+	case *ast.Text:
+		r.output.WriteString(html.EscapeString(n.Content))
+	case *ast.Bold:
+		r.renderElement("strong", n.Children)
+	case *ast.Italic:
+		r.renderElement("em", n.Children)
+	case *ast.BoldItalic:
+		r.output.WriteString("<strong><em>" + html.EscapeString(n.Content) + "</em></strong>")
+	case *ast.Code:
+		r.output.WriteString("<code>" + html.EscapeString(n.Content) + "</code>")
+	case *ast.Math:
+		r.output.WriteString("<code>" + html.EscapeString(n.Content) + "</code>")
+	case *ast.Image:
+		if isSafeURL(n.URL) {
+			r.output.WriteString(`<img src="` + html.EscapeString(n.URL) + `" alt="` + html.EscapeString(n.AltText) + `">`)
+		} else {
+			r.output.WriteString(html.EscapeString(n.AltText))
+		}
+	case *ast.Link:
+		r.renderLink(n.URL, func() { r.renderNodes(n.Content) })
+	case *ast.AutoLink:
+		// Auto links must be absolute, gomark takes anything in angle brackets for one.
+		if !strings.Contains(n.URL, ":") {
+			r.output.WriteString(html.EscapeString(n.Restore()))
+			break
+		}
+		r.renderLink(n.URL, func() { r.output.WriteString(html.EscapeString(n.URL)) })
+	case *ast.Tag:
+		r.output.WriteString("<span>#" + html.EscapeString(n.Content) + "</span>")
+	case *ast.Strikethrough:
+		r.output.WriteString("<del>" + html.EscapeString(n.Content) + "</del>")
+	case *ast.EscapingCharacter:
+		r.output.WriteString(html.EscapeString(n.Symbol))
+	case *ast.Highlight:
+		r.output.WriteString("<mark>" + html.EscapeString(n.Content) + "</mark>")
+	case *ast.Subscript:
+		r.output.WriteString("<sub>" + html.EscapeString(n.Content) + "</sub>")
+	case *ast.Superscript:
+		r.output.WriteString("<sup>" + html.EscapeString(n.Content) + "</sup>")
+	case *ast.ReferencedContent:
+		r.output.WriteString("<span>" + html.EscapeString(n.Restore()) + "</span>")
+	case *ast.Spoiler:
+		r.output.WriteString("<details><summary>" + html.EscapeString(n.Content) + "</summary></details>")
+	default:
+		// Unknown nodes are rendered as their escaped markdown rather than dropped.
+		r.output.WriteString(html.EscapeString(node.Restore()))
+	}
+}
+
+func (r *htmlRenderer) renderElement(tagName string, children []ast.Node) {
+	r.output.WriteString("<" + tagName + ">")
+	r.renderNodes(children)
+	r.output.WriteString("</" + tagName + ">")
+}
+
+// renderLink renders an anchor, or only its content when the URL is unsafe.
+func (r *htmlRenderer) renderLink(urlStr string, renderContent func()) {
+	if !isSafeURL(urlStr) {
+		renderContent()
+		return
+	}
+	r.output.WriteString(`<a href="` + html.EscapeString(urlStr) + `" rel="noopener noreferrer nofollow">`)
+	renderContent()
+	r.output.WriteString("</a>")
+}
+
+func (r *htmlRenderer) renderTable(table *ast.Table) {
+	r.output.WriteString("<table><thead><tr>")
+	for _, cell := range table.Header {
+		r.renderElement("th", []ast.Node{cell})
+	}
+	r.output.WriteString("</tr></thead><tbody>")
+	for _, row := range table.Rows {
+		r.output.WriteString("<tr>")
+		for _, cell := range row {
+			r.renderElement("td", []ast.Node{cell})
+		}
+		r.output.WriteString("</tr>")
+	}
+	r.output.WriteString("</tbody></table>")
+}
+
+// isSafeURL reports whether the URL is relative or has a safe scheme. Browsers ignore
+// control characters and whitespace in schemes, e.g. "java\tscript:", so URLs holding
+// them are rejected outright.
+func isSafeURL(urlStr string) bool {
+	if strings.IndexFunc(urlStr, func(r rune) bool { return r < ' ' || r == 0x7f }) >= 0 {
+		return false
+	}
+	u, err := url.Parse(strings.TrimSpace(urlStr))
+	if err != nil {
+		return false
+	}
+	if u.Scheme == "" {
+		// Without a scheme, a colon before the first slash would still be read as one.
+		path, _, _ := strings.Cut(urlStr, "/")
+		return !strings.Contains(path, ":")
+	}
+	for _, scheme := range safeURLSchemes {
+		if strings.EqualFold(u.Scheme, scheme) {
+			return true
+		}
+	}
+	return false
+}
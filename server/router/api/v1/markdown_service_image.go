@@ -0,0 +1,38 @@
+package v1
+
+import (
+	"net/url"
+
+	v1pb "github.com/usememos/memos/proto/gen/api/v1"
+)
+
+// extractImageReferences returns the images in the given nodes in document order, including
+// those nested in links and table cells.
+func extractImageReferences(nodes []*v1pb.Node) []*v1pb.ImageReference {
+	images := []*v1pb.ImageReference{}
+	var collect func(nodes []*v1pb.Node)
+	collect = func(nodes []*v1pb.Node) {
+		for _, node := range nodes {
+			if n, ok := node.Node.(*v1pb.Node_ImageNode); ok {
+				images = append(images, &v1pb.ImageReference{
+					Url:        n.ImageNode.Url,
+					AltText:    n.ImageNode.AltText,
+					IsRelative: isRelativeURL(n.ImageNode.Url),
+				})
+			}
+			collect(getNodeChildren(node))
+		}
+	}
+	collect(nodes)
+	return images
+}
+
+// isRelativeURL reports whether the URL has neither a scheme nor a host. An unparsable URL
+// is taken as relative, as it cannot be fetched as is.
+func isRelativeURL(urlStr string) bool {
+	u, err := url.Parse(urlStr)
+	if err != nil {
+		return true
+	}
+	return u.Scheme == "" && u.Host == ""
+}
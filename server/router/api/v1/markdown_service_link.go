@@ -0,0 +1,104 @@
+package v1
+
+import (
+	"net/url"
+	"strings"
+
+	"github.com/pkg/errors"
+	"google.golang.org/protobuf/proto"
+
+	v1pb "github.com/usememos/memos/proto/gen/api/v1"
+)
+
+// rewriteMarkdownLinks rewrites the URLs of the links and images in the given nodes against
+// the base URL as the link mode asks. The given nodes are not modified, the result is a copy.
+func rewriteMarkdownLinks(nodes []*v1pb.Node, mode v1pb.StringifyMarkdownNodesRequest_LinkMode, baseURL string) ([]*v1pb.Node, error) {
+	if mode == v1pb.StringifyMarkdownNodesRequest_LINK_MODE_UNSPECIFIED {
+		return nodes, nil
+	}
+	base, err := url.Parse(baseURL)
+	if err != nil || base.Scheme == "" || base.Host == "" {
+		return nil, errors.Errorf("base url %q must be an absolute URL", baseURL)
+	}
+	rewrite := func(urlStr string) string {
+		return absolutizeURL(base, urlStr)
+	}
+	if mode == v1pb.StringifyMarkdownNodesRequest_RELATIVIZE {
+		rewrite = func(urlStr string) string {
+			return relativizeURL(base, urlStr)
+		}
+	}
+
+	result := make([]*v1pb.Node, 0, len(nodes))
+	for _, node := range nodes {
+		node = proto.Clone(node).(*v1pb.Node)
+		rewriteNodeLinks(node, rewrite)
+		result = append(result, node)
+	}
+	return result, nil
+}
+
+func rewriteNodeLinks(node *v1pb.Node, rewrite func(string) string) {
+	switch n := node.Node.(type) {
+	case *v1pb.Node_LinkNode:
+		n.LinkNode.Url = rewrite(n.LinkNode.Url)
+	case *v1pb.Node_ImageNode:
+		n.ImageNode.Url = rewrite(n.ImageNode.Url)
+	}
+	for _, child := range getNodeChildren(node) {
+		rewriteNodeLinks(child, rewrite)
+	}
+}
+
+// absolutizeURL resolves the URL against the base URL. Absolute and fragment-only URLs are
+// returned as is.
+func absolutizeURL(base *url.URL, urlStr string) string {
+	if urlStr == "" || strings.HasPrefix(urlStr, "#") {
+		return urlStr
+	}
+	ref, err := url.Parse(urlStr)
+	if err != nil || ref.IsAbs() {
+		return urlStr
+	}
+	return base.ResolveReference(ref).String()
+}
+
+// relativizeURL makes the URL relative to the base URL when both are on the same host. URLs
+// under the directory of the base URL become path-relative, e.g. "img/a.png", and other URLs
+// on the host root-relative, e.g. "/img/a.png". Other URLs are returned as is.
+func relativizeURL(base *url.URL, urlStr string) string {
+	if urlStr == "" || strings.HasPrefix(urlStr, "#") {
+		return urlStr
+	}
+	ref, err := url.Parse(urlStr)
+	if err != nil || ref.Host == "" || ref.User != nil {
+		return urlStr
+	}
+	// Protocol-relative URLs have no scheme and match the base URL of any scheme.
+	if ref.Scheme != "" && !strings.EqualFold(ref.Scheme, base.Scheme) {
+		return urlStr
+	}
+	if !strings.EqualFold(ref.Host, base.Host) {
+		return urlStr
+	}
+
+	dir := base.Path[:strings.LastIndex(base.Path, "/")+1]
+	if dir == "" {
+		dir = "/"
+	}
+	path := ref.Path
+	if path == "" {
+		path = "/"
+	}
+	if strings.HasPrefix(path, dir) {
+		path = strings.TrimPrefix(path, dir)
+	} else if strings.HasPrefix(path, "//") {
+		// A root-relative path starting with "//" would be taken for a protocol-relative URL.
+		return urlStr
+	}
+	if path == "" {
+		// The directory of the base URL itself.
+		path = "./"
+	}
+	return (&url.URL{Path: path, RawQuery: ref.RawQuery, Fragment: ref.Fragment}).String()
+}
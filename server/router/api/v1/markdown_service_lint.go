@@ -0,0 +1,194 @@
+package v1
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/usememos/gomark/ast"
+
+	v1pb "github.com/usememos/memos/proto/gen/api/v1"
+	"github.com/usememos/memos/server/runner/memopayload"
+)
+
+// tableDelimiterCellRegexp matches a cell of the delimiter row of a table, e.g. ":---:".
+var tableDelimiterCellRegexp = regexp.MustCompile(`^:?-+:?$`)
+
+// lintBlock is a parsed block and its span in the markdown.
+type lintBlock struct {
+	node ast.Node
+	span nodeSpan
+}
+
+// lintMarkdown parses the given markdown and reports the syntax that gomark fell back to text
+// for: code fences that are not closed, tables whose rows do not match their header and
+// malformed links. The nodes are only inspected and then discarded.
+func lintMarkdown(content string) ([]*v1pb.MarkdownDiagnostic, error) {
+	parsed, err := parseMarkdown(content, parseMarkdownOptions{})
+	if err != nil {
+		return nil, err
+	}
+	spans := parsed.spans
+	blocks := flattenLintBlocks(parsed.nodes, &spans)
+	lineStarts := getLineStarts(content)
+	newDiagnostic := func(severity v1pb.MarkdownDiagnostic_Severity, span nodeSpan, format string, args ...any) *v1pb.MarkdownDiagnostic {
+		return &v1pb.MarkdownDiagnostic{
+			Severity: severity,
+			Message:  fmt.Sprintf(format, args...),
+			Position: convertNodeSpanToPosition(lineStarts, span),
+		}
+	}
+
+	diagnostics := []*v1pb.MarkdownDiagnostic{}
+	for _, block := range blocks {
+		for _, span := range findMalformedLinks(content[block.span.start:block.span.end], block.node) {
+			span = nodeSpan{start: block.span.start + span.start, end: block.span.start + span.end}
+			diagnostics = append(diagnostics, newDiagnostic(v1pb.MarkdownDiagnostic_WARNING, span, "link syntax is malformed and rendered as text"))
+		}
+	}
+	for i := 0; i < len(blocks); i++ {
+		block := blocks[i]
+		if _, ok := block.node.(*ast.Paragraph); !ok {
+			continue
+		}
+
+		line := strings.TrimLeft(content[block.span.start:block.span.end], " \t")
+		if strings.HasPrefix(line, "```") && !strings.Contains(line[3:], "```") {
+			diagnostics = append(diagnostics, newDiagnostic(v1pb.MarkdownDiagnostic_ERROR, block.span, "code fence is not closed"))
+			continue
+		}
+
+		// The rows of a table that gomark could not parse are paragraphs between line breaks.
+		rows := []lintBlock{}
+		for j := i; j < len(blocks); j += 2 {
+			if _, ok := blocks[j].node.(*ast.Paragraph); !ok || !isTableRow(content[blocks[j].span.start:blocks[j].span.end]) {
+				break
+			}
+			rows = append(rows, blocks[j])
+			if j+1 >= len(blocks) {
+				break
+			}
+			if _, ok := blocks[j+1].node.(*ast.LineBreak); !ok {
+				break
+			}
+		}
+		if len(rows) < 2 {
+			continue
+		}
+		i += 2*len(rows) - 2
+		cells := func(row lintBlock) []string {
+			return splitTableRow(content[row.span.start:row.span.end])
+		}
+		if !isTableDelimiterRow(cells(rows[1])) {
+			continue
+		}
+		headerCellCount := len(cells(rows[0]))
+		for j, row := range rows[1:] {
+			if cellCount := len(cells(row)); cellCount != headerCellCount {
+				name := "row"
+				if j == 0 {
+					name = "delimiter row"
+				}
+				diagnostics = append(diagnostics, newDiagnostic(v1pb.MarkdownDiagnostic_ERROR, row.span, "table %s has %d cells, but the header has %d", name, cellCount, headerCellCount))
+			}
+		}
+	}
+	sort.SliceStable(diagnostics, func(i, j int) bool {
+		return diagnostics[i].Position.Start < diagnostics[j].Position.Start
+	})
+	return diagnostics, nil
+}
+
+// flattenLintBlocks pairs the parsed blocks with their spans, in the same way as setBlockPositions.
+// Lists are replaced by their items, which have the spans.
+func flattenLintBlocks(nodes []ast.Node, spans *[]nodeSpan) []lintBlock {
+	blocks := []lintBlock{}
+	for _, node := range nodes {
+		if list, ok := node.(*ast.List); ok {
+			blocks = append(blocks, flattenLintBlocks(list.Children, spans)...)
+			continue
+		}
+		if len(*spans) == 0 {
+			break
+		}
+		blocks = append(blocks, lintBlock{node: node, span: (*spans)[0]})
+		*spans = (*spans)[1:]
+	}
+	return blocks
+}
+
+// findMalformedLinks returns the spans within the source of the block of the link syntax left in
+// its text, e.g. "[memos](https://usememos.com" without the closing parenthesis. Link syntax in code
+// is not text and not reported.
+func findMalformedLinks(source string, block ast.Node) []nodeSpan {
+	spans := []nodeSpan{}
+	cursor := 0
+	memopayload.TraverseASTNodes([]ast.Node{block}, func(node ast.Node) {
+		text, ok := node.(*ast.Text)
+		if !ok || text.Content == "" {
+			return
+		}
+		index := strings.Index(source[cursor:], text.Content)
+		if index < 0 {
+			return
+		}
+		offset := cursor + index
+		cursor = offset + len(text.Content)
+		for i := 0; ; {
+			index := strings.Index(text.Content[i:], "](")
+			if index < 0 {
+				return
+			}
+			end := i + index + len("](")
+			start := strings.LastIndex(text.Content[i:i+index], "[")
+			if start < 0 {
+				start = index
+			}
+			start += i
+			if start > 0 && text.Content[start-1] == '!' {
+				start--
+			}
+			spans = append(spans, nodeSpan{start: offset + start, end: offset + end})
+			i = end
+		}
+	})
+	return spans
+}
+
+// isTableRow reports whether the line looks like a row of a table, i.e. starts and ends with a pipe.
+func isTableRow(line string) bool {
+	line = strings.TrimSpace(line)
+	return len(line) > 1 && strings.HasPrefix(line, "|") && strings.HasSuffix(line, "|")
+}
+
+// splitTableRow returns the cells of the row of a table. Escaped pipes do not separate cells.
+func splitTableRow(line string) []string {
+	line = strings.TrimSpace(line)
+	line = strings.TrimSuffix(strings.TrimPrefix(line, "|"), "|")
+	cells := []string{}
+	var cell strings.Builder
+	for i := 0; i < len(line); i++ {
+		if line[i] == '\\' && i+1 < len(line) && line[i+1] == '|' {
+			cell.WriteString(`\|`)
+			i++
+			continue
+		}
+		if line[i] == '|' {
+			cells = append(cells, strings.TrimSpace(cell.String()))
+			cell.Reset()
+			continue
+		}
+		cell.WriteByte(line[i])
+	}
+	return append(cells, strings.TrimSpace(cell.String()))
+}
+
+func isTableDelimiterRow(cells []string) bool {
+	for _, cell := range cells {
+		if !tableDelimiterCellRegexp.MatchString(cell) {
+			return false
+		}
+	}
+	return len(cells) > 0
+}
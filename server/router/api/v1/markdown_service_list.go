@@ -0,0 +1,272 @@
+package v1
+
+import (
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/usememos/gomark/ast"
+	"github.com/usememos/gomark/parser"
+	"github.com/usememos/gomark/parser/tokenizer"
+
+	v1pb "github.com/usememos/memos/proto/gen/api/v1"
+)
+
+// listItemParser wraps a gomark list item parser so that items indented with tabs
+// are recognized as well. The raw indentation of such items is remembered so that
+// they can be restored byte for byte.
+type listItemParser struct {
+	parser.BlockParser
+
+	indentPrefixes map[ast.Node]string
+}
+
+func (p *listItemParser) Match(tokens []*tokenizer.Token) (ast.Node, int) {
+	prefix, size := getLeadingWhitespace(tokens)
+	if !strings.Contains(prefix, "\t") {
+		return p.BlockParser.Match(tokens)
+	}
+
+	// Replace the leading whitespace with one space token per character, which is
+	// the only indentation the gomark list item parsers understand.
+	normalizedTokens := make([]*tokenizer.Token, 0, len(prefix)+len(tokens)-size)
+	for range len(prefix) {
+		normalizedTokens = append(normalizedTokens, tokenizer.NewToken(tokenizer.Space, " "))
+	}
+	normalizedTokens = append(normalizedTokens, tokens[size:]...)
+	node, matchedSize := p.BlockParser.Match(normalizedTokens)
+	if node == nil || matchedSize == 0 {
+		return nil, 0
+	}
+	p.indentPrefixes[node] = prefix
+	return node, matchedSize - len(prefix) + size
+}
+
+// getLeadingWhitespace returns the spaces and tabs at the start of the first line
+// and the number of tokens they span.
+func getLeadingWhitespace(tokens []*tokenizer.Token) (string, int) {
+	var prefix strings.Builder
+	size := 0
+	for _, token := range tokens {
+		if token.Type == tokenizer.Space || (token.Type == tokenizer.Text && strings.Trim(token.Value, " \t") == "") {
+			prefix.WriteString(token.Value)
+			size++
+			continue
+		}
+		break
+	}
+	return prefix.String(), size
+}
+
+// defaultMaxNestingDepth is the max nesting depth of blockquotes and lists when the parse
+// options leave it unset.
+const defaultMaxNestingDepth = 32
+
+// parsedMarkdown is the result of parseMarkdown.
+type parsedMarkdown struct {
+	nodes []ast.Node
+	// indentPrefixes holds the raw indentation of the list items indented with tabs.
+	indentPrefixes map[ast.Node]string
+	// codeFences holds the fences of the code blocks, only when parsed with code fences.
+	codeFences map[ast.Node]codeFence
+	// spans are those of the parsed blocks in order.
+	spans []nodeSpan
+	// truncated is whether blockquotes or lists were nested deeper than the max nesting depth.
+	truncated bool
+}
+
+// parseMarkdown parses the given content into gomark nodes, accepting both spaces
+// and tabs as list indentation. Frontmatter, math blocks and footnote definitions are only
+// parsed when enabled by the options. Blockquotes nested deeper than the max nesting depth are kept as text and
+// deeper lists are flattened, so that crafted content cannot exhaust the stack.
+func parseMarkdown(content string, options parseMarkdownOptions) (result *parsedMarkdown, err error) {
+	// gomark is not known to panic, but a panic must not take down the server.
+	defer func() {
+		if r := recover(); r != nil {
+			result, err = nil, errors.Errorf("failed to parse markdown: %v", r)
+		}
+	}()
+
+	maxNestingDepth := options.maxNestingDepth
+	if maxNestingDepth <= 0 {
+		maxNestingDepth = defaultMaxNestingDepth
+	}
+	result = &parsedMarkdown{
+		indentPrefixes: map[ast.Node]string{},
+		spans:          []nodeSpan{},
+	}
+	tokens := tokenizer.Tokenize(content)
+	offsets := getTokenOffsets(tokens)
+	blockParsers := []parser.BlockParser{}
+	if options.withFrontmatter {
+		blockParsers = append(blockParsers, &frontmatterParser{tokenCount: len(tokens)})
+	}
+	if options.withCodeFences {
+		result.codeFences = map[ast.Node]codeFence{}
+	}
+	if options.withCodeLanguages || options.withCodeFences {
+		blockParsers = append(blockParsers, &codeBlockParser{fences: result.codeFences})
+	} else {
+		blockParsers = append(blockParsers, parser.NewCodeBlockParser())
+	}
+	blockParsers = append(blockParsers,
+		parser.NewTableParser(),
+		parser.NewHorizontalRuleParser(),
+		parser.NewHeadingParser(),
+		&blockquoteParser{depth: 1, maxDepth: maxNestingDepth, truncated: &result.truncated},
+		&listItemParser{BlockParser: parser.NewOrderedListItemParser(), indentPrefixes: result.indentPrefixes},
+		&listItemParser{BlockParser: parser.NewTaskListItemParser(), indentPrefixes: result.indentPrefixes},
+		&listItemParser{BlockParser: parser.NewUnorderedListItemParser(), indentPrefixes: result.indentPrefixes},
+	)
+	if options.withMath {
+		blockParsers = append(blockParsers, parser.NewMathBlockParser())
+	}
+	blockParsers = append(blockParsers, parser.NewEmbeddedContentParser())
+	if options.withFootnotes {
+		blockParsers = append(blockParsers, &footnoteDefParser{})
+	}
+	blockParsers = append(blockParsers,
+		parser.NewParagraphParser(),
+		parser.NewLineBreakParser(),
+	)
+	for i, blockParser := range blockParsers {
+		blockParsers[i] = &spanParser{BlockParser: blockParser, offsets: offsets, spans: &result.spans}
+	}
+	result.nodes, err = parser.ParseBlockWithParsers(tokens, blockParsers)
+	if err != nil {
+		return nil, err
+	}
+	if flattenNestedLists(result.nodes, maxNestingDepth) {
+		result.truncated = true
+	}
+	return result, nil
+}
+
+// parseMarkdownOptions are the opt-in features of parsing markdown into nodes.
+type parseMarkdownOptions struct {
+	// autoLinkWWW detects bare www.-prefixed hosts as auto links.
+	autoLinkWWW bool
+	// withPositions sets the positions of the nodes in the content.
+	withPositions bool
+	// withFrontmatter parses a leading frontmatter block.
+	withFrontmatter bool
+	// withMath parses "$...$" and "$$" blocks as math rather than text.
+	withMath bool
+	// withFootnotes parses footnote definitions and the references to them.
+	withFootnotes bool
+	// withScripts parses subscript and superscript strictly, see adjustScripts.
+	withScripts bool
+	// withEmoji expands known emoji shortcodes, e.g. ":smile:", into emoji nodes.
+	withEmoji bool
+	// withCodeLanguages takes any info string of code blocks and normalizes their languages.
+	withCodeLanguages bool
+	// withCodeFences parses fenced code blocks as CommonMark does and keeps their fences, see codeBlockParser.
+	withCodeFences bool
+	// codeLanguageAliases are the aliases of code languages in addition to the default ones.
+	codeLanguageAliases map[string]string
+	// extensions parse custom inline syntax after the built-in syntax, see RegisterMarkdownExtension.
+	extensions []*MarkdownExtension
+	// maxNestingDepth limits the nesting of blockquotes and lists, defaultMaxNestingDepth if not positive.
+	maxNestingDepth int
+	// rawHTMLMode controls the raw HTML in the nodes, which is escaped if not set.
+	rawHTMLMode v1pb.ParseMarkdownRequest_RawHTMLMode
+}
+
+// parseMarkdownNodes parses the given content into nodes, keeping the raw indentation
+// of list items, detecting bare URLs as auto links and excluding trailing punctuation from tags.
+// Math is only parsed when enabled, and only where its delimiters hug the content.
+// The returned bool reports whether the nesting was truncated, see parseMarkdown.
+func parseMarkdownNodes(content string, options parseMarkdownOptions) ([]*v1pb.Node, bool, error) {
+	parsed, err := parseMarkdown(content, options)
+	if err != nil {
+		return nil, false, err
+	}
+	rawNodes := adjustMath(parsed.nodes, options.withMath)
+	rawNodes = adjustScripts(rawNodes, options.withScripts)
+	rawNodes = applyRawHTMLMode(rawNodes, options.rawHTMLMode)
+	rawNodes = detectAutoLinks(rawNodes, options.autoLinkWWW)
+	rawNodes = splitTagPunctuation(rawNodes)
+	if options.withFootnotes {
+		rawNodes = parseFootnotes(rawNodes)
+	}
+	if options.withEmoji {
+		rawNodes = expandEmojiShortcodes(rawNodes)
+	}
+	if len(options.extensions) > 0 {
+		rawNodes = applyMarkdownExtensions(rawNodes, options.extensions)
+	}
+	nodes := convertFromASTNodes(rawNodes)
+	setListItemIndentPrefixes(rawNodes, nodes, parsed.indentPrefixes)
+	if options.withCodeFences {
+		setCodeFences(rawNodes, nodes, parsed.codeFences)
+	}
+	if options.withCodeLanguages {
+		normalizeCodeLanguages(nodes, options.codeLanguageAliases)
+	}
+	if options.withPositions {
+		setNodePositions(content, rawNodes, nodes, parsed.spans)
+	}
+	return nodes, parsed.truncated, nil
+}
+
+// setListItemIndentPrefixes copies the raw indentation of list items from the parsed
+// gomark nodes to the converted nodes.
+func setListItemIndentPrefixes(rawNodes []ast.Node, nodes []*v1pb.Node, indentPrefixes map[ast.Node]string) {
+	for i, rawNode := range rawNodes {
+		if list, ok := rawNode.(*ast.List); ok {
+			setListItemIndentPrefixes(list.Children, nodes[i].GetListNode().GetChildren(), indentPrefixes)
+			continue
+		}
+		indentPrefix, ok := indentPrefixes[rawNode]
+		if !ok {
+			continue
+		}
+		switch n := nodes[i].Node.(type) {
+		case *v1pb.Node_OrderedListItemNode:
+			n.OrderedListItemNode.IndentPrefix = indentPrefix
+		case *v1pb.Node_UnorderedListItemNode:
+			n.UnorderedListItemNode.IndentPrefix = indentPrefix
+		case *v1pb.Node_TaskListItemNode:
+			n.TaskListItemNode.IndentPrefix = indentPrefix
+		}
+	}
+}
+
+// restoreMarkdownNodes restores the given nodes to markdown content. List items keep
+// their raw indentation when it is known. With commonMark set, the GFM extensions
+// fall back to inline HTML, see convertToCommonMark.
+func restoreMarkdownNodes(nodes []*v1pb.Node, commonMark bool) string {
+	var result strings.Builder
+	for _, node := range nodes {
+		result.WriteString(restoreMarkdownNode(node, commonMark))
+	}
+	return result.String()
+}
+
+func restoreMarkdownNode(node *v1pb.Node, commonMark bool) string {
+	var indent int32
+	var indentPrefix string
+	switch n := node.Node.(type) {
+	case *v1pb.Node_ListNode:
+		return restoreMarkdownNodes(n.ListNode.Children, commonMark)
+	case *v1pb.Node_OrderedListItemNode:
+		indent, indentPrefix = n.OrderedListItemNode.Indent, n.OrderedListItemNode.IndentPrefix
+	case *v1pb.Node_UnorderedListItemNode:
+		indent, indentPrefix = n.UnorderedListItemNode.Indent, n.UnorderedListItemNode.IndentPrefix
+	case *v1pb.Node_TaskListItemNode:
+		indent, indentPrefix = n.TaskListItemNode.Indent, n.TaskListItemNode.IndentPrefix
+	case *v1pb.Node_CodeBlockNode:
+		if n.CodeBlockNode.Fence != "" {
+			return restoreFencedCodeBlock(n.CodeBlockNode)
+		}
+	}
+
+	rawNode := convertToASTNode(node)
+	if commonMark {
+		rawNode = convertToCommonMark(rawNode)
+	}
+	markdown := rawNode.Restore()
+	if indentPrefix != "" {
+		markdown = indentPrefix + strings.TrimPrefix(markdown, strings.Repeat(" ", int(indent)))
+	}
+	return markdown
+}
@@ -0,0 +1,98 @@
+package v1
+
+import (
+	"strings"
+
+	"github.com/usememos/gomark/ast"
+)
+
+// adjustMath applies the math option to the inline nodes. gomark takes anything between
+// two dollar signs on a line for math, so math nodes are turned back into text first. With
+// withMath set, the text is then scanned for math whose delimiters hug the content, as in
+// Pandoc. Escaped dollar signs are nodes of their own and never delimit math. The nodes
+// are modified in place.
+func adjustMath(nodes []ast.Node, withMath bool) []ast.Node {
+	result := make([]ast.Node, 0, len(nodes))
+	for _, node := range nodes {
+		switch n := node.(type) {
+		case *ast.Paragraph:
+			n.Children = adjustMath(n.Children, withMath)
+		case *ast.Heading:
+			n.Children = adjustMath(n.Children, withMath)
+		case *ast.Blockquote:
+			n.Children = adjustMath(n.Children, withMath)
+		case *ast.List:
+			n.Children = adjustMath(n.Children, withMath)
+		case *ast.OrderedListItem:
+			n.Children = adjustMath(n.Children, withMath)
+		case *ast.UnorderedListItem:
+			n.Children = adjustMath(n.Children, withMath)
+		case *ast.TaskListItem:
+			n.Children = adjustMath(n.Children, withMath)
+		case *ast.Bold:
+			n.Children = adjustMath(n.Children, withMath)
+		case *ast.Italic:
+			n.Children = adjustMath(n.Children, withMath)
+		case *footnoteDef:
+			n.Children = adjustMath(n.Children, withMath)
+		case *ast.Math:
+			node = &ast.Text{Content: n.Restore()}
+		}
+		// Merge the text around former math nodes, which may hold the delimiters of other math.
+		if text, ok := node.(*ast.Text); ok && len(result) > 0 {
+			if prevText, ok := result[len(result)-1].(*ast.Text); ok {
+				result[len(result)-1] = &ast.Text{Content: prevText.Content + text.Content}
+				continue
+			}
+		}
+		result = append(result, node)
+	}
+	if !withMath {
+		return result
+	}
+
+	nodes, result = result, make([]ast.Node, 0, len(result))
+	for _, node := range nodes {
+		if text, ok := node.(*ast.Text); ok {
+			result = append(result, splitMath(text.Content)...)
+			continue
+		}
+		result = append(result, node)
+	}
+	return result
+}
+
+// splitMath splits the given text into text and math nodes. Math starts at a "$" followed
+// by a non-space and ends at the next "$", which must follow a non-space and must not be
+// followed by a digit, so that "$5 and $10" is no math.
+func splitMath(content string) []ast.Node {
+	nodes := []ast.Node{}
+	start := 0
+	for i := 0; i < len(content); i++ {
+		if content[i] != '$' || i+1 >= len(content) || isMathSpace(content[i+1]) || content[i+1] == '$' {
+			continue
+		}
+		end := strings.IndexByte(content[i+1:], '$')
+		if end < 0 {
+			break
+		}
+		end += i + 1
+		if isMathSpace(content[end-1]) || (end+1 < len(content) && content[end+1] >= '0' && content[end+1] <= '9') {
+			continue
+		}
+		if i > start {
+			nodes = append(nodes, &ast.Text{Content: content[start:i]})
+		}
+		nodes = append(nodes, &ast.Math{Content: content[i+1 : end]})
+		start = end + 1
+		i = end
+	}
+	if start < len(content) {
+		nodes = append(nodes, &ast.Text{Content: content[start:]})
+	}
+	return nodes
+}
+
+func isMathSpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n'
+}
@@ -0,0 +1,93 @@
+package v1
+
+import (
+	"strings"
+
+	v1pb "github.com/usememos/memos/proto/gen/api/v1"
+)
+
+// maxNormalizedBlankLines is the number of blank lines in a row that normalized markdown keeps,
+// more are collapsed to one.
+const maxNormalizedBlankLines = 2
+
+// normalizeMarkdown canonicalizes the given markdown, see NormalizeMarkdownResponse. The
+// bullets of list items are replaced with the symbol of the style, if any.
+func normalizeMarkdown(content string, bulletStyle v1pb.NormalizeMarkdownRequest_BulletStyle) (string, error) {
+	nodes, _, err := parseMarkdownNodes(content, parseMarkdownOptions{})
+	if err != nil {
+		return "", err
+	}
+	if symbol := getBulletStyleSymbol(bulletStyle); symbol != "" {
+		setListItemBulletSymbols(nodes, symbol)
+	}
+
+	// The lines of code blocks are kept verbatim, so the markdown is restored block by block.
+	var lines []string
+	var verbatim []bool
+	var line strings.Builder
+	lineVerbatim := false
+	for _, node := range nodes {
+		_, isCodeBlock := node.Node.(*v1pb.Node_CodeBlockNode)
+		markdown := restoreMarkdownNodes([]*v1pb.Node{node}, false)
+		for i, part := range strings.Split(markdown, "\n") {
+			if i > 0 {
+				lines, verbatim = append(lines, line.String()), append(verbatim, lineVerbatim)
+				line.Reset()
+				lineVerbatim = false
+			}
+			line.WriteString(part)
+			lineVerbatim = lineVerbatim || isCodeBlock
+		}
+	}
+	lines, verbatim = append(lines, line.String()), append(verbatim, lineVerbatim)
+
+	result := make([]string, 0, len(lines))
+	blankLines := 0
+	for i, line := range lines {
+		if !verbatim[i] {
+			line = strings.TrimRight(line, " \t")
+			if line == "" {
+				blankLines++
+				continue
+			}
+		}
+		if blankLines > maxNormalizedBlankLines {
+			blankLines = 1
+		}
+		for ; blankLines > 0; blankLines-- {
+			result = append(result, "")
+		}
+		result = append(result, line)
+	}
+	normalized := strings.TrimRight(strings.Join(result, "\n"), "\n")
+	if normalized == "" {
+		return "", nil
+	}
+	return normalized + "\n", nil
+}
+
+func getBulletStyleSymbol(bulletStyle v1pb.NormalizeMarkdownRequest_BulletStyle) string {
+	switch bulletStyle {
+	case v1pb.NormalizeMarkdownRequest_DASH:
+		return "-"
+	case v1pb.NormalizeMarkdownRequest_ASTERISK:
+		return "*"
+	case v1pb.NormalizeMarkdownRequest_PLUS:
+		return "+"
+	default:
+		return ""
+	}
+}
+
+// setListItemBulletSymbols sets the symbol of the unordered and task list items in the given nodes.
+func setListItemBulletSymbols(nodes []*v1pb.Node, symbol string) {
+	for _, node := range nodes {
+		switch n := node.Node.(type) {
+		case *v1pb.Node_UnorderedListItemNode:
+			n.UnorderedListItemNode.Symbol = symbol
+		case *v1pb.Node_TaskListItemNode:
+			n.TaskListItemNode.Symbol = symbol
+		}
+		setListItemBulletSymbols(getNodeChildren(node), symbol)
+	}
+}
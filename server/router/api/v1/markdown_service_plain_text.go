@@ -0,0 +1,123 @@
+package v1
+
+import (
+	"strings"
+
+	v1pb "github.com/usememos/memos/proto/gen/api/v1"
+)
+
+// renderPlainText renders the given nodes to text without markdown syntax. Each
+// non-empty block becomes one or more lines, and blocks are separated by a single newline.
+func renderPlainText(nodes []*v1pb.Node) string {
+	return strings.Join(renderPlainTextBlocks(nodes, true), "\n")
+}
+
+// renderPlainTextBlocks renders the given block nodes, dropping line breaks and empty blocks,
+// as well as code blocks unless includeCodeBlocks is set.
+func renderPlainTextBlocks(nodes []*v1pb.Node, includeCodeBlocks bool) []string {
+	blocks := []string{}
+	for _, node := range nodes {
+		var block string
+		switch n := node.Node.(type) {
+		case *v1pb.Node_LineBreakNode, *v1pb.Node_HorizontalRuleNode, *v1pb.Node_EmbeddedContentNode, *v1pb.Node_HtmlElementNode, *v1pb.Node_FrontmatterNode:
+			continue
+		case *v1pb.Node_ParagraphNode:
+			block = renderPlainTextInline(n.ParagraphNode.Children)
+		case *v1pb.Node_CodeBlockNode:
+			if !includeCodeBlocks {
+				continue
+			}
+			block = n.CodeBlockNode.Content
+		case *v1pb.Node_HeadingNode:
+			block = renderPlainTextInline(n.HeadingNode.Children)
+		case *v1pb.Node_BlockquoteNode:
+			block = strings.Join(renderPlainTextBlocks(n.BlockquoteNode.Children, includeCodeBlocks), "\n")
+		case *v1pb.Node_ListNode:
+			block = strings.Join(renderPlainTextBlocks(n.ListNode.Children, includeCodeBlocks), "\n")
+		case *v1pb.Node_OrderedListItemNode:
+			block = renderPlainTextInline(n.OrderedListItemNode.Children)
+		case *v1pb.Node_UnorderedListItemNode:
+			block = renderPlainTextInline(n.UnorderedListItemNode.Children)
+		case *v1pb.Node_TaskListItemNode:
+			block = renderPlainTextInline(n.TaskListItemNode.Children)
+		case *v1pb.Node_MathBlockNode:
+			block = n.MathBlockNode.Content
+		case *v1pb.Node_TableNode:
+			lines := []string{renderPlainTextTableRow(n.TableNode.Header)}
+			for _, row := range n.TableNode.Rows {
+				lines = append(lines, renderPlainTextTableRow(row.Cells))
+			}
+			block = strings.Join(lines, "\n")
+		default:
+			// Inline nodes at the top level, e.g. in a table cell.
+			block = renderPlainTextInline([]*v1pb.Node{node})
+		}
+		if block = strings.Trim(block, "\n"); strings.TrimSpace(block) != "" {
+			blocks = append(blocks, block)
+		}
+	}
+	return blocks
+}
+
+func renderPlainTextTableRow(cells []*v1pb.Node) string {
+	texts := []string{}
+	for _, cell := range cells {
+		texts = append(texts, strings.TrimSpace(renderPlainTextInline([]*v1pb.Node{cell})))
+	}
+	return strings.Join(texts, " ")
+}
+
+// renderPlainTextInline renders the given inline nodes to text.
+func renderPlainTextInline(nodes []*v1pb.Node) string {
+	var result strings.Builder
+	for _, node := range nodes {
+		switch n := node.Node.(type) {
+		case *v1pb.Node_TextNode:
+			result.WriteString(n.TextNode.Content)
+		case *v1pb.Node_BoldNode:
+			result.WriteString(renderPlainTextInline(n.BoldNode.Children))
+		case *v1pb.Node_ItalicNode:
+			result.WriteString(renderPlainTextInline(n.ItalicNode.Children))
+		case *v1pb.Node_BoldItalicNode:
+			result.WriteString(n.BoldItalicNode.Content)
+		case *v1pb.Node_CodeNode:
+			result.WriteString(n.CodeNode.Content)
+		case *v1pb.Node_ImageNode:
+			result.WriteString(n.ImageNode.AltText)
+		case *v1pb.Node_LinkNode:
+			if label := renderPlainTextInline(n.LinkNode.Content); label != "" {
+				result.WriteString(label)
+			} else {
+				result.WriteString(n.LinkNode.Url)
+			}
+		case *v1pb.Node_AutoLinkNode:
+			result.WriteString(n.AutoLinkNode.Url)
+		case *v1pb.Node_TagNode:
+			result.WriteString("#" + n.TagNode.Content)
+		case *v1pb.Node_StrikethroughNode:
+			result.WriteString(n.StrikethroughNode.Content)
+		case *v1pb.Node_EscapingCharacterNode:
+			result.WriteString(n.EscapingCharacterNode.Symbol)
+		case *v1pb.Node_EmojiNode:
+			result.WriteString(":" + n.EmojiNode.Shortcode + ":")
+		case *v1pb.Node_CustomNode:
+			result.WriteString(n.CustomNode.Raw)
+		case *v1pb.Node_MathNode:
+			result.WriteString(n.MathNode.Content)
+		case *v1pb.Node_HighlightNode:
+			result.WriteString(n.HighlightNode.Content)
+		case *v1pb.Node_SubscriptNode:
+			result.WriteString(n.SubscriptNode.Content)
+		case *v1pb.Node_SuperscriptNode:
+			result.WriteString(n.SuperscriptNode.Content)
+		case *v1pb.Node_SpoilerNode:
+			result.WriteString(n.SpoilerNode.Content)
+		case *v1pb.Node_LineBreakNode:
+			result.WriteString("\n")
+		case *v1pb.Node_HtmlElementNode:
+			// <br /> is the only HTML element gomark parses.
+			result.WriteString("\n")
+		}
+	}
+	return result.String()
+}
@@ -0,0 +1,208 @@
+package v1
+
+import (
+	"strings"
+
+	"github.com/usememos/gomark/ast"
+	"github.com/usememos/gomark/parser"
+	"github.com/usememos/gomark/parser/tokenizer"
+
+	v1pb "github.com/usememos/memos/proto/gen/api/v1"
+)
+
+// nodeSpan is the byte range [start, end) of a node in the markdown content.
+type nodeSpan struct {
+	start int
+	end   int
+}
+
+// spanParser wraps a block parser to remember the span of each matched node in the
+// order of matching. The block parsers are always given the remaining suffix of the
+// tokens, so the index of the first token follows from the number of remaining tokens.
+// The spans are kept in order rather than by node, as gomark shares zero-sized nodes
+// such as line breaks.
+type spanParser struct {
+	parser.BlockParser
+
+	// offsets holds the byte offset of each token, plus the length of the content.
+	offsets []int
+	spans   *[]nodeSpan
+}
+
+func (p *spanParser) Match(tokens []*tokenizer.Token) (ast.Node, int) {
+	node, size := p.BlockParser.Match(tokens)
+	if node == nil || size == 0 {
+		return node, size
+	}
+	index := len(p.offsets) - 1 - len(tokens)
+	*p.spans = append(*p.spans, nodeSpan{start: p.offsets[index], end: p.offsets[index+size]})
+	return node, size
+}
+
+// getTokenOffsets returns the byte offset of each token followed by the total length.
+func getTokenOffsets(tokens []*tokenizer.Token) []int {
+	offsets := make([]int, 0, len(tokens)+1)
+	offset := 0
+	for _, token := range tokens {
+		offsets = append(offsets, offset)
+		offset += len(token.Value)
+	}
+	return append(offsets, offset)
+}
+
+// setNodePositions sets the positions of the converted nodes. Block nodes take the spans
+// of the tokens they were parsed from, which are in the order of the blocks once lists are
+// flattened. Nested nodes, whose tokens the gomark parsers do not expose, are looked up by
+// their restored markdown within the span of their parent and are left without a position
+// when it cannot be found.
+func setNodePositions(content string, rawNodes []ast.Node, nodes []*v1pb.Node, spans []nodeSpan) {
+	setBlockPositions(content, getLineStarts(content), rawNodes, nodes, &spans)
+}
+
+// getLineStarts returns the byte offset of the start of each line of the content.
+func getLineStarts(content string) []int {
+	lineStarts := []int{0}
+	for i := range len(content) {
+		if content[i] == '\n' {
+			lineStarts = append(lineStarts, i+1)
+		}
+	}
+	return lineStarts
+}
+
+// setBlockPositions sets the positions of the parsed blocks and consumes their spans.
+func setBlockPositions(content string, lineStarts []int, rawNodes []ast.Node, nodes []*v1pb.Node, spans *[]nodeSpan) {
+	for i, rawNode := range rawNodes {
+		if i >= len(nodes) || len(*spans) == 0 {
+			return
+		}
+		if list, ok := rawNode.(*ast.List); ok {
+			// Lists are made up after parsing and span their items.
+			first := (*spans)[0]
+			children := nodes[i].GetListNode().GetChildren()
+			setBlockPositions(content, lineStarts, list.Children, children, spans)
+			if len(children) > 0 && children[len(children)-1].Position != nil {
+				nodes[i].Position = convertNodeSpanToPosition(lineStarts, nodeSpan{start: first.start, end: int(children[len(children)-1].Position.End)})
+			}
+			continue
+		}
+
+		span := (*spans)[0]
+		*spans = (*spans)[1:]
+		nodes[i].Position = convertNodeSpanToPosition(lineStarts, span)
+		setInlinePositions(content, lineStarts, getASTNodeChildren(rawNode), getNodeChildren(nodes[i]), span)
+	}
+}
+
+// setInlinePositions sets the positions of the nested nodes within the span of their parent.
+func setInlinePositions(content string, lineStarts []int, rawNodes []ast.Node, nodes []*v1pb.Node, parentSpan nodeSpan) {
+	cursor := parentSpan.start
+	for i, rawNode := range rawNodes {
+		if i >= len(nodes) {
+			return
+		}
+		restored := rawNode.Restore()
+		if restored == "" {
+			continue
+		}
+		index := strings.Index(content[cursor:parentSpan.end], restored)
+		if index < 0 {
+			continue
+		}
+		span := nodeSpan{start: cursor + index, end: cursor + index + len(restored)}
+		cursor = span.end
+		nodes[i].Position = convertNodeSpanToPosition(lineStarts, span)
+		setInlinePositions(content, lineStarts, getASTNodeChildren(rawNode), getNodeChildren(nodes[i]), span)
+	}
+}
+
+// convertNodeSpanToPosition converts the span to a position with 1-based lines and columns.
+func convertNodeSpanToPosition(lineStarts []int, span nodeSpan) *v1pb.Position {
+	startLine, startColumn := getLineAndColumn(lineStarts, span.start)
+	endLine, endColumn := getLineAndColumn(lineStarts, span.end)
+	return &v1pb.Position{
+		Start:       int32(span.start),
+		End:         int32(span.end),
+		StartLine:   int32(startLine),
+		StartColumn: int32(startColumn),
+		EndLine:     int32(endLine),
+		EndColumn:   int32(endColumn),
+	}
+}
+
+func getLineAndColumn(lineStarts []int, offset int) (int, int) {
+	line := 0
+	for line+1 < len(lineStarts) && lineStarts[line+1] <= offset {
+		line++
+	}
+	return line + 1, offset - lineStarts[line] + 1
+}
+
+func getASTNodeChildren(node ast.Node) []ast.Node {
+	switch n := node.(type) {
+	case *ast.Paragraph:
+		return n.Children
+	case *ast.Heading:
+		return n.Children
+	case *ast.Blockquote:
+		return n.Children
+	case *ast.List:
+		return n.Children
+	case *ast.OrderedListItem:
+		return n.Children
+	case *ast.UnorderedListItem:
+		return n.Children
+	case *ast.TaskListItem:
+		return n.Children
+	case *ast.Bold:
+		return n.Children
+	case *ast.Italic:
+		return n.Children
+	case *footnoteDef:
+		return n.Children
+	case *ast.Link:
+		return n.Content
+	case *ast.Table:
+		// The cells in reading order, which is the order they appear in the content.
+		cells := append([]ast.Node{}, n.Header...)
+		for _, row := range n.Rows {
+			cells = append(cells, row...)
+		}
+		return cells
+	}
+	return nil
+}
+
+func getNodeChildren(node *v1pb.Node) []*v1pb.Node {
+	switch n := node.Node.(type) {
+	case *v1pb.Node_ParagraphNode:
+		return n.ParagraphNode.Children
+	case *v1pb.Node_HeadingNode:
+		return n.HeadingNode.Children
+	case *v1pb.Node_BlockquoteNode:
+		return n.BlockquoteNode.Children
+	case *v1pb.Node_ListNode:
+		return n.ListNode.Children
+	case *v1pb.Node_OrderedListItemNode:
+		return n.OrderedListItemNode.Children
+	case *v1pb.Node_UnorderedListItemNode:
+		return n.UnorderedListItemNode.Children
+	case *v1pb.Node_TaskListItemNode:
+		return n.TaskListItemNode.Children
+	case *v1pb.Node_BoldNode:
+		return n.BoldNode.Children
+	case *v1pb.Node_ItalicNode:
+		return n.ItalicNode.Children
+	case *v1pb.Node_FootnoteDefNode:
+		return n.FootnoteDefNode.Children
+	case *v1pb.Node_LinkNode:
+		return n.LinkNode.Content
+	case *v1pb.Node_TableNode:
+		cells := append([]*v1pb.Node{}, n.TableNode.Header...)
+		for _, row := range n.TableNode.Rows {
+			cells = append(cells, row.Cells...)
+		}
+		return cells
+	}
+	return nil
+}
@@ -0,0 +1,87 @@
+package v1
+
+import (
+	"regexp"
+
+	"github.com/usememos/gomark/ast"
+
+	v1pb "github.com/usememos/memos/proto/gen/api/v1"
+)
+
+var (
+	// rawHTMLTagRegexp matches HTML start and end tags, e.g. "<img src=x onerror=alert(1)>" and "</b>".
+	rawHTMLTagRegexp = regexp.MustCompile(`</?[A-Za-z][A-Za-z0-9-]*(?:\s[^<>]*)?/?>`)
+	// rawHTMLAutoLinkRegexp matches the whole URL of an auto link that is an HTML tag, see isRawHTMLAutoLink.
+	rawHTMLAutoLinkRegexp = regexp.MustCompile(`^/?[A-Za-z][A-Za-z0-9-]*(?:\s[^<>]*)?/?$`)
+)
+
+// isRawHTMLAutoLink reports whether the auto link is an HTML tag in angle brackets rather than
+// a URL. gomark takes anything in angle brackets for an auto link, e.g. "<img src=x>", but URLs
+// have a scheme and no spaces, and email addresses an "@".
+func isRawHTMLAutoLink(link *ast.AutoLink) bool {
+	return !link.IsRawText && rawHTMLAutoLinkRegexp.MatchString(link.URL)
+}
+
+// applyRawHTMLMode escapes or strips the raw HTML in the given nodes as the mode asks, see
+// ParseMarkdownRequest.RawHTMLMode. The text around raw HTML is merged. The nodes are
+// modified in place.
+func applyRawHTMLMode(nodes []ast.Node, mode v1pb.ParseMarkdownRequest_RawHTMLMode) []ast.Node {
+	if mode == v1pb.ParseMarkdownRequest_ALLOW {
+		return nodes
+	}
+	strip := mode == v1pb.ParseMarkdownRequest_STRIP
+	result := make([]ast.Node, 0, len(nodes))
+	for _, node := range nodes {
+		switch n := node.(type) {
+		case *ast.Paragraph:
+			n.Children = applyRawHTMLMode(n.Children, mode)
+		case *ast.Heading:
+			n.Children = applyRawHTMLMode(n.Children, mode)
+		case *ast.Blockquote:
+			n.Children = applyRawHTMLMode(n.Children, mode)
+		case *ast.List:
+			n.Children = applyRawHTMLMode(n.Children, mode)
+		case *ast.OrderedListItem:
+			n.Children = applyRawHTMLMode(n.Children, mode)
+		case *ast.UnorderedListItem:
+			n.Children = applyRawHTMLMode(n.Children, mode)
+		case *ast.TaskListItem:
+			n.Children = applyRawHTMLMode(n.Children, mode)
+		case *ast.Bold:
+			n.Children = applyRawHTMLMode(n.Children, mode)
+		case *ast.Italic:
+			n.Children = applyRawHTMLMode(n.Children, mode)
+		case *footnoteDef:
+			n.Children = applyRawHTMLMode(n.Children, mode)
+		case *ast.HTMLElement:
+			if strip {
+				continue
+			}
+			node = &ast.Text{Content: n.Restore()}
+		case *ast.AutoLink:
+			if isRawHTMLAutoLink(n) {
+				if strip {
+					continue
+				}
+				node = &ast.Text{Content: "<" + n.URL + ">"}
+			}
+		case *ast.Text:
+			if strip {
+				node = &ast.Text{Content: rawHTMLTagRegexp.ReplaceAllString(n.Content, "")}
+			}
+		}
+		if text, ok := node.(*ast.Text); ok {
+			if text.Content == "" {
+				continue
+			}
+			if len(result) > 0 {
+				if prevText, ok := result[len(result)-1].(*ast.Text); ok {
+					result[len(result)-1] = &ast.Text{Content: prevText.Content + text.Content}
+					continue
+				}
+			}
+		}
+		result = append(result, node)
+	}
+	return result
+}
@@ -0,0 +1,124 @@
+package v1
+
+import (
+	"strings"
+
+	"github.com/usememos/gomark/ast"
+)
+
+// adjustScripts applies the scripts option to the inline nodes. gomark takes anything between
+// two tildes or carets on a line for subscript or superscript, so with withScripts set, these
+// and strikethrough nodes are turned back into text, which is then scanned for subscript and
+// superscript without whitespace, as in Pandoc, and for strikethrough. The nodes are modified
+// in place.
+func adjustScripts(nodes []ast.Node, withScripts bool) []ast.Node {
+	if !withScripts {
+		return nodes
+	}
+	result := make([]ast.Node, 0, len(nodes))
+	for _, node := range nodes {
+		switch n := node.(type) {
+		case *ast.Paragraph:
+			n.Children = adjustScripts(n.Children, withScripts)
+		case *ast.Heading:
+			n.Children = adjustScripts(n.Children, withScripts)
+		case *ast.Blockquote:
+			n.Children = adjustScripts(n.Children, withScripts)
+		case *ast.List:
+			n.Children = adjustScripts(n.Children, withScripts)
+		case *ast.OrderedListItem:
+			n.Children = adjustScripts(n.Children, withScripts)
+		case *ast.UnorderedListItem:
+			n.Children = adjustScripts(n.Children, withScripts)
+		case *ast.TaskListItem:
+			n.Children = adjustScripts(n.Children, withScripts)
+		case *ast.Bold:
+			n.Children = adjustScripts(n.Children, withScripts)
+		case *ast.Italic:
+			n.Children = adjustScripts(n.Children, withScripts)
+		case *footnoteDef:
+			n.Children = adjustScripts(n.Children, withScripts)
+		case *ast.Subscript, *ast.Superscript, *ast.Strikethrough:
+			node = &ast.Text{Content: n.Restore()}
+		}
+		// Merge the text around former script nodes, which may hold the delimiters of others.
+		if text, ok := node.(*ast.Text); ok && len(result) > 0 {
+			if prevText, ok := result[len(result)-1].(*ast.Text); ok {
+				result[len(result)-1] = &ast.Text{Content: prevText.Content + text.Content}
+				continue
+			}
+		}
+		result = append(result, node)
+	}
+
+	nodes, result = result, make([]ast.Node, 0, len(result))
+	for _, node := range nodes {
+		if text, ok := node.(*ast.Text); ok {
+			result = append(result, splitScripts(text.Content)...)
+			continue
+		}
+		result = append(result, node)
+	}
+	return result
+}
+
+// splitScripts splits the given text into text, strikethrough, subscript and superscript nodes.
+// Strikethrough is any text between "~~" and the next "~~". Subscript and superscript are text
+// without whitespace between two "~" or "^". A "^" after "[" delimits nothing, so that footnote
+// references like "[^1]" are kept.
+func splitScripts(content string) []ast.Node {
+	nodes := []ast.Node{}
+	start := 0
+	for i := 0; i < len(content); i++ {
+		c := content[i]
+		if c != '~' && c != '^' {
+			continue
+		}
+		var node ast.Node
+		end := -1
+		if strings.HasPrefix(content[i:], "~~") {
+			if length := strings.Index(content[i+2:], "~~"); length > 0 && !strings.Contains(content[i+2:i+2+length], "\n") {
+				node = &ast.Strikethrough{Content: content[i+2 : i+2+length]}
+				end = i + 2 + length + 1
+			} else {
+				// Neither tilde of an unclosed "~~" opens a subscript.
+				i++
+				continue
+			}
+		} else if c == '~' || !isFootnoteCaret(content, i) {
+			length := strings.IndexFunc(content[i+1:], func(r rune) bool {
+				return isScriptDelimiter(r) || r == ' ' || r == '\t' || r == '\n'
+			})
+			if length > 0 && content[i+1+length] == c && (c == '~' || !isFootnoteCaret(content, i+1+length)) {
+				if c == '~' {
+					node = &ast.Subscript{Content: content[i+1 : i+1+length]}
+				} else {
+					node = &ast.Superscript{Content: content[i+1 : i+1+length]}
+				}
+				end = i + 1 + length
+			}
+		}
+		if node == nil {
+			continue
+		}
+		if i > start {
+			nodes = append(nodes, &ast.Text{Content: content[start:i]})
+		}
+		nodes = append(nodes, node)
+		start = end + 1
+		i = end
+	}
+	if start < len(content) {
+		nodes = append(nodes, &ast.Text{Content: content[start:]})
+	}
+	return nodes
+}
+
+func isScriptDelimiter(r rune) bool {
+	return r == '~' || r == '^'
+}
+
+// isFootnoteCaret reports whether the caret at i follows "[", as in a footnote reference.
+func isFootnoteCaret(content string, i int) bool {
+	return i > 0 && content[i-1] == '['
+}
@@ -0,0 +1,52 @@
+package v1
+
+import (
+	"unicode"
+
+	v1pb "github.com/usememos/memos/proto/gen/api/v1"
+)
+
+// readingWordsPerMinute is the reading speed used to estimate the reading time.
+const readingWordsPerMinute = 200
+
+// getMarkdownStats counts the words and characters of the given plain text. Words are
+// runs of characters between whitespace that hold a letter or a digit, so punctuation on
+// its own is not a word. CJK characters are written without spaces and count as a word
+// each, while Hangul, which is written with spaces, is counted like other scripts.
+func getMarkdownStats(text string) *v1pb.MarkdownStats {
+	wordCount, characterCount := 0, 0
+	inWord, wordHasContent := false, false
+	endWord := func() {
+		if inWord && wordHasContent {
+			wordCount++
+		}
+		inWord, wordHasContent = false, false
+	}
+	for _, r := range text {
+		if unicode.IsSpace(r) {
+			endWord()
+			continue
+		}
+		characterCount++
+		if isCJKCharacter(r) {
+			endWord()
+			wordCount++
+			continue
+		}
+		inWord = true
+		if unicode.IsLetter(r) || unicode.IsNumber(r) {
+			wordHasContent = true
+		}
+	}
+	endWord()
+
+	return &v1pb.MarkdownStats{
+		WordCount:          int32(wordCount),
+		CharacterCount:     int32(characterCount),
+		ReadingTimeMinutes: int32((wordCount + readingWordsPerMinute - 1) / readingWordsPerMinute),
+	}
+}
+
+func isCJKCharacter(r rune) bool {
+	return unicode.In(r, unicode.Han, unicode.Hiragana, unicode.Katakana)
+}
@@ -0,0 +1,50 @@
+package v1
+
+import (
+	"github.com/usememos/gomark/ast"
+
+	"github.com/usememos/memos/server/runner/memopayload"
+)
+
+// splitTagPunctuation moves the trailing punctuation of tags into text nodes, so that
+// "#foo." is the tag "foo" followed by a period. The nodes are modified in place.
+func splitTagPunctuation(nodes []ast.Node) []ast.Node {
+	result := make([]ast.Node, 0, len(nodes))
+	for _, node := range nodes {
+		switch n := node.(type) {
+		case *ast.Paragraph:
+			n.Children = splitTagPunctuation(n.Children)
+		case *ast.Heading:
+			n.Children = splitTagPunctuation(n.Children)
+		case *ast.Blockquote:
+			n.Children = splitTagPunctuation(n.Children)
+		case *ast.List:
+			n.Children = splitTagPunctuation(n.Children)
+		case *ast.OrderedListItem:
+			n.Children = splitTagPunctuation(n.Children)
+		case *ast.UnorderedListItem:
+			n.Children = splitTagPunctuation(n.Children)
+		case *ast.TaskListItem:
+			n.Children = splitTagPunctuation(n.Children)
+		case *ast.Bold:
+			n.Children = splitTagPunctuation(n.Children)
+		case *ast.Italic:
+			n.Children = splitTagPunctuation(n.Children)
+		case *footnoteDef:
+			n.Children = splitTagPunctuation(n.Children)
+		case *ast.Tag:
+			tag := memopayload.TrimTag(n.Content)
+			if tag == "" {
+				result = append(result, &ast.Text{Content: "#" + n.Content})
+				continue
+			}
+			result = append(result, &ast.Tag{Content: tag})
+			if len(tag) < len(n.Content) {
+				result = append(result, &ast.Text{Content: n.Content[len(tag):]})
+			}
+			continue
+		}
+		result = append(result, node)
+	}
+	return result
+}
@@ -0,0 +1,1730 @@
+package v1
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/usememos/memos/plugin/httpgetter"
+	v1pb "github.com/usememos/memos/proto/gen/api/v1"
+	storepb "github.com/usememos/memos/proto/gen/store"
+	"github.com/usememos/memos/server/runner/memopayload"
+	"github.com/usememos/memos/store"
+	teststore "github.com/usememos/memos/store/test"
+)
+
+func TestParseMarkdownListRoundTrip(t *testing.T) {
+	tests := []struct {
+		markdown string
+		// kinds and indents of the list nodes in document order.
+		kinds   []v1pb.ListNode_Kind
+		indents []int32
+	}{
+		{
+			markdown: "1. a\n  1. b\n    - c",
+			kinds:    []v1pb.ListNode_Kind{v1pb.ListNode_ORDERED, v1pb.ListNode_ORDERED, v1pb.ListNode_UNORDERED},
+			indents:  []int32{0, 2, 4},
+		},
+		{
+			markdown: "1. a\n- b\n2. c",
+			kinds:    []v1pb.ListNode_Kind{v1pb.ListNode_ORDERED, v1pb.ListNode_UNORDERED, v1pb.ListNode_ORDERED},
+			indents:  []int32{0, 0, 0},
+		},
+		{
+			markdown: "- a\n\t- b\n\t\t- c",
+			kinds:    []v1pb.ListNode_Kind{v1pb.ListNode_UNORDERED, v1pb.ListNode_UNORDERED, v1pb.ListNode_UNORDERED},
+			indents:  []int32{0, 1, 2},
+		},
+		{
+			markdown: "1. a\n\t2. b\n  3. c",
+			kinds:    []v1pb.ListNode_Kind{v1pb.ListNode_ORDERED, v1pb.ListNode_ORDERED, v1pb.ListNode_ORDERED},
+			indents:  []int32{0, 1, 2},
+		},
+		{
+			markdown: "1. a\n  - [ ] b\n    - [x] c\n2. d",
+			kinds:    []v1pb.ListNode_Kind{v1pb.ListNode_ORDERED, v1pb.ListNode_TASK, v1pb.ListNode_TASK, v1pb.ListNode_ORDERED},
+			indents:  []int32{0, 2, 4, 0},
+		},
+		{
+			markdown: "1. a\n\t- [x] b\n\n2. c",
+			kinds:    []v1pb.ListNode_Kind{v1pb.ListNode_ORDERED, v1pb.ListNode_TASK, v1pb.ListNode_ORDERED},
+			indents:  []int32{0, 1, 0},
+		},
+	}
+
+	s := newTestMarkdownService(t)
+	for _, test := range tests {
+		parseResponse, err := s.ParseMarkdown(context.Background(), &v1pb.ParseMarkdownRequest{Markdown: test.markdown})
+		require.NoError(t, err)
+		kinds, indents := []v1pb.ListNode_Kind{}, []int32{}
+		collectListNodes(parseResponse.Nodes, func(list *v1pb.ListNode) {
+			kinds = append(kinds, list.Kind)
+			indents = append(indents, list.Indent)
+		})
+		require.Equal(t, test.kinds, kinds, test.markdown)
+		require.Equal(t, test.indents, indents, test.markdown)
+
+		restoreResponse, err := s.RestoreMarkdownNodes(context.Background(), &v1pb.RestoreMarkdownNodesRequest{Nodes: parseResponse.Nodes})
+		require.NoError(t, err)
+		require.Equal(t, test.markdown, restoreResponse.Markdown)
+	}
+}
+
+func collectListNodes(nodes []*v1pb.Node, fn func(*v1pb.ListNode)) {
+	for _, node := range nodes {
+		if list := node.GetListNode(); list != nil {
+			fn(list)
+			collectListNodes(list.Children, fn)
+		}
+	}
+}
+
+func TestBatchParseMarkdown(t *testing.T) {
+	s := newTestMarkdownService(t)
+	response, err := s.BatchParseMarkdown(context.Background(), &v1pb.BatchParseMarkdownRequest{})
+	require.NoError(t, err)
+	require.Empty(t, response.Results)
+
+	markdowns := []string{"# heading", "", "- item"}
+	response, err = s.BatchParseMarkdown(context.Background(), &v1pb.BatchParseMarkdownRequest{Markdowns: markdowns})
+	require.NoError(t, err)
+	require.Len(t, response.Results, len(markdowns))
+	for i, markdown := range markdowns {
+		parseResponse, err := s.ParseMarkdown(context.Background(), &v1pb.ParseMarkdownRequest{Markdown: markdown})
+		require.NoError(t, err)
+		require.Empty(t, response.Results[i].Error)
+		require.Equal(t, len(parseResponse.Nodes), len(response.Results[i].Nodes))
+	}
+	require.Equal(t, v1pb.NodeType_HEADING, response.Results[0].Nodes[0].Type)
+	require.Equal(t, v1pb.NodeType_LIST, response.Results[2].Nodes[0].Type)
+
+	_, err = s.BatchParseMarkdown(context.Background(), &v1pb.BatchParseMarkdownRequest{Markdowns: make([]string, maxBatchParseMarkdownSize+1)})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestStringifyMarkdownNodesPlainText(t *testing.T) {
+	tests := []struct {
+		markdown  string
+		plainText string
+	}{
+		{
+			markdown:  "# Hello **world**",
+			plainText: "Hello world",
+		},
+		{
+			markdown:  "See [memos](https://usememos.com) and ![logo](https://usememos.com/logo.png)",
+			plainText: "See memos and logo",
+		},
+		{
+			markdown:  "```go\nfmt.Println(\"hi\")\n```",
+			plainText: "fmt.Println(\"hi\")",
+		},
+		{
+			markdown:  "first\n\n\nsecond\n---\n> quote",
+			plainText: "first\nsecond\nquote",
+		},
+		{
+			markdown:  "- a\n- [x] b\n1. `c` #tag",
+			plainText: "a\nb\nc #tag",
+		},
+	}
+
+	s := newTestMarkdownService(t)
+	for _, test := range tests {
+		parseResponse, err := s.ParseMarkdown(context.Background(), &v1pb.ParseMarkdownRequest{Markdown: test.markdown})
+		require.NoError(t, err)
+		response, err := s.StringifyMarkdownNodes(context.Background(), &v1pb.StringifyMarkdownNodesRequest{
+			Nodes: parseResponse.Nodes,
+			Mode:  v1pb.StringifyMarkdownNodesRequest_PLAIN_TEXT,
+		})
+		require.NoError(t, err)
+		require.Equal(t, test.plainText, response.PlainText, test.markdown)
+	}
+}
+
+func TestStringifyMarkdownNodesDialect(t *testing.T) {
+	tests := []struct {
+		markdown   string
+		commonMark string
+	}{
+		{
+			markdown:   "- [x] done\n- [ ] ~~todo~~",
+			commonMark: "- <input type=\"checkbox\" checked disabled /> done\n- <input type=\"checkbox\" disabled /> <del>todo</del>",
+		},
+		{
+			markdown:   "| a | b |\n| --- | --- |\n| 1 | <2> |",
+			commonMark: "<table>\n<thead>\n<tr><th>a</th><th>b</th></tr>\n</thead>\n<tbody>\n<tr><td>1</td><td>&lt;2&gt;</td></tr>\n</tbody>\n</table>",
+		},
+		{
+			markdown:   "# Hello ~~world~~ **bold**\n\n1. item",
+			commonMark: "# Hello <del>world</del> **bold**\n\n1. item",
+		},
+	}
+
+	s := newTestMarkdownService(t)
+	for _, test := range tests {
+		parseResponse, err := s.ParseMarkdown(context.Background(), &v1pb.ParseMarkdownRequest{Markdown: test.markdown})
+		require.NoError(t, err)
+		response, err := s.StringifyMarkdownNodes(context.Background(), &v1pb.StringifyMarkdownNodesRequest{
+			Nodes: parseResponse.Nodes,
+			Mode:  v1pb.StringifyMarkdownNodesRequest_GFM,
+		})
+		require.NoError(t, err)
+		require.Equal(t, test.markdown, response.PlainText, test.markdown)
+		response, err = s.StringifyMarkdownNodes(context.Background(), &v1pb.StringifyMarkdownNodesRequest{
+			Nodes: parseResponse.Nodes,
+			Mode:  v1pb.StringifyMarkdownNodesRequest_COMMONMARK,
+		})
+		require.NoError(t, err)
+		require.Equal(t, test.commonMark, response.PlainText, test.markdown)
+	}
+}
+
+func TestRestoreMarkdownNodesStrict(t *testing.T) {
+	s := newTestMarkdownService(t)
+	parseResponse, err := s.ParseMarkdown(context.Background(), &v1pb.ParseMarkdownRequest{
+		Markdown: "# Title\n\n| a | b |\n| --- | --- |\n| 1 | 2 |\n\n- [x] [link](https://usememos.com)",
+	})
+	require.NoError(t, err)
+	_, err = s.RestoreMarkdownNodes(context.Background(), &v1pb.RestoreMarkdownNodesRequest{Nodes: parseResponse.Nodes, Strict: true})
+	require.NoError(t, err)
+
+	tests := []struct {
+		nodes []*v1pb.Node
+		err   string
+	}{
+		{
+			nodes: []*v1pb.Node{{Type: v1pb.NodeType_TABLE, Node: &v1pb.Node_TableNode{TableNode: &v1pb.TableNode{
+				Header:    []*v1pb.Node{{Type: v1pb.NodeType_TEXT, Node: &v1pb.Node_TextNode{TextNode: &v1pb.TextNode{Content: "a"}}}},
+				Delimiter: []string{"---"},
+				Rows: []*v1pb.TableNode_Row{
+					{Cells: []*v1pb.Node{{Type: v1pb.NodeType_TEXT, Node: &v1pb.Node_TextNode{TextNode: &v1pb.TextNode{Content: "1"}}}}},
+					{Cells: []*v1pb.Node{}},
+				},
+			}}}},
+			err: "nodes[0].table_node.rows[1].cells has 0 entries, header has 1",
+		},
+		{
+			nodes: []*v1pb.Node{{Type: v1pb.NodeType_PARAGRAPH, Node: &v1pb.Node_ParagraphNode{ParagraphNode: &v1pb.ParagraphNode{
+				Children: []*v1pb.Node{
+					{Type: v1pb.NodeType_TEXT, Node: &v1pb.Node_TextNode{TextNode: &v1pb.TextNode{Content: "see "}}},
+					{Type: v1pb.NodeType_LINK, Node: &v1pb.Node_LinkNode{LinkNode: &v1pb.LinkNode{}}},
+				},
+			}}}},
+			err: "nodes[0].paragraph_node.children[1].link_node.url is empty",
+		},
+		{
+			nodes: []*v1pb.Node{{Type: v1pb.NodeType_LINE_BREAK}},
+			err:   "nodes[0] has no node",
+		},
+	}
+	for _, test := range tests {
+		_, err := s.RestoreMarkdownNodes(context.Background(), &v1pb.RestoreMarkdownNodesRequest{Nodes: test.nodes, Strict: true})
+		require.Equal(t, codes.InvalidArgument, status.Code(err))
+		require.ErrorContains(t, err, test.err)
+	}
+}
+
+func TestParseMarkdownTags(t *testing.T) {
+	tests := []struct {
+		markdown string
+		tags     []string
+	}{
+		{
+			markdown: "#foo and #nested/bar, then #foo again.",
+			tags:     []string{"foo", "nested/bar"},
+		},
+		{
+			markdown: "`#code` and # heading-like, #end.",
+			tags:     []string{"end"},
+		},
+		{
+			markdown: "```\n#block\n```\n- #item! (#paren) #...",
+			tags:     []string{"item", "paren"},
+		},
+	}
+
+	s := newTestMarkdownService(t)
+	for _, test := range tests {
+		response, err := s.ParseMarkdown(context.Background(), &v1pb.ParseMarkdownRequest{Markdown: test.markdown})
+		require.NoError(t, err)
+		require.Equal(t, test.tags, response.Tags, test.markdown)
+		restoreResponse, err := s.RestoreMarkdownNodes(context.Background(), &v1pb.RestoreMarkdownNodesRequest{Nodes: response.Nodes})
+		require.NoError(t, err)
+		require.Equal(t, test.markdown, restoreResponse.Markdown)
+	}
+}
+
+func TestParseMarkdownPositions(t *testing.T) {
+	markdown := "# Héllo\n\nSome `code` here\n- **bold**"
+	s := newTestMarkdownService(t)
+	response, err := s.ParseMarkdown(context.Background(), &v1pb.ParseMarkdownRequest{Markdown: markdown, IncludePositions: true})
+	require.NoError(t, err)
+	nodes := response.Nodes
+	require.Len(t, nodes, 6)
+
+	// The heading spans its raw markdown, in bytes of the UTF-8 content.
+	heading := nodes[0]
+	require.Equal(t, &v1pb.Position{Start: 0, End: 8, StartLine: 1, StartColumn: 1, EndLine: 1, EndColumn: 9}, heading.Position)
+	require.Equal(t, "Héllo", markdown[heading.GetHeadingNode().Children[0].Position.Start:heading.GetHeadingNode().Children[0].Position.End])
+
+	paragraph := nodes[3]
+	require.Equal(t, &v1pb.Position{Start: 10, End: 26, StartLine: 3, StartColumn: 1, EndLine: 3, EndColumn: 17}, paragraph.Position)
+	code := paragraph.GetParagraphNode().Children[1]
+	require.Equal(t, "code", code.GetCodeNode().Content)
+	require.Equal(t, &v1pb.Position{Start: 15, End: 21, StartLine: 3, StartColumn: 6, EndLine: 3, EndColumn: 12}, code.Position)
+
+	// Nested inline nodes have positions too.
+	list := nodes[5]
+	require.Equal(t, "- **bold**", markdown[list.Position.Start:list.Position.End])
+	bold := list.GetListNode().Children[0].GetUnorderedListItemNode().Children[0]
+	require.Equal(t, "**bold**", markdown[bold.Position.Start:bold.Position.End])
+	require.Equal(t, "bold", markdown[bold.GetBoldNode().Children[0].Position.Start:bold.GetBoldNode().Children[0].Position.End])
+
+	// Positions are left out unless requested.
+	response, err = s.ParseMarkdown(context.Background(), &v1pb.ParseMarkdownRequest{Markdown: markdown})
+	require.NoError(t, err)
+	require.Nil(t, response.Nodes[0].Position)
+}
+
+func TestParseMarkdownFrontmatter(t *testing.T) {
+	markdown := "---\ntitle: Trip\ntags: [travel]\n---\n# Day one\n\n---\nText"
+	s := newTestMarkdownService(t)
+	response, err := s.ParseMarkdown(context.Background(), &v1pb.ParseMarkdownRequest{Markdown: markdown, Frontmatter: true})
+	require.NoError(t, err)
+	nodes := response.Nodes
+	require.Equal(t, v1pb.NodeType_FRONTMATTER, nodes[0].Type)
+	require.Equal(t, "title: Trip\ntags: [travel]\n", nodes[0].GetFrontmatterNode().Content)
+	require.Equal(t, v1pb.NodeType_LINE_BREAK, nodes[1].Type)
+	require.Equal(t, v1pb.NodeType_HEADING, nodes[2].Type)
+	// Only a leading block is frontmatter.
+	require.Equal(t, v1pb.NodeType_HORIZONTAL_RULE, nodes[5].Type)
+
+	// The frontmatter is restored verbatim, but left out of the plain text.
+	stringifyResponse, err := s.StringifyMarkdownNodes(context.Background(), &v1pb.StringifyMarkdownNodesRequest{
+		Nodes: nodes,
+		Mode:  v1pb.StringifyMarkdownNodesRequest_GFM,
+	})
+	require.NoError(t, err)
+	require.Equal(t, markdown, stringifyResponse.PlainText)
+	stringifyResponse, err = s.StringifyMarkdownNodes(context.Background(), &v1pb.StringifyMarkdownNodesRequest{
+		Nodes: nodes,
+		Mode:  v1pb.StringifyMarkdownNodesRequest_PLAIN_TEXT,
+	})
+	require.NoError(t, err)
+	require.Equal(t, "Day one\nText", stringifyResponse.PlainText)
+
+	// Without the option, the leading block is parsed as before.
+	response, err = s.ParseMarkdown(context.Background(), &v1pb.ParseMarkdownRequest{Markdown: markdown})
+	require.NoError(t, err)
+	require.Equal(t, v1pb.NodeType_HORIZONTAL_RULE, response.Nodes[0].Type)
+
+	// Content without frontmatter is unaffected by the option.
+	plain := "# Title\n\n- item"
+	withOption, err := s.ParseMarkdown(context.Background(), &v1pb.ParseMarkdownRequest{Markdown: plain, Frontmatter: true})
+	require.NoError(t, err)
+	withoutOption, err := s.ParseMarkdown(context.Background(), &v1pb.ParseMarkdownRequest{Markdown: plain})
+	require.NoError(t, err)
+	require.Equal(t, withoutOption.Nodes, withOption.Nodes)
+}
+
+func TestParseMarkdownAutoLink(t *testing.T) {
+	tests := []struct {
+		markdown    string
+		autoLinkWWW bool
+		// urls of the auto link nodes in document order.
+		urls []string
+	}{
+		{
+			markdown: "check https://example.com today",
+			urls:     []string{"https://example.com"},
+		},
+		{
+			markdown: "see https://example.com/path.",
+			urls:     []string{"https://example.com/path"},
+		},
+		{
+			markdown: "(https://example.com/wiki/Go_(language)) and (https://example.com/a).",
+			urls:     []string{"https://example.com/wiki/Go_(language)", "https://example.com/a"},
+		},
+		{
+			markdown: "links:https://example.com, https://example.org?",
+			urls:     []string{"https://example.com", "https://example.org"},
+		},
+		{
+			markdown: "visit www.example.com.",
+			urls:     []string{},
+		},
+		{
+			markdown:    "visit www.example.com. or **www.example.org/docs**",
+			autoLinkWWW: true,
+			urls:        []string{"www.example.com", "www.example.org/docs"},
+		},
+		{
+			markdown:    "- item https://example.com!\n\n[label](https://example.com) `https://example.com`",
+			autoLinkWWW: true,
+			urls:        []string{"https://example.com"},
+		},
+	}
+
+	s := newTestMarkdownService(t)
+	for _, test := range tests {
+		parseResponse, err := s.ParseMarkdown(context.Background(), &v1pb.ParseMarkdownRequest{Markdown: test.markdown, AutoLinkWww: test.autoLinkWWW})
+		require.NoError(t, err)
+		urls := []string{}
+		collectAutoLinkURLs(parseResponse.Nodes, &urls)
+		require.Equal(t, test.urls, urls, test.markdown)
+
+		restoreResponse, err := s.RestoreMarkdownNodes(context.Background(), &v1pb.RestoreMarkdownNodesRequest{Nodes: parseResponse.Nodes})
+		require.NoError(t, err)
+		require.Equal(t, test.markdown, restoreResponse.Markdown)
+
+		stringifyResponse, err := s.StringifyMarkdownNodes(context.Background(), &v1pb.StringifyMarkdownNodesRequest{Nodes: parseResponse.Nodes})
+		require.NoError(t, err)
+		expected, err := s.StringifyMarkdownNodes(context.Background(), &v1pb.StringifyMarkdownNodesRequest{Nodes: parseMarkdownWithoutAutoLinkDetection(t, test.markdown)})
+		require.NoError(t, err)
+		require.Equal(t, expected.PlainText, stringifyResponse.PlainText, test.markdown)
+	}
+}
+
+func collectAutoLinkURLs(nodes []*v1pb.Node, urls *[]string) {
+	for _, node := range nodes {
+		if autoLink := node.GetAutoLinkNode(); autoLink != nil {
+			*urls = append(*urls, autoLink.Url)
+		}
+		children := []*v1pb.Node{}
+		children = append(children, node.GetParagraphNode().GetChildren()...)
+		children = append(children, node.GetListNode().GetChildren()...)
+		children = append(children, node.GetUnorderedListItemNode().GetChildren()...)
+		children = append(children, node.GetBoldNode().GetChildren()...)
+		collectAutoLinkURLs(children, urls)
+	}
+}
+
+// parseMarkdownWithoutAutoLinkDetection parses the markdown without detecting bare URLs.
+func parseMarkdownWithoutAutoLinkDetection(t *testing.T, markdown string) []*v1pb.Node {
+	parsed, err := parseMarkdown(markdown, parseMarkdownOptions{})
+	require.NoError(t, err)
+	return convertFromASTNodes(parsed.nodes)
+}
+
+func TestParseMarkdownMath(t *testing.T) {
+	s := newTestMarkdownService(t)
+	markdown := "Energy $E=mc^2$ holds.\n$$\n\\int_0^1 x \\, dx\n$$"
+	response, err := s.ParseMarkdown(context.Background(), &v1pb.ParseMarkdownRequest{Markdown: markdown, Math: true})
+	require.NoError(t, err)
+	nodes := response.Nodes
+	require.Len(t, nodes, 3)
+	require.Equal(t, "E=mc^2", nodes[0].GetParagraphNode().Children[1].GetMathNode().Content)
+	require.Equal(t, "\\int_0^1 x \\, dx", nodes[2].GetMathBlockNode().Content)
+	// The delimiters are restored exactly.
+	stringifyResponse, err := s.StringifyMarkdownNodes(context.Background(), &v1pb.StringifyMarkdownNodesRequest{
+		Nodes: nodes,
+		Mode:  v1pb.StringifyMarkdownNodesRequest_GFM,
+	})
+	require.NoError(t, err)
+	require.Equal(t, markdown, stringifyResponse.PlainText)
+
+	tests := []struct {
+		markdown string
+		types    []v1pb.NodeType
+	}{
+		{markdown: "It costs $5 and $10.", types: []v1pb.NodeType{v1pb.NodeType_TEXT}},
+		{markdown: "A lone $ sign", types: []v1pb.NodeType{v1pb.NodeType_TEXT}},
+		{markdown: "Not $ math $ either", types: []v1pb.NodeType{v1pb.NodeType_TEXT}},
+		{markdown: "Escaped \\$x$ sign", types: []v1pb.NodeType{v1pb.NodeType_TEXT, v1pb.NodeType_ESCAPING_CHARACTER, v1pb.NodeType_TEXT}},
+		{markdown: "From $5 to $x$", types: []v1pb.NodeType{v1pb.NodeType_TEXT, v1pb.NodeType_MATH}},
+	}
+	for _, test := range tests {
+		response, err := s.ParseMarkdown(context.Background(), &v1pb.ParseMarkdownRequest{Markdown: test.markdown, Math: true})
+		require.NoError(t, err)
+		types := []v1pb.NodeType{}
+		for _, node := range response.Nodes[0].GetParagraphNode().Children {
+			types = append(types, node.Type)
+		}
+		require.Equal(t, test.types, types, test.markdown)
+	}
+
+	// Without the option, math is text.
+	response, err = s.ParseMarkdown(context.Background(), &v1pb.ParseMarkdownRequest{Markdown: markdown})
+	require.NoError(t, err)
+	require.Equal(t, "Energy $E=mc^2$ holds.", response.Nodes[0].GetParagraphNode().Children[0].GetTextNode().Content)
+	for _, node := range response.Nodes {
+		require.NotEqual(t, v1pb.NodeType_MATH_BLOCK, node.Type)
+	}
+}
+
+func TestGetMarkdownStats(t *testing.T) {
+	tests := []struct {
+		name     string
+		markdown string
+		stats    *v1pb.MarkdownStats
+	}{
+		{
+			name:     "english",
+			markdown: "# Hello **world**\n\nIt's a [well-known](https://usememos.com) fact — see `code`.",
+			stats:    &v1pb.MarkdownStats{WordCount: 8, CharacterCount: 38, ReadingTimeMinutes: 1},
+		},
+		{
+			name:     "cjk",
+			markdown: "## 今天天气很好。\n- こんにちは",
+			stats:    &v1pb.MarkdownStats{WordCount: 11, CharacterCount: 12, ReadingTimeMinutes: 1},
+		},
+		{
+			name:     "mixed",
+			markdown: "我喜欢 Go 语言 and memos 2024",
+			stats:    &v1pb.MarkdownStats{WordCount: 9, CharacterCount: 19, ReadingTimeMinutes: 1},
+		},
+		{
+			name:     "empty",
+			markdown: "---\n",
+			stats:    &v1pb.MarkdownStats{},
+		},
+	}
+
+	s := &APIV1Service{}
+	for _, test := range tests {
+		response, err := s.GetMarkdownStats(context.Background(), &v1pb.GetMarkdownStatsRequest{Markdown: test.markdown})
+		require.NoError(t, err)
+		require.Equal(t, test.stats, response, test.name)
+	}
+
+	// Code blocks are only counted when requested, and the reading time rounds up.
+	markdown := strings.Repeat("word ", 200) + "\n```go\nfmt.Println(\"hi\")\n```"
+	response, err := s.GetMarkdownStats(context.Background(), &v1pb.GetMarkdownStatsRequest{Markdown: markdown})
+	require.NoError(t, err)
+	require.Equal(t, &v1pb.MarkdownStats{WordCount: 200, CharacterCount: 800, ReadingTimeMinutes: 1}, response)
+	response, err = s.GetMarkdownStats(context.Background(), &v1pb.GetMarkdownStatsRequest{Markdown: markdown, IncludeCodeBlocks: true})
+	require.NoError(t, err)
+	require.Equal(t, &v1pb.MarkdownStats{WordCount: 201, CharacterCount: 817, ReadingTimeMinutes: 2}, response)
+}
+
+func TestRenderMarkdownToHTML(t *testing.T) {
+	tests := []struct {
+		markdown string
+		html     string
+	}{
+		{
+			markdown: "# Title\n**bold** and `code` #tag",
+			html:     "<h1 id=\"title\">Title</h1><p><strong>bold</strong> and <code>code</code> <span>#tag</span></p>",
+		},
+		{
+			markdown: "[memos](https://usememos.com) ![logo](/logo.png)",
+			html:     `<p><a href="https://usememos.com" rel="noopener noreferrer nofollow">memos</a> <img src="/logo.png" alt="logo"></p>`,
+		},
+		{
+			markdown: "- [x] done\n- [ ] todo",
+			html:     `<ul><li><input type="checkbox" disabled checked>done</li><li><input type="checkbox" disabled>todo</li></ul>`,
+		},
+	}
+	s := &APIV1Service{}
+	for _, test := range tests {
+		response, err := s.RenderMarkdownToHTML(context.Background(), &v1pb.RenderMarkdownToHTMLRequest{Markdown: test.markdown})
+		require.NoError(t, err)
+		require.Equal(t, test.html, response.Html, test.markdown)
+	}
+}
+
+func TestRenderMarkdownToHTMLNeutralizesXSS(t *testing.T) {
+	payloads := []string{
+		"<script>alert(1)</script>",
+		"<img src=x onerror=alert(1)>",
+		"<svg/onload=alert(1)>",
+		"[click](javascript:alert(1))",
+		"[click](JaVaScRiPt:alert(1))",
+		"[click](java\tscript:alert(1))",
+		"![x](javascript:alert(1))",
+		"[click](data:text/html;base64,PHNjcmlwdD5hbGVydCgxKTwvc2NyaXB0Pg==)",
+		"[x](https://example.com\" onmouseover=\"alert(1))",
+		"`<script>alert(1)</script>`",
+		"```html\n<script>alert(1)</script>\n```",
+		"**<iframe src=javascript:alert(1)>**",
+		"| <b onclick=alert(1)>a</b> |\n| --- |\n| <script>x</script> |",
+	}
+	s := &APIV1Service{}
+	for _, payload := range payloads {
+		response, err := s.RenderMarkdownToHTML(context.Background(), &v1pb.RenderMarkdownToHTMLRequest{Markdown: payload})
+		require.NoError(t, err)
+		output := strings.ToLower(response.Html)
+		// Inline HTML only survives as escaped text.
+		for _, forbidden := range []string{"<script", "<img src=x", "<svg", "<iframe", "<b "} {
+			require.NotContains(t, output, forbidden, payload)
+		}
+		require.NotRegexp(t, `(href|src)="\s*(javascript|data):`, output, payload)
+		require.NotRegexp(t, `<[^>]*\son\w+=`, output, payload)
+	}
+}
+
+func TestStringifyMarkdownNodesWrapWidth(t *testing.T) {
+	tests := []struct {
+		markdown  string
+		mode      v1pb.StringifyMarkdownNodesRequest_Mode
+		wrapWidth int32
+		plainText string
+	}{
+		{
+			markdown:  "The quick brown fox jumps over the lazy dog",
+			mode:      v1pb.StringifyMarkdownNodesRequest_PLAIN_TEXT,
+			wrapWidth: 16,
+			plainText: "The quick brown\nfox jumps over\nthe lazy dog",
+		},
+		{
+			// A URL longer than the width gets a line of its own and is never broken.
+			markdown:  "Read https://example.com/a/very/long/path/to/the/article today",
+			mode:      v1pb.StringifyMarkdownNodesRequest_PLAIN_TEXT,
+			wrapWidth: 20,
+			plainText: "Read\nhttps://example.com/a/very/long/path/to/the/article\ntoday",
+		},
+		{
+			markdown:  "See [the memos docs](https://usememos.com/docs) for more",
+			mode:      v1pb.StringifyMarkdownNodesRequest_GFM,
+			wrapWidth: 20,
+			plainText: "See\n[the memos docs](https://usememos.com/docs)\nfor more",
+		},
+		{
+			// CJK characters take two columns and lines break between them.
+			markdown:  "这是一段很长的中文文本，用于测试换行。",
+			mode:      v1pb.StringifyMarkdownNodesRequest_PLAIN_TEXT,
+			wrapWidth: 10,
+			plainText: "这是一段很\n长的中文文\n本，用于测\n试换行。",
+		},
+		{
+			markdown:  "Memos 是一个开源的笔记服务",
+			mode:      v1pb.StringifyMarkdownNodesRequest_PLAIN_TEXT,
+			wrapWidth: 12,
+			plainText: "Memos 是一个\n开源的笔记服\n务",
+		},
+		{
+			// Code blocks, tables and headings are not wrapped.
+			markdown:  "# A heading that is long\n```\nsome code that is long\n```\n| a long cell | b |\n| --- | --- |\n| 1 | 2 |",
+			mode:      v1pb.StringifyMarkdownNodesRequest_GFM,
+			wrapWidth: 10,
+			plainText: "# A heading that is long\n```\nsome code that is long\n```\n| a long cell | b |\n| --- | --- |\n| 1 | 2 |",
+		},
+		{
+			// A line must not start with a list marker in markdown.
+			markdown:  "Prices go up 5 - 10 percent",
+			mode:      v1pb.StringifyMarkdownNodesRequest_GFM,
+			wrapWidth: 14,
+			plainText: "Prices go up\n5 - 10 percent",
+		},
+	}
+
+	s := newTestMarkdownService(t)
+	for _, test := range tests {
+		parseResponse, err := s.ParseMarkdown(context.Background(), &v1pb.ParseMarkdownRequest{Markdown: test.markdown})
+		require.NoError(t, err)
+		response, err := s.StringifyMarkdownNodes(context.Background(), &v1pb.StringifyMarkdownNodesRequest{
+			Nodes:     parseResponse.Nodes,
+			Mode:      test.mode,
+			WrapWidth: test.wrapWidth,
+		})
+		require.NoError(t, err)
+		require.Equal(t, test.plainText, response.PlainText, test.markdown)
+
+		// The request nodes are left as is.
+		response, err = s.StringifyMarkdownNodes(context.Background(), &v1pb.StringifyMarkdownNodesRequest{
+			Nodes: parseResponse.Nodes,
+			Mode:  v1pb.StringifyMarkdownNodesRequest_GFM,
+		})
+		require.NoError(t, err)
+		require.Equal(t, test.markdown, response.PlainText, test.markdown)
+	}
+
+	_, err := s.StringifyMarkdownNodes(context.Background(), &v1pb.StringifyMarkdownNodesRequest{WrapWidth: -1})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestStringifyMarkdownNodesLinkMode(t *testing.T) {
+	tests := []struct {
+		markdown  string
+		linkMode  v1pb.StringifyMarkdownNodesRequest_LinkMode
+		baseURL   string
+		plainText string
+	}{
+		{
+			markdown:  "[docs](docs/intro.md) ![logo](/img/logo.png)",
+			linkMode:  v1pb.StringifyMarkdownNodesRequest_LINK_MODE_UNSPECIFIED,
+			plainText: "[docs](docs/intro.md) ![logo](/img/logo.png)",
+		},
+		{
+			markdown:  "[docs](docs/intro.md) ![logo](/img/logo.png) [up](../about?lang=en#team)",
+			linkMode:  v1pb.StringifyMarkdownNodesRequest_ABSOLUTIZE,
+			baseURL:   "https://example.com/memos/",
+			plainText: "[docs](https://example.com/memos/docs/intro.md) ![logo](https://example.com/img/logo.png) [up](https://example.com/about?lang=en#team)",
+		},
+		{
+			// Absolute, protocol-relative and fragment-only URLs.
+			markdown:  "[a](https://usememos.com/a) [b](mailto:hi@example.com) [c](//cdn.example.com/c.js) [d](#section)",
+			linkMode:  v1pb.StringifyMarkdownNodesRequest_ABSOLUTIZE,
+			baseURL:   "http://example.com/memos/1",
+			plainText: "[a](https://usememos.com/a) [b](mailto:hi@example.com) [c](http://cdn.example.com/c.js) [d](#section)",
+		},
+		{
+			markdown:  "- [docs](https://example.com/memos/docs/intro.md)\n- ![logo](https://example.com/img/logo.png)\n- [home](https://example.com/memos/?q=1)",
+			linkMode:  v1pb.StringifyMarkdownNodesRequest_RELATIVIZE,
+			baseURL:   "https://example.com/memos/1",
+			plainText: "- [docs](docs/intro.md)\n- ![logo](/img/logo.png)\n- [home](./?q=1)",
+		},
+		{
+			// Protocol-relative URLs on the same host, other hosts and schemes, relative and fragment-only URLs.
+			markdown:  "[a](//example.com/a#top) [b](https://usememos.com/b) [c](http://example.com/c) [d](d.md) [e](#section)",
+			linkMode:  v1pb.StringifyMarkdownNodesRequest_RELATIVIZE,
+			baseURL:   "https://example.com",
+			plainText: "[a](a#top) [b](https://usememos.com/b) [c](http://example.com/c) [d](d.md) [e](#section)",
+		},
+	}
+
+	s := newTestMarkdownService(t)
+	for _, test := range tests {
+		parseResponse, err := s.ParseMarkdown(context.Background(), &v1pb.ParseMarkdownRequest{Markdown: test.markdown})
+		require.NoError(t, err)
+		response, err := s.StringifyMarkdownNodes(context.Background(), &v1pb.StringifyMarkdownNodesRequest{
+			Nodes:    parseResponse.Nodes,
+			Mode:     v1pb.StringifyMarkdownNodesRequest_GFM,
+			LinkMode: test.linkMode,
+			BaseUrl:  test.baseURL,
+		})
+		require.NoError(t, err)
+		require.Equal(t, test.plainText, response.PlainText, test.markdown)
+
+		// The request nodes are left as is.
+		response, err = s.StringifyMarkdownNodes(context.Background(), &v1pb.StringifyMarkdownNodesRequest{
+			Nodes: parseResponse.Nodes,
+			Mode:  v1pb.StringifyMarkdownNodesRequest_GFM,
+		})
+		require.NoError(t, err)
+		require.Equal(t, test.markdown, response.PlainText, test.markdown)
+	}
+
+	for _, baseURL := range []string{"", "/memos/", "example.com"} {
+		_, err := s.StringifyMarkdownNodes(context.Background(), &v1pb.StringifyMarkdownNodesRequest{
+			LinkMode: v1pb.StringifyMarkdownNodesRequest_ABSOLUTIZE,
+			BaseUrl:  baseURL,
+		})
+		require.Equal(t, codes.InvalidArgument, status.Code(err), baseURL)
+	}
+}
+
+func TestParseMarkdownMaxNestingDepth(t *testing.T) {
+	s := newTestMarkdownService(t)
+	getBlockquoteDepth := func(node *v1pb.Node) int {
+		depth := 0
+		for node.GetBlockquoteNode() != nil {
+			depth++
+			node = node.GetBlockquoteNode().Children[0]
+		}
+		return depth
+	}
+
+	// A thousand nested blockquotes are cut at the default depth without panicking.
+	markdown := strings.Repeat("> ", 1000) + "deep"
+	response, err := s.ParseMarkdown(context.Background(), &v1pb.ParseMarkdownRequest{Markdown: markdown})
+	require.NoError(t, err)
+	require.True(t, response.Truncated)
+	require.Len(t, response.Nodes, 1)
+	require.Equal(t, defaultMaxNestingDepth, getBlockquoteDepth(response.Nodes[0]))
+	stringifyResponse, err := s.StringifyMarkdownNodes(context.Background(), &v1pb.StringifyMarkdownNodesRequest{Nodes: response.Nodes, Mode: v1pb.StringifyMarkdownNodesRequest_GFM})
+	require.NoError(t, err)
+	require.Equal(t, markdown, stringifyResponse.PlainText)
+
+	response, err = s.ParseMarkdown(context.Background(), &v1pb.ParseMarkdownRequest{Markdown: "> > > quote\n> > > more", MaxNestingDepth: 2})
+	require.NoError(t, err)
+	require.True(t, response.Truncated)
+	require.Equal(t, 2, getBlockquoteDepth(response.Nodes[0]))
+
+	response, err = s.ParseMarkdown(context.Background(), &v1pb.ParseMarkdownRequest{Markdown: "> > quote", MaxNestingDepth: 2})
+	require.NoError(t, err)
+	require.False(t, response.Truncated)
+	require.Equal(t, 2, getBlockquoteDepth(response.Nodes[0]))
+
+	// gomark only nests quote markers followed by a space, so this is a paragraph.
+	response, err = s.ParseMarkdown(context.Background(), &v1pb.ParseMarkdownRequest{Markdown: strings.Repeat(">", 1000)})
+	require.NoError(t, err)
+	require.False(t, response.Truncated)
+
+	// List items nested deeper than the max depth are moved up into the innermost allowed list.
+	lines := []string{}
+	for i := 0; i < 6; i++ {
+		lines = append(lines, strings.Repeat("  ", i)+fmt.Sprintf("- item %d", i))
+	}
+	markdown = strings.Join(lines, "\n")
+	response, err = s.ParseMarkdown(context.Background(), &v1pb.ParseMarkdownRequest{Markdown: markdown, MaxNestingDepth: 3})
+	require.NoError(t, err)
+	require.True(t, response.Truncated)
+	getListItemCount := func(list *v1pb.ListNode) (int, *v1pb.ListNode) {
+		count, nestedList := 0, (*v1pb.ListNode)(nil)
+		for _, child := range list.Children {
+			if child.GetUnorderedListItemNode() != nil {
+				count++
+			} else if child.GetListNode() != nil {
+				nestedList = child.GetListNode()
+			}
+		}
+		return count, nestedList
+	}
+	list := response.Nodes[0].GetListNode()
+	for depth := 1; depth < 3; depth++ {
+		count, nestedList := getListItemCount(list)
+		require.Equal(t, 1, count)
+		list = nestedList
+	}
+	count, nestedList := getListItemCount(list)
+	require.Equal(t, 4, count)
+	require.Nil(t, nestedList)
+	stringifyResponse, err = s.StringifyMarkdownNodes(context.Background(), &v1pb.StringifyMarkdownNodesRequest{Nodes: response.Nodes, Mode: v1pb.StringifyMarkdownNodesRequest_GFM})
+	require.NoError(t, err)
+	require.Equal(t, markdown, stringifyResponse.PlainText)
+
+	_, err = s.ParseMarkdown(context.Background(), &v1pb.ParseMarkdownRequest{MaxNestingDepth: -1})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestParseMarkdownEmoji(t *testing.T) {
+	tests := []struct {
+		markdown string
+		// The shortcodes of the expanded emojis.
+		shortcodes []string
+		// The content stringified with emoji_unicode.
+		unicode string
+	}{
+		{
+			markdown:   "Good job :+1: :smile::tada:",
+			shortcodes: []string{"+1", "smile", "tada"},
+			unicode:    "Good job 👍 😄🎉",
+		},
+		{
+			// Unknown shortcodes and shortcodes next to words stay text.
+			markdown: ":notanemoji: :foo:bar: :smile:bar: a:smile: 10:30:00",
+			unicode:  ":notanemoji: :foo:bar: :smile:bar: a:smile: 10:30:00",
+		},
+		{
+			markdown: "`:smile:`",
+			unicode:  "`:smile:`",
+		},
+		{
+			markdown:   "- **:heart: it**\n> (:rocket:)",
+			shortcodes: []string{"heart", "rocket"},
+			unicode:    "- **❤️ it**\n> (🚀)",
+		},
+	}
+
+	s := newTestMarkdownService(t)
+	for _, test := range tests {
+		response, err := s.ParseMarkdown(context.Background(), &v1pb.ParseMarkdownRequest{Markdown: test.markdown, Emoji: true})
+		require.NoError(t, err)
+		shortcodes := []string{}
+		var collect func(nodes []*v1pb.Node)
+		collect = func(nodes []*v1pb.Node) {
+			for _, node := range nodes {
+				if n, ok := node.Node.(*v1pb.Node_EmojiNode); ok {
+					require.Equal(t, v1pb.NodeType_EMOJI, node.Type)
+					require.Equal(t, emojiShortcodes[n.EmojiNode.Shortcode], n.EmojiNode.Unicode)
+					shortcodes = append(shortcodes, n.EmojiNode.Shortcode)
+				}
+				collect(getNodeChildren(node))
+			}
+		}
+		collect(response.Nodes)
+		if test.shortcodes == nil {
+			test.shortcodes = []string{}
+		}
+		require.Equal(t, test.shortcodes, shortcodes, test.markdown)
+
+		// The shortcodes are stringified by default, and the unicode on request.
+		stringifyResponse, err := s.StringifyMarkdownNodes(context.Background(), &v1pb.StringifyMarkdownNodesRequest{Nodes: response.Nodes, Mode: v1pb.StringifyMarkdownNodesRequest_GFM})
+		require.NoError(t, err)
+		require.Equal(t, test.markdown, stringifyResponse.PlainText)
+		stringifyResponse, err = s.StringifyMarkdownNodes(context.Background(), &v1pb.StringifyMarkdownNodesRequest{Nodes: response.Nodes, Mode: v1pb.StringifyMarkdownNodesRequest_GFM, EmojiUnicode: true})
+		require.NoError(t, err)
+		require.Equal(t, test.unicode, stringifyResponse.PlainText)
+	}
+
+	// Shortcodes are not expanded without the option.
+	response, err := s.ParseMarkdown(context.Background(), &v1pb.ParseMarkdownRequest{Markdown: ":smile:"})
+	require.NoError(t, err)
+	require.Equal(t, ":smile:", response.Nodes[0].GetParagraphNode().Children[0].GetTextNode().Content)
+}
+
+func TestParseMarkdownImages(t *testing.T) {
+	image := func(url, altText string, isRelative bool) *v1pb.ImageReference {
+		return &v1pb.ImageReference{Url: url, AltText: altText, IsRelative: isRelative}
+	}
+	tests := []struct {
+		markdown string
+		images   []*v1pb.ImageReference
+	}{
+		{
+			markdown: "![cat](https://example.com/cat.png) and ![](assets/dog.jpg)",
+			images: []*v1pb.ImageReference{
+				image("https://example.com/cat.png", "cat", false),
+				image("assets/dog.jpg", "", true),
+			},
+		},
+		{
+			markdown: "# ![logo](/logo.svg)\n- ![a](//cdn.example.com/a.png)\n> ![b](data:image/png;base64,iVBORw0KGgo=)",
+			images: []*v1pb.ImageReference{
+				image("/logo.svg", "logo", true),
+				image("//cdn.example.com/a.png", "a", false),
+				image("data:image/png;base64,iVBORw0KGgo=", "b", false),
+			},
+		},
+		{
+			markdown: "[![badge](https://example.com/badge.svg)](https://example.com)",
+			images: []*v1pb.ImageReference{
+				image("https://example.com/badge.svg", "badge", false),
+			},
+		},
+		{
+			markdown: "| a | b |\n| --- | --- |\n| ![x](x.png) | ![y](https://example.com/y.png) |",
+			images: []*v1pb.ImageReference{
+				image("x.png", "x", true),
+				image("https://example.com/y.png", "y", false),
+			},
+		},
+		{
+			markdown: "```\n![code](code.png)\n```\n`![span](span.png)`",
+			images:   []*v1pb.ImageReference{},
+		},
+	}
+
+	s := newTestMarkdownService(t)
+	for _, test := range tests {
+		response, err := s.ParseMarkdown(context.Background(), &v1pb.ParseMarkdownRequest{Markdown: test.markdown, IncludeImages: true})
+		require.NoError(t, err)
+		require.Len(t, response.Images, len(test.images), test.markdown)
+		for i, image := range test.images {
+			require.True(t, proto.Equal(image, response.Images[i]), "%s: %v", test.markdown, response.Images[i])
+		}
+	}
+
+	// The images are not returned without the option.
+	response, err := s.ParseMarkdown(context.Background(), &v1pb.ParseMarkdownRequest{Markdown: "![cat](cat.png)"})
+	require.NoError(t, err)
+	require.Empty(t, response.Images)
+}
+
+func TestGetLinkMetadataLanguage(t *testing.T) {
+	ctx := context.Background()
+	ts := teststore.NewTestingStore(ctx, t)
+	defer ts.Close()
+	_, err := ts.UpsertWorkspaceSetting(ctx, &storepb.WorkspaceSetting{
+		Key: storepb.WorkspaceSettingKey_MEMO_RELATED,
+		Value: &storepb.WorkspaceSetting_MemoRelatedSetting{
+			MemoRelatedSetting: &storepb.WorkspaceMemoRelatedSetting{
+				LinkMetadataAllowInternalIps: true,
+				LinkMetadataAcceptLanguage:   "fr-FR",
+			},
+		},
+	})
+	require.NoError(t, err)
+	user, err := ts.CreateUser(ctx, &store.User{Username: "test", Role: store.RoleHost, Email: "test@test.com"})
+	require.NoError(t, err)
+	_, err = ts.UpsertUserSetting(ctx, &storepb.UserSetting{
+		UserId: user.ID,
+		Key:    storepb.UserSettingKey_LOCALE,
+		Value:  &storepb.UserSetting_Locale{Locale: "zh-Hans"},
+	})
+	require.NoError(t, err)
+
+	titles := map[string]string{"de-DE": "Hallo", "fr-FR": "Bonjour", "zh-Hans": "你好"}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		title, ok := titles[r.Header.Get("Accept-Language")]
+		if !ok {
+			title = "Hello"
+		}
+		w.Header().Set("Content-Type", "text/html")
+		_, _ = w.Write([]byte(`<html><head><title>` + title + `</title></head></html>`))
+	}))
+	defer server.Close()
+
+	s := &APIV1Service{
+		Store:                   ts,
+		LinkMetadataRateLimiter: NewMemoryRateLimiter(),
+		linkMetadataCache:       httpgetter.NewHTMLMetaCache(httpgetter.DefaultHTMLMetaCacheMaxEntries),
+	}
+	userCtx := context.WithValue(ctx, usernameContextKey, user.Username)
+	tests := []struct {
+		ctx      context.Context
+		language string
+		want     string
+	}{
+		{ctx: ctx, language: "de-DE", want: "Hallo"},
+		// Tags are canonicalized.
+		{ctx: ctx, language: "de-de", want: "Hallo"},
+		// Invalid tags fall back to the workspace setting for anonymous users.
+		{ctx: ctx, language: "not a tag", want: "Bonjour"},
+		{ctx: ctx, want: "Bonjour"},
+		// The locale of the user comes before the workspace setting.
+		{ctx: userCtx, want: "你好"},
+		{ctx: userCtx, language: "de-DE", want: "Hallo"},
+	}
+	for _, test := range tests {
+		linkMetadata, err := s.GetLinkMetadata(test.ctx, &v1pb.GetLinkMetadataRequest{Link: server.URL, Language: test.language})
+		require.NoError(t, err, test.language)
+		require.Equal(t, test.want, linkMetadata.Title, test.language)
+	}
+}
+
+func TestMarkdownExtension(t *testing.T) {
+	s := newTestMarkdownService(t)
+	require.NoError(t, s.RegisterMarkdownExtension(&MarkdownExtension{
+		Name:    "ticket",
+		Pattern: regexp.MustCompile(`#ticket-(\d+)`),
+		NewNode: func(submatches []string) (map[string]string, bool) {
+			// Ticket 0 does not exist.
+			return map[string]string{"id": submatches[1]}, submatches[1] != "0"
+		},
+	}))
+	// Registered later, so the ticket extension wins where both match.
+	require.NoError(t, s.RegisterMarkdownExtension(&MarkdownExtension{
+		Name:    "issue",
+		Pattern: regexp.MustCompile(`#ticket-\d+|#tick|issue-(\d+)`),
+		NewNode: func(submatches []string) (map[string]string, bool) {
+			return map[string]string{"id": submatches[1]}, true
+		},
+	}))
+	require.ErrorContains(t, s.RegisterMarkdownExtension(&MarkdownExtension{Name: "ticket", Pattern: regexp.MustCompile(`x`), NewNode: func([]string) (map[string]string, bool) { return nil, true }}), "already registered")
+	require.ErrorContains(t, s.RegisterMarkdownExtension(&MarkdownExtension{Pattern: regexp.MustCompile(`x`)}), "name is required")
+
+	markdown := "See #ticket-123 and #tag, not `#ticket-9` or #ticket-0, but **#ticket-4**, issue-7 and #tickle.\n\n# Heading #ticket-5"
+	parseResponse, err := s.ParseMarkdown(context.Background(), &v1pb.ParseMarkdownRequest{Markdown: markdown})
+	require.NoError(t, err)
+	customNodes := []*v1pb.CustomNode{}
+	var collect func(nodes []*v1pb.Node)
+	collect = func(nodes []*v1pb.Node) {
+		for _, node := range nodes {
+			if custom := node.GetCustomNode(); custom != nil {
+				require.Equal(t, v1pb.NodeType_CUSTOM, node.Type)
+				customNodes = append(customNodes, custom)
+			}
+			collect(getNodeChildren(node))
+		}
+	}
+	collect(parseResponse.Nodes)
+	require.Len(t, customNodes, 5)
+	for i, want := range []*v1pb.CustomNode{
+		{Extension: "ticket", Raw: "#ticket-123", Attributes: map[string]string{"id": "123"}},
+		// The match the ticket extension rejects falls to the next one.
+		{Extension: "issue", Raw: "#ticket-0", Attributes: map[string]string{"id": ""}},
+		{Extension: "ticket", Raw: "#ticket-4", Attributes: map[string]string{"id": "4"}},
+		{Extension: "issue", Raw: "issue-7", Attributes: map[string]string{"id": "7"}},
+		{Extension: "ticket", Raw: "#ticket-5", Attributes: map[string]string{"id": "5"}},
+	} {
+		require.True(t, proto.Equal(want, customNodes[i]), "%d: %v", i, customNodes[i])
+	}
+	// Tags taken by an extension are no longer tags, and "#tick" would cut the tag "tickle" in two.
+	require.Equal(t, []string{"tag", "tickle"}, parseResponse.Tags)
+
+	restoreResponse, err := s.RestoreMarkdownNodes(context.Background(), &v1pb.RestoreMarkdownNodesRequest{Nodes: parseResponse.Nodes, Strict: true})
+	require.NoError(t, err)
+	require.Equal(t, markdown, restoreResponse.Markdown)
+	for _, mode := range []v1pb.StringifyMarkdownNodesRequest_Mode{v1pb.StringifyMarkdownNodesRequest_GFM, v1pb.StringifyMarkdownNodesRequest_COMMONMARK} {
+		stringifyResponse, err := s.StringifyMarkdownNodes(context.Background(), &v1pb.StringifyMarkdownNodesRequest{Nodes: parseResponse.Nodes, Mode: mode})
+		require.NoError(t, err)
+		require.Equal(t, markdown, stringifyResponse.PlainText, mode)
+	}
+	stringifyResponse, err := s.StringifyMarkdownNodes(context.Background(), &v1pb.StringifyMarkdownNodesRequest{Nodes: parseResponse.Nodes, Mode: v1pb.StringifyMarkdownNodesRequest_PLAIN_TEXT})
+	require.NoError(t, err)
+	require.Contains(t, stringifyResponse.PlainText, "See #ticket-123 and #tag")
+
+	// Without extensions, the markdown parses as before.
+	parseResponse, err = newTestMarkdownService(t).ParseMarkdown(context.Background(), &v1pb.ParseMarkdownRequest{Markdown: markdown})
+	require.NoError(t, err)
+	require.Contains(t, parseResponse.Tags, "ticket-123")
+}
+
+func TestDiffMarkdownNodes(t *testing.T) {
+	describe := func(diff *v1pb.MarkdownNodeDiff) string {
+		restore := func(node *v1pb.Node) string {
+			if node == nil {
+				return ""
+			}
+			return restoreMarkdownNodes([]*v1pb.Node{node}, false)
+		}
+		return fmt.Sprintf("%s %v %v %q %q", diff.Type, diff.OldPath, diff.NewPath, restore(diff.OldNode), restore(diff.NewNode))
+	}
+	tests := []struct {
+		name        string
+		oldMarkdown string
+		newMarkdown string
+		diffs       []string
+	}{
+		{
+			name:        "added paragraph",
+			oldMarkdown: "first\n\nthird",
+			newMarkdown: "first\n\nsecond\n\nthird",
+			diffs:       []string{`ADDED [] [3] "" "second"`},
+		},
+		{
+			name:        "deleted list item",
+			oldMarkdown: "# Todo\n\n- milk\n- eggs\n- bread",
+			newMarkdown: "# Todo\n\n- milk\n- bread",
+			diffs:       []string{`REMOVED [3 2] [] "- eggs" ""`},
+		},
+		{
+			name:        "edited heading",
+			oldMarkdown: "# Draft\n\nbody",
+			newMarkdown: "# Final\n\nbody",
+			diffs:       []string{`CHANGED [0] [0] "# Draft" "# Final"`},
+		},
+		{
+			name:        "edited list item",
+			oldMarkdown: "- [ ] milk\n- [ ] eggs",
+			newMarkdown: "- [ ] milk\n- [x] eggs",
+			diffs:       []string{`CHANGED [0 2] [0 2] "- [ ] eggs" "- [x] eggs"`},
+		},
+		{
+			name:        "replaced block",
+			oldMarkdown: "intro\n\n> quote",
+			newMarkdown: "intro\n\n```\ncode\n```\n\nmore",
+			diffs: []string{
+				`REMOVED [3] [] "> quote" ""`,
+				"ADDED [] [3] \"\" \"```\\ncode\\n```\"",
+				`ADDED [] [6] "" "more"`,
+			},
+		},
+		{
+			name:        "added heading before edited paragraph",
+			oldMarkdown: "one\n\ntwo",
+			newMarkdown: "one\n\n## Section\n\ntwo!",
+			diffs: []string{
+				`ADDED [] [3] "" "## Section"`,
+				`CHANGED [3] [6] "two" "two!"`,
+			},
+		},
+		{
+			name:        "unchanged",
+			oldMarkdown: "same\n\n- list",
+			newMarkdown: "same\n\n- list",
+			diffs:       []string{},
+		},
+	}
+
+	s := newTestMarkdownService(t)
+	for _, test := range tests {
+		response, err := s.DiffMarkdownNodes(context.Background(), &v1pb.DiffMarkdownNodesRequest{OldMarkdown: test.oldMarkdown, NewMarkdown: test.newMarkdown})
+		require.NoError(t, err, test.name)
+		diffs := []string{}
+		for _, diff := range response.Diffs {
+			diffs = append(diffs, describe(diff))
+		}
+		require.Equal(t, test.diffs, diffs, test.name)
+	}
+
+	// Given nodes are compared by their markdown, so their positions do not matter.
+	parsed, err := s.ParseMarkdown(context.Background(), &v1pb.ParseMarkdownRequest{Markdown: "# Draft\n\nbody", IncludePositions: true})
+	require.NoError(t, err)
+	response, err := s.DiffMarkdownNodes(context.Background(), &v1pb.DiffMarkdownNodesRequest{OldNodes: parsed.Nodes, NewMarkdown: "# Final\n\nbody"})
+	require.NoError(t, err)
+	require.Len(t, response.Diffs, 1)
+	require.Equal(t, v1pb.MarkdownNodeDiff_CHANGED, response.Diffs[0].Type)
+	require.True(t, proto.Equal(parsed.Nodes[0], response.Diffs[0].OldNode))
+}
+
+func TestStringifyMarkdownNodesTableOfContents(t *testing.T) {
+	tests := []struct {
+		name     string
+		markdown string
+		toc      string
+	}{
+		{
+			name:     "nested headings",
+			markdown: "# Guide\n\n## Install **now**\n\n### On Linux\n\n## Usage\n\n# FAQ",
+			toc:      "- [Guide](#guide)\n  - [Install now](#install-now)\n    - [On Linux](#on-linux)\n  - [Usage](#usage)\n- [FAQ](#faq)",
+		},
+		{
+			name:     "duplicate headings",
+			markdown: "## Notes\n\n## Notes\n\n## notes!\n\n## Notes 1",
+			toc:      "- [Notes](#notes)\n- [Notes](#notes-1)\n- [notes!](#notes-2)\n- [Notes 1](#notes-1-1)",
+		},
+		{
+			name:     "CJK headings",
+			markdown: "# 你好，世界\n\n## 使用 说明\n\n## 使用 说明",
+			toc:      "- [你好，世界](#你好世界)\n  - [使用 说明](#使用-说明)\n  - [使用 说明](#使用-说明-1)",
+		},
+		{
+			name:     "skipped levels and special characters",
+			markdown: "### [Links] & `code`\n\n# !!!\n\n> # Quoted",
+			toc:      "- [\\[Links\\] & code](#links--code)\n- [!!!](#section)",
+		},
+		{
+			name:     "no headings",
+			markdown: "text",
+			toc:      "",
+		},
+	}
+
+	s := newTestMarkdownService(t)
+	for _, test := range tests {
+		parsed, err := s.ParseMarkdown(context.Background(), &v1pb.ParseMarkdownRequest{Markdown: test.markdown})
+		require.NoError(t, err)
+		response, err := s.StringifyMarkdownNodes(context.Background(), &v1pb.StringifyMarkdownNodesRequest{
+			Nodes: parsed.Nodes,
+			Mode:  v1pb.StringifyMarkdownNodesRequest_TABLE_OF_CONTENTS,
+		})
+		require.NoError(t, err)
+		require.Equal(t, test.toc, response.PlainText, test.name)
+
+		// The anchors are the ids of the headings in the HTML.
+		rendered, err := s.RenderMarkdownToHTML(context.Background(), &v1pb.RenderMarkdownToHTMLRequest{Markdown: test.markdown})
+		require.NoError(t, err)
+		var checkAnchors func(entries []*v1pb.TableOfContentsEntry)
+		checkAnchors = func(entries []*v1pb.TableOfContentsEntry) {
+			for _, entry := range entries {
+				require.Contains(t, rendered.Html, fmt.Sprintf(`<h%d id="%s">`, entry.Level, entry.Anchor), test.name)
+				checkAnchors(entry.Children)
+			}
+		}
+		checkAnchors(response.TableOfContents)
+	}
+
+	parsed, err := s.ParseMarkdown(context.Background(), &v1pb.ParseMarkdownRequest{Markdown: "# A\n\n### B\n\n## C"})
+	require.NoError(t, err)
+	response, err := s.StringifyMarkdownNodes(context.Background(), &v1pb.StringifyMarkdownNodesRequest{
+		Nodes: parsed.Nodes,
+		Mode:  v1pb.StringifyMarkdownNodesRequest_TABLE_OF_CONTENTS,
+	})
+	require.NoError(t, err)
+	require.Len(t, response.TableOfContents, 1)
+	entry := response.TableOfContents[0]
+	require.Equal(t, "A", entry.Title)
+	require.Equal(t, int32(1), entry.Level)
+	require.Len(t, entry.Children, 2)
+	require.Equal(t, "b", entry.Children[0].Anchor)
+	require.Equal(t, int32(3), entry.Children[0].Level)
+	require.Equal(t, "c", entry.Children[1].Anchor)
+
+	// Other modes leave the structure out.
+	response, err = s.StringifyMarkdownNodes(context.Background(), &v1pb.StringifyMarkdownNodesRequest{Nodes: parsed.Nodes})
+	require.NoError(t, err)
+	require.Empty(t, response.TableOfContents)
+}
+
+func TestParseMarkdownRawHTML(t *testing.T) {
+	s := newTestMarkdownService(t)
+	textNode := func(content string) *v1pb.Node {
+		return &v1pb.Node{Type: v1pb.NodeType_TEXT, Node: &v1pb.Node_TextNode{TextNode: &v1pb.TextNode{Content: content}}}
+	}
+	autoLinkNode := func(url string) *v1pb.Node {
+		return &v1pb.Node{Type: v1pb.NodeType_AUTO_LINK, Node: &v1pb.Node_AutoLinkNode{AutoLinkNode: &v1pb.AutoLinkNode{Url: url}}}
+	}
+	htmlElementNode := &v1pb.Node{Type: v1pb.NodeType_HTML_ELEMENT, Node: &v1pb.Node_HtmlElementNode{HtmlElementNode: &v1pb.HTMLElementNode{TagName: "br", Attributes: map[string]string{}}}}
+	tests := []struct {
+		markdown string
+		mode     v1pb.ParseMarkdownRequest_RawHTMLMode
+		// The children of the paragraph the markdown is parsed into.
+		children []*v1pb.Node
+		// The content stringified as plain text.
+		plainText string
+	}{
+		{
+			markdown:  "<img src=x onerror=alert(1)>",
+			mode:      v1pb.ParseMarkdownRequest_ALLOW,
+			children:  []*v1pb.Node{autoLinkNode("img src=x onerror=alert(1)")},
+			plainText: "img src=x onerror=alert(1)",
+		},
+		{
+			markdown:  "<img src=x onerror=alert(1)>",
+			mode:      v1pb.ParseMarkdownRequest_ESCAPE,
+			children:  []*v1pb.Node{textNode("<img src=x onerror=alert(1)>")},
+			plainText: "<img src=x onerror=alert(1)>",
+		},
+		{
+			// Raw HTML is escaped by default.
+			markdown:  "<img src=x onerror=alert(1)>",
+			children:  []*v1pb.Node{textNode("<img src=x onerror=alert(1)>")},
+			plainText: "<img src=x onerror=alert(1)>",
+		},
+		{
+			markdown:  "<img src=x onerror=alert(1)>",
+			mode:      v1pb.ParseMarkdownRequest_STRIP,
+			children:  nil,
+			plainText: "",
+		},
+		{
+			markdown:  "a<br />b <https://usememos.com>",
+			mode:      v1pb.ParseMarkdownRequest_ALLOW,
+			children:  []*v1pb.Node{textNode("a"), htmlElementNode, textNode("b "), autoLinkNode("https://usememos.com")},
+			plainText: "a\nb https://usememos.com",
+		},
+		{
+			markdown:  "a<br />b <https://usememos.com>",
+			mode:      v1pb.ParseMarkdownRequest_ESCAPE,
+			children:  []*v1pb.Node{textNode("a<br />b "), autoLinkNode("https://usememos.com")},
+			plainText: "a<br />b https://usememos.com",
+		},
+		{
+			markdown:  "a<br />b <https://usememos.com> <memos@usememos.com>",
+			mode:      v1pb.ParseMarkdownRequest_STRIP,
+			children:  []*v1pb.Node{textNode("ab "), autoLinkNode("https://usememos.com"), textNode(" "), autoLinkNode("memos@usememos.com")},
+			plainText: "ab https://usememos.com memos@usememos.com",
+		},
+		{
+			markdown:  "**<b>bold</b>** `<img>`",
+			mode:      v1pb.ParseMarkdownRequest_STRIP,
+			children:  []*v1pb.Node{{Type: v1pb.NodeType_BOLD, Node: &v1pb.Node_BoldNode{BoldNode: &v1pb.BoldNode{Symbol: "*", Children: []*v1pb.Node{textNode("bold")}}}}, textNode(" "), {Type: v1pb.NodeType_CODE, Node: &v1pb.Node_CodeNode{CodeNode: &v1pb.CodeNode{Content: "<img>"}}}},
+			plainText: "bold <img>",
+		},
+	}
+	for _, test := range tests {
+		name := fmt.Sprintf("%s (%v)", test.markdown, test.mode)
+		response, err := s.ParseMarkdown(context.Background(), &v1pb.ParseMarkdownRequest{Markdown: test.markdown, RawHtmlMode: test.mode})
+		require.NoError(t, err, name)
+		require.Len(t, response.Nodes, 1, name)
+		children := response.Nodes[0].GetParagraphNode().GetChildren()
+		require.Len(t, children, len(test.children), name)
+		for i, child := range test.children {
+			require.True(t, proto.Equal(child, children[i]), "%s: %v", name, children[i])
+		}
+		stringifyResponse, err := s.StringifyMarkdownNodes(context.Background(), &v1pb.StringifyMarkdownNodesRequest{
+			Nodes: response.Nodes,
+			Mode:  v1pb.StringifyMarkdownNodesRequest_PLAIN_TEXT,
+		})
+		require.NoError(t, err, name)
+		require.Equal(t, test.plainText, stringifyResponse.PlainText, name)
+	}
+}
+
+func TestParseMarkdownTagFormatting(t *testing.T) {
+	ctx := context.Background()
+	ts := teststore.NewTestingStore(ctx, t)
+	defer ts.Close()
+	user, err := ts.CreateUser(ctx, &store.User{Username: "test", Role: store.RoleHost, Email: "test@test.com"})
+	require.NoError(t, err)
+	s := &APIV1Service{Store: ts}
+
+	// The tags extracted from the markdown and the tags written directly are stored alike.
+	markdown := "#Work #WORK #work//notes- #work__log"
+	directTags := []string{" Work ", "WORK", "work//notes-", "work__log", "  "}
+	tests := []struct {
+		lowercase bool
+		want      []string
+	}{
+		{lowercase: false, want: []string{"Work", "WORK", "work/notes", "work_log"}},
+		{lowercase: true, want: []string{"work", "work/notes", "work_log"}},
+	}
+	for i, test := range tests {
+		_, err := ts.UpsertWorkspaceSetting(ctx, &storepb.WorkspaceSetting{
+			Key: storepb.WorkspaceSettingKey_MEMO_RELATED,
+			Value: &storepb.WorkspaceSetting_MemoRelatedSetting{
+				MemoRelatedSetting: &storepb.WorkspaceMemoRelatedSetting{TagLowercase: test.lowercase},
+			},
+		})
+		require.NoError(t, err)
+
+		response, err := s.ParseMarkdown(ctx, &v1pb.ParseMarkdownRequest{Markdown: markdown})
+		require.NoError(t, err)
+		require.Equal(t, test.want, response.Tags)
+
+		extracted := &store.Memo{UID: fmt.Sprintf("extracted-%d", i), CreatorID: user.ID, Content: markdown, Visibility: store.Private}
+		require.NoError(t, memopayload.RebuildMemoPayload(extracted))
+		direct := &store.Memo{UID: fmt.Sprintf("direct-%d", i), CreatorID: user.ID, Visibility: store.Private, Payload: &storepb.MemoPayload{Tags: directTags}}
+		for _, memo := range []*store.Memo{extracted, direct} {
+			created, err := ts.CreateMemo(ctx, memo)
+			require.NoError(t, err)
+			stored, err := ts.GetMemo(ctx, &store.FindMemo{ID: &created.ID})
+			require.NoError(t, err)
+			require.Equal(t, test.want, stored.Payload.Tags, memo.UID)
+		}
+	}
+}
+
+// newTestMarkdownService returns a service with a testing store, whose workspace settings
+// ParseMarkdown reads.
+func newTestMarkdownService(t *testing.T) *APIV1Service {
+	ctx := context.Background()
+	ts := teststore.NewTestingStore(ctx, t)
+	t.Cleanup(func() {
+		ts.Close()
+	})
+	return &APIV1Service{Store: ts}
+}
+
+func TestLintMarkdown(t *testing.T) {
+	s := &APIV1Service{}
+	type diagnostic struct {
+		severity  v1pb.MarkdownDiagnostic_Severity
+		message   string
+		startLine int32
+		source    string
+	}
+	tests := []struct {
+		markdown string
+		want     []diagnostic
+	}{
+		{
+			markdown: "# Title\n\n```go\nfmt.Println(\"hi\")",
+			want:     []diagnostic{{v1pb.MarkdownDiagnostic_ERROR, "code fence is not closed", 3, "```go"}},
+		},
+		{
+			markdown: "| a | b |\n| - | - |\n| 1 | 2 | 3 |\n| 4 | 5 |\n| 6 |",
+			want: []diagnostic{
+				{v1pb.MarkdownDiagnostic_ERROR, "table row has 3 cells, but the header has 2", 3, "| 1 | 2 | 3 |"},
+				{v1pb.MarkdownDiagnostic_ERROR, "table row has 1 cells, but the header has 2", 5, "| 6 |"},
+			},
+		},
+		{
+			markdown: "| a | b |\n| - |\n| 1 | 2 |",
+			want:     []diagnostic{{v1pb.MarkdownDiagnostic_ERROR, "table delimiter row has 1 cells, but the header has 2", 2, "| - |"}},
+		},
+		{
+			markdown: "see [memos](https://usememos.com and ![logo](logo.png\n\n`[code](`",
+			want: []diagnostic{
+				{v1pb.MarkdownDiagnostic_WARNING, "link syntax is malformed and rendered as text", 1, "[memos]("},
+				{v1pb.MarkdownDiagnostic_WARNING, "link syntax is malformed and rendered as text", 1, "![logo]("},
+			},
+		},
+		// Well-formed markdown has no diagnostics.
+		{
+			markdown: "```go\ncode\n```\n\n| a | b |\n| - | - |\n| 1 | 2 |\n\n[memos](https://usememos.com) `a | b`",
+			want:     []diagnostic{},
+		},
+	}
+	for _, test := range tests {
+		response, err := s.LintMarkdown(context.Background(), &v1pb.LintMarkdownRequest{Markdown: test.markdown})
+		require.NoError(t, err)
+		diagnostics := []diagnostic{}
+		for _, d := range response.Diagnostics {
+			diagnostics = append(diagnostics, diagnostic{d.Severity, d.Message, d.Position.StartLine, test.markdown[d.Position.Start:d.Position.End]})
+		}
+		require.Equal(t, test.want, diagnostics, test.markdown)
+	}
+}
+
+func TestParseMarkdownFootnotes(t *testing.T) {
+	tests := []struct {
+		markdown string
+		// The footnote and superscript nodes in document order, e.g. "ref 1 #1" for a reference to
+		// the footnote labeled 1 numbered 1, and "def 1 #1 duplicate" for a duplicate definition.
+		footnotes []string
+	}{
+		{
+			markdown:  "Text[^1] and more[^note].\n\n[^note]: Second, with **bold**.\n[^1]: First[^note].",
+			footnotes: []string{"ref 1 #1", "ref note #2", "def note #2", "def 1 #1", "ref note #2"},
+		},
+		{
+			// An undefined reference stays text, and an unreferenced definition has no number.
+			markdown:  "See[^missing] here.\n\n[^unused]: Never referenced.",
+			footnotes: []string{"def unused #0"},
+		},
+		{
+			// References link to the first of duplicate definitions.
+			markdown:  "A[^1] b[^1]\n\n[^1]: First.\n[^1]: Second.",
+			footnotes: []string{"ref 1 #1", "ref 1 #1", "def 1 #1", "def 1 #0 duplicate"},
+		},
+		{
+			// Superscript around references is kept.
+			markdown:  "x^2^ y[^a] z[^b] w^3^\n[^a]: A.\n[^b]:",
+			footnotes: []string{"sup 2", "ref a #1", "ref b #2", "sup 3", "def a #1", "def b #2"},
+		},
+	}
+
+	s := newTestMarkdownService(t)
+	for _, test := range tests {
+		response, err := s.ParseMarkdown(context.Background(), &v1pb.ParseMarkdownRequest{Markdown: test.markdown, Footnotes: true, IncludePositions: true})
+		require.NoError(t, err)
+		footnotes := []string{}
+		var collect func(nodes []*v1pb.Node)
+		collect = func(nodes []*v1pb.Node) {
+			for _, node := range nodes {
+				switch n := node.Node.(type) {
+				case *v1pb.Node_FootnoteRefNode:
+					require.Equal(t, v1pb.NodeType_FOOTNOTE_REF, node.Type)
+					require.Equal(t, "[^"+n.FootnoteRefNode.Label+"]", test.markdown[node.Position.Start:node.Position.End])
+					footnotes = append(footnotes, fmt.Sprintf("ref %s #%d", n.FootnoteRefNode.Label, n.FootnoteRefNode.Number))
+				case *v1pb.Node_FootnoteDefNode:
+					require.Equal(t, v1pb.NodeType_FOOTNOTE_DEF, node.Type)
+					footnote := fmt.Sprintf("def %s #%d", n.FootnoteDefNode.Label, n.FootnoteDefNode.Number)
+					if n.FootnoteDefNode.Duplicate {
+						footnote += " duplicate"
+					}
+					footnotes = append(footnotes, footnote)
+				case *v1pb.Node_SuperscriptNode:
+					footnotes = append(footnotes, "sup "+n.SuperscriptNode.Content)
+				}
+				collect(getNodeChildren(node))
+			}
+		}
+		collect(response.Nodes)
+		require.Equal(t, test.footnotes, footnotes, test.markdown)
+
+		// The references and definitions are stringified in place.
+		stringifyResponse, err := s.StringifyMarkdownNodes(context.Background(), &v1pb.StringifyMarkdownNodesRequest{Nodes: response.Nodes, Mode: v1pb.StringifyMarkdownNodesRequest_GFM})
+		require.NoError(t, err)
+		require.Equal(t, test.markdown, stringifyResponse.PlainText)
+		restoreResponse, err := s.RestoreMarkdownNodes(context.Background(), &v1pb.RestoreMarkdownNodesRequest{Nodes: response.Nodes, Strict: true})
+		require.NoError(t, err)
+		require.Equal(t, test.markdown, restoreResponse.Markdown)
+	}
+
+	// Renderers without footnotes keep the markers as text.
+	response, err := s.ParseMarkdown(context.Background(), &v1pb.ParseMarkdownRequest{Markdown: tests[0].markdown, Footnotes: true})
+	require.NoError(t, err)
+	stringifyResponse, err := s.StringifyMarkdownNodes(context.Background(), &v1pb.StringifyMarkdownNodesRequest{Nodes: response.Nodes, Mode: v1pb.StringifyMarkdownNodesRequest_PLAIN_TEXT})
+	require.NoError(t, err)
+	require.Equal(t, "Text[^1] and more[^note].\n[^note]: Second, with bold.\n[^1]: First[^note].", stringifyResponse.PlainText)
+
+	// Footnotes are text without the option.
+	response, err = s.ParseMarkdown(context.Background(), &v1pb.ParseMarkdownRequest{Markdown: tests[0].markdown})
+	require.NoError(t, err)
+	require.NotContains(t, fmt.Sprint(response.Nodes), "footnote")
+}
+
+func TestNormalizeMarkdown(t *testing.T) {
+	s := &APIV1Service{}
+	tests := []struct {
+		markdown    string
+		bulletStyle v1pb.NormalizeMarkdownRequest_BulletStyle
+		want        string
+	}{
+		{
+			markdown: "# Title  \n\n\n\n\nText\t\n\n\nMore text",
+			want:     "# Title\n\nText\n\n\nMore text\n",
+		},
+		{
+			markdown:    "* a\n  + b\n- [x] c\n\n1. d\n\n\n",
+			bulletStyle: v1pb.NormalizeMarkdownRequest_DASH,
+			want:        "- a\n  - b\n- [x] c\n\n1. d\n",
+		},
+		{
+			markdown:    "- a\n- b",
+			bulletStyle: v1pb.NormalizeMarkdownRequest_ASTERISK,
+			want:        "* a\n* b\n",
+		},
+		// Code blocks are kept verbatim.
+		{
+			markdown: "```\ncode  \n\n\n\n\nmore code\n```\n\n\n\n\ntext",
+			want:     "```\ncode  \n\n\n\n\nmore code\n```\n\ntext\n",
+		},
+		{
+			markdown: "\n\n  \n",
+			want:     "",
+		},
+	}
+	for _, test := range tests {
+		response, err := s.NormalizeMarkdown(context.Background(), &v1pb.NormalizeMarkdownRequest{Markdown: test.markdown, BulletStyle: test.bulletStyle})
+		require.NoError(t, err)
+		require.Equal(t, test.want, response.Markdown, test.markdown)
+
+		// Normalizing is idempotent.
+		again, err := s.NormalizeMarkdown(context.Background(), &v1pb.NormalizeMarkdownRequest{Markdown: response.Markdown, BulletStyle: test.bulletStyle})
+		require.NoError(t, err)
+		require.Equal(t, response.Markdown, again.Markdown, test.markdown)
+	}
+}
+
+func TestParseMarkdownCodeLanguages(t *testing.T) {
+	tests := []struct {
+		markdown string
+		aliases  map[string]string
+		language string
+		metadata string
+		info     string
+	}{
+		{
+			markdown: "```js\nconsole.log(1)\n```",
+			language: "javascript",
+			info:     "js",
+		},
+		{
+			markdown: "```Go {hl=1}\nfunc main() {}\n```",
+			language: "go",
+			metadata: "{hl=1}",
+			info:     "Go {hl=1}",
+		},
+		{
+			markdown: "```python title=\"main.py\"\nprint(1)\n```",
+			language: "python",
+			metadata: "title=\"main.py\"",
+			info:     "python title=\"main.py\"",
+		},
+		{
+			// The aliases of the request override the default ones.
+			markdown: "```js\n1\n```",
+			aliases:  map[string]string{"js": "jsx", "vue": "html"},
+			language: "jsx",
+			info:     "js",
+		},
+		{
+			markdown: "```\ncode\n```",
+		},
+	}
+
+	s := newTestMarkdownService(t)
+	for _, test := range tests {
+		response, err := s.ParseMarkdown(context.Background(), &v1pb.ParseMarkdownRequest{Markdown: test.markdown, CodeLanguages: true, CodeLanguageAliases: test.aliases})
+		require.NoError(t, err)
+		require.Len(t, response.Nodes, 1, test.markdown)
+		codeBlock := response.Nodes[0].GetCodeBlockNode()
+		require.NotNil(t, codeBlock, test.markdown)
+		require.Equal(t, test.language, codeBlock.Language, test.markdown)
+		require.Equal(t, test.metadata, codeBlock.Metadata, test.markdown)
+		require.Equal(t, test.info, codeBlock.Info, test.markdown)
+
+		// The raw info string is restored.
+		require.Equal(t, test.markdown, restoreMarkdownNodes(response.Nodes, false), test.markdown)
+	}
+
+	// Without the option, an info string with metadata is not a code block.
+	response, err := s.ParseMarkdown(context.Background(), &v1pb.ParseMarkdownRequest{Markdown: "```go {hl=1}\ncode\n```"})
+	require.NoError(t, err)
+	require.Nil(t, response.Nodes[0].GetCodeBlockNode())
+
+	// Code blocks are followed by the rest of the document.
+	response, err = s.ParseMarkdown(context.Background(), &v1pb.ParseMarkdownRequest{Markdown: "```ts\n```\ntext", CodeLanguages: true})
+	require.NoError(t, err)
+	require.Len(t, response.Nodes, 3)
+	require.Equal(t, "typescript", response.Nodes[0].GetCodeBlockNode().GetLanguage())
+	require.Equal(t, "", response.Nodes[0].GetCodeBlockNode().GetContent())
+}
+
+func TestParseMarkdownCodeFences(t *testing.T) {
+	tests := []struct {
+		markdown     string
+		language     string
+		content      string
+		fence        string
+		closingFence string
+	}{
+		{
+			// The trailing blank line is kept in the content.
+			markdown:     "```py\nif x:\n    pass\n\n```\ntext",
+			language:     "py",
+			content:      "if x:\n    pass\n\n",
+			fence:        "```",
+			closingFence: "```",
+		},
+		{
+			markdown:     "~~~\nall:\n\techo `date`\n~~~",
+			content:      "all:\n\techo `date`\n",
+			fence:        "~~~",
+			closingFence: "~~~",
+		},
+		{
+			// A longer fence may hold a shorter one, and the closing fence may be longer still.
+			markdown:     "````md\n```\ncode\n```\n`````",
+			language:     "md",
+			content:      "```\ncode\n```\n",
+			fence:        "````",
+			closingFence: "`````",
+		},
+		{
+			markdown:     "```\n```",
+			fence:        "```",
+			closingFence: "```",
+		},
+	}
+
+	s := newTestMarkdownService(t)
+	for _, test := range tests {
+		response, err := s.ParseMarkdown(context.Background(), &v1pb.ParseMarkdownRequest{Markdown: test.markdown, CodeFences: true})
+		require.NoError(t, err)
+		codeBlock := response.Nodes[0].GetCodeBlockNode()
+		require.NotNil(t, codeBlock, test.markdown)
+		require.Equal(t, test.language, codeBlock.Language, test.markdown)
+		require.Equal(t, test.content, codeBlock.Content, test.markdown)
+		require.Equal(t, test.fence, codeBlock.Fence, test.markdown)
+		require.Equal(t, test.closingFence, codeBlock.ClosingFence, test.markdown)
+
+		stringifyResponse, err := s.StringifyMarkdownNodes(context.Background(), &v1pb.StringifyMarkdownNodesRequest{
+			Nodes: response.Nodes,
+			Mode:  v1pb.StringifyMarkdownNodesRequest_GFM,
+		})
+		require.NoError(t, err)
+		require.Equal(t, test.markdown, stringifyResponse.PlainText, test.markdown)
+	}
+
+	// The code languages are normalized as usual, and the info string is restored.
+	response, err := s.ParseMarkdown(context.Background(), &v1pb.ParseMarkdownRequest{Markdown: "~~~Go {hl=1}\ncode\n~~~", CodeFences: true, CodeLanguages: true})
+	require.NoError(t, err)
+	codeBlock := response.Nodes[0].GetCodeBlockNode()
+	require.Equal(t, "go", codeBlock.Language)
+	require.Equal(t, "{hl=1}", codeBlock.Metadata)
+	require.Equal(t, "~~~Go {hl=1}\ncode\n~~~", restoreMarkdownNodes(response.Nodes, false))
+
+	// Without the option, a tilde fence is not a code block.
+	response, err = s.ParseMarkdown(context.Background(), &v1pb.ParseMarkdownRequest{Markdown: "~~~\ncode\n~~~"})
+	require.NoError(t, err)
+	require.Nil(t, response.Nodes[0].GetCodeBlockNode())
+}
+
+func TestParseMarkdownScripts(t *testing.T) {
+	tests := []struct {
+		markdown string
+		// The inline nodes of the paragraph, e.g. "sub 2" for a subscript and "text H" for text.
+		want []string
+	}{
+		{
+			markdown: "H~2~O and x^2^",
+			want:     []string{"text H", "sub 2", "text O and x", "sup 2"},
+		},
+		{
+			// Strikethrough wins over subscript.
+			markdown: "~~strike~~ and ~sub~",
+			want:     []string{"strike strike", "text  and ", "sub sub"},
+		},
+		{
+			markdown: "~sub~~~strike~~",
+			want:     []string{"sub sub", "strike strike"},
+		},
+		{
+			// Bare delimiters around whitespace are text.
+			markdown: "a^b c^d",
+			want:     []string{"text a^b c^d"},
+		},
+		{
+			markdown: "a ~ b ~ c and 2^10 or 3^",
+			want:     []string{"text a ~ b ~ c and 2^10 or 3^"},
+		},
+		{
+			markdown: "~~not closed~ and ^^",
+			want:     []string{"text ~~not closed~ and ^^"},
+		},
+		{
+			markdown: "**CO~2~** e^x^^y^",
+			want:     []string{"bold sub 2", "text  e", "sup x", "sup y"},
+		},
+		{
+			// Footnote references are not superscript.
+			markdown: "x[^a][^b]",
+			want:     []string{"text x[^a][^b]"},
+		},
+	}
+
+	s := newTestMarkdownService(t)
+	for _, test := range tests {
+		response, err := s.ParseMarkdown(context.Background(), &v1pb.ParseMarkdownRequest{Markdown: test.markdown, Scripts: true})
+		require.NoError(t, err)
+		require.Len(t, response.Nodes, 1, test.markdown)
+		got := []string{}
+		for _, child := range response.Nodes[0].GetParagraphNode().GetChildren() {
+			switch n := child.Node.(type) {
+			case *v1pb.Node_TextNode:
+				got = append(got, "text "+n.TextNode.Content)
+			case *v1pb.Node_SubscriptNode:
+				got = append(got, "sub "+n.SubscriptNode.Content)
+			case *v1pb.Node_SuperscriptNode:
+				got = append(got, "sup "+n.SuperscriptNode.Content)
+			case *v1pb.Node_StrikethroughNode:
+				got = append(got, "strike "+n.StrikethroughNode.Content)
+			case *v1pb.Node_BoldNode:
+				got = append(got, "bold sub "+n.BoldNode.Children[1].GetSubscriptNode().GetContent())
+			}
+		}
+		require.Equal(t, test.want, got, test.markdown)
+
+		stringified, err := s.StringifyMarkdownNodes(context.Background(), &v1pb.StringifyMarkdownNodesRequest{Nodes: response.Nodes, Mode: v1pb.StringifyMarkdownNodesRequest_GFM})
+		require.NoError(t, err)
+		require.Equal(t, test.markdown, stringified.PlainText, test.markdown)
+	}
+}
@@ -0,0 +1,119 @@
+package v1
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/usememos/gomark/ast"
+
+	v1pb "github.com/usememos/memos/proto/gen/api/v1"
+)
+
+// emptyHeadingAnchor is the anchor of headings without letters or digits, e.g. "# !!!".
+const emptyHeadingAnchor = "section"
+
+// headingSlugger generates the anchors of the headings of a document in order. The table of
+// contents and the HTML renderer share it, so that the links of the former match the ids of the latter.
+type headingSlugger struct {
+	used map[string]bool
+}
+
+func newHeadingSlugger() *headingSlugger {
+	return &headingSlugger{used: map[string]bool{}}
+}
+
+// slug returns the anchor of a heading of the given text: the text lowercased, with whitespace
+// turned into hyphens and all but letters, digits, hyphens and underscores dropped, e.g.
+// "hello-world" for "Hello, World!". CJK text is kept as it is. An anchor already returned gets
+// the lowest free numeric suffix, e.g. "hello-world-1".
+func (s *headingSlugger) slug(text string) string {
+	var builder strings.Builder
+	for _, r := range strings.ToLower(strings.TrimSpace(text)) {
+		switch {
+		case unicode.IsSpace(r):
+			builder.WriteRune('-')
+		case unicode.IsLetter(r), unicode.IsDigit(r), unicode.IsMark(r), r == '-', r == '_':
+			builder.WriteRune(r)
+		}
+	}
+	base := builder.String()
+	if base == "" {
+		base = emptyHeadingAnchor
+	}
+	anchor := base
+	for i := 1; s.used[anchor]; i++ {
+		anchor = fmt.Sprintf("%s-%d", base, i)
+	}
+	s.used[anchor] = true
+	return anchor
+}
+
+// getHeadingTitle returns the plain text of the heading, by which its anchor is generated.
+func getHeadingTitle(heading *v1pb.HeadingNode) string {
+	return strings.TrimSpace(renderPlainTextInline(heading.Children))
+}
+
+// getHeadingAnchors returns the anchors of the top-level headings of the given gomark nodes, see
+// getTableOfContents.
+func getHeadingAnchors(nodes []ast.Node) map[*ast.Heading]string {
+	slugger := newHeadingSlugger()
+	anchors := map[*ast.Heading]string{}
+	for _, node := range nodes {
+		if heading, ok := node.(*ast.Heading); ok {
+			anchors[heading] = slugger.slug(getHeadingTitle(convertFromASTNode(heading).GetHeadingNode()))
+		}
+	}
+	return anchors
+}
+
+// getTableOfContents returns the table of contents of the top-level headings of the given nodes.
+// Headings in blockquotes and lists are left out, as they are rather quoted than sections.
+func getTableOfContents(nodes []*v1pb.Node) []*v1pb.TableOfContentsEntry {
+	slugger := newHeadingSlugger()
+	entries := []*v1pb.TableOfContentsEntry{}
+	// parents are the entries of the open sections, of increasing levels.
+	parents := []*v1pb.TableOfContentsEntry{}
+	for _, node := range nodes {
+		heading := node.GetHeadingNode()
+		if heading == nil {
+			continue
+		}
+		title := getHeadingTitle(heading)
+		entry := &v1pb.TableOfContentsEntry{
+			Title:    title,
+			Level:    heading.Level,
+			Anchor:   slugger.slug(title),
+			Children: []*v1pb.TableOfContentsEntry{},
+		}
+		for len(parents) > 0 && parents[len(parents)-1].Level >= entry.Level {
+			parents = parents[:len(parents)-1]
+		}
+		if len(parents) == 0 {
+			entries = append(entries, entry)
+		} else {
+			parent := parents[len(parents)-1]
+			parent.Children = append(parent.Children, entry)
+		}
+		parents = append(parents, entry)
+	}
+	return entries
+}
+
+// tableOfContentsLabelEscaper escapes the characters of titles that would end or break the label of a link.
+var tableOfContentsLabelEscaper = strings.NewReplacer(`\`, `\\`, "[", `\[`, "]", `\]`)
+
+// renderTableOfContents renders the table of contents as a nested markdown list of links to the
+// anchors of the headings, indenting each level of subheadings by two spaces.
+func renderTableOfContents(entries []*v1pb.TableOfContentsEntry) string {
+	lines := []string{}
+	var render func(entries []*v1pb.TableOfContentsEntry, depth int)
+	render = func(entries []*v1pb.TableOfContentsEntry, depth int) {
+		for _, entry := range entries {
+			lines = append(lines, fmt.Sprintf("%s- [%s](#%s)", strings.Repeat("  ", depth), tableOfContentsLabelEscaper.Replace(entry.Title), entry.Anchor))
+			render(entry.Children, depth+1)
+		}
+	}
+	render(entries, 0)
+	return strings.Join(lines, "\n")
+}
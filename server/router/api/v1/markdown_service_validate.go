@@ -0,0 +1,114 @@
+package v1
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	v1pb "github.com/usememos/memos/proto/gen/api/v1"
+)
+
+// validateMarkdownNodes checks that the given nodes can be restored to markdown. The error
+// names the path of the first malformed node, e.g. "nodes[1].table_node.rows[2].cells".
+func validateMarkdownNodes(nodes []*v1pb.Node, path string) error {
+	for i, node := range nodes {
+		if err := validateMarkdownNode(node, fmt.Sprintf("%s[%d]", path, i)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func validateMarkdownNode(node *v1pb.Node, path string) error {
+	switch n := node.GetNode().(type) {
+	case nil:
+		return errors.Errorf("%s has no node", path)
+	case *v1pb.Node_ParagraphNode:
+		return validateMarkdownNodes(n.ParagraphNode.Children, path+".paragraph_node.children")
+	case *v1pb.Node_HeadingNode:
+		if n.HeadingNode.Level < 1 || n.HeadingNode.Level > 6 {
+			return errors.Errorf("%s.heading_node.level is %d, must be between 1 and 6", path, n.HeadingNode.Level)
+		}
+		return validateMarkdownNodes(n.HeadingNode.Children, path+".heading_node.children")
+	case *v1pb.Node_BlockquoteNode:
+		return validateMarkdownNodes(n.BlockquoteNode.Children, path+".blockquote_node.children")
+	case *v1pb.Node_ListNode:
+		return validateMarkdownNodes(n.ListNode.Children, path+".list_node.children")
+	case *v1pb.Node_OrderedListItemNode:
+		return validateMarkdownNodes(n.OrderedListItemNode.Children, path+".ordered_list_item_node.children")
+	case *v1pb.Node_UnorderedListItemNode:
+		if n.UnorderedListItemNode.Symbol == "" {
+			return errors.Errorf("%s.unordered_list_item_node.symbol is empty", path)
+		}
+		return validateMarkdownNodes(n.UnorderedListItemNode.Children, path+".unordered_list_item_node.children")
+	case *v1pb.Node_TaskListItemNode:
+		if n.TaskListItemNode.Symbol == "" {
+			return errors.Errorf("%s.task_list_item_node.symbol is empty", path)
+		}
+		return validateMarkdownNodes(n.TaskListItemNode.Children, path+".task_list_item_node.children")
+	case *v1pb.Node_TableNode:
+		return validateTableNode(n.TableNode, path+".table_node")
+	case *v1pb.Node_BoldNode:
+		return validateMarkdownNodes(n.BoldNode.Children, path+".bold_node.children")
+	case *v1pb.Node_ItalicNode:
+		return validateMarkdownNodes(n.ItalicNode.Children, path+".italic_node.children")
+	case *v1pb.Node_LinkNode:
+		if n.LinkNode.Url == "" {
+			return errors.Errorf("%s.link_node.url is empty", path)
+		}
+		return validateMarkdownNodes(n.LinkNode.Content, path+".link_node.content")
+	case *v1pb.Node_AutoLinkNode:
+		if n.AutoLinkNode.Url == "" {
+			return errors.Errorf("%s.auto_link_node.url is empty", path)
+		}
+	case *v1pb.Node_ImageNode:
+		if n.ImageNode.Url == "" {
+			return errors.Errorf("%s.image_node.url is empty", path)
+		}
+	case *v1pb.Node_EmojiNode:
+		if n.EmojiNode.Shortcode == "" {
+			return errors.Errorf("%s.emoji_node.shortcode is empty", path)
+		}
+	case *v1pb.Node_CustomNode:
+		if n.CustomNode.Raw == "" {
+			return errors.Errorf("%s.custom_node.raw is empty", path)
+		}
+	case *v1pb.Node_FootnoteDefNode:
+		if !isFootnoteLabel(n.FootnoteDefNode.Label) {
+			return errors.Errorf("%s.footnote_def_node.label %q is not a valid footnote label", path, n.FootnoteDefNode.Label)
+		}
+		return validateMarkdownNodes(n.FootnoteDefNode.Children, path+".footnote_def_node.children")
+	case *v1pb.Node_FootnoteRefNode:
+		if !isFootnoteLabel(n.FootnoteRefNode.Label) {
+			return errors.Errorf("%s.footnote_ref_node.label %q is not a valid footnote label", path, n.FootnoteRefNode.Label)
+		}
+	case *v1pb.Node_FrontmatterNode:
+		if content := n.FrontmatterNode.Content; content != "" && !strings.HasSuffix(content, "\n") {
+			return errors.Errorf("%s.frontmatter_node.content must end with a newline", path)
+		}
+	}
+	return nil
+}
+
+func validateTableNode(table *v1pb.TableNode, path string) error {
+	if len(table.Header) == 0 {
+		return errors.Errorf("%s.header is empty", path)
+	}
+	if len(table.Delimiter) != len(table.Header) {
+		return errors.Errorf("%s.delimiter has %d entries, header has %d", path, len(table.Delimiter), len(table.Header))
+	}
+	if err := validateMarkdownNodes(table.Header, path+".header"); err != nil {
+		return err
+	}
+	for i, row := range table.Rows {
+		rowPath := fmt.Sprintf("%s.rows[%d].cells", path, i)
+		if len(row.Cells) != len(table.Header) {
+			return errors.Errorf("%s has %d entries, header has %d", rowPath, len(row.Cells), len(table.Header))
+		}
+		if err := validateMarkdownNodes(row.Cells, rowPath); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,181 @@
+package v1
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+
+	"github.com/usememos/gomark/ast"
+	"github.com/usememos/gomark/renderer"
+
+	v1pb "github.com/usememos/memos/proto/gen/api/v1"
+)
+
+// markdownBlockStartRegexp matches the words that start a block when they begin a line,
+// e.g. "-" of a list item or "#" of a heading.
+var markdownBlockStartRegexp = regexp.MustCompile(`^([-+*>]|#{1,6}|\d{1,9}[.)])$`)
+
+// wrapUnit is a piece of a paragraph that is not broken across lines, i.e. a word, a CJK
+// character or an inline node other than text.
+type wrapUnit struct {
+	// space is the whitespace before the unit, which is dropped when the line breaks there.
+	space string
+	// canBreak is whether the line may break before the unit.
+	canBreak bool
+	text     string
+	node     *v1pb.Node
+	width    int
+}
+
+// wrapMarkdownNodes hard-wraps the paragraphs of the given nodes at width columns by
+// inserting line breaks between their words. A word longer than the width gets a line of
+// its own. The given nodes are not modified, wrapped paragraphs are copies.
+func wrapMarkdownNodes(nodes []*v1pb.Node, width int, mode v1pb.StringifyMarkdownNodesRequest_Mode) []*v1pb.Node {
+	result := make([]*v1pb.Node, 0, len(nodes))
+	for _, node := range nodes {
+		if n, ok := node.Node.(*v1pb.Node_ParagraphNode); ok {
+			node = &v1pb.Node{
+				Type: node.Type,
+				Node: &v1pb.Node_ParagraphNode{ParagraphNode: &v1pb.ParagraphNode{
+					Children: wrapInlineNodes(n.ParagraphNode.Children, width, mode),
+				}},
+				Position: node.Position,
+			}
+		}
+		result = append(result, node)
+	}
+	return result
+}
+
+func wrapInlineNodes(nodes []*v1pb.Node, width int, mode v1pb.StringifyMarkdownNodesRequest_Mode) []*v1pb.Node {
+	units, trailingSpace := splitWrapUnits(nodes, mode)
+	// The width of the units up to the next break, which have to fit on the line together.
+	groupWidths := make([]int, len(units))
+	for i := len(units) - 1; i >= 0; i-- {
+		groupWidths[i] = units[i].width
+		if i+1 < len(units) && !units[i+1].canBreak {
+			groupWidths[i] += len(units[i+1].space) + groupWidths[i+1]
+		}
+	}
+
+	result := []*v1pb.Node{}
+	var text strings.Builder
+	flushText := func() {
+		if text.Len() > 0 {
+			result = append(result, &v1pb.Node{Type: v1pb.NodeType_TEXT, Node: &v1pb.Node_TextNode{TextNode: &v1pb.TextNode{Content: text.String()}}})
+			text.Reset()
+		}
+	}
+	column := 0
+	for i, unit := range units {
+		if unit.canBreak && column > 0 && column+len(unit.space)+groupWidths[i] > width {
+			flushText()
+			result = append(result, &v1pb.Node{Type: v1pb.NodeType_LINE_BREAK, Node: &v1pb.Node_LineBreakNode{LineBreakNode: &v1pb.LineBreakNode{}}})
+			column = 0
+		} else {
+			text.WriteString(unit.space)
+			column += len(unit.space)
+		}
+		if unit.node == nil {
+			text.WriteString(unit.text)
+			column += unit.width
+			continue
+		}
+		flushText()
+		result = append(result, unit.node)
+		column += unit.width
+		if _, ok := unit.node.Node.(*v1pb.Node_LineBreakNode); ok {
+			column = 0
+		}
+	}
+	text.WriteString(trailingSpace)
+	flushText()
+	return result
+}
+
+// splitWrapUnits splits the given inline nodes into units. Lines may break at whitespace
+// and around CJK characters, which are written without spaces, but not before a word that
+// would start a new block in the markdown modes.
+func splitWrapUnits(nodes []*v1pb.Node, mode v1pb.StringifyMarkdownNodesRequest_Mode) ([]*wrapUnit, string) {
+	units := []*wrapUnit{}
+	var space strings.Builder
+	var prev rune
+	for _, node := range nodes {
+		textNode, ok := node.Node.(*v1pb.Node_TextNode)
+		if !ok {
+			_, isLineBreak := node.Node.(*v1pb.Node_LineBreakNode)
+			units = append(units, &wrapUnit{
+				space:    space.String(),
+				canBreak: !isLineBreak && (space.Len() > 0 || isCJKCharacter(prev)),
+				node:     node,
+				width:    getDisplayWidth(renderWrapUnitNode(node, mode)),
+			})
+			space.Reset()
+			prev = 0
+			continue
+		}
+		for _, r := range textNode.TextNode.Content {
+			if unicode.IsSpace(r) {
+				space.WriteRune(r)
+				prev = r
+				continue
+			}
+			var last *wrapUnit
+			if len(units) > 0 {
+				last = units[len(units)-1]
+			}
+			canBreak := space.Len() > 0 || isCJKCharacter(prev) || (isCJKCharacter(r) && !unicode.Is(unicode.Ps, prev))
+			if last != nil && last.node == nil && !canBreak && prev != 0 {
+				last.text += string(r)
+				last.width += getRuneWidth(r)
+			} else {
+				units = append(units, &wrapUnit{
+					space:    space.String(),
+					canBreak: canBreak,
+					text:     string(r),
+					width:    getRuneWidth(r),
+				})
+				space.Reset()
+			}
+			prev = r
+		}
+	}
+	if mode == v1pb.StringifyMarkdownNodesRequest_GFM || mode == v1pb.StringifyMarkdownNodesRequest_COMMONMARK {
+		for _, unit := range units {
+			if unit.node == nil && markdownBlockStartRegexp.MatchString(unit.text) {
+				unit.canBreak = false
+			}
+		}
+	}
+	return units, space.String()
+}
+
+// renderWrapUnitNode renders the given inline node as StringifyMarkdownNodes does in the given mode.
+func renderWrapUnitNode(node *v1pb.Node, mode v1pb.StringifyMarkdownNodesRequest_Mode) string {
+	switch mode {
+	case v1pb.StringifyMarkdownNodesRequest_PLAIN_TEXT:
+		return renderPlainTextInline([]*v1pb.Node{node})
+	case v1pb.StringifyMarkdownNodesRequest_GFM:
+		return convertToASTNode(node).Restore()
+	case v1pb.StringifyMarkdownNodesRequest_COMMONMARK:
+		return convertToCommonMark(convertToASTNode(node)).Restore()
+	default:
+		return renderer.NewStringRenderer().Render([]ast.Node{convertToASTNode(node)})
+	}
+}
+
+// getDisplayWidth returns the number of columns the given text takes in a terminal.
+func getDisplayWidth(text string) int {
+	width := 0
+	for _, r := range text {
+		width += getRuneWidth(r)
+	}
+	return width
+}
+
+func getRuneWidth(r rune) int {
+	if isCJKCharacter(r) || unicode.Is(unicode.Hangul, r) || (r >= 0x3000 && r <= 0x303f) || (r >= 0xff01 && r <= 0xff60) {
+		return 2
+	}
+	return 1
+}
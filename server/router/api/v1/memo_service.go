@@ -7,7 +7,6 @@ import (
 	"time"
 	"unicode/utf8"
 
-	"github.com/lithammer/shortuuid/v4"
 	"github.com/pkg/errors"
 	"github.com/usememos/gomark/ast"
 	"github.com/usememos/gomark/parser"
@@ -33,11 +32,26 @@ func (s *APIV1Service) CreateMemo(ctx context.Context, request *v1pb.CreateMemoR
 	}
 
 	create := &store.Memo{
-		UID:        shortuuid.New(),
 		CreatorID:  user.ID,
 		Content:    request.Memo.Content,
 		Visibility: convertVisibilityToStore(request.Memo.Visibility),
 	}
+	if request.TemplateId != "" {
+		if request.Memo.Content != "" {
+			return nil, status.Errorf(codes.InvalidArgument, "content must be empty when creating from a template")
+		}
+		create.Content, err = s.getExpandedTemplate(ctx, user.ID, request.TemplateId)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if request.Memo.Visibility == v1pb.Visibility_VISIBILITY_UNSPECIFIED {
+		visibility, err := s.Store.GetUserMemoVisibility(ctx, user.ID)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to get user memo visibility: %v", err)
+		}
+		create.Visibility = visibility
+	}
 	workspaceMemoRelatedSetting, err := s.Store.GetWorkspaceMemoRelatedSetting(ctx)
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "failed to get workspace memo related setting")
@@ -45,13 +59,6 @@ func (s *APIV1Service) CreateMemo(ctx context.Context, request *v1pb.CreateMemoR
 	if workspaceMemoRelatedSetting.DisallowPublicVisibility && create.Visibility == store.Public {
 		return nil, status.Errorf(codes.PermissionDenied, "disable public memos system setting is enabled")
 	}
-	contentLengthLimit, err := s.getContentLengthLimit(ctx)
-	if err != nil {
-		return nil, status.Errorf(codes.Internal, "failed to get content length limit")
-	}
-	if len(create.Content) > contentLengthLimit {
-		return nil, status.Errorf(codes.InvalidArgument, "content too long (max %d characters)", contentLengthLimit)
-	}
 	if err := memopayload.RebuildMemoPayload(create); err != nil {
 		return nil, status.Errorf(codes.Internal, "failed to rebuild memo payload: %v", err)
 	}
@@ -59,8 +66,36 @@ func (s *APIV1Service) CreateMemo(ctx context.Context, request *v1pb.CreateMemoR
 		create.Payload.Location = convertLocationToStore(request.Memo.Location)
 	}
 
-	memo, err := s.Store.CreateMemo(ctx, create)
+	if len(request.IdempotencyKey) > store.MaxMemoIdempotencyKeyLength {
+		return nil, status.Errorf(codes.InvalidArgument, "idempotency key is longer than %d bytes", store.MaxMemoIdempotencyKeyLength)
+	}
+
+	var memo *store.Memo
+	if request.IdempotencyKey != "" {
+		var created bool
+		memo, created, err = s.Store.CreateMemoWithIdempotencyKey(ctx, create, request.IdempotencyKey)
+		if err == nil && !created {
+			// The memo was created by an earlier request with the same key, which has set it up already.
+			memoMessage, err := s.convertMemoFromStore(ctx, memo)
+			if err != nil {
+				return nil, errors.Wrap(err, "failed to convert memo")
+			}
+			return memoMessage, nil
+		}
+	} else {
+		memo, err = s.Store.CreateMemo(ctx, create)
+	}
 	if err != nil {
+		if isContentTooLongError(err) {
+			return nil, status.Errorf(codes.InvalidArgument, "%v", err)
+		}
+		if errors.Is(err, store.ErrInvalidMemoLocation) {
+			return nil, status.Errorf(codes.InvalidArgument, "%v", err)
+		}
+		var memoQuotaExceededErr *store.MemoQuotaExceededError
+		if errors.As(err, &memoQuotaExceededErr) {
+			return nil, status.Errorf(codes.ResourceExhausted, "%v", err)
+		}
 		return nil, err
 	}
 	if len(request.Memo.Resources) > 0 {
@@ -110,7 +145,9 @@ func (s *APIV1Service) ListMemos(ctx context.Context, request *v1pb.ListMemosReq
 		memoFind.CreatorID = &userID
 		memoFind.OrderByPinned = true
 	}
-	if request.State == v1pb.State_ARCHIVED {
+	if request.IncludeArchived {
+		memoFind.RowStatus = nil
+	} else if request.State == v1pb.State_ARCHIVED {
 		state := store.Archived
 		memoFind.RowStatus = &state
 	} else {
@@ -131,6 +168,19 @@ func (s *APIV1Service) ListMemos(ctx context.Context, request *v1pb.ListMemosReq
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "failed to get user")
 	}
+	if request.Shortcut != "" {
+		if currentUser == nil {
+			return nil, status.Errorf(codes.Unauthenticated, "shortcuts require a signed-in user")
+		}
+		shortcutFilter, err := s.getShortcutFilter(ctx, currentUser.ID, request.Shortcut)
+		if err != nil {
+			return nil, err
+		}
+		if memoFind.Filter != nil {
+			shortcutFilter = fmt.Sprintf("(%s) && (%s)", *memoFind.Filter, shortcutFilter)
+		}
+		memoFind.Filter = &shortcutFilter
+	}
 	if currentUser == nil {
 		memoFind.VisibilityList = []store.Visibility{store.Public}
 	} else {
@@ -156,6 +206,7 @@ func (s *APIV1Service) ListMemos(ctx context.Context, request *v1pb.ListMemosReq
 	}
 
 	var limit, offset int
+	var cursor string
 	if request.PageToken != "" {
 		var pageToken v1pb.PageToken
 		if err := unmarshalPageToken(request.PageToken, &pageToken); err != nil {
@@ -163,29 +214,64 @@ func (s *APIV1Service) ListMemos(ctx context.Context, request *v1pb.ListMemosReq
 		}
 		limit = int(pageToken.Limit)
 		offset = int(pageToken.Offset)
+		cursor = pageToken.Cursor
 	} else {
 		limit = int(request.PageSize)
 	}
 	if limit <= 0 {
 		limit = DefaultPageSize
 	}
-	limitPlusOne := limit + 1
-	memoFind.Limit = &limitPlusOne
-	memoFind.Offset = &offset
-	memos, err := s.Store.ListMemos(ctx, memoFind)
-	if err != nil {
-		return nil, status.Errorf(codes.Internal, "failed to list memos: %v", err)
+	memoFind.IncludeReactionSummaries = true
+	if currentUser != nil {
+		memoFind.ViewerID = &currentUser.ID
 	}
 
-	memoMessages := []*v1pb.Memo{}
+	var memos []*store.Memo
 	nextPageToken := ""
-	if len(memos) == limitPlusOne {
-		memos = memos[:limit]
-		nextPageToken, err = getPageToken(limit, offset+limit)
+	if request.UpdatedAfter != nil {
+		// Sync clients page through the changes by update time with a cursor, which is not
+		// thrown off by memos updated between pages.
+		updatedTs := request.UpdatedAfter.AsTime().Unix()
+		memoFind.UpdatedTsAfter = &updatedTs
+		memoFind.OrderByUpdatedTs = true
+		memoFind.OrderByTimeAsc = true
+		memoFind.OrderByPinned = false
+		memoFind.Limit = &limit
+		if cursor != "" {
+			if _, err := store.DecodeMemoCursor(cursor); err != nil {
+				return nil, status.Errorf(codes.InvalidArgument, "invalid page token: %v", err)
+			}
+			memoFind.Cursor = &cursor
+		}
+		var nextCursor string
+		memos, nextCursor, err = s.Store.ListMemosWithCursor(ctx, memoFind)
 		if err != nil {
-			return nil, status.Errorf(codes.Internal, "failed to get next page token, error: %v", err)
+			return nil, status.Errorf(codes.Internal, "failed to list memos: %v", err)
+		}
+		if nextCursor != "" {
+			nextPageToken, err = marshalPageToken(&v1pb.PageToken{Limit: int32(limit), Cursor: nextCursor})
+			if err != nil {
+				return nil, status.Errorf(codes.Internal, "failed to get next page token, error: %v", err)
+			}
+		}
+	} else {
+		limitPlusOne := limit + 1
+		memoFind.Limit = &limitPlusOne
+		memoFind.Offset = &offset
+		memos, err = s.Store.ListMemos(ctx, memoFind)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to list memos: %v", err)
+		}
+		if len(memos) == limitPlusOne {
+			memos = memos[:limit]
+			nextPageToken, err = getPageToken(limit, offset+limit)
+			if err != nil {
+				return nil, status.Errorf(codes.Internal, "failed to get next page token, error: %v", err)
+			}
 		}
 	}
+
+	memoMessages := []*v1pb.Memo{}
 	for _, memo := range memos {
 		memoMessage, err := s.convertMemoFromStore(ctx, memo)
 		if err != nil {
@@ -198,9 +284,75 @@ func (s *APIV1Service) ListMemos(ctx context.Context, request *v1pb.ListMemosReq
 		Memos:         memoMessages,
 		NextPageToken: nextPageToken,
 	}
+	if request.IncludePageInfo || request.IncludeTotalSize {
+		// Pages are fetched with one more memo than the limit, which tells whether there are more.
+		response.PageInfo = &v1pb.ListMemosResponse_PageInfo{
+			NextPageToken: nextPageToken,
+			HasMore:       nextPageToken != "",
+		}
+		if request.IncludeTotalSize {
+			countFind := *memoFind
+			countFind.Limit, countFind.Offset, countFind.Cursor = nil, nil, nil
+			count, err := s.Store.CountMemos(ctx, &countFind)
+			if err != nil {
+				return nil, status.Errorf(codes.Internal, "failed to count memos: %v", err)
+			}
+			totalSize := int32(count)
+			response.PageInfo.TotalSize = &totalSize
+		}
+	}
 	return response, nil
 }
 
+func (s *APIV1Service) ExplainListMemos(ctx context.Context, request *v1pb.ExplainListMemosRequest) (*v1pb.ExplainListMemosResponse, error) {
+	user, err := s.GetCurrentUser(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get user")
+	}
+	if user == nil {
+		return nil, status.Errorf(codes.Unauthenticated, "user not authenticated")
+	}
+	if !isSuperUser(user) {
+		return nil, status.Errorf(codes.PermissionDenied, "permission denied")
+	}
+
+	state := store.Normal
+	limit := DefaultPageSize
+	memoFind := &store.FindMemo{
+		ExcludeComments: true,
+		RowStatus:       &state,
+		Limit:           &limit,
+	}
+	if request.Filter != "" {
+		if err := s.validateFilter(ctx, request.Filter); err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid filter: %v", err)
+		}
+		memoFind.Filter = &request.Filter
+	}
+	workspaceMemoRelatedSetting, err := s.Store.GetWorkspaceMemoRelatedSetting(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get workspace memo related setting")
+	}
+	memoFind.OrderByUpdatedTs = workspaceMemoRelatedSetting.DisplayWithUpdateTime
+
+	plan, err := s.Store.ExplainListMemos(ctx, memoFind, request.Analyze)
+	if err != nil {
+		if errors.Is(err, store.ErrQueryExplainDisabled) {
+			return nil, status.Errorf(codes.FailedPrecondition, "query explain is disabled in the workspace general setting")
+		}
+		return nil, status.Errorf(codes.Internal, "failed to explain memo list: %v", err)
+	}
+	args := make([]string, 0, len(plan.Args))
+	for _, arg := range plan.Args {
+		args = append(args, fmt.Sprint(arg))
+	}
+	return &v1pb.ExplainListMemosResponse{
+		Sql:  plan.SQL,
+		Args: args,
+		Plan: plan.Plan,
+	}, nil
+}
+
 func (s *APIV1Service) GetMemo(ctx context.Context, request *v1pb.GetMemoRequest) (*v1pb.Memo, error) {
 	memoUID, err := ExtractMemoUIDFromName(request.Name)
 	if err != nil {
@@ -261,18 +413,19 @@ func (s *APIV1Service) UpdateMemo(ctx context.Context, request *v1pb.UpdateMemoR
 		return nil, status.Errorf(codes.PermissionDenied, "permission denied")
 	}
 
+	// Check the expected version before the resources and relations are set, which the
+	// update of the memo itself does not guard.
+	if request.ExpectedVersion != nil && *request.ExpectedVersion != memo.Version {
+		return nil, status.Errorf(codes.Aborted, "memo has been updated to version %d", memo.Version)
+	}
+
 	update := &store.UpdateMemo{
-		ID: memo.ID,
+		ID:              memo.ID,
+		EditorID:        user.ID,
+		ExpectedVersion: request.ExpectedVersion,
 	}
 	for _, path := range request.UpdateMask.Paths {
 		if path == "content" {
-			contentLengthLimit, err := s.getContentLengthLimit(ctx)
-			if err != nil {
-				return nil, status.Errorf(codes.Internal, "failed to get content length limit")
-			}
-			if len(request.Memo.Content) > contentLengthLimit {
-				return nil, status.Errorf(codes.InvalidArgument, "content too long (max %d characters)", contentLengthLimit)
-			}
 			memo.Content = request.Memo.Content
 			if err := memopayload.RebuildMemoPayload(memo); err != nil {
 				return nil, status.Errorf(codes.Internal, "failed to rebuild memo payload: %v", err)
@@ -294,6 +447,11 @@ func (s *APIV1Service) UpdateMemo(ctx context.Context, request *v1pb.UpdateMemoR
 		} else if path == "state" {
 			rowStatus := convertStateToStore(request.Memo.State)
 			update.RowStatus = &rowStatus
+			// Archiving or restoring is a change that sync clients pick up by the update time.
+			if rowStatus != memo.RowStatus && update.UpdatedTs == nil {
+				updatedTs := time.Now().Unix()
+				update.UpdatedTs = &updatedTs
+			}
 		} else if path == "create_time" {
 			createdTs := request.Memo.CreateTime.AsTime().Unix()
 			update.CreatedTs = &createdTs
@@ -338,6 +496,15 @@ func (s *APIV1Service) UpdateMemo(ctx context.Context, request *v1pb.UpdateMemoR
 	}
 
 	if err = s.Store.UpdateMemo(ctx, update); err != nil {
+		if isContentTooLongError(err) {
+			return nil, status.Errorf(codes.InvalidArgument, "%v", err)
+		}
+		if errors.Is(err, store.ErrInvalidMemoLocation) {
+			return nil, status.Errorf(codes.InvalidArgument, "%v", err)
+		}
+		if errors.Is(err, store.ErrMemoVersionConflict) {
+			return nil, status.Errorf(codes.Aborted, "memo has been updated meanwhile")
+		}
 		return nil, status.Errorf(codes.Internal, "failed to update memo")
 	}
 
@@ -390,7 +557,7 @@ func (s *APIV1Service) DeleteMemo(ctx context.Context, request *v1pb.DeleteMemoR
 		}
 	}
 
-	if err = s.Store.DeleteMemo(ctx, &store.DeleteMemo{ID: memo.ID}); err != nil {
+	if err = s.Store.DeleteMemo(ctx, &store.DeleteMemo{ID: memo.ID, Hard: true}); err != nil {
 		return nil, status.Errorf(codes.Internal, "failed to delete memo")
 	}
 
@@ -417,7 +584,7 @@ func (s *APIV1Service) DeleteMemo(ctx context.Context, request *v1pb.DeleteMemoR
 		return nil, status.Errorf(codes.Internal, "failed to list memo comments")
 	}
 	for _, relation := range relations {
-		if err := s.Store.DeleteMemo(ctx, &store.DeleteMemo{ID: relation.MemoID}); err != nil {
+		if err := s.Store.DeleteMemo(ctx, &store.DeleteMemo{ID: relation.MemoID, Hard: true}); err != nil {
 			return nil, status.Errorf(codes.Internal, "failed to delete memo comment")
 		}
 	}
@@ -591,9 +758,10 @@ func (s *APIV1Service) RenameMemoTag(ctx context.Context, request *v1pb.RenameMe
 			return nil, status.Errorf(codes.Internal, "failed to rebuild memo payload: %v", err)
 		}
 		if err := s.Store.UpdateMemo(ctx, &store.UpdateMemo{
-			ID:      memo.ID,
-			Content: &memo.Content,
-			Payload: memo.Payload,
+			ID:       memo.ID,
+			Content:  &memo.Content,
+			Payload:  memo.Payload,
+			EditorID: user.ID,
 		}); err != nil {
 			return nil, status.Errorf(codes.Internal, "failed to update memo: %v", err)
 		}
@@ -629,7 +797,7 @@ func (s *APIV1Service) DeleteMemoTag(ctx context.Context, request *v1pb.DeleteMe
 
 	for _, memo := range memos {
 		if request.DeleteRelatedMemos {
-			err := s.Store.DeleteMemo(ctx, &store.DeleteMemo{ID: memo.ID})
+			err := s.Store.DeleteMemo(ctx, &store.DeleteMemo{ID: memo.ID, Hard: true})
 			if err != nil {
 				return nil, status.Errorf(codes.Internal, "failed to delete memo")
 			}
@@ -648,12 +816,9 @@ func (s *APIV1Service) DeleteMemoTag(ctx context.Context, request *v1pb.DeleteMe
 	return &emptypb.Empty{}, nil
 }
 
-func (s *APIV1Service) getContentLengthLimit(ctx context.Context) (int, error) {
-	workspaceMemoRelatedSetting, err := s.Store.GetWorkspaceMemoRelatedSetting(ctx)
-	if err != nil {
-		return 0, status.Errorf(codes.Internal, "failed to get workspace memo related setting")
-	}
-	return int(workspaceMemoRelatedSetting.ContentLengthLimit), nil
+func isContentTooLongError(err error) bool {
+	var contentTooLongErr *store.ContentTooLongError
+	return errors.As(err, &contentTooLongErr)
 }
 
 // DispatchMemoCreatedWebhook dispatches webhook when memo is created.
@@ -682,6 +847,17 @@ func (s *APIV1Service) dispatchMemoRelatedWebhook(ctx context.Context, memo *v1p
 	if err != nil {
 		return err
 	}
+	if len(webhooks) == 0 {
+		return nil
+	}
+	workspaceMemoRelatedSetting, err := s.Store.GetWorkspaceMemoRelatedSetting(ctx)
+	if err != nil {
+		return errors.Wrap(err, "failed to get workspace memo related setting")
+	}
+	currentUser, err := s.GetCurrentUser(ctx)
+	if err != nil {
+		return errors.Wrap(err, "failed to get current user")
+	}
 	for _, hook := range webhooks {
 		payload, err := convertMemoToWebhookPayload(memo)
 		if err != nil {
@@ -689,9 +865,19 @@ func (s *APIV1Service) dispatchMemoRelatedWebhook(ctx context.Context, memo *v1p
 		}
 		payload.ActivityType = activityType
 		payload.Url = hook.URL
-		if err := webhook.Post(payload); err != nil {
-			return errors.Wrap(err, "failed to post webhook")
+		if currentUser != nil {
+			payload.Actor = fmt.Sprintf("%s%d", UserNamePrefix, currentUser.ID)
+		}
+		options := webhook.Options{
+			Secret:           hook.Secret,
+			AllowInternalIPs: workspaceMemoRelatedSetting.WebhookAllowInternalIps,
 		}
+		// Post in the background, as failed requests are retried with backoff.
+		go func() {
+			if err := webhook.Post(payload, options); err != nil {
+				slog.Warn("Failed to post webhook", slog.String("url", payload.Url), slog.Any("err", err))
+			}
+		}()
 	}
 	return nil
 }
@@ -37,6 +37,7 @@ func (s *APIV1Service) convertMemoFromStore(ctx context.Context, memo *store.Mem
 		Content:     memo.Content,
 		Visibility:  convertVisibilityFromStore(memo.Visibility),
 		Pinned:      memo.Pinned,
+		Version:     memo.Version,
 	}
 	if memo.Payload != nil {
 		memoMessage.Tags = memo.Payload.Tags
@@ -67,11 +68,30 @@ func (s *APIV1Service) convertMemoFromStore(ctx context.Context, memo *store.Mem
 	}
 	memoMessage.Resources = listMemoResourcesResponse.Resources
 
-	listMemoReactionsResponse, err := s.ListMemoReactions(ctx, &v1pb.ListMemoReactionsRequest{Name: name})
-	if err != nil {
-		return nil, errors.Wrap(err, "failed to list memo reactions")
+	// The reactions are loaded with the memo when it is listed, and only listed for it otherwise.
+	if memo.Reactions != nil {
+		memoMessage.Reactions = []*v1pb.Reaction{}
+		for _, reaction := range memo.Reactions {
+			reactionMessage, err := s.convertReactionFromStore(ctx, reaction)
+			if err != nil {
+				return nil, errors.Wrap(err, "failed to convert reaction")
+			}
+			memoMessage.Reactions = append(memoMessage.Reactions, reactionMessage)
+		}
+	} else {
+		listMemoReactionsResponse, err := s.ListMemoReactions(ctx, &v1pb.ListMemoReactionsRequest{Name: name})
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to list memo reactions")
+		}
+		memoMessage.Reactions = listMemoReactionsResponse.Reactions
+	}
+	for _, summary := range memo.ReactionSummaries {
+		memoMessage.ReactionSummaries = append(memoMessage.ReactionSummaries, &v1pb.ReactionSummary{
+			ReactionType:  summary.ReactionType,
+			Count:         summary.Count,
+			ViewerReacted: summary.ViewerReacted,
+		})
 	}
-	memoMessage.Reactions = listMemoReactionsResponse.Reactions
 
 	nodes, err := parser.Parse(tokenizer.Tokenize(memo.Content))
 	if err != nil {
@@ -0,0 +1,112 @@
+package v1
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	v1pb "github.com/usememos/memos/proto/gen/api/v1"
+	"github.com/usememos/memos/store"
+	teststore "github.com/usememos/memos/store/test"
+)
+
+func TestListMemosPageInfo(t *testing.T) {
+	ctx := context.Background()
+	ts := teststore.NewTestingStore(ctx, t)
+	defer ts.Close()
+	user, err := ts.CreateUser(ctx, &store.User{Username: "test", Role: store.RoleHost, Email: "test@test.com"})
+	require.NoError(t, err)
+	s := &APIV1Service{Store: ts}
+	userCtx := context.WithValue(ctx, usernameContextKey, user.Username)
+	for i := 0; i < 5; i++ {
+		_, err := ts.CreateMemo(ctx, &store.Memo{UID: fmt.Sprintf("memo-%d", i), CreatorID: user.ID, Content: "test", Visibility: store.Private})
+		require.NoError(t, err)
+	}
+
+	// The page info is opt-in.
+	response, err := s.ListMemos(userCtx, &v1pb.ListMemosRequest{PageSize: 2})
+	require.NoError(t, err)
+	require.Nil(t, response.PageInfo)
+
+	// Pages of 2 memos: has more on the first two pages, not on the last one.
+	pageToken := ""
+	hasMores := []bool{}
+	for _, includeTotalSize := range []bool{true, false, true} {
+		response, err := s.ListMemos(userCtx, &v1pb.ListMemosRequest{
+			PageSize:         2,
+			PageToken:        pageToken,
+			IncludePageInfo:  true,
+			IncludeTotalSize: includeTotalSize,
+		})
+		require.NoError(t, err)
+		require.NotNil(t, response.PageInfo)
+		require.Equal(t, response.NextPageToken, response.PageInfo.NextPageToken)
+		if includeTotalSize {
+			require.Equal(t, int32(5), response.PageInfo.GetTotalSize())
+		} else {
+			require.Nil(t, response.PageInfo.TotalSize)
+		}
+		hasMores = append(hasMores, response.PageInfo.HasMore)
+		pageToken = response.NextPageToken
+	}
+	require.Equal(t, []bool{true, true, false}, hasMores)
+
+	// An exactly full last page has no more either.
+	response, err = s.ListMemos(userCtx, &v1pb.ListMemosRequest{PageSize: 5, IncludePageInfo: true})
+	require.NoError(t, err)
+	require.Len(t, response.Memos, 5)
+	require.False(t, response.PageInfo.HasMore)
+}
+
+// reactionQueryCountingDriver counts the queries for reactions.
+type reactionQueryCountingDriver struct {
+	store.Driver
+	queries int
+}
+
+func (d *reactionQueryCountingDriver) ListReactions(ctx context.Context, find *store.FindReaction) ([]*store.Reaction, error) {
+	d.queries++
+	return d.Driver.ListReactions(ctx, find)
+}
+
+func (d *reactionQueryCountingDriver) ListReactionSummaries(ctx context.Context, contentIDList []string, viewerID *int32) ([]*store.ReactionSummary, error) {
+	d.queries++
+	return d.Driver.ListReactionSummaries(ctx, contentIDList, viewerID)
+}
+
+func TestListMemosReactionQueries(t *testing.T) {
+	ctx := context.Background()
+	ts := teststore.NewTestingStore(ctx, t)
+	defer ts.Close()
+	user, err := ts.CreateUser(ctx, &store.User{Username: "test", Role: store.RoleHost, Email: "test@test.com"})
+	require.NoError(t, err)
+	for i := 0; i < 5; i++ {
+		uid := fmt.Sprintf("memo-%d", i)
+		_, err := ts.CreateMemo(ctx, &store.Memo{UID: uid, CreatorID: user.ID, Content: "test", Visibility: store.Private})
+		require.NoError(t, err)
+		for _, reactionType := range []string{"👍", "🎉"}[:i%3] {
+			_, err := ts.UpsertReaction(ctx, &store.Reaction{CreatorID: user.ID, ContentID: MemoNamePrefix + uid, ReactionType: reactionType})
+			require.NoError(t, err)
+		}
+	}
+	driver := &reactionQueryCountingDriver{Driver: ts.GetDriver()}
+	s := &APIV1Service{Store: store.New(driver, ts.Profile)}
+	userCtx := context.WithValue(ctx, usernameContextKey, user.Username)
+
+	// The reactions of a page are loaded at once, whatever the number of memos.
+	response, err := s.ListMemos(userCtx, &v1pb.ListMemosRequest{PageSize: 5})
+	require.NoError(t, err)
+	require.Len(t, response.Memos, 5)
+	require.Equal(t, 2, driver.queries)
+	reactionCounts := map[string]int{}
+	for _, memo := range response.Memos {
+		reactionCounts[memo.Name] = len(memo.Reactions)
+		require.Len(t, memo.ReactionSummaries, len(memo.Reactions))
+		for _, reaction := range memo.Reactions {
+			require.Equal(t, memo.Name, reaction.ContentId)
+		}
+	}
+	require.Equal(t, map[string]int{"memos/memo-0": 0, "memos/memo-1": 1, "memos/memo-2": 2, "memos/memo-3": 0, "memos/memo-4": 1}, reactionCounts)
+}
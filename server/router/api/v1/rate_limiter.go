@@ -0,0 +1,117 @@
+package v1
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+)
+
+// maxRateLimiterKeys is the number of keys above which the memory rate limiter drops
+// the buckets that have refilled, which limit nothing anymore.
+const maxRateLimiterKeys = 10000
+
+// RateLimit allows Burst actions at once, refilled at Rate actions per second.
+type RateLimit struct {
+	Rate  float64
+	Burst int
+}
+
+// RateLimiter limits the rate of actions per key, e.g. per user. It is an interface so
+// that the buckets can be kept in a shared store when running several instances.
+type RateLimiter interface {
+	// Allow takes a token for the key under the given limit. When none is left, it
+	// returns false and how long to wait before the next token is available.
+	Allow(ctx context.Context, key string, limit RateLimit) (bool, time.Duration, error)
+}
+
+// memoryRateLimiter is a RateLimiter with token buckets kept in memory.
+type memoryRateLimiter struct {
+	now func() time.Time
+
+	mu      sync.Mutex
+	buckets map[string]*tokenBucket
+}
+
+type tokenBucket struct {
+	tokens    float64
+	updatedAt time.Time
+}
+
+// NewMemoryRateLimiter returns a RateLimiter that keeps its buckets in memory.
+func NewMemoryRateLimiter() RateLimiter {
+	return &memoryRateLimiter{
+		now:     time.Now,
+		buckets: map[string]*tokenBucket{},
+	}
+}
+
+func (l *memoryRateLimiter) Allow(_ context.Context, key string, limit RateLimit) (bool, time.Duration, error) {
+	if limit.Rate <= 0 || limit.Burst <= 0 {
+		return false, 0, fmt.Errorf("invalid rate limit %v", limit)
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	bucket, ok := l.buckets[key]
+	if !ok {
+		if len(l.buckets) >= maxRateLimiterKeys {
+			l.dropFullBuckets(now, limit)
+		}
+		bucket = &tokenBucket{tokens: float64(limit.Burst), updatedAt: now}
+		l.buckets[key] = bucket
+	}
+	bucket.tokens = min(float64(limit.Burst), bucket.tokens+now.Sub(bucket.updatedAt).Seconds()*limit.Rate)
+	bucket.updatedAt = now
+	if bucket.tokens >= 1 {
+		bucket.tokens--
+		return true, 0, nil
+	}
+	retryAfter := time.Duration((1 - bucket.tokens) / limit.Rate * float64(time.Second))
+	return false, retryAfter, nil
+}
+
+func (l *memoryRateLimiter) dropFullBuckets(now time.Time, limit RateLimit) {
+	for key, bucket := range l.buckets {
+		if bucket.tokens+now.Sub(bucket.updatedAt).Seconds()*limit.Rate >= float64(limit.Burst) {
+			delete(l.buckets, key)
+		}
+	}
+}
+
+// getClientIP returns the IP of the client of the request. Requests through the gateway
+// carry it in the X-Forwarded-For header, to which the gateway appends the remote address
+// of the request. Only that last entry is used, as the client can send any entries before it.
+func getClientIP(ctx context.Context) string {
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if values := md.Get("x-forwarded-for"); len(values) > 0 {
+			entries := strings.Split(values[len(values)-1], ",")
+			if clientIP := strings.TrimSpace(entries[len(entries)-1]); clientIP != "" {
+				return clientIP
+			}
+		}
+	}
+	if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+		if host, _, err := net.SplitHostPort(p.Addr.String()); err == nil {
+			return host
+		}
+		return p.Addr.String()
+	}
+	return ""
+}
+
+// outgoingHeaderMatcher passes the Retry-After hint of rate limited requests on to the
+// gateway response as is, and other metadata with the default prefix.
+func outgoingHeaderMatcher(key string) (string, bool) {
+	if key == "retry-after" {
+		return "Retry-After", true
+	}
+	return runtime.MetadataHeaderPrefix + key, true
+}
@@ -0,0 +1,88 @@
+package v1
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+)
+
+func TestMemoryRateLimiter(t *testing.T) {
+	ctx := context.Background()
+	now := time.Unix(1700000000, 0)
+	limiter := &memoryRateLimiter{
+		now:     func() time.Time { return now },
+		buckets: map[string]*tokenBucket{},
+	}
+	// One request per second, three at once.
+	limit := RateLimit{Rate: 1, Burst: 3}
+
+	for i := 0; i < 3; i++ {
+		allowed, _, err := limiter.Allow(ctx, "user:1", limit)
+		require.NoError(t, err)
+		require.True(t, allowed)
+	}
+	allowed, retryAfter, err := limiter.Allow(ctx, "user:1", limit)
+	require.NoError(t, err)
+	require.False(t, allowed)
+	require.Equal(t, time.Second, retryAfter)
+
+	// Other keys have buckets of their own.
+	allowed, _, err = limiter.Allow(ctx, "user:2", limit)
+	require.NoError(t, err)
+	require.True(t, allowed)
+
+	// The bucket refills over time, up to the burst.
+	now = now.Add(500 * time.Millisecond)
+	allowed, retryAfter, err = limiter.Allow(ctx, "user:1", limit)
+	require.NoError(t, err)
+	require.False(t, allowed)
+	require.Equal(t, 500*time.Millisecond, retryAfter)
+	now = now.Add(500 * time.Millisecond)
+	allowed, _, err = limiter.Allow(ctx, "user:1", limit)
+	require.NoError(t, err)
+	require.True(t, allowed)
+	now = now.Add(time.Hour)
+	for i := 0; i < 3; i++ {
+		allowed, _, err := limiter.Allow(ctx, "user:1", limit)
+		require.NoError(t, err)
+		require.True(t, allowed)
+	}
+	allowed, _, err = limiter.Allow(ctx, "user:1", limit)
+	require.NoError(t, err)
+	require.False(t, allowed)
+
+	_, _, err = limiter.Allow(ctx, "user:1", RateLimit{})
+	require.Error(t, err)
+}
+
+func TestGetClientIP(t *testing.T) {
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("x-forwarded-for", "203.0.113.7"))
+	require.Equal(t, "203.0.113.7", getClientIP(ctx))
+
+	// Entries sent by the client before the one appended by the gateway do not change the IP.
+	for _, spoofed := range []string{"198.51.100.1", "198.51.100.2, 198.51.100.3"} {
+		ctx = metadata.NewIncomingContext(context.Background(), metadata.Pairs("x-forwarded-for", spoofed+", 203.0.113.7"))
+		require.Equal(t, "203.0.113.7", getClientIP(ctx))
+		ctx = metadata.NewIncomingContext(context.Background(), metadata.Pairs("x-forwarded-for", spoofed, "x-forwarded-for", "203.0.113.7"))
+		require.Equal(t, "203.0.113.7", getClientIP(ctx))
+	}
+
+	ctx = peer.NewContext(context.Background(), &peer.Peer{Addr: &net.TCPAddr{IP: net.ParseIP("192.0.2.1"), Port: 5230}})
+	require.Equal(t, "192.0.2.1", getClientIP(ctx))
+
+	require.Equal(t, "", getClientIP(context.Background()))
+}
+
+func TestOutgoingHeaderMatcher(t *testing.T) {
+	header, ok := outgoingHeaderMatcher("retry-after")
+	require.True(t, ok)
+	require.Equal(t, "Retry-After", header)
+	header, ok = outgoingHeaderMatcher("x-trace")
+	require.True(t, ok)
+	require.Equal(t, "Grpc-Metadata-x-trace", header)
+}
@@ -71,9 +71,6 @@ func (s *APIV1Service) CreateResource(ctx context.Context, request *v1pb.CreateR
 	}
 	create.Size = int64(size)
 	create.Blob = request.Resource.Content
-	if err := SaveResourceBlob(ctx, s.Store, create); err != nil {
-		return nil, status.Errorf(codes.Internal, "failed to save resource blob: %v", err)
-	}
 
 	if request.Resource.Memo != nil {
 		memoUID, err := ExtractMemoUIDFromName(*request.Resource.Memo)
@@ -86,10 +83,24 @@ func (s *APIV1Service) CreateResource(ctx context.Context, request *v1pb.CreateR
 		}
 		create.MemoID = &memo.ID
 	}
-	resource, err := s.Store.CreateResource(ctx, create)
+
+	// Blobs stored as local files or s3 objects are shared by the resources of the user with the same content.
+	if workspaceStorageSetting.StorageType == storepb.WorkspaceStorageSetting_LOCAL || workspaceStorageSetting.StorageType == storepb.WorkspaceStorageSetting_S3 {
+		create.ContentHash = store.HashResourceBlob(create.Blob)
+	}
+	resource, err := s.Store.CreateDuplicateResource(ctx, create)
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "failed to create resource: %v", err)
 	}
+	if resource == nil {
+		if err := SaveResourceBlob(ctx, s.Store, create); err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to save resource blob: %v", err)
+		}
+		resource, err = s.Store.CreateResource(ctx, create)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to create resource: %v", err)
+		}
+	}
 
 	return s.convertResourceFromStore(ctx, resource), nil
 }
@@ -17,7 +17,9 @@ import (
 	"google.golang.org/genproto/googleapis/api/httpbody"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
 	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/structpb"
 	"google.golang.org/protobuf/types/known/timestamppb"
 
 	"github.com/usememos/memos/internal/util"
@@ -282,11 +284,60 @@ func (s *APIV1Service) GetUserSetting(ctx context.Context, _ *v1pb.GetUserSettin
 			userSettingMessage.Appearance = setting.GetAppearance()
 		} else if setting.Key == storepb.UserSettingKey_MEMO_VISIBILITY {
 			userSettingMessage.MemoVisibility = setting.GetMemoVisibility()
+		} else if setting.Key == storepb.UserSettingKey_PREFERENCES {
+			preferences := &structpb.Struct{}
+			if err := protojson.Unmarshal([]byte(setting.GetPreferences()), preferences); err != nil {
+				return nil, status.Errorf(codes.Internal, "failed to unmarshal preferences: %v", err)
+			}
+			userSettingMessage.Preferences = preferences
+		} else if setting.Key == storepb.UserSettingKey_TIMEZONE {
+			userSettingMessage.Timezone = setting.GetTimezone()
 		}
 	}
 	return userSettingMessage, nil
 }
 
+// secretUserSettingKeys are the keys of the user settings that hold secrets, such as access tokens.
+var secretUserSettingKeys = []storepb.UserSettingKey{
+	storepb.UserSettingKey_ACCESS_TOKENS,
+}
+
+func (s *APIV1Service) SearchUserSettings(ctx context.Context, request *v1pb.SearchUserSettingsRequest) (*v1pb.SearchUserSettingsResponse, error) {
+	currentUser, err := s.GetCurrentUser(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get user: %v", err)
+	}
+	if currentUser == nil || (currentUser.Role != store.RoleHost && currentUser.Role != store.RoleAdmin) {
+		return nil, status.Errorf(codes.PermissionDenied, "permission denied")
+	}
+	key, ok := storepb.UserSettingKey_value[request.Key]
+	if !ok || key == int32(storepb.UserSettingKey_USER_SETTING_KEY_UNSPECIFIED) {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid user setting key: %s", request.Key)
+	}
+	// Searching the settings holding secrets would reveal them one character at a time.
+	if slices.Contains(secretUserSettingKeys, storepb.UserSettingKey(key)) {
+		return nil, status.Errorf(codes.PermissionDenied, "user setting %s is not searchable", request.Key)
+	}
+	if request.Value == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "value is required")
+	}
+
+	userSettings, err := s.Store.ListUserSettings(ctx, &store.FindUserSetting{
+		Key:           storepb.UserSettingKey(key),
+		ValueContains: &request.Value,
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list user settings: %v", err)
+	}
+	response := &v1pb.SearchUserSettingsResponse{
+		Users: []string{},
+	}
+	for _, userSetting := range userSettings {
+		response.Users = append(response.Users, fmt.Sprintf("%s%d", UserNamePrefix, userSetting.UserId))
+	}
+	return response, nil
+}
+
 func (s *APIV1Service) UpdateUserSetting(ctx context.Context, request *v1pb.UpdateUserSettingRequest) (*v1pb.UserSetting, error) {
 	user, err := s.GetCurrentUser(ctx)
 	if err != nil {
@@ -319,6 +370,9 @@ func (s *APIV1Service) UpdateUserSetting(ctx context.Context, request *v1pb.Upda
 				return nil, status.Errorf(codes.Internal, "failed to upsert user setting: %v", err)
 			}
 		} else if field == "memo_visibility" {
+			if visibility := v1pb.Visibility(v1pb.Visibility_value[request.Setting.MemoVisibility]); visibility == v1pb.Visibility_VISIBILITY_UNSPECIFIED {
+				return nil, status.Errorf(codes.InvalidArgument, "invalid memo visibility: %s", request.Setting.MemoVisibility)
+			}
 			if _, err := s.Store.UpsertUserSetting(ctx, &storepb.UserSetting{
 				UserId: user.ID,
 				Key:    storepb.UserSettingKey_MEMO_VISIBILITY,
@@ -328,6 +382,33 @@ func (s *APIV1Service) UpdateUserSetting(ctx context.Context, request *v1pb.Upda
 			}); err != nil {
 				return nil, status.Errorf(codes.Internal, "failed to upsert user setting: %v", err)
 			}
+		} else if field == "preferences" {
+			preferences, err := protojson.Marshal(request.Setting.Preferences)
+			if err != nil {
+				return nil, status.Errorf(codes.InvalidArgument, "invalid preferences: %v", err)
+			}
+			if _, err := s.Store.UpsertUserSetting(ctx, &storepb.UserSetting{
+				UserId: user.ID,
+				Key:    storepb.UserSettingKey_PREFERENCES,
+				Value: &storepb.UserSetting_Preferences{
+					Preferences: string(preferences),
+				},
+			}); err != nil {
+				return nil, status.Errorf(codes.Internal, "failed to upsert user setting: %v", err)
+			}
+		} else if field == "timezone" {
+			if _, err := time.LoadLocation(request.Setting.Timezone); err != nil || request.Setting.Timezone == "Local" {
+				return nil, status.Errorf(codes.InvalidArgument, "invalid timezone: %s", request.Setting.Timezone)
+			}
+			if _, err := s.Store.UpsertUserSetting(ctx, &storepb.UserSetting{
+				UserId: user.ID,
+				Key:    storepb.UserSettingKey_TIMEZONE,
+				Value: &storepb.UserSetting_Timezone{
+					Timezone: request.Setting.Timezone,
+				},
+			}); err != nil {
+				return nil, status.Errorf(codes.Internal, "failed to upsert user setting: %v", err)
+			}
 		} else {
 			return nil, status.Errorf(codes.InvalidArgument, "invalid update path: %s", field)
 		}
@@ -381,6 +462,8 @@ func (s *APIV1Service) ListUserAccessTokens(ctx context.Context, request *v1pb.L
 			AccessToken: userAccessToken.AccessToken,
 			Description: userAccessToken.Description,
 			IssuedAt:    timestamppb.New(claims.IssuedAt.Time),
+			LastUsedAt:  userAccessToken.LastUsedTime,
+			Revoked:     userAccessToken.Revoked,
 		}
 		if claims.ExpiresAt != nil {
 			userAccessToken.ExpiresAt = timestamppb.New(claims.ExpiresAt.Time)
@@ -472,50 +555,49 @@ func (s *APIV1Service) DeleteUserAccessToken(ctx context.Context, request *v1pb.
 		return nil, status.Errorf(codes.PermissionDenied, "permission denied")
 	}
 
+	if err := s.Store.RemoveUserAccessToken(ctx, currentUser.ID, request.AccessToken); err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to remove access token: %v", err)
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+func (s *APIV1Service) RevokeUserAccessToken(ctx context.Context, request *v1pb.RevokeUserAccessTokenRequest) (*emptypb.Empty, error) {
+	userID, err := ExtractUserIDFromName(request.Name)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid user name: %v", err)
+	}
+	currentUser, err := s.GetCurrentUser(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get current user: %v", err)
+	}
+	if currentUser == nil {
+		return nil, status.Errorf(codes.PermissionDenied, "permission denied")
+	}
+	if currentUser.ID != userID {
+		return nil, status.Errorf(codes.PermissionDenied, "permission denied")
+	}
+
 	userAccessTokens, err := s.Store.GetUserAccessTokens(ctx, currentUser.ID)
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "failed to list access tokens: %v", err)
 	}
-	updatedUserAccessTokens := []*storepb.AccessTokensUserSetting_AccessToken{}
-	for _, userAccessToken := range userAccessTokens {
-		if userAccessToken.AccessToken == request.AccessToken {
-			continue
-		}
-		updatedUserAccessTokens = append(updatedUserAccessTokens, userAccessToken)
-	}
-	if _, err := s.Store.UpsertUserSetting(ctx, &storepb.UserSetting{
-		UserId: currentUser.ID,
-		Key:    storepb.UserSettingKey_ACCESS_TOKENS,
-		Value: &storepb.UserSetting_AccessTokens{
-			AccessTokens: &storepb.AccessTokensUserSetting{
-				AccessTokens: updatedUserAccessTokens,
-			},
-		},
-	}); err != nil {
-		return nil, status.Errorf(codes.Internal, "failed to upsert user setting: %v", err)
+	if !slices.ContainsFunc(userAccessTokens, func(t *storepb.AccessTokensUserSetting_AccessToken) bool {
+		return t.AccessToken == request.AccessToken
+	}) {
+		return nil, status.Errorf(codes.NotFound, "access token not found")
+	}
+	if err := s.Store.RevokeUserAccessToken(ctx, currentUser.ID, request.AccessToken); err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to revoke access token: %v", err)
 	}
 
 	return &emptypb.Empty{}, nil
 }
 
 func (s *APIV1Service) UpsertAccessTokenToStore(ctx context.Context, user *store.User, accessToken, description string) error {
-	userAccessTokens, err := s.Store.GetUserAccessTokens(ctx, user.ID)
-	if err != nil {
-		return errors.Wrap(err, "failed to get user access tokens")
-	}
-	userAccessToken := storepb.AccessTokensUserSetting_AccessToken{
+	if err := s.Store.AddUserAccessToken(ctx, user.ID, &storepb.AccessTokensUserSetting_AccessToken{
 		AccessToken: accessToken,
 		Description: description,
-	}
-	userAccessTokens = append(userAccessTokens, &userAccessToken)
-	if _, err := s.Store.UpsertUserSetting(ctx, &storepb.UserSetting{
-		UserId: user.ID,
-		Key:    storepb.UserSettingKey_ACCESS_TOKENS,
-		Value: &storepb.UserSetting_AccessTokens{
-			AccessTokens: &storepb.AccessTokensUserSetting{
-				AccessTokens: userAccessTokens,
-			},
-		},
 	}); err != nil {
 		return errors.Wrap(err, "failed to upsert user setting")
 	}
@@ -0,0 +1,48 @@
+package v1
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	v1pb "github.com/usememos/memos/proto/gen/api/v1"
+	storepb "github.com/usememos/memos/proto/gen/store"
+	"github.com/usememos/memos/store"
+	teststore "github.com/usememos/memos/store/test"
+)
+
+func TestSearchUserSettings(t *testing.T) {
+	ctx := context.Background()
+	ts := teststore.NewTestingStore(ctx, t)
+	defer ts.Close()
+	host, err := ts.CreateUser(ctx, &store.User{Username: "host", Role: store.RoleHost, Email: "host@test.com"})
+	require.NoError(t, err)
+	_, err = ts.UpsertUserSetting(ctx, &storepb.UserSetting{
+		UserId: host.ID,
+		Key:    storepb.UserSettingKey_LOCALE,
+		Value:  &storepb.UserSetting_Locale{Locale: "zh-Hans"},
+	})
+	require.NoError(t, err)
+	_, err = ts.UpsertUserSetting(ctx, &storepb.UserSetting{
+		UserId: host.ID,
+		Key:    storepb.UserSettingKey_ACCESS_TOKENS,
+		Value: &storepb.UserSetting_AccessTokens{AccessTokens: &storepb.AccessTokensUserSetting{
+			AccessTokens: []*storepb.AccessTokensUserSetting_AccessToken{{AccessToken: "secret-token"}},
+		}},
+	})
+	require.NoError(t, err)
+	s := &APIV1Service{Store: ts}
+	hostCtx := context.WithValue(ctx, usernameContextKey, host.Username)
+
+	response, err := s.SearchUserSettings(hostCtx, &v1pb.SearchUserSettingsRequest{Key: storepb.UserSettingKey_LOCALE.String(), Value: "zh"})
+	require.NoError(t, err)
+	require.Equal(t, []string{fmt.Sprintf("%s%d", UserNamePrefix, host.ID)}, response.Users)
+
+	// Secrets are not searchable, not even by the host.
+	_, err = s.SearchUserSettings(hostCtx, &v1pb.SearchUserSettingsRequest{Key: storepb.UserSettingKey_ACCESS_TOKENS.String(), Value: "secret"})
+	require.Equal(t, codes.PermissionDenied, status.Code(err))
+}
@@ -45,11 +45,7 @@ func (s *APIV1Service) ListShortcuts(ctx context.Context, request *v1pb.ListShor
 	shortcutsUserSetting := userSetting.GetShortcuts()
 	shortcuts := []*v1pb.Shortcut{}
 	for _, shortcut := range shortcutsUserSetting.GetShortcuts() {
-		shortcuts = append(shortcuts, &v1pb.Shortcut{
-			Id:     shortcut.GetId(),
-			Title:  shortcut.GetTitle(),
-			Filter: shortcut.GetFilter(),
-		})
+		shortcuts = append(shortcuts, convertShortcutFromStore(shortcut))
 	}
 
 	return &v1pb.ListShortcutsResponse{
@@ -82,13 +78,6 @@ func (s *APIV1Service) CreateShortcut(ctx context.Context, request *v1pb.CreateS
 	if err := s.validateFilter(ctx, newShortcut.Filter); err != nil {
 		return nil, status.Errorf(codes.InvalidArgument, "invalid filter: %v", err)
 	}
-	if request.ValidateOnly {
-		return &v1pb.Shortcut{
-			Id:     newShortcut.GetId(),
-			Title:  newShortcut.GetTitle(),
-			Filter: newShortcut.GetFilter(),
-		}, nil
-	}
 
 	userSetting, err := s.Store.GetUserSetting(ctx, &store.FindUserSetting{
 		UserID: &userID,
@@ -97,6 +86,12 @@ func (s *APIV1Service) CreateShortcut(ctx context.Context, request *v1pb.CreateS
 	if err != nil {
 		return nil, err
 	}
+	if findShortcutByTitle(userSetting.GetShortcuts().GetShortcuts(), newShortcut.Title) != nil {
+		return nil, status.Errorf(codes.AlreadyExists, "shortcut %q already exists", newShortcut.Title)
+	}
+	if request.ValidateOnly {
+		return convertShortcutFromStore(newShortcut), nil
+	}
 	if userSetting == nil {
 		userSetting = &storepb.UserSetting{
 			UserId: userID,
@@ -122,11 +117,7 @@ func (s *APIV1Service) CreateShortcut(ctx context.Context, request *v1pb.CreateS
 		return nil, err
 	}
 
-	return &v1pb.Shortcut{
-		Id:     request.Shortcut.GetId(),
-		Title:  request.Shortcut.GetTitle(),
-		Filter: request.Shortcut.GetFilter(),
-	}, nil
+	return convertShortcutFromStore(newShortcut), nil
 }
 
 func (s *APIV1Service) UpdateShortcut(ctx context.Context, request *v1pb.UpdateShortcutRequest) (*v1pb.Shortcut, error) {
@@ -167,6 +158,9 @@ func (s *APIV1Service) UpdateShortcut(ctx context.Context, request *v1pb.UpdateS
 					if request.Shortcut.GetTitle() == "" {
 						return nil, status.Errorf(codes.InvalidArgument, "title is required")
 					}
+					if existing := findShortcutByTitle(shortcuts, request.Shortcut.GetTitle()); existing != nil && existing.GetId() != shortcut.GetId() {
+						return nil, status.Errorf(codes.AlreadyExists, "shortcut %q already exists", request.Shortcut.GetTitle())
+					}
 					shortcut.Title = request.Shortcut.GetTitle()
 				} else if field == "filter" {
 					if err := s.validateFilter(ctx, request.Shortcut.GetFilter()); err != nil {
@@ -255,3 +249,37 @@ func (s *APIV1Service) validateFilter(_ context.Context, filterStr string) error
 	}
 	return nil
 }
+
+// getShortcutFilter returns the filter of the shortcut of the user with the given title.
+func (s *APIV1Service) getShortcutFilter(ctx context.Context, userID int32, title string) (string, error) {
+	userSetting, err := s.Store.GetUserSetting(ctx, &store.FindUserSetting{
+		UserID: &userID,
+		Key:    storepb.UserSettingKey_SHORTCUTS,
+	})
+	if err != nil {
+		return "", status.Errorf(codes.Internal, "failed to get user setting: %v", err)
+	}
+	shortcut := findShortcutByTitle(userSetting.GetShortcuts().GetShortcuts(), title)
+	if shortcut == nil {
+		return "", status.Errorf(codes.NotFound, "shortcut %q not found", title)
+	}
+	return shortcut.GetFilter(), nil
+}
+
+// findShortcutByTitle returns the shortcut with the given title, or nil if there is none.
+func findShortcutByTitle(shortcuts []*storepb.ShortcutsUserSetting_Shortcut, title string) *storepb.ShortcutsUserSetting_Shortcut {
+	for _, shortcut := range shortcuts {
+		if shortcut.GetTitle() == title {
+			return shortcut
+		}
+	}
+	return nil
+}
+
+func convertShortcutFromStore(shortcut *storepb.ShortcutsUserSetting_Shortcut) *v1pb.Shortcut {
+	return &v1pb.Shortcut{
+		Id:     shortcut.GetId(),
+		Title:  shortcut.GetTitle(),
+		Filter: shortcut.GetFilter(),
+	}
+}
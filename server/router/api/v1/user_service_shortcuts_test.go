@@ -0,0 +1,96 @@
+package v1
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+
+	v1pb "github.com/usememos/memos/proto/gen/api/v1"
+	"github.com/usememos/memos/server/runner/memopayload"
+	"github.com/usememos/memos/store"
+	teststore "github.com/usememos/memos/store/test"
+)
+
+func TestShortcuts(t *testing.T) {
+	ctx := context.Background()
+	ts := teststore.NewTestingStore(ctx, t)
+	defer ts.Close()
+	user, err := ts.CreateUser(ctx, &store.User{Username: "test", Role: store.RoleHost, Email: "test@test.com"})
+	require.NoError(t, err)
+	s := &APIV1Service{Store: ts}
+	userCtx := context.WithValue(ctx, usernameContextKey, user.Username)
+	parent := fmt.Sprintf("%s%d", UserNamePrefix, user.ID)
+	for i, content := range []string{"#work report", "#work plan", "#home chores"} {
+		memo := &store.Memo{UID: fmt.Sprintf("memo-%d", i), CreatorID: user.ID, Content: content, Visibility: store.Private}
+		require.NoError(t, memopayload.RebuildMemoPayload(memo))
+		_, err := ts.CreateMemo(ctx, memo)
+		require.NoError(t, err)
+	}
+
+	// The filter of a shortcut is saved as is and round-trips.
+	filter := `tag in ["work"] && content.contains("plan")`
+	created, err := s.CreateShortcut(userCtx, &v1pb.CreateShortcutRequest{
+		Parent:   parent,
+		Shortcut: &v1pb.Shortcut{Title: "Work plans", Filter: filter},
+	})
+	require.NoError(t, err)
+	require.NotEmpty(t, created.Id)
+	listed, err := s.ListShortcuts(userCtx, &v1pb.ListShortcutsRequest{Parent: parent})
+	require.NoError(t, err)
+	require.Len(t, listed.Shortcuts, 1)
+	require.Equal(t, created.Id, listed.Shortcuts[0].Id)
+	require.Equal(t, filter, listed.Shortcuts[0].Filter)
+
+	// Malformed filters and duplicate titles are rejected when saved.
+	for _, invalid := range []string{"", `tag in ["work"`, `unknown == 1`, `pinned == "yes"`} {
+		_, err := s.CreateShortcut(userCtx, &v1pb.CreateShortcutRequest{
+			Parent:   parent,
+			Shortcut: &v1pb.Shortcut{Title: "Invalid", Filter: invalid},
+		})
+		require.Equal(t, codes.InvalidArgument, status.Code(err), invalid)
+	}
+	_, err = s.CreateShortcut(userCtx, &v1pb.CreateShortcutRequest{
+		Parent:   parent,
+		Shortcut: &v1pb.Shortcut{Title: "Work plans", Filter: `pinned`},
+	})
+	require.Equal(t, codes.AlreadyExists, status.Code(err))
+	home, err := s.CreateShortcut(userCtx, &v1pb.CreateShortcutRequest{
+		Parent:   parent,
+		Shortcut: &v1pb.Shortcut{Title: "Home", Filter: `tag in ["home"]`},
+	})
+	require.NoError(t, err)
+	_, err = s.UpdateShortcut(userCtx, &v1pb.UpdateShortcutRequest{
+		Parent:     parent,
+		Shortcut:   &v1pb.Shortcut{Id: home.Id, Title: "Work plans"},
+		UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"title"}},
+	})
+	require.Equal(t, codes.AlreadyExists, status.Code(err))
+
+	// ListMemos applies a shortcut by its title, along with the filter.
+	listMemoNames := func(request *v1pb.ListMemosRequest) []string {
+		response, err := s.ListMemos(userCtx, request)
+		require.NoError(t, err)
+		names := []string{}
+		for _, memo := range response.Memos {
+			names = append(names, memo.Name)
+		}
+		return names
+	}
+	require.Equal(t, []string{"memos/memo-1"}, listMemoNames(&v1pb.ListMemosRequest{Shortcut: "Work plans"}))
+	require.Equal(t, []string{"memos/memo-2"}, listMemoNames(&v1pb.ListMemosRequest{Shortcut: "Home"}))
+	require.Empty(t, listMemoNames(&v1pb.ListMemosRequest{Shortcut: "Home", Filter: `tag in ["work"]`}))
+	_, err = s.ListMemos(userCtx, &v1pb.ListMemosRequest{Shortcut: "Missing"})
+	require.Equal(t, codes.NotFound, status.Code(err))
+	_, err = s.ListMemos(ctx, &v1pb.ListMemosRequest{Shortcut: "Home"})
+	require.Equal(t, codes.Unauthenticated, status.Code(err))
+
+	_, err = s.DeleteShortcut(userCtx, &v1pb.DeleteShortcutRequest{Parent: parent, Id: home.Id})
+	require.NoError(t, err)
+	_, err = s.ListMemos(userCtx, &v1pb.ListMemosRequest{Shortcut: "Home"})
+	require.Equal(t, codes.NotFound, status.Code(err))
+}
@@ -0,0 +1,176 @@
+package v1
+
+import (
+	"context"
+	"regexp"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+
+	"github.com/usememos/memos/internal/util"
+	v1pb "github.com/usememos/memos/proto/gen/api/v1"
+	storepb "github.com/usememos/memos/proto/gen/store"
+	"github.com/usememos/memos/store"
+)
+
+// templatePlaceholderRegexp matches a placeholder in the content of a template, e.g. "{{date}}".
+var templatePlaceholderRegexp = regexp.MustCompile(`\{\{\s*([a-z]+)\s*\}\}`)
+
+// templatePlaceholderLayouts are the time layouts of the known placeholders.
+var templatePlaceholderLayouts = map[string]string{
+	"date":     "2006-01-02",
+	"time":     "15:04",
+	"datetime": "2006-01-02 15:04",
+	"weekday":  "Monday",
+}
+
+// expandTemplate expands the placeholders in the content of a template with the given time,
+// which should be in the timezone of the user. Unknown placeholders are kept as is.
+func expandTemplate(content string, now time.Time) string {
+	return templatePlaceholderRegexp.ReplaceAllStringFunc(content, func(placeholder string) string {
+		name := templatePlaceholderRegexp.FindStringSubmatch(placeholder)[1]
+		layout, ok := templatePlaceholderLayouts[name]
+		if !ok {
+			return placeholder
+		}
+		return now.Format(layout)
+	})
+}
+
+func (s *APIV1Service) ListTemplates(ctx context.Context, request *v1pb.ListTemplatesRequest) (*v1pb.ListTemplatesResponse, error) {
+	userID, err := s.checkTemplateOwner(ctx, request.Parent)
+	if err != nil {
+		return nil, err
+	}
+
+	templates, err := s.listUserTemplates(ctx, userID)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list templates: %v", err)
+	}
+	response := &v1pb.ListTemplatesResponse{
+		Templates: []*v1pb.Template{},
+	}
+	for _, template := range templates {
+		response.Templates = append(response.Templates, convertTemplateFromStore(template))
+	}
+	return response, nil
+}
+
+func (s *APIV1Service) CreateTemplate(ctx context.Context, request *v1pb.CreateTemplateRequest) (*v1pb.Template, error) {
+	userID, err := s.checkTemplateOwner(ctx, request.Parent)
+	if err != nil {
+		return nil, err
+	}
+	if request.Template.GetName() == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "name is required")
+	}
+
+	templates, err := s.listUserTemplates(ctx, userID)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list templates: %v", err)
+	}
+	template := &storepb.TemplatesUserSetting_Template{
+		Id:      util.GenUUID(),
+		Name:    request.Template.GetName(),
+		Content: request.Template.GetContent(),
+	}
+	if err := s.upsertUserTemplates(ctx, userID, append(templates, template)); err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to upsert templates: %v", err)
+	}
+	return convertTemplateFromStore(template), nil
+}
+
+func (s *APIV1Service) DeleteTemplate(ctx context.Context, request *v1pb.DeleteTemplateRequest) (*emptypb.Empty, error) {
+	userID, err := s.checkTemplateOwner(ctx, request.Parent)
+	if err != nil {
+		return nil, err
+	}
+
+	templates, err := s.listUserTemplates(ctx, userID)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to list templates: %v", err)
+	}
+	newTemplates := make([]*storepb.TemplatesUserSetting_Template, 0, len(templates))
+	for _, template := range templates {
+		if template.GetId() != request.Id {
+			newTemplates = append(newTemplates, template)
+		}
+	}
+	if len(newTemplates) == len(templates) {
+		return nil, status.Errorf(codes.NotFound, "template not found")
+	}
+	if err := s.upsertUserTemplates(ctx, userID, newTemplates); err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to upsert templates: %v", err)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+// getExpandedTemplate returns the content of the template of the user with its placeholders
+// expanded at the current time in the timezone of the user.
+func (s *APIV1Service) getExpandedTemplate(ctx context.Context, userID int32, templateID string) (string, error) {
+	templates, err := s.listUserTemplates(ctx, userID)
+	if err != nil {
+		return "", status.Errorf(codes.Internal, "failed to list templates: %v", err)
+	}
+	for _, template := range templates {
+		if template.GetId() != templateID {
+			continue
+		}
+		location, err := s.Store.GetUserTimezone(ctx, userID)
+		if err != nil {
+			return "", status.Errorf(codes.Internal, "failed to get user timezone: %v", err)
+		}
+		return expandTemplate(template.GetContent(), time.Now().In(location)), nil
+	}
+	return "", status.Errorf(codes.NotFound, "template not found")
+}
+
+// checkTemplateOwner returns the id of the user of the given name, who must be the current user.
+func (s *APIV1Service) checkTemplateOwner(ctx context.Context, name string) (int32, error) {
+	userID, err := ExtractUserIDFromName(name)
+	if err != nil {
+		return 0, status.Errorf(codes.InvalidArgument, "invalid user name: %v", err)
+	}
+	currentUser, err := s.GetCurrentUser(ctx)
+	if err != nil {
+		return 0, status.Errorf(codes.Internal, "failed to get current user: %v", err)
+	}
+	if currentUser == nil || currentUser.ID != userID {
+		return 0, status.Errorf(codes.PermissionDenied, "permission denied")
+	}
+	return userID, nil
+}
+
+func (s *APIV1Service) listUserTemplates(ctx context.Context, userID int32) ([]*storepb.TemplatesUserSetting_Template, error) {
+	userSetting, err := s.Store.GetUserSetting(ctx, &store.FindUserSetting{
+		UserID: &userID,
+		Key:    storepb.UserSettingKey_TEMPLATES,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return userSetting.GetTemplates().GetTemplates(), nil
+}
+
+func (s *APIV1Service) upsertUserTemplates(ctx context.Context, userID int32, templates []*storepb.TemplatesUserSetting_Template) error {
+	_, err := s.Store.UpsertUserSetting(ctx, &storepb.UserSetting{
+		UserId: userID,
+		Key:    storepb.UserSettingKey_TEMPLATES,
+		Value: &storepb.UserSetting_Templates{
+			Templates: &storepb.TemplatesUserSetting{
+				Templates: templates,
+			},
+		},
+	})
+	return err
+}
+
+func convertTemplateFromStore(template *storepb.TemplatesUserSetting_Template) *v1pb.Template {
+	return &v1pb.Template{
+		Id:      template.GetId(),
+		Name:    template.GetName(),
+		Content: template.GetContent(),
+	}
+}
@@ -0,0 +1,51 @@
+package v1
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestExpandTemplate(t *testing.T) {
+	shanghai, err := time.LoadLocation("Asia/Shanghai")
+	require.NoError(t, err)
+	// 2025-01-02 23:30 UTC is already Friday morning in Shanghai.
+	now := time.Date(2025, 1, 2, 23, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		content  string
+		location *time.Location
+		want     string
+	}{
+		{
+			content:  "# {{date}}\n\nWritten at {{time}} on {{weekday}}.",
+			location: time.UTC,
+			want:     "# 2025-01-02\n\nWritten at 23:30 on Thursday.",
+		},
+		{
+			content:  "# {{date}}\n\nWritten at {{time}} on {{weekday}}.",
+			location: shanghai,
+			want:     "# 2025-01-03\n\nWritten at 07:30 on Friday.",
+		},
+		{
+			content:  "Meeting {{ datetime }}",
+			location: shanghai,
+			want:     "Meeting 2025-01-03 07:30",
+		},
+		{
+			// Unknown and malformed placeholders are kept as is.
+			content:  "{{author}} {{Date}} {{date} {date}} {{ }} {{date}}",
+			location: time.UTC,
+			want:     "{{author}} {{Date}} {{date} {date}} {{ }} 2025-01-02",
+		},
+		{
+			content:  "No placeholders",
+			location: time.UTC,
+			want:     "No placeholders",
+		},
+	}
+	for _, test := range tests {
+		require.Equal(t, test.want, expandTemplate(test.content, now.In(test.location)), test.content)
+	}
+}
@@ -14,6 +14,7 @@ import (
 	"google.golang.org/grpc/health/grpc_health_v1"
 	"google.golang.org/grpc/reflection"
 
+	"github.com/usememos/memos/plugin/httpgetter"
 	v1pb "github.com/usememos/memos/proto/gen/api/v1"
 	"github.com/usememos/memos/server/profile"
 	"github.com/usememos/memos/store"
@@ -37,8 +38,14 @@ type APIV1Service struct {
 	Secret  string
 	Profile *profile.Profile
 	Store   *store.Store
+	// LinkMetadataRateLimiter limits how often each user may request link metadata.
+	LinkMetadataRateLimiter RateLimiter
 
 	grpcServer *grpc.Server
+
+	linkMetadataCache *httpgetter.HTMLMetaCache
+	// markdownExtensions are the extensions of the parsed markdown, see RegisterMarkdownExtension.
+	markdownExtensions []*MarkdownExtension
 }
 
 func NewAPIV1Service(secret string, profile *profile.Profile, store *store.Store, grpcServer *grpc.Server) *APIV1Service {
@@ -48,6 +55,9 @@ func NewAPIV1Service(secret string, profile *profile.Profile, store *store.Store
 		Profile:    profile,
 		Store:      store,
 		grpcServer: grpcServer,
+
+		LinkMetadataRateLimiter: NewMemoryRateLimiter(),
+		linkMetadataCache:       httpgetter.NewHTMLMetaCache(httpgetter.DefaultHTMLMetaCacheMaxEntries),
 	}
 	grpc_health_v1.RegisterHealthServer(grpcServer, apiv1Service)
 	v1pb.RegisterWorkspaceServiceServer(grpcServer, apiv1Service)
@@ -76,7 +86,7 @@ func (s *APIV1Service) RegisterGateway(ctx context.Context, echoServer *echo.Ech
 		return err
 	}
 
-	gwMux := runtime.NewServeMux()
+	gwMux := runtime.NewServeMux(runtime.WithOutgoingHeaderMatcher(outgoingHeaderMatcher))
 	if err := v1pb.RegisterWorkspaceServiceHandler(ctx, gwMux, conn); err != nil {
 		return err
 	}
@@ -112,7 +122,7 @@ func (s *APIV1Service) RegisterGateway(ctx context.Context, echoServer *echo.Ech
 	}
 	gwGroup := echoServer.Group("")
 	gwGroup.Use(middleware.CORS())
-	handler := echo.WrapHandler(gwMux)
+	handler := echo.WrapHandler(newMarkdownETagHandler(gwMux, s.Profile.Version))
 
 	gwGroup.Any("/api/v1/*", handler)
 	gwGroup.Any("/file/*", handler)
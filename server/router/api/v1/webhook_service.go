@@ -11,25 +11,45 @@ import (
 	"google.golang.org/protobuf/types/known/emptypb"
 	"google.golang.org/protobuf/types/known/timestamppb"
 
+	"github.com/usememos/memos/internal/util"
+	"github.com/usememos/memos/plugin/httpgetter"
 	v1pb "github.com/usememos/memos/proto/gen/api/v1"
 	"github.com/usememos/memos/store"
 )
 
+// webhookSecretLength is the length of the generated webhook secrets.
+const webhookSecretLength = 32
+
 func (s *APIV1Service) CreateWebhook(ctx context.Context, request *v1pb.CreateWebhookRequest) (*v1pb.Webhook, error) {
 	currentUser, err := s.GetCurrentUser(ctx)
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "failed to get user: %v", err)
 	}
 
+	url := strings.TrimSpace(request.Url)
+	if err := httpgetter.ValidateURL(url); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid webhook url: %v", err)
+	}
+	secret := request.Secret
+	if secret == "" {
+		secret, err = util.RandomString(webhookSecretLength)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to generate webhook secret: %v", err)
+		}
+	}
+
 	webhook, err := s.Store.CreateWebhook(ctx, &store.Webhook{
 		CreatorID: currentUser.ID,
 		Name:      request.Name,
-		URL:       strings.TrimSpace(request.Url),
+		URL:       url,
+		Secret:    secret,
 	})
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "failed to create webhook, error: %+v", err)
 	}
-	return convertWebhookFromStore(webhook), nil
+	webhookMessage := convertWebhookFromStore(webhook)
+	webhookMessage.Secret = webhook.Secret
+	return webhookMessage, nil
 }
 
 func (s *APIV1Service) ListWebhooks(ctx context.Context, request *v1pb.ListWebhooksRequest) (*v1pb.ListWebhooksResponse, error) {
@@ -77,14 +97,28 @@ func (s *APIV1Service) UpdateWebhook(ctx context.Context, request *v1pb.UpdateWe
 	if request.UpdateMask == nil || len(request.UpdateMask.Paths) == 0 {
 		return nil, status.Errorf(codes.InvalidArgument, "update_mask is required")
 	}
+	if _, err := s.getCurrentUserWebhook(ctx, request.Webhook.Id); err != nil {
+		return nil, err
+	}
 
-	update := &store.UpdateWebhook{}
+	update := &store.UpdateWebhook{
+		ID: request.Webhook.Id,
+	}
 	for _, field := range request.UpdateMask.Paths {
 		switch field {
 		case "name":
 			update.Name = &request.Webhook.Name
 		case "url":
-			update.URL = &request.Webhook.Url
+			url := strings.TrimSpace(request.Webhook.Url)
+			if err := httpgetter.ValidateURL(url); err != nil {
+				return nil, status.Errorf(codes.InvalidArgument, "invalid webhook url: %v", err)
+			}
+			update.URL = &url
+		case "secret":
+			if request.Webhook.Secret == "" {
+				return nil, status.Errorf(codes.InvalidArgument, "webhook secret must not be empty")
+			}
+			update.Secret = &request.Webhook.Secret
 		}
 	}
 
@@ -92,10 +126,17 @@ func (s *APIV1Service) UpdateWebhook(ctx context.Context, request *v1pb.UpdateWe
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "failed to update webhook, error: %+v", err)
 	}
-	return convertWebhookFromStore(webhook), nil
+	webhookMessage := convertWebhookFromStore(webhook)
+	if update.Secret != nil {
+		webhookMessage.Secret = webhook.Secret
+	}
+	return webhookMessage, nil
 }
 
 func (s *APIV1Service) DeleteWebhook(ctx context.Context, request *v1pb.DeleteWebhookRequest) (*emptypb.Empty, error) {
+	if _, err := s.getCurrentUserWebhook(ctx, request.Id); err != nil {
+		return nil, err
+	}
 	err := s.Store.DeleteWebhook(ctx, &store.DeleteWebhook{
 		ID: request.Id,
 	})
@@ -105,6 +146,29 @@ func (s *APIV1Service) DeleteWebhook(ctx context.Context, request *v1pb.DeleteWe
 	return &emptypb.Empty{}, nil
 }
 
+// getCurrentUserWebhook returns the webhook with the given id if the current user created it,
+// so that users cannot change or delete the webhooks of others.
+func (s *APIV1Service) getCurrentUserWebhook(ctx context.Context, id int32) (*store.Webhook, error) {
+	currentUser, err := s.GetCurrentUser(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get user: %v", err)
+	}
+	if currentUser == nil {
+		return nil, status.Errorf(codes.PermissionDenied, "permission denied")
+	}
+	webhook, err := s.Store.GetWebhook(ctx, &store.FindWebhook{
+		ID:        &id,
+		CreatorID: &currentUser.ID,
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to get webhook, error: %+v", err)
+	}
+	if webhook == nil {
+		return nil, status.Errorf(codes.NotFound, "webhook not found")
+	}
+	return webhook, nil
+}
+
 func convertWebhookFromStore(webhook *store.Webhook) *v1pb.Webhook {
 	return &v1pb.Webhook{
 		Id:         webhook.ID,
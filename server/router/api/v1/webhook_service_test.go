@@ -0,0 +1,56 @@
+package v1
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+
+	v1pb "github.com/usememos/memos/proto/gen/api/v1"
+	"github.com/usememos/memos/store"
+	teststore "github.com/usememos/memos/store/test"
+)
+
+func TestWebhookOwnership(t *testing.T) {
+	ctx := context.Background()
+	ts := teststore.NewTestingStore(ctx, t)
+	defer ts.Close()
+	owner, err := ts.CreateUser(ctx, &store.User{Username: "owner", Role: store.RoleUser, Email: "owner@test.com"})
+	require.NoError(t, err)
+	other, err := ts.CreateUser(ctx, &store.User{Username: "other", Role: store.RoleUser, Email: "other@test.com"})
+	require.NoError(t, err)
+	s := &APIV1Service{Store: ts}
+	ownerCtx := context.WithValue(ctx, usernameContextKey, owner.Username)
+	otherCtx := context.WithValue(ctx, usernameContextKey, other.Username)
+
+	webhook, err := ts.CreateWebhook(ctx, &store.Webhook{CreatorID: owner.ID, Name: "hook", URL: "https://example.com/hook", Secret: "secret"})
+	require.NoError(t, err)
+
+	// Other users cannot change or delete the webhook.
+	_, err = s.UpdateWebhook(otherCtx, &v1pb.UpdateWebhookRequest{
+		Webhook:    &v1pb.Webhook{Id: webhook.ID, Name: "stolen"},
+		UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"name"}},
+	})
+	require.Equal(t, codes.NotFound, status.Code(err))
+	_, err = s.DeleteWebhook(otherCtx, &v1pb.DeleteWebhookRequest{Id: webhook.ID})
+	require.Equal(t, codes.NotFound, status.Code(err))
+	found, err := ts.GetWebhook(ctx, &store.FindWebhook{ID: &webhook.ID})
+	require.NoError(t, err)
+	require.Equal(t, "hook", found.Name)
+
+	// The owner can.
+	updated, err := s.UpdateWebhook(ownerCtx, &v1pb.UpdateWebhookRequest{
+		Webhook:    &v1pb.Webhook{Id: webhook.ID, Name: "renamed"},
+		UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"name"}},
+	})
+	require.NoError(t, err)
+	require.Equal(t, "renamed", updated.Name)
+	_, err = s.DeleteWebhook(ownerCtx, &v1pb.DeleteWebhookRequest{Id: webhook.ID})
+	require.NoError(t, err)
+	found, err = ts.GetWebhook(ctx, &store.FindWebhook{ID: &webhook.ID})
+	require.NoError(t, err)
+	require.Nil(t, found)
+}
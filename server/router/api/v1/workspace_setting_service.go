@@ -136,6 +136,7 @@ func convertWorkspaceGeneralSettingFromStore(setting *storepb.WorkspaceGeneralSe
 		WeekStartDayOffset:       setting.WeekStartDayOffset,
 		DisallowChangeUsername:   setting.DisallowChangeUsername,
 		DisallowChangeNickname:   setting.DisallowChangeNickname,
+		EnableQueryExplain:       setting.EnableQueryExplain,
 	}
 	if setting.CustomProfile != nil {
 		generalSetting.CustomProfile = &v1pb.WorkspaceCustomProfile{
@@ -161,6 +162,7 @@ func convertWorkspaceGeneralSettingToStore(setting *v1pb.WorkspaceGeneralSetting
 		WeekStartDayOffset:       setting.WeekStartDayOffset,
 		DisallowChangeUsername:   setting.DisallowChangeUsername,
 		DisallowChangeNickname:   setting.DisallowChangeNickname,
+		EnableQueryExplain:       setting.EnableQueryExplain,
 	}
 	if setting.CustomProfile != nil {
 		generalSetting.CustomProfile = &storepb.WorkspaceCustomProfile{
@@ -223,17 +225,33 @@ func convertWorkspaceMemoRelatedSettingFromStore(setting *storepb.WorkspaceMemoR
 		return nil
 	}
 	return &v1pb.WorkspaceMemoRelatedSetting{
-		DisallowPublicVisibility: setting.DisallowPublicVisibility,
-		DisplayWithUpdateTime:    setting.DisplayWithUpdateTime,
-		ContentLengthLimit:       setting.ContentLengthLimit,
-		EnableDoubleClickEdit:    setting.EnableDoubleClickEdit,
-		EnableLinkPreview:        setting.EnableLinkPreview,
-		EnableComment:            setting.EnableComment,
-		EnableLocation:           setting.EnableLocation,
-		Reactions:                setting.Reactions,
-		DisableMarkdownShortcuts: setting.DisableMarkdownShortcuts,
-		EnableBlurNsfwContent:    setting.EnableBlurNsfwContent,
-		NsfwTags:                 setting.NsfwTags,
+		DisallowPublicVisibility:     setting.DisallowPublicVisibility,
+		DisplayWithUpdateTime:        setting.DisplayWithUpdateTime,
+		ContentLengthLimit:           setting.ContentLengthLimit,
+		EnableDoubleClickEdit:        setting.EnableDoubleClickEdit,
+		EnableLinkPreview:            setting.EnableLinkPreview,
+		EnableComment:                setting.EnableComment,
+		EnableLocation:               setting.EnableLocation,
+		Reactions:                    setting.Reactions,
+		DisableMarkdownShortcuts:     setting.DisableMarkdownShortcuts,
+		EnableBlurNsfwContent:        setting.EnableBlurNsfwContent,
+		NsfwTags:                     setting.NsfwTags,
+		LinkMetadataCacheTtl:         setting.LinkMetadataCacheTtl,
+		LinkMetadataFetchTimeout:     setting.LinkMetadataFetchTimeout,
+		LinkMetadataAllowInternalIps: setting.LinkMetadataAllowInternalIps,
+		LinkMetadataUserAgent:        setting.LinkMetadataUserAgent,
+		LinkMetadataAcceptLanguage:   setting.LinkMetadataAcceptLanguage,
+		LinkMetadataRateLimit:        setting.LinkMetadataRateLimit,
+		LinkMetadataRateLimitBurst:   setting.LinkMetadataRateLimitBurst,
+		WebhookAllowInternalIps:      setting.WebhookAllowInternalIps,
+		LinkMetadataRespectRobotsTxt: setting.LinkMetadataRespectRobotsTxt,
+		UpdateTimeOnMetadataChange:   setting.UpdateTimeOnMetadataChange,
+		ContentCompressionThreshold:  setting.ContentCompressionThreshold,
+		MemoCountQuota:               setting.MemoCountQuota,
+		MemoSizeQuota:                setting.MemoSizeQuota,
+		MemoQuotaIncludeArchived:     setting.MemoQuotaIncludeArchived,
+		MemoRevisionLimit:            setting.MemoRevisionLimit,
+		TagLowercase:                 setting.TagLowercase,
 	}
 }
 
@@ -242,16 +260,32 @@ func convertWorkspaceMemoRelatedSettingToStore(setting *v1pb.WorkspaceMemoRelate
 		return nil
 	}
 	return &storepb.WorkspaceMemoRelatedSetting{
-		DisallowPublicVisibility: setting.DisallowPublicVisibility,
-		DisplayWithUpdateTime:    setting.DisplayWithUpdateTime,
-		ContentLengthLimit:       setting.ContentLengthLimit,
-		EnableDoubleClickEdit:    setting.EnableDoubleClickEdit,
-		EnableLinkPreview:        setting.EnableLinkPreview,
-		EnableComment:            setting.EnableComment,
-		EnableLocation:           setting.EnableLocation,
-		Reactions:                setting.Reactions,
-		DisableMarkdownShortcuts: setting.DisableMarkdownShortcuts,
-		EnableBlurNsfwContent:    setting.EnableBlurNsfwContent,
-		NsfwTags:                 setting.NsfwTags,
+		DisallowPublicVisibility:     setting.DisallowPublicVisibility,
+		DisplayWithUpdateTime:        setting.DisplayWithUpdateTime,
+		ContentLengthLimit:           setting.ContentLengthLimit,
+		EnableDoubleClickEdit:        setting.EnableDoubleClickEdit,
+		EnableLinkPreview:            setting.EnableLinkPreview,
+		EnableComment:                setting.EnableComment,
+		EnableLocation:               setting.EnableLocation,
+		Reactions:                    setting.Reactions,
+		DisableMarkdownShortcuts:     setting.DisableMarkdownShortcuts,
+		EnableBlurNsfwContent:        setting.EnableBlurNsfwContent,
+		NsfwTags:                     setting.NsfwTags,
+		LinkMetadataCacheTtl:         setting.LinkMetadataCacheTtl,
+		LinkMetadataFetchTimeout:     setting.LinkMetadataFetchTimeout,
+		LinkMetadataAllowInternalIps: setting.LinkMetadataAllowInternalIps,
+		LinkMetadataUserAgent:        setting.LinkMetadataUserAgent,
+		LinkMetadataAcceptLanguage:   setting.LinkMetadataAcceptLanguage,
+		LinkMetadataRateLimit:        setting.LinkMetadataRateLimit,
+		LinkMetadataRateLimitBurst:   setting.LinkMetadataRateLimitBurst,
+		WebhookAllowInternalIps:      setting.WebhookAllowInternalIps,
+		LinkMetadataRespectRobotsTxt: setting.LinkMetadataRespectRobotsTxt,
+		UpdateTimeOnMetadataChange:   setting.UpdateTimeOnMetadataChange,
+		ContentCompressionThreshold:  setting.ContentCompressionThreshold,
+		MemoCountQuota:               setting.MemoCountQuota,
+		MemoSizeQuota:                setting.MemoSizeQuota,
+		MemoQuotaIncludeArchived:     setting.MemoQuotaIncludeArchived,
+		MemoRevisionLimit:            setting.MemoRevisionLimit,
+		TagLowercase:                 setting.TagLowercase,
 	}
 }
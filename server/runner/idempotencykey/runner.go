@@ -0,0 +1,43 @@
+package idempotencykey
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/usememos/memos/store"
+)
+
+type Runner struct {
+	Store *store.Store
+}
+
+func NewRunner(store *store.Store) *Runner {
+	return &Runner{
+		Store: store,
+	}
+}
+
+// Schedule runner every hour.
+const runnerInterval = time.Hour
+
+func (r *Runner) Run(ctx context.Context) {
+	ticker := time.NewTicker(runnerInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			r.RunOnce(ctx)
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// RunOnce deletes the memo idempotency keys that have expired.
+func (r *Runner) RunOnce(ctx context.Context) {
+	if err := r.Store.DeleteExpiredMemoIdempotencyKeys(ctx, store.MemoIdempotencyKeyTTL); err != nil {
+		slog.Error("failed to delete expired memo idempotency keys", "err", err)
+	}
+}
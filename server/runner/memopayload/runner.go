@@ -4,6 +4,7 @@ import (
 	"context"
 	"log/slog"
 	"slices"
+	"strings"
 
 	"github.com/pkg/errors"
 	"github.com/usememos/gomark/ast"
@@ -46,6 +47,43 @@ func (r *Runner) RunOnce(ctx context.Context) {
 	}
 }
 
+// memoTagsBatchSize is the number of memos whose tags RebuildMemoTags writes in a transaction.
+const memoTagsBatchSize = 100
+
+// RebuildMemoTags extracts the tags of the memos after afterID from their contents and writes
+// them in batches, e.g. after the tag rules changed. Rebuilding is idempotent. It returns the id
+// of the last memo whose tags were written, from which an interrupted rebuild can be resumed.
+func RebuildMemoTags(ctx context.Context, stores *store.Store, afterID int32) (int32, error) {
+	batch := []*store.MemoTags{}
+	flush := func() error {
+		if err := stores.UpdateMemoTags(ctx, batch); err != nil {
+			return errors.Wrap(err, "failed to update memo tags")
+		}
+		if len(batch) > 0 {
+			afterID = batch[len(batch)-1].MemoID
+		}
+		batch = []*store.MemoTags{}
+		return nil
+	}
+	if err := stores.ForEachMemoContent(ctx, afterID, func(id int32, content string) error {
+		nodes, err := parser.Parse(tokenizer.Tokenize(content))
+		if err != nil {
+			return errors.Wrapf(err, "failed to parse content of memo %d", id)
+		}
+		batch = append(batch, &store.MemoTags{MemoID: id, Tags: ExtractTags(nodes)})
+		if len(batch) < memoTagsBatchSize {
+			return nil
+		}
+		return flush()
+	}); err != nil {
+		return afterID, err
+	}
+	if err := flush(); err != nil {
+		return afterID, err
+	}
+	return afterID, nil
+}
+
 func RebuildMemoPayload(memo *store.Memo) error {
 	nodes, err := parser.Parse(tokenizer.Tokenize(memo.Content))
 	if err != nil {
@@ -55,15 +93,9 @@ func RebuildMemoPayload(memo *store.Memo) error {
 	if memo.Payload == nil {
 		memo.Payload = &storepb.MemoPayload{}
 	}
-	tags := []string{}
 	property := &storepb.MemoPayload_Property{}
 	TraverseASTNodes(nodes, func(node ast.Node) {
 		switch n := node.(type) {
-		case *ast.Tag:
-			tag := n.Content
-			if !slices.Contains(tags, tag) {
-				tags = append(tags, tag)
-			}
 		case *ast.Link, *ast.AutoLink:
 			property.HasLink = true
 		case *ast.TaskListItem:
@@ -78,11 +110,33 @@ func RebuildMemoPayload(memo *store.Memo) error {
 			property.References = append(property.References, n.ResourceName)
 		}
 	})
-	memo.Payload.Tags = tags
+	memo.Payload.Tags = ExtractTags(nodes)
 	memo.Payload.Property = property
 	return nil
 }
 
+// tagTrailingPunctuations are the punctuations that end a sentence rather than a tag.
+const tagTrailingPunctuations = ".,:;!?'\")]}。，：；！？、）"
+
+// TrimTag removes the trailing punctuation of the given tag content, e.g. "foo." becomes "foo".
+func TrimTag(tag string) string {
+	return strings.TrimRight(tag, tagTrailingPunctuations)
+}
+
+// ExtractTags returns the distinct tags of the given nodes in order of first appearance.
+func ExtractTags(nodes []ast.Node) []string {
+	tags := []string{}
+	TraverseASTNodes(nodes, func(node ast.Node) {
+		if n, ok := node.(*ast.Tag); ok {
+			tag := TrimTag(n.Content)
+			if tag != "" && !slices.Contains(tags, tag) {
+				tags = append(tags, tag)
+			}
+		}
+	})
+	return tags
+}
+
 func TraverseASTNodes(nodes []ast.Node, fn func(ast.Node)) {
 	for _, node := range nodes {
 		fn(node)
@@ -22,6 +22,8 @@ import (
 	apiv1 "github.com/usememos/memos/server/router/api/v1"
 	"github.com/usememos/memos/server/router/frontend"
 	"github.com/usememos/memos/server/router/rss"
+	"github.com/usememos/memos/server/runner/idempotencykey"
+	"github.com/usememos/memos/server/runner/memoexpiry"
 	"github.com/usememos/memos/server/runner/memopayload"
 	"github.com/usememos/memos/server/runner/s3presign"
 	"github.com/usememos/memos/store"
@@ -146,8 +148,19 @@ func (s *Server) StartBackgroundRunners(ctx context.Context) {
 	memopayloadRunner := memopayload.NewRunner(s.Store)
 	// Rebuild all memos' payload after server starts.
 	memopayloadRunner.RunOnce(ctx)
+	// Clean up the resources left behind by deleted users.
+	if err := s.Store.VacuumResources(ctx); err != nil {
+		slog.Error("failed to vacuum resources", slog.String("error", err.Error()))
+	}
+
+	idempotencykeyRunner := idempotencykey.NewRunner(s.Store)
+	idempotencykeyRunner.RunOnce(ctx)
+	memoexpiryRunner := memoexpiry.NewRunner(s.Store)
+	memoexpiryRunner.RunOnce(ctx)
 
 	go s3presignRunner.Run(ctx)
+	go idempotencykeyRunner.Run(ctx)
+	go memoexpiryRunner.Run(ctx)
 }
 
 func (s *Server) getOrUpsertWorkspaceBasicSetting(ctx context.Context) (*storepb.WorkspaceBasicSetting, error) {
@@ -1,6 +1,10 @@
 package store
 
-import "google.golang.org/protobuf/encoding/protojson"
+import (
+	"strings"
+
+	"google.golang.org/protobuf/encoding/protojson"
+)
 
 var (
 	protojsonUnmarshaler = protojson.UnmarshalOptions{
@@ -22,3 +26,11 @@ const (
 func (r RowStatus) String() string {
 	return string(r)
 }
+
+var likePatternEscaper = strings.NewReplacer("!", "!!", "%", "!%", "_", "!_")
+
+// ContainsLikePattern returns a LIKE pattern that matches values containing s literally.
+// The pattern must be used with ESCAPE '!', so that % and _ in s are not wildcards.
+func ContainsLikePattern(s string) string {
+	return "%" + likePatternEscaper.Replace(s) + "%"
+}
@@ -1,6 +1,8 @@
 package db
 
 import (
+	"database/sql"
+
 	"github.com/pkg/errors"
 
 	"github.com/usememos/memos/server/profile"
@@ -28,5 +30,23 @@ func NewDBDriver(profile *profile.Profile) (store.Driver, error) {
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to create db driver")
 	}
+	applyDBPool(driver.GetDB(), profile.DBPool)
 	return driver, nil
 }
+
+// applyDBPool configures the connection pool of the database, keeping the defaults of
+// database/sql for the zero values of the pool.
+func applyDBPool(db *sql.DB, pool profile.DBPool) {
+	if pool.MaxOpenConns > 0 {
+		db.SetMaxOpenConns(pool.MaxOpenConns)
+	}
+	if pool.MaxIdleConns != 0 {
+		db.SetMaxIdleConns(pool.MaxIdleConns)
+	}
+	if pool.ConnMaxLifetime > 0 {
+		db.SetConnMaxLifetime(pool.ConnMaxLifetime)
+	}
+	if pool.ConnMaxIdleTime > 0 {
+		db.SetConnMaxIdleTime(pool.ConnMaxIdleTime)
+	}
+}
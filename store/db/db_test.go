@@ -0,0 +1,93 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/usememos/memos/server/profile"
+)
+
+func TestNewDBDriverPool(t *testing.T) {
+	ctx := context.Background()
+	// holdConns opens n connections at once and releases them, leaving idle those the pool keeps.
+	holdConns := func(db *sql.DB, n int) {
+		conns := []*sql.Conn{}
+		for i := 0; i < n; i++ {
+			conn, err := db.Conn(ctx)
+			require.NoError(t, err)
+			conns = append(conns, conn)
+		}
+		for _, conn := range conns {
+			require.NoError(t, conn.Close())
+		}
+	}
+
+	tests := []struct {
+		name         string
+		pool         profile.DBPool
+		maxOpenConns int
+		idleConns    int
+	}{
+		{
+			name:         "defaults",
+			pool:         profile.DBPool{},
+			maxOpenConns: 0,
+			idleConns:    2,
+		},
+		{
+			name:         "configured",
+			pool:         profile.DBPool{MaxOpenConns: 8, MaxIdleConns: 3, ConnMaxLifetime: time.Hour, ConnMaxIdleTime: time.Minute},
+			maxOpenConns: 8,
+			idleConns:    3,
+		},
+		{
+			name:         "no idle connections",
+			pool:         profile.DBPool{MaxIdleConns: -1},
+			maxOpenConns: 0,
+			idleConns:    0,
+		},
+	}
+	for _, test := range tests {
+		driver, err := NewDBDriver(&profile.Profile{
+			Driver: "sqlite",
+			DSN:    filepath.Join(t.TempDir(), "memos.db"),
+			DBPool: test.pool,
+		})
+		require.NoError(t, err, test.name)
+		db := driver.GetDB()
+		require.Equal(t, test.maxOpenConns, db.Stats().MaxOpenConnections, test.name)
+		holdConns(db, 5)
+		require.Equal(t, test.idleConns, db.Stats().Idle, test.name)
+		require.NoError(t, driver.Close())
+	}
+
+	// Open connections wait for each other at the limit.
+	driver, err := NewDBDriver(&profile.Profile{
+		Driver: "sqlite",
+		DSN:    filepath.Join(t.TempDir(), "memos.db"),
+		DBPool: profile.DBPool{MaxOpenConns: 1},
+	})
+	require.NoError(t, err)
+	defer driver.Close()
+	conn, err := driver.GetDB().Conn(ctx)
+	require.NoError(t, err)
+	var wg sync.WaitGroup
+	var execErr error
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		_, execErr = driver.GetDB().ExecContext(ctx, "SELECT 1")
+	}()
+	require.Eventually(t, func() bool {
+		return driver.GetDB().Stats().WaitCount == 1
+	}, time.Second, 10*time.Millisecond)
+	require.NoError(t, conn.Close())
+	wg.Wait()
+	require.NoError(t, execErr)
+}
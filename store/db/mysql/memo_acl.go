@@ -0,0 +1,81 @@
+package mysql
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/usememos/memos/store"
+)
+
+func (d *DB) UpsertMemoACL(ctx context.Context, upsert *store.MemoACL) (*store.MemoACL, error) {
+	stmt := "INSERT INTO `memo_acl` (`memo_id`, `user_id`) VALUES (?, ?) ON DUPLICATE KEY UPDATE `memo_id` = `memo_id`"
+	if _, err := d.db.ExecContext(ctx, stmt, upsert.MemoID, upsert.UserID); err != nil {
+		return nil, err
+	}
+
+	list, err := d.ListMemoACLs(ctx, &store.FindMemoACL{MemoID: &upsert.MemoID, UserID: &upsert.UserID})
+	if err != nil {
+		return nil, err
+	}
+	if len(list) != 1 {
+		return nil, errors.Errorf("unexpected memo acl count: %d", len(list))
+	}
+	return list[0], nil
+}
+
+func (d *DB) ListMemoACLs(ctx context.Context, find *store.FindMemoACL) ([]*store.MemoACL, error) {
+	where, args := []string{"TRUE"}, []any{}
+	if find.MemoID != nil {
+		where, args = append(where, "`memo_id` = ?"), append(args, *find.MemoID)
+	}
+	if len(find.MemoIDList) != 0 {
+		placeholders := []string{}
+		for _, id := range find.MemoIDList {
+			placeholders, args = append(placeholders, "?"), append(args, id)
+		}
+		where = append(where, fmt.Sprintf("`memo_id` IN (%s)", strings.Join(placeholders, ", ")))
+	}
+	if find.UserID != nil {
+		where, args = append(where, "`user_id` = ?"), append(args, *find.UserID)
+	}
+
+	rows, err := d.db.QueryContext(ctx, "SELECT `memo_id`, `user_id`, UNIX_TIMESTAMP(`created_ts`) FROM `memo_acl` WHERE "+strings.Join(where, " AND ")+" ORDER BY `created_ts` ASC, `user_id` ASC", args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	list := []*store.MemoACL{}
+	for rows.Next() {
+		memoACL := &store.MemoACL{}
+		if err := rows.Scan(
+			&memoACL.MemoID,
+			&memoACL.UserID,
+			&memoACL.CreatedTs,
+		); err != nil {
+			return nil, err
+		}
+		list = append(list, memoACL)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return list, nil
+}
+
+func (d *DB) DeleteMemoACL(ctx context.Context, delete *store.DeleteMemoACL) error {
+	where, args := []string{"TRUE"}, []any{}
+	if delete.MemoID != nil {
+		where, args = append(where, "`memo_id` = ?"), append(args, *delete.MemoID)
+	}
+	if delete.UserID != nil {
+		where, args = append(where, "`user_id` = ?"), append(args, *delete.UserID)
+	}
+	_, err := d.db.ExecContext(ctx, "DELETE FROM `memo_acl` WHERE "+strings.Join(where, " AND "), args...)
+	return err
+}
@@ -0,0 +1,30 @@
+package mysql
+
+import (
+	"context"
+
+	"github.com/usememos/memos/store"
+)
+
+func (d *DB) ListMemoDayCounts(ctx context.Context, creatorID int32, fromTs, toTs int64, tzOffset int) ([]*store.MemoDayCount, error) {
+	// The day is computed from the unix time, so that the timezone of the session does not apply.
+	query := "SELECT DATE_FORMAT('1970-01-01' + INTERVAL (UNIX_TIMESTAMP(`created_ts`) + ?) SECOND, '%Y-%m-%d') AS `date`, COUNT(*) FROM `memo` WHERE `creator_id` = ? AND `row_status` = 'NORMAL' AND UNIX_TIMESTAMP(`created_ts`) >= ? AND UNIX_TIMESTAMP(`created_ts`) < ? GROUP BY `date` ORDER BY `date`"
+	rows, err := d.db.QueryContext(ctx, query, tzOffset, creatorID, fromTs, toTs)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	list := []*store.MemoDayCount{}
+	for rows.Next() {
+		dayCount := &store.MemoDayCount{}
+		if err := rows.Scan(&dayCount.Date, &dayCount.Count); err != nil {
+			return nil, err
+		}
+		list = append(list, dayCount)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return list, nil
+}
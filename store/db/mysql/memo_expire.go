@@ -0,0 +1,90 @@
+package mysql
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pkg/errors"
+
+	"github.com/usememos/memos/store"
+)
+
+// DeleteExpiredMemos deletes the memos archived by deleting them before the given time, with the
+// rows depending on them, in a transaction. The references of their shared resources are released
+// in it, leaving out the resources of shared blobs still in use.
+func (d *DB) DeleteExpiredMemos(ctx context.Context, deletedTsBefore int64) (*store.ExpiredMemos, error) {
+	tx, err := d.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	rows, err := tx.QueryContext(ctx, "SELECT `id`, `uid` FROM `memo` WHERE `row_status` = ? AND `deleted_ts` > 0 AND `deleted_ts` < ?", store.Archived, deletedTsBefore)
+	if err != nil {
+		return nil, err
+	}
+	expired := &store.ExpiredMemos{IDs: []int32{}, Resources: []*store.Resource{}}
+	memoIDs, contentIDs := []any{}, []any{}
+	for rows.Next() {
+		var id int32
+		var uid string
+		if err := rows.Scan(&id, &uid); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		expired.IDs = append(expired.IDs, id)
+		memoIDs, contentIDs = append(memoIDs, id), append(contentIDs, fmt.Sprintf("memos/%s", uid))
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	if len(memoIDs) == 0 {
+		return expired, nil
+	}
+
+	inMemoIDs, memoIDArgs := buildInCondition("`memo_id`", memoIDs)
+	resources, err := listErasedResources(ctx, tx, inMemoIDs, memoIDArgs)
+	if err != nil {
+		return nil, err
+	}
+
+	inRelatedMemoIDs, _ := buildInCondition("`related_memo_id`", memoIDs)
+	inIDs, _ := buildInCondition("`id`", memoIDs)
+	inContentIDs, contentIDArgs := buildInCondition("`content_id`", contentIDs)
+	deletes := []struct {
+		table string
+		where string
+		args  []any
+	}{
+		{"memo_relation", inMemoIDs + " OR " + inRelatedMemoIDs, append(append([]any{}, memoIDArgs...), memoIDArgs...)},
+		{"memo_organizer", inMemoIDs, memoIDArgs},
+		{"memo_acl", inMemoIDs, memoIDArgs},
+		{"memo_revision", inMemoIDs, memoIDArgs},
+		{"memo_idempotency_key", inMemoIDs, memoIDArgs},
+		{"reaction", inContentIDs, contentIDArgs},
+		{"resource", inMemoIDs, memoIDArgs},
+		{"memo", inIDs, memoIDArgs},
+	}
+	for _, delete := range deletes {
+		if _, err := tx.ExecContext(ctx, "DELETE FROM `"+delete.table+"` WHERE "+delete.where, delete.args...); err != nil {
+			return nil, errors.Wrapf(err, "failed to delete from %s", delete.table)
+		}
+	}
+	for _, resource := range resources {
+		if resource.ContentHash != "" {
+			released, err := releaseResourceBlob(ctx, tx, resource, 1)
+			if err != nil {
+				return nil, err
+			}
+			if !released {
+				continue
+			}
+		}
+		expired.Resources = append(expired.Resources, resource)
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return expired, nil
+}
@@ -10,6 +10,7 @@ import (
 	exprv1 "google.golang.org/genproto/googleapis/api/expr/v1alpha1"
 
 	"github.com/usememos/memos/plugin/filter"
+	"github.com/usememos/memos/store"
 )
 
 func (d *DB) ConvertExprToSQL(ctx *filter.ConvertContext, expr *exprv1.Expr) error {
@@ -117,7 +118,11 @@ func (d *DB) ConvertExprToSQL(ctx *filter.ConvertContext, expr *exprv1.Expr) err
 				} else if identifier == "content" {
 					factor = "`memo`.`content`"
 				}
-				if _, err := ctx.Buffer.WriteString(fmt.Sprintf("%s %s ?", factor, operator)); err != nil {
+				condition := fmt.Sprintf("%s %s ?", factor, operator)
+				if identifier == "content" && ctx.CompressedContentEquals != nil {
+					condition = store.CompressedContentCondition(condition, "`memo`.`content_compressed`", "`memo`.`id`", ctx.CompressedContentEquals[valueStr], operator == "!=")
+				}
+				if _, err := ctx.Buffer.WriteString(condition); err != nil {
 					return err
 				}
 				ctx.Args = append(ctx.Args, valueStr)
@@ -200,7 +205,11 @@ func (d *DB) ConvertExprToSQL(ctx *filter.ConvertContext, expr *exprv1.Expr) err
 			if err != nil {
 				return err
 			}
-			if _, err := ctx.Buffer.WriteString("`memo`.`content` LIKE ?"); err != nil {
+			condition := "`memo`.`content` LIKE ?"
+			if ctx.CompressedContentContains != nil {
+				condition = store.CompressedContentCondition(condition, "`memo`.`content_compressed`", "`memo`.`id`", ctx.CompressedContentContains[fmt.Sprint(arg)], false)
+			}
+			if _, err := ctx.Buffer.WriteString(condition); err != nil {
 				return err
 			}
 			ctx.Args = append(ctx.Args, fmt.Sprintf("%%%s%%", arg))
@@ -0,0 +1,70 @@
+package mysql
+
+import (
+	"context"
+
+	"github.com/usememos/memos/store"
+)
+
+// CreateMemoWithIdempotencyKey claims the idempotency key and creates the memo in one transaction.
+// When the key is already claimed, the id of its memo is returned instead. Otherwise the memo is
+// checked against the quota, if any, before it is created.
+func (d *DB) CreateMemoWithIdempotencyKey(ctx context.Context, create *store.Memo, idempotencyKey string, quota *store.MemoQuota) (int32, bool, error) {
+	tx, err := d.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, false, err
+	}
+	defer tx.Rollback()
+
+	// Concurrent creations with the same key wait on the claimed key until this transaction ends.
+	// The no-op update locks a claimed key exclusively, as INSERT IGNORE would only take a shared
+	// lock, which concurrent creations could not upgrade without deadlocking.
+	result, err := tx.ExecContext(ctx, "INSERT INTO `memo_idempotency_key` (`creator_id`, `idempotency_key`) VALUES (?, ?) ON DUPLICATE KEY UPDATE `memo_id` = `memo_id`", create.CreatorID, idempotencyKey)
+	if err != nil {
+		return 0, false, err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return 0, false, err
+	}
+	if affected == 0 {
+		var memoID int32
+		// Lock the row to read the latest committed memo id rather than the snapshot of the transaction.
+		if err := tx.QueryRowContext(ctx, "SELECT `memo_id` FROM `memo_idempotency_key` WHERE `creator_id` = ? AND `idempotency_key` = ? FOR UPDATE", create.CreatorID, idempotencyKey).Scan(&memoID); err != nil {
+			return 0, false, err
+		}
+		return memoID, false, tx.Commit()
+	}
+	if quota != nil {
+		usage, err := getMemoUsage(ctx, tx, create.CreatorID, quota.IncludeArchived)
+		if err != nil {
+			return 0, false, err
+		}
+		if err := quota.Check(usage, create); err != nil {
+			return 0, false, err
+		}
+	}
+
+	stmt, args, err := buildMemoInsert(create)
+	if err != nil {
+		return 0, false, err
+	}
+	result, err = tx.ExecContext(ctx, stmt, args...)
+	if err != nil {
+		return 0, false, convertMemoInsertError(err)
+	}
+	rawID, err := result.LastInsertId()
+	if err != nil {
+		return 0, false, err
+	}
+	memoID := int32(rawID)
+	if _, err := tx.ExecContext(ctx, "UPDATE `memo_idempotency_key` SET `memo_id` = ? WHERE `creator_id` = ? AND `idempotency_key` = ?", memoID, create.CreatorID, idempotencyKey); err != nil {
+		return 0, false, err
+	}
+	return memoID, true, tx.Commit()
+}
+
+func (d *DB) DeleteMemoIdempotencyKeys(ctx context.Context, createdTsBefore int64) error {
+	_, err := d.db.ExecContext(ctx, "DELETE FROM `memo_idempotency_key` WHERE `created_ts` < FROM_UNIXTIME(?)", createdTsBefore)
+	return err
+}
@@ -0,0 +1,126 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/usememos/memos/store"
+)
+
+func (d *DB) ListMemoRevisions(ctx context.Context, find *store.FindMemoRevision) ([]*store.MemoRevision, error) {
+	where, args := []string{"TRUE"}, []any{}
+	if find.ID != nil {
+		where, args = append(where, "`id` = ?"), append(args, *find.ID)
+	}
+	if find.MemoID != nil {
+		where, args = append(where, "`memo_id` = ?"), append(args, *find.MemoID)
+	}
+
+	rows, err := d.db.QueryContext(ctx, "SELECT `id`, `memo_id`, `content`, `editor_id`, UNIX_TIMESTAMP(`created_ts`) FROM `memo_revision` WHERE "+strings.Join(where, " AND ")+" ORDER BY `id` DESC", args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	list := []*store.MemoRevision{}
+	for rows.Next() {
+		revision := &store.MemoRevision{}
+		if err := rows.Scan(
+			&revision.ID,
+			&revision.MemoID,
+			&revision.Content,
+			&revision.EditorID,
+			&revision.CreatedTs,
+		); err != nil {
+			return nil, err
+		}
+		list = append(list, revision)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return list, nil
+}
+
+func (d *DB) DeleteMemoRevision(ctx context.Context, delete *store.DeleteMemoRevision) error {
+	where, args := []string{"TRUE"}, []any{}
+	if delete.MemoID != nil {
+		where, args = append(where, "`memo_id` = ?"), append(args, *delete.MemoID)
+	}
+	_, err := d.db.ExecContext(ctx, "DELETE FROM `memo_revision` WHERE "+strings.Join(where, " AND "), args...)
+	return err
+}
+
+// updateMemoWithRevision runs the update statement of the memo and records its new content as a
+// revision in a transaction, see store.MemoRevision. Nothing is recorded when the content is
+// unchanged. The oldest revisions beyond the revision limit of the update are pruned.
+func (d *DB) updateMemoWithRevision(ctx context.Context, update *store.UpdateMemo, stmt string, args []any) error {
+	tx, err := d.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	var content string
+	var compressed bool
+	var creatorID int32
+	var updatedTs int64
+	if err := tx.QueryRowContext(ctx, "SELECT `content`, `content_compressed`, `creator_id`, UNIX_TIMESTAMP(`updated_ts`) FROM `memo` WHERE `id` = ? FOR UPDATE", update.ID).Scan(&content, &compressed, &creatorID, &updatedTs); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil
+		}
+		return err
+	}
+	if compressed {
+		if content, err = store.DecompressMemoContent(content); err != nil {
+			return err
+		}
+	}
+	result, err := tx.ExecContext(ctx, stmt, args...)
+	if err != nil {
+		return err
+	}
+	if err := checkMemoVersion(update, result); err != nil {
+		return err
+	}
+	if content == *update.Content {
+		return tx.Commit()
+	}
+
+	var count int
+	if err := tx.QueryRowContext(ctx, "SELECT COUNT(*) FROM `memo_revision` WHERE `memo_id` = ?", update.ID).Scan(&count); err != nil {
+		return err
+	}
+	insert := "INSERT INTO `memo_revision` (`memo_id`, `content`, `editor_id`, `created_ts`) VALUES (?, ?, ?, FROM_UNIXTIME(?))"
+	if count == 0 {
+		if _, err := tx.ExecContext(ctx, insert, update.ID, content, creatorID, updatedTs); err != nil {
+			return err
+		}
+	}
+	createdTs := time.Now().Unix()
+	if update.UpdatedTs != nil {
+		createdTs = *update.UpdatedTs
+	}
+	if _, err := tx.ExecContext(ctx, insert, update.ID, *update.Content, update.EditorID, createdTs); err != nil {
+		return err
+	}
+
+	var oldestKeptID int32
+	if err := tx.QueryRowContext(ctx, fmt.Sprintf("SELECT `id` FROM `memo_revision` WHERE `memo_id` = ? ORDER BY `id` DESC LIMIT 1 OFFSET %d", update.RevisionLimit-1), update.ID).Scan(&oldestKeptID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return tx.Commit()
+		}
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, "DELETE FROM `memo_revision` WHERE `memo_id` = ? AND `id` < ?", update.ID, oldestKeptID); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
@@ -0,0 +1,113 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+	"google.golang.org/protobuf/encoding/protojson"
+
+	storepb "github.com/usememos/memos/proto/gen/store"
+	"github.com/usememos/memos/store"
+)
+
+func (d *DB) ListMemoContents(ctx context.Context, afterID int32, limit int) ([]*store.MemoContent, error) {
+	rows, err := d.db.QueryContext(ctx, fmt.Sprintf("SELECT `id`, `content`, `content_compressed` FROM `memo` WHERE `id` > ? ORDER BY `id` ASC LIMIT %d", limit), afterID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	list := []*store.MemoContent{}
+	for rows.Next() {
+		memoContent := &store.MemoContent{}
+		var compressed bool
+		if err := rows.Scan(&memoContent.ID, &memoContent.Content, &compressed); err != nil {
+			return nil, err
+		}
+		if compressed {
+			content, err := store.DecompressMemoContent(memoContent.Content)
+			if err != nil {
+				return nil, errors.Wrapf(err, "failed to decompress content of memo %d", memoContent.ID)
+			}
+			memoContent.Content = content
+		}
+		list = append(list, memoContent)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return list, nil
+}
+
+// UpdateMemoTags sets the tags in the payloads of the memos in a transaction. Memos deleted
+// meanwhile are skipped.
+func (d *DB) UpdateMemoTags(ctx context.Context, list []*store.MemoTags) error {
+	tx, err := d.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	for _, memoTags := range list {
+		var payloadBytes []byte
+		if err := tx.QueryRowContext(ctx, "SELECT `payload` FROM `memo` WHERE `id` = ? FOR UPDATE", memoTags.MemoID).Scan(&payloadBytes); err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				continue
+			}
+			return err
+		}
+		payload := &storepb.MemoPayload{}
+		if err := protojsonUnmarshaler.Unmarshal(payloadBytes, payload); err != nil {
+			return errors.Wrap(err, "failed to unmarshal payload")
+		}
+		payload.Tags = memoTags.Tags
+		payload.NormalizedTags = memoTags.NormalizedTags
+		payloadBytes, err := protojson.Marshal(payload)
+		if err != nil {
+			return err
+		}
+		if _, err := tx.ExecContext(ctx, "UPDATE `memo` SET `payload` = ? WHERE `id` = ?", string(payloadBytes), memoTags.MemoID); err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+// ListMemoTagOverlaps returns the memos matching find that have any of the normalized tags, with
+// the number of them they have, by most first and then by newest first.
+func (d *DB) ListMemoTagOverlaps(ctx context.Context, find *store.FindMemo, normalizedTags []string, limit int) ([]*store.MemoTagOverlap, error) {
+	where, args, err := d.buildMemoFindWhere(find)
+	if err != nil {
+		return nil, err
+	}
+	placeholders := []string{}
+	for _, tag := range normalizedTags {
+		placeholders, args = append(placeholders, "?"), append(args, tag)
+	}
+	where = append(where, fmt.Sprintf("`tag`.`name` IN (%s)", strings.Join(placeholders, ", ")))
+
+	query := "SELECT `memo`.`id`, COUNT(DISTINCT `tag`.`name`) AS `overlap` FROM `memo` LEFT JOIN `memo_relation` ON `memo`.`id` = `memo_relation`.`memo_id` AND `memo_relation`.`type` = 'COMMENT' CROSS JOIN JSON_TABLE(`memo`.`payload`, '$.normalizedTags[*]' COLUMNS (`name` VARCHAR(256) CHARACTER SET utf8mb4 COLLATE utf8mb4_bin PATH '$')) AS `tag` WHERE " + strings.Join(where, " AND ") + fmt.Sprintf(" GROUP BY `memo`.`id` ORDER BY `overlap` DESC, `memo`.`created_ts` DESC, `memo`.`id` DESC LIMIT %d", limit)
+	rows, err := d.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	list := []*store.MemoTagOverlap{}
+	for rows.Next() {
+		overlap := &store.MemoTagOverlap{}
+		if err := rows.Scan(&overlap.MemoID, &overlap.Count); err != nil {
+			return nil, err
+		}
+		list = append(list, overlap)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return list, nil
+}
@@ -8,11 +8,14 @@ import (
 
 	"github.com/usememos/memos/server/profile"
 	"github.com/usememos/memos/store"
+	"github.com/usememos/memos/store/db/sqlbuilder"
 )
 
 type DB struct {
 	db      *sql.DB
 	profile *profile.Profile
+	// dialect builds the statements shared with the other drivers.
+	dialect sqlbuilder.Dialect
 	config  *mysql.Config
 }
 
@@ -25,7 +28,7 @@ func NewDB(profile *profile.Profile) (store.Driver, error) {
 		return nil, err
 	}
 
-	driver := DB{profile: profile}
+	driver := DB{profile: profile, dialect: sqlbuilder.MySQL}
 	driver.config, err = mysql.ParseDSN(dsn)
 	if err != nil {
 		return nil, errors.New("Parse DSN eroor")
@@ -14,37 +14,59 @@ import (
 )
 
 func (d *DB) CreateResource(ctx context.Context, create *store.Resource) (*store.Resource, error) {
-	fields := []string{"`uid`", "`filename`", "`blob`", "`type`", "`size`", "`creator_id`", "`memo_id`", "`storage_type`", "`reference`", "`payload`"}
-	placeholder := []string{"?", "?", "?", "?", "?", "?", "?", "?", "?", "?"}
-	storageType := ""
-	if create.StorageType != storepb.ResourceStorageType_RESOURCE_STORAGE_TYPE_UNSPECIFIED {
-		storageType = create.StorageType.String()
+	stmt, args, err := buildResourceInsert(create)
+	if err != nil {
+		return nil, err
 	}
-	payloadString := "{}"
-	if create.Payload != nil {
-		bytes, err := protojson.Marshal(create.Payload)
-		if err != nil {
-			return nil, errors.Wrap(err, "failed to marshal resource payload")
-		}
-		payloadString = string(bytes)
+	tx, err := d.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
 	}
-	args := []any{create.UID, create.Filename, create.Blob, create.Type, create.Size, create.CreatorID, create.MemoID, storageType, create.Reference, payloadString}
+	defer tx.Rollback()
 
-	stmt := "INSERT INTO `resource` (" + strings.Join(fields, ", ") + ") VALUES (" + strings.Join(placeholder, ", ") + ")"
-	result, err := d.db.ExecContext(ctx, stmt, args...)
+	result, err := tx.ExecContext(ctx, stmt, args...)
 	if err != nil {
 		return nil, err
 	}
-
 	id, err := result.LastInsertId()
 	if err != nil {
 		return nil, err
 	}
+	if create.ContentHash != "" {
+		if _, err := tx.ExecContext(ctx, "INSERT INTO `resource_blob` (`creator_id`, `content_hash`, `reference_count`) VALUES (?, ?, 1) ON DUPLICATE KEY UPDATE `reference_count` = `reference_count` + 1", create.CreatorID, create.ContentHash); err != nil {
+			return nil, err
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
 
 	id32 := int32(id)
 	return d.GetResource(ctx, &store.FindResource{ID: &id32})
 }
 
+// buildResourceInsert returns the statement inserting the resource.
+func buildResourceInsert(create *store.Resource) (string, []any, error) {
+	fields := []string{"`uid`", "`filename`", "`blob`", "`type`", "`size`", "`creator_id`", "`memo_id`", "`storage_type`", "`reference`", "`payload`", "`content_hash`"}
+	placeholder := []string{"?", "?", "?", "?", "?", "?", "?", "?", "?", "?", "?"}
+	storageType := ""
+	if create.StorageType != storepb.ResourceStorageType_RESOURCE_STORAGE_TYPE_UNSPECIFIED {
+		storageType = create.StorageType.String()
+	}
+	payloadString := "{}"
+	if create.Payload != nil {
+		bytes, err := protojson.Marshal(create.Payload)
+		if err != nil {
+			return "", nil, errors.Wrap(err, "failed to marshal resource payload")
+		}
+		payloadString = string(bytes)
+	}
+	args := []any{create.UID, create.Filename, create.Blob, create.Type, create.Size, create.CreatorID, create.MemoID, storageType, create.Reference, payloadString, create.ContentHash}
+
+	stmt := "INSERT INTO `resource` (" + strings.Join(fields, ", ") + ") VALUES (" + strings.Join(placeholder, ", ") + ")"
+	return stmt, args, nil
+}
+
 func (d *DB) ListResources(ctx context.Context, find *store.FindResource) ([]*store.Resource, error) {
 	where, args := []string{"1 = 1"}, []any{}
 
@@ -69,11 +91,14 @@ func (d *DB) ListResources(ctx context.Context, find *store.FindResource) ([]*st
 	if find.HasRelatedMemo {
 		where = append(where, "`memo_id` IS NOT NULL")
 	}
+	if find.Orphaned {
+		where = append(where, "`memo_id` IS NULL AND `creator_id` NOT IN (SELECT `id` FROM `user`)")
+	}
 	if find.StorageType != nil {
 		where, args = append(where, "`storage_type` = ?"), append(args, find.StorageType.String())
 	}
 
-	fields := []string{"`id`", "`uid`", "`filename`", "`type`", "`size`", "`creator_id`", "UNIX_TIMESTAMP(`created_ts`)", "UNIX_TIMESTAMP(`updated_ts`)", "`memo_id`", "`storage_type`", "`reference`", "`payload`"}
+	fields := []string{"`id`", "`uid`", "`filename`", "`type`", "`size`", "`creator_id`", "UNIX_TIMESTAMP(`created_ts`)", "UNIX_TIMESTAMP(`updated_ts`)", "`memo_id`", "`storage_type`", "`reference`", "`payload`", "`content_hash`"}
 	if find.GetBlob {
 		fields = append(fields, "`blob`")
 	}
@@ -111,6 +136,7 @@ func (d *DB) ListResources(ctx context.Context, find *store.FindResource) ([]*st
 			&storageType,
 			&resource.Reference,
 			&payloadBytes,
+			&resource.ContentHash,
 		}
 		if find.GetBlob {
 			dests = append(dests, &resource.Blob)
@@ -0,0 +1,110 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	storepb "github.com/usememos/memos/proto/gen/store"
+	"github.com/usememos/memos/store"
+)
+
+func (d *DB) EraseUser(ctx context.Context, userID int32) (*store.ErasedUser, error) {
+	tx, err := d.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	// The memos of the user go with the comments of others on them, which are handed over to the
+	// user first, so that all the memos to erase are found by their creator. The memos are matched
+	// by subqueries rather than listed, as a long history would exceed the limit of arguments.
+	if _, err := tx.ExecContext(ctx, "UPDATE `memo` SET `creator_id` = ? WHERE `id` IN (SELECT `memo_id` FROM `memo_relation` WHERE `type` = 'COMMENT' AND `related_memo_id` IN (SELECT `id` FROM (SELECT `id` FROM `memo` WHERE `creator_id` = ?) AS `user_memo`))", userID, userID); err != nil {
+		return nil, errors.Wrap(err, "failed to hand over the comments on the memos")
+	}
+	resources, err := listErasedResources(ctx, tx, "`creator_id` = ? OR `memo_id` IN (SELECT `id` FROM `memo` WHERE `creator_id` = ?)", []any{userID, userID})
+	if err != nil {
+		return nil, err
+	}
+
+	deletes := []struct {
+		table string
+		where string
+		args  []any
+	}{
+		{"memo_relation", "`memo_id` IN (SELECT `id` FROM `memo` WHERE `creator_id` = ?) OR `related_memo_id` IN (SELECT `id` FROM `memo` WHERE `creator_id` = ?)", []any{userID, userID}},
+		{"memo_organizer", "`user_id` = ? OR `memo_id` IN (SELECT `id` FROM `memo` WHERE `creator_id` = ?)", []any{userID, userID}},
+		{"memo_acl", "`user_id` = ? OR `memo_id` IN (SELECT `id` FROM `memo` WHERE `creator_id` = ?)", []any{userID, userID}},
+		{"memo_revision", "`memo_id` IN (SELECT `id` FROM `memo` WHERE `creator_id` = ?)", []any{userID}},
+		{"memo_idempotency_key", "`creator_id` = ? OR `memo_id` IN (SELECT `id` FROM `memo` WHERE `creator_id` = ?)", []any{userID, userID}},
+		{"reaction", "`creator_id` = ? OR `content_id` IN (SELECT CONCAT('memos/', `uid`) FROM `memo` WHERE `creator_id` = ?)", []any{userID, userID}},
+		{"resource", "`creator_id` = ? OR `memo_id` IN (SELECT `id` FROM `memo` WHERE `creator_id` = ?)", []any{userID, userID}},
+		{"memo", "`creator_id` = ?", []any{userID}},
+		{"inbox", "`sender_id` = ? OR `receiver_id` = ?", []any{userID, userID}},
+		{"activity", "`creator_id` = ?", []any{userID}},
+		{"webhook", "`creator_id` = ?", []any{userID}},
+		{"user_setting", "`user_id` = ?", []any{userID}},
+		{"user", "`id` = ?", []any{userID}},
+	}
+	erased := &store.ErasedUser{
+		DeletedRows: map[string]int64{},
+		Resources:   resources,
+	}
+	for _, delete := range deletes {
+		result, err := tx.ExecContext(ctx, "DELETE FROM `"+delete.table+"` WHERE "+delete.where, delete.args...)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to delete from %s", delete.table)
+		}
+		affected, err := result.RowsAffected()
+		if err != nil {
+			return nil, err
+		}
+		erased.DeletedRows[delete.table] = affected
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return erased, nil
+}
+
+// buildInCondition returns the condition that the column is one of the values, which is false
+// when there are none.
+func buildInCondition(column string, values []any) (string, []any) {
+	if len(values) == 0 {
+		return "FALSE", nil
+	}
+	return column + " IN (" + strings.Repeat("?, ", len(values)-1) + "?)", values
+}
+
+// listErasedResources lists the resources to erase without their blobs, whose local files and
+// s3 objects are deleted once the resources are.
+func listErasedResources(ctx context.Context, tx *sql.Tx, where string, args []any) ([]*store.Resource, error) {
+	rows, err := tx.QueryContext(ctx, "SELECT `id`, `uid`, `creator_id`, `storage_type`, `reference`, `payload`, `content_hash` FROM `resource` WHERE "+where, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	list := []*store.Resource{}
+	for rows.Next() {
+		resource := &store.Resource{}
+		var storageType string
+		var payloadBytes []byte
+		if err := rows.Scan(&resource.ID, &resource.UID, &resource.CreatorID, &storageType, &resource.Reference, &payloadBytes, &resource.ContentHash); err != nil {
+			return nil, err
+		}
+		resource.StorageType = storepb.ResourceStorageType(storepb.ResourceStorageType_value[storageType])
+		payload := &storepb.ResourcePayload{}
+		if err := protojsonUnmarshaler.Unmarshal(payloadBytes, payload); err != nil {
+			return nil, err
+		}
+		resource.Payload = payload
+		list = append(list, resource)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return list, nil
+}
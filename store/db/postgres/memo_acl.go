@@ -0,0 +1,91 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/usememos/memos/store"
+)
+
+func (d *DB) UpsertMemoACL(ctx context.Context, upsert *store.MemoACL) (*store.MemoACL, error) {
+	stmt := `
+		INSERT INTO memo_acl (
+			memo_id,
+			user_id
+		)
+		VALUES (` + placeholders(2) + `)
+		ON CONFLICT (memo_id, user_id) DO UPDATE SET memo_id = excluded.memo_id
+		RETURNING memo_id, user_id, created_ts
+	`
+	memoACL := &store.MemoACL{}
+	if err := d.db.QueryRowContext(ctx, stmt, upsert.MemoID, upsert.UserID).Scan(
+		&memoACL.MemoID,
+		&memoACL.UserID,
+		&memoACL.CreatedTs,
+	); err != nil {
+		return nil, err
+	}
+	return memoACL, nil
+}
+
+func (d *DB) ListMemoACLs(ctx context.Context, find *store.FindMemoACL) ([]*store.MemoACL, error) {
+	where, args := []string{"TRUE"}, []any{}
+	if find.MemoID != nil {
+		where, args = append(where, "memo_id = "+placeholder(len(args)+1)), append(args, *find.MemoID)
+	}
+	if len(find.MemoIDList) != 0 {
+		placeholders := []string{}
+		for _, id := range find.MemoIDList {
+			placeholders, args = append(placeholders, placeholder(len(args)+1)), append(args, id)
+		}
+		where = append(where, fmt.Sprintf("memo_id IN (%s)", strings.Join(placeholders, ", ")))
+	}
+	if find.UserID != nil {
+		where, args = append(where, "user_id = "+placeholder(len(args)+1)), append(args, *find.UserID)
+	}
+
+	rows, err := d.db.QueryContext(ctx, `
+		SELECT
+			memo_id,
+			user_id,
+			created_ts
+		FROM memo_acl
+		WHERE `+strings.Join(where, " AND ")+`
+		ORDER BY created_ts ASC, user_id ASC`, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	list := []*store.MemoACL{}
+	for rows.Next() {
+		memoACL := &store.MemoACL{}
+		if err := rows.Scan(
+			&memoACL.MemoID,
+			&memoACL.UserID,
+			&memoACL.CreatedTs,
+		); err != nil {
+			return nil, err
+		}
+		list = append(list, memoACL)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return list, nil
+}
+
+func (d *DB) DeleteMemoACL(ctx context.Context, delete *store.DeleteMemoACL) error {
+	where, args := []string{"TRUE"}, []any{}
+	if delete.MemoID != nil {
+		where, args = append(where, "memo_id = "+placeholder(len(args)+1)), append(args, *delete.MemoID)
+	}
+	if delete.UserID != nil {
+		where, args = append(where, "user_id = "+placeholder(len(args)+1)), append(args, *delete.UserID)
+	}
+	_, err := d.db.ExecContext(ctx, "DELETE FROM memo_acl WHERE "+strings.Join(where, " AND "), args...)
+	return err
+}
@@ -0,0 +1,29 @@
+package postgres
+
+import (
+	"context"
+
+	"github.com/usememos/memos/store"
+)
+
+func (d *DB) ListMemoDayCounts(ctx context.Context, creatorID int32, fromTs, toTs int64, tzOffset int) ([]*store.MemoDayCount, error) {
+	query := "SELECT to_char(date_trunc('day', to_timestamp(created_ts + $1) AT TIME ZONE 'UTC'), 'YYYY-MM-DD') AS date, COUNT(*) FROM memo WHERE creator_id = $2 AND row_status = 'NORMAL' AND created_ts >= $3 AND created_ts < $4 GROUP BY date ORDER BY date"
+	rows, err := d.db.QueryContext(ctx, query, tzOffset, creatorID, fromTs, toTs)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	list := []*store.MemoDayCount{}
+	for rows.Next() {
+		dayCount := &store.MemoDayCount{}
+		if err := rows.Scan(&dayCount.Date, &dayCount.Count); err != nil {
+			return nil, err
+		}
+		list = append(list, dayCount)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return list, nil
+}
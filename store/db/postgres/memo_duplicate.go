@@ -0,0 +1,145 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/usememos/memos/store"
+)
+
+func (d *DB) ListDuplicateMemoContentHashes(ctx context.Context, creatorID int32) ([]*store.MemoContentHash, error) {
+	query := "SELECT memo.id, memo.content_hash FROM memo " +
+		"LEFT JOIN memo_relation ON memo.id = memo_relation.memo_id AND memo_relation.type = 'COMMENT' " +
+		"WHERE memo.creator_id = $1 AND memo_relation.related_memo_id IS NULL AND memo.content_hash IN (" +
+		"SELECT content_hash FROM memo WHERE creator_id = $1 AND content_hash != '' GROUP BY content_hash HAVING COUNT(*) > 1" +
+		") ORDER BY memo.id"
+	rows, err := d.db.QueryContext(ctx, query, creatorID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	list := []*store.MemoContentHash{}
+	for rows.Next() {
+		contentHash := &store.MemoContentHash{}
+		if err := rows.Scan(&contentHash.MemoID, &contentHash.ContentHash); err != nil {
+			return nil, err
+		}
+		list = append(list, contentHash)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return list, nil
+}
+
+func (d *DB) MergeMemos(ctx context.Context, merge *store.MergeMemos) error {
+	tx, err := d.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	// The ids are the first arguments of the statements, $1 to $n.
+	idArgs := []any{}
+	merged := map[int32]bool{}
+	for _, id := range merge.MergeIDs {
+		idArgs = append(idArgs, id)
+		merged[id] = true
+	}
+	inIDs := "(" + placeholders(len(idArgs)) + ")"
+	next := placeholder(len(idArgs) + 1)
+
+	// Relations are moved by inserting them again, as they have no id to update by.
+	rows, err := tx.QueryContext(ctx, "SELECT memo_id, related_memo_id, type FROM memo_relation WHERE memo_id IN "+inIDs+" OR related_memo_id IN "+inIDs, idArgs...)
+	if err != nil {
+		return err
+	}
+	relations := []*store.MemoRelation{}
+	for rows.Next() {
+		relation := &store.MemoRelation{}
+		if err := rows.Scan(&relation.MemoID, &relation.RelatedMemoID, &relation.Type); err != nil {
+			rows.Close()
+			return err
+		}
+		relations = append(relations, relation)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, "DELETE FROM memo_relation WHERE memo_id IN "+inIDs+" OR related_memo_id IN "+inIDs, idArgs...); err != nil {
+		return err
+	}
+	for _, relation := range relations {
+		if merged[relation.MemoID] {
+			relation.MemoID = merge.KeepID
+		}
+		if merged[relation.RelatedMemoID] {
+			relation.RelatedMemoID = merge.KeepID
+		}
+		if relation.MemoID == relation.RelatedMemoID {
+			continue
+		}
+		if _, err := tx.ExecContext(ctx, "INSERT INTO memo_relation (memo_id, related_memo_id, type) VALUES ($1, $2, $3) ON CONFLICT DO NOTHING", relation.MemoID, relation.RelatedMemoID, relation.Type); err != nil {
+			return err
+		}
+	}
+
+	if _, err := tx.ExecContext(ctx, "UPDATE resource SET memo_id = "+next+" WHERE memo_id IN "+inIDs, append(idArgs, merge.KeepID)...); err != nil {
+		return err
+	}
+
+	// A user's reaction of a type to the kept memo stays, and the same ones to the merged memos are dropped.
+	contentIDArgs := []any{merge.KeepReactionContentID}
+	for _, contentID := range merge.MergeReactionContentIDs {
+		contentIDArgs = append(contentIDArgs, contentID)
+	}
+	rows, err = tx.QueryContext(ctx, "SELECT id, creator_id, content_id, reaction_type FROM reaction WHERE content_id IN ("+placeholders(len(contentIDArgs))+") ORDER BY content_id = $1 DESC, id", contentIDArgs...)
+	if err != nil {
+		return err
+	}
+	reactions := []*store.Reaction{}
+	for rows.Next() {
+		reaction := &store.Reaction{}
+		if err := rows.Scan(&reaction.ID, &reaction.CreatorID, &reaction.ContentID, &reaction.ReactionType); err != nil {
+			rows.Close()
+			return err
+		}
+		reactions = append(reactions, reaction)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return err
+	}
+	kept := map[string]bool{}
+	for _, reaction := range reactions {
+		key := fmt.Sprintf("%d/%s", reaction.CreatorID, reaction.ReactionType)
+		if kept[key] {
+			if _, err := tx.ExecContext(ctx, "DELETE FROM reaction WHERE id = $1", reaction.ID); err != nil {
+				return err
+			}
+			continue
+		}
+		kept[key] = true
+		if reaction.ContentID != merge.KeepReactionContentID {
+			if _, err := tx.ExecContext(ctx, "UPDATE reaction SET content_id = $1 WHERE id = $2", merge.KeepReactionContentID, reaction.ID); err != nil {
+				return err
+			}
+		}
+	}
+
+	if _, err := tx.ExecContext(ctx, "UPDATE memo_idempotency_key SET memo_id = "+next+" WHERE memo_id IN "+inIDs, append(idArgs, merge.KeepID)...); err != nil {
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, "DELETE FROM memo_acl WHERE memo_id IN "+inIDs, idArgs...); err != nil {
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, "DELETE FROM memo_revision WHERE memo_id IN "+inIDs, idArgs...); err != nil {
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, "DELETE FROM memo WHERE id IN "+inIDs, idArgs...); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
@@ -0,0 +1,42 @@
+package postgres
+
+import (
+	"context"
+	"strings"
+
+	"github.com/usememos/memos/store"
+)
+
+// ExplainListMemos reports the plan of EXPLAIN, or EXPLAIN ANALYZE with analyze set, which runs the query.
+func (d *DB) ExplainListMemos(ctx context.Context, find *store.FindMemo, analyze bool) (*store.MemoQueryPlan, error) {
+	query, args, err := d.buildMemoListQuery(find)
+	if err != nil {
+		return nil, err
+	}
+	explain := "EXPLAIN "
+	if analyze {
+		explain = "EXPLAIN ANALYZE "
+	}
+	rows, err := d.db.QueryContext(ctx, explain+query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	lines := []string{}
+	for rows.Next() {
+		var line string
+		if err := rows.Scan(&line); err != nil {
+			return nil, err
+		}
+		lines = append(lines, line)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return &store.MemoQueryPlan{
+		SQL:  query,
+		Args: args,
+		Plan: strings.Join(lines, "\n"),
+	}, nil
+}
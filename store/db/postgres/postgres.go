@@ -10,11 +10,14 @@ import (
 
 	"github.com/usememos/memos/server/profile"
 	"github.com/usememos/memos/store"
+	"github.com/usememos/memos/store/db/sqlbuilder"
 )
 
 type DB struct {
 	db      *sql.DB
 	profile *profile.Profile
+	// dialect builds the statements shared with the other drivers.
+	dialect sqlbuilder.Dialect
 	// Add any other fields as needed
 }
 
@@ -33,6 +36,7 @@ func NewDB(profile *profile.Profile) (store.Driver, error) {
 	var driver store.Driver = &DB{
 		db:      db,
 		profile: profile,
+		dialect: sqlbuilder.Postgres,
 	}
 
 	// Return the DB struct
@@ -33,6 +33,13 @@ func (d *DB) ListReactions(ctx context.Context, find *store.FindReaction) ([]*st
 	if find.ContentID != nil {
 		where, args = append(where, "content_id = "+placeholder(len(args)+1)), append(args, *find.ContentID)
 	}
+	if len(find.ContentIDList) > 0 {
+		placeholders := []string{}
+		for _, contentID := range find.ContentIDList {
+			placeholders, args = append(placeholders, placeholder(len(args)+1)), append(args, contentID)
+		}
+		where = append(where, "content_id IN ("+strings.Join(placeholders, ", ")+")")
+	}
 
 	rows, err := d.db.QueryContext(ctx, `
 		SELECT
@@ -73,6 +80,60 @@ func (d *DB) ListReactions(ctx context.Context, find *store.FindReaction) ([]*st
 	return list, nil
 }
 
+func (d *DB) ListReactionSummaries(ctx context.Context, contentIDList []string, viewerID *int32) ([]*store.ReactionSummary, error) {
+	if len(contentIDList) == 0 {
+		return []*store.ReactionSummary{}, nil
+	}
+	args := []any{}
+	viewerReacted := "0"
+	if viewerID != nil {
+		viewerReacted, args = "MAX(CASE WHEN creator_id = $1 THEN 1 ELSE 0 END)", append(args, *viewerID)
+	}
+	placeholders := []string{}
+	for _, contentID := range contentIDList {
+		placeholders, args = append(placeholders, placeholder(len(args)+1)), append(args, contentID)
+	}
+
+	rows, err := d.db.QueryContext(ctx, `
+		SELECT
+			content_id,
+			reaction_type,
+			COUNT(*),
+			`+viewerReacted+`
+		FROM reaction
+		WHERE content_id IN (`+strings.Join(placeholders, ", ")+`)
+		GROUP BY content_id, reaction_type
+		ORDER BY MIN(id) ASC`,
+		args...,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	list := []*store.ReactionSummary{}
+	for rows.Next() {
+		summary := &store.ReactionSummary{}
+		viewerReacted := 0
+		if err := rows.Scan(
+			&summary.ContentID,
+			&summary.ReactionType,
+			&summary.Count,
+			&viewerReacted,
+		); err != nil {
+			return nil, err
+		}
+		summary.ViewerReacted = viewerReacted > 0
+		list = append(list, summary)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return list, nil
+}
+
 func (d *DB) DeleteReaction(ctx context.Context, delete *store.DeleteReaction) error {
 	_, err := d.db.ExecContext(ctx, "DELETE FROM reaction WHERE id = $1", delete.ID)
 	return err
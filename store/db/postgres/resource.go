@@ -14,7 +14,31 @@ import (
 )
 
 func (d *DB) CreateResource(ctx context.Context, create *store.Resource) (*store.Resource, error) {
-	fields := []string{"uid", "filename", "blob", "type", "size", "creator_id", "memo_id", "storage_type", "reference", "payload"}
+	stmt, args, err := buildResourceInsert(create)
+	if err != nil {
+		return nil, err
+	}
+	tx, err := d.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	if err := tx.QueryRowContext(ctx, stmt, args...).Scan(&create.ID, &create.CreatedTs, &create.UpdatedTs); err != nil {
+		return nil, err
+	}
+	if create.ContentHash != "" {
+		if _, err := tx.ExecContext(ctx, "INSERT INTO resource_blob (creator_id, content_hash, reference_count) VALUES ($1, $2, 1) ON CONFLICT (creator_id, content_hash) DO UPDATE SET reference_count = resource_blob.reference_count + 1", create.CreatorID, create.ContentHash); err != nil {
+			return nil, err
+		}
+	}
+	return create, tx.Commit()
+}
+
+// buildResourceInsert returns the statement inserting the resource, which returns the id, created_ts
+// and updated_ts of the resource.
+func buildResourceInsert(create *store.Resource) (string, []any, error) {
+	fields := []string{"uid", "filename", "blob", "type", "size", "creator_id", "memo_id", "storage_type", "reference", "payload", "content_hash"}
 	storageType := ""
 	if create.StorageType != storepb.ResourceStorageType_RESOURCE_STORAGE_TYPE_UNSPECIFIED {
 		storageType = create.StorageType.String()
@@ -23,17 +47,14 @@ func (d *DB) CreateResource(ctx context.Context, create *store.Resource) (*store
 	if create.Payload != nil {
 		bytes, err := protojson.Marshal(create.Payload)
 		if err != nil {
-			return nil, errors.Wrap(err, "failed to marshal resource payload")
+			return "", nil, errors.Wrap(err, "failed to marshal resource payload")
 		}
 		payloadString = string(bytes)
 	}
-	args := []any{create.UID, create.Filename, create.Blob, create.Type, create.Size, create.CreatorID, create.MemoID, storageType, create.Reference, payloadString}
+	args := []any{create.UID, create.Filename, create.Blob, create.Type, create.Size, create.CreatorID, create.MemoID, storageType, create.Reference, payloadString, create.ContentHash}
 
 	stmt := "INSERT INTO resource (" + strings.Join(fields, ", ") + ") VALUES (" + placeholders(len(args)) + ") RETURNING id, created_ts, updated_ts"
-	if err := d.db.QueryRowContext(ctx, stmt, args...).Scan(&create.ID, &create.CreatedTs, &create.UpdatedTs); err != nil {
-		return nil, err
-	}
-	return create, nil
+	return stmt, args, nil
 }
 
 func (d *DB) ListResources(ctx context.Context, find *store.FindResource) ([]*store.Resource, error) {
@@ -60,11 +81,14 @@ func (d *DB) ListResources(ctx context.Context, find *store.FindResource) ([]*st
 	if find.HasRelatedMemo {
 		where = append(where, "memo_id IS NOT NULL")
 	}
+	if find.Orphaned {
+		where = append(where, `memo_id IS NULL AND creator_id NOT IN (SELECT id FROM "user")`)
+	}
 	if v := find.StorageType; v != nil {
 		where, args = append(where, "storage_type = "+placeholder(len(args)+1)), append(args, v.String())
 	}
 
-	fields := []string{"id", "uid", "filename", "type", "size", "creator_id", "created_ts", "updated_ts", "memo_id", "storage_type", "reference", "payload"}
+	fields := []string{"id", "uid", "filename", "type", "size", "creator_id", "created_ts", "updated_ts", "memo_id", "storage_type", "reference", "payload", "content_hash"}
 	if find.GetBlob {
 		fields = append(fields, "blob")
 	}
@@ -108,6 +132,7 @@ func (d *DB) ListResources(ctx context.Context, find *store.FindResource) ([]*st
 			&storageType,
 			&resource.Reference,
 			&payloadBytes,
+			&resource.ContentHash,
 		}
 		if find.GetBlob {
 			dests = append(dests, &resource.Blob)
@@ -0,0 +1,170 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/pkg/errors"
+
+	storepb "github.com/usememos/memos/proto/gen/store"
+	"github.com/usememos/memos/store"
+)
+
+// CreateDuplicateResource takes a reference to the shared blob and creates the resource with the
+// storage of a resource sharing it in one transaction. Nil is returned when the blob is no longer
+// referenced.
+func (d *DB) CreateDuplicateResource(ctx context.Context, create *store.Resource) (*store.Resource, error) {
+	tx, err := d.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	// Taking the reference first locks the row of the blob, so that it cannot be released meanwhile.
+	result, err := tx.ExecContext(ctx, "UPDATE resource_blob SET reference_count = reference_count + 1 WHERE creator_id = $1 AND content_hash = $2", create.CreatorID, create.ContentHash)
+	if err != nil {
+		return nil, err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return nil, err
+	}
+	if affected == 0 {
+		return nil, nil
+	}
+	var storageType string
+	var payloadBytes []byte
+	if err := tx.QueryRowContext(ctx, "SELECT storage_type, reference, payload FROM resource WHERE creator_id = $1 AND content_hash = $2 LIMIT 1", create.CreatorID, create.ContentHash).Scan(&storageType, &create.Reference, &payloadBytes); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	create.Blob = nil
+	create.StorageType = storepb.ResourceStorageType(storepb.ResourceStorageType_value[storageType])
+	payload := &storepb.ResourcePayload{}
+	if err := protojsonUnmarshaler.Unmarshal(payloadBytes, payload); err != nil {
+		return nil, err
+	}
+	create.Payload = payload
+
+	stmt, args, err := buildResourceInsert(create)
+	if err != nil {
+		return nil, err
+	}
+	if err := tx.QueryRowContext(ctx, stmt, args...).Scan(&create.ID, &create.CreatedTs, &create.UpdatedTs); err != nil {
+		return nil, err
+	}
+	return create, tx.Commit()
+}
+
+// DeleteSharedResource deletes the resource and releases its reference to the shared blob in one
+// transaction, reporting whether the blob is to be deleted, see releaseResourceBlob.
+func (d *DB) DeleteSharedResource(ctx context.Context, resource *store.Resource) (bool, error) {
+	tx, err := d.db.BeginTx(ctx, nil)
+	if err != nil {
+		return false, err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, "DELETE FROM resource WHERE id = $1", resource.ID); err != nil {
+		return false, err
+	}
+	released, err := releaseResourceBlob(ctx, tx, resource, 1)
+	if err != nil {
+		return false, err
+	}
+	return released, tx.Commit()
+}
+
+// ReleaseResourceBlob releases the given number of references of the creator of the resource to its
+// shared blob in one transaction, reporting whether the blob is to be deleted, see releaseResourceBlob.
+func (d *DB) ReleaseResourceBlob(ctx context.Context, resource *store.Resource, count int) (bool, error) {
+	tx, err := d.db.BeginTx(ctx, nil)
+	if err != nil {
+		return false, err
+	}
+	defer tx.Rollback()
+
+	released, err := releaseResourceBlob(ctx, tx, resource, count)
+	if err != nil {
+		return false, err
+	}
+	return released, tx.Commit()
+}
+
+// releaseResourceBlob releases references of the creator of the resource to its shared blob, which
+// is forgotten once no reference is left. The blob is only to be deleted then, and only if no other
+// resource still uses its storage, as resources transferred to other users do. A blob that is not
+// known is not released, as it may be shared.
+func releaseResourceBlob(ctx context.Context, tx *sql.Tx, resource *store.Resource, count int) (bool, error) {
+	// Releasing the references first locks the row of the blob, so that no reference can be taken meanwhile.
+	result, err := tx.ExecContext(ctx, "UPDATE resource_blob SET reference_count = reference_count - $1 WHERE creator_id = $2 AND content_hash = $3", count, resource.CreatorID, resource.ContentHash)
+	if err != nil {
+		return false, err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	if affected == 0 {
+		return false, nil
+	}
+	var referenceCount int
+	if err := tx.QueryRowContext(ctx, "SELECT reference_count FROM resource_blob WHERE creator_id = $1 AND content_hash = $2", resource.CreatorID, resource.ContentHash).Scan(&referenceCount); err != nil {
+		return false, err
+	}
+	if referenceCount > 0 {
+		return false, nil
+	}
+	if _, err := tx.ExecContext(ctx, "DELETE FROM resource_blob WHERE creator_id = $1 AND content_hash = $2", resource.CreatorID, resource.ContentHash); err != nil {
+		return false, err
+	}
+	var used bool
+	if err := tx.QueryRowContext(ctx, "SELECT EXISTS (SELECT 1 FROM resource WHERE storage_type = $1 AND reference = $2)", resource.StorageType.String(), resource.Reference).Scan(&used); err != nil {
+		return false, err
+	}
+	return !used, nil
+}
+
+// transferResourceBlobs moves the references of the shared resources of the memo to their blobs to
+// the given user, to be called before the resources are transferred to them. A blob may then be
+// referenced by both users, see releaseResourceBlob.
+func transferResourceBlobs(ctx context.Context, tx *sql.Tx, memoID, toUserID int32) error {
+	rows, err := tx.QueryContext(ctx, "SELECT creator_id, content_hash, COUNT(*) FROM resource WHERE memo_id = $1 AND content_hash != '' AND creator_id != $2 GROUP BY creator_id, content_hash", memoID, toUserID)
+	if err != nil {
+		return err
+	}
+	type blobReferences struct {
+		creatorID   int32
+		contentHash string
+		count       int
+	}
+	list := []blobReferences{}
+	for rows.Next() {
+		var references blobReferences
+		if err := rows.Scan(&references.creatorID, &references.contentHash, &references.count); err != nil {
+			rows.Close()
+			return err
+		}
+		list = append(list, references)
+	}
+	if err := rows.Err(); err != nil {
+		rows.Close()
+		return err
+	}
+	rows.Close()
+
+	for _, references := range list {
+		if _, err := tx.ExecContext(ctx, "UPDATE resource_blob SET reference_count = reference_count - $1 WHERE creator_id = $2 AND content_hash = $3", references.count, references.creatorID, references.contentHash); err != nil {
+			return err
+		}
+		if _, err := tx.ExecContext(ctx, "DELETE FROM resource_blob WHERE creator_id = $1 AND content_hash = $2 AND reference_count <= 0", references.creatorID, references.contentHash); err != nil {
+			return err
+		}
+		if _, err := tx.ExecContext(ctx, "INSERT INTO resource_blob (creator_id, content_hash, reference_count) VALUES ($1, $2, $3) ON CONFLICT (creator_id, content_hash) DO UPDATE SET reference_count = resource_blob.reference_count + EXCLUDED.reference_count", toUserID, references.contentHash, references.count); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -2,68 +2,143 @@ package postgres
 
 import (
 	"context"
-	"strings"
+	"database/sql"
 
 	storepb "github.com/usememos/memos/proto/gen/store"
 	"github.com/usememos/memos/store"
+	"github.com/usememos/memos/store/db/sqlbuilder"
 )
 
 func (d *DB) UpsertUserSetting(ctx context.Context, upsert *store.UserSetting) (*store.UserSetting, error) {
-	stmt := `
-		INSERT INTO user_setting (
-			user_id, key, value
-		)
-		VALUES ($1, $2, $3)
-		ON CONFLICT(user_id, key) DO UPDATE 
-		SET value = EXCLUDED.value
-	`
-	if _, err := d.db.ExecContext(ctx, stmt, upsert.UserID, upsert.Key.String(), upsert.Value); err != nil {
+	stmt, args, err := d.dialect.BuildUpsert(userSettingUpsert([]*store.UserSetting{upsert}))
+	if err != nil {
+		return nil, err
+	}
+	if _, err := d.db.ExecContext(ctx, stmt, args...); err != nil {
 		return nil, err
 	}
 	return upsert, nil
 }
 
-func (d *DB) ListUserSettings(ctx context.Context, find *store.FindUserSetting) ([]*store.UserSetting, error) {
-	where, args := []string{"1 = 1"}, []any{}
+// BatchUpsertUserSettings upserts the settings with a single multi-row statement in a transaction.
+func (d *DB) BatchUpsertUserSettings(ctx context.Context, upserts []*store.UserSetting) ([]*store.UserSetting, error) {
+	stmt, args, err := d.dialect.BuildUpsert(userSettingUpsert(upserts))
+	if err != nil {
+		return nil, err
+	}
+	tx, err := d.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
 
-	if v := find.Key; v != storepb.UserSettingKey_USER_SETTING_KEY_UNSPECIFIED {
-		where, args = append(where, "key = "+placeholder(len(args)+1)), append(args, v.String())
+	if _, err := tx.ExecContext(ctx, stmt, args...); err != nil {
+		return nil, err
 	}
-	if v := find.UserID; v != nil {
-		where, args = append(where, "user_id = "+placeholder(len(args)+1)), append(args, *find.UserID)
+	if err := tx.Commit(); err != nil {
+		return nil, err
 	}
+	return upserts, nil
+}
 
-	query := `
-		SELECT
-			user_id,
-		  key,
-			value
-		FROM user_setting
-		WHERE ` + strings.Join(where, " AND ")
-	rows, err := d.db.QueryContext(ctx, query, args...)
+// UpdateUserSetting replaces the setting of the user with the key by the setting update returns
+// for it, or for nil when there is none, in a transaction. The row of the setting is locked before it is
+// read, so that concurrent updates do not overwrite each other.
+// Nothing is written when update returns nil, which UpdateUserSetting returns as well.
+func (d *DB) UpdateUserSetting(ctx context.Context, userID int32, key storepb.UserSettingKey, update func(*store.UserSetting) (*store.UserSetting, error)) (*store.UserSetting, error) {
+	tx, err := d.db.BeginTx(ctx, nil)
 	if err != nil {
 		return nil, err
 	}
-	defer rows.Close()
+	defer tx.Rollback()
 
-	userSettingList := make([]*store.UserSetting, 0)
-	for rows.Next() {
-		userSetting := &store.UserSetting{}
-		var keyString string
-		if err := rows.Scan(
-			&userSetting.UserID,
-			&keyString,
-			&userSetting.Value,
-		); err != nil {
+	// A missing setting is inserted empty first, so that there is a row to lock.
+	stmt, args, err := d.dialect.BuildUpsert(&sqlbuilder.Upsert{
+		Table:           "user_setting",
+		Columns:         []string{"user_id", "key", "value"},
+		Rows:            [][]any{{userID, key.String(), ""}},
+		ConflictColumns: []string{"user_id", "key"},
+		DoNothing:       true,
+	})
+	if err != nil {
+		return nil, err
+	}
+	result, err := tx.ExecContext(ctx, stmt, args...)
+	if err != nil {
+		return nil, err
+	}
+	inserted, err := result.RowsAffected()
+	if err != nil {
+		return nil, err
+	}
+	var userSetting *store.UserSetting
+	if inserted == 0 {
+		userSetting = &store.UserSetting{UserID: userID, Key: key, RawKey: key.String()}
+		query, args := d.dialect.Select("user_setting", "value").WhereEqual("user_id", userID).WhereEqual("key", key.String()).ForUpdate().Build()
+		if err := tx.QueryRowContext(ctx, query, args...).Scan(&userSetting.Value); err != nil {
 			return nil, err
 		}
-		userSetting.Key = storepb.UserSettingKey(storepb.UserSettingKey_value[keyString])
-		userSettingList = append(userSettingList, userSetting)
 	}
+	userSetting, err = update(userSetting)
+	if err != nil || userSetting == nil {
+		return nil, err
+	}
+	stmt, args, err = d.dialect.Update("user_setting").Set("value", userSetting.Value).WhereEqual("user_id", userID).WhereEqual("key", key.String()).Build()
+	if err != nil {
+		return nil, err
+	}
+	if _, err := tx.ExecContext(ctx, stmt, args...); err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return userSetting, nil
+}
 
-	if err := rows.Err(); err != nil {
+func (d *DB) ListUserSettings(ctx context.Context, find *store.FindUserSetting) ([]*store.UserSetting, error) {
+	builder := d.dialect.Select("user_setting", "user_id", "key", "value")
+	if v := find.Key; v != storepb.UserSettingKey_USER_SETTING_KEY_UNSPECIFIED {
+		builder.WhereEqual("key", v.String())
+	}
+	if v := find.UserID; v != nil {
+		builder.WhereEqual("user_id", *v)
+	}
+	if v := find.ValueContains; v != nil {
+		builder.Where("strpos(\"value\", ?) > 0", *v)
+	}
+	query, args := builder.OrderBy("user_id", "key").Limit(find.Limit, find.Offset).Build()
+	rows, err := d.db.QueryContext(ctx, query, args...)
+	if err != nil {
 		return nil, err
 	}
+	return sqlbuilder.ScanRows(rows, func(rows *sql.Rows) (*store.UserSetting, error) {
+		userSetting := &store.UserSetting{}
+		if err := rows.Scan(&userSetting.UserID, &userSetting.RawKey, &userSetting.Value); err != nil {
+			return nil, err
+		}
+		userSetting.Key = storepb.UserSettingKey(storepb.UserSettingKey_value[userSetting.RawKey])
+		return userSetting, nil
+	})
+}
+
+func (d *DB) DeleteUserSetting(ctx context.Context, delete *store.DeleteUserSetting) error {
+	stmt, args := d.dialect.Delete("user_setting").WhereEqual("user_id", delete.UserID).WhereEqual("key", delete.Key.String()).Build()
+	_, err := d.db.ExecContext(ctx, stmt, args...)
+	return err
+}
 
-	return userSettingList, nil
+// userSettingUpsert returns the upsert of the settings, which replaces the value of existing ones.
+func userSettingUpsert(upserts []*store.UserSetting) *sqlbuilder.Upsert {
+	rows := make([][]any, 0, len(upserts))
+	for _, upsert := range upserts {
+		rows = append(rows, []any{upsert.UserID, upsert.Key.String(), upsert.Value})
+	}
+	return &sqlbuilder.Upsert{
+		Table:           "user_setting",
+		Columns:         []string{"user_id", "key", "value"},
+		Rows:            rows,
+		ConflictColumns: []string{"user_id", "key"},
+		UpdateColumns:   []string{"value"},
+	}
 }
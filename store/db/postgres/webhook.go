@@ -8,8 +8,8 @@ import (
 )
 
 func (d *DB) CreateWebhook(ctx context.Context, create *store.Webhook) (*store.Webhook, error) {
-	fields := []string{"name", "url", "creator_id"}
-	args := []any{create.Name, create.URL, create.CreatorID}
+	fields := []string{"name", "url", "creator_id", "secret"}
+	args := []any{create.Name, create.URL, create.CreatorID, create.Secret}
 	stmt := "INSERT INTO webhook (" + strings.Join(fields, ", ") + ") VALUES (" + placeholders(len(args)) + ") RETURNING id, created_ts, updated_ts"
 	if err := d.db.QueryRowContext(ctx, stmt, args...).Scan(
 		&create.ID,
@@ -38,7 +38,8 @@ func (d *DB) ListWebhooks(ctx context.Context, find *store.FindWebhook) ([]*stor
 			updated_ts,
 			creator_id,
 			name,
-			url
+			url,
+			secret
 		FROM webhook
 		WHERE `+strings.Join(where, " AND ")+`
 		ORDER BY id DESC`,
@@ -59,6 +60,7 @@ func (d *DB) ListWebhooks(ctx context.Context, find *store.FindWebhook) ([]*stor
 			&webhook.CreatorID,
 			&webhook.Name,
 			&webhook.URL,
+			&webhook.Secret,
 		); err != nil {
 			return nil, err
 		}
@@ -80,8 +82,11 @@ func (d *DB) UpdateWebhook(ctx context.Context, update *store.UpdateWebhook) (*s
 	if update.URL != nil {
 		set, args = append(set, "url = "+placeholder(len(args)+1)), append(args, *update.URL)
 	}
+	if update.Secret != nil {
+		set, args = append(set, "secret = "+placeholder(len(args)+1)), append(args, *update.Secret)
+	}
 
-	stmt := "UPDATE webhook SET " + strings.Join(set, ", ") + " WHERE id = " + placeholder(len(args)+1) + " RETURNING id, created_ts, updated_ts, creator_id, name, url"
+	stmt := "UPDATE webhook SET " + strings.Join(set, ", ") + " WHERE id = " + placeholder(len(args)+1) + " RETURNING id, created_ts, updated_ts, creator_id, name, url, secret"
 	args = append(args, update.ID)
 	webhook := &store.Webhook{}
 	if err := d.db.QueryRowContext(ctx, stmt, args...).Scan(
@@ -91,6 +96,7 @@ func (d *DB) UpdateWebhook(ctx context.Context, update *store.UpdateWebhook) (*s
 		&webhook.CreatorID,
 		&webhook.Name,
 		&webhook.URL,
+		&webhook.Secret,
 	); err != nil {
 		return nil, err
 	}
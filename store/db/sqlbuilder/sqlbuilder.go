@@ -0,0 +1,306 @@
+// Package sqlbuilder builds the SQL statements that differ between the database drivers only
+// in their syntax, i.e. placeholders, identifier quoting and upserts, so that the drivers can
+// share them instead of keeping a copy each.
+package sqlbuilder
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// Dialect is the SQL dialect of a database driver.
+type Dialect string
+
+const (
+	SQLite   Dialect = "sqlite"
+	MySQL    Dialect = "mysql"
+	Postgres Dialect = "postgres"
+)
+
+// Quote quotes the identifier, e.g. `key` or "user".
+func (d Dialect) Quote(identifier string) string {
+	if d == Postgres {
+		return `"` + identifier + `"`
+	}
+	return "`" + identifier + "`"
+}
+
+// Placeholder returns the placeholder of the nth argument, counting from 1.
+func (d Dialect) Placeholder(n int) string {
+	if d == Postgres {
+		return fmt.Sprintf("$%d", n)
+	}
+	return "?"
+}
+
+func (d Dialect) quoteAll(identifiers []string) string {
+	quoted := make([]string, 0, len(identifiers))
+	for _, identifier := range identifiers {
+		quoted = append(quoted, d.Quote(identifier))
+	}
+	return strings.Join(quoted, ", ")
+}
+
+// Upsert inserts rows, and updates the given columns of the rows that conflict on a unique key.
+type Upsert struct {
+	Table   string
+	Columns []string
+	// Rows are the values of the rows to insert, in the order of Columns.
+	Rows [][]any
+	// ConflictColumns are the columns of the unique key. MySQL updates the rows that conflict
+	// on any unique key and does not need them.
+	ConflictColumns []string
+	// UpdateColumns are set to the inserted values on conflict.
+	UpdateColumns []string
+	// DoNothing keeps the rows that conflict as they are instead of updating UpdateColumns.
+	// MySQL sets the first column to itself instead, which locks the row exclusively as well.
+	DoNothing bool
+}
+
+// BuildUpsert returns the statement and the arguments of the upsert.
+func (d Dialect) BuildUpsert(upsert *Upsert) (string, []any, error) {
+	if len(upsert.Rows) == 0 {
+		return "", nil, errors.New("no rows to upsert")
+	}
+	if len(upsert.UpdateColumns) == 0 && !upsert.DoNothing {
+		return "", nil, errors.New("no columns to update on conflict")
+	}
+	values, args := []string{}, []any{}
+	for _, row := range upsert.Rows {
+		if len(row) != len(upsert.Columns) {
+			return "", nil, errors.Errorf("row has %d values for %d columns", len(row), len(upsert.Columns))
+		}
+		placeholders := []string{}
+		for _, value := range row {
+			args = append(args, value)
+			placeholders = append(placeholders, d.Placeholder(len(args)))
+		}
+		values = append(values, "("+strings.Join(placeholders, ", ")+")")
+	}
+	stmt := fmt.Sprintf("INSERT INTO %s (%s) VALUES %s", d.Quote(upsert.Table), d.quoteAll(upsert.Columns), strings.Join(values, ", "))
+
+	if upsert.DoNothing {
+		if d == MySQL {
+			return stmt + fmt.Sprintf(" ON DUPLICATE KEY UPDATE %s = %s", d.Quote(upsert.Columns[0]), d.Quote(upsert.Columns[0])), args, nil
+		}
+		if len(upsert.ConflictColumns) == 0 {
+			return "", nil, errors.New("no conflict columns")
+		}
+		return stmt + fmt.Sprintf(" ON CONFLICT(%s) DO NOTHING", d.quoteAll(upsert.ConflictColumns)), args, nil
+	}
+
+	set := []string{}
+	for _, column := range upsert.UpdateColumns {
+		if d == MySQL {
+			set = append(set, fmt.Sprintf("%s = VALUES(%s)", d.Quote(column), d.Quote(column)))
+		} else {
+			set = append(set, fmt.Sprintf("%s = EXCLUDED.%s", d.Quote(column), d.Quote(column)))
+		}
+	}
+	if d == MySQL {
+		stmt += " ON DUPLICATE KEY UPDATE " + strings.Join(set, ", ")
+	} else {
+		if len(upsert.ConflictColumns) == 0 {
+			return "", nil, errors.New("no conflict columns")
+		}
+		stmt += fmt.Sprintf(" ON CONFLICT(%s) DO UPDATE SET %s", d.quoteAll(upsert.ConflictColumns), strings.Join(set, ", "))
+	}
+	return stmt, args, nil
+}
+
+// conditions are the conditions a row must all match, in which "?" is the placeholder of each
+// argument in turn.
+type conditions struct {
+	where []string
+	args  []any
+}
+
+func (c *conditions) add(condition string, args ...any) {
+	c.where = append(c.where, condition)
+	c.args = append(c.args, args...)
+}
+
+func (c *conditions) clause() string {
+	if len(c.where) == 0 {
+		return ""
+	}
+	return " WHERE " + strings.Join(c.where, " AND ")
+}
+
+// SelectBuilder builds a select of the columns of the rows of a table matching all of its
+// conditions.
+type SelectBuilder struct {
+	dialect    Dialect
+	table      string
+	columns    []string
+	conditions conditions
+	orderBy    []string
+	limit      *int
+	offset     *int
+	forUpdate  bool
+}
+
+// Select starts a select of the columns of the table.
+func (d Dialect) Select(table string, columns ...string) *SelectBuilder {
+	return &SelectBuilder{dialect: d, table: table, columns: columns}
+}
+
+// Where adds a condition, in which "?" is the placeholder of each argument in turn. The
+// condition must not contain "?" otherwise, e.g. in a string literal.
+func (b *SelectBuilder) Where(condition string, args ...any) *SelectBuilder {
+	b.conditions.add(condition, args...)
+	return b
+}
+
+// WhereEqual adds a condition that the column equals the value.
+func (b *SelectBuilder) WhereEqual(column string, value any) *SelectBuilder {
+	return b.Where(b.dialect.Quote(column)+" = ?", value)
+}
+
+// OrderBy orders the rows by the columns, ascending.
+func (b *SelectBuilder) OrderBy(columns ...string) *SelectBuilder {
+	b.orderBy = append(b.orderBy, columns...)
+	return b
+}
+
+// Limit limits the rows to the given number, after skipping the offset if it is not nil.
+func (b *SelectBuilder) Limit(limit *int, offset *int) *SelectBuilder {
+	b.limit, b.offset = limit, offset
+	return b
+}
+
+// ForUpdate locks the selected rows until the end of the transaction. SQLite has no row locks,
+// its writes lock the whole database, and selects without it.
+func (b *SelectBuilder) ForUpdate() *SelectBuilder {
+	b.forUpdate = true
+	return b
+}
+
+// Build returns the query and its arguments.
+func (b *SelectBuilder) Build() (string, []any) {
+	d := b.dialect
+	query := fmt.Sprintf("SELECT %s FROM %s", d.quoteAll(b.columns), d.Quote(b.table)) + b.conditions.clause()
+	if len(b.orderBy) > 0 {
+		query += " ORDER BY " + d.quoteAll(b.orderBy)
+	}
+	// An offset without a limit is ignored, as the drivers did.
+	if b.limit != nil {
+		query += fmt.Sprintf(" LIMIT %d", *b.limit)
+		if b.offset != nil {
+			query += fmt.Sprintf(" OFFSET %d", *b.offset)
+		}
+	}
+	if b.forUpdate && d != SQLite {
+		query += " FOR UPDATE"
+	}
+	return d.bindPlaceholders(query), b.conditions.args
+}
+
+// UpdateBuilder builds an update of the rows of a table matching all of its conditions.
+type UpdateBuilder struct {
+	dialect    Dialect
+	table      string
+	set        []string
+	setArgs    []any
+	conditions conditions
+}
+
+// Update starts an update of the rows of the table.
+func (d Dialect) Update(table string) *UpdateBuilder {
+	return &UpdateBuilder{dialect: d, table: table}
+}
+
+// Set sets the column to the value.
+func (b *UpdateBuilder) Set(column string, value any) *UpdateBuilder {
+	b.set = append(b.set, b.dialect.Quote(column)+" = ?")
+	b.setArgs = append(b.setArgs, value)
+	return b
+}
+
+// Where adds a condition, as SelectBuilder.Where does.
+func (b *UpdateBuilder) Where(condition string, args ...any) *UpdateBuilder {
+	b.conditions.add(condition, args...)
+	return b
+}
+
+// WhereEqual adds a condition that the column equals the value.
+func (b *UpdateBuilder) WhereEqual(column string, value any) *UpdateBuilder {
+	return b.Where(b.dialect.Quote(column)+" = ?", value)
+}
+
+// Build returns the statement and its arguments.
+func (b *UpdateBuilder) Build() (string, []any, error) {
+	if len(b.set) == 0 {
+		return "", nil, errors.New("no columns to update")
+	}
+	d := b.dialect
+	stmt := fmt.Sprintf("UPDATE %s SET %s", d.Quote(b.table), strings.Join(b.set, ", ")) + b.conditions.clause()
+	return d.bindPlaceholders(stmt), append(append([]any{}, b.setArgs...), b.conditions.args...), nil
+}
+
+// DeleteBuilder builds a delete of the rows of a table matching all of its conditions.
+type DeleteBuilder struct {
+	dialect    Dialect
+	table      string
+	conditions conditions
+}
+
+// Delete starts a delete of the rows of the table.
+func (d Dialect) Delete(table string) *DeleteBuilder {
+	return &DeleteBuilder{dialect: d, table: table}
+}
+
+// Where adds a condition, as SelectBuilder.Where does.
+func (b *DeleteBuilder) Where(condition string, args ...any) *DeleteBuilder {
+	b.conditions.add(condition, args...)
+	return b
+}
+
+// WhereEqual adds a condition that the column equals the value.
+func (b *DeleteBuilder) WhereEqual(column string, value any) *DeleteBuilder {
+	return b.Where(b.dialect.Quote(column)+" = ?", value)
+}
+
+// Build returns the statement and its arguments.
+func (b *DeleteBuilder) Build() (string, []any) {
+	d := b.dialect
+	return d.bindPlaceholders(fmt.Sprintf("DELETE FROM %s", d.Quote(b.table)) + b.conditions.clause()), b.conditions.args
+}
+
+// ScanRows scans each of the rows with scan, and closes them.
+func ScanRows[T any](rows *sql.Rows, scan func(*sql.Rows) (T, error)) ([]T, error) {
+	defer rows.Close()
+	list := make([]T, 0)
+	for rows.Next() {
+		item, err := scan(rows)
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, item)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return list, nil
+}
+
+// bindPlaceholders replaces the "?" placeholders with the placeholders of the dialect.
+func (d Dialect) bindPlaceholders(query string) string {
+	if d != Postgres {
+		return query
+	}
+	var result strings.Builder
+	n := 0
+	for _, r := range query {
+		if r == '?' {
+			n++
+			result.WriteString(d.Placeholder(n))
+			continue
+		}
+		result.WriteRune(r)
+	}
+	return result.String()
+}
@@ -0,0 +1,169 @@
+package sqlbuilder
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildUpsert(t *testing.T) {
+	upsert := &Upsert{
+		Table:           "user_setting",
+		Columns:         []string{"user_id", "key", "value"},
+		Rows:            [][]any{{1, "GENERAL", "{}"}, {2, "GENERAL", "[]"}},
+		ConflictColumns: []string{"user_id", "key"},
+		UpdateColumns:   []string{"value"},
+	}
+	tests := []struct {
+		dialect Dialect
+		want    string
+	}{
+		{
+			dialect: SQLite,
+			want:    "INSERT INTO `user_setting` (`user_id`, `key`, `value`) VALUES (?, ?, ?), (?, ?, ?) ON CONFLICT(`user_id`, `key`) DO UPDATE SET `value` = EXCLUDED.`value`",
+		},
+		{
+			dialect: MySQL,
+			want:    "INSERT INTO `user_setting` (`user_id`, `key`, `value`) VALUES (?, ?, ?), (?, ?, ?) ON DUPLICATE KEY UPDATE `value` = VALUES(`value`)",
+		},
+		{
+			dialect: Postgres,
+			want:    `INSERT INTO "user_setting" ("user_id", "key", "value") VALUES ($1, $2, $3), ($4, $5, $6) ON CONFLICT("user_id", "key") DO UPDATE SET "value" = EXCLUDED."value"`,
+		},
+	}
+	for _, test := range tests {
+		stmt, args, err := test.dialect.BuildUpsert(upsert)
+		require.NoError(t, err)
+		require.Equal(t, test.want, stmt, test.dialect)
+		require.Equal(t, []any{1, "GENERAL", "{}", 2, "GENERAL", "[]"}, args)
+	}
+}
+
+func TestBuildUpsertDoNothing(t *testing.T) {
+	upsert := &Upsert{
+		Table:           "user_setting",
+		Columns:         []string{"user_id", "key", "value"},
+		Rows:            [][]any{{1, "GENERAL", ""}},
+		ConflictColumns: []string{"user_id", "key"},
+		DoNothing:       true,
+	}
+	tests := []struct {
+		dialect Dialect
+		want    string
+	}{
+		{
+			dialect: SQLite,
+			want:    "INSERT INTO `user_setting` (`user_id`, `key`, `value`) VALUES (?, ?, ?) ON CONFLICT(`user_id`, `key`) DO NOTHING",
+		},
+		{
+			dialect: MySQL,
+			want:    "INSERT INTO `user_setting` (`user_id`, `key`, `value`) VALUES (?, ?, ?) ON DUPLICATE KEY UPDATE `user_id` = `user_id`",
+		},
+		{
+			dialect: Postgres,
+			want:    `INSERT INTO "user_setting" ("user_id", "key", "value") VALUES ($1, $2, $3) ON CONFLICT("user_id", "key") DO NOTHING`,
+		},
+	}
+	for _, test := range tests {
+		stmt, args, err := test.dialect.BuildUpsert(upsert)
+		require.NoError(t, err)
+		require.Equal(t, test.want, stmt, test.dialect)
+		require.Equal(t, []any{1, "GENERAL", ""}, args)
+	}
+}
+
+func TestBuildUpsertInvalid(t *testing.T) {
+	_, _, err := SQLite.BuildUpsert(&Upsert{Table: "user_setting", Columns: []string{"user_id"}, UpdateColumns: []string{"user_id"}})
+	require.ErrorContains(t, err, "no rows to upsert")
+	_, _, err = SQLite.BuildUpsert(&Upsert{Table: "user_setting", Columns: []string{"user_id", "key"}, Rows: [][]any{{1}}, UpdateColumns: []string{"key"}})
+	require.ErrorContains(t, err, "row has 1 values for 2 columns")
+	_, _, err = Postgres.BuildUpsert(&Upsert{Table: "user_setting", Columns: []string{"user_id"}, Rows: [][]any{{1}}, UpdateColumns: []string{"user_id"}})
+	require.ErrorContains(t, err, "no conflict columns")
+	// MySQL does not name the unique key.
+	_, _, err = MySQL.BuildUpsert(&Upsert{Table: "user_setting", Columns: []string{"user_id"}, Rows: [][]any{{1}}, UpdateColumns: []string{"user_id"}})
+	require.NoError(t, err)
+}
+
+func TestBuildSelect(t *testing.T) {
+	limit, offset := 10, 20
+	tests := []struct {
+		dialect Dialect
+		want    string
+	}{
+		{
+			dialect: SQLite,
+			want:    "SELECT `user_id`, `key`, `value` FROM `user_setting` WHERE `key` = ? AND `user_id` IN (?, ?) ORDER BY `user_id`, `key` LIMIT 10 OFFSET 20",
+		},
+		{
+			dialect: MySQL,
+			want:    "SELECT `user_id`, `key`, `value` FROM `user_setting` WHERE `key` = ? AND `user_id` IN (?, ?) ORDER BY `user_id`, `key` LIMIT 10 OFFSET 20",
+		},
+		{
+			dialect: Postgres,
+			want:    `SELECT "user_id", "key", "value" FROM "user_setting" WHERE "key" = $1 AND "user_id" IN ($2, $3) ORDER BY "user_id", "key" LIMIT 10 OFFSET 20`,
+		},
+	}
+	for _, test := range tests {
+		query, args := test.dialect.Select("user_setting", "user_id", "key", "value").
+			WhereEqual("key", "GENERAL").
+			Where(test.dialect.Quote("user_id")+" IN (?, ?)", 1, 2).
+			OrderBy("user_id", "key").
+			Limit(&limit, &offset).
+			Build()
+		require.Equal(t, test.want, query, test.dialect)
+		require.Equal(t, []any{"GENERAL", 1, 2}, args)
+	}
+
+	// The offset is ignored without a limit.
+	query, args := Postgres.Select("user_setting", "value").Limit(nil, &offset).Build()
+	require.Equal(t, `SELECT "value" FROM "user_setting"`, query)
+	require.Empty(t, args)
+}
+
+func TestBuildSelectForUpdate(t *testing.T) {
+	query, _ := SQLite.Select("user_setting", "value").WhereEqual("user_id", 1).ForUpdate().Build()
+	require.Equal(t, "SELECT `value` FROM `user_setting` WHERE `user_id` = ?", query)
+	query, _ = MySQL.Select("user_setting", "value").WhereEqual("user_id", 1).ForUpdate().Build()
+	require.Equal(t, "SELECT `value` FROM `user_setting` WHERE `user_id` = ? FOR UPDATE", query)
+	query, _ = Postgres.Select("user_setting", "value").WhereEqual("user_id", 1).ForUpdate().Build()
+	require.Equal(t, `SELECT "value" FROM "user_setting" WHERE "user_id" = $1 FOR UPDATE`, query)
+}
+
+func TestBuildUpdate(t *testing.T) {
+	tests := []struct {
+		dialect Dialect
+		want    string
+	}{
+		{
+			dialect: SQLite,
+			want:    "UPDATE `user_setting` SET `value` = ? WHERE `user_id` = ? AND `key` = ?",
+		},
+		{
+			dialect: MySQL,
+			want:    "UPDATE `user_setting` SET `value` = ? WHERE `user_id` = ? AND `key` = ?",
+		},
+		{
+			dialect: Postgres,
+			want:    `UPDATE "user_setting" SET "value" = $1 WHERE "user_id" = $2 AND "key" = $3`,
+		},
+	}
+	for _, test := range tests {
+		// The conditions may be added before the columns are set.
+		stmt, args, err := test.dialect.Update("user_setting").WhereEqual("user_id", 1).Set("value", "{}").WhereEqual("key", "GENERAL").Build()
+		require.NoError(t, err)
+		require.Equal(t, test.want, stmt, test.dialect)
+		require.Equal(t, []any{"{}", 1, "GENERAL"}, args)
+	}
+
+	_, _, err := SQLite.Update("user_setting").WhereEqual("user_id", 1).Build()
+	require.ErrorContains(t, err, "no columns to update")
+}
+
+func TestBuildDelete(t *testing.T) {
+	stmt, args := SQLite.Delete("user_setting").WhereEqual("user_id", 1).WhereEqual("key", "GENERAL").Build()
+	require.Equal(t, "DELETE FROM `user_setting` WHERE `user_id` = ? AND `key` = ?", stmt)
+	require.Equal(t, []any{1, "GENERAL"}, args)
+	stmt, args = Postgres.Delete("user_setting").WhereEqual("user_id", 1).WhereEqual("key", "GENERAL").Build()
+	require.Equal(t, `DELETE FROM "user_setting" WHERE "user_id" = $1 AND "key" = $2`, stmt)
+	require.Equal(t, []any{1, "GENERAL"}, args)
+}
@@ -2,11 +2,15 @@ package sqlite
 
 import (
 	"context"
+	"database/sql"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/pkg/errors"
 	"google.golang.org/protobuf/encoding/protojson"
+	"modernc.org/sqlite"
+	sqlite3 "modernc.org/sqlite/lib"
 
 	"github.com/usememos/memos/plugin/filter"
 	storepb "github.com/usememos/memos/proto/gen/store"
@@ -14,61 +18,284 @@ import (
 )
 
 func (d *DB) CreateMemo(ctx context.Context, create *store.Memo) (*store.Memo, error) {
-	fields := []string{"`uid`", "`creator_id`", "`content`", "`visibility`", "`payload`"}
-	placeholder := []string{"?", "?", "?", "?", "?"}
+	stmt, args, err := buildMemoInsert(create)
+	if err != nil {
+		return nil, err
+	}
+	if err := d.db.QueryRowContext(ctx, stmt, args...).Scan(
+		&create.ID,
+		&create.CreatedTs,
+		&create.UpdatedTs,
+		&create.RowStatus,
+	); err != nil {
+		return nil, convertMemoInsertError(err)
+	}
+
+	return create, nil
+}
+
+// convertMemoInsertError returns store.ErrMemoUIDConflict for the unique violation of the uid,
+// the only unique key of memos.
+func convertMemoInsertError(err error) error {
+	var sqliteErr *sqlite.Error
+	if errors.As(err, &sqliteErr) && sqliteErr.Code() == sqlite3.SQLITE_CONSTRAINT_UNIQUE {
+		return store.ErrMemoUIDConflict
+	}
+	return err
+}
+
+// buildMemoInsert returns the statement inserting the memo, which returns the id, created_ts,
+// updated_ts and row_status of the memo.
+func buildMemoInsert(create *store.Memo) (string, []any, error) {
+	fields := []string{"`uid`", "`creator_id`", "`content`", "`visibility`", "`payload`", "`content_hash`", "`content_compressed`", "`latitude`", "`longitude`"}
+	placeholder := []string{"?", "?", "?", "?", "?", "?", "?", "?", "?"}
 	payload := "{}"
 	if create.Payload != nil {
 		payloadBytes, err := protojson.Marshal(create.Payload)
 		if err != nil {
-			return nil, err
+			return "", nil, err
 		}
 		payload = string(payloadBytes)
 	}
-	args := []any{create.UID, create.CreatorID, create.Content, create.Visibility, payload}
+	content := create.Content
+	if create.ContentCompressed {
+		compressed, err := store.CompressMemoContent(content)
+		if err != nil {
+			return "", nil, err
+		}
+		content = compressed
+	}
+	latitude, longitude := store.GetMemoCoordinates(create.Payload)
+	args := []any{create.UID, create.CreatorID, content, create.Visibility, payload, store.HashMemoContent(create.Content), create.ContentCompressed, latitude, longitude}
 
 	stmt := "INSERT INTO `memo` (" + strings.Join(fields, ", ") + ") VALUES (" + strings.Join(placeholder, ", ") + ") RETURNING `id`, `created_ts`, `updated_ts`, `row_status`"
-	if err := d.db.QueryRowContext(ctx, stmt, args...).Scan(
-		&create.ID,
-		&create.CreatedTs,
-		&create.UpdatedTs,
-		&create.RowStatus,
-	); err != nil {
+	return stmt, args, nil
+}
+
+func (d *DB) ListMemos(ctx context.Context, find *store.FindMemo) ([]*store.Memo, error) {
+	list := make([]*store.Memo, 0)
+	if err := d.StreamMemos(ctx, find, func(memo *store.Memo) error {
+		list = append(list, memo)
+		return nil
+	}); err != nil {
 		return nil, err
 	}
+	return list, nil
+}
 
-	return create, nil
+func (d *DB) StreamMemos(ctx context.Context, find *store.FindMemo, fn func(*store.Memo) error) error {
+	query, args, err := d.buildMemoListQuery(find)
+	if err != nil {
+		return err
+	}
+
+	rows, err := d.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var memo store.Memo
+		var payloadBytes []byte
+		dests := []any{
+			&memo.ID,
+			&memo.UID,
+			&memo.CreatorID,
+			&memo.CreatedTs,
+			&memo.UpdatedTs,
+			&memo.RowStatus,
+			&memo.Visibility,
+			&memo.Pinned,
+			&payloadBytes,
+			&memo.ContentCompressed,
+			&memo.Version,
+			&memo.ParentID,
+		}
+		if !find.ExcludeContent {
+			dests = append(dests, &memo.Content)
+		}
+		if find.IncludeCommentCount {
+			dests = append(dests, &memo.CommentCount)
+		}
+		if err := rows.Scan(dests...); err != nil {
+			return err
+		}
+		payload := &storepb.MemoPayload{}
+		if err := protojsonUnmarshaler.Unmarshal(payloadBytes, payload); err != nil {
+			return errors.Wrap(err, "failed to unmarshal payload")
+		}
+		memo.Payload = payload
+		if !find.ExcludeContent && memo.ContentCompressed {
+			content, err := store.DecompressMemoContent(memo.Content)
+			if err != nil {
+				return errors.Wrapf(err, "failed to decompress content of memo %d", memo.ID)
+			}
+			memo.Content = content
+		}
+		if err := fn(&memo); err != nil {
+			return err
+		}
+	}
+
+	return rows.Err()
 }
 
-func (d *DB) ListMemos(ctx context.Context, find *store.FindMemo) ([]*store.Memo, error) {
+// buildMemoListQuery returns the query listing the memos found by find and its args.
+func (d *DB) buildMemoListQuery(find *store.FindMemo) (string, []any, error) {
+	where, args, err := d.buildMemoFindWhere(find)
+	if err != nil {
+		return "", nil, err
+	}
+
+	order := "DESC"
+	if find.OrderByTimeAsc {
+		order = "ASC"
+	}
+	orderBy := []string{}
+	if find.OrderByPinned {
+		orderBy = append(orderBy, "`pinned` DESC")
+	}
+	if find.OrderByUpdatedTs {
+		orderBy = append(orderBy, "`updated_ts` "+order)
+	} else {
+		orderBy = append(orderBy, "`created_ts` "+order)
+	}
+	// Break ties by id so that the order is stable across pages.
+	orderBy = append(orderBy, "`memo`.`id` "+order)
+	fields := []string{
+		"`memo`.`id` AS `id`",
+		"`memo`.`uid` AS `uid`",
+		"`memo`.`creator_id` AS `creator_id`",
+		"`memo`.`created_ts` AS `created_ts`",
+		"`memo`.`updated_ts` AS `updated_ts`",
+		"`memo`.`row_status` AS `row_status`",
+		"`memo`.`visibility` AS `visibility`",
+		"`memo`.`pinned` AS `pinned`",
+		"`memo`.`payload` AS `payload`",
+		"`memo`.`content_compressed` AS `content_compressed`",
+		"`memo`.`version` AS `version`",
+		"`memo_relation`.`related_memo_id` AS `parent_id`",
+	}
+	if !find.ExcludeContent {
+		fields = append(fields, "`memo`.`content` AS `content`")
+	}
+	if find.IncludeCommentCount {
+		fields = append(fields, "(SELECT COUNT(*) FROM `memo_relation` AS `comment` WHERE `comment`.`related_memo_id` = `memo`.`id` AND `comment`.`type` = 'COMMENT') AS `comment_count`")
+	}
+
+	query := "SELECT " + strings.Join(fields, ", ") + "FROM `memo` " +
+		"LEFT JOIN `memo_relation` ON `memo`.`id` = `memo_relation`.`memo_id` AND `memo_relation`.`type` = \"COMMENT\" " +
+		"WHERE " + strings.Join(where, " AND ") + " " +
+		"ORDER BY " + strings.Join(orderBy, ", ")
+	if find.Limit != nil {
+		query = fmt.Sprintf("%s LIMIT %d", query, *find.Limit)
+		if find.Offset != nil {
+			query = fmt.Sprintf("%s OFFSET %d", query, *find.Offset)
+		}
+	}
+	return query, args, nil
+}
+
+func (d *DB) CountMemos(ctx context.Context, find *store.FindMemo) (int, error) {
+	where, args, err := d.buildMemoFindWhere(find)
+	if err != nil {
+		return 0, err
+	}
+
+	query := "SELECT COUNT(*) FROM `memo` LEFT JOIN `memo_relation` ON `memo`.`id` = `memo_relation`.`memo_id` AND `memo_relation`.`type` = \"COMMENT\" WHERE " + strings.Join(where, " AND ")
+	count := 0
+	if err := d.db.QueryRowContext(ctx, query, args...).Scan(&count); err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+func (d *DB) ListMemoTags(ctx context.Context, find *store.FindMemo) ([]*store.TagCount, error) {
+	where, args, err := d.buildMemoFindWhere(find)
+	if err != nil {
+		return nil, err
+	}
+
+	query := "SELECT `tag`.`value`, COUNT(DISTINCT `memo`.`id`) FROM `memo` LEFT JOIN `memo_relation` ON `memo`.`id` = `memo_relation`.`memo_id` AND `memo_relation`.`type` = \"COMMENT\" JOIN JSON_EACH(`memo`.`payload`, '$.tags') AS `tag` WHERE " + strings.Join(where, " AND ") + " GROUP BY `tag`.`value`"
+	rows, err := d.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	list := []*store.TagCount{}
+	for rows.Next() {
+		tagCount := &store.TagCount{}
+		if err := rows.Scan(&tagCount.Tag, &tagCount.Count); err != nil {
+			return nil, err
+		}
+		list = append(list, tagCount)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return list, nil
+}
+
+// buildMemoFindWhere builds the conditions of the memos matching find, which ListMemos
+// and CountMemos share. The memo table is joined with its parent relation as memo_relation.
+func (d *DB) buildMemoFindWhere(find *store.FindMemo) ([]string, []any, error) {
 	where, args := []string{"1 = 1"}, []any{}
 
 	if v := find.ID; v != nil {
 		where, args = append(where, "`memo`.`id` = ?"), append(args, *v)
 	}
+	if v := find.IDList; len(v) != 0 {
+		placeholders := []string{}
+		for _, id := range v {
+			placeholders, args = append(placeholders, "?"), append(args, id)
+		}
+		where = append(where, fmt.Sprintf("`memo`.`id` IN (%s)", strings.Join(placeholders, ", ")))
+	}
 	if v := find.UID; v != nil {
 		where, args = append(where, "`memo`.`uid` = ?"), append(args, *v)
 	}
 	if v := find.CreatorID; v != nil {
 		where, args = append(where, "`memo`.`creator_id` = ?"), append(args, *v)
 	}
+	if v := find.CreatorUsername; v != nil {
+		condition := "`user`.`username` = ?"
+		if find.CreatorUsernameInsensitive {
+			condition = "LOWER(`user`.`username`) = LOWER(?)"
+		}
+		where, args = append(where, "`memo`.`creator_id` IN (SELECT `user`.`id` FROM `user` WHERE "+condition+")"), append(args, *v)
+	}
 	if v := find.RowStatus; v != nil {
 		where, args = append(where, "`memo`.`row_status` = ?"), append(args, *v)
 	}
+	if v := find.LocationBounds; v != nil {
+		where, args = append(where, "`memo`.`latitude` BETWEEN ? AND ?"), append(args, v.MinLatitude, v.MaxLatitude)
+		if v.CrossesAntimeridian() {
+			where, args = append(where, "(`memo`.`longitude` >= ? OR `memo`.`longitude` <= ?)"), append(args, v.MinLongitude, v.MaxLongitude)
+		} else {
+			where, args = append(where, "`memo`.`longitude` BETWEEN ? AND ?"), append(args, v.MinLongitude, v.MaxLongitude)
+		}
+	}
 	if v := find.CreatedTsBefore; v != nil {
 		where, args = append(where, "`memo`.`created_ts` < ?"), append(args, *v)
 	}
 	if v := find.CreatedTsAfter; v != nil {
-		where, args = append(where, "`memo`.`created_ts` > ?"), append(args, *v)
+		where, args = append(where, "`memo`.`created_ts` >= ?"), append(args, *v)
 	}
 	if v := find.UpdatedTsBefore; v != nil {
 		where, args = append(where, "`memo`.`updated_ts` < ?"), append(args, *v)
 	}
 	if v := find.UpdatedTsAfter; v != nil {
-		where, args = append(where, "`memo`.`updated_ts` > ?"), append(args, *v)
+		where, args = append(where, "`memo`.`updated_ts` >= ?"), append(args, *v)
 	}
 	if v := find.ContentSearch; len(v) != 0 {
 		for _, s := range v {
-			where, args = append(where, "`memo`.`content` LIKE ?"), append(args, fmt.Sprintf("%%%s%%", s))
+			condition := "`memo`.`content` LIKE ? ESCAPE '!'"
+			if matches := find.CompressedContentMatches; matches != nil {
+				condition = store.CompressedContentCondition(condition, "`memo`.`content_compressed`", "`memo`.`id`", matches.Contains[s], false)
+			}
+			where, args = append(where, condition), append(args, store.ContainsLikePattern(s))
 		}
 	}
 	if v := find.VisibilityList; len(v) != 0 {
@@ -77,18 +304,54 @@ func (d *DB) ListMemos(ctx context.Context, find *store.FindMemo) ([]*store.Memo
 			placeholder = append(placeholder, "?")
 			args = append(args, visibility.String())
 		}
-		where = append(where, fmt.Sprintf("`memo`.`visibility` IN (%s)", strings.Join(placeholder, ",")))
+		condition := fmt.Sprintf("`memo`.`visibility` IN (%s)", strings.Join(placeholder, ","))
+		if v := find.VisibleToUserID; v != nil {
+			condition = fmt.Sprintf("(%s OR `memo`.`creator_id` = ? OR EXISTS (SELECT 1 FROM `memo_acl` WHERE `memo_acl`.`memo_id` = `memo`.`id` AND `memo_acl`.`user_id` = ?))", condition)
+			args = append(args, *v, *v)
+		}
+		where = append(where, condition)
 	}
 	if v := find.Pinned; v != nil {
 		where, args = append(where, "`memo`.`pinned` = ?"), append(args, *v)
 	}
+	if v := find.ContentHash; v != nil {
+		where, args = append(where, "`memo`.`content_hash` = ?"), append(args, *v)
+	}
+	if v := find.ContentCompressed; v != nil {
+		where, args = append(where, "`memo`.`content_compressed` = ?"), append(args, *v)
+	}
+	if find.HasResources != nil || find.ResourceType != "" {
+		condition := "SELECT 1 FROM `resource` WHERE `resource`.`memo_id` = `memo`.`id`"
+		if v := find.ResourceType; v != "" {
+			if prefix, ok := strings.CutSuffix(v, "/*"); ok {
+				condition, args = condition+" AND `resource`.`type` LIKE ?", append(args, prefix+"/%")
+			} else {
+				condition, args = condition+" AND `resource`.`type` = ?", append(args, v)
+			}
+		}
+		if find.HasResources != nil && !*find.HasResources {
+			where = append(where, "NOT EXISTS ("+condition+")")
+		} else {
+			where = append(where, "EXISTS ("+condition+")")
+		}
+	}
+	if find.NoTags {
+		where = append(where, "NOT EXISTS (SELECT 1 FROM JSON_EACH(`memo`.`payload`, '$.tags'))")
+	}
 	if v := find.PayloadFind; v != nil {
 		if v.Raw != nil {
 			where, args = append(where, "`memo`.`payload` = ?"), append(args, *v.Raw)
 		}
 		if len(v.TagSearch) != 0 {
+			tagsPath := "$.tags"
+			if v.TagSearchInsensitive {
+				tagsPath = "$.normalizedTags"
+			}
 			for _, tag := range v.TagSearch {
-				where, args = append(where, "(JSON_EXTRACT(`memo`.`payload`, '$.tags') LIKE ? OR JSON_EXTRACT(`memo`.`payload`, '$.tags') LIKE ?)"), append(args, fmt.Sprintf(`%%"%s"%%`, tag), fmt.Sprintf(`%%"%s/%%`, tag))
+				if v.TagSearchInsensitive {
+					tag = store.NormalizeTag(tag)
+				}
+				where, args = append(where, "(JSON_EXTRACT(`memo`.`payload`, ?) LIKE ? OR JSON_EXTRACT(`memo`.`payload`, ?) LIKE ?)"), append(args, tagsPath, fmt.Sprintf(`%%"%s"%%`, tag), tagsPath, fmt.Sprintf(`%%"%s/%%`, tag))
 			}
 		}
 		if v.HasLink {
@@ -109,12 +372,15 @@ func (d *DB) ListMemos(ctx context.Context, find *store.FindMemo) ([]*store.Memo
 		// The filter string should be a CEL expression.
 		parsedExpr, err := filter.Parse(*v, filter.MemoFilterCELAttributes...)
 		if err != nil {
-			return nil, err
+			return nil, nil, err
 		}
 		convertCtx := filter.NewConvertContext()
+		if matches := find.CompressedContentMatches; matches != nil {
+			convertCtx.CompressedContentContains, convertCtx.CompressedContentEquals = matches.Contains, matches.Equals
+		}
 		// ConvertExprToSQL converts the parsed expression to a SQL condition string.
 		if err := d.ConvertExprToSQL(convertCtx, parsedExpr.GetExpr()); err != nil {
-			return nil, err
+			return nil, nil, err
 		}
 		condition := convertCtx.Buffer.String()
 		if condition != "" {
@@ -123,90 +389,33 @@ func (d *DB) ListMemos(ctx context.Context, find *store.FindMemo) ([]*store.Memo
 		}
 	}
 	if find.ExcludeComments {
-		where = append(where, "`parent_id` IS NULL")
-	}
-
-	order := "DESC"
-	if find.OrderByTimeAsc {
-		order = "ASC"
-	}
-	orderBy := []string{}
-	if find.OrderByPinned {
-		orderBy = append(orderBy, "`pinned` DESC")
-	}
-	if find.OrderByUpdatedTs {
-		orderBy = append(orderBy, "`updated_ts` "+order)
-	} else {
-		orderBy = append(orderBy, "`created_ts` "+order)
-	}
-	fields := []string{
-		"`memo`.`id` AS `id`",
-		"`memo`.`uid` AS `uid`",
-		"`memo`.`creator_id` AS `creator_id`",
-		"`memo`.`created_ts` AS `created_ts`",
-		"`memo`.`updated_ts` AS `updated_ts`",
-		"`memo`.`row_status` AS `row_status`",
-		"`memo`.`visibility` AS `visibility`",
-		"`memo`.`pinned` AS `pinned`",
-		"`memo`.`payload` AS `payload`",
-		"`memo_relation`.`related_memo_id` AS `parent_id`",
-	}
-	if !find.ExcludeContent {
-		fields = append(fields, "`memo`.`content` AS `content`")
-	}
-
-	query := "SELECT " + strings.Join(fields, ", ") + "FROM `memo` " +
-		"LEFT JOIN `memo_relation` ON `memo`.`id` = `memo_relation`.`memo_id` AND `memo_relation`.`type` = \"COMMENT\" " +
-		"WHERE " + strings.Join(where, " AND ") + " " +
-		"ORDER BY " + strings.Join(orderBy, ", ")
-	if find.Limit != nil {
-		query = fmt.Sprintf("%s LIMIT %d", query, *find.Limit)
-		if find.Offset != nil {
-			query = fmt.Sprintf("%s OFFSET %d", query, *find.Offset)
-		}
-	}
-
-	rows, err := d.db.QueryContext(ctx, query, args...)
-	if err != nil {
-		return nil, err
+		where = append(where, "`memo_relation`.`related_memo_id` IS NULL")
 	}
-	defer rows.Close()
-
-	list := make([]*store.Memo, 0)
-	for rows.Next() {
-		var memo store.Memo
-		var payloadBytes []byte
-		dests := []any{
-			&memo.ID,
-			&memo.UID,
-			&memo.CreatorID,
-			&memo.CreatedTs,
-			&memo.UpdatedTs,
-			&memo.RowStatus,
-			&memo.Visibility,
-			&memo.Pinned,
-			&payloadBytes,
-			&memo.ParentID,
+	if v := find.Cursor; v != nil {
+		cursor, err := store.DecodeMemoCursor(*v)
+		if err != nil {
+			return nil, nil, err
 		}
-		if !find.ExcludeContent {
-			dests = append(dests, &memo.Content)
+		column, comparator := "`memo`.`created_ts`", "<"
+		if find.OrderByUpdatedTs {
+			column = "`memo`.`updated_ts`"
 		}
-		if err := rows.Scan(dests...); err != nil {
-			return nil, err
+		if find.OrderByTimeAsc {
+			comparator = ">"
 		}
-		payload := &storepb.MemoPayload{}
-		if err := protojsonUnmarshaler.Unmarshal(payloadBytes, payload); err != nil {
-			return nil, errors.Wrap(err, "failed to unmarshal payload")
+		condition, conditionArgs := fmt.Sprintf("(%s, `memo`.`id`) %s (?, ?)", column, comparator), []any{cursor.Ts, cursor.ID}
+		if find.OrderByPinned && cursor.Pinned != nil {
+			// Pinned memos come first, so the memos after the cursor are those further down its group.
+			condition = "(`memo`.`pinned` <> ? AND NOT `memo`.`pinned` OR `memo`.`pinned` = ? AND " + condition + ")"
+			conditionArgs = append([]any{*cursor.Pinned, *cursor.Pinned}, conditionArgs...)
 		}
-		memo.Payload = payload
-		list = append(list, &memo)
+		where, args = append(where, condition), append(args, conditionArgs...)
 	}
-
-	if err := rows.Err(); err != nil {
-		return nil, err
+	if v := find.ParentID; v != nil {
+		where, args = append(where, "`memo_relation`.`related_memo_id` = ?"), append(args, *v)
 	}
 
-	return list, nil
+	return where, args, nil
 }
 
 func (d *DB) UpdateMemo(ctx context.Context, update *store.UpdateMemo) error {
@@ -223,8 +432,21 @@ func (d *DB) UpdateMemo(ctx context.Context, update *store.UpdateMemo) error {
 	if v := update.RowStatus; v != nil {
 		set, args = append(set, "`row_status` = ?"), append(args, *v)
 	}
+	if v := update.DeletedTs; v != nil {
+		set, args = append(set, "`deleted_ts` = ?"), append(args, *v)
+	}
 	if v := update.Content; v != nil {
-		set, args = append(set, "`content` = ?"), append(args, *v)
+		content := *v
+		if update.ContentCompressed {
+			compressed, err := store.CompressMemoContent(content)
+			if err != nil {
+				return err
+			}
+			content = compressed
+		}
+		set, args = append(set, "`content` = ?"), append(args, content)
+		set, args = append(set, "`content_hash` = ?"), append(args, store.HashMemoContent(*v))
+		set, args = append(set, "`content_compressed` = ?"), append(args, update.ContentCompressed)
 	}
 	if v := update.Visibility; v != nil {
 		set, args = append(set, "`visibility` = ?"), append(args, *v)
@@ -238,28 +460,177 @@ func (d *DB) UpdateMemo(ctx context.Context, update *store.UpdateMemo) error {
 			return err
 		}
 		set, args = append(set, "`payload` = ?"), append(args, string(payloadBytes))
+		latitude, longitude := store.GetMemoCoordinates(v)
+		set, args = append(set, "`latitude` = ?", "`longitude` = ?"), append(args, latitude, longitude)
 	}
 	if len(set) == 0 {
 		return nil
 	}
+	set = append(set, "`version` = `version` + 1")
+	where := []string{"`id` = ?"}
 	args = append(args, update.ID)
+	if v := update.ExpectedVersion; v != nil {
+		where, args = append(where, "`version` = ?"), append(args, *v)
+	}
 
-	stmt := "UPDATE `memo` SET " + strings.Join(set, ", ") + " WHERE `id` = ?"
-	if _, err := d.db.ExecContext(ctx, stmt, args...); err != nil {
+	stmt := "UPDATE `memo` SET " + strings.Join(set, ", ") + " WHERE " + strings.Join(where, " AND ")
+	if update.Content != nil && update.RevisionLimit > 0 {
+		return d.updateMemoWithRevision(ctx, update, stmt, args)
+	}
+	result, err := d.db.ExecContext(ctx, stmt, args...)
+	if err != nil {
 		return err
 	}
+	return checkMemoVersion(update, result)
+}
+
+// checkMemoVersion returns store.ErrMemoVersionConflict when the update expects a version of
+// the memo and no memo was updated, as the stored version is another one.
+func checkMemoVersion(update *store.UpdateMemo, result sql.Result) error {
+	if update.ExpectedVersion == nil {
+		return nil
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return store.ErrMemoVersionConflict
+	}
 	return nil
 }
 
-func (d *DB) DeleteMemo(ctx context.Context, delete *store.DeleteMemo) error {
-	where, args := []string{"`id` = ?"}, []any{delete.ID}
-	stmt := "DELETE FROM `memo` WHERE " + strings.Join(where, " AND ")
-	result, err := d.db.ExecContext(ctx, stmt, args...)
+// TransferMemoOwnership moves the memo and its resources to another user in a transaction.
+// The memo is only updated while it is still owned by fromUserID.
+func (d *DB) TransferMemoOwnership(ctx context.Context, memoID, fromUserID, toUserID int32) error {
+	tx, err := d.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	result, err := tx.ExecContext(ctx, "UPDATE `memo` SET `creator_id` = ?, `version` = `version` + 1 WHERE `id` = ? AND `creator_id` = ?", toUserID, memoID, fromUserID)
 	if err != nil {
 		return err
 	}
-	if _, err := result.RowsAffected(); err != nil {
+	affected, err := result.RowsAffected()
+	if err != nil {
 		return err
 	}
-	return nil
+	if affected == 0 {
+		return errors.Errorf("memo %d is not owned by user %d", memoID, fromUserID)
+	}
+	if err := transferResourceBlobs(ctx, tx, memoID, toUserID); err != nil {
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, "UPDATE `resource` SET `creator_id` = ? WHERE `memo_id` = ?", toUserID, memoID); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// BulkUpdateMemoVisibility sets the visibility of the memos of the creator with visibility from
+// to to in a single statement, and returns their ids.
+func (d *DB) BulkUpdateMemoVisibility(ctx context.Context, creatorID int32, from, to store.Visibility) ([]int32, error) {
+	tx, err := d.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	rows, err := tx.QueryContext(ctx, "UPDATE `memo` SET `visibility` = ?, `version` = `version` + 1 WHERE `creator_id` = ? AND `visibility` = ? RETURNING `id`", to.String(), creatorID, from.String())
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	ids := []int32{}
+	for rows.Next() {
+		var id int32
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
+
+// DeleteMemo archives the memo, or deletes it with its revisions and ACLs if Hard is set, in a
+// transaction. With CascadeComments, its comments and theirs are deleted in the same way, along
+// with their relations if Hard is set. It returns the ids of the deleted memos, the memo last.
+func (d *DB) DeleteMemo(ctx context.Context, delete *store.DeleteMemo) ([]int32, error) {
+	tx, err := d.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	ids := []int32{}
+	if delete.CascadeComments {
+		// UNION rather than UNION ALL stops at comments already found, should relations form a cycle.
+		query := "WITH RECURSIVE `comment` (`id`) AS (" +
+			"SELECT `memo_id` FROM `memo_relation` WHERE `related_memo_id` = ? AND `type` = 'COMMENT' UNION " +
+			"SELECT `memo_relation`.`memo_id` FROM `memo_relation` JOIN `comment` ON `memo_relation`.`related_memo_id` = `comment`.`id` WHERE `memo_relation`.`type` = 'COMMENT'" +
+			") SELECT `id` FROM `comment`"
+		rows, err := tx.QueryContext(ctx, query, delete.ID)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to list comments")
+		}
+		for rows.Next() {
+			var id int32
+			if err := rows.Scan(&id); err != nil {
+				rows.Close()
+				return nil, err
+			}
+			if id != delete.ID {
+				ids = append(ids, id)
+			}
+		}
+		rows.Close()
+		if err := rows.Err(); err != nil {
+			return nil, err
+		}
+	}
+	ids = append(ids, delete.ID)
+	memoIDs := make([]any, 0, len(ids))
+	for _, id := range ids {
+		memoIDs = append(memoIDs, id)
+	}
+
+	inIDs, idArgs := buildInCondition("`id`", memoIDs)
+	if !delete.Hard {
+		stmt := "UPDATE `memo` SET `row_status` = ?, `updated_ts` = ?, `deleted_ts` = ?, `version` = `version` + 1 WHERE " + inIDs
+		now := time.Now().Unix()
+		if _, err := tx.ExecContext(ctx, stmt, append([]any{store.Archived, now, now}, idArgs...)...); err != nil {
+			return nil, err
+		}
+		return ids, tx.Commit()
+	}
+
+	inMemoIDs, memoIDArgs := buildInCondition("`memo_id`", memoIDs)
+	inCommentIDs, commentIDArgs := buildInCondition("`memo_id`", memoIDs[:len(memoIDs)-1])
+	deletes := []struct {
+		table string
+		where string
+		args  []any
+	}{
+		{"memo_revision", inMemoIDs, memoIDArgs},
+		{"memo_acl", inMemoIDs, memoIDArgs},
+		{"memo_relation", inCommentIDs, commentIDArgs},
+	}
+	for _, delete := range deletes {
+		if _, err := tx.ExecContext(ctx, "DELETE FROM `"+delete.table+"` WHERE "+delete.where, delete.args...); err != nil {
+			return nil, errors.Wrapf(err, "failed to delete from %s", delete.table)
+		}
+	}
+	if _, err := tx.ExecContext(ctx, "DELETE FROM `memo` WHERE "+inIDs, memoIDArgs...); err != nil {
+		return nil, err
+	}
+	return ids, tx.Commit()
 }
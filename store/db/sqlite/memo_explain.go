@@ -0,0 +1,42 @@
+package sqlite
+
+import (
+	"context"
+	"strings"
+
+	"github.com/usememos/memos/store"
+)
+
+// ExplainListMemos reports the plan of EXPLAIN QUERY PLAN, indented by the nesting of its steps.
+// SQLite cannot analyze a query, so analyze is ignored.
+func (d *DB) ExplainListMemos(ctx context.Context, find *store.FindMemo, _ bool) (*store.MemoQueryPlan, error) {
+	query, args, err := d.buildMemoListQuery(find)
+	if err != nil {
+		return nil, err
+	}
+	rows, err := d.db.QueryContext(ctx, "EXPLAIN QUERY PLAN "+query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	depths := map[int]int{}
+	lines := []string{}
+	for rows.Next() {
+		var id, parent, notUsed int
+		var detail string
+		if err := rows.Scan(&id, &parent, &notUsed, &detail); err != nil {
+			return nil, err
+		}
+		depths[id] = depths[parent] + 1
+		lines = append(lines, strings.Repeat("  ", depths[id]-1)+detail)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return &store.MemoQueryPlan{
+		SQL:  query,
+		Args: args,
+		Plan: strings.Join(lines, "\n"),
+	}, nil
+}
@@ -0,0 +1,66 @@
+package sqlite
+
+import (
+	"context"
+
+	"github.com/usememos/memos/store"
+)
+
+// CreateMemoWithIdempotencyKey claims the idempotency key and creates the memo in one transaction.
+// When the key is already claimed, the id of its memo is returned instead. Otherwise the memo is
+// checked against the quota, if any, before it is created.
+func (d *DB) CreateMemoWithIdempotencyKey(ctx context.Context, create *store.Memo, idempotencyKey string, quota *store.MemoQuota) (int32, bool, error) {
+	tx, err := d.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, false, err
+	}
+	defer tx.Rollback()
+
+	// Claiming the key first takes the write lock, so that concurrent creations with the same key wait for this one.
+	result, err := tx.ExecContext(ctx, "INSERT INTO `memo_idempotency_key` (`creator_id`, `idempotency_key`) VALUES (?, ?) ON CONFLICT DO NOTHING", create.CreatorID, idempotencyKey)
+	if err != nil {
+		return 0, false, err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return 0, false, err
+	}
+	if affected == 0 {
+		var memoID int32
+		if err := tx.QueryRowContext(ctx, "SELECT `memo_id` FROM `memo_idempotency_key` WHERE `creator_id` = ? AND `idempotency_key` = ?", create.CreatorID, idempotencyKey).Scan(&memoID); err != nil {
+			return 0, false, err
+		}
+		return memoID, false, tx.Commit()
+	}
+	if quota != nil {
+		usage, err := getMemoUsage(ctx, tx, create.CreatorID, quota.IncludeArchived)
+		if err != nil {
+			return 0, false, err
+		}
+		if err := quota.Check(usage, create); err != nil {
+			return 0, false, err
+		}
+	}
+
+	stmt, args, err := buildMemoInsert(create)
+	if err != nil {
+		return 0, false, err
+	}
+	if err := tx.QueryRowContext(ctx, stmt, args...).Scan(
+		&create.ID,
+		&create.CreatedTs,
+		&create.UpdatedTs,
+		&create.RowStatus,
+	); err != nil {
+		return 0, false, convertMemoInsertError(err)
+	}
+	if _, err := tx.ExecContext(ctx, "UPDATE `memo_idempotency_key` SET `memo_id` = ? WHERE `creator_id` = ? AND `idempotency_key` = ?", create.ID, create.CreatorID, idempotencyKey); err != nil {
+		return 0, false, err
+	}
+	return create.ID, true, tx.Commit()
+}
+
+func (d *DB) DeleteMemoIdempotencyKeys(ctx context.Context, createdTsBefore int64) error {
+	_, err := d.db.ExecContext(ctx, "DELETE FROM `memo_idempotency_key` WHERE `created_ts` < ?", createdTsBefore)
+	return err
+}
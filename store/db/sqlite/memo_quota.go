@@ -0,0 +1,31 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/usememos/memos/store"
+)
+
+func (d *DB) GetMemoUsage(ctx context.Context, creatorID int32, includeArchived bool) (*store.MemoUsage, error) {
+	return getMemoUsage(ctx, d.db, creatorID, includeArchived)
+}
+
+// rowQuerier is a *sql.DB or a *sql.Tx.
+type rowQuerier interface {
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+}
+
+// getMemoUsage counts the memos of the creator through db, which may be a transaction.
+func getMemoUsage(ctx context.Context, db rowQuerier, creatorID int32, includeArchived bool) (*store.MemoUsage, error) {
+	// LENGTH counts characters of text, so the content is counted as a blob for its bytes.
+	query := "SELECT COUNT(*), COALESCE(SUM(LENGTH(CAST(`content` AS BLOB))), 0) FROM `memo` WHERE `creator_id` = ?"
+	if !includeArchived {
+		query += " AND `row_status` = 'NORMAL'"
+	}
+	usage := &store.MemoUsage{}
+	if err := db.QueryRowContext(ctx, query, creatorID).Scan(&usage.Count, &usage.Size); err != nil {
+		return nil, err
+	}
+	return usage, nil
+}
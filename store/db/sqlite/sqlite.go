@@ -10,11 +10,14 @@ import (
 
 	"github.com/usememos/memos/server/profile"
 	"github.com/usememos/memos/store"
+	"github.com/usememos/memos/store/db/sqlbuilder"
 )
 
 type DB struct {
 	db      *sql.DB
 	profile *profile.Profile
+	// dialect builds the statements shared with the other drivers.
+	dialect sqlbuilder.Dialect
 }
 
 // NewDB opens a database specified by its database driver name and a
@@ -45,7 +48,7 @@ func NewDB(profile *profile.Profile) (store.Driver, error) {
 		return nil, errors.Wrapf(err, "failed to open db with dsn: %s", profile.DSN)
 	}
 
-	driver := DB{db: sqliteDB, profile: profile}
+	driver := DB{db: sqliteDB, profile: profile, dialect: sqlbuilder.SQLite}
 
 	return &driver, nil
 }
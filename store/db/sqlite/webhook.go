@@ -8,9 +8,9 @@ import (
 )
 
 func (d *DB) CreateWebhook(ctx context.Context, create *store.Webhook) (*store.Webhook, error) {
-	fields := []string{"`name`", "`url`", "`creator_id`"}
-	placeholder := []string{"?", "?", "?"}
-	args := []any{create.Name, create.URL, create.CreatorID}
+	fields := []string{"`name`", "`url`", "`creator_id`", "`secret`"}
+	placeholder := []string{"?", "?", "?", "?"}
+	args := []any{create.Name, create.URL, create.CreatorID, create.Secret}
 	stmt := "INSERT INTO `webhook` (" + strings.Join(fields, ", ") + ") VALUES (" + strings.Join(placeholder, ", ") + ") RETURNING `id`, `created_ts`, `updated_ts`"
 	if err := d.db.QueryRowContext(ctx, stmt, args...).Scan(
 		&create.ID,
@@ -39,7 +39,8 @@ func (d *DB) ListWebhooks(ctx context.Context, find *store.FindWebhook) ([]*stor
 			updated_ts,
 			creator_id,
 			name,
-			url
+			url,
+			secret
 		FROM webhook
 		WHERE `+strings.Join(where, " AND ")+`
 		ORDER BY id DESC`,
@@ -60,6 +61,7 @@ func (d *DB) ListWebhooks(ctx context.Context, find *store.FindWebhook) ([]*stor
 			&webhook.CreatorID,
 			&webhook.Name,
 			&webhook.URL,
+			&webhook.Secret,
 		); err != nil {
 			return nil, err
 		}
@@ -81,9 +83,12 @@ func (d *DB) UpdateWebhook(ctx context.Context, update *store.UpdateWebhook) (*s
 	if update.URL != nil {
 		set, args = append(set, "url = ?"), append(args, *update.URL)
 	}
+	if update.Secret != nil {
+		set, args = append(set, "secret = ?"), append(args, *update.Secret)
+	}
 	args = append(args, update.ID)
 
-	stmt := "UPDATE `webhook` SET " + strings.Join(set, ", ") + " WHERE `id` = ? RETURNING `id`, `created_ts`, `updated_ts`, `creator_id`, `name`, `url`"
+	stmt := "UPDATE `webhook` SET " + strings.Join(set, ", ") + " WHERE `id` = ? RETURNING `id`, `created_ts`, `updated_ts`, `creator_id`, `name`, `url`, `secret`"
 	webhook := &store.Webhook{}
 	if err := d.db.QueryRowContext(ctx, stmt, args...).Scan(
 		&webhook.ID,
@@ -92,6 +97,7 @@ func (d *DB) UpdateWebhook(ctx context.Context, update *store.UpdateWebhook) (*s
 		&webhook.CreatorID,
 		&webhook.Name,
 		&webhook.URL,
+		&webhook.Secret,
 	); err != nil {
 		return nil, err
 	}
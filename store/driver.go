@@ -7,6 +7,7 @@ import (
 	exprv1 "google.golang.org/genproto/googleapis/api/expr/v1alpha1"
 
 	"github.com/usememos/memos/plugin/filter"
+	storepb "github.com/usememos/memos/proto/gen/store"
 )
 
 // Driver is an interface for store driver.
@@ -28,12 +29,40 @@ type Driver interface {
 	ListResources(ctx context.Context, find *FindResource) ([]*Resource, error)
 	UpdateResource(ctx context.Context, update *UpdateResource) error
 	DeleteResource(ctx context.Context, delete *DeleteResource) error
+	CreateDuplicateResource(ctx context.Context, create *Resource) (*Resource, error)
+	DeleteSharedResource(ctx context.Context, resource *Resource) (bool, error)
+	ReleaseResourceBlob(ctx context.Context, resource *Resource, count int) (bool, error)
 
 	// Memo model related methods.
 	CreateMemo(ctx context.Context, create *Memo) (*Memo, error)
 	ListMemos(ctx context.Context, find *FindMemo) ([]*Memo, error)
+	StreamMemos(ctx context.Context, find *FindMemo, fn func(*Memo) error) error
+	CountMemos(ctx context.Context, find *FindMemo) (int, error)
+	ListMemoTags(ctx context.Context, find *FindMemo) ([]*TagCount, error)
+	ListMemoContents(ctx context.Context, afterID int32, limit int) ([]*MemoContent, error)
+	UpdateMemoTags(ctx context.Context, list []*MemoTags) error
+	ListMemoTagOverlaps(ctx context.Context, find *FindMemo, normalizedTags []string, limit int) ([]*MemoTagOverlap, error)
 	UpdateMemo(ctx context.Context, update *UpdateMemo) error
-	DeleteMemo(ctx context.Context, delete *DeleteMemo) error
+	DeleteMemo(ctx context.Context, delete *DeleteMemo) ([]int32, error)
+	DeleteExpiredMemos(ctx context.Context, deletedTsBefore int64) (*ExpiredMemos, error)
+	TransferMemoOwnership(ctx context.Context, memoID, fromUserID, toUserID int32) error
+	BulkUpdateMemoVisibility(ctx context.Context, creatorID int32, from, to Visibility) ([]int32, error)
+	ListDuplicateMemoContentHashes(ctx context.Context, creatorID int32) ([]*MemoContentHash, error)
+	MergeMemos(ctx context.Context, merge *MergeMemos) error
+	GetMemoUsage(ctx context.Context, creatorID int32, includeArchived bool) (*MemoUsage, error)
+	CreateMemoWithIdempotencyKey(ctx context.Context, create *Memo, idempotencyKey string, quota *MemoQuota) (int32, bool, error)
+	DeleteMemoIdempotencyKeys(ctx context.Context, createdTsBefore int64) error
+	ExplainListMemos(ctx context.Context, find *FindMemo, analyze bool) (*MemoQueryPlan, error)
+	ListMemoDayCounts(ctx context.Context, creatorID int32, fromTs, toTs int64, tzOffset int) ([]*MemoDayCount, error)
+
+	// MemoACL model related methods.
+	UpsertMemoACL(ctx context.Context, upsert *MemoACL) (*MemoACL, error)
+	ListMemoACLs(ctx context.Context, find *FindMemoACL) ([]*MemoACL, error)
+	DeleteMemoACL(ctx context.Context, delete *DeleteMemoACL) error
+
+	// MemoRevision model related methods.
+	ListMemoRevisions(ctx context.Context, find *FindMemoRevision) ([]*MemoRevision, error)
+	DeleteMemoRevision(ctx context.Context, delete *DeleteMemoRevision) error
 
 	// MemoRelation model related methods.
 	UpsertMemoRelation(ctx context.Context, create *MemoRelation) (*MemoRelation, error)
@@ -50,10 +79,14 @@ type Driver interface {
 	UpdateUser(ctx context.Context, update *UpdateUser) (*User, error)
 	ListUsers(ctx context.Context, find *FindUser) ([]*User, error)
 	DeleteUser(ctx context.Context, delete *DeleteUser) error
+	EraseUser(ctx context.Context, userID int32) (*ErasedUser, error)
 
 	// UserSetting model related methods.
 	UpsertUserSetting(ctx context.Context, upsert *UserSetting) (*UserSetting, error)
+	BatchUpsertUserSettings(ctx context.Context, upserts []*UserSetting) ([]*UserSetting, error)
+	UpdateUserSetting(ctx context.Context, userID int32, key storepb.UserSettingKey, update func(*UserSetting) (*UserSetting, error)) (*UserSetting, error)
 	ListUserSettings(ctx context.Context, find *FindUserSetting) ([]*UserSetting, error)
+	DeleteUserSetting(ctx context.Context, delete *DeleteUserSetting) error
 
 	// IdentityProvider model related methods.
 	CreateIdentityProvider(ctx context.Context, create *IdentityProvider) (*IdentityProvider, error)
@@ -76,6 +109,7 @@ type Driver interface {
 	// Reaction model related methods.
 	UpsertReaction(ctx context.Context, create *Reaction) (*Reaction, error)
 	ListReactions(ctx context.Context, find *FindReaction) ([]*Reaction, error)
+	ListReactionSummaries(ctx context.Context, contentIDList []string, viewerID *int32) ([]*ReactionSummary, error)
 	DeleteReaction(ctx context.Context, delete *DeleteReaction) error
 
 	// Shortcut related methods.
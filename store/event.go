@@ -0,0 +1,60 @@
+package store
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+)
+
+// StoreEvent is a write the store has committed, one of the event types below.
+type StoreEvent interface {
+	storeEvent()
+}
+
+// MemoCreated is emitted when a memo is created.
+type MemoCreated struct {
+	Memo *Memo
+}
+
+// MemoUpdated is emitted when a memo is updated, including its ownership.
+type MemoUpdated struct {
+	ID int32
+}
+
+// MemoDeleted is emitted when a memo is archived, or deleted permanently if Hard is set.
+type MemoDeleted struct {
+	ID   int32
+	Hard bool
+}
+
+func (*MemoCreated) storeEvent() {}
+func (*MemoUpdated) storeEvent() {}
+func (*MemoDeleted) storeEvent() {}
+
+// StoreEventListener is notified of the events of the store, e.g. to send webhooks or to
+// invalidate caches. See Store.AddEventListener.
+type StoreEventListener interface {
+	// OnStoreEvent is called after the write of the event has been committed, in the goroutine
+	// of the write. Its error is logged and does not fail the write, which cannot be undone.
+	OnStoreEvent(ctx context.Context, event StoreEvent) error
+}
+
+// AddEventListener registers the listener for the events of the store. Events are only emitted
+// for committed writes, never for writes that fail or are rolled back.
+func (s *Store) AddEventListener(listener StoreEventListener) {
+	s.eventListenersMutex.Lock()
+	defer s.eventListenersMutex.Unlock()
+	s.eventListeners = append(s.eventListeners, listener)
+}
+
+// emitEvent notifies the listeners of the committed event.
+func (s *Store) emitEvent(ctx context.Context, event StoreEvent) {
+	s.eventListenersMutex.RLock()
+	listeners := s.eventListeners
+	s.eventListenersMutex.RUnlock()
+	for _, listener := range listeners {
+		if err := listener.OnStoreEvent(ctx, event); err != nil {
+			slog.Warn("Failed to handle store event", slog.String("event", fmt.Sprintf("%T", event)), slog.Any("err", err))
+		}
+	}
+}
@@ -2,7 +2,11 @@ package store
 
 import (
 	"context"
-	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/pkg/errors"
 
 	"github.com/usememos/memos/internal/util"
 
@@ -50,18 +54,41 @@ type Memo struct {
 	Visibility Visibility
 	Pinned     bool
 	Payload    *storepb.MemoPayload
+	// ContentCompressed is whether the content is stored compressed, see CompressMemoContent.
+	// The drivers compress and decompress it, so Content is always plain.
+	ContentCompressed bool
+	// Version is bumped on each update of the memo, see UpdateMemo.ExpectedVersion.
+	Version int32
 
 	// Composed fields
 	ParentID *int32
+	// CommentCount counts archived comments too. It is only set when the memo is found with IncludeCommentCount.
+	CommentCount int32
+	// RelatedMemos are the memos the memo references. It is only set when the memo is found with IncludeRelatedMemos.
+	RelatedMemos []*Memo
+	// ReactionSummaries are the reactions to the memo per reaction type. It is only set when
+	// the memo is found with IncludeReactionSummaries.
+	ReactionSummaries []*ReactionSummary
+	// Reactions are the reactions to the memo, oldest first. It is only set when the memo is
+	// found with IncludeReactionSummaries.
+	Reactions []*Reaction
 }
 
 type FindMemo struct {
-	ID  *int32
-	UID *string
+	ID     *int32
+	IDList []int32
+	UID    *string
 
 	// Standard fields
-	RowStatus       *RowStatus
-	CreatorID       *int32
+	RowStatus *RowStatus
+	CreatorID *int32
+	// CreatorUsername finds the memos of the user with the username, through the username index
+	// of the user table. It may be combined with CreatorID, and finds nothing for unknown usernames.
+	CreatorUsername *string
+	// CreatorUsernameInsensitive matches CreatorUsername ignoring case. Otherwise MySQL compares
+	// usernames by the collation of the column, as it does when finding users.
+	CreatorUsernameInsensitive bool
+	// The time ranges are half-open: the after bounds are inclusive and the before bounds are exclusive.
 	CreatedTsAfter  *int64
 	CreatedTsBefore *int64
 	UpdatedTsAfter  *int64
@@ -74,11 +101,51 @@ type FindMemo struct {
 	PayloadFind     *FindMemoPayload
 	ExcludeContent  bool
 	ExcludeComments bool
-	Filter          *string
+	// ContentHash finds the memos whose content has the hash, see HashMemoContent. The empty
+	// hash finds the memos that were not hashed yet.
+	ContentHash *string
+	// ContentCompressed finds the memos whose content is, or is not, stored compressed.
+	ContentCompressed *bool
+	// CompressedContentMatches are the memos with compressed content matching the content conditions
+	// of ContentSearch and Filter, which the store sets for the drivers, see matchCompressedMemoContent.
+	CompressedContentMatches *CompressedContentMatches
+	// VisibleToUserID widens VisibilityList to also find the memos the user created and the
+	// memos shared with the user in a memo ACL. It has no effect without VisibilityList.
+	VisibleToUserID *int32
+	// HasResources finds the memos with, or without, attached resources.
+	HasResources *bool
+	// ResourceType limits HasResources to resources of the given MIME type. A trailing
+	// wildcard matches a whole class of types, e.g. "image/*". It implies HasResources.
+	ResourceType string
+	// NoTags finds the memos without tags in their payload, e.g. to triage untagged memos.
+	NoTags bool
+	// ParentID finds the comments of the given memo.
+	ParentID *int32
+	// LocationBounds finds the memos located within the bounding box, e.g. for a map view.
+	LocationBounds *MemoLocationBounds
+	Filter         *string
+	// Explore finds the public memos of all users that are not archived, pinned ones first and
+	// then the most recently created, e.g. for the explore feed, which is the same for every
+	// visitor. It overrides RowStatus, VisibilityList, VisibleToUserID and the ordering, and
+	// pages with Cursor as well.
+	Explore bool
+	// IncludeCommentCount counts the comments of each memo into CommentCount.
+	IncludeCommentCount bool
+	// IncludeRelatedMemos loads the memos each memo references into RelatedMemos, leaving
+	// out those that ViewerID cannot see.
+	IncludeRelatedMemos bool
+	// IncludeReactionSummaries loads the reactions to each memo into Reactions and
+	// ReactionSummaries, noting whether ViewerID reacted.
+	IncludeReactionSummaries bool
+	// ViewerID is the user the related memos and reaction summaries are loaded for, or nil for anonymous users.
+	ViewerID *int32
 
 	// Pagination
 	Limit  *int
 	Offset *int
+	// Cursor finds the memos after the one it was encoded from, in the list order.
+	// Unlike Offset it is stable when memos are created between pages. See ListMemosWithCursor.
+	Cursor *string
 
 	// Ordering
 	OrderByUpdatedTs bool
@@ -87,14 +154,20 @@ type FindMemo struct {
 }
 
 type FindMemoPayload struct {
-	Raw                *string
-	TagSearch          []string
+	Raw       *string
+	TagSearch []string
+	// TagSearchInsensitive matches TagSearch ignoring case and diacritics, e.g. "Café" matches "cafe".
+	// The tags are normalized when the payload is written and the search tags when the memos are found.
+	TagSearchInsensitive bool
+
 	HasLink            bool
 	HasTaskList        bool
 	HasCode            bool
 	HasIncompleteTasks bool
 }
 
+// UpdateMemo updates the non-nil fields of a memo, leaving the others as they are. UpdatedTs is
+// set to now when content is updated without it, see Store.UpdateMemo.
 type UpdateMemo struct {
 	ID         int32
 	UID        *string
@@ -105,21 +178,362 @@ type UpdateMemo struct {
 	Visibility *Visibility
 	Pinned     *bool
 	Payload    *storepb.MemoPayload
+	// ContentCompressed is whether Content is stored compressed. It is ignored without Content.
+	ContentCompressed bool
+	// EditorID is the user who updates Content, recorded as the editor of its revision, or 0 when unknown.
+	EditorID int32
+	// RevisionLimit is the number of revisions of the memo kept when Content is updated, of which the
+	// oldest are pruned. No revisions are recorded when zero, e.g. when the content hash is backfilled.
+	RevisionLimit int
+	// ExpectedVersion updates the memo only while its version is the expected one, failing with
+	// ErrMemoVersionConflict otherwise, e.g. when another client has updated the memo meanwhile.
+	ExpectedVersion *int32
+	// DeletedTs is the time the memo was deleted when it is archived by DeleteMemo, or 0. UpdateMemo
+	// resets it to 0 whenever RowStatus is set without it, so that restored memos and memos archived
+	// by their users are not expired, see ExpireArchivedMemos.
+	DeletedTs *int64
 }
 
 type DeleteMemo struct {
 	ID int32
+	// Hard deletes the memo permanently instead of archiving it.
+	Hard bool
+	// CascadeComments deletes the comments of the memo in the same way.
+	CascadeComments bool
 }
 
+// CreateMemo creates the memo, generating its uid when empty.
 func (s *Store) CreateMemo(ctx context.Context, create *Memo) (*Memo, error) {
+	generated := create.UID == ""
+	if err := s.prepareMemoCreate(ctx, create); err != nil {
+		return nil, err
+	}
+	if err := s.checkMemoQuota(ctx, create); err != nil {
+		return nil, err
+	}
+	var memo *Memo
+	if err := s.createWithMemoUID(ctx, create, generated, func() (err error) {
+		memo, err = s.driver.CreateMemo(ctx, create)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+	s.emitEvent(ctx, &MemoCreated{Memo: memo})
+	return memo, nil
+}
+
+// prepareMemoCreate generates the uid of the memo if not set and validates the memo.
+func (s *Store) prepareMemoCreate(ctx context.Context, create *Memo) error {
+	if create.UID == "" {
+		uid, err := s.generateMemoUID(ctx)
+		if err != nil {
+			return err
+		}
+		create.UID = uid
+	}
 	if !util.UIDMatcher.MatchString(create.UID) {
-		return nil, errors.New("invalid uid")
+		return errors.New("invalid uid")
+	}
+	if err := s.checkContentLength(ctx, create.Content); err != nil {
+		return err
+	}
+	compressed, err := s.shouldCompressMemoContent(ctx, create.Content)
+	if err != nil {
+		return err
+	}
+	create.ContentCompressed = compressed
+	if create.Payload != nil {
+		if err := validateMemoLocation(create.Payload); err != nil {
+			return err
+		}
+		if err := s.formatPayloadTags(ctx, create.Payload); err != nil {
+			return err
+		}
 	}
-	return s.driver.CreateMemo(ctx, create)
+	return nil
 }
 
 func (s *Store) ListMemos(ctx context.Context, find *FindMemo) ([]*Memo, error) {
-	return s.driver.ListMemos(ctx, find)
+	find = applyMemoExplore(find)
+	if err := validateMemoFindCursor(find); err != nil {
+		return nil, err
+	}
+	find, err := s.matchCompressedMemoContent(ctx, find)
+	if err != nil {
+		return nil, err
+	}
+	list, err := s.driver.ListMemos(ctx, find)
+	if err != nil {
+		return nil, err
+	}
+	if find.IncludeRelatedMemos {
+		if err := s.loadRelatedMemos(ctx, list, find.ViewerID); err != nil {
+			return nil, err
+		}
+	}
+	if find.IncludeReactionSummaries {
+		if err := s.loadReactionSummaries(ctx, list, find.ViewerID); err != nil {
+			return nil, err
+		}
+	}
+	return list, nil
+}
+
+// ListMemosByIDs returns the memos with the given ids in the order of the ids, e.g. as ranked
+// by a search, leaving out the ids of memos that do not exist or are out of scope. The memos are
+// scoped by find, e.g. by VisibilityList and VisibleToUserID, if given. Its IDList, pagination
+// and ordering are ignored.
+func (s *Store) ListMemosByIDs(ctx context.Context, ids []int32, find *FindMemo) ([]*Memo, error) {
+	if len(ids) == 0 {
+		return []*Memo{}, nil
+	}
+	scopedFind := &FindMemo{}
+	if find != nil {
+		copied := *find
+		scopedFind = &copied
+	}
+	scopedFind.IDList = ids
+	scopedFind.Limit, scopedFind.Offset, scopedFind.Cursor = nil, nil, nil
+	scopedFind.OrderByPinned = false
+	list, err := s.ListMemos(ctx, scopedFind)
+	if err != nil {
+		return nil, err
+	}
+
+	memoMap := make(map[int32]*Memo, len(list))
+	for _, memo := range list {
+		memoMap[memo.ID] = memo
+	}
+	result := make([]*Memo, 0, len(list))
+	for _, id := range ids {
+		if memo, ok := memoMap[id]; ok {
+			result = append(result, memo)
+			// Repeated ids are listed once.
+			delete(memoMap, id)
+		}
+	}
+	return result, nil
+}
+
+// StreamMemos calls fn with each memo matching find, in the list order, without loading them
+// all into memory, e.g. to export the memos of a user. The rows stay open while fn runs. An
+// error from fn stops the iteration and is returned. The memos are passed as found, so the
+// options loading data of several memos at once, e.g. IncludeRelatedMemos, are not supported.
+func (s *Store) StreamMemos(ctx context.Context, find *FindMemo, fn func(*Memo) error) error {
+	find = applyMemoExplore(find)
+	if err := validateMemoFindCursor(find); err != nil {
+		return err
+	}
+	if find.IncludeRelatedMemos || find.IncludeReactionSummaries {
+		return errors.New("related memos and reaction summaries cannot be streamed")
+	}
+	find, err := s.matchCompressedMemoContent(ctx, find)
+	if err != nil {
+		return err
+	}
+	return s.driver.StreamMemos(ctx, find, fn)
+}
+
+// loadReactionSummaries sets the reactions and the reaction summaries of the given memos with
+// one query for the reactions to all memos and one grouped query for their summaries.
+func (s *Store) loadReactionSummaries(ctx context.Context, memos []*Memo, viewerID *int32) error {
+	memoMap := map[string]*Memo{}
+	contentIDs := []string{}
+	for _, memo := range memos {
+		memo.ReactionSummaries, memo.Reactions = []*ReactionSummary{}, []*Reaction{}
+		contentID := getMemoReactionContentID(memo)
+		memoMap[contentID] = memo
+		contentIDs = append(contentIDs, contentID)
+	}
+	if len(contentIDs) == 0 {
+		return nil
+	}
+	summaries, err := s.driver.ListReactionSummaries(ctx, contentIDs, viewerID)
+	if err != nil {
+		return errors.Wrap(err, "failed to list reaction summaries")
+	}
+	for _, summary := range summaries {
+		if memo := memoMap[summary.ContentID]; memo != nil {
+			memo.ReactionSummaries = append(memo.ReactionSummaries, summary)
+		}
+	}
+	reactions, err := s.driver.ListReactions(ctx, &FindReaction{ContentIDList: contentIDs})
+	if err != nil {
+		return errors.Wrap(err, "failed to list reactions")
+	}
+	for _, reaction := range reactions {
+		if memo := memoMap[reaction.ContentID]; memo != nil {
+			memo.Reactions = append(memo.Reactions, reaction)
+		}
+	}
+	return nil
+}
+
+// loadRelatedMemos sets the referenced memos of the given memos that the viewer can see,
+// with one query for the relations and one for the referenced memos of all memos.
+func (s *Store) loadRelatedMemos(ctx context.Context, memos []*Memo, viewerID *int32) error {
+	memoIDs := []int32{}
+	for _, memo := range memos {
+		memo.RelatedMemos = []*Memo{}
+		memoIDs = append(memoIDs, memo.ID)
+	}
+	if len(memoIDs) == 0 {
+		return nil
+	}
+	relationType := MemoRelationReference
+	relations, err := s.driver.ListMemoRelations(ctx, &FindMemoRelation{
+		MemoIDList: memoIDs,
+		Type:       &relationType,
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to list memo relations")
+	}
+	if len(relations) == 0 {
+		return nil
+	}
+
+	relatedMemoIDs := []int32{}
+	for _, relation := range relations {
+		relatedMemoIDs = append(relatedMemoIDs, relation.RelatedMemoID)
+	}
+	// Archived memos are not shown as references.
+	normal := Normal
+	relatedMemos, err := s.driver.ListMemos(ctx, &FindMemo{IDList: relatedMemoIDs, RowStatus: &normal})
+	if err != nil {
+		return errors.Wrap(err, "failed to list related memos")
+	}
+	visibleRelatedMemos, err := s.filterVisibleMemos(ctx, relatedMemos, viewerID)
+	if err != nil {
+		return err
+	}
+	relatedMemoMap := map[int32]*Memo{}
+	for _, relatedMemo := range visibleRelatedMemos {
+		relatedMemoMap[relatedMemo.ID] = relatedMemo
+	}
+	memoMap := map[int32]*Memo{}
+	for _, memo := range memos {
+		memoMap[memo.ID] = memo
+	}
+	for _, relation := range relations {
+		memo, relatedMemo := memoMap[relation.MemoID], relatedMemoMap[relation.RelatedMemoID]
+		if memo != nil && relatedMemo != nil {
+			memo.RelatedMemos = append(memo.RelatedMemos, relatedMemo)
+		}
+	}
+	return nil
+}
+
+// filterVisibleMemos returns the memos the viewer can see, see canViewMemo, and the memos shared
+// with the viewer whatever their visibility. The memo ACLs are only queried for the memos the
+// viewer cannot see otherwise.
+func (s *Store) filterVisibleMemos(ctx context.Context, memos []*Memo, viewerID *int32) ([]*Memo, error) {
+	hiddenMemoIDs := []int32{}
+	for _, memo := range memos {
+		if !canViewMemo(memo, viewerID) {
+			hiddenMemoIDs = append(hiddenMemoIDs, memo.ID)
+		}
+	}
+	sharedMemoIDs := map[int32]bool{}
+	if viewerID != nil && len(hiddenMemoIDs) > 0 {
+		memoACLs, err := s.driver.ListMemoACLs(ctx, &FindMemoACL{MemoIDList: hiddenMemoIDs, UserID: viewerID})
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to list memo acls")
+		}
+		for _, memoACL := range memoACLs {
+			sharedMemoIDs[memoACL.MemoID] = true
+		}
+	}
+	visibleMemos := []*Memo{}
+	for _, memo := range memos {
+		if canViewMemo(memo, viewerID) || sharedMemoIDs[memo.ID] {
+			visibleMemos = append(visibleMemos, memo)
+		}
+	}
+	return visibleMemos, nil
+}
+
+// canViewMemo reports whether the viewer can see the memo: public memos are visible to
+// anyone, protected memos to signed-in users and private memos to their creator only.
+func canViewMemo(memo *Memo, viewerID *int32) bool {
+	switch memo.Visibility {
+	case Public:
+		return true
+	case Protected:
+		return viewerID != nil
+	default:
+		return viewerID != nil && *viewerID == memo.CreatorID
+	}
+}
+
+// CountMemos returns the number of memos matching find without fetching them.
+// Limit, Offset and the ordering of find are ignored.
+func (s *Store) CountMemos(ctx context.Context, find *FindMemo) (int, error) {
+	find, err := s.matchCompressedMemoContent(ctx, applyMemoExplore(find))
+	if err != nil {
+		return 0, err
+	}
+	return s.driver.CountMemos(ctx, find)
+}
+
+// TagCount is a tag and the number of memos that have it.
+type TagCount struct {
+	Tag   string
+	Count int
+}
+
+// ListUserTags returns the tags of the normal memos, not comments, created by the user,
+// ordered by the number of memos that have each tag and then by name. Only the memos of
+// the given visibilities are counted, or all of them when visibilityList is empty.
+func (s *Store) ListUserTags(ctx context.Context, userID int32, visibilityList []Visibility) ([]*TagCount, error) {
+	normalStatus := Normal
+	tagCounts, err := s.driver.ListMemoTags(ctx, &FindMemo{
+		CreatorID:       &userID,
+		RowStatus:       &normalStatus,
+		VisibilityList:  visibilityList,
+		ExcludeComments: true,
+	})
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(tagCounts, func(i, j int) bool {
+		if tagCounts[i].Count != tagCounts[j].Count {
+			return tagCounts[i].Count > tagCounts[j].Count
+		}
+		return tagCounts[i].Tag < tagCounts[j].Tag
+	})
+	return tagCounts, nil
+}
+
+// ListMemosWithCursor lists a page of at most find.Limit memos and returns the cursor
+// of the next page, which is empty after the last page.
+func (s *Store) ListMemosWithCursor(ctx context.Context, find *FindMemo) ([]*Memo, string, error) {
+	if find.Limit == nil || *find.Limit <= 0 {
+		return nil, "", errors.New("limit is required")
+	}
+	find = applyMemoExplore(find)
+	limit := *find.Limit
+	// Fetch one more memo to tell whether there is a next page.
+	pageFind := *find
+	pageLimit := limit + 1
+	pageFind.Limit = &pageLimit
+	memos, err := s.ListMemos(ctx, &pageFind)
+	if err != nil {
+		return nil, "", err
+	}
+	if len(memos) <= limit {
+		return memos, "", nil
+	}
+
+	memos = memos[:limit]
+	last := memos[limit-1]
+	cursor := &MemoCursor{Ts: last.CreatedTs, ID: last.ID}
+	if find.OrderByUpdatedTs {
+		cursor.Ts = last.UpdatedTs
+	}
+	if find.OrderByPinned {
+		cursor.Pinned = &last.Pinned
+	}
+	return memos, EncodeMemoCursor(cursor), nil
 }
 
 func (s *Store) GetMemo(ctx context.Context, find *FindMemo) (*Memo, error) {
@@ -135,13 +549,171 @@ func (s *Store) GetMemo(ctx context.Context, find *FindMemo) (*Memo, error) {
 	return memo, nil
 }
 
+// ListMemoComments returns the comments of the given memo, oldest first.
+func (s *Store) ListMemoComments(ctx context.Context, parentID int32) ([]*Memo, error) {
+	return s.driver.ListMemos(ctx, &FindMemo{
+		ParentID:       &parentID,
+		OrderByTimeAsc: true,
+	})
+}
+
 func (s *Store) UpdateMemo(ctx context.Context, update *UpdateMemo) error {
 	if update.UID != nil && !util.UIDMatcher.MatchString(*update.UID) {
 		return errors.New("invalid uid")
 	}
-	return s.driver.UpdateMemo(ctx, update)
+	// Only new content is checked, so that memos over a lowered limit can still be shrunk or edited otherwise.
+	if update.Content != nil {
+		if err := s.checkContentLength(ctx, *update.Content); err != nil {
+			return err
+		}
+		compressed, err := s.shouldCompressMemoContent(ctx, *update.Content)
+		if err != nil {
+			return err
+		}
+		update.ContentCompressed = compressed
+		memoRelatedSetting, err := s.GetWorkspaceMemoRelatedSetting(ctx)
+		if err != nil {
+			return errors.Wrap(err, "failed to get workspace memo related setting")
+		}
+		update.RevisionLimit = int(memoRelatedSetting.MemoRevisionLimit)
+	}
+	if update.RowStatus != nil && update.DeletedTs == nil {
+		notDeleted := int64(0)
+		update.DeletedTs = &notDeleted
+	}
+	if update.Payload != nil {
+		if err := validateMemoLocation(update.Payload); err != nil {
+			return err
+		}
+		if err := s.formatPayloadTags(ctx, update.Payload); err != nil {
+			return err
+		}
+	}
+	if update.UpdatedTs == nil {
+		touched, err := s.touchesMemoUpdatedTs(ctx, update)
+		if err != nil {
+			return err
+		}
+		if touched {
+			updatedTs := time.Now().Unix()
+			update.UpdatedTs = &updatedTs
+		}
+	}
+	if err := s.driver.UpdateMemo(ctx, update); err != nil {
+		return err
+	}
+	s.emitEvent(ctx, &MemoUpdated{ID: update.ID})
+	return nil
+}
+
+// touchesMemoUpdatedTs reports whether the update changes the update time of the memo. Only
+// content does, or also visibility and pinned when the workspace setting asks. Payload alone
+// does not, e.g. when payloads are rebuilt.
+func (s *Store) touchesMemoUpdatedTs(ctx context.Context, update *UpdateMemo) (bool, error) {
+	if update.Content != nil {
+		return true, nil
+	}
+	if update.Visibility == nil && update.Pinned == nil {
+		return false, nil
+	}
+	memoRelatedSetting, err := s.GetWorkspaceMemoRelatedSetting(ctx)
+	if err != nil {
+		return false, errors.Wrap(err, "failed to get workspace memo related setting")
+	}
+	return memoRelatedSetting.UpdateTimeOnMetadataChange, nil
+}
+
+// ErrMemoVersionConflict is returned when a memo is updated with an expected version other
+// than its stored version.
+var ErrMemoVersionConflict = errors.New("memo version conflict")
+
+// ContentTooLongError is returned when the content of a memo is longer than the content
+// length limit of the workspace.
+type ContentTooLongError struct {
+	// Length and Limit are in bytes of UTF-8.
+	Length int
+	Limit  int
+}
+
+func (e *ContentTooLongError) Error() string {
+	return fmt.Sprintf("content too long: %d bytes (max %d bytes)", e.Length, e.Limit)
+}
+
+// checkContentLength returns a ContentTooLongError when the content is longer than the
+// content length limit of the workspace.
+func (s *Store) checkContentLength(ctx context.Context, content string) error {
+	workspaceMemoRelatedSetting, err := s.GetWorkspaceMemoRelatedSetting(ctx)
+	if err != nil {
+		return errors.Wrap(err, "failed to get workspace memo related setting")
+	}
+	if limit := int(workspaceMemoRelatedSetting.ContentLengthLimit); len(content) > limit {
+		return &ContentTooLongError{Length: len(content), Limit: limit}
+	}
+	return nil
 }
 
+// DeleteMemo archives the memo, which can be restored by updating its row status to normal.
+// Deleted memos are deleted permanently by ExpireArchivedMemos, or right away with Hard set.
 func (s *Store) DeleteMemo(ctx context.Context, delete *DeleteMemo) error {
-	return s.driver.DeleteMemo(ctx, delete)
+	ids, err := s.driver.DeleteMemo(ctx, delete)
+	if err != nil {
+		return err
+	}
+	for _, id := range ids {
+		s.emitEvent(ctx, &MemoDeleted{ID: id, Hard: delete.Hard})
+	}
+	return nil
+}
+
+// TransferMemoOwnership moves the memo and its attached resources from one user to another,
+// e.g. when consolidating accounts. The memo must be owned by fromUserID, and toUserID must be
+// an active user. Comments and relations are left as they are.
+func (s *Store) TransferMemoOwnership(ctx context.Context, memoID, fromUserID, toUserID int32) error {
+	memo, err := s.GetMemo(ctx, &FindMemo{ID: &memoID, ExcludeContent: true})
+	if err != nil {
+		return err
+	}
+	if memo == nil {
+		return errors.Errorf("memo %d not found", memoID)
+	}
+	if memo.CreatorID != fromUserID {
+		return errors.Errorf("memo %d is not owned by user %d", memoID, fromUserID)
+	}
+	toUser, err := s.GetUser(ctx, &FindUser{ID: &toUserID})
+	if err != nil {
+		return err
+	}
+	if toUser == nil {
+		return errors.Errorf("user %d not found", toUserID)
+	}
+	if toUser.RowStatus == Archived {
+		return errors.Errorf("user %d is archived", toUserID)
+	}
+	if err := s.driver.TransferMemoOwnership(ctx, memoID, fromUserID, toUserID); err != nil {
+		return err
+	}
+	s.emitEvent(ctx, &MemoUpdated{ID: memoID})
+	return nil
+}
+
+// BulkUpdateMemoVisibility sets the visibility of all memos of the user with visibility from to to,
+// e.g. when the user makes the account private, and returns the number of memos changed. The update
+// times of the memos are kept.
+func (s *Store) BulkUpdateMemoVisibility(ctx context.Context, userID int32, from, to Visibility) (int, error) {
+	for _, visibility := range []Visibility{from, to} {
+		if visibility != Public && visibility != Protected && visibility != Private {
+			return 0, errors.Errorf("invalid visibility %q", visibility)
+		}
+	}
+	if from == to {
+		return 0, nil
+	}
+	ids, err := s.driver.BulkUpdateMemoVisibility(ctx, userID, from, to)
+	if err != nil {
+		return 0, err
+	}
+	for _, id := range ids {
+		s.emitEvent(ctx, &MemoUpdated{ID: id})
+	}
+	return len(ids), nil
 }
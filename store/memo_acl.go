@@ -0,0 +1,43 @@
+package store
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+)
+
+// MemoACL grants a user read access to a memo regardless of its visibility.
+type MemoACL struct {
+	MemoID    int32
+	UserID    int32
+	CreatedTs int64
+}
+
+type FindMemoACL struct {
+	MemoID     *int32
+	MemoIDList []int32
+	UserID     *int32
+}
+
+type DeleteMemoACL struct {
+	MemoID *int32
+	UserID *int32
+}
+
+// UpsertMemoACL grants the user read access to the memo. Granting access again keeps the
+// original grant.
+func (s *Store) UpsertMemoACL(ctx context.Context, upsert *MemoACL) (*MemoACL, error) {
+	return s.driver.UpsertMemoACL(ctx, upsert)
+}
+
+func (s *Store) ListMemoACLs(ctx context.Context, find *FindMemoACL) ([]*MemoACL, error) {
+	return s.driver.ListMemoACLs(ctx, find)
+}
+
+// DeleteMemoACL revokes the matching grants, e.g. all grants of a memo when only MemoID is set.
+func (s *Store) DeleteMemoACL(ctx context.Context, delete *DeleteMemoACL) error {
+	if delete.MemoID == nil && delete.UserID == nil {
+		return errors.New("memo id or user id is required")
+	}
+	return s.driver.DeleteMemoACL(ctx, delete)
+}
@@ -0,0 +1,131 @@
+package store
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/base64"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/usememos/memos/plugin/filter"
+)
+
+// CompressedContentMatches are the ids of the memos with compressed content that match the content
+// conditions of a FindMemo, which the drivers cannot match in SQL.
+type CompressedContentMatches struct {
+	// Contains maps the strings of ContentSearch and of content.contains filters to the ids of the
+	// memos containing them, ignoring case as the SQL conditions do.
+	Contains map[string][]int32
+	// Equals maps the strings of content == and != filters to the ids of the memos with that content.
+	Equals map[string][]int32
+}
+
+// CompressMemoContent returns the content of a memo as the drivers store it compressed, gzipped
+// and then base64 encoded so that it fits in a text column.
+func CompressMemoContent(content string) (string, error) {
+	var buf bytes.Buffer
+	writer := gzip.NewWriter(&buf)
+	if _, err := writer.Write([]byte(content)); err != nil {
+		return "", errors.Wrap(err, "failed to compress memo content")
+	}
+	if err := writer.Close(); err != nil {
+		return "", errors.Wrap(err, "failed to compress memo content")
+	}
+	return base64.StdEncoding.EncodeToString(buf.Bytes()), nil
+}
+
+// DecompressMemoContent returns the content of a memo stored by CompressMemoContent.
+func DecompressMemoContent(stored string) (string, error) {
+	compressed, err := base64.StdEncoding.DecodeString(stored)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to decode compressed memo content")
+	}
+	reader, err := gzip.NewReader(bytes.NewReader(compressed))
+	if err != nil {
+		return "", errors.Wrap(err, "failed to decompress memo content")
+	}
+	defer reader.Close()
+	content, err := io.ReadAll(reader)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to decompress memo content")
+	}
+	return string(content), nil
+}
+
+// shouldCompressMemoContent reports whether the content is longer than the compression
+// threshold of the workspace, if any.
+func (s *Store) shouldCompressMemoContent(ctx context.Context, content string) (bool, error) {
+	memoRelatedSetting, err := s.GetWorkspaceMemoRelatedSetting(ctx)
+	if err != nil {
+		return false, errors.Wrap(err, "failed to get workspace memo related setting")
+	}
+	threshold := int(memoRelatedSetting.ContentCompressionThreshold)
+	return threshold > 0 && len(content) > threshold, nil
+}
+
+// matchCompressedMemoContent returns the find with the memos with compressed content that match its
+// content conditions, which are decompressed and matched for it, if it has any.
+func (s *Store) matchCompressedMemoContent(ctx context.Context, find *FindMemo) (*FindMemo, error) {
+	contains, equals := find.ContentSearch, []string{}
+	if find.Filter != nil {
+		parsedExpr, err := filter.Parse(*find.Filter, filter.MemoFilterCELAttributes...)
+		if err != nil {
+			return nil, err
+		}
+		filterContains, filterEquals := filter.GetContentConditions(parsedExpr.GetExpr())
+		contains, equals = append(append([]string{}, contains...), filterContains...), filterEquals
+	}
+	if len(contains) == 0 && len(equals) == 0 {
+		return find, nil
+	}
+
+	matches := &CompressedContentMatches{
+		Contains: map[string][]int32{},
+		Equals:   map[string][]int32{},
+	}
+	compressed := true
+	if err := s.driver.StreamMemos(ctx, &FindMemo{CreatorID: find.CreatorID, ContentCompressed: &compressed}, func(memo *Memo) error {
+		content := strings.ToLower(memo.Content)
+		for _, term := range contains {
+			if strings.Contains(content, strings.ToLower(term)) {
+				matches.Contains[term] = append(matches.Contains[term], memo.ID)
+			}
+		}
+		for _, term := range equals {
+			if memo.Content == term {
+				matches.Equals[term] = append(matches.Equals[term], memo.ID)
+			}
+		}
+		return nil
+	}); err != nil {
+		return nil, errors.Wrap(err, "failed to match compressed memo content")
+	}
+	matched := *find
+	matched.CompressedContentMatches = matches
+	return &matched, nil
+}
+
+// CompressedContentCondition returns the condition on the content of memos, e.g. "memo.content LIKE ?",
+// extended to the memos with compressed content, which only match when they are among the given ids.
+// A negative condition, e.g. "memo.content != ?", matches the compressed memos not among them.
+func CompressedContentCondition(condition, compressedColumn, idColumn string, ids []int32, negative bool) string {
+	uncompressed := "(NOT " + compressedColumn + " AND " + condition + ")"
+	idList := make([]string, 0, len(ids))
+	for _, id := range ids {
+		idList = append(idList, strconv.Itoa(int(id)))
+	}
+	switch {
+	case !negative && len(ids) == 0:
+		return uncompressed
+	case !negative:
+		return "(" + uncompressed + " OR " + idColumn + " IN (" + strings.Join(idList, ", ") + "))"
+	case len(ids) == 0:
+		return "(" + uncompressed + " OR " + compressedColumn + ")"
+	default:
+		return "(" + uncompressed + " OR (" + compressedColumn + " AND " + idColumn + " NOT IN (" + strings.Join(idList, ", ") + ")))"
+	}
+}
@@ -0,0 +1,77 @@
+package store
+
+import (
+	"encoding/base64"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// MemoCursor is the position of a memo in a list ordered by time and then by id.
+// Ts is the created or updated time of the memo, whichever the list is ordered by.
+type MemoCursor struct {
+	Ts int64
+	ID int32
+	// Pinned is whether the memo is pinned, only set for lists ordered by pinned first.
+	Pinned *bool
+}
+
+// EncodeMemoCursor returns the opaque form of the cursor used by FindMemo.Cursor.
+func EncodeMemoCursor(cursor *MemoCursor) string {
+	s := fmt.Sprintf("%d:%d", cursor.Ts, cursor.ID)
+	if cursor.Pinned != nil {
+		s = fmt.Sprintf("%s:%t", s, *cursor.Pinned)
+	}
+	return base64.RawURLEncoding.EncodeToString([]byte(s))
+}
+
+// DecodeMemoCursor parses a cursor returned by EncodeMemoCursor.
+func DecodeMemoCursor(s string) (*MemoCursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid cursor")
+	}
+	parts := strings.Split(string(data), ":")
+	if len(parts) != 2 && len(parts) != 3 {
+		return nil, errors.New("invalid cursor")
+	}
+	ts, err := strconv.ParseInt(parts[0], 10, 64)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid cursor time")
+	}
+	id, err := strconv.ParseInt(parts[1], 10, 32)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid cursor id")
+	}
+	cursor := &MemoCursor{Ts: ts, ID: int32(id)}
+	if len(parts) == 3 {
+		pinned, err := strconv.ParseBool(parts[2])
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid cursor pinned")
+		}
+		cursor.Pinned = &pinned
+	}
+	return cursor, nil
+}
+
+// validateMemoFindCursor checks that the cursor of the find, if any, fits its pagination and
+// ordering: a cursor cannot be combined with an offset, and lists ordered by pinned first need
+// cursors that know whether their memo is pinned.
+func validateMemoFindCursor(find *FindMemo) error {
+	if find.Cursor == nil {
+		return nil
+	}
+	if find.Offset != nil {
+		return errors.New("cursor cannot be used with offset")
+	}
+	cursor, err := DecodeMemoCursor(*find.Cursor)
+	if err != nil {
+		return err
+	}
+	if find.OrderByPinned != (cursor.Pinned != nil) {
+		return errors.New("cursor does not match the ordering by pinned")
+	}
+	return nil
+}
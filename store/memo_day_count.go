@@ -0,0 +1,28 @@
+package store
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// maxTimezoneOffset is the largest offset of a timezone from UTC, that of UTC+14.
+const maxTimezoneOffset = 14 * time.Hour
+
+// MemoDayCount is the number of memos created on a day.
+type MemoDayCount struct {
+	// Date is the day in the timezone of the counts, e.g. "2024-01-31".
+	Date  string
+	Count int
+}
+
+// ListMemoDayCounts returns the number of normal memos the user created per day between from,
+// inclusive, and to, exclusive, e.g. for a heatmap. The days are those of the timezone whose offset
+// from UTC is tzOffset, e.g. -5 hours for UTC-5, and are ascending. Days without memos are left out.
+func (s *Store) ListMemoDayCounts(ctx context.Context, userID int32, from, to time.Time, tzOffset time.Duration) ([]*MemoDayCount, error) {
+	if tzOffset < -maxTimezoneOffset || tzOffset > maxTimezoneOffset {
+		return nil, errors.Errorf("timezone offset %s is not within [-14h, 14h]", tzOffset)
+	}
+	return s.driver.ListMemoDayCounts(ctx, userID, from.Unix(), to.Unix(), int(tzOffset/time.Second))
+}
@@ -0,0 +1,125 @@
+package store
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+)
+
+// MemoDetailsOptions selects the related data GetMemoWithDetails loads with a memo.
+type MemoDetailsOptions struct {
+	// ViewerID is the user the memo is loaded for, or nil for anonymous users.
+	ViewerID *int32
+	// IncludeResources loads the resources of the memo into Resources.
+	IncludeResources bool
+	// IncludeRelations loads the relations from and to the memo into Relations, leaving out
+	// those with memos that ViewerID cannot see.
+	IncludeRelations bool
+	// IncludeRelatedMemos loads the memos the memo references into Memo.RelatedMemos, leaving
+	// out those that ViewerID cannot see.
+	IncludeRelatedMemos bool
+	// IncludeReactionSummaries loads the reactions to the memo into Memo.Reactions and Memo.ReactionSummaries.
+	IncludeReactionSummaries bool
+	// IncludeCommentCount counts the comments of the memo into Memo.CommentCount.
+	IncludeCommentCount bool
+}
+
+// MemoDetails is a memo with the related data loaded by GetMemoWithDetails. Resources and
+// Relations are nil unless loaded.
+type MemoDetails struct {
+	Memo      *Memo
+	Resources []*Resource
+	Relations []*MemoRelation
+}
+
+// GetMemoWithDetails returns the memo of the id with the related data selected by opts, or nil
+// when the memo does not exist or the viewer cannot see it, see canViewMemo. The comment count
+// is found with the memo, and each other related set costs a few queries whatever its size.
+func (s *Store) GetMemoWithDetails(ctx context.Context, id int32, opts *MemoDetailsOptions) (*MemoDetails, error) {
+	if opts == nil {
+		opts = &MemoDetailsOptions{}
+	}
+	memos, err := s.driver.ListMemos(ctx, &FindMemo{ID: &id, IncludeCommentCount: opts.IncludeCommentCount})
+	if err != nil {
+		return nil, err
+	}
+	memos, err = s.filterVisibleMemos(ctx, memos, opts.ViewerID)
+	if err != nil {
+		return nil, err
+	}
+	if len(memos) == 0 {
+		return nil, nil
+	}
+
+	details := &MemoDetails{Memo: memos[0]}
+	if opts.IncludeRelatedMemos {
+		if err := s.loadRelatedMemos(ctx, memos, opts.ViewerID); err != nil {
+			return nil, err
+		}
+	}
+	if opts.IncludeReactionSummaries {
+		if err := s.loadReactionSummaries(ctx, memos, opts.ViewerID); err != nil {
+			return nil, err
+		}
+	}
+	if opts.IncludeResources {
+		resources, err := s.driver.ListResources(ctx, &FindResource{MemoID: &id})
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to list resources")
+		}
+		details.Resources = resources
+	}
+	if opts.IncludeRelations {
+		relations, err := s.listVisibleMemoRelations(ctx, id, opts.ViewerID)
+		if err != nil {
+			return nil, err
+		}
+		details.Relations = relations
+	}
+	return details, nil
+}
+
+// listVisibleMemoRelations returns the relations from and to the memo with the memos the viewer
+// can see.
+func (s *Store) listVisibleMemoRelations(ctx context.Context, memoID int32, viewerID *int32) ([]*MemoRelation, error) {
+	relations, err := s.driver.ListMemoRelations(ctx, &FindMemoRelation{MemoID: &memoID})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list memo relations")
+	}
+	inboundRelations, err := s.driver.ListMemoRelations(ctx, &FindMemoRelation{RelatedMemoID: &memoID})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list memo relations")
+	}
+	relations = append(relations, inboundRelations...)
+	if len(relations) == 0 {
+		return []*MemoRelation{}, nil
+	}
+
+	otherMemoIDs := []int32{}
+	for _, relation := range relations {
+		if relation.MemoID == memoID {
+			otherMemoIDs = append(otherMemoIDs, relation.RelatedMemoID)
+		} else {
+			otherMemoIDs = append(otherMemoIDs, relation.MemoID)
+		}
+	}
+	otherMemos, err := s.driver.ListMemos(ctx, &FindMemo{IDList: otherMemoIDs, ExcludeContent: true})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list related memos")
+	}
+	visibleMemos, err := s.filterVisibleMemos(ctx, otherMemos, viewerID)
+	if err != nil {
+		return nil, err
+	}
+	visibleMemoIDs := map[int32]bool{}
+	for _, memo := range visibleMemos {
+		visibleMemoIDs[memo.ID] = true
+	}
+	visibleRelations := []*MemoRelation{}
+	for i, relation := range relations {
+		if visibleMemoIDs[otherMemoIDs[i]] {
+			visibleRelations = append(visibleRelations, relation)
+		}
+	}
+	return visibleRelations, nil
+}
@@ -0,0 +1,112 @@
+package store
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+
+	"github.com/pkg/errors"
+)
+
+// HashMemoContent returns the content hash of a memo, the hex SHA-256 of its content, which
+// the drivers store with the memo to find duplicates.
+func HashMemoContent(content string) string {
+	hash := sha256.Sum256([]byte(content))
+	return hex.EncodeToString(hash[:])
+}
+
+// MemoContentHash is the content hash of a memo.
+type MemoContentHash struct {
+	MemoID      int32
+	ContentHash string
+}
+
+// FindDuplicateMemos returns the clusters of the ids of the memos of the user with the same
+// content. The ids of each cluster are ascending, and so are the first ids of the clusters. Comments are left out. Memos written before the content
+// hash was stored are hashed first.
+func (s *Store) FindDuplicateMemos(ctx context.Context, userID int32) ([][]int32, error) {
+	unhashed := ""
+	memos, err := s.driver.ListMemos(ctx, &FindMemo{CreatorID: &userID, ContentHash: &unhashed})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list unhashed memos")
+	}
+	for _, memo := range memos {
+		// Writing the content stores its hash, without touching the update time.
+		if err := s.driver.UpdateMemo(ctx, &UpdateMemo{ID: memo.ID, Content: &memo.Content, ContentCompressed: memo.ContentCompressed}); err != nil {
+			return nil, errors.Wrapf(err, "failed to hash memo %d", memo.ID)
+		}
+	}
+	contentHashes, err := s.driver.ListDuplicateMemoContentHashes(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	clusters, indexes := [][]int32{}, map[string]int{}
+	for _, contentHash := range contentHashes {
+		index, ok := indexes[contentHash.ContentHash]
+		if !ok {
+			index = len(clusters)
+			indexes[contentHash.ContentHash] = index
+			clusters = append(clusters, []int32{})
+		}
+		clusters[index] = append(clusters[index], contentHash.MemoID)
+	}
+	// A memo may only share its content with comments, which are left out.
+	duplicates := [][]int32{}
+	for _, cluster := range clusters {
+		if len(cluster) > 1 {
+			duplicates = append(duplicates, cluster)
+		}
+	}
+	return duplicates, nil
+}
+
+// MergeMemos merges memos into another memo, see Store.MergeMemos.
+type MergeMemos struct {
+	KeepID   int32
+	MergeIDs []int32
+	// The content ids of the reactions to the kept memo and to the merged memos.
+	KeepReactionContentID   string
+	MergeReactionContentIDs []string
+}
+
+// MergeMemos merges the memos of mergeIDs into the memo of keepID in a transaction. Their
+// resources, relations and reactions are moved to the kept memo, and they are deleted with
+// their revisions. The relations that would be duplicated or would relate the kept memo to
+// itself are dropped, and so are the reactions the kept memo already has from the same user.
+// All memos must have the same creator.
+func (s *Store) MergeMemos(ctx context.Context, keepID int32, mergeIDs []int32) error {
+	if len(mergeIDs) == 0 {
+		return errors.New("no memos to merge")
+	}
+	idList := []int32{keepID}
+	seen := map[int32]bool{keepID: true}
+	for _, id := range mergeIDs {
+		if id == keepID {
+			return errors.Errorf("memo %d cannot be merged into itself", keepID)
+		}
+		if !seen[id] {
+			seen[id] = true
+			idList = append(idList, id)
+		}
+	}
+	memos, err := s.driver.ListMemos(ctx, &FindMemo{IDList: idList, ExcludeContent: true})
+	if err != nil {
+		return err
+	}
+	if len(memos) != len(idList) {
+		return errors.New("memo not found")
+	}
+	merge := &MergeMemos{KeepID: keepID}
+	for _, memo := range memos {
+		if memo.CreatorID != memos[0].CreatorID {
+			return errors.New("memos of different creators cannot be merged")
+		}
+		if memo.ID == keepID {
+			merge.KeepReactionContentID = getMemoReactionContentID(memo)
+		} else {
+			merge.MergeIDs = append(merge.MergeIDs, memo.ID)
+			merge.MergeReactionContentIDs = append(merge.MergeReactionContentIDs, getMemoReactionContentID(memo))
+		}
+	}
+	return s.driver.MergeMemos(ctx, merge)
+}
@@ -0,0 +1,40 @@
+package store
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// DeletedMemoRetention is how long deleted memos can be restored before they are expired.
+const DeletedMemoRetention = 30 * 24 * time.Hour
+
+// ExpiredMemos are the memos deleted permanently by Store.ExpireArchivedMemos.
+type ExpiredMemos struct {
+	IDs []int32
+	// Resources are the deleted resources of the memos, without their blobs, whose local files and
+	// s3 objects are left to delete. Those of shared blobs still in use are left out.
+	Resources []*Resource
+}
+
+// ExpireArchivedMemos permanently deletes the memos archived by DeleteMemo more than retention ago,
+// along with their relations, organizers, ACLs, revisions, idempotency keys, reactions and resources
+// in a transaction. Memos archived by their users are kept. Deleting the local files and s3 objects
+// of the resources is best-effort, as the resources are already deleted.
+func (s *Store) ExpireArchivedMemos(ctx context.Context, retention time.Duration) error {
+	expired, err := s.driver.DeleteExpiredMemos(ctx, time.Now().Add(-retention).Unix())
+	if err != nil {
+		return errors.Wrap(err, "failed to delete expired memos")
+	}
+	for _, resource := range expired.Resources {
+		if err := s.deleteResourceBlob(ctx, resource); err != nil {
+			slog.Warn("Failed to delete blob of expired resource", slog.Int("id", int(resource.ID)), slog.Any("err", err))
+		}
+	}
+	for _, id := range expired.IDs {
+		s.emitEvent(ctx, &MemoDeleted{ID: id, Hard: true})
+	}
+	return nil
+}
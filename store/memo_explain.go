@@ -0,0 +1,43 @@
+package store
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+)
+
+// ErrQueryExplainDisabled is returned by ExplainListMemos unless the workspace enables it, see
+// WorkspaceGeneralSetting.enable_query_explain.
+var ErrQueryExplainDisabled = errors.New("query explain is disabled")
+
+// MemoQueryPlan is the query that lists memos and the plan of the database for it.
+type MemoQueryPlan struct {
+	// SQL is the query as ListMemos executes it, with the placeholders of the driver.
+	SQL  string
+	Args []any
+	// Plan is the plan as the database reports it, one step per line.
+	Plan string
+}
+
+// ExplainListMemos returns the query ListMemos executes for the given find and its plan, e.g. to
+// diagnose slow memo lists without a database console. With analyze set, the query is run to
+// report actual timings where the database supports it, which SQLite does not. It is only
+// available when enabled in the workspace general setting, as the plan reveals the schema.
+func (s *Store) ExplainListMemos(ctx context.Context, find *FindMemo, analyze bool) (*MemoQueryPlan, error) {
+	workspaceGeneralSetting, err := s.GetWorkspaceGeneralSetting(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if !workspaceGeneralSetting.EnableQueryExplain {
+		return nil, ErrQueryExplainDisabled
+	}
+	find = applyMemoExplore(find)
+	if err := validateMemoFindCursor(find); err != nil {
+		return nil, err
+	}
+	find, err = s.matchCompressedMemoContent(ctx, find)
+	if err != nil {
+		return nil, err
+	}
+	return s.driver.ExplainListMemos(ctx, find, analyze)
+}
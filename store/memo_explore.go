@@ -0,0 +1,18 @@
+package store
+
+// applyMemoExplore returns a copy of the find scoped and ordered as the explore feed when it
+// is set to Explore, see FindMemo.Explore, and the find itself otherwise.
+func applyMemoExplore(find *FindMemo) *FindMemo {
+	if !find.Explore {
+		return find
+	}
+	explore := *find
+	normal := Normal
+	explore.RowStatus = &normal
+	explore.VisibilityList = []Visibility{Public}
+	explore.VisibleToUserID = nil
+	explore.OrderByPinned = true
+	explore.OrderByUpdatedTs = false
+	explore.OrderByTimeAsc = false
+	return &explore
+}
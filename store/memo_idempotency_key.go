@@ -0,0 +1,60 @@
+package store
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// MaxMemoIdempotencyKeyLength is the max length of an idempotency key in bytes.
+	MaxMemoIdempotencyKeyLength = 128
+	// MemoIdempotencyKeyTTL is how long an idempotency key is kept after the memo is created.
+	MemoIdempotencyKeyTTL = 24 * time.Hour
+)
+
+// CreateMemoWithIdempotencyKey creates the memo unless its creator has already created a memo
+// with the same idempotency key, in which case that memo is returned instead. The returned bool
+// reports whether the memo was created. Concurrent calls with the same key create one memo.
+func (s *Store) CreateMemoWithIdempotencyKey(ctx context.Context, create *Memo, idempotencyKey string) (*Memo, bool, error) {
+	if idempotencyKey == "" || len(idempotencyKey) > MaxMemoIdempotencyKeyLength {
+		return nil, false, errors.Errorf("idempotency key must be 1 to %d bytes", MaxMemoIdempotencyKeyLength)
+	}
+	generated := create.UID == ""
+	if err := s.prepareMemoCreate(ctx, create); err != nil {
+		return nil, false, err
+	}
+	// The quota is checked only when the memo is created, so that a retry returns the memo even
+	// though it filled the quota.
+	quota, err := s.getMemoQuota(ctx)
+	if err != nil {
+		return nil, false, err
+	}
+
+	var memoID int32
+	var created bool
+	if err := s.createWithMemoUID(ctx, create, generated, func() (err error) {
+		memoID, created, err = s.driver.CreateMemoWithIdempotencyKey(ctx, create, idempotencyKey, quota)
+		return err
+	}); err != nil {
+		return nil, false, err
+	}
+	memo, err := s.GetMemo(ctx, &FindMemo{ID: &memoID})
+	if err != nil {
+		return nil, false, err
+	}
+	if memo == nil {
+		return nil, false, errors.Errorf("memo %d of idempotency key %s not found", memoID, idempotencyKey)
+	}
+	if created {
+		s.emitEvent(ctx, &MemoCreated{Memo: memo})
+	}
+	return memo, created, nil
+}
+
+// DeleteExpiredMemoIdempotencyKeys deletes the idempotency keys older than the ttl, after which
+// a memo created with the same key is a new memo.
+func (s *Store) DeleteExpiredMemoIdempotencyKeys(ctx context.Context, ttl time.Duration) error {
+	return s.driver.DeleteMemoIdempotencyKeys(ctx, time.Now().Add(-ttl).Unix())
+}
@@ -0,0 +1,53 @@
+package store
+
+import (
+	"math"
+
+	"github.com/pkg/errors"
+
+	storepb "github.com/usememos/memos/proto/gen/store"
+)
+
+// ErrInvalidMemoLocation is returned when a memo is written with a location whose latitude is not
+// within [-90, 90] or whose longitude is not within [-180, 180].
+var ErrInvalidMemoLocation = errors.New("invalid memo location")
+
+// MemoLocationBounds is a bounding box of memo locations, including its edges. A box whose
+// MinLongitude is greater than its MaxLongitude crosses the antimeridian, e.g. 170 to -170
+// covers the 20 degrees around it rather than the 340 degrees between.
+type MemoLocationBounds struct {
+	MinLatitude  float64
+	MaxLatitude  float64
+	MinLongitude float64
+	MaxLongitude float64
+}
+
+// CrossesAntimeridian reports whether the box crosses the antimeridian, see MemoLocationBounds.
+func (b *MemoLocationBounds) CrossesAntimeridian() bool {
+	return b.MinLongitude > b.MaxLongitude
+}
+
+// GetMemoCoordinates returns the latitude and longitude of the location in the payload, which the
+// drivers store in their columns to find memos by LocationBounds, or nils without a location.
+func GetMemoCoordinates(payload *storepb.MemoPayload) (*float64, *float64) {
+	location := payload.GetLocation()
+	if location == nil {
+		return nil, nil
+	}
+	return &location.Latitude, &location.Longitude
+}
+
+// validateMemoLocation returns ErrInvalidMemoLocation when the location in the payload is out of range.
+func validateMemoLocation(payload *storepb.MemoPayload) error {
+	location := payload.GetLocation()
+	if location == nil {
+		return nil
+	}
+	if math.IsNaN(location.Latitude) || location.Latitude < -90 || location.Latitude > 90 {
+		return errors.Wrapf(ErrInvalidMemoLocation, "latitude %v is not within [-90, 90]", location.Latitude)
+	}
+	if math.IsNaN(location.Longitude) || location.Longitude < -180 || location.Longitude > 180 {
+		return errors.Wrapf(ErrInvalidMemoLocation, "longitude %v is not within [-180, 180]", location.Longitude)
+	}
+	return nil
+}
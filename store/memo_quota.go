@@ -0,0 +1,100 @@
+package store
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pkg/errors"
+)
+
+// MemoUsage is how much a user stores in memos.
+type MemoUsage struct {
+	Count int64
+	// Size is the total size of the content of the memos in bytes, as stored.
+	Size int64
+}
+
+// MemoQuotaExceededError is returned when creating a memo would exceed a memo quota of the
+// workspace for its creator.
+type MemoQuotaExceededError struct {
+	// Unit is "memos" for the count quota and "bytes" for the size quota.
+	Unit      string
+	Usage     int64
+	Requested int64
+	Limit     int64
+}
+
+func (e *MemoQuotaExceededError) Error() string {
+	return fmt.Sprintf("memo quota exceeded: %d of %d %s used, %d more requested", e.Usage, e.Limit, e.Unit, e.Requested)
+}
+
+// GetMemoUsage returns how much the user stores in memos, archived ones included if asked.
+func (s *Store) GetMemoUsage(ctx context.Context, userID int32, includeArchived bool) (*MemoUsage, error) {
+	return s.driver.GetMemoUsage(ctx, userID, includeArchived)
+}
+
+// MemoQuota is the memo quotas of the workspace for each user.
+type MemoQuota struct {
+	// Count is the max number of memos, unlimited when not positive.
+	Count int64
+	// Size is the max total size of the content of the memos in bytes, as stored, unlimited
+	// when not positive.
+	Size int64
+	// IncludeArchived counts archived memos too.
+	IncludeArchived bool
+}
+
+// Check returns a MemoQuotaExceededError when creating the memo would exceed the quota, given
+// the usage of its creator.
+func (q *MemoQuota) Check(usage *MemoUsage, create *Memo) error {
+	if q.Count > 0 && usage.Count+1 > q.Count {
+		return &MemoQuotaExceededError{Unit: "memos", Usage: usage.Count, Requested: 1, Limit: q.Count}
+	}
+	if q.Size > 0 {
+		size := int64(len(create.Content))
+		if create.ContentCompressed {
+			compressed, err := CompressMemoContent(create.Content)
+			if err != nil {
+				return err
+			}
+			size = int64(len(compressed))
+		}
+		if usage.Size+size > q.Size {
+			return &MemoQuotaExceededError{Unit: "bytes", Usage: usage.Size, Requested: size, Limit: q.Size}
+		}
+	}
+	return nil
+}
+
+// getMemoQuota returns the memo quota of the workspace, or nil when memos are unlimited.
+func (s *Store) getMemoQuota(ctx context.Context) (*MemoQuota, error) {
+	memoRelatedSetting, err := s.GetWorkspaceMemoRelatedSetting(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get workspace memo related setting")
+	}
+	quota := &MemoQuota{
+		Count:           int64(memoRelatedSetting.MemoCountQuota),
+		Size:            memoRelatedSetting.MemoSizeQuota,
+		IncludeArchived: memoRelatedSetting.MemoQuotaIncludeArchived,
+	}
+	if quota.Count <= 0 && quota.Size <= 0 {
+		return nil, nil
+	}
+	return quota, nil
+}
+
+// checkMemoQuota returns a MemoQuotaExceededError when creating the memo would exceed the memo
+// quotas of the workspace for its creator. The usage is counted before the memo is created, so
+// concurrent creations may exceed the quotas slightly. Creations with an idempotency key are
+// checked by the driver instead, only when the key is new.
+func (s *Store) checkMemoQuota(ctx context.Context, create *Memo) error {
+	quota, err := s.getMemoQuota(ctx)
+	if err != nil || quota == nil {
+		return err
+	}
+	usage, err := s.driver.GetMemoUsage(ctx, create.CreatorID, quota.IncludeArchived)
+	if err != nil {
+		return errors.Wrap(err, "failed to get memo usage")
+	}
+	return quota.Check(usage, create)
+}
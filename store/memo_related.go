@@ -0,0 +1,85 @@
+package store
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+)
+
+// RelatedMemo is a memo related to another by the tags they share.
+type RelatedMemo struct {
+	Memo *Memo
+	// SharedTagCount is the number of the tags of the other memo that the memo has too.
+	SharedTagCount int
+}
+
+// MemoTagOverlap is the number of given tags that a memo has, see Driver.ListMemoTagOverlaps.
+type MemoTagOverlap struct {
+	MemoID int32
+	Count  int
+}
+
+// ListRelatedMemosByTags returns at most limit other normal memos that share tags with the memo,
+// e.g. for recommendations on its page, by most shared tags first and then by newest first. Tags
+// are compared normalized, see NormalizeTag. Only the memos the viewer can see are returned, which
+// are the public ones for anonymous viewers, see FindMemo.VisibleToUserID. Comments are left out.
+// The memo itself must be visible to the viewer too, otherwise it is not found.
+func (s *Store) ListRelatedMemosByTags(ctx context.Context, memoID int32, viewerID *int32, limit int) ([]*RelatedMemo, error) {
+	if limit <= 0 {
+		return nil, errors.New("limit must be positive")
+	}
+	visibilityList := []Visibility{Public}
+	if viewerID != nil {
+		visibilityList = []Visibility{Public, Protected}
+	}
+	// A memo the viewer cannot see is not found, so that its tags are not revealed by the related memos.
+	memo, err := s.GetMemo(ctx, &FindMemo{ID: &memoID, ExcludeContent: true, VisibilityList: visibilityList, VisibleToUserID: viewerID})
+	if err != nil {
+		return nil, err
+	}
+	if memo == nil {
+		return nil, errors.Errorf("memo %d not found", memoID)
+	}
+	normalizedTags := memo.Payload.GetNormalizedTags()
+	if len(normalizedTags) == 0 {
+		return []*RelatedMemo{}, nil
+	}
+
+	normal := Normal
+	find := &FindMemo{
+		RowStatus:       &normal,
+		ExcludeComments: true,
+		VisibilityList:  visibilityList,
+		VisibleToUserID: viewerID,
+	}
+	// The memo itself has all of its tags, so it is listed first unless it is not visible.
+	overlaps, err := s.driver.ListMemoTagOverlaps(ctx, find, normalizedTags, limit+1)
+	if err != nil {
+		return nil, err
+	}
+	ids, counts := []int32{}, map[int32]int{}
+	for _, overlap := range overlaps {
+		if overlap.MemoID != memoID && len(ids) < limit {
+			ids, counts[overlap.MemoID] = append(ids, overlap.MemoID), overlap.Count
+		}
+	}
+	if len(ids) == 0 {
+		return []*RelatedMemo{}, nil
+	}
+	memos, err := s.ListMemos(ctx, &FindMemo{IDList: ids})
+	if err != nil {
+		return nil, err
+	}
+	memosByID := map[int32]*Memo{}
+	for _, memo := range memos {
+		memosByID[memo.ID] = memo
+	}
+	relatedMemos := []*RelatedMemo{}
+	for _, id := range ids {
+		// A memo deleted meanwhile is left out.
+		if memo, ok := memosByID[id]; ok {
+			relatedMemos = append(relatedMemos, &RelatedMemo{Memo: memo, SharedTagCount: counts[id]})
+		}
+	}
+	return relatedMemos, nil
+}
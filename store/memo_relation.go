@@ -21,6 +21,7 @@ type MemoRelation struct {
 
 type FindMemoRelation struct {
 	MemoID        *int32
+	MemoIDList    []int32
 	RelatedMemoID *int32
 	Type          *MemoRelationType
 	MemoFilter    *string
@@ -0,0 +1,64 @@
+package store
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+)
+
+// MemoRevision is a version of the content of a memo. Each update of the content through
+// Store.UpdateMemo records the new content as a revision, after the content it replaces
+// when the memo has no revisions yet, so that the content before the first update is kept.
+type MemoRevision struct {
+	ID     int32
+	MemoID int32
+	// Content is the content of the memo in this revision.
+	Content string
+	// EditorID is the user who wrote the revision, or 0 when unknown. The first content of a
+	// memo is attributed to its creator.
+	EditorID  int32
+	CreatedTs int64
+}
+
+type FindMemoRevision struct {
+	ID     *int32
+	MemoID *int32
+}
+
+type DeleteMemoRevision struct {
+	MemoID *int32
+}
+
+// ListMemoRevisions returns the matching revisions, newest first.
+func (s *Store) ListMemoRevisions(ctx context.Context, find *FindMemoRevision) ([]*MemoRevision, error) {
+	return s.driver.ListMemoRevisions(ctx, find)
+}
+
+func (s *Store) GetMemoRevision(ctx context.Context, find *FindMemoRevision) (*MemoRevision, error) {
+	list, err := s.ListMemoRevisions(ctx, find)
+	if err != nil {
+		return nil, err
+	}
+	if len(list) == 0 {
+		return nil, nil
+	}
+	return list[0], nil
+}
+
+// RevertMemoToRevision updates the content of the memo of the revision back to the content of
+// the revision, which is recorded as a new revision by the editor. The payload of the memo is
+// left as it is, so callers that derive it from the content should rebuild it.
+func (s *Store) RevertMemoToRevision(ctx context.Context, revisionID, editorID int32) error {
+	revision, err := s.GetMemoRevision(ctx, &FindMemoRevision{ID: &revisionID})
+	if err != nil {
+		return err
+	}
+	if revision == nil {
+		return errors.Errorf("memo revision %d not found", revisionID)
+	}
+	return s.UpdateMemo(ctx, &UpdateMemo{
+		ID:       revision.MemoID,
+		Content:  &revision.Content,
+		EditorID: editorID,
+	})
+}
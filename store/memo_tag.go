@@ -0,0 +1,149 @@
+package store
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"unicode"
+
+	"github.com/pkg/errors"
+	"golang.org/x/text/runes"
+	"golang.org/x/text/transform"
+	"golang.org/x/text/unicode/norm"
+
+	storepb "github.com/usememos/memos/proto/gen/store"
+)
+
+// tagSeparators are the characters that separate the words of a tag, e.g. "/" of hierarchical tags.
+const tagSeparators = "/-_"
+
+// FormatTag formats the tag as it is stored and listed: the surrounding spaces and separators are
+// trimmed and runs of internal spaces or separators are collapsed into their first, e.g.
+// " work//notes- " becomes "work/notes". The tag is lowercased too with lowercase, see
+// WorkspaceMemoRelatedSetting.tag_lowercase.
+func FormatTag(tag string, lowercase bool) string {
+	tag = strings.Trim(strings.TrimSpace(tag), tagSeparators)
+	var builder strings.Builder
+	var last rune
+	for _, r := range tag {
+		isSeparator := unicode.IsSpace(r) || strings.ContainsRune(tagSeparators, r)
+		if isSeparator && (unicode.IsSpace(last) || strings.ContainsRune(tagSeparators, last)) {
+			continue
+		}
+		if unicode.IsSpace(r) {
+			r = ' '
+		}
+		builder.WriteRune(r)
+		last = r
+	}
+	if lowercase {
+		return strings.ToLower(builder.String())
+	}
+	return builder.String()
+}
+
+// FormatTags formats the tags and drops the empty tags and duplicates that formatting yields.
+func FormatTags(tags []string, lowercase bool) []string {
+	formattedTags := []string{}
+	for _, tag := range tags {
+		formatted := FormatTag(tag, lowercase)
+		if formatted == "" || slices.Contains(formattedTags, formatted) {
+			continue
+		}
+		formattedTags = append(formattedTags, formatted)
+	}
+	return formattedTags
+}
+
+// formatPayloadTags formats the tags of the payload as the workspace setting asks and sets their
+// normalized tags, as they are written.
+func (s *Store) formatPayloadTags(ctx context.Context, payload *storepb.MemoPayload) error {
+	memoRelatedSetting, err := s.GetWorkspaceMemoRelatedSetting(ctx)
+	if err != nil {
+		return errors.Wrap(err, "failed to get workspace memo related setting")
+	}
+	payload.Tags = FormatTags(payload.Tags, memoRelatedSetting.TagLowercase)
+	payload.NormalizedTags = NormalizeTags(payload.Tags)
+	return nil
+}
+
+// NormalizeTag lowercases the tag and strips its diacritics, e.g. "Café" becomes "cafe".
+func NormalizeTag(tag string) string {
+	stripDiacritics := transform.Chain(norm.NFD, runes.Remove(runes.In(unicode.Mn)), norm.NFC)
+	normalized, _, err := transform.String(stripDiacritics, tag)
+	if err != nil {
+		normalized = tag
+	}
+	return strings.ToLower(normalized)
+}
+
+// NormalizeTags normalizes the tags and drops the duplicates that the normalization yields.
+func NormalizeTags(tags []string) []string {
+	normalizedTags := []string{}
+	seen := map[string]bool{}
+	for _, tag := range tags {
+		normalized := NormalizeTag(tag)
+		if seen[normalized] {
+			continue
+		}
+		seen[normalized] = true
+		normalizedTags = append(normalizedTags, normalized)
+	}
+	return normalizedTags
+}
+
+// MemoContent is the content of a memo, as scanned by ForEachMemoContent.
+type MemoContent struct {
+	ID      int32
+	Content string
+}
+
+// MemoTags are the tags of a memo, as rebuilt from its content.
+type MemoTags struct {
+	MemoID int32
+	Tags   []string
+	// NormalizedTags are set from Tags by UpdateMemoTags.
+	NormalizedTags []string
+}
+
+// memoContentPageSize is the number of memos ForEachMemoContent reads at once.
+const memoContentPageSize = 100
+
+// ForEachMemoContent calls fn with the content of each memo after afterID, in the order of ids,
+// e.g. to rebuild the tags of all memos. The memos are read a page at a time, and no rows are
+// open while fn runs, so fn may write memos. An error from fn stops the scan and is returned.
+func (s *Store) ForEachMemoContent(ctx context.Context, afterID int32, fn func(id int32, content string) error) error {
+	for {
+		list, err := s.driver.ListMemoContents(ctx, afterID, memoContentPageSize)
+		if err != nil {
+			return errors.Wrap(err, "failed to list memo contents")
+		}
+		for _, memoContent := range list {
+			if err := fn(memoContent.ID, memoContent.Content); err != nil {
+				return err
+			}
+			afterID = memoContent.ID
+		}
+		if len(list) < memoContentPageSize {
+			return nil
+		}
+	}
+}
+
+// UpdateMemoTags replaces the tags in the payloads of the memos in a transaction, formatting and
+// normalizing them as UpdateMemo does. The rest of the payloads and the update times are left as
+// they are.
+func (s *Store) UpdateMemoTags(ctx context.Context, list []*MemoTags) error {
+	if len(list) == 0 {
+		return nil
+	}
+	memoRelatedSetting, err := s.GetWorkspaceMemoRelatedSetting(ctx)
+	if err != nil {
+		return errors.Wrap(err, "failed to get workspace memo related setting")
+	}
+	for _, memoTags := range list {
+		memoTags.Tags = FormatTags(memoTags.Tags, memoRelatedSetting.TagLowercase)
+		memoTags.NormalizedTags = NormalizeTags(memoTags.Tags)
+	}
+	return s.driver.UpdateMemoTags(ctx, list)
+}
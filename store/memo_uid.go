@@ -0,0 +1,77 @@
+package store
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+
+	"github.com/usememos/memos/internal/util"
+)
+
+const (
+	// memoUIDLength is the length of generated memo uids. With 62 symbols it takes
+	// billions of memos before a collision becomes likely.
+	memoUIDLength = 12
+	// maxMemoUIDAttempts is the number of generated uids tried before giving up.
+	maxMemoUIDAttempts = 5
+)
+
+// ErrMemoUIDConflict is returned by the drivers when a memo is created with the uid of another memo.
+var ErrMemoUIDConflict = errors.New("memo uid is taken")
+
+// MemoUIDGenerator generates the short uid of a new memo, which is used in public URLs.
+// The generated uids must match util.UIDMatcher.
+type MemoUIDGenerator func() (string, error)
+
+// GenerateMemoUID is the default MemoUIDGenerator. Like nanoid, it picks random
+// alphanumeric characters from a cryptographically secure source.
+func GenerateMemoUID() (string, error) {
+	return util.RandomString(memoUIDLength)
+}
+
+// SetMemoUIDGenerator replaces the generator of the uids of memos created without one.
+func (s *Store) SetMemoUIDGenerator(generator MemoUIDGenerator) {
+	s.memoUIDGenerator = generator
+}
+
+// generateMemoUID generates a uid that is not taken by another memo yet. The unique
+// index on the uid column still rejects a uid taken in the meantime, see createWithMemoUID.
+func (s *Store) generateMemoUID(ctx context.Context) (string, error) {
+	generator := s.memoUIDGenerator
+	if generator == nil {
+		generator = GenerateMemoUID
+	}
+	for range maxMemoUIDAttempts {
+		uid, err := generator()
+		if err != nil {
+			return "", errors.Wrap(err, "failed to generate uid")
+		}
+		if !util.UIDMatcher.MatchString(uid) {
+			return "", errors.Errorf("generated uid %q is invalid", uid)
+		}
+		memos, err := s.driver.ListMemos(ctx, &FindMemo{UID: &uid, ExcludeContent: true})
+		if err != nil {
+			return "", err
+		}
+		if len(memos) == 0 {
+			return uid, nil
+		}
+	}
+	return "", errors.Errorf("failed to generate a unique uid in %d attempts", maxMemoUIDAttempts)
+}
+
+// createWithMemoUID calls create, which inserts the memo. When its uid was generated, create is
+// retried with another generated uid as long as the uid turns out to be taken by a memo created
+// since generateMemoUID checked it.
+func (s *Store) createWithMemoUID(ctx context.Context, memo *Memo, generated bool, create func() error) error {
+	err := create()
+	for attempt := 1; generated && errors.Is(err, ErrMemoUIDConflict) && attempt < maxMemoUIDAttempts; attempt++ {
+		uid, generateErr := s.generateMemoUID(ctx)
+		if generateErr != nil {
+			return generateErr
+		}
+		memo.UID = uid
+		err = create()
+	}
+	return err
+}
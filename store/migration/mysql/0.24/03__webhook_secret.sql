@@ -0,0 +1,2 @@
+-- Add secret column to sign the webhook requests.
+ALTER TABLE `webhook` ADD COLUMN `secret` TEXT NOT NULL DEFAULT ('');
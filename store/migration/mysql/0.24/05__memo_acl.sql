@@ -0,0 +1,9 @@
+-- Add memo_acl table to share memos with specific users.
+CREATE TABLE `memo_acl` (
+  `memo_id` INT NOT NULL,
+  `user_id` INT NOT NULL,
+  `created_ts` TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  UNIQUE(`memo_id`,`user_id`)
+);
+
+CREATE INDEX `idx_memo_acl_user_id` ON `memo_acl` (`user_id`);
@@ -0,0 +1,10 @@
+-- Add memo_revision table to keep the history of the content of memos.
+CREATE TABLE `memo_revision` (
+  `id` INT NOT NULL AUTO_INCREMENT PRIMARY KEY,
+  `memo_id` INT NOT NULL,
+  `content` TEXT NOT NULL,
+  `editor_id` INT NOT NULL DEFAULT 0,
+  `created_ts` TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX `idx_memo_revision_memo_id` ON `memo_revision` (`memo_id`);
@@ -0,0 +1,2 @@
+-- Add version column to update memos with optimistic concurrency. It is bumped on each update.
+ALTER TABLE `memo` ADD COLUMN `version` INT NOT NULL DEFAULT 0;
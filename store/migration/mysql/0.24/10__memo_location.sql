@@ -0,0 +1,9 @@
+-- Add latitude and longitude columns to find memos by location, filled from the location in the payload.
+ALTER TABLE `memo` ADD COLUMN `latitude` DOUBLE;
+ALTER TABLE `memo` ADD COLUMN `longitude` DOUBLE;
+
+UPDATE `memo`
+SET `latitude` = COALESCE(CAST(JSON_EXTRACT(`payload`, '$.location.latitude') AS DOUBLE), 0), `longitude` = COALESCE(CAST(JSON_EXTRACT(`payload`, '$.location.longitude') AS DOUBLE), 0)
+WHERE JSON_EXTRACT(`payload`, '$.location') IS NOT NULL;
+
+CREATE INDEX `idx_memo_latitude_longitude` ON `memo` (`latitude`, `longitude`);
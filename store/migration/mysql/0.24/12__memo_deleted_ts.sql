@@ -0,0 +1,4 @@
+-- Add deleted_ts column to expire the memos archived by deleting them, but not those archived by users.
+ALTER TABLE `memo` ADD COLUMN `deleted_ts` BIGINT NOT NULL DEFAULT 0;
+
+CREATE INDEX `idx_memo_deleted_ts` ON `memo` (`deleted_ts`);
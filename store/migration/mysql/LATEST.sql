@@ -45,9 +45,21 @@ CREATE TABLE `memo` (
   `content` TEXT NOT NULL,
   `visibility` VARCHAR(256) NOT NULL DEFAULT 'PRIVATE',
   `pinned` BOOLEAN NOT NULL DEFAULT FALSE,
-  `payload` JSON NOT NULL
+  `payload` JSON NOT NULL,
+  `content_hash` VARCHAR(64) NOT NULL DEFAULT '',
+  `content_compressed` BOOLEAN NOT NULL DEFAULT FALSE,
+  `version` INT NOT NULL DEFAULT 0,
+  `latitude` DOUBLE,
+  `longitude` DOUBLE,
+  `deleted_ts` BIGINT NOT NULL DEFAULT 0
 );
 
+CREATE INDEX `idx_memo_creator_id_content_hash` ON `memo` (`creator_id`, `content_hash`);
+
+CREATE INDEX `idx_memo_latitude_longitude` ON `memo` (`latitude`, `longitude`);
+
+CREATE INDEX `idx_memo_deleted_ts` ON `memo` (`deleted_ts`);
+
 -- memo_organizer
 CREATE TABLE `memo_organizer` (
   `memo_id` INT NOT NULL,
@@ -78,9 +90,12 @@ CREATE TABLE `resource` (
   `memo_id` INT DEFAULT NULL,
   `storage_type` VARCHAR(256) NOT NULL DEFAULT '',
   `reference` TEXT NOT NULL DEFAULT (''),
-  `payload` TEXT NOT NULL
+  `payload` TEXT NOT NULL,
+  `content_hash` VARCHAR(64) NOT NULL DEFAULT ''
 );
 
+CREATE INDEX `idx_resource_creator_id_content_hash` ON `resource` (`creator_id`, `content_hash`);
+
 -- activity
 CREATE TABLE `activity` (
   `id` INT NOT NULL AUTO_INCREMENT PRIMARY KEY,
@@ -118,7 +133,8 @@ CREATE TABLE `webhook` (
   `row_status` VARCHAR(256) NOT NULL DEFAULT 'NORMAL',
   `creator_id` INT NOT NULL,
   `name` TEXT NOT NULL,
-  `url` TEXT NOT NULL
+  `url` TEXT NOT NULL,
+  `secret` TEXT NOT NULL DEFAULT ('')
 );
 
 -- reaction
@@ -130,3 +146,43 @@ CREATE TABLE `reaction` (
   `reaction_type` VARCHAR(256) NOT NULL,
   UNIQUE(`creator_id`,`content_id`,`reaction_type`)  
 );
+
+-- memo_idempotency_key
+CREATE TABLE `memo_idempotency_key` (
+  `creator_id` INT NOT NULL,
+  `idempotency_key` VARCHAR(256) NOT NULL,
+  `memo_id` INT NOT NULL DEFAULT 0,
+  `created_ts` TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  PRIMARY KEY(`creator_id`,`idempotency_key`)
+);
+
+CREATE INDEX `idx_memo_idempotency_key_created_ts` ON `memo_idempotency_key` (`created_ts`);
+
+-- memo_acl
+CREATE TABLE `memo_acl` (
+  `memo_id` INT NOT NULL,
+  `user_id` INT NOT NULL,
+  `created_ts` TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  UNIQUE(`memo_id`,`user_id`)
+);
+
+CREATE INDEX `idx_memo_acl_user_id` ON `memo_acl` (`user_id`);
+
+-- memo_revision
+CREATE TABLE `memo_revision` (
+  `id` INT NOT NULL AUTO_INCREMENT PRIMARY KEY,
+  `memo_id` INT NOT NULL,
+  `content` TEXT NOT NULL,
+  `editor_id` INT NOT NULL DEFAULT 0,
+  `created_ts` TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX `idx_memo_revision_memo_id` ON `memo_revision` (`memo_id`);
+
+-- resource_blob
+CREATE TABLE `resource_blob` (
+  `creator_id` INT NOT NULL,
+  `content_hash` VARCHAR(64) NOT NULL,
+  `reference_count` INT NOT NULL DEFAULT 0,
+  PRIMARY KEY(`creator_id`,`content_hash`)
+);
@@ -0,0 +1,2 @@
+-- Add content_compressed column to store the content of large memos compressed. Existing memos stay uncompressed.
+ALTER TABLE memo ADD COLUMN content_compressed BOOLEAN NOT NULL DEFAULT FALSE;
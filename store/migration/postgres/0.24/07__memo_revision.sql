@@ -0,0 +1,10 @@
+-- Add memo_revision table to keep the history of the content of memos.
+CREATE TABLE memo_revision (
+  id SERIAL PRIMARY KEY,
+  memo_id INTEGER NOT NULL,
+  content TEXT NOT NULL DEFAULT '',
+  editor_id INTEGER NOT NULL DEFAULT 0,
+  created_ts BIGINT NOT NULL DEFAULT EXTRACT(EPOCH FROM NOW())
+);
+
+CREATE INDEX idx_memo_revision_memo_id ON memo_revision (memo_id);
@@ -0,0 +1,9 @@
+-- Add latitude and longitude columns to find memos by location, filled from the location in the payload.
+ALTER TABLE memo ADD COLUMN latitude DOUBLE PRECISION;
+ALTER TABLE memo ADD COLUMN longitude DOUBLE PRECISION;
+
+UPDATE memo
+SET latitude = COALESCE((payload->'location'->>'latitude')::DOUBLE PRECISION, 0), longitude = COALESCE((payload->'location'->>'longitude')::DOUBLE PRECISION, 0)
+WHERE payload->'location' IS NOT NULL;
+
+CREATE INDEX idx_memo_latitude_longitude ON memo (latitude, longitude);
@@ -0,0 +1,11 @@
+-- Add content_hash column and resource_blob table to share the blobs of resources with the same content.
+ALTER TABLE resource ADD COLUMN content_hash TEXT NOT NULL DEFAULT '';
+
+CREATE INDEX idx_resource_creator_id_content_hash ON resource (creator_id, content_hash);
+
+CREATE TABLE resource_blob (
+  creator_id INTEGER NOT NULL,
+  content_hash TEXT NOT NULL,
+  reference_count INTEGER NOT NULL DEFAULT 0,
+  PRIMARY KEY(creator_id, content_hash)
+);
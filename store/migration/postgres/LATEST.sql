@@ -26,6 +26,8 @@ CREATE TABLE "user" (
   description TEXT NOT NULL DEFAULT ''
 );
 
+CREATE INDEX idx_user_username_lower ON "user" (LOWER(username));
+
 -- user_setting
 CREATE TABLE user_setting (
   user_id INTEGER NOT NULL,
@@ -45,9 +47,21 @@ CREATE TABLE memo (
   content TEXT NOT NULL,
   visibility TEXT NOT NULL DEFAULT 'PRIVATE',
   pinned BOOLEAN NOT NULL DEFAULT FALSE,
-  payload JSONB NOT NULL DEFAULT '{}'
+  payload JSONB NOT NULL DEFAULT '{}',
+  content_hash TEXT NOT NULL DEFAULT '',
+  content_compressed BOOLEAN NOT NULL DEFAULT FALSE,
+  version INTEGER NOT NULL DEFAULT 0,
+  latitude DOUBLE PRECISION,
+  longitude DOUBLE PRECISION,
+  deleted_ts BIGINT NOT NULL DEFAULT 0
 );
 
+CREATE INDEX idx_memo_creator_id_content_hash ON memo (creator_id, content_hash);
+
+CREATE INDEX idx_memo_latitude_longitude ON memo (latitude, longitude);
+
+CREATE INDEX idx_memo_deleted_ts ON memo (deleted_ts);
+
 -- memo_organizer
 CREATE TABLE memo_organizer (
   memo_id INTEGER NOT NULL,
@@ -78,9 +92,12 @@ CREATE TABLE resource (
   memo_id INTEGER DEFAULT NULL,
   storage_type TEXT NOT NULL DEFAULT '',
   reference TEXT NOT NULL DEFAULT '',
-  payload TEXT NOT NULL DEFAULT '{}'
+  payload TEXT NOT NULL DEFAULT '{}',
+  content_hash TEXT NOT NULL DEFAULT ''
 );
 
+CREATE INDEX idx_resource_creator_id_content_hash ON resource (creator_id, content_hash);
+
 -- activity
 CREATE TABLE activity (
   id SERIAL PRIMARY KEY,
@@ -118,7 +135,8 @@ CREATE TABLE webhook (
   row_status TEXT NOT NULL DEFAULT 'NORMAL',
   creator_id INTEGER NOT NULL,
   name TEXT NOT NULL,
-  url TEXT NOT NULL
+  url TEXT NOT NULL,
+  secret TEXT NOT NULL DEFAULT ''
 );
 
 -- reaction
@@ -130,3 +148,43 @@ CREATE TABLE reaction (
   reaction_type TEXT NOT NULL,
   UNIQUE(creator_id, content_id, reaction_type)
 );
+
+-- memo_idempotency_key
+CREATE TABLE memo_idempotency_key (
+  creator_id INTEGER NOT NULL,
+  idempotency_key TEXT NOT NULL,
+  memo_id INTEGER NOT NULL DEFAULT 0,
+  created_ts BIGINT NOT NULL DEFAULT EXTRACT(EPOCH FROM NOW()),
+  PRIMARY KEY(creator_id, idempotency_key)
+);
+
+CREATE INDEX idx_memo_idempotency_key_created_ts ON memo_idempotency_key (created_ts);
+
+-- memo_acl
+CREATE TABLE memo_acl (
+  memo_id INTEGER NOT NULL,
+  user_id INTEGER NOT NULL,
+  created_ts BIGINT NOT NULL DEFAULT EXTRACT(EPOCH FROM NOW()),
+  UNIQUE(memo_id, user_id)
+);
+
+CREATE INDEX idx_memo_acl_user_id ON memo_acl (user_id);
+
+-- memo_revision
+CREATE TABLE memo_revision (
+  id SERIAL PRIMARY KEY,
+  memo_id INTEGER NOT NULL,
+  content TEXT NOT NULL DEFAULT '',
+  editor_id INTEGER NOT NULL DEFAULT 0,
+  created_ts BIGINT NOT NULL DEFAULT EXTRACT(EPOCH FROM NOW())
+);
+
+CREATE INDEX idx_memo_revision_memo_id ON memo_revision (memo_id);
+
+-- resource_blob
+CREATE TABLE resource_blob (
+  creator_id INTEGER NOT NULL,
+  content_hash TEXT NOT NULL,
+  reference_count INTEGER NOT NULL DEFAULT 0,
+  PRIMARY KEY(creator_id, content_hash)
+);
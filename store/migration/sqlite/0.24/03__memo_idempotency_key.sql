@@ -0,0 +1,10 @@
+-- Add memo_idempotency_key table to deduplicate memo creations.
+CREATE TABLE memo_idempotency_key (
+  creator_id INTEGER NOT NULL,
+  idempotency_key TEXT NOT NULL,
+  memo_id INTEGER NOT NULL DEFAULT 0,
+  created_ts BIGINT NOT NULL DEFAULT (strftime('%s', 'now')),
+  PRIMARY KEY(creator_id, idempotency_key)
+);
+
+CREATE INDEX idx_memo_idempotency_key_created_ts ON memo_idempotency_key (created_ts);
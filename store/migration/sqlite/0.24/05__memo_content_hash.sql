@@ -0,0 +1,4 @@
+-- Add content_hash column to find duplicate memos. Existing memos are hashed when duplicates are first looked for.
+ALTER TABLE memo ADD COLUMN content_hash TEXT NOT NULL DEFAULT '';
+
+CREATE INDEX idx_memo_creator_id_content_hash ON memo (creator_id, content_hash);
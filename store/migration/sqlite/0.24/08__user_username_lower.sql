@@ -0,0 +1,2 @@
+-- Add an index on the lowercased username to find the memos of a user by username ignoring case.
+CREATE INDEX idx_user_username_lower ON user (LOWER(username));
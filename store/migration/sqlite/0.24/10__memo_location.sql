@@ -0,0 +1,9 @@
+-- Add latitude and longitude columns to find memos by location, filled from the location in the payload.
+ALTER TABLE memo ADD COLUMN latitude REAL;
+ALTER TABLE memo ADD COLUMN longitude REAL;
+
+UPDATE memo
+SET latitude = COALESCE(json_extract(payload, '$.location.latitude'), 0), longitude = COALESCE(json_extract(payload, '$.location.longitude'), 0)
+WHERE json_extract(payload, '$.location') IS NOT NULL;
+
+CREATE INDEX idx_memo_latitude_longitude ON memo (latitude, longitude);
@@ -29,6 +29,8 @@ CREATE TABLE user (
 
 CREATE INDEX idx_user_username ON user (username);
 
+CREATE INDEX idx_user_username_lower ON user (LOWER(username));
+
 -- user_setting
 CREATE TABLE user_setting (
   user_id INTEGER NOT NULL,
@@ -48,11 +50,23 @@ CREATE TABLE memo (
   content TEXT NOT NULL DEFAULT '',
   visibility TEXT NOT NULL CHECK (visibility IN ('PUBLIC', 'PROTECTED', 'PRIVATE')) DEFAULT 'PRIVATE',
   pinned INTEGER NOT NULL CHECK (pinned IN (0, 1)) DEFAULT 0,
-  payload TEXT NOT NULL DEFAULT '{}'
+  payload TEXT NOT NULL DEFAULT '{}',
+  content_hash TEXT NOT NULL DEFAULT '',
+  content_compressed INTEGER NOT NULL CHECK (content_compressed IN (0, 1)) DEFAULT 0,
+  version INTEGER NOT NULL DEFAULT 0,
+  latitude REAL,
+  longitude REAL,
+  deleted_ts BIGINT NOT NULL DEFAULT 0
 );
 
 CREATE INDEX idx_memo_creator_id ON memo (creator_id);
 
+CREATE INDEX idx_memo_creator_id_content_hash ON memo (creator_id, content_hash);
+
+CREATE INDEX idx_memo_latitude_longitude ON memo (latitude, longitude);
+
+CREATE INDEX idx_memo_deleted_ts ON memo (deleted_ts);
+
 -- memo_organizer
 CREATE TABLE memo_organizer (
   memo_id INTEGER NOT NULL,
@@ -83,13 +97,16 @@ CREATE TABLE resource (
   memo_id INTEGER,
   storage_type TEXT NOT NULL DEFAULT '',
   reference TEXT NOT NULL DEFAULT '',
-  payload TEXT NOT NULL DEFAULT '{}'
+  payload TEXT NOT NULL DEFAULT '{}',
+  content_hash TEXT NOT NULL DEFAULT ''
 );
 
 CREATE INDEX idx_resource_creator_id ON resource (creator_id);
 
 CREATE INDEX idx_resource_memo_id ON resource (memo_id);
 
+CREATE INDEX idx_resource_creator_id_content_hash ON resource (creator_id, content_hash);
+
 -- activity
 CREATE TABLE activity (
   id INTEGER PRIMARY KEY AUTOINCREMENT,
@@ -127,7 +144,8 @@ CREATE TABLE webhook (
   row_status TEXT NOT NULL CHECK (row_status IN ('NORMAL', 'ARCHIVED')) DEFAULT 'NORMAL',
   creator_id INTEGER NOT NULL,
   name TEXT NOT NULL,
-  url TEXT NOT NULL
+  url TEXT NOT NULL,
+  secret TEXT NOT NULL DEFAULT ''
 );
 
 CREATE INDEX idx_webhook_creator_id ON webhook (creator_id);
@@ -141,3 +159,43 @@ CREATE TABLE reaction (
   reaction_type TEXT NOT NULL,
   UNIQUE(creator_id, content_id, reaction_type)
 );
+
+-- memo_idempotency_key
+CREATE TABLE memo_idempotency_key (
+  creator_id INTEGER NOT NULL,
+  idempotency_key TEXT NOT NULL,
+  memo_id INTEGER NOT NULL DEFAULT 0,
+  created_ts BIGINT NOT NULL DEFAULT (strftime('%s', 'now')),
+  PRIMARY KEY(creator_id, idempotency_key)
+);
+
+CREATE INDEX idx_memo_idempotency_key_created_ts ON memo_idempotency_key (created_ts);
+
+-- memo_acl
+CREATE TABLE memo_acl (
+  memo_id INTEGER NOT NULL,
+  user_id INTEGER NOT NULL,
+  created_ts BIGINT NOT NULL DEFAULT (strftime('%s', 'now')),
+  UNIQUE(memo_id, user_id)
+);
+
+CREATE INDEX idx_memo_acl_user_id ON memo_acl (user_id);
+
+-- memo_revision
+CREATE TABLE memo_revision (
+  id INTEGER PRIMARY KEY AUTOINCREMENT,
+  memo_id INTEGER NOT NULL,
+  content TEXT NOT NULL DEFAULT '',
+  editor_id INTEGER NOT NULL DEFAULT 0,
+  created_ts BIGINT NOT NULL DEFAULT (strftime('%s', 'now'))
+);
+
+CREATE INDEX idx_memo_revision_memo_id ON memo_revision (memo_id);
+
+-- resource_blob
+CREATE TABLE resource_blob (
+  creator_id INTEGER NOT NULL,
+  content_hash TEXT NOT NULL,
+  reference_count INTEGER NOT NULL DEFAULT 0,
+  PRIMARY KEY(creator_id, content_hash)
+);
@@ -33,8 +33,16 @@ const (
 	LatestSchemaFileName = "LATEST.sql"
 )
 
-// Migrate applies the latest schema to the database.
+// ErrSchemaVersionTooNew is returned by Migrate when the database was migrated by a newer
+// binary, whose schema this binary does not know.
+var ErrSchemaVersionTooNew = errors.New("database schema version is newer than the binary supports")
+
+// Migrate applies the latest schema to the database. It refuses to touch a database whose
+// schema is newer than the binary's, see ErrSchemaVersionTooNew.
 func (s *Store) Migrate(ctx context.Context) error {
+	if err := s.checkSchemaVersion(ctx); err != nil {
+		return err
+	}
 	if err := s.preMigrate(ctx); err != nil {
 		return errors.Wrap(err, "failed to pre-migrate")
 	}
@@ -116,6 +124,38 @@ func (s *Store) Migrate(ctx context.Context) error {
 	return nil
 }
 
+// checkSchemaVersion returns ErrSchemaVersionTooNew if the schema version recorded in the
+// database, by the migration history or the workspace basic setting, is greater than the
+// schema version of the binary. A database without migration history is a new one.
+func (s *Store) checkSchemaVersion(ctx context.Context) error {
+	migrationHistoryList, err := s.driver.FindMigrationHistoryList(ctx, &FindMigrationHistory{})
+	if err != nil || len(migrationHistoryList) == 0 {
+		return nil
+	}
+	recordedVersions := []string{}
+	for _, migrationHistory := range migrationHistoryList {
+		recordedVersions = append(recordedVersions, migrationHistory.Version)
+	}
+	workspaceBasicSetting, err := s.GetWorkspaceBasicSetting(ctx)
+	if err != nil {
+		return errors.Wrap(err, "failed to get workspace basic setting")
+	}
+	if workspaceBasicSetting.SchemaVersion != "" {
+		recordedVersions = append(recordedVersions, workspaceBasicSetting.SchemaVersion)
+	}
+	sort.Sort(version.SortVersion(recordedVersions))
+	recordedVersion := recordedVersions[len(recordedVersions)-1]
+
+	schemaVersion, err := s.GetCurrentSchemaVersion()
+	if err != nil {
+		return errors.Wrap(err, "failed to get current schema version")
+	}
+	if version.IsVersionGreaterThan(recordedVersion, schemaVersion) {
+		return errors.Wrapf(ErrSchemaVersionTooNew, "database schema version %s, binary schema version %s", recordedVersion, schemaVersion)
+	}
+	return nil
+}
+
 func (s *Store) preMigrate(ctx context.Context) error {
 	// TODO: using schema version in basic setting instead of migration history.
 	migrationHistoryList, err := s.driver.FindMigrationHistoryList(ctx, &FindMigrationHistory{})
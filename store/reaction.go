@@ -2,6 +2,7 @@ package store
 
 import (
 	"context"
+	"fmt"
 )
 
 type Reaction struct {
@@ -18,6 +19,17 @@ type FindReaction struct {
 	ID        *int32
 	CreatorID *int32
 	ContentID *string
+	// ContentIDList finds the reactions to any of the contents.
+	ContentIDList []string
+}
+
+// ReactionSummary is the number of reactions of one type to a content.
+type ReactionSummary struct {
+	ContentID    string
+	ReactionType string
+	Count        int32
+	// ViewerReacted is whether the viewer the summary is listed for is one of the reactors.
+	ViewerReacted bool
 }
 
 type DeleteReaction struct {
@@ -32,6 +44,18 @@ func (s *Store) ListReactions(ctx context.Context, find *FindReaction) ([]*React
 	return s.driver.ListReactions(ctx, find)
 }
 
+// ListReactionSummaries returns the reactions to the given contents grouped by content and
+// reaction type, in the order each reaction type was first used on a content.
+func (s *Store) ListReactionSummaries(ctx context.Context, contentIDList []string, viewerID *int32) ([]*ReactionSummary, error) {
+	return s.driver.ListReactionSummaries(ctx, contentIDList, viewerID)
+}
+
 func (s *Store) DeleteReaction(ctx context.Context, delete *DeleteReaction) error {
 	return s.driver.DeleteReaction(ctx, delete)
 }
+
+// getMemoReactionContentID returns the content id of the reactions to the memo, which is
+// the name of the memo in the API.
+func getMemoReactionContentID(memo *Memo) string {
+	return fmt.Sprintf("memos/%s", memo.UID)
+}
@@ -32,6 +32,10 @@ type Resource struct {
 	StorageType storepb.ResourceStorageType
 	Reference   string
 	Payload     *storepb.ResourcePayload
+	// ContentHash is the hash of the blob of a resource stored as a local file or s3 object, see
+	// HashResourceBlob. Resources of the same creator with the same hash share the blob, which
+	// is only deleted with the last of them. Empty when the blob is not shared.
+	ContentHash string
 
 	// The related memo ID.
 	MemoID *int32
@@ -46,9 +50,11 @@ type FindResource struct {
 	FilenameSearch *string
 	MemoID         *int32
 	HasRelatedMemo bool
-	StorageType    *storepb.ResourceStorageType
-	Limit          *int
-	Offset         *int
+	// Orphaned finds the resources related to no memo whose creator no longer exists.
+	Orphaned    bool
+	StorageType *storepb.ResourceStorageType
+	Limit       *int
+	Offset      *int
 }
 
 type UpdateResource struct {
@@ -66,6 +72,8 @@ type DeleteResource struct {
 	MemoID *int32
 }
 
+// CreateResource creates the resource. A resource with a content hash takes a reference to its
+// blob, see CreateDuplicateResource.
 func (s *Store) CreateResource(ctx context.Context, create *Resource) (*Resource, error) {
 	if !util.UIDMatcher.MatchString(create.UID) {
 		return nil, errors.New("invalid uid")
@@ -105,51 +113,85 @@ func (s *Store) DeleteResource(ctx context.Context, delete *DeleteResource) erro
 	if resource == nil {
 		return errors.New("resource not found")
 	}
+	if resource.ContentHash != "" {
+		return s.deleteSharedResource(ctx, resource)
+	}
 
 	if resource.StorageType == storepb.ResourceStorageType_LOCAL {
-		if err := func() error {
-			p := filepath.FromSlash(resource.Reference)
-			if !filepath.IsAbs(p) {
-				p = filepath.Join(s.Profile.Data, p)
-			}
-			err := os.Remove(p)
-			if err != nil {
-				return errors.Wrap(err, "failed to delete local file")
-			}
-			return nil
-		}(); err != nil {
+		if err := s.deleteResourceBlob(ctx, resource); err != nil {
 			return errors.Wrap(err, "failed to delete local file")
 		}
 	} else if resource.StorageType == storepb.ResourceStorageType_S3 {
-		if err := func() error {
-			s3ObjectPayload := resource.Payload.GetS3Object()
-			if s3ObjectPayload == nil {
-				return errors.Errorf("No s3 object found")
-			}
-			workspaceStorageSetting, err := s.GetWorkspaceStorageSetting(ctx)
-			if err != nil {
-				return errors.Wrap(err, "failed to get workspace storage setting")
-			}
-			s3Config := s3ObjectPayload.S3Config
-			if s3Config == nil {
-				if workspaceStorageSetting.S3Config == nil {
-					return errors.Errorf("S3 config is not found")
-				}
-				s3Config = workspaceStorageSetting.S3Config
-			}
+		if err := s.deleteResourceBlob(ctx, resource); err != nil {
+			slog.Warn("Failed to delete s3 object", slog.Any("err", err))
+		}
+	}
+
+	return s.driver.DeleteResource(ctx, delete)
+}
 
-			s3Client, err := s3.NewClient(ctx, s3Config)
-			if err != nil {
-				return errors.Wrap(err, "Failed to create s3 client")
+// VacuumResources deletes the resources related to no memo whose creator no longer exists.
+// Deleting the local files and s3 objects is best-effort, so that a missing file does not
+// keep the resource row.
+func (s *Store) VacuumResources(ctx context.Context) error {
+	resources, err := s.ListResources(ctx, &FindResource{Orphaned: true})
+	if err != nil {
+		return errors.Wrap(err, "failed to list orphaned resources")
+	}
+
+	for _, resource := range resources {
+		if resource.ContentHash == "" {
+			if err := s.deleteResourceBlob(ctx, resource); err != nil {
+				slog.Warn("Failed to delete orphaned resource blob", slog.Int("id", int(resource.ID)), slog.Any("err", err))
 			}
-			if err := s3Client.DeleteObject(ctx, s3ObjectPayload.Key); err != nil {
-				return errors.Wrap(err, "Failed to delete s3 object")
+			if err := s.driver.DeleteResource(ctx, &DeleteResource{ID: resource.ID}); err != nil {
+				return errors.Wrapf(err, "failed to delete orphaned resource %d", resource.ID)
 			}
-			return nil
-		}(); err != nil {
-			slog.Warn("Failed to delete s3 object", slog.Any("err", err))
+		} else if err := s.deleteSharedResource(ctx, resource); err != nil {
+			return errors.Wrapf(err, "failed to delete orphaned resource %d", resource.ID)
 		}
+		slog.Info("Deleted orphaned resource", slog.Int("id", int(resource.ID)), slog.String("filename", resource.Filename), slog.Int("creator", int(resource.CreatorID)))
 	}
+	return nil
+}
 
-	return s.driver.DeleteResource(ctx, delete)
+// deleteResourceBlob deletes the local file or s3 object of the resource.
+// Resources stored in the database have no blob to delete.
+func (s *Store) deleteResourceBlob(ctx context.Context, resource *Resource) error {
+	if resource.StorageType == storepb.ResourceStorageType_LOCAL {
+		p := filepath.FromSlash(resource.Reference)
+		if !filepath.IsAbs(p) {
+			p = filepath.Join(s.Profile.Data, p)
+		}
+		err := os.Remove(p)
+		if err != nil {
+			return errors.Wrap(err, "failed to delete local file")
+		}
+		return nil
+	} else if resource.StorageType == storepb.ResourceStorageType_S3 {
+		s3ObjectPayload := resource.Payload.GetS3Object()
+		if s3ObjectPayload == nil {
+			return errors.Errorf("No s3 object found")
+		}
+		workspaceStorageSetting, err := s.GetWorkspaceStorageSetting(ctx)
+		if err != nil {
+			return errors.Wrap(err, "failed to get workspace storage setting")
+		}
+		s3Config := s3ObjectPayload.S3Config
+		if s3Config == nil {
+			if workspaceStorageSetting.S3Config == nil {
+				return errors.Errorf("S3 config is not found")
+			}
+			s3Config = workspaceStorageSetting.S3Config
+		}
+
+		s3Client, err := s3.NewClient(ctx, s3Config)
+		if err != nil {
+			return errors.Wrap(err, "Failed to create s3 client")
+		}
+		if err := s3Client.DeleteObject(ctx, s3ObjectPayload.Key); err != nil {
+			return errors.Wrap(err, "Failed to delete s3 object")
+		}
+	}
+	return nil
 }
@@ -0,0 +1,49 @@
+package store
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"log/slog"
+
+	"github.com/pkg/errors"
+
+	"github.com/usememos/memos/internal/util"
+)
+
+// HashResourceBlob returns the content hash of a resource, the hex SHA-256 of its blob.
+func HashResourceBlob(blob []byte) string {
+	hash := sha256.Sum256(blob)
+	return hex.EncodeToString(hash[:])
+}
+
+// CreateDuplicateResource creates the resource without a blob of its own, sharing the local file or
+// s3 object of a resource of the same creator with the same content hash, and takes a reference to
+// it. It returns nil when there is no such resource, or the content hash is empty, in which case the
+// blob is to be saved and the resource created with CreateResource.
+func (s *Store) CreateDuplicateResource(ctx context.Context, create *Resource) (*Resource, error) {
+	if create.ContentHash == "" {
+		return nil, nil
+	}
+	if !util.UIDMatcher.MatchString(create.UID) {
+		return nil, errors.New("invalid uid")
+	}
+	return s.driver.CreateDuplicateResource(ctx, create)
+}
+
+// deleteSharedResource deletes the resource with a content hash and releases its reference to the
+// shared blob in one transaction. The blob is deleted once the last reference is released and no
+// other resource uses it, which resources transferred to other users may. Deleting the local file or
+// s3 object is best-effort, as the resource is already deleted.
+func (s *Store) deleteSharedResource(ctx context.Context, resource *Resource) error {
+	released, err := s.driver.DeleteSharedResource(ctx, resource)
+	if err != nil {
+		return errors.Wrap(err, "failed to delete shared resource")
+	}
+	if released {
+		if err := s.deleteResourceBlob(ctx, resource); err != nil {
+			slog.Warn("Failed to delete shared resource blob", slog.Int("id", int(resource.ID)), slog.Any("err", err))
+		}
+	}
+	return nil
+}
@@ -14,6 +14,16 @@ type Store struct {
 	userCache             sync.Map // map[int]*User
 	userSettingCache      sync.Map // map[string]*storepb.UserSetting
 	idpCache              sync.Map // map[int]*storepb.IdentityProvider
+
+	// accessTokensMutex serializes the updates of access tokens in user settings, so that the
+	// cached settings are written in the order of the updates.
+	accessTokensMutex sync.Mutex
+
+	// memoUIDGenerator generates the uids of memos created without one, GenerateMemoUID if nil.
+	memoUIDGenerator MemoUIDGenerator
+
+	eventListenersMutex sync.RWMutex
+	eventListeners      []StoreEventListener
 }
 
 // New creates a new instance of Store.
@@ -0,0 +1,77 @@
+package teststore
+
+import (
+	"context"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+
+	"github.com/usememos/memos/store"
+)
+
+type testingEventListener struct {
+	events []store.StoreEvent
+	err    error
+}
+
+func (l *testingEventListener) OnStoreEvent(_ context.Context, event store.StoreEvent) error {
+	l.events = append(l.events, event)
+	return l.err
+}
+
+func TestStoreEvents(t *testing.T) {
+	ctx := context.Background()
+	ts := NewTestingStore(ctx, t)
+	user, err := createTestingHostUser(ctx, ts)
+	require.NoError(t, err)
+	listener := &testingEventListener{}
+	ts.AddEventListener(listener)
+
+	memo, err := ts.CreateMemo(ctx, &store.Memo{UID: "memo", CreatorID: user.ID, Content: "memo", Visibility: store.Public})
+	require.NoError(t, err)
+	content := "memo v2"
+	require.NoError(t, ts.UpdateMemo(ctx, &store.UpdateMemo{ID: memo.ID, Content: &content, EditorID: user.ID}))
+	require.NoError(t, ts.DeleteMemo(ctx, &store.DeleteMemo{ID: memo.ID}))
+	require.NoError(t, ts.DeleteMemo(ctx, &store.DeleteMemo{ID: memo.ID, Hard: true}))
+	require.Len(t, listener.events, 4)
+	created, ok := listener.events[0].(*store.MemoCreated)
+	require.True(t, ok)
+	require.Equal(t, memo.ID, created.Memo.ID)
+	require.Equal(t, []store.StoreEvent{
+		&store.MemoUpdated{ID: memo.ID},
+		&store.MemoDeleted{ID: memo.ID},
+		&store.MemoDeleted{ID: memo.ID, Hard: true},
+	}, listener.events[1:])
+
+	// Rejected or rolled back writes emit nothing.
+	listener.events = nil
+	memo, err = ts.CreateMemo(ctx, &store.Memo{UID: "other", CreatorID: user.ID, Content: "other", Visibility: store.Public})
+	require.NoError(t, err)
+	staleVersion := memo.Version + 1
+	err = ts.UpdateMemo(ctx, &store.UpdateMemo{ID: memo.ID, Content: &content, EditorID: user.ID, ExpectedVersion: &staleVersion})
+	require.ErrorIs(t, err, store.ErrMemoVersionConflict)
+	invalidUID := "invalid uid"
+	require.Error(t, ts.UpdateMemo(ctx, &store.UpdateMemo{ID: memo.ID, UID: &invalidUID}))
+	require.Len(t, listener.events, 1)
+	// The revision is inserted after the memo is updated, in the same transaction.
+	db := ts.GetDriver().GetDB()
+	_, err = db.ExecContext(ctx, "CREATE TRIGGER reject_revision BEFORE INSERT ON memo_revision BEGIN SELECT RAISE(ABORT, 'revision rejected'); END")
+	require.NoError(t, err)
+	err = ts.UpdateMemo(ctx, &store.UpdateMemo{ID: memo.ID, Content: &content, EditorID: user.ID})
+	require.ErrorContains(t, err, "revision rejected")
+	_, err = db.ExecContext(ctx, "DROP TRIGGER reject_revision")
+	require.NoError(t, err)
+	require.Len(t, listener.events, 1)
+	found, err := ts.GetMemo(ctx, &store.FindMemo{ID: &memo.ID})
+	require.NoError(t, err)
+	require.Equal(t, "other", found.Content)
+	require.Equal(t, memo.Version, found.Version)
+
+	// A failing listener does not fail the write.
+	listener.events, listener.err = nil, errors.New("listener failed")
+	_, err = ts.CreateMemo(ctx, &store.Memo{UID: "another", CreatorID: user.ID, Content: "another", Visibility: store.Public})
+	require.NoError(t, err)
+	require.Len(t, listener.events, 1)
+	ts.Close()
+}
@@ -0,0 +1,104 @@
+package teststore
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/usememos/memos/store"
+)
+
+func TestMemoACL(t *testing.T) {
+	ctx := context.Background()
+	ts := NewTestingStore(ctx, t)
+	user, err := createTestingHostUser(ctx, ts)
+	require.NoError(t, err)
+	viewer, err := ts.CreateUser(ctx, &store.User{Username: "viewer", Role: store.RoleUser, Email: "viewer@test.com"})
+	require.NoError(t, err)
+	other, err := ts.CreateUser(ctx, &store.User{Username: "other", Role: store.RoleUser, Email: "other@test.com"})
+	require.NoError(t, err)
+
+	privateMemo, err := ts.CreateMemo(ctx, &store.Memo{UID: "private-memo", CreatorID: user.ID, Content: "private", Visibility: store.Private})
+	require.NoError(t, err)
+	publicMemo, err := ts.CreateMemo(ctx, &store.Memo{UID: "public-memo", CreatorID: user.ID, Content: "public", Visibility: store.Public})
+	require.NoError(t, err)
+	viewerMemo, err := ts.CreateMemo(ctx, &store.Memo{UID: "viewer-memo", CreatorID: viewer.ID, Content: "own", Visibility: store.Private})
+	require.NoError(t, err)
+
+	listVisibleMemoIDs := func(userID int32) []int32 {
+		memos, err := ts.ListMemos(ctx, &store.FindMemo{
+			VisibilityList:  []store.Visibility{store.Public},
+			VisibleToUserID: &userID,
+			OrderByTimeAsc:  true,
+		})
+		require.NoError(t, err)
+		memoIDs := []int32{}
+		for _, memo := range memos {
+			memoIDs = append(memoIDs, memo.ID)
+		}
+		return memoIDs
+	}
+	require.ElementsMatch(t, []int32{publicMemo.ID, viewerMemo.ID}, listVisibleMemoIDs(viewer.ID))
+
+	// The private memo is visible to the viewer once shared with them, and to nobody else.
+	memoACL, err := ts.UpsertMemoACL(ctx, &store.MemoACL{MemoID: privateMemo.ID, UserID: viewer.ID})
+	require.NoError(t, err)
+	require.Equal(t, privateMemo.ID, memoACL.MemoID)
+	require.Equal(t, viewer.ID, memoACL.UserID)
+	regranted, err := ts.UpsertMemoACL(ctx, &store.MemoACL{MemoID: privateMemo.ID, UserID: viewer.ID})
+	require.NoError(t, err)
+	require.Equal(t, memoACL.CreatedTs, regranted.CreatedTs)
+	require.ElementsMatch(t, []int32{privateMemo.ID, publicMemo.ID, viewerMemo.ID}, listVisibleMemoIDs(viewer.ID))
+	require.ElementsMatch(t, []int32{publicMemo.ID}, listVisibleMemoIDs(other.ID))
+	count, err := ts.CountMemos(ctx, &store.FindMemo{VisibilityList: []store.Visibility{store.Public}, VisibleToUserID: &viewer.ID})
+	require.NoError(t, err)
+	require.Equal(t, 3, count)
+
+	memoACLs, err := ts.ListMemoACLs(ctx, &store.FindMemoACL{MemoID: &privateMemo.ID})
+	require.NoError(t, err)
+	require.Len(t, memoACLs, 1)
+
+	// Revoking the grant hides the memo again.
+	require.NoError(t, ts.DeleteMemoACL(ctx, &store.DeleteMemoACL{MemoID: &privateMemo.ID, UserID: &viewer.ID}))
+	require.ElementsMatch(t, []int32{publicMemo.ID, viewerMemo.ID}, listVisibleMemoIDs(viewer.ID))
+	require.Error(t, ts.DeleteMemoACL(ctx, &store.DeleteMemoACL{}))
+
+	// Deleting the memo permanently deletes its grants.
+	_, err = ts.UpsertMemoACL(ctx, &store.MemoACL{MemoID: privateMemo.ID, UserID: other.ID})
+	require.NoError(t, err)
+	require.NoError(t, ts.DeleteMemo(ctx, &store.DeleteMemo{ID: privateMemo.ID, Hard: true}))
+	memoACLs, err = ts.ListMemoACLs(ctx, &store.FindMemoACL{UserID: &other.ID})
+	require.NoError(t, err)
+	require.Empty(t, memoACLs)
+	ts.Close()
+}
+
+func TestMemoListWithRelatedMemosSharedWithViewer(t *testing.T) {
+	ctx := context.Background()
+	ts := NewTestingStore(ctx, t)
+	user, err := createTestingHostUser(ctx, ts)
+	require.NoError(t, err)
+	viewer, err := ts.CreateUser(ctx, &store.User{Username: "viewer", Role: store.RoleUser, Email: "viewer@test.com"})
+	require.NoError(t, err)
+
+	memo, err := ts.CreateMemo(ctx, &store.Memo{UID: "memo", CreatorID: user.ID, Content: "memo", Visibility: store.Public})
+	require.NoError(t, err)
+	privateMemo, err := ts.CreateMemo(ctx, &store.Memo{UID: "private-memo", CreatorID: user.ID, Content: "private", Visibility: store.Private})
+	require.NoError(t, err)
+	_, err = ts.UpsertMemoRelation(ctx, &store.MemoRelation{MemoID: memo.ID, RelatedMemoID: privateMemo.ID, Type: store.MemoRelationReference})
+	require.NoError(t, err)
+
+	getRelatedMemos := func() []*store.Memo {
+		found, err := ts.GetMemo(ctx, &store.FindMemo{ID: &memo.ID, IncludeRelatedMemos: true, ViewerID: &viewer.ID})
+		require.NoError(t, err)
+		return found.RelatedMemos
+	}
+	require.Empty(t, getRelatedMemos())
+	_, err = ts.UpsertMemoACL(ctx, &store.MemoACL{MemoID: privateMemo.ID, UserID: viewer.ID})
+	require.NoError(t, err)
+	relatedMemos := getRelatedMemos()
+	require.Len(t, relatedMemos, 1)
+	require.Equal(t, privateMemo.ID, relatedMemos[0].ID)
+	ts.Close()
+}
@@ -0,0 +1,153 @@
+package teststore
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	storepb "github.com/usememos/memos/proto/gen/store"
+	"github.com/usememos/memos/store"
+)
+
+func TestMemoContentCompression(t *testing.T) {
+	ctx := context.Background()
+	ts := NewTestingStore(ctx, t)
+	user, err := createTestingHostUser(ctx, ts)
+	require.NoError(t, err)
+	getStoredContent := func(memoID int32) (string, bool) {
+		var content string
+		var compressed bool
+		require.NoError(t, ts.GetDriver().GetDB().QueryRowContext(ctx, "SELECT content, content_compressed FROM memo WHERE id = ?", memoID).Scan(&content, &compressed))
+		return content, compressed
+	}
+	getContent := func(memoID int32) string {
+		memo, err := ts.GetMemo(ctx, &store.FindMemo{ID: &memoID})
+		require.NoError(t, err)
+		return memo.Content
+	}
+
+	// Without a threshold, nothing is compressed.
+	large := strings.Repeat("a large memo ", 100)
+	existing, err := ts.CreateMemo(ctx, &store.Memo{UID: "existing", CreatorID: user.ID, Content: large, Visibility: store.Private})
+	require.NoError(t, err)
+	_, compressed := getStoredContent(existing.ID)
+	require.False(t, compressed)
+
+	_, err = ts.UpsertWorkspaceSetting(ctx, &storepb.WorkspaceSetting{
+		Key: storepb.WorkspaceSettingKey_MEMO_RELATED,
+		Value: &storepb.WorkspaceSetting_MemoRelatedSetting{
+			MemoRelatedSetting: &storepb.WorkspaceMemoRelatedSetting{ContentCompressionThreshold: 256},
+		},
+	})
+	require.NoError(t, err)
+
+	// Existing rows stay as they are and are read as before.
+	storedContent, compressed := getStoredContent(existing.ID)
+	require.False(t, compressed)
+	require.Equal(t, large, storedContent)
+	require.Equal(t, large, getContent(existing.ID))
+
+	small, err := ts.CreateMemo(ctx, &store.Memo{UID: "small", CreatorID: user.ID, Content: "a small memo", Visibility: store.Private})
+	require.NoError(t, err)
+	storedContent, compressed = getStoredContent(small.ID)
+	require.False(t, compressed)
+	require.Equal(t, "a small memo", storedContent)
+
+	memo, err := ts.CreateMemo(ctx, &store.Memo{UID: "large", CreatorID: user.ID, Content: large, Visibility: store.Private})
+	require.NoError(t, err)
+	require.Equal(t, large, memo.Content)
+	storedContent, compressed = getStoredContent(memo.ID)
+	require.True(t, compressed)
+	require.Less(t, len(storedContent), len(large))
+	require.Equal(t, large, getContent(memo.ID))
+
+	// The content is hashed before it is compressed.
+	clusters, err := ts.FindDuplicateMemos(ctx, user.ID)
+	require.NoError(t, err)
+	require.Equal(t, [][]int32{{existing.ID, memo.ID}}, clusters)
+
+	// Shrinking a memo stores it uncompressed, and growing it compresses it again.
+	content := "shrunk"
+	require.NoError(t, ts.UpdateMemo(ctx, &store.UpdateMemo{ID: memo.ID, Content: &content}))
+	storedContent, compressed = getStoredContent(memo.ID)
+	require.False(t, compressed)
+	require.Equal(t, "shrunk", storedContent)
+	require.NoError(t, ts.UpdateMemo(ctx, &store.UpdateMemo{ID: memo.ID, Content: &large}))
+	_, compressed = getStoredContent(memo.ID)
+	require.True(t, compressed)
+
+	// Updates of other fields keep the content as stored.
+	pinned := true
+	require.NoError(t, ts.UpdateMemo(ctx, &store.UpdateMemo{ID: memo.ID, Pinned: &pinned}))
+	_, compressed = getStoredContent(memo.ID)
+	require.True(t, compressed)
+	memos, err := ts.ListMemos(ctx, &store.FindMemo{CreatorID: &user.ID})
+	require.NoError(t, err)
+	require.Len(t, memos, 3)
+	for _, memo := range memos {
+		require.Contains(t, []string{large, "a small memo"}, memo.Content)
+	}
+	ts.Close()
+}
+
+func TestMemoContentCompressionSearch(t *testing.T) {
+	ctx := context.Background()
+	ts := NewTestingStore(ctx, t)
+	user, err := createTestingHostUser(ctx, ts)
+	require.NoError(t, err)
+	_, err = ts.UpsertWorkspaceSetting(ctx, &storepb.WorkspaceSetting{
+		Key: storepb.WorkspaceSettingKey_MEMO_RELATED,
+		Value: &storepb.WorkspaceSetting_MemoRelatedSetting{
+			MemoRelatedSetting: &storepb.WorkspaceMemoRelatedSetting{ContentCompressionThreshold: 256},
+		},
+	})
+	require.NoError(t, err)
+	large := strings.Repeat("a large memo ", 100) + "needle"
+	compressed, err := ts.CreateMemo(ctx, &store.Memo{UID: "compressed", CreatorID: user.ID, Content: large, Visibility: store.Private})
+	require.NoError(t, err)
+	uncompressed, err := ts.CreateMemo(ctx, &store.Memo{UID: "uncompressed", CreatorID: user.ID, Content: "a small needle", Visibility: store.Private})
+	require.NoError(t, err)
+	_, err = ts.CreateMemo(ctx, &store.Memo{UID: "other", CreatorID: user.ID, Content: "a small memo", Visibility: store.Private})
+	require.NoError(t, err)
+	listMemoIDs := func(find *store.FindMemo) []int32 {
+		find.CreatorID = &user.ID
+		memos, err := ts.ListMemos(ctx, find)
+		require.NoError(t, err)
+		ids := []int32{}
+		for _, memo := range memos {
+			ids = append(ids, memo.ID)
+		}
+		return ids
+	}
+	filter := func(filter string) *store.FindMemo {
+		return &store.FindMemo{Filter: &filter}
+	}
+
+	require.ElementsMatch(t, []int32{compressed.ID, uncompressed.ID}, listMemoIDs(&store.FindMemo{ContentSearch: []string{"NEEDLE"}}))
+	require.ElementsMatch(t, []int32{compressed.ID}, listMemoIDs(&store.FindMemo{ContentSearch: []string{"large", "needle"}}))
+	require.ElementsMatch(t, []int32{compressed.ID, uncompressed.ID}, listMemoIDs(filter(`content.contains("needle")`)))
+	require.ElementsMatch(t, []int32{compressed.ID}, listMemoIDs(filter(`content.contains("large memo")`)))
+	require.NotContains(t, listMemoIDs(filter(`!content.contains("needle")`)), compressed.ID)
+	require.ElementsMatch(t, []int32{compressed.ID}, listMemoIDs(filter(fmt.Sprintf("content == %q", large))))
+	require.NotContains(t, listMemoIDs(filter(fmt.Sprintf("content != %q", large))), compressed.ID)
+	require.Contains(t, listMemoIDs(filter(`content != "a small memo"`)), compressed.ID)
+	count, err := ts.CountMemos(ctx, &store.FindMemo{CreatorID: &user.ID, ContentSearch: []string{"needle"}})
+	require.NoError(t, err)
+	require.Equal(t, 2, count)
+	ts.Close()
+}
+
+func TestCompressMemoContent(t *testing.T) {
+	for _, content := range []string{"", "memo", strings.Repeat("# Heading\n\n- [ ] 任务 🚀\n", 1000)} {
+		compressed, err := store.CompressMemoContent(content)
+		require.NoError(t, err)
+		decompressed, err := store.DecompressMemoContent(compressed)
+		require.NoError(t, err)
+		require.Equal(t, content, decompressed)
+	}
+	_, err := store.DecompressMemoContent("not compressed")
+	require.Error(t, err)
+}
@@ -0,0 +1,50 @@
+package teststore
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/usememos/memos/store"
+)
+
+func TestListMemoDayCounts(t *testing.T) {
+	ctx := context.Background()
+	ts := NewTestingStore(ctx, t)
+	user, err := createTestingHostUser(ctx, ts)
+	require.NoError(t, err)
+	createMemo := func(uid string, createdAt time.Time) *store.Memo {
+		memo, err := ts.CreateMemo(ctx, &store.Memo{UID: uid, CreatorID: user.ID, Content: uid, Visibility: store.Public})
+		require.NoError(t, err)
+		createdTs := createdAt.Unix()
+		require.NoError(t, ts.UpdateMemo(ctx, &store.UpdateMemo{ID: memo.ID, CreatedTs: &createdTs}))
+		return memo
+	}
+	// Near midnight of January 2 in UTC, which is still January 1 in UTC-5 and already January 3 in UTC+9.
+	createMemo("before-midnight", time.Date(2024, 1, 2, 23, 30, 0, 0, time.UTC))
+	createMemo("after-midnight", time.Date(2024, 1, 3, 0, 30, 0, 0, time.UTC))
+	createMemo("morning", time.Date(2024, 1, 2, 4, 0, 0, 0, time.UTC))
+	archived := createMemo("archived", time.Date(2024, 1, 2, 12, 0, 0, 0, time.UTC))
+	require.NoError(t, ts.DeleteMemo(ctx, &store.DeleteMemo{ID: archived.ID}))
+	createMemo("out-of-range", time.Date(2024, 2, 1, 12, 0, 0, 0, time.UTC))
+
+	from, to := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC).Add(-12*time.Hour), time.Date(2024, 1, 4, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		tzOffset time.Duration
+		want     []*store.MemoDayCount
+	}{
+		{0, []*store.MemoDayCount{{Date: "2024-01-02", Count: 2}, {Date: "2024-01-03", Count: 1}}},
+		{-5 * time.Hour, []*store.MemoDayCount{{Date: "2024-01-01", Count: 1}, {Date: "2024-01-02", Count: 2}}},
+		{9 * time.Hour, []*store.MemoDayCount{{Date: "2024-01-02", Count: 1}, {Date: "2024-01-03", Count: 2}}},
+	}
+	for _, test := range tests {
+		dayCounts, err := ts.ListMemoDayCounts(ctx, user.ID, from, to, test.tzOffset)
+		require.NoError(t, err)
+		require.Equal(t, test.want, dayCounts, test.tzOffset)
+	}
+	_, err = ts.ListMemoDayCounts(ctx, user.ID, from, to, 15*time.Hour)
+	require.Error(t, err)
+	ts.Close()
+}
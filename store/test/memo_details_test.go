@@ -0,0 +1,141 @@
+package teststore
+
+import (
+	"context"
+	"testing"
+
+	"github.com/lithammer/shortuuid/v4"
+	"github.com/stretchr/testify/require"
+
+	"github.com/usememos/memos/store"
+)
+
+func TestGetMemoWithDetails(t *testing.T) {
+	ctx := context.Background()
+	ts := NewTestingStore(ctx, t)
+	user, err := createTestingHostUser(ctx, ts)
+	require.NoError(t, err)
+	other, err := ts.CreateUser(ctx, &store.User{Username: "other", Role: store.RoleUser, Email: "other@test.com"})
+	require.NoError(t, err)
+	createMemo := func(uid string, creatorID int32, visibility store.Visibility) *store.Memo {
+		memo, err := ts.CreateMemo(ctx, &store.Memo{UID: uid, CreatorID: creatorID, Content: uid, Visibility: visibility})
+		require.NoError(t, err)
+		return memo
+	}
+	memo := createMemo("memo", user.ID, store.Protected)
+	publicRef := createMemo("public-ref", user.ID, store.Public)
+	privateRef := createMemo("private-ref", user.ID, store.Private)
+	comment := createMemo("comment", other.ID, store.Public)
+	for _, relation := range []*store.MemoRelation{
+		{MemoID: memo.ID, RelatedMemoID: publicRef.ID, Type: store.MemoRelationReference},
+		{MemoID: memo.ID, RelatedMemoID: privateRef.ID, Type: store.MemoRelationReference},
+		{MemoID: comment.ID, RelatedMemoID: memo.ID, Type: store.MemoRelationComment},
+	} {
+		_, err := ts.UpsertMemoRelation(ctx, relation)
+		require.NoError(t, err)
+	}
+	_, err = ts.CreateResource(ctx, &store.Resource{UID: shortuuid.New(), CreatorID: user.ID, Filename: "a.png", Type: "image/png", MemoID: &memo.ID})
+	require.NoError(t, err)
+	_, err = ts.UpsertReaction(ctx, &store.Reaction{CreatorID: other.ID, ContentID: "memos/memo", ReactionType: "👍"})
+	require.NoError(t, err)
+
+	// Nothing is loaded unless asked.
+	details, err := ts.GetMemoWithDetails(ctx, memo.ID, &store.MemoDetailsOptions{ViewerID: &user.ID})
+	require.NoError(t, err)
+	require.Equal(t, "memo", details.Memo.Content)
+	require.Nil(t, details.Resources)
+	require.Nil(t, details.Relations)
+	require.Nil(t, details.Memo.RelatedMemos)
+	require.Nil(t, details.Memo.ReactionSummaries)
+	require.Zero(t, details.Memo.CommentCount)
+
+	tests := []struct {
+		name  string
+		opts  *store.MemoDetailsOptions
+		check func(*store.MemoDetails)
+	}{
+		{
+			name: "resources",
+			opts: &store.MemoDetailsOptions{IncludeResources: true},
+			check: func(details *store.MemoDetails) {
+				require.Len(t, details.Resources, 1)
+				require.Equal(t, "a.png", details.Resources[0].Filename)
+				require.Nil(t, details.Relations)
+			},
+		},
+		{
+			name: "relations",
+			opts: &store.MemoDetailsOptions{IncludeRelations: true},
+			check: func(details *store.MemoDetails) {
+				require.Len(t, details.Relations, 3)
+				require.Nil(t, details.Resources)
+			},
+		},
+		{
+			name: "related memos",
+			opts: &store.MemoDetailsOptions{IncludeRelatedMemos: true},
+			check: func(details *store.MemoDetails) {
+				require.Len(t, details.Memo.RelatedMemos, 2)
+				require.Nil(t, details.Memo.ReactionSummaries)
+			},
+		},
+		{
+			name: "reactions",
+			opts: &store.MemoDetailsOptions{IncludeReactionSummaries: true},
+			check: func(details *store.MemoDetails) {
+				require.Len(t, details.Memo.ReactionSummaries, 1)
+				require.Equal(t, int32(1), details.Memo.ReactionSummaries[0].Count)
+				require.Nil(t, details.Memo.RelatedMemos)
+			},
+		},
+		{
+			name: "comment count",
+			opts: &store.MemoDetailsOptions{IncludeCommentCount: true},
+			check: func(details *store.MemoDetails) {
+				require.Equal(t, int32(1), details.Memo.CommentCount)
+			},
+		},
+	}
+	for _, test := range tests {
+		test.opts.ViewerID = &user.ID
+		details, err := ts.GetMemoWithDetails(ctx, memo.ID, test.opts)
+		require.NoError(t, err, test.name)
+		test.check(details)
+	}
+
+	// The related memos the viewer cannot see are left out.
+	all := &store.MemoDetailsOptions{ViewerID: &other.ID, IncludeRelations: true, IncludeRelatedMemos: true}
+	details, err = ts.GetMemoWithDetails(ctx, memo.ID, all)
+	require.NoError(t, err)
+	require.Len(t, details.Memo.RelatedMemos, 1)
+	require.Equal(t, publicRef.ID, details.Memo.RelatedMemos[0].ID)
+	require.Len(t, details.Relations, 2)
+	for _, relation := range details.Relations {
+		require.NotEqual(t, privateRef.ID, relation.RelatedMemoID)
+	}
+
+	// Unless the memo is shared with them.
+	_, err = ts.UpsertMemoACL(ctx, &store.MemoACL{MemoID: privateRef.ID, UserID: other.ID})
+	require.NoError(t, err)
+	details, err = ts.GetMemoWithDetails(ctx, memo.ID, all)
+	require.NoError(t, err)
+	require.Len(t, details.Memo.RelatedMemos, 2)
+	require.Len(t, details.Relations, 3)
+
+	// Memos the viewer cannot see are not found.
+	details, err = ts.GetMemoWithDetails(ctx, memo.ID, nil)
+	require.NoError(t, err)
+	require.Nil(t, details)
+	details, err = ts.GetMemoWithDetails(ctx, privateRef.ID, &store.MemoDetailsOptions{ViewerID: &user.ID})
+	require.NoError(t, err)
+	require.NotNil(t, details)
+	outsider, err := ts.CreateUser(ctx, &store.User{Username: "outsider", Role: store.RoleUser, Email: "outsider@test.com"})
+	require.NoError(t, err)
+	details, err = ts.GetMemoWithDetails(ctx, privateRef.ID, &store.MemoDetailsOptions{ViewerID: &outsider.ID})
+	require.NoError(t, err)
+	require.Nil(t, details)
+	details, err = ts.GetMemoWithDetails(ctx, 404, &store.MemoDetailsOptions{ViewerID: &user.ID})
+	require.NoError(t, err)
+	require.Nil(t, details)
+	ts.Close()
+}
@@ -0,0 +1,149 @@
+package teststore
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/lithammer/shortuuid/v4"
+	"github.com/stretchr/testify/require"
+
+	"github.com/usememos/memos/store"
+)
+
+func TestFindDuplicateMemos(t *testing.T) {
+	ctx := context.Background()
+	ts := NewTestingStore(ctx, t)
+	user, err := createTestingHostUser(ctx, ts)
+	require.NoError(t, err)
+	otherUser, err := ts.CreateUser(ctx, &store.User{Username: "other", Role: store.RoleUser, Email: "other@test.com"})
+	require.NoError(t, err)
+
+	contents := []string{"daily", "weekly", "daily", "unique", "weekly", "daily"}
+	memoIDs := []int32{}
+	for i, content := range contents {
+		memo, err := ts.CreateMemo(ctx, &store.Memo{UID: fmt.Sprintf("memo-%d", i), CreatorID: user.ID, Content: content, Visibility: store.Private})
+		require.NoError(t, err)
+		memoIDs = append(memoIDs, memo.ID)
+	}
+	// The same content of another user and of a comment are not duplicates.
+	_, err = ts.CreateMemo(ctx, &store.Memo{UID: "other-unique", CreatorID: otherUser.ID, Content: "unique", Visibility: store.Private})
+	require.NoError(t, err)
+	comment, err := ts.CreateMemo(ctx, &store.Memo{UID: "comment", CreatorID: user.ID, Content: "unique", Visibility: store.Private})
+	require.NoError(t, err)
+	_, err = ts.UpsertMemoRelation(ctx, &store.MemoRelation{MemoID: comment.ID, RelatedMemoID: memoIDs[0], Type: store.MemoRelationComment})
+	require.NoError(t, err)
+
+	clusters, err := ts.FindDuplicateMemos(ctx, user.ID)
+	require.NoError(t, err)
+	require.Equal(t, [][]int32{
+		{memoIDs[0], memoIDs[2], memoIDs[5]},
+		{memoIDs[1], memoIDs[4]},
+	}, clusters)
+
+	// Memos written before the content hash was stored are hashed first.
+	_, err = ts.GetDriver().GetDB().ExecContext(ctx, "UPDATE memo SET content_hash = ''")
+	require.NoError(t, err)
+	clusters, err = ts.FindDuplicateMemos(ctx, user.ID)
+	require.NoError(t, err)
+	require.Len(t, clusters, 2)
+
+	// Editing a memo rehashes it.
+	content := "weekly"
+	require.NoError(t, ts.UpdateMemo(ctx, &store.UpdateMemo{ID: memoIDs[3], Content: &content}))
+	clusters, err = ts.FindDuplicateMemos(ctx, user.ID)
+	require.NoError(t, err)
+	require.Equal(t, []int32{memoIDs[1], memoIDs[3], memoIDs[4]}, clusters[1])
+	ts.Close()
+}
+
+func TestMergeMemos(t *testing.T) {
+	ctx := context.Background()
+	ts := NewTestingStore(ctx, t)
+	user, err := createTestingHostUser(ctx, ts)
+	require.NoError(t, err)
+	otherUser, err := ts.CreateUser(ctx, &store.User{Username: "other", Role: store.RoleUser, Email: "other@test.com"})
+	require.NoError(t, err)
+	createMemo := func(uid string) *store.Memo {
+		memo, err := ts.CreateMemo(ctx, &store.Memo{UID: uid, CreatorID: user.ID, Content: "imported", Visibility: store.Private})
+		require.NoError(t, err)
+		return memo
+	}
+	keep, duplicate, another := createMemo("keep"), createMemo("duplicate"), createMemo("another")
+	target, referrer := createMemo("target"), createMemo("referrer")
+	comment := createMemo("comment")
+
+	// Both the kept memo and the duplicate reference the target, the duplicate is referenced and
+	// commented on, and the duplicates reference each other.
+	for _, relation := range []*store.MemoRelation{
+		{MemoID: keep.ID, RelatedMemoID: target.ID, Type: store.MemoRelationReference},
+		{MemoID: duplicate.ID, RelatedMemoID: target.ID, Type: store.MemoRelationReference},
+		{MemoID: referrer.ID, RelatedMemoID: duplicate.ID, Type: store.MemoRelationReference},
+		{MemoID: comment.ID, RelatedMemoID: another.ID, Type: store.MemoRelationComment},
+		{MemoID: duplicate.ID, RelatedMemoID: keep.ID, Type: store.MemoRelationReference},
+		{MemoID: another.ID, RelatedMemoID: duplicate.ID, Type: store.MemoRelationReference},
+	} {
+		_, err := ts.UpsertMemoRelation(ctx, relation)
+		require.NoError(t, err)
+	}
+	resource, err := ts.CreateResource(ctx, &store.Resource{UID: shortuuid.New(), CreatorID: user.ID, Filename: "a.png", Type: "image/png", MemoID: &duplicate.ID})
+	require.NoError(t, err)
+	for _, reaction := range []*store.Reaction{
+		{CreatorID: user.ID, ContentID: "memos/keep", ReactionType: "👍"},
+		{CreatorID: user.ID, ContentID: "memos/duplicate", ReactionType: "👍"},
+		{CreatorID: otherUser.ID, ContentID: "memos/duplicate", ReactionType: "👍"},
+		{CreatorID: otherUser.ID, ContentID: "memos/another", ReactionType: "👍"},
+	} {
+		_, err := ts.UpsertReaction(ctx, reaction)
+		require.NoError(t, err)
+	}
+
+	require.NoError(t, ts.MergeMemos(ctx, keep.ID, []int32{duplicate.ID, another.ID, duplicate.ID}))
+
+	// The merged memos are deleted.
+	memos, err := ts.ListMemos(ctx, &store.FindMemo{IDList: []int32{keep.ID, duplicate.ID, another.ID}})
+	require.NoError(t, err)
+	require.Len(t, memos, 1)
+	require.Equal(t, keep.ID, memos[0].ID)
+
+	// The relations are reattached once, without relating the kept memo to itself.
+	relations, err := ts.ListMemoRelations(ctx, &store.FindMemoRelation{})
+	require.NoError(t, err)
+	type relationKey struct {
+		memoID, relatedMemoID int32
+		relationType          store.MemoRelationType
+	}
+	keys := []relationKey{}
+	for _, relation := range relations {
+		keys = append(keys, relationKey{relation.MemoID, relation.RelatedMemoID, relation.Type})
+	}
+	require.ElementsMatch(t, []relationKey{
+		{keep.ID, target.ID, store.MemoRelationReference},
+		{referrer.ID, keep.ID, store.MemoRelationReference},
+		{comment.ID, keep.ID, store.MemoRelationComment},
+	}, keys)
+
+	resource, err = ts.GetResource(ctx, &store.FindResource{ID: &resource.ID})
+	require.NoError(t, err)
+	require.Equal(t, keep.ID, *resource.MemoID)
+
+	// Each user keeps one reaction of a type.
+	keepContentID := "memos/keep"
+	reactions, err := ts.ListReactions(ctx, &store.FindReaction{ContentID: &keepContentID})
+	require.NoError(t, err)
+	require.Len(t, reactions, 2)
+	for _, contentID := range []string{"memos/duplicate", "memos/another"} {
+		reactions, err := ts.ListReactions(ctx, &store.FindReaction{ContentID: &contentID})
+		require.NoError(t, err)
+		require.Empty(t, reactions)
+	}
+
+	// Invalid merges are refused.
+	require.ErrorContains(t, ts.MergeMemos(ctx, keep.ID, nil), "no memos to merge")
+	require.ErrorContains(t, ts.MergeMemos(ctx, keep.ID, []int32{keep.ID}), "cannot be merged into itself")
+	require.ErrorContains(t, ts.MergeMemos(ctx, keep.ID, []int32{duplicate.ID}), "memo not found")
+	otherMemo, err := ts.CreateMemo(ctx, &store.Memo{UID: "other", CreatorID: otherUser.ID, Content: "imported", Visibility: store.Private})
+	require.NoError(t, err)
+	require.ErrorContains(t, ts.MergeMemos(ctx, keep.ID, []int32{otherMemo.ID}), "different creators")
+	ts.Close()
+}
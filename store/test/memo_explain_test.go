@@ -0,0 +1,72 @@
+package teststore
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	storepb "github.com/usememos/memos/proto/gen/store"
+	"github.com/usememos/memos/store"
+)
+
+func TestExplainListMemos(t *testing.T) {
+	ctx := context.Background()
+	ts := NewTestingStore(ctx, t)
+	user, err := createTestingHostUser(ctx, ts)
+	require.NoError(t, err)
+	for _, memo := range []*store.Memo{
+		{UID: "public", CreatorID: user.ID, Content: "public", Visibility: store.Public},
+		{UID: "private", CreatorID: user.ID, Content: "private", Visibility: store.Private},
+		{UID: "protected", CreatorID: user.ID, Content: "protected", Visibility: store.Protected},
+	} {
+		_, err := ts.CreateMemo(ctx, memo)
+		require.NoError(t, err)
+	}
+	filter := `visibility in ["PUBLIC", "PROTECTED"]`
+	limit := 10
+	find := &store.FindMemo{Filter: &filter, ExcludeComments: true, Limit: &limit}
+
+	// Explain is disabled by default.
+	_, err = ts.ExplainListMemos(ctx, find, false)
+	require.ErrorIs(t, err, store.ErrQueryExplainDisabled)
+
+	_, err = ts.UpsertWorkspaceSetting(ctx, &storepb.WorkspaceSetting{
+		Key: storepb.WorkspaceSettingKey_GENERAL,
+		Value: &storepb.WorkspaceSetting_GeneralSetting{
+			GeneralSetting: &storepb.WorkspaceGeneralSetting{EnableQueryExplain: true},
+		},
+	})
+	require.NoError(t, err)
+	plan, err := ts.ExplainListMemos(ctx, find, true)
+	require.NoError(t, err)
+	require.Contains(t, plan.Plan, "memo")
+
+	// The explained query lists the same memos as ListMemos.
+	memos, err := ts.ListMemos(ctx, find)
+	require.NoError(t, err)
+	expectedIDs := []int32{}
+	for _, memo := range memos {
+		expectedIDs = append(expectedIDs, memo.ID)
+	}
+	require.Len(t, expectedIDs, 2)
+	rows, err := ts.GetDriver().GetDB().QueryContext(ctx, plan.SQL, plan.Args...)
+	require.NoError(t, err)
+	defer rows.Close()
+	columns, err := rows.Columns()
+	require.NoError(t, err)
+	ids := []int32{}
+	for rows.Next() {
+		values := make([]any, len(columns))
+		var id int32
+		values[0] = &id
+		for i := 1; i < len(values); i++ {
+			values[i] = new(any)
+		}
+		require.NoError(t, rows.Scan(values...))
+		ids = append(ids, id)
+	}
+	require.NoError(t, rows.Err())
+	require.Equal(t, expectedIDs, ids)
+	ts.Close()
+}
@@ -0,0 +1,101 @@
+package teststore
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/usememos/memos/store"
+)
+
+func TestCreateMemoWithIdempotencyKey(t *testing.T) {
+	ctx := context.Background()
+	ts := NewTestingStore(ctx, t)
+	user, err := createTestingHostUser(ctx, ts)
+	require.NoError(t, err)
+	other, err := ts.CreateUser(ctx, &store.User{Username: "other", Role: store.RoleUser, Email: "other@test.com"})
+	require.NoError(t, err)
+
+	memo, created, err := ts.CreateMemoWithIdempotencyKey(ctx, &store.Memo{CreatorID: user.ID, Content: "first", Visibility: store.Public}, "key")
+	require.NoError(t, err)
+	require.True(t, created)
+	require.Equal(t, "first", memo.Content)
+
+	// A retry with the same key returns the memo of the first request.
+	retried, created, err := ts.CreateMemoWithIdempotencyKey(ctx, &store.Memo{CreatorID: user.ID, Content: "retry", Visibility: store.Public}, "key")
+	require.NoError(t, err)
+	require.False(t, created)
+	require.Equal(t, memo.ID, retried.ID)
+	require.Equal(t, "first", retried.Content)
+
+	// Keys are scoped to the creator.
+	otherMemo, created, err := ts.CreateMemoWithIdempotencyKey(ctx, &store.Memo{CreatorID: other.ID, Content: "other", Visibility: store.Public}, "key")
+	require.NoError(t, err)
+	require.True(t, created)
+	require.NotEqual(t, memo.ID, otherMemo.ID)
+
+	anotherKeyMemo, created, err := ts.CreateMemoWithIdempotencyKey(ctx, &store.Memo{CreatorID: user.ID, Content: "another", Visibility: store.Public}, "another-key")
+	require.NoError(t, err)
+	require.True(t, created)
+	require.NotEqual(t, memo.ID, anotherKeyMemo.ID)
+
+	_, _, err = ts.CreateMemoWithIdempotencyKey(ctx, &store.Memo{CreatorID: user.ID, Content: "empty key", Visibility: store.Public}, "")
+	require.Error(t, err)
+
+	// Once the key expires, the same key creates a new memo.
+	require.NoError(t, ts.DeleteExpiredMemoIdempotencyKeys(ctx, -time.Minute))
+	recreated, created, err := ts.CreateMemoWithIdempotencyKey(ctx, &store.Memo{CreatorID: user.ID, Content: "recreated", Visibility: store.Public}, "key")
+	require.NoError(t, err)
+	require.True(t, created)
+	require.NotEqual(t, memo.ID, recreated.ID)
+	ts.Close()
+}
+
+func TestCreateMemoWithIdempotencyKeyConcurrently(t *testing.T) {
+	ctx := context.Background()
+	ts := NewTestingStore(ctx, t)
+	user, err := createTestingHostUser(ctx, ts)
+	require.NoError(t, err)
+
+	const count = 8
+	memoIDs := make([]int32, count)
+	createdCount := 0
+	var mutex sync.Mutex
+	var wg sync.WaitGroup
+	// Errors are asserted on the test goroutine, as require must not be called on others.
+	errs := make(chan error, count)
+	for i := 0; i < count; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			memo, created, err := ts.CreateMemoWithIdempotencyKey(ctx, &store.Memo{CreatorID: user.ID, Content: "test_content", Visibility: store.Public}, "key")
+			if err != nil {
+				errs <- err
+				return
+			}
+			mutex.Lock()
+			defer mutex.Unlock()
+			memoIDs[i] = memo.ID
+			if created {
+				createdCount++
+			}
+		}(i)
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		require.NoError(t, err)
+	}
+
+	require.Equal(t, 1, createdCount)
+	for _, memoID := range memoIDs {
+		require.Equal(t, memoIDs[0], memoID)
+	}
+	memos, err := ts.ListMemos(ctx, &store.FindMemo{CreatorID: &user.ID})
+	require.NoError(t, err)
+	require.Len(t, memos, 1)
+	ts.Close()
+}
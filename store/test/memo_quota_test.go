@@ -0,0 +1,133 @@
+package teststore
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	storepb "github.com/usememos/memos/proto/gen/store"
+	"github.com/usememos/memos/store"
+)
+
+func TestMemoCountQuota(t *testing.T) {
+	ctx := context.Background()
+	ts := NewTestingStore(ctx, t)
+	user, err := createTestingHostUser(ctx, ts)
+	require.NoError(t, err)
+	other, err := ts.CreateUser(ctx, &store.User{Username: "other", Role: store.RoleUser, Email: "other@test.com"})
+	require.NoError(t, err)
+	setMemoQuotas(ctx, t, ts, &storepb.WorkspaceMemoRelatedSetting{MemoCountQuota: 2})
+	createMemo := func(creatorID int32, uid string) (*store.Memo, error) {
+		return ts.CreateMemo(ctx, &store.Memo{UID: uid, CreatorID: creatorID, Content: "memo", Visibility: store.Private})
+	}
+
+	first, err := createMemo(user.ID, "first")
+	require.NoError(t, err)
+	_, err = createMemo(user.ID, "second")
+	require.NoError(t, err)
+	_, err = createMemo(user.ID, "third")
+	quotaErr := &store.MemoQuotaExceededError{}
+	require.ErrorAs(t, err, &quotaErr)
+	require.Equal(t, &store.MemoQuotaExceededError{Unit: "memos", Usage: 2, Requested: 1, Limit: 2}, quotaErr)
+	require.ErrorContains(t, err, "2 of 2 memos used")
+	_, _, err = ts.CreateMemoWithIdempotencyKey(ctx, &store.Memo{UID: "keyed", CreatorID: user.ID, Content: "memo", Visibility: store.Private}, "key")
+	require.ErrorAs(t, err, &quotaErr)
+	// The quota is per user.
+	_, err = createMemo(other.ID, "other")
+	require.NoError(t, err)
+
+	// Archived memos are not counted by default.
+	archived := store.Archived
+	require.NoError(t, ts.UpdateMemo(ctx, &store.UpdateMemo{ID: first.ID, RowStatus: &archived}))
+	_, err = createMemo(user.ID, "third")
+	require.NoError(t, err)
+	_, err = createMemo(user.ID, "fourth")
+	require.ErrorAs(t, err, &quotaErr)
+
+	// They are when the setting asks.
+	setMemoQuotas(ctx, t, ts, &storepb.WorkspaceMemoRelatedSetting{MemoCountQuota: 3, MemoQuotaIncludeArchived: true})
+	_, err = createMemo(user.ID, "fourth")
+	require.ErrorAs(t, err, &quotaErr)
+	require.Equal(t, int64(3), quotaErr.Usage)
+	usage, err := ts.GetMemoUsage(ctx, user.ID, true)
+	require.NoError(t, err)
+	require.Equal(t, int64(3), usage.Count)
+	usage, err = ts.GetMemoUsage(ctx, user.ID, false)
+	require.NoError(t, err)
+	require.Equal(t, int64(2), usage.Count)
+
+	// Without quotas, memos are created freely.
+	setMemoQuotas(ctx, t, ts, &storepb.WorkspaceMemoRelatedSetting{})
+	for i := 0; i < 3; i++ {
+		_, err = createMemo(user.ID, fmt.Sprintf("free-%d", i))
+		require.NoError(t, err)
+	}
+	ts.Close()
+}
+
+func TestMemoSizeQuota(t *testing.T) {
+	ctx := context.Background()
+	ts := NewTestingStore(ctx, t)
+	user, err := createTestingHostUser(ctx, ts)
+	require.NoError(t, err)
+	setMemoQuotas(ctx, t, ts, &storepb.WorkspaceMemoRelatedSetting{MemoSizeQuota: 10})
+	createMemo := func(uid, content string) error {
+		_, err := ts.CreateMemo(ctx, &store.Memo{UID: uid, CreatorID: user.ID, Content: content, Visibility: store.Private})
+		return err
+	}
+
+	// Sizes are in bytes, not characters.
+	require.NoError(t, createMemo("first", "你好"))
+	require.NoError(t, createMemo("second", "1234"))
+	err = createMemo("third", "1")
+	quotaErr := &store.MemoQuotaExceededError{}
+	require.ErrorAs(t, err, &quotaErr)
+	require.Equal(t, &store.MemoQuotaExceededError{Unit: "bytes", Usage: 10, Requested: 1, Limit: 10}, quotaErr)
+	require.NoError(t, createMemo("empty", ""))
+
+	usage, err := ts.GetMemoUsage(ctx, user.ID, false)
+	require.NoError(t, err)
+	require.Equal(t, &store.MemoUsage{Count: 3, Size: 10}, usage)
+	ts.Close()
+}
+
+func TestMemoQuotaIdempotentRetry(t *testing.T) {
+	ctx := context.Background()
+	ts := NewTestingStore(ctx, t)
+	user, err := createTestingHostUser(ctx, ts)
+	require.NoError(t, err)
+	setMemoQuotas(ctx, t, ts, &storepb.WorkspaceMemoRelatedSetting{MemoCountQuota: 2})
+	_, err = ts.CreateMemo(ctx, &store.Memo{UID: "first", CreatorID: user.ID, Content: "memo", Visibility: store.Private})
+	require.NoError(t, err)
+
+	// The memo fills the quota, yet a retry with the same key still returns it.
+	memo, created, err := ts.CreateMemoWithIdempotencyKey(ctx, &store.Memo{CreatorID: user.ID, Content: "keyed", Visibility: store.Private}, "key")
+	require.NoError(t, err)
+	require.True(t, created)
+	retried, created, err := ts.CreateMemoWithIdempotencyKey(ctx, &store.Memo{CreatorID: user.ID, Content: "keyed", Visibility: store.Private}, "key")
+	require.NoError(t, err)
+	require.False(t, created)
+	require.Equal(t, memo.ID, retried.ID)
+
+	// A new key is checked against the quota, and is not claimed when it is exceeded.
+	_, _, err = ts.CreateMemoWithIdempotencyKey(ctx, &store.Memo{CreatorID: user.ID, Content: "another", Visibility: store.Private}, "another-key")
+	quotaErr := &store.MemoQuotaExceededError{}
+	require.ErrorAs(t, err, &quotaErr)
+	setMemoQuotas(ctx, t, ts, &storepb.WorkspaceMemoRelatedSetting{MemoCountQuota: 3})
+	_, created, err = ts.CreateMemoWithIdempotencyKey(ctx, &store.Memo{CreatorID: user.ID, Content: "another", Visibility: store.Private}, "another-key")
+	require.NoError(t, err)
+	require.True(t, created)
+	ts.Close()
+}
+
+func setMemoQuotas(ctx context.Context, t *testing.T, ts *store.Store, setting *storepb.WorkspaceMemoRelatedSetting) {
+	_, err := ts.UpsertWorkspaceSetting(ctx, &storepb.WorkspaceSetting{
+		Key: storepb.WorkspaceSettingKey_MEMO_RELATED,
+		Value: &storepb.WorkspaceSetting_MemoRelatedSetting{
+			MemoRelatedSetting: setting,
+		},
+	})
+	require.NoError(t, err)
+}
@@ -0,0 +1,69 @@
+package teststore
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	storepb "github.com/usememos/memos/proto/gen/store"
+	"github.com/usememos/memos/store"
+)
+
+func TestListRelatedMemosByTags(t *testing.T) {
+	ctx := context.Background()
+	ts := NewTestingStore(ctx, t)
+	user, err := createTestingHostUser(ctx, ts)
+	require.NoError(t, err)
+	other, err := ts.CreateUser(ctx, &store.User{Username: "other", Role: store.RoleUser, Email: "other@test.com"})
+	require.NoError(t, err)
+
+	createMemo := func(uid string, creatorID int32, visibility store.Visibility, tags ...string) *store.Memo {
+		memo, err := ts.CreateMemo(ctx, &store.Memo{UID: uid, CreatorID: creatorID, Content: uid, Visibility: visibility, Payload: &storepb.MemoPayload{Tags: tags}})
+		require.NoError(t, err)
+		return memo
+	}
+	source := createMemo("source", user.ID, store.Public, "go", "Databases", "travel")
+	createMemo("one", user.ID, store.Public, "travel", "food")
+	createMemo("three", user.ID, store.Public, "databases", "go", "travel")
+	createMemo("two", other.ID, store.Protected, "go", "databases")
+	createMemo("private", user.ID, store.Private, "go", "databases", "travel")
+	createMemo("unrelated", user.ID, store.Public, "food")
+	archived := createMemo("archived", user.ID, store.Public, "go")
+	archivedStatus := store.Archived
+	require.NoError(t, ts.UpdateMemo(ctx, &store.UpdateMemo{ID: archived.ID, RowStatus: &archivedStatus}))
+
+	listRelated := func(viewerID *int32, limit int) []string {
+		relatedMemos, err := ts.ListRelatedMemosByTags(ctx, source.ID, viewerID, limit)
+		require.NoError(t, err)
+		related := []string{}
+		for _, relatedMemo := range relatedMemos {
+			related = append(related, relatedMemo.Memo.UID)
+			require.Equal(t, map[string]int{"one": 1, "two": 2, "three": 3, "private": 3}[relatedMemo.Memo.UID], relatedMemo.SharedTagCount)
+		}
+		return related
+	}
+	// The private memo is only related for its author, and newer memos come first on a tie.
+	require.Equal(t, []string{"private", "three", "two", "one"}, listRelated(&user.ID, 10))
+	require.Equal(t, []string{"three", "two", "one"}, listRelated(&other.ID, 10))
+	require.Equal(t, []string{"three", "one"}, listRelated(nil, 10))
+	require.Equal(t, []string{"private", "three"}, listRelated(&user.ID, 2))
+
+	// The tags of a memo the viewer cannot see are not revealed.
+	private := createMemo("private-source", user.ID, store.Private, "go")
+	_, err = ts.ListRelatedMemosByTags(ctx, private.ID, &other.ID, 10)
+	require.ErrorContains(t, err, "not found")
+	_, err = ts.ListRelatedMemosByTags(ctx, private.ID, nil, 10)
+	require.ErrorContains(t, err, "not found")
+	relatedMemos, err := ts.ListRelatedMemosByTags(ctx, private.ID, &user.ID, 10)
+	require.NoError(t, err)
+	require.NotEmpty(t, relatedMemos)
+
+	untagged := createMemo("untagged", user.ID, store.Public)
+	relatedMemos, err = ts.ListRelatedMemosByTags(ctx, untagged.ID, &user.ID, 10)
+	require.NoError(t, err)
+	require.Empty(t, relatedMemos)
+	_, err = ts.ListRelatedMemosByTags(ctx, source.ID, &user.ID, 0)
+	require.Error(t, err)
+	ts.Close()
+}
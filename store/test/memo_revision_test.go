@@ -0,0 +1,163 @@
+package teststore
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	storepb "github.com/usememos/memos/proto/gen/store"
+	"github.com/usememos/memos/store"
+)
+
+func TestMemoRevisionHistory(t *testing.T) {
+	ctx := context.Background()
+	ts := NewTestingStore(ctx, t)
+	user, err := createTestingHostUser(ctx, ts)
+	require.NoError(t, err)
+	editor, err := ts.CreateUser(ctx, &store.User{Username: "editor", Role: store.RoleAdmin, Email: "editor@test.com"})
+	require.NoError(t, err)
+	memo, err := ts.CreateMemo(ctx, &store.Memo{UID: "memo", CreatorID: user.ID, Content: "v1", Visibility: store.Private})
+	require.NoError(t, err)
+	listRevisions := func() []*store.MemoRevision {
+		revisions, err := ts.ListMemoRevisions(ctx, &store.FindMemoRevision{MemoID: &memo.ID})
+		require.NoError(t, err)
+		return revisions
+	}
+	updateContent := func(content string, editorID int32) {
+		require.NoError(t, ts.UpdateMemo(ctx, &store.UpdateMemo{ID: memo.ID, Content: &content, EditorID: editorID}))
+	}
+
+	// Creating a memo and updating other fields records nothing.
+	require.Empty(t, listRevisions())
+	pinned := true
+	require.NoError(t, ts.UpdateMemo(ctx, &store.UpdateMemo{ID: memo.ID, Pinned: &pinned}))
+	require.Empty(t, listRevisions())
+
+	// The first update keeps the original content as the first revision.
+	updateContent("v2", editor.ID)
+	revisions := listRevisions()
+	require.Len(t, revisions, 2)
+	require.Equal(t, "v2", revisions[0].Content)
+	require.Equal(t, editor.ID, revisions[0].EditorID)
+	require.Equal(t, "v1", revisions[1].Content)
+	require.Equal(t, user.ID, revisions[1].EditorID)
+
+	// Each later update adds a revision, unless the content is unchanged.
+	updateContent("v3", user.ID)
+	updateContent("v3", user.ID)
+	revisions = listRevisions()
+	require.Len(t, revisions, 3)
+	require.Equal(t, "v3", revisions[0].Content)
+
+	revision, err := ts.GetMemoRevision(ctx, &store.FindMemoRevision{ID: &revisions[2].ID})
+	require.NoError(t, err)
+	require.Equal(t, "v1", revision.Content)
+	require.Equal(t, memo.ID, revision.MemoID)
+	notFound := int32(404)
+	revision, err = ts.GetMemoRevision(ctx, &store.FindMemoRevision{ID: &notFound})
+	require.NoError(t, err)
+	require.Nil(t, revision)
+
+	// Hard deleting the memo deletes its revisions.
+	require.NoError(t, ts.DeleteMemo(ctx, &store.DeleteMemo{ID: memo.ID, Hard: true}))
+	require.Empty(t, listRevisions())
+	ts.Close()
+}
+
+func TestHardDeleteMemoRollsBack(t *testing.T) {
+	ctx := context.Background()
+	ts := NewTestingStore(ctx, t)
+	user, err := createTestingHostUser(ctx, ts)
+	require.NoError(t, err)
+	viewer, err := ts.CreateUser(ctx, &store.User{Username: "viewer", Role: store.RoleUser, Email: "viewer@test.com"})
+	require.NoError(t, err)
+	memo, err := ts.CreateMemo(ctx, &store.Memo{UID: "memo", CreatorID: user.ID, Content: "v1", Visibility: store.Private})
+	require.NoError(t, err)
+	content := "v2"
+	require.NoError(t, ts.UpdateMemo(ctx, &store.UpdateMemo{ID: memo.ID, Content: &content, EditorID: user.ID}))
+	_, err = ts.UpsertMemoACL(ctx, &store.MemoACL{MemoID: memo.ID, UserID: viewer.ID})
+	require.NoError(t, err)
+	_, err = ts.GetDriver().GetDB().ExecContext(ctx, "CREATE TRIGGER reject_acl_delete BEFORE DELETE ON memo_acl BEGIN SELECT RAISE(ABORT, 'acl delete rejected'); END")
+	require.NoError(t, err)
+
+	// The memo, its revisions and its ACLs are deleted in one transaction, so all are kept.
+	require.ErrorContains(t, ts.DeleteMemo(ctx, &store.DeleteMemo{ID: memo.ID, Hard: true}), "acl delete rejected")
+	kept, err := ts.GetMemo(ctx, &store.FindMemo{ID: &memo.ID})
+	require.NoError(t, err)
+	require.NotNil(t, kept)
+	revisions, err := ts.ListMemoRevisions(ctx, &store.FindMemoRevision{MemoID: &memo.ID})
+	require.NoError(t, err)
+	require.Len(t, revisions, 2)
+	ts.Close()
+}
+
+func TestMemoRevisionLimit(t *testing.T) {
+	ctx := context.Background()
+	ts := NewTestingStore(ctx, t)
+	user, err := createTestingHostUser(ctx, ts)
+	require.NoError(t, err)
+	_, err = ts.UpsertWorkspaceSetting(ctx, &storepb.WorkspaceSetting{
+		Key: storepb.WorkspaceSettingKey_MEMO_RELATED,
+		Value: &storepb.WorkspaceSetting_MemoRelatedSetting{
+			MemoRelatedSetting: &storepb.WorkspaceMemoRelatedSetting{MemoRevisionLimit: 3},
+		},
+	})
+	require.NoError(t, err)
+	memo, err := ts.CreateMemo(ctx, &store.Memo{UID: "memo", CreatorID: user.ID, Content: "v0", Visibility: store.Private})
+	require.NoError(t, err)
+	other, err := ts.CreateMemo(ctx, &store.Memo{UID: "other", CreatorID: user.ID, Content: "other", Visibility: store.Private})
+	require.NoError(t, err)
+	otherContent := "other v1"
+	require.NoError(t, ts.UpdateMemo(ctx, &store.UpdateMemo{ID: other.ID, Content: &otherContent}))
+
+	for i := 1; i <= 5; i++ {
+		content := fmt.Sprintf("v%d", i)
+		require.NoError(t, ts.UpdateMemo(ctx, &store.UpdateMemo{ID: memo.ID, Content: &content}))
+	}
+	revisions, err := ts.ListMemoRevisions(ctx, &store.FindMemoRevision{MemoID: &memo.ID})
+	require.NoError(t, err)
+	contents := []string{}
+	for _, revision := range revisions {
+		contents = append(contents, revision.Content)
+	}
+	require.Equal(t, []string{"v5", "v4", "v3"}, contents)
+
+	// The revisions of other memos are not pruned.
+	revisions, err = ts.ListMemoRevisions(ctx, &store.FindMemoRevision{MemoID: &other.ID})
+	require.NoError(t, err)
+	require.Len(t, revisions, 2)
+	ts.Close()
+}
+
+func TestRevertMemoToRevision(t *testing.T) {
+	ctx := context.Background()
+	ts := NewTestingStore(ctx, t)
+	user, err := createTestingHostUser(ctx, ts)
+	require.NoError(t, err)
+	memo, err := ts.CreateMemo(ctx, &store.Memo{UID: "memo", CreatorID: user.ID, Content: "original", Visibility: store.Private})
+	require.NoError(t, err)
+	content := "edited"
+	require.NoError(t, ts.UpdateMemo(ctx, &store.UpdateMemo{ID: memo.ID, Content: &content, EditorID: user.ID}))
+	revisions, err := ts.ListMemoRevisions(ctx, &store.FindMemoRevision{MemoID: &memo.ID})
+	require.NoError(t, err)
+	require.Len(t, revisions, 2)
+	original := revisions[1]
+
+	require.NoError(t, ts.RevertMemoToRevision(ctx, original.ID, user.ID))
+	memo, err = ts.GetMemo(ctx, &store.FindMemo{ID: &memo.ID})
+	require.NoError(t, err)
+	require.Equal(t, "original", memo.Content)
+
+	// The revert is a new revision, and the history before it is kept.
+	revisions, err = ts.ListMemoRevisions(ctx, &store.FindMemoRevision{MemoID: &memo.ID})
+	require.NoError(t, err)
+	require.Len(t, revisions, 3)
+	require.Equal(t, "original", revisions[0].Content)
+	require.NotEqual(t, original.ID, revisions[0].ID)
+	require.Equal(t, "edited", revisions[1].Content)
+
+	require.ErrorContains(t, ts.RevertMemoToRevision(ctx, 404, user.ID), "not found")
+	ts.Close()
+}
@@ -0,0 +1,77 @@
+package teststore
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+
+	storepb "github.com/usememos/memos/proto/gen/store"
+	"github.com/usememos/memos/server/runner/memopayload"
+	"github.com/usememos/memos/store"
+)
+
+func TestRebuildMemoTags(t *testing.T) {
+	ctx := context.Background()
+	ts := NewTestingStore(ctx, t)
+	user, err := createTestingHostUser(ctx, ts)
+	require.NoError(t, err)
+	// More memos than fit in a batch, with tags set incrementally as memos are saved.
+	for i := 0; i < 150; i++ {
+		memo := &store.Memo{
+			UID:        fmt.Sprintf("memo-%d", i),
+			CreatorID:  user.ID,
+			Content:    fmt.Sprintf("#Tag%d #Café #shared/%d", i%7, i%3),
+			Visibility: store.Public,
+		}
+		require.NoError(t, memopayload.RebuildMemoPayload(memo))
+		memo, err = ts.CreateMemo(ctx, memo)
+		require.NoError(t, err)
+		if i%10 == 0 {
+			memo.Content = "Untagged now."
+			require.NoError(t, memopayload.RebuildMemoPayload(memo))
+			require.NoError(t, ts.UpdateMemo(ctx, &store.UpdateMemo{ID: memo.ID, Content: &memo.Content, Payload: memo.Payload}))
+		}
+	}
+	listPayloads := func() map[int32]*storepb.MemoPayload {
+		memos, err := ts.ListMemos(ctx, &store.FindMemo{})
+		require.NoError(t, err)
+		payloads := map[int32]*storepb.MemoPayload{}
+		for _, memo := range memos {
+			payloads[memo.ID] = memo.Payload
+		}
+		return payloads
+	}
+	expected := listPayloads()
+	memos, err := ts.ListMemos(ctx, &store.FindMemo{OrderByTimeAsc: true})
+	require.NoError(t, err)
+	for _, memo := range memos {
+		memo.Payload.Tags = []string{"stale"}
+		require.NoError(t, ts.UpdateMemo(ctx, &store.UpdateMemo{ID: memo.ID, Payload: memo.Payload}))
+	}
+
+	// A rebuild resumed after the memos done before an interruption only rebuilds the rest.
+	middle := memos[74].ID
+	lastID, err := memopayload.RebuildMemoTags(ctx, ts, middle)
+	require.NoError(t, err)
+	require.Equal(t, memos[len(memos)-1].ID, lastID)
+	payloads := listPayloads()
+	require.Equal(t, []string{"stale"}, payloads[middle].Tags)
+	require.Equal(t, expected[lastID].Tags, payloads[lastID].Tags)
+
+	// Rebuilding all memos, twice, yields the tags kept incrementally.
+	for i := 0; i < 2; i++ {
+		_, err = memopayload.RebuildMemoTags(ctx, ts, 0)
+		require.NoError(t, err)
+		payloads = listPayloads()
+		for id, payload := range expected {
+			require.True(t, proto.Equal(payload, payloads[id]), id)
+		}
+	}
+	tagCounts, err := ts.ListUserTags(ctx, user.ID, []store.Visibility{store.Public})
+	require.NoError(t, err)
+	require.NotEmpty(t, tagCounts)
+	ts.Close()
+}
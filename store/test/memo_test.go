@@ -2,7 +2,12 @@ package teststore
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 
@@ -44,7 +49,8 @@ func TestMemoStore(t *testing.T) {
 	require.Equal(t, 1, len(memoList))
 	require.Equal(t, memo, memoList[0])
 	err = ts.DeleteMemo(ctx, &store.DeleteMemo{
-		ID: memo.ID,
+		ID:   memo.ID,
+		Hard: true,
 	})
 	require.NoError(t, err)
 	memoList, err = ts.ListMemos(ctx, &store.FindMemo{
@@ -116,3 +122,1370 @@ func TestDeleteMemoStore(t *testing.T) {
 	require.NoError(t, err)
 	ts.Close()
 }
+
+func TestMemoArchiveRestoreAndExpire(t *testing.T) {
+	ctx := context.Background()
+	ts := NewTestingStore(ctx, t)
+	user, err := createTestingHostUser(ctx, ts)
+	require.NoError(t, err)
+	memo, err := ts.CreateMemo(ctx, &store.Memo{
+		UID:        "test-resource-name",
+		CreatorID:  user.ID,
+		Content:    "test_content",
+		Visibility: store.Public,
+	})
+	require.NoError(t, err)
+
+	// Deleting archives the memo.
+	err = ts.DeleteMemo(ctx, &store.DeleteMemo{ID: memo.ID})
+	require.NoError(t, err)
+	memo, err = ts.GetMemo(ctx, &store.FindMemo{ID: &memo.ID})
+	require.NoError(t, err)
+	require.NotNil(t, memo)
+	require.Equal(t, store.Archived, memo.RowStatus)
+
+	// Archived memos can be restored.
+	normal := store.Normal
+	err = ts.UpdateMemo(ctx, &store.UpdateMemo{ID: memo.ID, RowStatus: &normal})
+	require.NoError(t, err)
+	memo, err = ts.GetMemo(ctx, &store.FindMemo{ID: &memo.ID})
+	require.NoError(t, err)
+	require.Equal(t, store.Normal, memo.RowStatus)
+	err = ts.ExpireArchivedMemos(ctx, -time.Minute)
+	require.NoError(t, err)
+	memo, err = ts.GetMemo(ctx, &store.FindMemo{ID: &memo.ID})
+	require.NoError(t, err)
+	require.NotNil(t, memo)
+
+	// Only memos deleted for longer than the retention are expired, with their resources and reactions.
+	err = ts.DeleteMemo(ctx, &store.DeleteMemo{ID: memo.ID})
+	require.NoError(t, err)
+	resource, err := ts.CreateResource(ctx, &store.Resource{UID: "expired-resource", CreatorID: user.ID, Filename: "file.txt", Blob: []byte("file"), Type: "text/plain", MemoID: &memo.ID})
+	require.NoError(t, err)
+	contentID := "memos/" + memo.UID
+	_, err = ts.UpsertReaction(ctx, &store.Reaction{CreatorID: user.ID, ContentID: contentID, ReactionType: "👍"})
+	require.NoError(t, err)
+	err = ts.ExpireArchivedMemos(ctx, time.Hour)
+	require.NoError(t, err)
+	memo, err = ts.GetMemo(ctx, &store.FindMemo{ID: &memo.ID})
+	require.NoError(t, err)
+	require.NotNil(t, memo)
+
+	// Memos archived by their users are never expired, whatever their update time.
+	userArchived, err := ts.CreateMemo(ctx, &store.Memo{UID: "user-archived", CreatorID: user.ID, Content: "archived", Visibility: store.Public})
+	require.NoError(t, err)
+	archived, updatedTs := store.Archived, time.Now().Add(-time.Hour).Unix()
+	err = ts.UpdateMemo(ctx, &store.UpdateMemo{ID: userArchived.ID, RowStatus: &archived, UpdatedTs: &updatedTs})
+	require.NoError(t, err)
+
+	err = ts.ExpireArchivedMemos(ctx, -time.Minute)
+	require.NoError(t, err)
+	memo, err = ts.GetMemo(ctx, &store.FindMemo{ID: &memo.ID})
+	require.NoError(t, err)
+	require.Nil(t, memo)
+	resource, err = ts.GetResource(ctx, &store.FindResource{ID: &resource.ID})
+	require.NoError(t, err)
+	require.Nil(t, resource)
+	reactions, err := ts.ListReactions(ctx, &store.FindReaction{ContentID: &contentID})
+	require.NoError(t, err)
+	require.Empty(t, reactions)
+	userArchived, err = ts.GetMemo(ctx, &store.FindMemo{ID: &userArchived.ID})
+	require.NoError(t, err)
+	require.NotNil(t, userArchived)
+	require.Equal(t, store.Archived, userArchived.RowStatus)
+	ts.Close()
+}
+
+func TestMemoListContentSearch(t *testing.T) {
+	ctx := context.Background()
+	ts := NewTestingStore(ctx, t)
+	user, err := createTestingHostUser(ctx, ts)
+	require.NoError(t, err)
+	for i, content := range []string{"Buy Milk and eggs", "milk tea", "100% done", "snake_case"} {
+		_, err := ts.CreateMemo(ctx, &store.Memo{
+			UID:        fmt.Sprintf("memo-%d", i),
+			CreatorID:  user.ID,
+			Content:    content,
+			Visibility: store.Public,
+		})
+		require.NoError(t, err)
+	}
+
+	tests := []struct {
+		contentSearch []string
+		want          []string
+	}{
+		{contentSearch: []string{"milk"}, want: []string{"Buy Milk and eggs", "milk tea"}},
+		{contentSearch: []string{"MILK", "eggs"}, want: []string{"Buy Milk and eggs"}},
+		{contentSearch: []string{"milk", "coffee"}, want: []string{}},
+		{contentSearch: []string{"0%"}, want: []string{"100% done"}},
+		{contentSearch: []string{"e_c"}, want: []string{"snake_case"}},
+		{contentSearch: []string{"%"}, want: []string{"100% done"}},
+	}
+	for _, test := range tests {
+		memos, err := ts.ListMemos(ctx, &store.FindMemo{
+			ContentSearch: test.contentSearch,
+		})
+		require.NoError(t, err)
+		contents := []string{}
+		for _, memo := range memos {
+			contents = append(contents, memo.Content)
+		}
+		require.ElementsMatch(t, test.want, contents, "content search %v", test.contentSearch)
+	}
+	ts.Close()
+}
+
+func TestMemoListTimeRange(t *testing.T) {
+	ctx := context.Background()
+	ts := NewTestingStore(ctx, t)
+	user, err := createTestingHostUser(ctx, ts)
+	require.NoError(t, err)
+	start, end := int64(1700000000), int64(1700086400)
+	for i, createdTs := range []int64{start - 1, start, end - 1, end} {
+		memo, err := ts.CreateMemo(ctx, &store.Memo{
+			UID:        fmt.Sprintf("memo-%d", i),
+			CreatorID:  user.ID,
+			Content:    fmt.Sprintf("%d", createdTs),
+			Visibility: store.Public,
+		})
+		require.NoError(t, err)
+		updatedTs := createdTs + 10
+		require.NoError(t, ts.UpdateMemo(ctx, &store.UpdateMemo{
+			ID:        memo.ID,
+			CreatedTs: &createdTs,
+			UpdatedTs: &updatedTs,
+		}))
+	}
+
+	listContents := func(find *store.FindMemo) []string {
+		memos, err := ts.ListMemos(ctx, find)
+		require.NoError(t, err)
+		contents := []string{}
+		for _, memo := range memos {
+			contents = append(contents, memo.Content)
+		}
+		return contents
+	}
+	require.ElementsMatch(t, []string{fmt.Sprint(start), fmt.Sprint(end - 1)}, listContents(&store.FindMemo{
+		CreatedTsAfter:  &start,
+		CreatedTsBefore: &end,
+	}))
+	updatedStart, updatedEnd := start+10, end+10
+	require.ElementsMatch(t, []string{fmt.Sprint(start), fmt.Sprint(end - 1)}, listContents(&store.FindMemo{
+		UpdatedTsAfter:  &updatedStart,
+		UpdatedTsBefore: &updatedEnd,
+	}))
+	require.ElementsMatch(t, []string{fmt.Sprint(end)}, listContents(&store.FindMemo{
+		CreatedTsAfter: &end,
+	}))
+	ts.Close()
+}
+
+func TestMemoListOrderByPinned(t *testing.T) {
+	ctx := context.Background()
+	ts := NewTestingStore(ctx, t)
+	user, err := createTestingHostUser(ctx, ts)
+	require.NoError(t, err)
+	// The memos b and c have the same created time, which is broken by id.
+	for i, content := range []string{"a", "b", "c", "d"} {
+		memo, err := ts.CreateMemo(ctx, &store.Memo{
+			UID:        fmt.Sprintf("memo-%d", i),
+			CreatorID:  user.ID,
+			Content:    content,
+			Visibility: store.Public,
+		})
+		require.NoError(t, err)
+		createdTs := []int64{100, 200, 200, 300}[i]
+		pinned := content == "a" || content == "c"
+		require.NoError(t, ts.UpdateMemo(ctx, &store.UpdateMemo{
+			ID:        memo.ID,
+			CreatedTs: &createdTs,
+			Pinned:    &pinned,
+		}))
+	}
+
+	listContents := func(find *store.FindMemo) []string {
+		memos, err := ts.ListMemos(ctx, find)
+		require.NoError(t, err)
+		contents := []string{}
+		for _, memo := range memos {
+			contents = append(contents, memo.Content)
+		}
+		return contents
+	}
+	require.Equal(t, []string{"c", "a", "d", "b"}, listContents(&store.FindMemo{OrderByPinned: true}))
+	require.Equal(t, []string{"d", "c", "b", "a"}, listContents(&store.FindMemo{}))
+	pinned := true
+	require.Equal(t, []string{"c", "a"}, listContents(&store.FindMemo{Pinned: &pinned, OrderByPinned: true}))
+	limit, offset := 2, 1
+	require.Equal(t, []string{"a", "d"}, listContents(&store.FindMemo{OrderByPinned: true, Limit: &limit, Offset: &offset}))
+	ts.Close()
+}
+
+func TestMemoListExplore(t *testing.T) {
+	ctx := context.Background()
+	ts := NewTestingStore(ctx, t)
+	user, err := createTestingHostUser(ctx, ts)
+	require.NoError(t, err)
+	other, err := ts.CreateUser(ctx, &store.User{Username: "other", Role: store.RoleUser, Email: "other@test.com"})
+	require.NoError(t, err)
+	archived := store.Archived
+	for i, memo := range []struct {
+		content    string
+		creatorID  int32
+		visibility store.Visibility
+		pinned     bool
+		rowStatus  *store.RowStatus
+	}{
+		{"public-old", user.ID, store.Public, false, nil},
+		{"public-pinned-old", other.ID, store.Public, true, nil},
+		{"private-pinned", user.ID, store.Private, true, nil},
+		{"protected", other.ID, store.Protected, false, nil},
+		{"public-archived", user.ID, store.Public, true, &archived},
+		{"public-new", other.ID, store.Public, false, nil},
+		{"public-pinned-new", user.ID, store.Public, true, nil},
+		{"public-newest", user.ID, store.Public, false, nil},
+	} {
+		created, err := ts.CreateMemo(ctx, &store.Memo{
+			UID:        fmt.Sprintf("memo-%d", i),
+			CreatorID:  memo.creatorID,
+			Content:    memo.content,
+			Visibility: memo.visibility,
+		})
+		require.NoError(t, err)
+		createdTs := int64(100 * (i + 1))
+		require.NoError(t, ts.UpdateMemo(ctx, &store.UpdateMemo{
+			ID:        created.ID,
+			CreatedTs: &createdTs,
+			Pinned:    &memo.pinned,
+			RowStatus: memo.rowStatus,
+		}))
+	}
+	expected := []string{"public-pinned-new", "public-pinned-old", "public-newest", "public-new", "public-old"}
+
+	// The feed is the same for anonymous visitors and users, whatever the find says about them.
+	for _, find := range []*store.FindMemo{
+		{Explore: true},
+		{Explore: true, VisibleToUserID: &user.ID, VisibilityList: []store.Visibility{store.Private}, OrderByTimeAsc: true},
+	} {
+		memos, err := ts.ListMemos(ctx, find)
+		require.NoError(t, err)
+		contents := []string{}
+		for _, memo := range memos {
+			contents = append(contents, memo.Content)
+		}
+		require.Equal(t, expected, contents)
+	}
+	count, err := ts.CountMemos(ctx, &store.FindMemo{Explore: true})
+	require.NoError(t, err)
+	require.Equal(t, len(expected), count)
+
+	// The cursor pages through the pinned memos and on into the others.
+	for _, limit := range []int{1, 2, 3} {
+		contents := []string{}
+		var cursor *string
+		for {
+			memos, next, err := ts.ListMemosWithCursor(ctx, &store.FindMemo{Explore: true, Limit: &limit, Cursor: cursor})
+			require.NoError(t, err)
+			for _, memo := range memos {
+				contents = append(contents, memo.Content)
+			}
+			if next == "" {
+				break
+			}
+			cursor = &next
+		}
+		require.Equal(t, expected, contents, limit)
+	}
+
+	// Cursors of lists not ordered by pinned do not fit the feed.
+	limit := 1
+	_, next, err := ts.ListMemosWithCursor(ctx, &store.FindMemo{Limit: &limit})
+	require.NoError(t, err)
+	_, err = ts.ListMemos(ctx, &store.FindMemo{Explore: true, Cursor: &next})
+	require.Error(t, err)
+	ts.Close()
+}
+
+func TestMemoComments(t *testing.T) {
+	ctx := context.Background()
+	ts := NewTestingStore(ctx, t)
+	user, err := createTestingHostUser(ctx, ts)
+	require.NoError(t, err)
+	parent, err := ts.CreateMemo(ctx, &store.Memo{
+		UID:        "parent",
+		CreatorID:  user.ID,
+		Content:    "parent",
+		Visibility: store.Public,
+	})
+	require.NoError(t, err)
+	// Created out of order to check that comments are ordered by created_ts.
+	for i, createdTs := range []int64{300, 100, 200} {
+		comment, err := ts.CreateMemo(ctx, &store.Memo{
+			UID:        fmt.Sprintf("comment-%d", i),
+			CreatorID:  user.ID,
+			Content:    fmt.Sprintf("comment %d", createdTs),
+			Visibility: store.Public,
+		})
+		require.NoError(t, err)
+		err = ts.UpdateMemo(ctx, &store.UpdateMemo{ID: comment.ID, CreatedTs: &createdTs})
+		require.NoError(t, err)
+		_, err = ts.UpsertMemoRelation(ctx, &store.MemoRelation{
+			MemoID:        comment.ID,
+			RelatedMemoID: parent.ID,
+			Type:          store.MemoRelationComment,
+		})
+		require.NoError(t, err)
+	}
+
+	memo, err := ts.GetMemo(ctx, &store.FindMemo{ID: &parent.ID, IncludeCommentCount: true})
+	require.NoError(t, err)
+	require.Equal(t, int32(3), memo.CommentCount)
+
+	comments, err := ts.ListMemoComments(ctx, parent.ID)
+	require.NoError(t, err)
+	require.Len(t, comments, 3)
+	for i, content := range []string{"comment 100", "comment 200", "comment 300"} {
+		require.Equal(t, content, comments[i].Content)
+		require.Equal(t, parent.ID, *comments[i].ParentID)
+	}
+
+	err = ts.DeleteMemo(ctx, &store.DeleteMemo{ID: parent.ID, Hard: true, CascadeComments: true})
+	require.NoError(t, err)
+	memos, err := ts.ListMemos(ctx, &store.FindMemo{CreatorID: &user.ID})
+	require.NoError(t, err)
+	require.Len(t, memos, 0)
+	relations, err := ts.ListMemoRelations(ctx, &store.FindMemoRelation{RelatedMemoID: &parent.ID})
+	require.NoError(t, err)
+	require.Len(t, relations, 0)
+	ts.Close()
+}
+
+func TestDeleteMemoCascadesCommentsInTransaction(t *testing.T) {
+	ctx := context.Background()
+	ts := NewTestingStore(ctx, t)
+	user, err := createTestingHostUser(ctx, ts)
+	require.NoError(t, err)
+	createMemo := func(uid string, parentID int32) *store.Memo {
+		memo, err := ts.CreateMemo(ctx, &store.Memo{UID: uid, CreatorID: user.ID, Content: uid, Visibility: store.Public})
+		require.NoError(t, err)
+		if parentID != 0 {
+			_, err = ts.UpsertMemoRelation(ctx, &store.MemoRelation{MemoID: memo.ID, RelatedMemoID: parentID, Type: store.MemoRelationComment})
+			require.NoError(t, err)
+		}
+		return memo
+	}
+	parent := createMemo("parent", 0)
+	comment := createMemo("comment", parent.ID)
+	reply := createMemo("reply", comment.ID)
+	listMemoIDs := func(rowStatus store.RowStatus) []int32 {
+		memos, err := ts.ListMemos(ctx, &store.FindMemo{CreatorID: &user.ID, RowStatus: &rowStatus})
+		require.NoError(t, err)
+		ids := []int32{}
+		for _, memo := range memos {
+			ids = append(ids, memo.ID)
+		}
+		return ids
+	}
+	db := ts.GetDriver().GetDB()
+
+	// The comments are archived in the same transaction as the memo, so none are when it fails.
+	_, err = db.ExecContext(ctx, fmt.Sprintf("CREATE TRIGGER reject_parent_update BEFORE UPDATE ON memo WHEN OLD.id = %d BEGIN SELECT RAISE(ABORT, 'update rejected'); END", parent.ID))
+	require.NoError(t, err)
+	require.ErrorContains(t, ts.DeleteMemo(ctx, &store.DeleteMemo{ID: parent.ID, CascadeComments: true}), "update rejected")
+	require.ElementsMatch(t, []int32{parent.ID, comment.ID, reply.ID}, listMemoIDs(store.Normal))
+	_, err = db.ExecContext(ctx, "DROP TRIGGER reject_parent_update")
+	require.NoError(t, err)
+
+	// Likewise for deleting them permanently, along with the relations of the comments.
+	_, err = db.ExecContext(ctx, fmt.Sprintf("CREATE TRIGGER reject_parent_delete BEFORE DELETE ON memo WHEN OLD.id = %d BEGIN SELECT RAISE(ABORT, 'delete rejected'); END", parent.ID))
+	require.NoError(t, err)
+	require.ErrorContains(t, ts.DeleteMemo(ctx, &store.DeleteMemo{ID: parent.ID, Hard: true, CascadeComments: true}), "delete rejected")
+	require.ElementsMatch(t, []int32{parent.ID, comment.ID, reply.ID}, listMemoIDs(store.Normal))
+	relations, err := ts.ListMemoRelations(ctx, &store.FindMemoRelation{MemoIDList: []int32{comment.ID, reply.ID}})
+	require.NoError(t, err)
+	require.Len(t, relations, 2)
+	_, err = db.ExecContext(ctx, "DROP TRIGGER reject_parent_delete")
+	require.NoError(t, err)
+
+	require.NoError(t, ts.DeleteMemo(ctx, &store.DeleteMemo{ID: parent.ID, CascadeComments: true}))
+	require.Empty(t, listMemoIDs(store.Normal))
+	require.ElementsMatch(t, []int32{parent.ID, comment.ID, reply.ID}, listMemoIDs(store.Archived))
+	require.NoError(t, ts.DeleteMemo(ctx, &store.DeleteMemo{ID: parent.ID, Hard: true, CascadeComments: true}))
+	require.Empty(t, listMemoIDs(store.Archived))
+	relations, err = ts.ListMemoRelations(ctx, &store.FindMemoRelation{MemoIDList: []int32{comment.ID, reply.ID}})
+	require.NoError(t, err)
+	require.Empty(t, relations)
+	ts.Close()
+}
+
+func TestMemoListWithCursor(t *testing.T) {
+	ctx := context.Background()
+	ts := NewTestingStore(ctx, t)
+	user, err := createTestingHostUser(ctx, ts)
+	require.NoError(t, err)
+	// Some memos share created_ts so that the pages have to break ties by id.
+	createdTsList := []int64{100, 200, 200, 200, 300, 400, 400}
+	for i, createdTs := range createdTsList {
+		memo, err := ts.CreateMemo(ctx, &store.Memo{
+			UID:        fmt.Sprintf("memo-%d", i),
+			CreatorID:  user.ID,
+			Content:    fmt.Sprintf("memo %d", i),
+			Visibility: store.Public,
+		})
+		require.NoError(t, err)
+		err = ts.UpdateMemo(ctx, &store.UpdateMemo{ID: memo.ID, CreatedTs: &createdTs})
+		require.NoError(t, err)
+	}
+
+	for _, orderByTimeAsc := range []bool{false, true} {
+		seen := map[string]bool{}
+		var cursor *string
+		for page := 0; ; page++ {
+			limit := 3
+			memos, next, err := ts.ListMemosWithCursor(ctx, &store.FindMemo{
+				CreatorID:      &user.ID,
+				Limit:          &limit,
+				Cursor:         cursor,
+				OrderByTimeAsc: orderByTimeAsc,
+			})
+			require.NoError(t, err)
+			for _, memo := range memos {
+				require.False(t, seen[memo.UID], "duplicate memo %s", memo.UID)
+				seen[memo.UID] = true
+			}
+			if page == 0 {
+				// A memo created between two pages must not shift the following pages.
+				createdTs := int64(250)
+				memo, err := ts.CreateMemo(ctx, &store.Memo{
+					UID:        fmt.Sprintf("inserted-%t", orderByTimeAsc),
+					CreatorID:  user.ID,
+					Content:    "inserted",
+					Visibility: store.Public,
+				})
+				require.NoError(t, err)
+				err = ts.UpdateMemo(ctx, &store.UpdateMemo{ID: memo.ID, CreatedTs: &createdTs})
+				require.NoError(t, err)
+			}
+			if next == "" {
+				break
+			}
+			cursor = &next
+		}
+		for i := range createdTsList {
+			require.True(t, seen[fmt.Sprintf("memo-%d", i)], "missing memo-%d", i)
+		}
+		require.True(t, seen[fmt.Sprintf("inserted-%t", orderByTimeAsc)])
+	}
+
+	invalid := "not-a-cursor"
+	_, err = ts.ListMemos(ctx, &store.FindMemo{Cursor: &invalid})
+	require.Error(t, err)
+	ts.Close()
+}
+
+func TestMemoListByTagsInsensitive(t *testing.T) {
+	ctx := context.Background()
+	ts := NewTestingStore(ctx, t)
+	user, err := createTestingHostUser(ctx, ts)
+	require.NoError(t, err)
+	for i, tags := range [][]string{{"Travel"}, {"travel", "Café/Paris"}, {"cafeteria"}, {"Ümlaut"}} {
+		_, err := ts.CreateMemo(ctx, &store.Memo{
+			UID:        fmt.Sprintf("memo-%d", i),
+			CreatorID:  user.ID,
+			Content:    "test_content",
+			Visibility: store.Public,
+			Payload:    &storepb.MemoPayload{Tags: tags},
+		})
+		require.NoError(t, err)
+	}
+
+	tests := []struct {
+		tag         string
+		insensitive bool
+		uids        []string
+	}{
+		{tag: "TRAVEL", insensitive: true, uids: []string{"memo-1", "memo-0"}},
+		{tag: "cafe", uids: []string{}},
+		{tag: "cafe", insensitive: true, uids: []string{"memo-1"}},
+		{tag: "CAFÉ/paris", insensitive: true, uids: []string{"memo-1"}},
+		{tag: "umlaut", insensitive: true, uids: []string{"memo-3"}},
+	}
+	for _, test := range tests {
+		memos, err := ts.ListMemos(ctx, &store.FindMemo{
+			PayloadFind: &store.FindMemoPayload{
+				TagSearch:            []string{test.tag},
+				TagSearchInsensitive: test.insensitive,
+			},
+		})
+		require.NoError(t, err)
+		uids := []string{}
+		for _, memo := range memos {
+			uids = append(uids, memo.UID)
+		}
+		require.Equal(t, test.uids, uids, test.tag)
+	}
+
+	// The normalized tags follow the tags when the payload is updated.
+	memo, err := ts.GetMemo(ctx, &store.FindMemo{UID: &[]string{"memo-0"}[0]})
+	require.NoError(t, err)
+	require.Equal(t, []string{"travel"}, memo.Payload.NormalizedTags)
+	err = ts.UpdateMemo(ctx, &store.UpdateMemo{ID: memo.ID, Payload: &storepb.MemoPayload{Tags: []string{"Résumé", "resume"}}})
+	require.NoError(t, err)
+	memo, err = ts.GetMemo(ctx, &store.FindMemo{ID: &memo.ID})
+	require.NoError(t, err)
+	require.Equal(t, []string{"resume"}, memo.Payload.NormalizedTags)
+	ts.Close()
+}
+
+func TestCountMemos(t *testing.T) {
+	ctx := context.Background()
+	ts := NewTestingStore(ctx, t)
+	user, err := createTestingHostUser(ctx, ts)
+	require.NoError(t, err)
+	memos := []*store.Memo{
+		{UID: "public-tagged", Content: "#travel", Visibility: store.Public, Payload: &storepb.MemoPayload{Tags: []string{"travel"}}},
+		{UID: "public", Content: "public", Visibility: store.Public},
+		{UID: "private-tagged", Content: "#travel/japan", Visibility: store.Private, Payload: &storepb.MemoPayload{Tags: []string{"travel/japan"}}},
+		{UID: "protected", Content: "protected", Visibility: store.Protected},
+		{UID: "comment", Content: "comment", Visibility: store.Public},
+	}
+	for _, memo := range memos {
+		memo.CreatorID = user.ID
+		_, err := ts.CreateMemo(ctx, memo)
+		require.NoError(t, err)
+	}
+	_, err = ts.UpsertMemoRelation(ctx, &store.MemoRelation{
+		MemoID:        memos[4].ID,
+		RelatedMemoID: memos[1].ID,
+		Type:          store.MemoRelationComment,
+	})
+	require.NoError(t, err)
+
+	limit := 1
+	for _, find := range []*store.FindMemo{
+		{},
+		{CreatorID: &user.ID},
+		{VisibilityList: []store.Visibility{store.Public}},
+		{VisibilityList: []store.Visibility{store.Private, store.Protected}},
+		{PayloadFind: &store.FindMemoPayload{TagSearch: []string{"travel"}}},
+		{PayloadFind: &store.FindMemoPayload{TagSearch: []string{"travel"}}, VisibilityList: []store.Visibility{store.Public}},
+		{ExcludeComments: true},
+		{ParentID: &memos[1].ID},
+		{ContentSearch: []string{"public"}},
+	} {
+		list, err := ts.ListMemos(ctx, find)
+		require.NoError(t, err)
+		count, err := ts.CountMemos(ctx, find)
+		require.NoError(t, err)
+		require.Equal(t, len(list), count)
+		// The limit only applies to listing.
+		find.Limit = &limit
+		count, err = ts.CountMemos(ctx, find)
+		require.NoError(t, err)
+		require.Equal(t, len(list), count)
+	}
+	count, err := ts.CountMemos(ctx, &store.FindMemo{PayloadFind: &store.FindMemoPayload{TagSearch: []string{"travel"}}})
+	require.NoError(t, err)
+	require.Equal(t, 2, count)
+	ts.Close()
+}
+
+func TestMemoListWithRelatedMemos(t *testing.T) {
+	ctx := context.Background()
+	ts := NewTestingStore(ctx, t)
+	user, err := createTestingHostUser(ctx, ts)
+	require.NoError(t, err)
+	// The viewer is only compared with the creators of the memos.
+	otherID := user.ID + 1
+	memo, err := ts.CreateMemo(ctx, &store.Memo{UID: "memo", CreatorID: user.ID, Content: "memo", Visibility: store.Public})
+	require.NoError(t, err)
+	lonely, err := ts.CreateMemo(ctx, &store.Memo{UID: "lonely", CreatorID: user.ID, Content: "lonely", Visibility: store.Public})
+	require.NoError(t, err)
+	for _, visibility := range []store.Visibility{store.Public, store.Protected, store.Private} {
+		related, err := ts.CreateMemo(ctx, &store.Memo{
+			UID:        strings.ToLower(string(visibility)),
+			CreatorID:  user.ID,
+			Content:    string(visibility),
+			Visibility: visibility,
+		})
+		require.NoError(t, err)
+		_, err = ts.UpsertMemoRelation(ctx, &store.MemoRelation{MemoID: memo.ID, RelatedMemoID: related.ID, Type: store.MemoRelationReference})
+		require.NoError(t, err)
+	}
+
+	getRelatedContents := func(memo *store.Memo) []string {
+		contents := []string{}
+		for _, relatedMemo := range memo.RelatedMemos {
+			contents = append(contents, relatedMemo.Content)
+		}
+		return contents
+	}
+	tests := []struct {
+		viewerID *int32
+		contents []string
+	}{
+		{viewerID: nil, contents: []string{"PUBLIC"}},
+		{viewerID: &otherID, contents: []string{"PUBLIC", "PROTECTED"}},
+		{viewerID: &user.ID, contents: []string{"PUBLIC", "PROTECTED", "PRIVATE"}},
+	}
+	for _, test := range tests {
+		memos, err := ts.ListMemos(ctx, &store.FindMemo{
+			IDList:              []int32{memo.ID, lonely.ID},
+			IncludeRelatedMemos: true,
+			ViewerID:            test.viewerID,
+		})
+		require.NoError(t, err)
+		require.Len(t, memos, 2)
+		for _, found := range memos {
+			if found.ID == memo.ID {
+				require.ElementsMatch(t, test.contents, getRelatedContents(found))
+			} else {
+				require.Empty(t, found.RelatedMemos)
+			}
+		}
+	}
+
+	// Archived memos are not listed as references.
+	publicUID := "public"
+	public, err := ts.GetMemo(ctx, &store.FindMemo{UID: &publicUID})
+	require.NoError(t, err)
+	require.NoError(t, ts.DeleteMemo(ctx, &store.DeleteMemo{ID: public.ID}))
+	found, err := ts.GetMemo(ctx, &store.FindMemo{ID: &memo.ID, IncludeRelatedMemos: true, ViewerID: &user.ID})
+	require.NoError(t, err)
+	require.ElementsMatch(t, []string{"PROTECTED", "PRIVATE"}, getRelatedContents(found))
+
+	// Comments are not references.
+	_, err = ts.UpsertMemoRelation(ctx, &store.MemoRelation{MemoID: lonely.ID, RelatedMemoID: memo.ID, Type: store.MemoRelationComment})
+	require.NoError(t, err)
+	found, err = ts.GetMemo(ctx, &store.FindMemo{ID: &lonely.ID, IncludeRelatedMemos: true})
+	require.NoError(t, err)
+	require.Empty(t, found.RelatedMemos)
+	ts.Close()
+}
+
+func TestCreateMemoGeneratesUID(t *testing.T) {
+	ctx := context.Background()
+	ts := NewTestingStore(ctx, t)
+	user, err := createTestingHostUser(ctx, ts)
+	require.NoError(t, err)
+
+	memo, err := ts.CreateMemo(ctx, &store.Memo{CreatorID: user.ID, Content: "default", Visibility: store.Public})
+	require.NoError(t, err)
+	require.Len(t, memo.UID, 12)
+	found, err := ts.GetMemo(ctx, &store.FindMemo{UID: &memo.UID})
+	require.NoError(t, err)
+	require.Equal(t, memo.ID, found.ID)
+
+	// A generated uid that is taken already is retried.
+	_, err = ts.CreateMemo(ctx, &store.Memo{UID: "taken", CreatorID: user.ID, Content: "taken", Visibility: store.Public})
+	require.NoError(t, err)
+	uids := []string{"taken", "taken", "fresh"}
+	attempts := 0
+	ts.SetMemoUIDGenerator(func() (string, error) {
+		uid := uids[attempts]
+		attempts++
+		return uid, nil
+	})
+	memo, err = ts.CreateMemo(ctx, &store.Memo{CreatorID: user.ID, Content: "retried", Visibility: store.Public})
+	require.NoError(t, err)
+	require.Equal(t, "fresh", memo.UID)
+	require.Equal(t, 3, attempts)
+
+	// Creating fails once all attempts collide.
+	ts.SetMemoUIDGenerator(func() (string, error) {
+		return "taken", nil
+	})
+	_, err = ts.CreateMemo(ctx, &store.Memo{CreatorID: user.ID, Content: "failed", Visibility: store.Public})
+	require.Error(t, err)
+	ts.Close()
+}
+
+// racingDriver creates a memo with the uid of the first memo it creates just before it, as a
+// concurrent creation could after the uid was checked.
+type racingDriver struct {
+	store.Driver
+	raced bool
+}
+
+func (d *racingDriver) CreateMemo(ctx context.Context, create *store.Memo) (*store.Memo, error) {
+	if !d.raced {
+		d.raced = true
+		racer := *create
+		if _, err := d.Driver.CreateMemo(ctx, &racer); err != nil {
+			return nil, err
+		}
+	}
+	return d.Driver.CreateMemo(ctx, create)
+}
+
+func TestCreateMemoRetriesTakenUID(t *testing.T) {
+	ctx := context.Background()
+	ts := NewTestingStore(ctx, t)
+	user, err := createTestingHostUser(ctx, ts)
+	require.NoError(t, err)
+	ts = store.New(&racingDriver{Driver: ts.GetDriver()}, ts.Profile)
+	uids := []string{"raced", "fresh"}
+	ts.SetMemoUIDGenerator(func() (string, error) {
+		uid := uids[0]
+		uids = uids[1:]
+		return uid, nil
+	})
+
+	memo, err := ts.CreateMemo(ctx, &store.Memo{CreatorID: user.ID, Content: "retried", Visibility: store.Public})
+	require.NoError(t, err)
+	require.Equal(t, "fresh", memo.UID)
+	require.Empty(t, uids)
+	uid := "raced"
+	raced, err := ts.GetMemo(ctx, &store.FindMemo{UID: &uid})
+	require.NoError(t, err)
+	require.NotNil(t, raced)
+
+	// A uid given by the caller is not replaced.
+	_, err = ts.CreateMemo(ctx, &store.Memo{UID: "raced", CreatorID: user.ID, Content: "given", Visibility: store.Public})
+	require.ErrorIs(t, err, store.ErrMemoUIDConflict)
+	ts.Close()
+}
+
+func TestTransferMemoOwnership(t *testing.T) {
+	ctx := context.Background()
+	ts := NewTestingStore(ctx, t)
+	user, err := createTestingHostUser(ctx, ts)
+	require.NoError(t, err)
+	other, err := ts.CreateUser(ctx, &store.User{Username: "other", Role: store.RoleUser, Email: "other@test.com"})
+	require.NoError(t, err)
+	archived, err := ts.CreateUser(ctx, &store.User{Username: "archived", Role: store.RoleUser, Email: "archived@test.com"})
+	require.NoError(t, err)
+	archivedStatus := store.Archived
+	_, err = ts.UpdateUser(ctx, &store.UpdateUser{ID: archived.ID, RowStatus: &archivedStatus})
+	require.NoError(t, err)
+
+	memo, err := ts.CreateMemo(ctx, &store.Memo{UID: "memo", CreatorID: user.ID, Content: "memo", Visibility: store.Private})
+	require.NoError(t, err)
+	resource, err := ts.CreateResource(ctx, &store.Resource{
+		UID:       "resource",
+		CreatorID: user.ID,
+		Filename:  "test.txt",
+		Blob:      []byte("test"),
+		Type:      "text/plain",
+		Size:      4,
+		MemoID:    &memo.ID,
+	})
+	require.NoError(t, err)
+
+	// The source user must own the memo.
+	err = ts.TransferMemoOwnership(ctx, memo.ID, other.ID, user.ID)
+	require.Error(t, err)
+	// The destination user must not be archived.
+	err = ts.TransferMemoOwnership(ctx, memo.ID, user.ID, archived.ID)
+	require.Error(t, err)
+	found, err := ts.GetMemo(ctx, &store.FindMemo{ID: &memo.ID})
+	require.NoError(t, err)
+	require.Equal(t, user.ID, found.CreatorID)
+
+	err = ts.TransferMemoOwnership(ctx, memo.ID, user.ID, other.ID)
+	require.NoError(t, err)
+	found, err = ts.GetMemo(ctx, &store.FindMemo{ID: &memo.ID})
+	require.NoError(t, err)
+	require.Equal(t, other.ID, found.CreatorID)
+	require.Equal(t, memo.Version+1, found.Version)
+	foundResource, err := ts.GetResource(ctx, &store.FindResource{ID: &resource.ID})
+	require.NoError(t, err)
+	require.Equal(t, other.ID, foundResource.CreatorID)
+	ts.Close()
+}
+
+func TestBulkUpdateMemoVisibility(t *testing.T) {
+	ctx := context.Background()
+	ts := NewTestingStore(ctx, t)
+	user, err := createTestingHostUser(ctx, ts)
+	require.NoError(t, err)
+	other, err := ts.CreateUser(ctx, &store.User{Username: "other", Role: store.RoleUser, Email: "other@test.com"})
+	require.NoError(t, err)
+
+	visibilities, versions := map[string]store.Visibility{}, map[string]int32{}
+	for uid, memo := range map[string]*store.Memo{
+		"public-1":  {CreatorID: user.ID, Visibility: store.Public},
+		"public-2":  {CreatorID: user.ID, Visibility: store.Public},
+		"protected": {CreatorID: user.ID, Visibility: store.Protected},
+		"private":   {CreatorID: user.ID, Visibility: store.Private},
+		"other":     {CreatorID: other.ID, Visibility: store.Public},
+	} {
+		memo.UID, memo.Content = uid, uid
+		memo, err := ts.CreateMemo(ctx, memo)
+		require.NoError(t, err)
+		visibilities[uid], versions[uid] = memo.Visibility, memo.Version
+	}
+	listener := &testingEventListener{}
+	ts.AddEventListener(listener)
+
+	count, err := ts.BulkUpdateMemoVisibility(ctx, user.ID, store.Public, store.Private)
+	require.NoError(t, err)
+	require.Equal(t, 2, count)
+	visibilities["public-1"], visibilities["public-2"] = store.Private, store.Private
+	versions["public-1"]++
+	versions["public-2"]++
+	for uid, visibility := range visibilities {
+		memo, err := ts.GetMemo(ctx, &store.FindMemo{UID: &uid})
+		require.NoError(t, err)
+		require.Equal(t, visibility, memo.Visibility, uid)
+		require.Equal(t, versions[uid], memo.Version, uid)
+	}
+	require.Len(t, listener.events, 2)
+	for _, event := range listener.events {
+		require.IsType(t, &store.MemoUpdated{}, event)
+	}
+
+	// Nothing matches anymore.
+	count, err = ts.BulkUpdateMemoVisibility(ctx, user.ID, store.Public, store.Private)
+	require.NoError(t, err)
+	require.Equal(t, 0, count)
+	require.Len(t, listener.events, 2)
+	_, err = ts.BulkUpdateMemoVisibility(ctx, user.ID, store.Public, "HIDDEN")
+	require.Error(t, err)
+	ts.Close()
+}
+
+func TestMemoListByResources(t *testing.T) {
+	ctx := context.Background()
+	ts := NewTestingStore(ctx, t)
+	user, err := createTestingHostUser(ctx, ts)
+	require.NoError(t, err)
+	resourceTypes := map[string]string{
+		"image": "image/png",
+		"file":  "application/pdf",
+		"plain": "",
+	}
+	for uid, resourceType := range resourceTypes {
+		memo, err := ts.CreateMemo(ctx, &store.Memo{UID: uid, CreatorID: user.ID, Content: uid, Visibility: store.Public})
+		require.NoError(t, err)
+		if resourceType == "" {
+			continue
+		}
+		_, err = ts.CreateResource(ctx, &store.Resource{
+			UID:       uid + "-resource",
+			CreatorID: user.ID,
+			Filename:  uid,
+			Blob:      []byte(uid),
+			Type:      resourceType,
+			Size:      int64(len(uid)),
+			MemoID:    &memo.ID,
+		})
+		require.NoError(t, err)
+	}
+	// A resource without a memo does not count.
+	_, err = ts.CreateResource(ctx, &store.Resource{UID: "unattached", CreatorID: user.ID, Filename: "unattached", Type: "image/png"})
+	require.NoError(t, err)
+
+	hasResources, hasNoResources := true, false
+	tests := []struct {
+		find *store.FindMemo
+		uids []string
+	}{
+		{find: &store.FindMemo{HasResources: &hasResources}, uids: []string{"image", "file"}},
+		{find: &store.FindMemo{HasResources: &hasNoResources}, uids: []string{"plain"}},
+		{find: &store.FindMemo{ResourceType: "image/*"}, uids: []string{"image"}},
+		{find: &store.FindMemo{HasResources: &hasResources, ResourceType: "application/pdf"}, uids: []string{"file"}},
+		{find: &store.FindMemo{HasResources: &hasNoResources, ResourceType: "image/*"}, uids: []string{"file", "plain"}},
+		{find: &store.FindMemo{ResourceType: "video/*"}, uids: []string{}},
+	}
+	for _, test := range tests {
+		memos, err := ts.ListMemos(ctx, test.find)
+		require.NoError(t, err)
+		uids := []string{}
+		for _, memo := range memos {
+			uids = append(uids, memo.UID)
+		}
+		require.ElementsMatch(t, test.uids, uids)
+	}
+	ts.Close()
+}
+
+func TestMemoListNoTags(t *testing.T) {
+	ctx := context.Background()
+	ts := NewTestingStore(ctx, t)
+	user, err := createTestingHostUser(ctx, ts)
+	require.NoError(t, err)
+	other, err := ts.CreateUser(ctx, &store.User{Username: "other", Role: store.RoleUser, Email: "other@test.com"})
+	require.NoError(t, err)
+	memos := []*store.Memo{
+		{UID: "tagged", CreatorID: user.ID, Visibility: store.Public, Payload: &storepb.MemoPayload{Tags: []string{"travel"}}},
+		{UID: "untagged", CreatorID: user.ID, Visibility: store.Public, Payload: &storepb.MemoPayload{}},
+		{UID: "emptytags", CreatorID: user.ID, Visibility: store.Public, Payload: &storepb.MemoPayload{Tags: []string{}}},
+		{UID: "private", CreatorID: user.ID, Visibility: store.Private, Payload: &storepb.MemoPayload{}},
+		{UID: "others", CreatorID: other.ID, Visibility: store.Public, Payload: &storepb.MemoPayload{}},
+		{UID: "otherstagged", CreatorID: other.ID, Visibility: store.Public, Payload: &storepb.MemoPayload{Tags: []string{"work"}}},
+	}
+	for _, memo := range memos {
+		_, err := ts.CreateMemo(ctx, memo)
+		require.NoError(t, err)
+	}
+
+	tests := []struct {
+		find *store.FindMemo
+		uids []string
+	}{
+		{find: &store.FindMemo{NoTags: true}, uids: []string{"untagged", "emptytags", "private", "others"}},
+		{find: &store.FindMemo{NoTags: true, CreatorID: &user.ID}, uids: []string{"untagged", "emptytags", "private"}},
+		{find: &store.FindMemo{NoTags: true, CreatorID: &user.ID, VisibilityList: []store.Visibility{store.Public}}, uids: []string{"untagged", "emptytags"}},
+		{find: &store.FindMemo{CreatorID: &other.ID}, uids: []string{"others", "otherstagged"}},
+	}
+	for _, test := range tests {
+		memos, err := ts.ListMemos(ctx, test.find)
+		require.NoError(t, err)
+		uids := []string{}
+		for _, memo := range memos {
+			uids = append(uids, memo.UID)
+		}
+		require.ElementsMatch(t, test.uids, uids)
+	}
+	ts.Close()
+}
+
+func TestListUserTags(t *testing.T) {
+	ctx := context.Background()
+	ts := NewTestingStore(ctx, t)
+	user, err := createTestingHostUser(ctx, ts)
+	require.NoError(t, err)
+	other, err := ts.CreateUser(ctx, &store.User{Username: "other", Role: store.RoleUser, Email: "other@test.com"})
+	require.NoError(t, err)
+	memos := []*store.Memo{
+		{UID: "m1", CreatorID: user.ID, Visibility: store.Public, Payload: &storepb.MemoPayload{Tags: []string{"travel", "food"}}},
+		{UID: "m2", CreatorID: user.ID, Visibility: store.Private, Payload: &storepb.MemoPayload{Tags: []string{"travel", "work", "travel"}}},
+		{UID: "m3", CreatorID: user.ID, Visibility: store.Protected, Payload: &storepb.MemoPayload{Tags: []string{"food", "travel", "book"}}},
+		{UID: "m4", CreatorID: user.ID, Visibility: store.Public},
+		{UID: "archived", CreatorID: user.ID, Visibility: store.Public, Payload: &storepb.MemoPayload{Tags: []string{"archived"}}},
+		{UID: "comment", CreatorID: user.ID, Visibility: store.Public, Payload: &storepb.MemoPayload{Tags: []string{"comment"}}},
+		{UID: "other", CreatorID: other.ID, Visibility: store.Public, Payload: &storepb.MemoPayload{Tags: []string{"work", "other"}}},
+	}
+	for _, memo := range memos {
+		memo.Content = memo.UID
+		_, err := ts.CreateMemo(ctx, memo)
+		require.NoError(t, err)
+	}
+	archived := store.Archived
+	require.NoError(t, ts.UpdateMemo(ctx, &store.UpdateMemo{ID: memos[4].ID, RowStatus: &archived}))
+	_, err = ts.UpsertMemoRelation(ctx, &store.MemoRelation{
+		MemoID:        memos[5].ID,
+		RelatedMemoID: memos[0].ID,
+		Type:          store.MemoRelationComment,
+	})
+	require.NoError(t, err)
+
+	tags, err := ts.ListUserTags(ctx, user.ID, nil)
+	require.NoError(t, err)
+	require.Equal(t, []*store.TagCount{
+		{Tag: "travel", Count: 3},
+		{Tag: "food", Count: 2},
+		{Tag: "book", Count: 1},
+		{Tag: "work", Count: 1},
+	}, tags)
+
+	tags, err = ts.ListUserTags(ctx, user.ID, []store.Visibility{store.Public, store.Protected})
+	require.NoError(t, err)
+	require.Equal(t, []*store.TagCount{
+		{Tag: "food", Count: 2},
+		{Tag: "travel", Count: 2},
+		{Tag: "book", Count: 1},
+	}, tags)
+
+	tags, err = ts.ListUserTags(ctx, other.ID, []store.Visibility{store.Private})
+	require.NoError(t, err)
+	require.Empty(t, tags)
+	ts.Close()
+}
+
+func TestMemoContentLengthLimit(t *testing.T) {
+	ctx := context.Background()
+	ts := NewTestingStore(ctx, t)
+	user, err := createTestingHostUser(ctx, ts)
+	require.NoError(t, err)
+	setContentLengthLimit := func(limit int32) {
+		_, err := ts.UpsertWorkspaceSetting(ctx, &storepb.WorkspaceSetting{
+			Key: storepb.WorkspaceSettingKey_MEMO_RELATED,
+			Value: &storepb.WorkspaceSetting_MemoRelatedSetting{
+				MemoRelatedSetting: &storepb.WorkspaceMemoRelatedSetting{ContentLengthLimit: limit},
+			},
+		})
+		require.NoError(t, err)
+	}
+	limit := store.DefaultContentLengthLimit
+
+	memo, err := ts.CreateMemo(ctx, &store.Memo{UID: "at-limit", CreatorID: user.ID, Content: strings.Repeat("a", limit), Visibility: store.Public})
+	require.NoError(t, err)
+	_, err = ts.CreateMemo(ctx, &store.Memo{UID: "over-limit", CreatorID: user.ID, Content: strings.Repeat("a", limit+1), Visibility: store.Public})
+	var contentTooLongErr *store.ContentTooLongError
+	require.ErrorAs(t, err, &contentTooLongErr)
+	require.Equal(t, limit+1, contentTooLongErr.Length)
+	require.Equal(t, limit, contentTooLongErr.Limit)
+	// Bytes of UTF-8 are counted rather than characters, "中" is 3 bytes.
+	_, err = ts.CreateMemo(ctx, &store.Memo{UID: "multibyte", CreatorID: user.ID, Content: strings.Repeat("中", limit/3+1), Visibility: store.Public})
+	require.ErrorAs(t, err, &contentTooLongErr)
+	require.Equal(t, (limit/3+1)*3, contentTooLongErr.Length)
+
+	content := strings.Repeat("b", limit+1)
+	err = ts.UpdateMemo(ctx, &store.UpdateMemo{ID: memo.ID, Content: &content})
+	require.ErrorAs(t, err, &contentTooLongErr)
+
+	// A memo created under a higher limit stays editable, and its content may be shrunk.
+	setContentLengthLimit(int32(limit * 2))
+	bigMemo, err := ts.CreateMemo(ctx, &store.Memo{UID: "big", CreatorID: user.ID, Content: strings.Repeat("a", limit*2), Visibility: store.Public})
+	require.NoError(t, err)
+	setContentLengthLimit(int32(limit))
+	pinned := true
+	require.NoError(t, ts.UpdateMemo(ctx, &store.UpdateMemo{ID: bigMemo.ID, Pinned: &pinned}))
+	content = strings.Repeat("a", limit+100)
+	err = ts.UpdateMemo(ctx, &store.UpdateMemo{ID: bigMemo.ID, Content: &content})
+	require.ErrorAs(t, err, &contentTooLongErr)
+	content = strings.Repeat("a", limit)
+	require.NoError(t, ts.UpdateMemo(ctx, &store.UpdateMemo{ID: bigMemo.ID, Content: &content}))
+	ts.Close()
+}
+
+func TestStreamMemos(t *testing.T) {
+	ctx := context.Background()
+	ts := NewTestingStore(ctx, t)
+	user, err := createTestingHostUser(ctx, ts)
+	require.NoError(t, err)
+	memoIDs := []int32{}
+	for i := 0; i < 5; i++ {
+		memo, err := ts.CreateMemo(ctx, &store.Memo{UID: fmt.Sprintf("memo-%d", i), CreatorID: user.ID, Content: fmt.Sprintf("memo %d", i), Visibility: store.Public})
+		require.NoError(t, err)
+		memoIDs = append(memoIDs, memo.ID)
+	}
+	_, err = ts.CreateMemo(ctx, &store.Memo{UID: "private-memo", CreatorID: user.ID, Content: "private", Visibility: store.Private})
+	require.NoError(t, err)
+
+	// The callback is called once per matching memo, in the list order.
+	find := &store.FindMemo{VisibilityList: []store.Visibility{store.Public}, OrderByTimeAsc: true}
+	streamedIDs := []int32{}
+	require.NoError(t, ts.StreamMemos(ctx, find, func(memo *store.Memo) error {
+		require.NotEmpty(t, memo.Content)
+		streamedIDs = append(streamedIDs, memo.ID)
+		return nil
+	}))
+	require.Equal(t, memoIDs, streamedIDs)
+
+	// An error from the callback stops the iteration.
+	errStop := errors.New("stop")
+	calls := 0
+	err = ts.StreamMemos(ctx, find, func(*store.Memo) error {
+		calls++
+		if calls == 2 {
+			return errStop
+		}
+		return nil
+	})
+	require.ErrorIs(t, err, errStop)
+	require.Equal(t, 2, calls)
+
+	require.Error(t, ts.StreamMemos(ctx, &store.FindMemo{IncludeRelatedMemos: true}, func(*store.Memo) error { return nil }))
+	ts.Close()
+}
+
+func TestListMemosByIDs(t *testing.T) {
+	ctx := context.Background()
+	ts := NewTestingStore(ctx, t)
+	user, err := createTestingHostUser(ctx, ts)
+	require.NoError(t, err)
+	memoIDs := []int32{}
+	for i := 0; i < 4; i++ {
+		visibility := store.Public
+		if i == 3 {
+			visibility = store.Private
+		}
+		memo, err := ts.CreateMemo(ctx, &store.Memo{UID: fmt.Sprintf("memo-%d", i), CreatorID: user.ID, Content: fmt.Sprintf("memo %d", i), Visibility: visibility})
+		require.NoError(t, err)
+		memoIDs = append(memoIDs, memo.ID)
+	}
+	getIDs := func(memos []*store.Memo) []int32 {
+		ids := []int32{}
+		for _, memo := range memos {
+			ids = append(ids, memo.ID)
+		}
+		return ids
+	}
+
+	// The memos are in the order of the ids, which may repeat.
+	ids := []int32{memoIDs[2], memoIDs[0], memoIDs[3], memoIDs[1], memoIDs[0]}
+	memos, err := ts.ListMemosByIDs(ctx, ids, nil)
+	require.NoError(t, err)
+	require.Equal(t, []int32{memoIDs[2], memoIDs[0], memoIDs[3], memoIDs[1]}, getIDs(memos))
+
+	// Missing ids are left out.
+	memos, err = ts.ListMemosByIDs(ctx, []int32{memoIDs[1], 9999, memoIDs[0]}, nil)
+	require.NoError(t, err)
+	require.Equal(t, []int32{memoIDs[1], memoIDs[0]}, getIDs(memos))
+
+	// So are the memos out of scope.
+	limit := 1
+	memos, err = ts.ListMemosByIDs(ctx, ids, &store.FindMemo{VisibilityList: []store.Visibility{store.Public}, Limit: &limit})
+	require.NoError(t, err)
+	require.Equal(t, []int32{memoIDs[2], memoIDs[0], memoIDs[1]}, getIDs(memos))
+	ts.Close()
+
+	// No ids need no query, so the closed store is fine.
+	memos, err = ts.ListMemosByIDs(ctx, nil, nil)
+	require.NoError(t, err)
+	require.NotNil(t, memos)
+	require.Empty(t, memos)
+}
+
+func TestListMemosUpdatedAfter(t *testing.T) {
+	ctx := context.Background()
+	ts := NewTestingStore(ctx, t)
+	user, err := createTestingHostUser(ctx, ts)
+	require.NoError(t, err)
+	// memo-0 and memo-1 were synced before the watermark, memo-2 to memo-4 were updated after it.
+	updatedTsList := []int64{1000, 1500, 2000, 2000, 3000}
+	memoIDs := []int32{}
+	for i, updatedTs := range updatedTsList {
+		memo, err := ts.CreateMemo(ctx, &store.Memo{UID: fmt.Sprintf("memo-%d", i), CreatorID: user.ID, Content: fmt.Sprintf("memo %d", i), Visibility: store.Public})
+		require.NoError(t, err)
+		require.NoError(t, ts.UpdateMemo(ctx, &store.UpdateMemo{ID: memo.ID, UpdatedTs: &updatedTs}))
+		memoIDs = append(memoIDs, memo.ID)
+	}
+	// Archiving memo-0 after the watermark turns it into a tombstone to sync.
+	require.NoError(t, ts.DeleteMemo(ctx, &store.DeleteMemo{ID: memoIDs[0]}))
+
+	watermark := int64(1800)
+	listChanges := func(limit int) []*store.Memo {
+		changes := []*store.Memo{}
+		var cursor *string
+		for {
+			memos, next, err := ts.ListMemosWithCursor(ctx, &store.FindMemo{
+				CreatorID:        &user.ID,
+				UpdatedTsAfter:   &watermark,
+				Limit:            &limit,
+				Cursor:           cursor,
+				OrderByUpdatedTs: true,
+				OrderByTimeAsc:   true,
+			})
+			require.NoError(t, err)
+			changes = append(changes, memos...)
+			if next == "" {
+				return changes
+			}
+			cursor = &next
+		}
+	}
+	for _, limit := range []int{1, 2, 10} {
+		changes := listChanges(limit)
+		uids := []string{}
+		for _, memo := range changes {
+			uids = append(uids, memo.UID)
+		}
+		// Ordered by update time and then by id, with the tombstone updated last.
+		require.Equal(t, []string{"memo-2", "memo-3", "memo-4", "memo-0"}, uids, "limit %d", limit)
+		require.Equal(t, store.Archived, changes[3].RowStatus)
+		require.Equal(t, store.Normal, changes[0].RowStatus)
+	}
+	ts.Close()
+}
+
+func TestUpdateMemoPartially(t *testing.T) {
+	ctx := context.Background()
+	ts := NewTestingStore(ctx, t)
+	user, err := createTestingHostUser(ctx, ts)
+	require.NoError(t, err)
+	memo, err := ts.CreateMemo(ctx, &store.Memo{UID: "partial", CreatorID: user.ID, Content: "original", Visibility: store.Private})
+	require.NoError(t, err)
+	oldUpdatedTs := int64(1000)
+	require.NoError(t, ts.UpdateMemo(ctx, &store.UpdateMemo{ID: memo.ID, UpdatedTs: &oldUpdatedTs}))
+	getMemo := func() *store.Memo {
+		memo, err := ts.GetMemo(ctx, &store.FindMemo{ID: &memo.ID})
+		require.NoError(t, err)
+		return memo
+	}
+
+	// Updating only pinned and visibility leaves the content and the update time untouched.
+	pinned, visibility := true, store.Public
+	require.NoError(t, ts.UpdateMemo(ctx, &store.UpdateMemo{ID: memo.ID, Pinned: &pinned, Visibility: &visibility}))
+	updated := getMemo()
+	require.True(t, updated.Pinned)
+	require.Equal(t, store.Public, updated.Visibility)
+	require.Equal(t, "original", updated.Content)
+	require.Equal(t, oldUpdatedTs, updated.UpdatedTs)
+
+	// Updating the content updates the update time, and leaves pinned untouched.
+	content := "edited"
+	before := time.Now().Unix()
+	require.NoError(t, ts.UpdateMemo(ctx, &store.UpdateMemo{ID: memo.ID, Content: &content}))
+	updated = getMemo()
+	require.Equal(t, "edited", updated.Content)
+	require.True(t, updated.Pinned)
+	require.GreaterOrEqual(t, updated.UpdatedTs, before)
+
+	// An explicit update time wins.
+	content = "backdated"
+	require.NoError(t, ts.UpdateMemo(ctx, &store.UpdateMemo{ID: memo.ID, Content: &content, UpdatedTs: &oldUpdatedTs}))
+	require.Equal(t, oldUpdatedTs, getMemo().UpdatedTs)
+
+	// Changes of pinned update the update time when the workspace setting asks.
+	_, err = ts.UpsertWorkspaceSetting(ctx, &storepb.WorkspaceSetting{
+		Key: storepb.WorkspaceSettingKey_MEMO_RELATED,
+		Value: &storepb.WorkspaceSetting_MemoRelatedSetting{
+			MemoRelatedSetting: &storepb.WorkspaceMemoRelatedSetting{UpdateTimeOnMetadataChange: true},
+		},
+	})
+	require.NoError(t, err)
+	pinned = false
+	require.NoError(t, ts.UpdateMemo(ctx, &store.UpdateMemo{ID: memo.ID, Pinned: &pinned}))
+	updated = getMemo()
+	require.False(t, updated.Pinned)
+	require.Equal(t, "backdated", updated.Content)
+	require.GreaterOrEqual(t, updated.UpdatedTs, before)
+	ts.Close()
+}
+
+func TestMemoListByCreatorUsername(t *testing.T) {
+	ctx := context.Background()
+	ts := NewTestingStore(ctx, t)
+	user, err := createTestingHostUser(ctx, ts)
+	require.NoError(t, err)
+	other, err := ts.CreateUser(ctx, &store.User{Username: "Other", Role: store.RoleUser, Email: "other@test.com"})
+	require.NoError(t, err)
+	for i, creatorID := range []int32{user.ID, other.ID, other.ID} {
+		_, err := ts.CreateMemo(ctx, &store.Memo{
+			UID:        fmt.Sprintf("memo-%d", i),
+			CreatorID:  creatorID,
+			Content:    "test_content",
+			Visibility: store.Public,
+		})
+		require.NoError(t, err)
+	}
+
+	tests := []struct {
+		username    string
+		insensitive bool
+		creatorID   *int32
+		uids        []string
+	}{
+		{username: "Other", uids: []string{"memo-2", "memo-1"}},
+		{username: "other", uids: []string{}},
+		{username: "OTHER", insensitive: true, uids: []string{"memo-2", "memo-1"}},
+		{username: user.Username, uids: []string{"memo-0"}},
+		{username: "Other", creatorID: &user.ID, uids: []string{}},
+		{username: "Other", creatorID: &other.ID, uids: []string{"memo-2", "memo-1"}},
+		{username: "nobody", insensitive: true, uids: []string{}},
+	}
+	for _, test := range tests {
+		memos, err := ts.ListMemos(ctx, &store.FindMemo{
+			CreatorID:                  test.creatorID,
+			CreatorUsername:            &test.username,
+			CreatorUsernameInsensitive: test.insensitive,
+		})
+		require.NoError(t, err)
+		uids := []string{}
+		for _, memo := range memos {
+			uids = append(uids, memo.UID)
+		}
+		require.Equal(t, test.uids, uids, test.username)
+	}
+	ts.Close()
+}
+
+func TestUpdateMemoWithExpectedVersion(t *testing.T) {
+	ctx := context.Background()
+	ts := NewTestingStore(ctx, t)
+	user, err := createTestingHostUser(ctx, ts)
+	require.NoError(t, err)
+	memo, err := ts.CreateMemo(ctx, &store.Memo{
+		UID:        "memo",
+		CreatorID:  user.ID,
+		Content:    "v0",
+		Visibility: store.Public,
+	})
+	require.NoError(t, err)
+	memo, err = ts.GetMemo(ctx, &store.FindMemo{ID: &memo.ID})
+	require.NoError(t, err)
+	require.Equal(t, int32(0), memo.Version)
+
+	content := "v1"
+	err = ts.UpdateMemo(ctx, &store.UpdateMemo{ID: memo.ID, Content: &content, ExpectedVersion: &memo.Version})
+	require.NoError(t, err)
+	updated, err := ts.GetMemo(ctx, &store.FindMemo{ID: &memo.ID})
+	require.NoError(t, err)
+	require.Equal(t, "v1", updated.Content)
+	require.Equal(t, int32(1), updated.Version)
+
+	// The memo is at version 1 now, so updates based on version 0 are stale.
+	staleContent := "stale"
+	err = ts.UpdateMemo(ctx, &store.UpdateMemo{ID: memo.ID, Content: &staleContent, ExpectedVersion: &memo.Version})
+	require.ErrorIs(t, err, store.ErrMemoVersionConflict)
+	pinned := true
+	err = ts.UpdateMemo(ctx, &store.UpdateMemo{ID: memo.ID, Pinned: &pinned, ExpectedVersion: &memo.Version})
+	require.ErrorIs(t, err, store.ErrMemoVersionConflict)
+	updated, err = ts.GetMemo(ctx, &store.FindMemo{ID: &memo.ID})
+	require.NoError(t, err)
+	require.Equal(t, "v1", updated.Content)
+	require.False(t, updated.Pinned)
+	require.Equal(t, int32(1), updated.Version)
+
+	// Updates without an expected version bump the version too.
+	err = ts.UpdateMemo(ctx, &store.UpdateMemo{ID: memo.ID, Pinned: &pinned})
+	require.NoError(t, err)
+	updated, err = ts.GetMemo(ctx, &store.FindMemo{ID: &memo.ID})
+	require.NoError(t, err)
+	require.True(t, updated.Pinned)
+	require.Equal(t, int32(2), updated.Version)
+	ts.Close()
+}
+
+func TestMemoListByLocationBounds(t *testing.T) {
+	ctx := context.Background()
+	ts := NewTestingStore(ctx, t)
+	user, err := createTestingHostUser(ctx, ts)
+	require.NoError(t, err)
+	createMemo := func(uid string, location *storepb.MemoPayload_Location) *store.Memo {
+		memo, err := ts.CreateMemo(ctx, &store.Memo{
+			UID:        uid,
+			CreatorID:  user.ID,
+			Content:    uid,
+			Visibility: store.Public,
+			Payload:    &storepb.MemoPayload{Location: location},
+		})
+		require.NoError(t, err)
+		return memo
+	}
+	createMemo("berlin", &storepb.MemoPayload_Location{Latitude: 52.52, Longitude: 13.405})
+	paris := createMemo("paris", &storepb.MemoPayload_Location{Latitude: 48.8566, Longitude: 2.3522})
+	createMemo("fiji", &storepb.MemoPayload_Location{Latitude: -17.7134, Longitude: 178.065})
+	createMemo("samoa", &storepb.MemoPayload_Location{Latitude: -13.759, Longitude: -172.1046})
+	createMemo("nowhere", nil)
+	listUIDs := func(bounds *store.MemoLocationBounds) []string {
+		memos, err := ts.ListMemos(ctx, &store.FindMemo{LocationBounds: bounds})
+		require.NoError(t, err)
+		uids := []string{}
+		for _, memo := range memos {
+			uids = append(uids, memo.UID)
+		}
+		return uids
+	}
+
+	require.ElementsMatch(t, []string{"berlin"}, listUIDs(&store.MemoLocationBounds{MinLatitude: 50, MaxLatitude: 55, MinLongitude: 10, MaxLongitude: 15}))
+	require.ElementsMatch(t, []string{"berlin", "paris"}, listUIDs(&store.MemoLocationBounds{MinLatitude: 45, MaxLatitude: 55, MinLongitude: 0, MaxLongitude: 15}))
+	require.Empty(t, listUIDs(&store.MemoLocationBounds{MinLatitude: 0, MaxLatitude: 10, MinLongitude: 0, MaxLongitude: 10}))
+	// A box from 170 to -170 crosses the antimeridian and covers Fiji and Samoa, not Europe.
+	require.ElementsMatch(t, []string{"fiji", "samoa"}, listUIDs(&store.MemoLocationBounds{MinLatitude: -20, MaxLatitude: 0, MinLongitude: 170, MaxLongitude: -170}))
+
+	// Updating the payload moves the memo.
+	err = ts.UpdateMemo(ctx, &store.UpdateMemo{ID: paris.ID, Payload: &storepb.MemoPayload{Location: &storepb.MemoPayload_Location{Latitude: 52.5, Longitude: 13.4}}})
+	require.NoError(t, err)
+	require.ElementsMatch(t, []string{"berlin", "paris"}, listUIDs(&store.MemoLocationBounds{MinLatitude: 50, MaxLatitude: 55, MinLongitude: 10, MaxLongitude: 15}))
+
+	// Coordinates out of range are rejected on write.
+	for _, location := range []*storepb.MemoPayload_Location{
+		{Latitude: 90.5, Longitude: 0},
+		{Latitude: -91, Longitude: 0},
+		{Latitude: 0, Longitude: 180.1},
+		{Latitude: 0, Longitude: math.NaN()},
+	} {
+		_, err := ts.CreateMemo(ctx, &store.Memo{UID: "invalid", CreatorID: user.ID, Content: "invalid", Visibility: store.Public, Payload: &storepb.MemoPayload{Location: location}})
+		require.ErrorIs(t, err, store.ErrInvalidMemoLocation)
+		err = ts.UpdateMemo(ctx, &store.UpdateMemo{ID: paris.ID, Payload: &storepb.MemoPayload{Location: location}})
+		require.ErrorIs(t, err, store.ErrInvalidMemoLocation)
+	}
+	ts.Close()
+}
@@ -5,6 +5,10 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/require"
+
+	storepb "github.com/usememos/memos/proto/gen/store"
+	"github.com/usememos/memos/server/version"
+	"github.com/usememos/memos/store"
 )
 
 func TestGetCurrentSchemaVersion(t *testing.T) {
@@ -13,5 +17,39 @@ func TestGetCurrentSchemaVersion(t *testing.T) {
 
 	currentSchemaVersion, err := ts.GetCurrentSchemaVersion()
 	require.NoError(t, err)
-	require.Equal(t, "0.24.2", currentSchemaVersion)
+	require.Equal(t, "0.24.13", currentSchemaVersion)
+}
+
+func TestMigrateRefusesNewerSchemaVersion(t *testing.T) {
+	ctx := context.Background()
+	ts := NewTestingStore(ctx, t)
+	// Migrating a database of the same schema version is a no-op.
+	require.NoError(t, store.New(ts.GetDriver(), ts.Profile).Migrate(ctx))
+
+	// A newer binary recorded its schema version in the migration history.
+	_, err := ts.GetDriver().UpsertMigrationHistory(ctx, &store.UpsertMigrationHistory{Version: "0.99.0"})
+	require.NoError(t, err)
+	err = store.New(ts.GetDriver(), ts.Profile).Migrate(ctx)
+	require.ErrorIs(t, err, store.ErrSchemaVersionTooNew)
+	require.Contains(t, err.Error(), "0.99.0")
+	ts.Close()
+}
+
+func TestMigrateRefusesNewerWorkspaceSchemaVersion(t *testing.T) {
+	ctx := context.Background()
+	ts := NewTestingStore(ctx, t)
+	currentSchemaVersion, err := ts.GetCurrentSchemaVersion()
+	require.NoError(t, err)
+
+	// A newer patch version recorded in the workspace basic setting only.
+	_, err = ts.UpsertWorkspaceSetting(ctx, &storepb.WorkspaceSetting{
+		Key: storepb.WorkspaceSettingKey_BASIC,
+		Value: &storepb.WorkspaceSetting_BasicSetting{BasicSetting: &storepb.WorkspaceBasicSetting{
+			SchemaVersion: version.GetMinorVersion(currentSchemaVersion) + ".99",
+		}},
+	})
+	require.NoError(t, err)
+	err = store.New(ts.GetDriver(), ts.Profile).Migrate(ctx)
+	require.ErrorIs(t, err, store.ErrSchemaVersionTooNew)
+	ts.Close()
 }
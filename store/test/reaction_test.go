@@ -46,3 +46,73 @@ func TestReactionStore(t *testing.T) {
 
 	ts.Close()
 }
+
+func TestMemoListWithReactionSummaries(t *testing.T) {
+	ctx := context.Background()
+	ts := NewTestingStore(ctx, t)
+	user, err := createTestingHostUser(ctx, ts)
+	require.NoError(t, err)
+	other, err := ts.CreateUser(ctx, &store.User{Username: "other", Role: store.RoleUser, Email: "other@test.com"})
+	require.NoError(t, err)
+
+	popular, err := ts.CreateMemo(ctx, &store.Memo{UID: "popular", CreatorID: user.ID, Content: "popular", Visibility: store.Public})
+	require.NoError(t, err)
+	lonely, err := ts.CreateMemo(ctx, &store.Memo{UID: "lonely", CreatorID: user.ID, Content: "lonely", Visibility: store.Public})
+	require.NoError(t, err)
+	for _, reaction := range []*store.Reaction{
+		{CreatorID: other.ID, ReactionType: "👍"},
+		{CreatorID: user.ID, ReactionType: "🎉"},
+		{CreatorID: user.ID, ReactionType: "👍"},
+		{CreatorID: other.ID, ReactionType: "🎉"},
+		{CreatorID: other.ID, ReactionType: "❤️"},
+	} {
+		reaction.ContentID = "memos/" + popular.UID
+		_, err := ts.UpsertReaction(ctx, reaction)
+		require.NoError(t, err)
+	}
+
+	tests := []struct {
+		viewerID  *int32
+		summaries []*store.ReactionSummary
+	}{
+		{
+			viewerID: &user.ID,
+			summaries: []*store.ReactionSummary{
+				{ContentID: "memos/popular", ReactionType: "👍", Count: 2, ViewerReacted: true},
+				{ContentID: "memos/popular", ReactionType: "🎉", Count: 2, ViewerReacted: true},
+				{ContentID: "memos/popular", ReactionType: "❤️", Count: 1, ViewerReacted: false},
+			},
+		},
+		{
+			viewerID: nil,
+			summaries: []*store.ReactionSummary{
+				{ContentID: "memos/popular", ReactionType: "👍", Count: 2, ViewerReacted: false},
+				{ContentID: "memos/popular", ReactionType: "🎉", Count: 2, ViewerReacted: false},
+				{ContentID: "memos/popular", ReactionType: "❤️", Count: 1, ViewerReacted: false},
+			},
+		},
+	}
+	for _, test := range tests {
+		memos, err := ts.ListMemos(ctx, &store.FindMemo{
+			IDList:                   []int32{popular.ID, lonely.ID},
+			IncludeReactionSummaries: true,
+			ViewerID:                 test.viewerID,
+		})
+		require.NoError(t, err)
+		require.Len(t, memos, 2)
+		for _, memo := range memos {
+			if memo.ID == popular.ID {
+				require.Equal(t, test.summaries, memo.ReactionSummaries)
+			} else {
+				require.NotNil(t, memo.ReactionSummaries)
+				require.Empty(t, memo.ReactionSummaries)
+			}
+		}
+	}
+
+	// Reaction summaries are only loaded when asked for.
+	memo, err := ts.GetMemo(ctx, &store.FindMemo{ID: &popular.ID})
+	require.NoError(t, err)
+	require.Nil(t, memo.ReactionSummaries)
+	ts.Close()
+}
@@ -0,0 +1,105 @@
+package teststore
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/lithammer/shortuuid/v4"
+	"github.com/stretchr/testify/require"
+
+	storepb "github.com/usememos/memos/proto/gen/store"
+	"github.com/usememos/memos/store"
+)
+
+func TestResourceBlobDeduplication(t *testing.T) {
+	ctx := context.Background()
+	ts := NewTestingStore(ctx, t)
+	user, err := createTestingHostUser(ctx, ts)
+	require.NoError(t, err)
+	blob := []byte("image")
+	contentHash := store.HashResourceBlob(blob)
+	localFile := filepath.Join(t.TempDir(), "image.png")
+	require.NoError(t, os.WriteFile(localFile, blob, 0644))
+
+	// The first upload has no blob to share, so its blob is saved.
+	first := &store.Resource{UID: shortuuid.New(), CreatorID: user.ID, Filename: "image.png", Blob: blob, Size: int64(len(blob)), ContentHash: contentHash}
+	duplicate, err := ts.CreateDuplicateResource(ctx, first)
+	require.NoError(t, err)
+	require.Nil(t, duplicate)
+	first.Blob, first.StorageType, first.Reference = nil, storepb.ResourceStorageType_LOCAL, localFile
+	first, err = ts.CreateResource(ctx, first)
+	require.NoError(t, err)
+
+	// The second upload shares the blob of the first.
+	second, err := ts.CreateDuplicateResource(ctx, &store.Resource{UID: shortuuid.New(), CreatorID: user.ID, Filename: "copy.png", Blob: blob, Size: int64(len(blob)), ContentHash: contentHash})
+	require.NoError(t, err)
+	require.NotNil(t, second)
+	second, err = ts.GetResource(ctx, &store.FindResource{ID: &second.ID, GetBlob: true})
+	require.NoError(t, err)
+	require.Equal(t, "copy.png", second.Filename)
+	require.Equal(t, storepb.ResourceStorageType_LOCAL, second.StorageType)
+	require.Equal(t, localFile, second.Reference)
+	require.Equal(t, contentHash, second.ContentHash)
+	require.Empty(t, second.Blob)
+
+	// Blobs are not shared between users.
+	other, err := ts.CreateUser(ctx, &store.User{Username: "other", Role: store.RoleUser, Email: "other@test.com"})
+	require.NoError(t, err)
+	duplicate, err = ts.CreateDuplicateResource(ctx, &store.Resource{UID: shortuuid.New(), CreatorID: other.ID, Filename: "image.png", Blob: blob, ContentHash: contentHash})
+	require.NoError(t, err)
+	require.Nil(t, duplicate)
+
+	// The blob is deleted with its last reference only.
+	require.NoError(t, ts.DeleteResource(ctx, &store.DeleteResource{ID: first.ID}))
+	_, err = os.Stat(localFile)
+	require.NoError(t, err)
+	require.NoError(t, ts.DeleteResource(ctx, &store.DeleteResource{ID: second.ID}))
+	_, err = os.Stat(localFile)
+	require.True(t, os.IsNotExist(err))
+	duplicate, err = ts.CreateDuplicateResource(ctx, &store.Resource{UID: shortuuid.New(), CreatorID: user.ID, Filename: "image.png", Blob: blob, ContentHash: contentHash})
+	require.NoError(t, err)
+	require.Nil(t, duplicate)
+	ts.Close()
+}
+
+func TestResourceBlobTransfer(t *testing.T) {
+	ctx := context.Background()
+	ts := NewTestingStore(ctx, t)
+	user, err := createTestingHostUser(ctx, ts)
+	require.NoError(t, err)
+	other, err := ts.CreateUser(ctx, &store.User{Username: "other", Role: store.RoleUser, Email: "other@test.com"})
+	require.NoError(t, err)
+	blob := []byte("image")
+	contentHash := store.HashResourceBlob(blob)
+	localFile := filepath.Join(t.TempDir(), "image.png")
+	require.NoError(t, os.WriteFile(localFile, blob, 0644))
+
+	transferred, err := ts.CreateMemo(ctx, &store.Memo{UID: "transferred", CreatorID: user.ID, Content: "transferred", Visibility: store.Public})
+	require.NoError(t, err)
+	kept, err := ts.CreateMemo(ctx, &store.Memo{UID: "kept", CreatorID: user.ID, Content: "kept", Visibility: store.Public})
+	require.NoError(t, err)
+	first, err := ts.CreateResource(ctx, &store.Resource{UID: shortuuid.New(), CreatorID: user.ID, Filename: "image.png", Size: int64(len(blob)), StorageType: storepb.ResourceStorageType_LOCAL, Reference: localFile, ContentHash: contentHash, MemoID: &transferred.ID})
+	require.NoError(t, err)
+	second, err := ts.CreateDuplicateResource(ctx, &store.Resource{UID: shortuuid.New(), CreatorID: user.ID, Filename: "copy.png", Size: int64(len(blob)), ContentHash: contentHash, MemoID: &kept.ID})
+	require.NoError(t, err)
+	require.NotNil(t, second)
+
+	// The reference of the transferred resource moves to the new owner, who can then share the blob.
+	require.NoError(t, ts.TransferMemoOwnership(ctx, transferred.ID, user.ID, other.ID))
+	third, err := ts.CreateDuplicateResource(ctx, &store.Resource{UID: shortuuid.New(), CreatorID: other.ID, Filename: "third.png", Size: int64(len(blob)), ContentHash: contentHash})
+	require.NoError(t, err)
+	require.NotNil(t, third)
+	require.Equal(t, localFile, third.Reference)
+
+	// The blob is kept while a resource of either user uses it.
+	require.NoError(t, ts.DeleteResource(ctx, &store.DeleteResource{ID: first.ID}))
+	require.NoError(t, ts.DeleteResource(ctx, &store.DeleteResource{ID: third.ID}))
+	_, err = os.Stat(localFile)
+	require.NoError(t, err)
+	require.NoError(t, ts.DeleteResource(ctx, &store.DeleteResource{ID: second.ID}))
+	_, err = os.Stat(localFile)
+	require.True(t, os.IsNotExist(err))
+	ts.Close()
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/lithammer/shortuuid/v4"
 	"github.com/stretchr/testify/require"
 
+	storepb "github.com/usememos/memos/proto/gen/store"
 	"github.com/usememos/memos/store"
 )
 
@@ -61,3 +62,40 @@ func TestResourceStore(t *testing.T) {
 	require.ErrorContains(t, err, "resource not found")
 	ts.Close()
 }
+
+func TestVacuumResources(t *testing.T) {
+	ctx := context.Background()
+	ts := NewTestingStore(ctx, t)
+	user, err := createTestingHostUser(ctx, ts)
+	require.NoError(t, err)
+	memo, err := ts.CreateMemo(ctx, &store.Memo{
+		UID:        shortuuid.New(),
+		CreatorID:  101,
+		Content:    "test",
+		Visibility: store.Public,
+	})
+	require.NoError(t, err)
+	resources := []*store.Resource{
+		// Kept as the creator exists.
+		{UID: shortuuid.New(), CreatorID: user.ID, Filename: "kept.txt", Blob: []byte("test")},
+		// Kept as it is related to a memo.
+		{UID: shortuuid.New(), CreatorID: 101, Filename: "related.txt", Blob: []byte("test"), MemoID: &memo.ID},
+		{UID: shortuuid.New(), CreatorID: 101, Filename: "orphaned.txt", Blob: []byte("test")},
+		// The local file does not exist, which must not keep the resource.
+		{UID: shortuuid.New(), CreatorID: 101, Filename: "missing.txt", StorageType: storepb.ResourceStorageType_LOCAL, Reference: "assets/missing.txt"},
+	}
+	for _, resource := range resources {
+		_, err := ts.CreateResource(ctx, resource)
+		require.NoError(t, err)
+	}
+
+	require.NoError(t, ts.VacuumResources(ctx))
+	list, err := ts.ListResources(ctx, &store.FindResource{})
+	require.NoError(t, err)
+	filenames := []string{}
+	for _, resource := range list {
+		filenames = append(filenames, resource.Filename)
+	}
+	require.ElementsMatch(t, []string{"kept.txt", "related.txt"}, filenames)
+	ts.Close()
+}
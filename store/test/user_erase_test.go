@@ -0,0 +1,166 @@
+package teststore
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	storepb "github.com/usememos/memos/proto/gen/store"
+	"github.com/usememos/memos/store"
+)
+
+func TestEraseUser(t *testing.T) {
+	ctx := context.Background()
+	ts := NewTestingStore(ctx, t)
+	user, err := createTestingHostUser(ctx, ts)
+	require.NoError(t, err)
+	other, err := ts.CreateUser(ctx, &store.User{Username: "other", Role: store.RoleUser, Email: "other@test.com"})
+	require.NoError(t, err)
+
+	createMemo := func(uid string, creatorID int32) *store.Memo {
+		memo, err := ts.CreateMemo(ctx, &store.Memo{UID: uid, CreatorID: creatorID, Content: uid, Visibility: store.Public})
+		require.NoError(t, err)
+		return memo
+	}
+	memo := createMemo("memo", user.ID)
+	otherMemo := createMemo("other-memo", other.ID)
+	comment := createMemo("comment", other.ID)
+	idempotentMemo, _, err := ts.CreateMemoWithIdempotencyKey(ctx, &store.Memo{UID: "idempotent-memo", CreatorID: user.ID, Content: "idempotent", Visibility: store.Public}, "key")
+	require.NoError(t, err)
+
+	// The comment of the other user on the memo is erased with it, the reference to their memo is not.
+	_, err = ts.UpsertMemoRelation(ctx, &store.MemoRelation{MemoID: comment.ID, RelatedMemoID: memo.ID, Type: store.MemoRelationComment})
+	require.NoError(t, err)
+	_, err = ts.UpsertMemoRelation(ctx, &store.MemoRelation{MemoID: idempotentMemo.ID, RelatedMemoID: otherMemo.ID, Type: store.MemoRelationReference})
+	require.NoError(t, err)
+	content := "memo v2"
+	require.NoError(t, ts.UpdateMemo(ctx, &store.UpdateMemo{ID: memo.ID, Content: &content, EditorID: user.ID}))
+	for _, acl := range []*store.MemoACL{{MemoID: otherMemo.ID, UserID: user.ID}, {MemoID: memo.ID, UserID: other.ID}} {
+		_, err = ts.UpsertMemoACL(ctx, acl)
+		require.NoError(t, err)
+	}
+	for _, reaction := range []*store.Reaction{
+		{CreatorID: other.ID, ContentID: "memos/memo", ReactionType: "👍"},
+		{CreatorID: user.ID, ContentID: "memos/other-memo", ReactionType: "👍"},
+		{CreatorID: other.ID, ContentID: "memos/other-memo", ReactionType: "👍"},
+	} {
+		_, err = ts.UpsertReaction(ctx, reaction)
+		require.NoError(t, err)
+	}
+
+	localFile := filepath.Join(t.TempDir(), "local.txt")
+	require.NoError(t, os.WriteFile(localFile, []byte("local"), 0644))
+	for _, resource := range []*store.Resource{
+		{UID: "local", CreatorID: user.ID, Filename: "local.txt", StorageType: storepb.ResourceStorageType_LOCAL, Reference: localFile, MemoID: &memo.ID},
+		{UID: "attached", CreatorID: other.ID, Filename: "attached.txt", Blob: []byte("attached"), MemoID: &memo.ID},
+		{UID: "kept", CreatorID: other.ID, Filename: "kept.txt", Blob: []byte("kept")},
+	} {
+		_, err = ts.CreateResource(ctx, resource)
+		require.NoError(t, err)
+	}
+
+	for _, inbox := range []*store.Inbox{
+		{SenderID: other.ID, ReceiverID: user.ID, Status: store.UNREAD, Message: &storepb.InboxMessage{}},
+		{SenderID: user.ID, ReceiverID: other.ID, Status: store.UNREAD, Message: &storepb.InboxMessage{}},
+		{SenderID: other.ID, ReceiverID: other.ID, Status: store.UNREAD, Message: &storepb.InboxMessage{}},
+	} {
+		_, err = ts.CreateInbox(ctx, inbox)
+		require.NoError(t, err)
+	}
+	for _, creatorID := range []int32{user.ID, other.ID} {
+		_, err = ts.CreateActivity(ctx, &store.Activity{CreatorID: creatorID, Type: store.ActivityTypeMemoComment, Level: store.ActivityLevelInfo, Payload: &storepb.ActivityPayload{}})
+		require.NoError(t, err)
+	}
+	_, err = ts.CreateWebhook(ctx, &store.Webhook{CreatorID: user.ID, Name: "webhook", URL: "https://example.com"})
+	require.NoError(t, err)
+	require.NoError(t, ts.AddUserAccessToken(ctx, user.ID, &storepb.AccessTokensUserSetting_AccessToken{AccessToken: "token"}))
+
+	deletedRows, err := ts.EraseUser(ctx, user.ID)
+	require.NoError(t, err)
+	require.Equal(t, map[string]int64{
+		"memo_relation":        2,
+		"memo_organizer":       0,
+		"memo_acl":             2,
+		"memo_revision":        2,
+		"memo_idempotency_key": 1,
+		"reaction":             2,
+		"resource":             2,
+		"memo":                 3,
+		"inbox":                2,
+		"activity":             1,
+		"webhook":              1,
+		"user_setting":         1,
+		"user":                 1,
+	}, deletedRows)
+	_, err = os.Stat(localFile)
+	require.True(t, os.IsNotExist(err))
+
+	// Nothing refers to the user or their memos anymore, and the other user keeps the rest.
+	erasedMemoIDs := fmt.Sprintf("(%d, %d, %d)", memo.ID, idempotentMemo.ID, comment.ID)
+	for query, count := range map[string]int{
+		fmt.Sprintf("SELECT COUNT(*) FROM memo WHERE creator_id = %d OR id IN %s", user.ID, erasedMemoIDs):                                   0,
+		fmt.Sprintf("SELECT COUNT(*) FROM memo_relation WHERE memo_id IN %s OR related_memo_id IN %s", erasedMemoIDs, erasedMemoIDs):         0,
+		fmt.Sprintf("SELECT COUNT(*) FROM memo_acl WHERE user_id = %d OR memo_id IN %s", user.ID, erasedMemoIDs):                             0,
+		fmt.Sprintf("SELECT COUNT(*) FROM memo_revision WHERE memo_id IN %s", erasedMemoIDs):                                                 0,
+		fmt.Sprintf("SELECT COUNT(*) FROM resource WHERE creator_id = %d OR memo_id IN %s", user.ID, erasedMemoIDs):                          0,
+		fmt.Sprintf("SELECT COUNT(*) FROM reaction WHERE creator_id = %d OR content_id IN ('memos/memo', 'memos/idempotent-memo')", user.ID): 0,
+		fmt.Sprintf("SELECT COUNT(*) FROM user_setting WHERE user_id = %d", user.ID):                                                         0,
+		"SELECT COUNT(*) FROM memo":     1,
+		"SELECT COUNT(*) FROM resource": 1,
+		"SELECT COUNT(*) FROM reaction": 1,
+		"SELECT COUNT(*) FROM inbox":    1,
+		"SELECT COUNT(*) FROM activity": 1,
+	} {
+		var actual int
+		require.NoError(t, ts.GetDriver().GetDB().QueryRowContext(ctx, query).Scan(&actual))
+		require.Equal(t, count, actual, query)
+	}
+	erasedUser, err := ts.GetUser(ctx, &store.FindUser{ID: &user.ID})
+	require.NoError(t, err)
+	require.Nil(t, erasedUser)
+	otherUser, err := ts.GetUser(ctx, &store.FindUser{ID: &other.ID})
+	require.NoError(t, err)
+	require.NotNil(t, otherUser)
+	ts.Close()
+}
+
+func TestEraseUserWithLongHistory(t *testing.T) {
+	ctx := context.Background()
+	ts := NewTestingStore(ctx, t)
+	user, err := createTestingHostUser(ctx, ts)
+	require.NoError(t, err)
+	other, err := ts.CreateUser(ctx, &store.User{Username: "other", Role: store.RoleUser, Email: "other@test.com"})
+	require.NoError(t, err)
+
+	// More memos than SQLite's limit of 32766 arguments, inserted at once as creating them one by one would be slow.
+	const count = 33000
+	db := ts.GetDriver().GetDB()
+	_, err = db.ExecContext(ctx, "WITH RECURSIVE n(i) AS (SELECT 1 UNION ALL SELECT i + 1 FROM n WHERE i < ?) INSERT INTO memo (uid, creator_id, content) SELECT 'memo-' || i, ?, 'memo' FROM n", count, user.ID)
+	require.NoError(t, err)
+	uid := "memo-1"
+	memo, err := ts.GetMemo(ctx, &store.FindMemo{UID: &uid})
+	require.NoError(t, err)
+	comment, err := ts.CreateMemo(ctx, &store.Memo{UID: "comment", CreatorID: other.ID, Content: "comment", Visibility: store.Public})
+	require.NoError(t, err)
+	_, err = ts.UpsertMemoRelation(ctx, &store.MemoRelation{MemoID: comment.ID, RelatedMemoID: memo.ID, Type: store.MemoRelationComment})
+	require.NoError(t, err)
+	_, err = ts.UpsertReaction(ctx, &store.Reaction{CreatorID: other.ID, ContentID: "memos/memo-2", ReactionType: "👍"})
+	require.NoError(t, err)
+	kept, err := ts.CreateMemo(ctx, &store.Memo{UID: "kept", CreatorID: other.ID, Content: "kept", Visibility: store.Public})
+	require.NoError(t, err)
+
+	deletedRows, err := ts.EraseUser(ctx, user.ID)
+	require.NoError(t, err)
+	require.Equal(t, int64(count+1), deletedRows["memo"])
+	require.Equal(t, int64(1), deletedRows["memo_relation"])
+	require.Equal(t, int64(1), deletedRows["reaction"])
+	memos, err := ts.ListMemos(ctx, &store.FindMemo{})
+	require.NoError(t, err)
+	require.Len(t, memos, 1)
+	require.Equal(t, kept.ID, memos[0].ID)
+	ts.Close()
+}
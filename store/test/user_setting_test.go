@@ -2,9 +2,13 @@ package teststore
 
 import (
 	"context"
+	"fmt"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
 
 	storepb "github.com/usememos/memos/proto/gen/store"
 	"github.com/usememos/memos/store"
@@ -24,5 +28,421 @@ func TestUserSettingStore(t *testing.T) {
 	list, err := ts.ListUserSettings(ctx, &store.FindUserSetting{})
 	require.NoError(t, err)
 	require.Equal(t, 1, len(list))
+	// Upserting the same key again updates the value.
+	_, err = ts.UpsertUserSetting(ctx, &storepb.UserSetting{
+		UserId: user.ID,
+		Key:    storepb.UserSettingKey_LOCALE,
+		Value:  &storepb.UserSetting_Locale{Locale: "zh"},
+	})
+	require.NoError(t, err)
+	list, err = ts.ListUserSettings(ctx, &store.FindUserSetting{})
+	require.NoError(t, err)
+	require.Equal(t, 1, len(list))
+	require.Equal(t, "zh", list[0].GetLocale())
+	ts.Close()
+}
+
+func TestUserSettingMemoVisibility(t *testing.T) {
+	ctx := context.Background()
+	ts := NewTestingStore(ctx, t)
+	user, err := createTestingHostUser(ctx, ts)
+	require.NoError(t, err)
+	visibility, err := ts.GetUserMemoVisibility(ctx, user.ID)
+	require.NoError(t, err)
+	require.Equal(t, store.Private, visibility)
+	_, err = ts.UpsertUserSetting(ctx, &storepb.UserSetting{
+		UserId: user.ID,
+		Key:    storepb.UserSettingKey_MEMO_VISIBILITY,
+		Value:  &storepb.UserSetting_MemoVisibility{MemoVisibility: "PROTECTED"},
+	})
+	require.NoError(t, err)
+	visibility, err = ts.GetUserMemoVisibility(ctx, user.ID)
+	require.NoError(t, err)
+	require.Equal(t, store.Protected, visibility)
+	_, err = ts.UpsertUserSetting(ctx, &storepb.UserSetting{
+		UserId: user.ID,
+		Key:    storepb.UserSettingKey_MEMO_VISIBILITY,
+		Value:  &storepb.UserSetting_MemoVisibility{MemoVisibility: "UNKNOWN"},
+	})
+	require.NoError(t, err)
+	visibility, err = ts.GetUserMemoVisibility(ctx, user.ID)
+	require.NoError(t, err)
+	require.Equal(t, store.Private, visibility)
+	ts.Close()
+}
+
+func TestUserSettingTimezone(t *testing.T) {
+	ctx := context.Background()
+	ts := NewTestingStore(ctx, t)
+	user, err := createTestingHostUser(ctx, ts)
+	require.NoError(t, err)
+	location, err := ts.GetUserTimezone(ctx, user.ID)
+	require.NoError(t, err)
+	require.Equal(t, time.UTC, location)
+	_, err = ts.UpsertUserSetting(ctx, &storepb.UserSetting{
+		UserId: user.ID,
+		Key:    storepb.UserSettingKey_TIMEZONE,
+		Value:  &storepb.UserSetting_Timezone{Timezone: "Asia/Shanghai"},
+	})
+	require.NoError(t, err)
+	location, err = ts.GetUserTimezone(ctx, user.ID)
+	require.NoError(t, err)
+	require.Equal(t, "Asia/Shanghai", location.String())
+	// Invalid timezones are refused.
+	for _, timezone := range []string{"Mars/Olympus_Mons", "Local"} {
+		_, err = ts.UpsertUserSetting(ctx, &storepb.UserSetting{
+			UserId: user.ID,
+			Key:    storepb.UserSettingKey_TIMEZONE,
+			Value:  &storepb.UserSetting_Timezone{Timezone: timezone},
+		})
+		require.ErrorContains(t, err, "invalid timezone")
+	}
+	ts.Close()
+}
+
+func TestUserSettingTemplates(t *testing.T) {
+	ctx := context.Background()
+	ts := NewTestingStore(ctx, t)
+	user, err := createTestingHostUser(ctx, ts)
+	require.NoError(t, err)
+	templates := &storepb.TemplatesUserSetting{
+		Templates: []*storepb.TemplatesUserSetting_Template{
+			{Id: "journal", Name: "Daily journal", Content: "# {{date}}\n\n- "},
+			{Id: "meeting", Name: "Meeting notes", Content: "## Meeting at {{time}}"},
+		},
+	}
+	_, err = ts.UpsertUserSetting(ctx, &storepb.UserSetting{
+		UserId: user.ID,
+		Key:    storepb.UserSettingKey_TEMPLATES,
+		Value:  &storepb.UserSetting_Templates{Templates: templates},
+	})
+	require.NoError(t, err)
+	userSetting, err := ts.GetUserSetting(ctx, &store.FindUserSetting{
+		UserID: &user.ID,
+		Key:    storepb.UserSettingKey_TEMPLATES,
+	})
+	require.NoError(t, err)
+	require.True(t, proto.Equal(templates, userSetting.GetTemplates()))
+	ts.Close()
+}
+
+func TestUserSettingStorePagination(t *testing.T) {
+	ctx := context.Background()
+	ts := NewTestingStore(ctx, t)
+	user, err := createTestingHostUser(ctx, ts)
+	require.NoError(t, err)
+	_, err = ts.UpsertUserSetting(ctx, &storepb.UserSetting{
+		UserId: user.ID,
+		Key:    storepb.UserSettingKey_MEMO_VISIBILITY,
+		Value:  &storepb.UserSetting_MemoVisibility{MemoVisibility: "PUBLIC"},
+	})
+	require.NoError(t, err)
+	_, err = ts.UpsertUserSetting(ctx, &storepb.UserSetting{
+		UserId: user.ID,
+		Key:    storepb.UserSettingKey_APPEARANCE,
+		Value:  &storepb.UserSetting_Appearance{Appearance: "dark"},
+	})
+	require.NoError(t, err)
+	// Settings written by a newer version may have keys unknown to this one.
+	_, err = ts.GetDriver().UpsertUserSetting(ctx, &store.UserSetting{
+		UserID: user.ID,
+		Key:    storepb.UserSettingKey(99),
+		Value:  "unknown",
+	})
+	require.NoError(t, err)
+
+	limit, offset := 1, 1
+	list, err := ts.ListUserSettings(ctx, &store.FindUserSetting{Limit: &limit, Offset: &offset})
+	require.NoError(t, err)
+	require.Equal(t, 1, len(list))
+	require.Equal(t, storepb.UserSettingKey_APPEARANCE, list[0].Key)
+	list, err = ts.ListUserSettings(ctx, &store.FindUserSetting{})
+	require.NoError(t, err)
+	require.Equal(t, 2, len(list))
+
+	rawList, err := ts.ListRawUserSettings(ctx, &store.FindUserSetting{})
+	require.NoError(t, err)
+	require.Equal(t, 3, len(rawList))
+	require.Equal(t, "99", rawList[0].RawKey)
+	require.Equal(t, storepb.UserSettingKey_USER_SETTING_KEY_UNSPECIFIED, rawList[0].Key)
+	require.Equal(t, "unknown", rawList[0].Value)
+	ts.Close()
+}
+
+func TestUserSettingPreferences(t *testing.T) {
+	ctx := context.Background()
+	ts := NewTestingStore(ctx, t)
+	user, err := createTestingHostUser(ctx, ts)
+	require.NoError(t, err)
+	preferences := `{"editor": {"fontSize": 14}, "plugin.foo": [1, "two"]}`
+	_, err = ts.UpsertUserSetting(ctx, &storepb.UserSetting{
+		UserId: user.ID,
+		Key:    storepb.UserSettingKey_PREFERENCES,
+		Value:  &storepb.UserSetting_Preferences{Preferences: preferences},
+	})
+	require.NoError(t, err)
+	_, err = ts.UpsertUserSetting(ctx, &storepb.UserSetting{
+		UserId: user.ID,
+		Key:    storepb.UserSettingKey_PREFERENCES,
+		Value:  &storepb.UserSetting_Preferences{Preferences: `{"editor":`},
+	})
+	require.Error(t, err)
+	list, err := ts.ListUserSettings(ctx, &store.FindUserSetting{
+		UserID: &user.ID,
+		Key:    storepb.UserSettingKey_PREFERENCES,
+	})
+	require.NoError(t, err)
+	require.Equal(t, 1, len(list))
+	require.Equal(t, preferences, list[0].GetPreferences())
+	ts.Close()
+}
+
+func TestUserAccessTokenRevokeAndTouch(t *testing.T) {
+	ctx := context.Background()
+	ts := NewTestingStore(ctx, t)
+	user, err := createTestingHostUser(ctx, ts)
+	require.NoError(t, err)
+
+	var wg sync.WaitGroup
+	// Errors are asserted on the test goroutine, as require must not be called on others.
+	errs := make(chan error, 10)
+	for i := range 10 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs <- ts.AddUserAccessToken(ctx, user.ID, &storepb.AccessTokensUserSetting_AccessToken{
+				AccessToken: fmt.Sprintf("token-%d", i),
+			})
+		}()
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		require.NoError(t, err)
+	}
+	accessTokens, err := ts.GetUserAccessTokens(ctx, user.ID)
+	require.NoError(t, err)
+	require.Equal(t, 10, len(accessTokens))
+
+	now := time.Unix(1700000000, 0)
+	require.NoError(t, ts.TouchUserAccessToken(ctx, user.ID, "token-1", now))
+	require.NoError(t, ts.TouchUserAccessToken(ctx, user.ID, "token-1", now.Add(30*time.Second)))
+	require.NoError(t, ts.RevokeUserAccessToken(ctx, user.ID, "token-2"))
+	accessTokens, err = ts.GetUserAccessTokens(ctx, user.ID)
+	require.NoError(t, err)
+	for _, accessToken := range accessTokens {
+		switch accessToken.AccessToken {
+		case "token-1":
+			require.Equal(t, now.Unix(), accessToken.LastUsedTime.AsTime().Unix())
+			require.False(t, accessToken.Revoked)
+		case "token-2":
+			require.Nil(t, accessToken.LastUsedTime)
+			require.True(t, accessToken.Revoked)
+		}
+	}
+	require.NoError(t, ts.TouchUserAccessToken(ctx, user.ID, "token-1", now.Add(store.AccessTokenLastUsedInterval)))
+	accessTokens, err = ts.GetUserAccessTokens(ctx, user.ID)
+	require.NoError(t, err)
+	for _, accessToken := range accessTokens {
+		if accessToken.AccessToken == "token-1" {
+			require.Equal(t, now.Add(store.AccessTokenLastUsedInterval).Unix(), accessToken.LastUsedTime.AsTime().Unix())
+		}
+	}
+	ts.Close()
+}
+
+func TestBatchUpsertUserSettings(t *testing.T) {
+	ctx := context.Background()
+	ts := NewTestingStore(ctx, t)
+	user, err := createTestingHostUser(ctx, ts)
+	require.NoError(t, err)
+
+	// An invalid setting in the middle aborts the whole batch.
+	_, err = ts.BatchUpsertUserSettings(ctx, []*storepb.UserSetting{
+		{UserId: user.ID, Key: storepb.UserSettingKey_LOCALE, Value: &storepb.UserSetting_Locale{Locale: "en"}},
+		{UserId: user.ID, Key: storepb.UserSettingKey_USER_SETTING_KEY_UNSPECIFIED},
+		{UserId: user.ID, Key: storepb.UserSettingKey_APPEARANCE, Value: &storepb.UserSetting_Appearance{Appearance: "dark"}},
+	})
+	require.Error(t, err)
+	list, err := ts.ListUserSettings(ctx, &store.FindUserSetting{UserID: &user.ID})
+	require.NoError(t, err)
+	require.Len(t, list, 0)
+
+	// A row failing in the database after earlier rows were written rolls them back as well.
+	_, err = ts.UpsertUserSetting(ctx, &storepb.UserSetting{UserId: user.ID, Key: storepb.UserSettingKey_LOCALE, Value: &storepb.UserSetting_Locale{Locale: "fr"}})
+	require.NoError(t, err)
+	db := ts.GetDriver().GetDB()
+	_, err = db.ExecContext(ctx, "CREATE TRIGGER reject_timezone BEFORE INSERT ON user_setting WHEN NEW.key = 'TIMEZONE' BEGIN SELECT RAISE(ABORT, 'timezone rejected'); END")
+	require.NoError(t, err)
+	_, err = ts.BatchUpsertUserSettings(ctx, []*storepb.UserSetting{
+		{UserId: user.ID, Key: storepb.UserSettingKey_LOCALE, Value: &storepb.UserSetting_Locale{Locale: "en"}},
+		{UserId: user.ID, Key: storepb.UserSettingKey_APPEARANCE, Value: &storepb.UserSetting_Appearance{Appearance: "dark"}},
+		{UserId: user.ID, Key: storepb.UserSettingKey_TIMEZONE, Value: &storepb.UserSetting_Timezone{Timezone: "Asia/Shanghai"}},
+	})
+	require.ErrorContains(t, err, "timezone rejected")
+	_, err = db.ExecContext(ctx, "DROP TRIGGER reject_timezone")
+	require.NoError(t, err)
+	var count int
+	require.NoError(t, db.QueryRowContext(ctx, "SELECT COUNT(*) FROM user_setting WHERE user_id = ?", user.ID).Scan(&count))
+	require.Equal(t, 1, count)
+	var value string
+	require.NoError(t, db.QueryRowContext(ctx, "SELECT value FROM user_setting WHERE user_id = ? AND key = 'LOCALE'", user.ID).Scan(&value))
+	require.Equal(t, "fr", value)
+
+	userSettings, err := ts.BatchUpsertUserSettings(ctx, []*storepb.UserSetting{
+		{UserId: user.ID, Key: storepb.UserSettingKey_LOCALE, Value: &storepb.UserSetting_Locale{Locale: "en"}},
+		{UserId: user.ID, Key: storepb.UserSettingKey_APPEARANCE, Value: &storepb.UserSetting_Appearance{Appearance: "dark"}},
+		{UserId: user.ID, Key: storepb.UserSettingKey_LOCALE, Value: &storepb.UserSetting_Locale{Locale: "zh"}},
+	})
+	require.NoError(t, err)
+	require.Len(t, userSettings, 2)
+	require.Equal(t, "zh", userSettings[0].GetLocale())
+	require.Equal(t, "dark", userSettings[1].GetAppearance())
+
+	// Existing settings are updated.
+	_, err = ts.BatchUpsertUserSettings(ctx, []*storepb.UserSetting{
+		{UserId: user.ID, Key: storepb.UserSettingKey_APPEARANCE, Value: &storepb.UserSetting_Appearance{Appearance: "light"}},
+		{UserId: user.ID, Key: storepb.UserSettingKey_MEMO_VISIBILITY, Value: &storepb.UserSetting_MemoVisibility{MemoVisibility: "PUBLIC"}},
+	})
+	require.NoError(t, err)
+	list, err = ts.ListUserSettings(ctx, &store.FindUserSetting{UserID: &user.ID})
+	require.NoError(t, err)
+	values := map[storepb.UserSettingKey]string{}
+	for _, userSetting := range list {
+		switch userSetting.Key {
+		case storepb.UserSettingKey_LOCALE:
+			values[userSetting.Key] = userSetting.GetLocale()
+		case storepb.UserSettingKey_APPEARANCE:
+			values[userSetting.Key] = userSetting.GetAppearance()
+		case storepb.UserSettingKey_MEMO_VISIBILITY:
+			values[userSetting.Key] = userSetting.GetMemoVisibility()
+		}
+	}
+	require.Equal(t, map[storepb.UserSettingKey]string{
+		storepb.UserSettingKey_LOCALE:          "zh",
+		storepb.UserSettingKey_APPEARANCE:      "light",
+		storepb.UserSettingKey_MEMO_VISIBILITY: "PUBLIC",
+	}, values)
+	ts.Close()
+}
+
+func TestDeleteUserSetting(t *testing.T) {
+	ctx := context.Background()
+	ts := NewTestingStore(ctx, t)
+	user, err := createTestingHostUser(ctx, ts)
+	require.NoError(t, err)
+	_, err = ts.BatchUpsertUserSettings(ctx, []*storepb.UserSetting{
+		{UserId: user.ID, Key: storepb.UserSettingKey_LOCALE, Value: &storepb.UserSetting_Locale{Locale: "zh"}},
+		{UserId: user.ID, Key: storepb.UserSettingKey_MEMO_VISIBILITY, Value: &storepb.UserSetting_MemoVisibility{MemoVisibility: "PUBLIC"}},
+	})
+	require.NoError(t, err)
+	// Load the setting into the cache.
+	visibility, err := ts.GetUserMemoVisibility(ctx, user.ID)
+	require.NoError(t, err)
+	require.Equal(t, store.Public, visibility)
+
+	err = ts.DeleteUserSetting(ctx, &store.DeleteUserSetting{UserID: user.ID, Key: storepb.UserSettingKey_MEMO_VISIBILITY})
+	require.NoError(t, err)
+	list, err := ts.ListUserSettings(ctx, &store.FindUserSetting{UserID: &user.ID})
+	require.NoError(t, err)
+	require.Len(t, list, 1)
+	require.Equal(t, storepb.UserSettingKey_LOCALE, list[0].Key)
+	// The setting falls back to its default.
+	visibility, err = ts.GetUserMemoVisibility(ctx, user.ID)
+	require.NoError(t, err)
+	require.Equal(t, store.Private, visibility)
+
+	// Deleting a setting that does not exist is a no-op.
+	err = ts.DeleteUserSetting(ctx, &store.DeleteUserSetting{UserID: user.ID, Key: storepb.UserSettingKey_MEMO_VISIBILITY})
+	require.NoError(t, err)
+	err = ts.DeleteUserSetting(ctx, &store.DeleteUserSetting{UserID: user.ID + 1, Key: storepb.UserSettingKey_LOCALE})
+	require.NoError(t, err)
+	list, err = ts.ListUserSettings(ctx, &store.FindUserSetting{UserID: &user.ID})
+	require.NoError(t, err)
+	require.Len(t, list, 1)
+
+	err = ts.DeleteUserSetting(ctx, &store.DeleteUserSetting{UserID: user.ID})
+	require.Error(t, err)
+	ts.Close()
+}
+
+func TestUserAccessTokenConcurrentStores(t *testing.T) {
+	ctx := context.Background()
+	ts := NewTestingStore(ctx, t)
+	user, err := createTestingHostUser(ctx, ts)
+	require.NoError(t, err)
+
+	// Stores sharing the database, like servers behind a load balancer, do not share their locks.
+	stores := []*store.Store{ts, store.New(ts.GetDriver(), ts.Profile)}
+	var wg sync.WaitGroup
+	// Errors are asserted on the test goroutine, as require must not be called on others.
+	errs := make(chan error, 10)
+	for i := range 10 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs <- stores[i%len(stores)].AddUserAccessToken(ctx, user.ID, &storepb.AccessTokensUserSetting_AccessToken{
+				AccessToken: fmt.Sprintf("token-%d", i),
+			})
+		}()
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		require.NoError(t, err)
+	}
+	accessTokens, err := store.New(ts.GetDriver(), ts.Profile).GetUserAccessTokens(ctx, user.ID)
+	require.NoError(t, err)
+	require.Equal(t, 10, len(accessTokens))
+
+	// Removing a token that is not there writes nothing.
+	require.NoError(t, ts.RemoveUserAccessToken(ctx, user.ID, "missing"))
+	otherUser, err := ts.CreateUser(ctx, &store.User{Username: "other", Role: store.RoleUser, Email: "other@test.com"})
+	require.NoError(t, err)
+	require.NoError(t, ts.RemoveUserAccessToken(ctx, otherUser.ID, "missing"))
+	list, err := ts.ListUserSettings(ctx, &store.FindUserSetting{UserID: &otherUser.ID})
+	require.NoError(t, err)
+	require.Equal(t, 0, len(list))
+	ts.Close()
+}
+
+func TestUserSettingValueContains(t *testing.T) {
+	ctx := context.Background()
+	ts := NewTestingStore(ctx, t)
+	user, err := createTestingHostUser(ctx, ts)
+	require.NoError(t, err)
+	other, err := ts.CreateUser(ctx, &store.User{Username: "other", Role: store.RoleUser, Email: "other@test.com"})
+	require.NoError(t, err)
+
+	require.NoError(t, ts.AddUserAccessToken(ctx, user.ID, &storepb.AccessTokensUserSetting_AccessToken{AccessToken: "eyJhbGciOi.rotate-me"}))
+	require.NoError(t, ts.AddUserAccessToken(ctx, other.ID, &storepb.AccessTokensUserSetting_AccessToken{AccessToken: "eyJhbGciOi.keep-me"}))
+	// A value of another key is not matched.
+	_, err = ts.UpsertUserSetting(ctx, &storepb.UserSetting{
+		UserId: other.ID,
+		Key:    storepb.UserSettingKey_PREFERENCES,
+		Value:  &storepb.UserSetting_Preferences{Preferences: `{"note":"rotate-me"}`},
+	})
+	require.NoError(t, err)
+
+	fragment := "rotate-me"
+	list, err := ts.ListUserSettings(ctx, &store.FindUserSetting{Key: storepb.UserSettingKey_ACCESS_TOKENS, ValueContains: &fragment})
+	require.NoError(t, err)
+	require.Equal(t, 1, len(list))
+	require.Equal(t, user.ID, list[0].UserId)
+
+	fragment = "eyJhbGciOi"
+	list, err = ts.ListUserSettings(ctx, &store.FindUserSetting{Key: storepb.UserSettingKey_ACCESS_TOKENS, ValueContains: &fragment})
+	require.NoError(t, err)
+	require.Equal(t, 2, len(list))
+
+	// The match is case-sensitive, and wildcards are not special.
+	for _, fragment := range []string{"ROTATE-ME", "rotate%", "rotate_me"} {
+		list, err = ts.ListUserSettings(ctx, &store.FindUserSetting{Key: storepb.UserSettingKey_ACCESS_TOKENS, ValueContains: &fragment})
+		require.NoError(t, err)
+		require.Equal(t, 0, len(list), fragment)
+	}
+
+	_, err = ts.ListUserSettings(ctx, &store.FindUserSetting{ValueContains: &fragment})
+	require.Error(t, err)
 	ts.Close()
 }
@@ -18,6 +18,7 @@ func TestWebhookStore(t *testing.T) {
 		CreatorID: user.ID,
 		Name:      "test_webhook",
 		URL:       "https://example.com",
+		Secret:    "secret",
 	})
 	require.NoError(t, err)
 	require.Equal(t, "test_webhook", webhook.Name)
@@ -36,6 +37,15 @@ func TestWebhookStore(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, newName, updatedWebhook.Name)
 	require.Equal(t, webhook.CreatorID, updatedWebhook.CreatorID)
+	require.Equal(t, "secret", updatedWebhook.Secret)
+	newSecret := "new_secret"
+	updatedWebhook, err = ts.UpdateWebhook(ctx, &store.UpdateWebhook{
+		ID:     webhook.ID,
+		Secret: &newSecret,
+	})
+	require.NoError(t, err)
+	require.Equal(t, newSecret, updatedWebhook.Secret)
+	require.Equal(t, newName, updatedWebhook.Name)
 	err = ts.DeleteWebhook(ctx, &store.DeleteWebhook{
 		ID: webhook.ID,
 	})
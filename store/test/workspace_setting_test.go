@@ -29,3 +29,53 @@ func TestWorkspaceSettingV1Store(t *testing.T) {
 	require.Equal(t, workspaceSetting, setting)
 	ts.Close()
 }
+
+func TestWorkspaceMemoRelatedSettingLinkMetadataHeaders(t *testing.T) {
+	ctx := context.Background()
+	ts := NewTestingStore(ctx, t)
+	setting, err := ts.GetWorkspaceMemoRelatedSetting(ctx)
+	require.NoError(t, err)
+	require.Equal(t, "memos/"+ts.Profile.Version+" (+https://www.usememos.com)", setting.LinkMetadataUserAgent)
+	require.Equal(t, "", setting.LinkMetadataAcceptLanguage)
+
+	_, err = ts.UpsertWorkspaceSetting(ctx, &storepb.WorkspaceSetting{
+		Key: storepb.WorkspaceSettingKey_MEMO_RELATED,
+		Value: &storepb.WorkspaceSetting_MemoRelatedSetting{
+			MemoRelatedSetting: &storepb.WorkspaceMemoRelatedSetting{
+				LinkMetadataUserAgent:      "Mozilla/5.0 (compatible; memos)",
+				LinkMetadataAcceptLanguage: "fr-FR",
+			},
+		},
+	})
+	require.NoError(t, err)
+	setting, err = ts.GetWorkspaceMemoRelatedSetting(ctx)
+	require.NoError(t, err)
+	require.Equal(t, "Mozilla/5.0 (compatible; memos)", setting.LinkMetadataUserAgent)
+	require.Equal(t, "fr-FR", setting.LinkMetadataAcceptLanguage)
+	ts.Close()
+}
+
+func TestWorkspaceMemoRelatedSettingLinkMetadataRateLimit(t *testing.T) {
+	ctx := context.Background()
+	ts := NewTestingStore(ctx, t)
+	setting, err := ts.GetWorkspaceMemoRelatedSetting(ctx)
+	require.NoError(t, err)
+	require.Equal(t, int32(store.DefaultLinkMetadataRateLimit), setting.LinkMetadataRateLimit)
+	require.Equal(t, int32(store.DefaultLinkMetadataRateLimitBurst), setting.LinkMetadataRateLimitBurst)
+
+	_, err = ts.UpsertWorkspaceSetting(ctx, &storepb.WorkspaceSetting{
+		Key: storepb.WorkspaceSettingKey_MEMO_RELATED,
+		Value: &storepb.WorkspaceSetting_MemoRelatedSetting{
+			MemoRelatedSetting: &storepb.WorkspaceMemoRelatedSetting{
+				LinkMetadataRateLimit:      10,
+				LinkMetadataRateLimitBurst: 2,
+			},
+		},
+	})
+	require.NoError(t, err)
+	setting, err = ts.GetWorkspaceMemoRelatedSetting(ctx)
+	require.NoError(t, err)
+	require.Equal(t, int32(10), setting.LinkMetadataRateLimit)
+	require.Equal(t, int32(2), setting.LinkMetadataRateLimitBurst)
+	ts.Close()
+}
@@ -2,6 +2,10 @@ package store
 
 import (
 	"context"
+
+	"github.com/pkg/errors"
+
+	storepb "github.com/usememos/memos/proto/gen/store"
 )
 
 // Role is the type of a role.
@@ -154,7 +158,13 @@ func (s *Store) DeleteUser(ctx context.Context, delete *DeleteUser) error {
 	if err != nil {
 		return err
 	}
+	if err := s.driver.DeleteMemoACL(ctx, &DeleteMemoACL{UserID: &delete.ID}); err != nil {
+		return errors.Wrap(err, "failed to delete memo acls of user")
+	}
 
 	s.userCache.Delete(delete.ID)
+	for key := range storepb.UserSettingKey_name {
+		s.userSettingCache.Delete(getUserSettingCacheKey(delete.ID, storepb.UserSettingKey(key).String()))
+	}
 	return nil
 }
@@ -0,0 +1,65 @@
+package store
+
+import (
+	"context"
+	"log/slog"
+
+	storepb "github.com/usememos/memos/proto/gen/store"
+)
+
+// ErasedUser is what was erased with a user, see Store.EraseUser.
+type ErasedUser struct {
+	// DeletedRows is the number of rows deleted per table.
+	DeletedRows map[string]int64
+	// Resources are the deleted resources, without their blobs, whose local files and s3 objects
+	// are left to delete.
+	Resources []*Resource
+}
+
+// EraseUser deletes the user and everything they own in a transaction, returning the number of
+// rows deleted per table: their memos along with the comments of others on them, and the
+// relations, organizers, acls, revisions, idempotency keys, reactions and resources of those
+// memos, as well as their own reactions, resources, inboxes, activities, webhooks and settings,
+// which include their access tokens. The local files and s3 objects of the resources are then
+// deleted best-effort, as they cannot be rolled back. Shared blobs are only deleted once the
+// last reference to them is released.
+func (s *Store) EraseUser(ctx context.Context, userID int32) (map[string]int64, error) {
+	erased, err := s.driver.EraseUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	s.userCache.Delete(userID)
+	for key := range storepb.UserSettingKey_name {
+		s.userSettingCache.Delete(getUserSettingCacheKey(userID, storepb.UserSettingKey(key).String()))
+	}
+	type sharedBlob struct {
+		creatorID   int32
+		contentHash string
+	}
+	sharedBlobReferences := map[sharedBlob][]*Resource{}
+	for _, resource := range erased.Resources {
+		if resource.ContentHash != "" {
+			blob := sharedBlob{creatorID: resource.CreatorID, contentHash: resource.ContentHash}
+			sharedBlobReferences[blob] = append(sharedBlobReferences[blob], resource)
+			continue
+		}
+		if err := s.deleteResourceBlob(ctx, resource); err != nil {
+			slog.Warn("Failed to delete blob of erased resource", slog.Int("id", int(resource.ID)), slog.Int("user", int(userID)), slog.Any("err", err))
+		}
+	}
+	for _, resources := range sharedBlobReferences {
+		released, err := s.driver.ReleaseResourceBlob(ctx, resources[0], len(resources))
+		if err != nil {
+			slog.Warn("Failed to release shared blob of erased resources", slog.Int("id", int(resources[0].ID)), slog.Int("user", int(userID)), slog.Any("err", err))
+			continue
+		}
+		if !released {
+			continue
+		}
+		if err := s.deleteResourceBlob(ctx, resources[0]); err != nil {
+			slog.Warn("Failed to delete blob of erased resource", slog.Int("id", int(resources[0].ID)), slog.Int("user", int(userID)), slog.Any("err", err))
+		}
+	}
+	return erased.DeletedRows, nil
+}
@@ -2,22 +2,42 @@ package store
 
 import (
 	"context"
+	"encoding/json"
+	"time"
 
 	"github.com/pkg/errors"
 	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/types/known/timestamppb"
 
 	storepb "github.com/usememos/memos/proto/gen/store"
 )
 
+// AccessTokenLastUsedInterval is the min interval between updates of the last used time of an access token.
+const AccessTokenLastUsedInterval = time.Minute
+
 type UserSetting struct {
 	UserID int32
 	Key    storepb.UserSettingKey
 	Value  string
+	// RawKey is the key as stored, which is kept for keys unknown to this version.
+	RawKey string
 }
 
 type FindUserSetting struct {
 	UserID *int32
 	Key    storepb.UserSettingKey
+	// ValueContains matches the settings whose stored value, e.g. the JSON of access tokens,
+	// contains it. It requires Key, as the values of different keys are stored differently.
+	ValueContains *string
+
+	// Pagination. Settings are ordered by user id and key.
+	Limit  *int
+	Offset *int
+}
+
+type DeleteUserSetting struct {
+	UserID int32
+	Key    storepb.UserSettingKey
 }
 
 func (s *Store) UpsertUserSetting(ctx context.Context, upsert *storepb.UserSetting) (*storepb.UserSetting, error) {
@@ -41,7 +61,53 @@ func (s *Store) UpsertUserSetting(ctx context.Context, upsert *storepb.UserSetti
 	return userSetting, nil
 }
 
+// BatchUpsertUserSettings upserts the settings atomically: when any of them is invalid or
+// fails to be written, none is. Later settings win over earlier ones with the same user and key.
+func (s *Store) BatchUpsertUserSettings(ctx context.Context, upserts []*storepb.UserSetting) ([]*storepb.UserSetting, error) {
+	userSettingRawList := []*UserSetting{}
+	indexes := map[string]int{}
+	for _, upsert := range upserts {
+		userSettingRaw, err := convertUserSettingToRaw(upsert)
+		if err != nil {
+			return nil, err
+		}
+		// A statement may not upsert the same row twice.
+		cacheKey := getUserSettingCacheKey(userSettingRaw.UserID, userSettingRaw.Key.String())
+		if index, ok := indexes[cacheKey]; ok {
+			userSettingRawList[index] = userSettingRaw
+			continue
+		}
+		indexes[cacheKey] = len(userSettingRawList)
+		userSettingRawList = append(userSettingRawList, userSettingRaw)
+	}
+	if len(userSettingRawList) == 0 {
+		return []*storepb.UserSetting{}, nil
+	}
+	userSettingRawList, err := s.driver.BatchUpsertUserSettings(ctx, userSettingRawList)
+	if err != nil {
+		return nil, err
+	}
+
+	userSettings := []*storepb.UserSetting{}
+	for _, userSettingRaw := range userSettingRawList {
+		userSetting, err := convertUserSettingFromRaw(userSettingRaw)
+		if err != nil {
+			return nil, err
+		}
+		if userSetting == nil {
+			return nil, errors.New("unexpected nil user setting")
+		}
+		s.userSettingCache.Store(getUserSettingCacheKey(userSetting.UserId, userSetting.Key.String()), userSetting)
+		userSettings = append(userSettings, userSetting)
+	}
+	return userSettings, nil
+}
+
+// ListUserSettings skips settings with unknown keys, so a page may hold fewer than Limit settings.
 func (s *Store) ListUserSettings(ctx context.Context, find *FindUserSetting) ([]*storepb.UserSetting, error) {
+	if find.ValueContains != nil && find.Key == storepb.UserSettingKey_USER_SETTING_KEY_UNSPECIFIED {
+		return nil, errors.New("user setting key is required to match values")
+	}
 	userSettingRawList, err := s.driver.ListUserSettings(ctx, find)
 	if err != nil {
 		return nil, err
@@ -62,6 +128,25 @@ func (s *Store) ListUserSettings(ctx context.Context, find *FindUserSetting) ([]
 	return userSettings, nil
 }
 
+// DeleteUserSetting deletes the setting of the user, so that it falls back to its default.
+// Deleting a setting the user does not have is not an error.
+func (s *Store) DeleteUserSetting(ctx context.Context, delete *DeleteUserSetting) error {
+	if delete.Key == storepb.UserSettingKey_USER_SETTING_KEY_UNSPECIFIED {
+		return errors.New("user setting key is required")
+	}
+	if err := s.driver.DeleteUserSetting(ctx, delete); err != nil {
+		return err
+	}
+	s.userSettingCache.Delete(getUserSettingCacheKey(delete.UserID, delete.Key.String()))
+	return nil
+}
+
+// ListRawUserSettings returns the stored user settings without converting them, including
+// those with unknown keys that ListUserSettings skips, e.g. for exporting all settings.
+func (s *Store) ListRawUserSettings(ctx context.Context, find *FindUserSetting) ([]*UserSetting, error) {
+	return s.driver.ListUserSettings(ctx, find)
+}
+
 func (s *Store) GetUserSetting(ctx context.Context, find *FindUserSetting) (*storepb.UserSetting, error) {
 	if find.UserID != nil {
 		if cache, ok := s.userSettingCache.Load(getUserSettingCacheKey(*find.UserID, find.Key.String())); ok {
@@ -105,31 +190,145 @@ func (s *Store) GetUserAccessTokens(ctx context.Context, userID int32) ([]*store
 	return accessTokensUserSetting.AccessTokens, nil
 }
 
-// RemoveUserAccessToken remove the access token of the user.
-func (s *Store) RemoveUserAccessToken(ctx context.Context, userID int32, token string) error {
-	oldAccessTokens, err := s.GetUserAccessTokens(ctx, userID)
+// GetUserMemoVisibility returns the default visibility of the memos created by the user.
+// It falls back to private when the user has no valid setting.
+func (s *Store) GetUserMemoVisibility(ctx context.Context, userID int32) (Visibility, error) {
+	userSetting, err := s.GetUserSetting(ctx, &FindUserSetting{
+		UserID: &userID,
+		Key:    storepb.UserSettingKey_MEMO_VISIBILITY,
+	})
 	if err != nil {
-		return err
+		return "", err
+	}
+	if userSetting == nil {
+		return Private, nil
 	}
 
-	newAccessTokens := make([]*storepb.AccessTokensUserSetting_AccessToken, 0, len(oldAccessTokens))
-	for _, t := range oldAccessTokens {
-		if token != t.AccessToken {
-			newAccessTokens = append(newAccessTokens, t)
-		}
+	switch visibility := Visibility(userSetting.GetMemoVisibility()); visibility {
+	case Public, Protected, Private:
+		return visibility, nil
+	default:
+		return Private, nil
 	}
+}
 
-	_, err = s.UpsertUserSetting(ctx, &storepb.UserSetting{
-		UserId: userID,
-		Key:    storepb.UserSettingKey_ACCESS_TOKENS,
-		Value: &storepb.UserSetting_AccessTokens{
-			AccessTokens: &storepb.AccessTokensUserSetting{
-				AccessTokens: newAccessTokens,
+// GetUserTimezone returns the location of the timezone of the user.
+// It falls back to UTC when the user has no valid setting.
+func (s *Store) GetUserTimezone(ctx context.Context, userID int32) (*time.Location, error) {
+	userSetting, err := s.GetUserSetting(ctx, &FindUserSetting{
+		UserID: &userID,
+		Key:    storepb.UserSettingKey_TIMEZONE,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if userSetting == nil || userSetting.GetTimezone() == "" {
+		return time.UTC, nil
+	}
+	location, err := time.LoadLocation(userSetting.GetTimezone())
+	if err != nil {
+		return time.UTC, nil
+	}
+	return location, nil
+}
+
+// AddUserAccessToken adds the access token to the user.
+func (s *Store) AddUserAccessToken(ctx context.Context, userID int32, accessToken *storepb.AccessTokensUserSetting_AccessToken) error {
+	return s.updateUserAccessTokens(ctx, userID, func(accessTokens []*storepb.AccessTokensUserSetting_AccessToken) ([]*storepb.AccessTokensUserSetting_AccessToken, bool) {
+		return append(accessTokens, accessToken), true
+	})
+}
+
+// RemoveUserAccessToken remove the access token of the user.
+func (s *Store) RemoveUserAccessToken(ctx context.Context, userID int32, token string) error {
+	return s.updateUserAccessTokens(ctx, userID, func(accessTokens []*storepb.AccessTokensUserSetting_AccessToken) ([]*storepb.AccessTokensUserSetting_AccessToken, bool) {
+		newAccessTokens := make([]*storepb.AccessTokensUserSetting_AccessToken, 0, len(accessTokens))
+		for _, t := range accessTokens {
+			if token != t.AccessToken {
+				newAccessTokens = append(newAccessTokens, t)
+			}
+		}
+		return newAccessTokens, len(newAccessTokens) != len(accessTokens)
+	})
+}
+
+// RevokeUserAccessToken marks the access token of the user as revoked.
+func (s *Store) RevokeUserAccessToken(ctx context.Context, userID int32, token string) error {
+	return s.updateUserAccessTokens(ctx, userID, func(accessTokens []*storepb.AccessTokensUserSetting_AccessToken) ([]*storepb.AccessTokensUserSetting_AccessToken, bool) {
+		changed := false
+		for _, t := range accessTokens {
+			if token == t.AccessToken && !t.Revoked {
+				t.Revoked = true
+				changed = true
+			}
+		}
+		return accessTokens, changed
+	})
+}
+
+// TouchUserAccessToken sets the last used time of the access token of the user to now,
+// unless it was set less than AccessTokenLastUsedInterval ago.
+func (s *Store) TouchUserAccessToken(ctx context.Context, userID int32, token string, now time.Time) error {
+	return s.updateUserAccessTokens(ctx, userID, func(accessTokens []*storepb.AccessTokensUserSetting_AccessToken) ([]*storepb.AccessTokensUserSetting_AccessToken, bool) {
+		changed := false
+		for _, t := range accessTokens {
+			if token == t.AccessToken && IsAccessTokenLastUsedStale(t, now) {
+				t.LastUsedTime = timestamppb.New(now)
+				changed = true
+			}
+		}
+		return accessTokens, changed
+	})
+}
+
+// IsAccessTokenLastUsedStale reports whether the last used time of the access token
+// should be updated when the access token is used at now.
+func IsAccessTokenLastUsedStale(accessToken *storepb.AccessTokensUserSetting_AccessToken, now time.Time) bool {
+	return accessToken.LastUsedTime == nil || now.Sub(accessToken.LastUsedTime.AsTime()) >= AccessTokenLastUsedInterval
+}
+
+// updateUserAccessTokens replaces the access tokens of the user with the result of update,
+// which is given a copy of the current access tokens and reports whether it changed them.
+// The read and the write are done in a transaction which locks the setting, so that concurrent
+// updates, also of other servers sharing the database, do not overwrite each other.
+func (s *Store) updateUserAccessTokens(ctx context.Context, userID int32, update func([]*storepb.AccessTokensUserSetting_AccessToken) ([]*storepb.AccessTokensUserSetting_AccessToken, bool)) error {
+	// The lock keeps the cached setting in the order of the writes.
+	s.accessTokensMutex.Lock()
+	defer s.accessTokensMutex.Unlock()
+
+	userSettingRaw, err := s.driver.UpdateUserSetting(ctx, userID, storepb.UserSettingKey_ACCESS_TOKENS, func(raw *UserSetting) (*UserSetting, error) {
+		accessTokens := []*storepb.AccessTokensUserSetting_AccessToken{}
+		if raw != nil {
+			userSetting, err := convertUserSettingFromRaw(raw)
+			if err != nil {
+				return nil, err
+			}
+			accessTokens = userSetting.GetAccessTokens().GetAccessTokens()
+		}
+		newAccessTokens, changed := update(accessTokens)
+		if !changed {
+			return nil, nil
+		}
+		return convertUserSettingToRaw(&storepb.UserSetting{
+			UserId: userID,
+			Key:    storepb.UserSettingKey_ACCESS_TOKENS,
+			Value: &storepb.UserSetting_AccessTokens{
+				AccessTokens: &storepb.AccessTokensUserSetting{
+					AccessTokens: newAccessTokens,
+				},
 			},
-		},
+		})
 	})
+	if err != nil || userSettingRaw == nil {
+		return err
+	}
 
-	return err
+	userSetting, err := convertUserSettingFromRaw(userSettingRaw)
+	if err != nil {
+		return err
+	}
+	s.userSettingCache.Store(getUserSettingCacheKey(userSetting.UserId, userSetting.Key.String()), userSetting)
+	return nil
 }
 
 func convertUserSettingFromRaw(raw *UserSetting) (*storepb.UserSetting, error) {
@@ -157,6 +356,16 @@ func convertUserSettingFromRaw(raw *UserSetting) (*storepb.UserSetting, error) {
 		userSetting.Value = &storepb.UserSetting_Appearance{Appearance: raw.Value}
 	case storepb.UserSettingKey_MEMO_VISIBILITY:
 		userSetting.Value = &storepb.UserSetting_MemoVisibility{MemoVisibility: raw.Value}
+	case storepb.UserSettingKey_PREFERENCES:
+		userSetting.Value = &storepb.UserSetting_Preferences{Preferences: raw.Value}
+	case storepb.UserSettingKey_TIMEZONE:
+		userSetting.Value = &storepb.UserSetting_Timezone{Timezone: raw.Value}
+	case storepb.UserSettingKey_TEMPLATES:
+		templatesUserSetting := &storepb.TemplatesUserSetting{}
+		if err := protojsonUnmarshaler.Unmarshal([]byte(raw.Value), templatesUserSetting); err != nil {
+			return nil, err
+		}
+		userSetting.Value = &storepb.UserSetting_Templates{Templates: templatesUserSetting}
 	default:
 		return nil, nil
 	}
@@ -190,6 +399,23 @@ func convertUserSettingToRaw(userSetting *storepb.UserSetting) (*UserSetting, er
 		raw.Value = userSetting.GetAppearance()
 	case storepb.UserSettingKey_MEMO_VISIBILITY:
 		raw.Value = userSetting.GetMemoVisibility()
+	case storepb.UserSettingKey_PREFERENCES:
+		if !json.Valid([]byte(userSetting.GetPreferences())) {
+			return nil, errors.New("invalid preferences: not a valid JSON")
+		}
+		raw.Value = userSetting.GetPreferences()
+	case storepb.UserSettingKey_TIMEZONE:
+		// "Local" depends on the server, so it is not a timezone of a user.
+		if _, err := time.LoadLocation(userSetting.GetTimezone()); err != nil || userSetting.GetTimezone() == "Local" {
+			return nil, errors.Errorf("invalid timezone: %s", userSetting.GetTimezone())
+		}
+		raw.Value = userSetting.GetTimezone()
+	case storepb.UserSettingKey_TEMPLATES:
+		value, err := protojson.Marshal(userSetting.GetTemplates())
+		if err != nil {
+			return nil, err
+		}
+		raw.Value = string(value)
 	default:
 		return nil, errors.Errorf("unsupported user setting key: %v", userSetting.Key)
 	}
@@ -11,6 +11,8 @@ type Webhook struct {
 	CreatorID int32
 	Name      string
 	URL       string
+	// Secret signs the webhook requests. Requests are not signed when it is empty.
+	Secret string
 }
 
 type FindWebhook struct {
@@ -19,9 +21,10 @@ type FindWebhook struct {
 }
 
 type UpdateWebhook struct {
-	ID   int32
-	Name *string
-	URL  *string
+	ID     int32
+	Name   *string
+	URL    *string
+	Secret *string
 }
 
 type DeleteWebhook struct {
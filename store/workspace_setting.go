@@ -2,6 +2,7 @@ package store
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/pkg/errors"
 	"google.golang.org/protobuf/encoding/protojson"
@@ -146,6 +147,31 @@ var DefaultReactions = []string{"👍", "👎", "❤️", "🎉", "😄", "😕"
 // DefaultNsfwTags is the default tags that mark content as NSFW for blurring.
 var DefaultNsfwTags = []string{"nsfw"}
 
+// DefaultLinkMetadataCacheTTL is the default duration in seconds that link metadata is cached. 1 hour.
+const DefaultLinkMetadataCacheTTL = 60 * 60
+
+// DefaultLinkMetadataFetchTimeout is the default timeout in seconds of fetching link metadata.
+const DefaultLinkMetadataFetchTimeout = 5
+
+// DefaultLinkMetadataRateLimit is the default number of link metadata requests a user may send per minute.
+const DefaultLinkMetadataRateLimit = 60
+
+// DefaultLinkMetadataRateLimitBurst is the default number of link metadata requests a user may send at once.
+const DefaultLinkMetadataRateLimitBurst = 20
+
+// DefaultMemoRevisionLimit is the default number of revisions of the content kept per memo.
+const DefaultMemoRevisionLimit = 50
+
+// getDefaultLinkMetadataUserAgent returns the User-Agent of link metadata fetches, which
+// identifies memos and its version so that sites can tell where the requests come from.
+func (s *Store) getDefaultLinkMetadataUserAgent() string {
+	version := "unknown"
+	if s.Profile != nil && s.Profile.Version != "" {
+		version = s.Profile.Version
+	}
+	return fmt.Sprintf("memos/%s (+https://www.usememos.com)", version)
+}
+
 func (s *Store) GetWorkspaceMemoRelatedSetting(ctx context.Context) (*storepb.WorkspaceMemoRelatedSetting, error) {
 	workspaceSetting, err := s.GetWorkspaceSetting(ctx, &FindWorkspaceSetting{
 		Name: storepb.WorkspaceSettingKey_MEMO_RELATED.String(),
@@ -167,6 +193,24 @@ func (s *Store) GetWorkspaceMemoRelatedSetting(ctx context.Context) (*storepb.Wo
 	if len(workspaceMemoRelatedSetting.NsfwTags) == 0 {
 		workspaceMemoRelatedSetting.NsfwTags = append(workspaceMemoRelatedSetting.NsfwTags, DefaultNsfwTags...)
 	}
+	if workspaceMemoRelatedSetting.LinkMetadataCacheTtl <= 0 {
+		workspaceMemoRelatedSetting.LinkMetadataCacheTtl = DefaultLinkMetadataCacheTTL
+	}
+	if workspaceMemoRelatedSetting.LinkMetadataFetchTimeout <= 0 {
+		workspaceMemoRelatedSetting.LinkMetadataFetchTimeout = DefaultLinkMetadataFetchTimeout
+	}
+	if workspaceMemoRelatedSetting.LinkMetadataUserAgent == "" {
+		workspaceMemoRelatedSetting.LinkMetadataUserAgent = s.getDefaultLinkMetadataUserAgent()
+	}
+	if workspaceMemoRelatedSetting.LinkMetadataRateLimit <= 0 {
+		workspaceMemoRelatedSetting.LinkMetadataRateLimit = DefaultLinkMetadataRateLimit
+	}
+	if workspaceMemoRelatedSetting.LinkMetadataRateLimitBurst <= 0 {
+		workspaceMemoRelatedSetting.LinkMetadataRateLimitBurst = DefaultLinkMetadataRateLimitBurst
+	}
+	if workspaceMemoRelatedSetting.MemoRevisionLimit <= 0 {
+		workspaceMemoRelatedSetting.MemoRevisionLimit = DefaultMemoRevisionLimit
+	}
 	s.workspaceSettingCache.Store(storepb.WorkspaceSettingKey_MEMO_RELATED.String(), &storepb.WorkspaceSetting{
 		Key:   storepb.WorkspaceSettingKey_MEMO_RELATED,
 		Value: &storepb.WorkspaceSetting_MemoRelatedSetting{MemoRelatedSetting: workspaceMemoRelatedSetting},
@@ -19,7 +19,11 @@ const getFaviconWithGoogleS2 = (url: string) => {
   }
 };
 
+// Bare www.-prefixed auto links have no scheme.
+const getHref = (url: string) => (/^www\./i.test(url) ? `https://${url}` : url);
+
 const Link: React.FC<Props> = ({ content, url }: Props) => {
+  const href = getHref(url);
   const workspaceMemoRelatedSetting = workspaceStore.state.memoRelatedSetting;
   const [initialized, setInitialized] = useState<boolean>(false);
   const [showTooltip, setShowTooltip] = useState<boolean>(false);
@@ -33,7 +37,7 @@ const Link: React.FC<Props> = ({ content, url }: Props) => {
     setShowTooltip(true);
     if (!initialized) {
       try {
-        const linkMetadata = await markdownServiceClient.getLinkMetadata({ link: url });
+        const linkMetadata = await markdownServiceClient.getLinkMetadata({ link: href });
         setLinkMetadata(linkMetadata);
       } catch (error) {
         console.error("Error fetching URL metadata:", error);
@@ -49,7 +53,7 @@ const Link: React.FC<Props> = ({ content, url }: Props) => {
         linkMetadata && (
           <div className="w-full max-w-64 sm:max-w-96 p-1 flex flex-col">
             <div className="w-full flex flex-row justify-start items-center gap-1">
-              <img className="w-5 h-5 rounded" src={getFaviconWithGoogleS2(url)} alt={linkMetadata?.title} />
+              <img className="w-5 h-5 rounded" src={getFaviconWithGoogleS2(href)} alt={linkMetadata?.title} />
               <h3 className="text-base truncate dark:opacity-90">{linkMetadata?.title}</h3>
             </div>
             {linkMetadata.description && (
@@ -64,7 +68,7 @@ const Link: React.FC<Props> = ({ content, url }: Props) => {
       open={showTooltip}
       arrow
     >
-      <MLink underline="always" target="_blank" href={url} rel="noopener noreferrer">
+      <MLink underline="always" target="_blank" href={href} rel="noopener noreferrer">
         <span onMouseEnter={handleMouseEnter} onMouseLeave={() => setShowTooltip(false)}>
           {content ? content.map((child, index) => <Renderer key={`${child.type}-${index}`} index={String(index)} node={child} />) : url}
         </span>
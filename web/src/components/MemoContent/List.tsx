@@ -22,6 +22,7 @@ const List: React.FC<Props> = ({ kind, indent, children }: Props) => {
       case ListNode_Kind.UNORDERED:
         return "ul";
       case ListNode_Kind.DESCRIPTION:
+      case ListNode_Kind.TASK:
         return "dl";
       default:
         return "div";
@@ -123,6 +123,31 @@ const MemoRelatedSettings = () => {
           onBlur={(event) => updatePartialSetting({ contentLengthLimit: Number(event.target.value) })}
         />
       </div>
+      <div className="w-full flex flex-row justify-between items-center">
+        <span>{t("setting.memo-related-settings.link-metadata-cache-ttl")}</span>
+        <Input
+          className="w-24"
+          type="number"
+          defaultValue={memoRelatedSetting.linkMetadataCacheTtl}
+          onBlur={(event) => updatePartialSetting({ linkMetadataCacheTtl: Number(event.target.value) })}
+        />
+      </div>
+      <div className="w-full flex flex-row justify-between items-center">
+        <span>{t("setting.memo-related-settings.link-metadata-fetch-timeout")}</span>
+        <Input
+          className="w-24"
+          type="number"
+          defaultValue={memoRelatedSetting.linkMetadataFetchTimeout}
+          onBlur={(event) => updatePartialSetting({ linkMetadataFetchTimeout: Number(event.target.value) })}
+        />
+      </div>
+      <div className="w-full flex flex-row justify-between items-center">
+        <span>{t("setting.memo-related-settings.link-metadata-allow-internal-ips")}</span>
+        <Switch
+          checked={memoRelatedSetting.linkMetadataAllowInternalIps}
+          onChange={(event) => updatePartialSetting({ linkMetadataAllowInternalIps: event.target.checked })}
+        />
+      </div>
       <div className="w-full">
         <span className="truncate">{t("setting.memo-related-settings.reactions")}</span>
         <div className="mt-2 w-full flex flex-row flex-wrap gap-1">